@@ -3,44 +3,348 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
 	"github.com/spf13/cobra"
 )
 
+// checkStatus is the outcome of a single system check
+type checkStatus int
+
+const (
+	checkPass checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+// checkResult describes the outcome of a system check
+type checkResult struct {
+	Name    string
+	Status  checkStatus
+	Message string
+}
+
+// builtinApps are INSTALLED_APPS entries provided by the framework itself
+var builtinApps = map[string]bool{
+	"gojango.contrib.admin": true,
+}
+
+// placeholderSecretPattern matches SECRET_KEY values that were never changed
+var placeholderSecretPattern = regexp.MustCompile(`(?i)(your-secret|change-?me|secret-key-here|insecure|example|^secret$|^dev$|^test$)`)
+
 func newCheckCmd() *cobra.Command {
+	var deploy bool
+	var settingsFile string
+
 	cmd := &cobra.Command{
 		Use:   "check",
 		Short: "Check for common issues",
-		Long:  "Perform system checks for common Gojango setup issues.",
+		Long: `Perform Django-style system checks on the current Gojango project.
+
+Checks that the settings file loads, INSTALLED_APPS reference existing
+packages, the migrations directory is well-formed and the database
+configuration produces a valid DSN. Use --deploy to also check for
+settings that are unsafe in production.`,
+		SilenceUsage:  true,
+		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			fmt.Println("🔍 Performing system checks...")
-			
-			// Check if we're in a Gojango project
-			if _, err := os.Stat("go.mod"); err == nil {
-				fmt.Println("❌ You appear to be in a Go project directory")
-				fmt.Println("💡 Use 'go run manage.go <command>' for project-specific commands")
-				fmt.Println("💡 Use 'gojango new <project>' to create a new project")
-				return nil
+
+			results := runSystemChecks(".", settingsFile, deploy)
+
+			failures, warnings := 0, 0
+			for _, result := range results {
+				switch result.Status {
+				case checkPass:
+					fmt.Printf("✅ %s: %s\n", result.Name, result.Message)
+				case checkWarn:
+					warnings++
+					fmt.Printf("⚠️  %s: %s\n", result.Name, result.Message)
+				case checkFail:
+					failures++
+					fmt.Printf("❌ %s: %s\n", result.Name, result.Message)
+				}
 			}
-			
-			// Check Go installation
-			if err := checkGoInstallation(); err != nil {
-				fmt.Printf("❌ Go installation issue: %v\n", err)
-				return nil
+
+			if failures > 0 {
+				return fmt.Errorf("system check identified %d issue(s) (%d warning(s))", failures, warnings)
 			}
-			fmt.Println("✅ Go installation looks good")
-			
-			fmt.Println("✅ System checks passed")
-			fmt.Println("💡 Run 'gojango new <project-name>' to create a new Gojango project")
-			
+
+			fmt.Printf("✅ System check identified no issues (%d warning(s))\n", warnings)
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&deploy, "deploy", false, "Check deployment settings (DEBUG, SECRET_KEY)")
+	cmd.Flags().StringVar(&settingsFile, "settings", filepath.Join("config", "settings.star"), "Settings file to check")
+
 	return cmd
 }
 
-func checkGoInstallation() error {
-	// Basic check - if we got here, Go is working
+// runSystemChecks runs all checks against the project in dir
+func runSystemChecks(dir, settingsFile string, deploy bool) []checkResult {
+	var results []checkResult
+
+	settingsPath := settingsFile
+	if !filepath.IsAbs(settingsPath) {
+		settingsPath = filepath.Join(dir, settingsFile)
+	}
+
+	settings := gojango.NewSettingsStack()
+	if err := settings.LoadFile(settingsPath); err != nil {
+		results = append(results, checkResult{"settings", checkFail, fmt.Sprintf("failed to load %s: %v", settingsFile, err)})
+		// Remaining checks depend on settings, except migrations
+		return append(results, checkMigrations(filepath.Join(dir, "migrations")))
+	}
+	settings.LoadEnv()
+	results = append(results, checkResult{"settings", checkPass, fmt.Sprintf("%s loaded", settingsFile)})
+
+	results = append(results, checkInstalledApps(dir, settings)...)
+	results = append(results, checkMigrations(filepath.Join(dir, "migrations")))
+	results = append(results, checkDatabaseConfig(settings))
+
+	if deploy {
+		results = append(results, checkDeploySettings(settings)...)
+	}
+
+	return results
+}
+
+// checkInstalledApps verifies that each INSTALLED_APPS entry resolves to a package
+func checkInstalledApps(dir string, settings gojango.Settings) []checkResult {
+	apps, ok := settings.Get("INSTALLED_APPS").([]interface{})
+	if !ok || len(apps) == 0 {
+		return []checkResult{{"installed_apps", checkWarn, "INSTALLED_APPS is empty or not a list"}}
+	}
+
+	modulePath := readModulePath(filepath.Join(dir, "go.mod"))
+
+	var results []checkResult
+	for _, entry := range apps {
+		app, ok := entry.(string)
+		if !ok {
+			results = append(results, checkResult{"installed_apps", checkFail, fmt.Sprintf("entry %v is not a string", entry)})
+			continue
+		}
+
+		if err := resolveInstalledApp(dir, modulePath, app); err != nil {
+			results = append(results, checkResult{"installed_apps", checkFail, fmt.Sprintf("%s: %v", app, err)})
+			continue
+		}
+		results = append(results, checkResult{"installed_apps", checkPass, fmt.Sprintf("%s found", app)})
+	}
+
+	return results
+}
+
+// resolveInstalledApp checks that an app entry can be imported. Entries may be
+// dotted project paths ("apps.blog") or Go import paths.
+func resolveInstalledApp(dir, modulePath, app string) error {
+	if builtinApps[app] {
+		return nil
+	}
+
+	if !strings.Contains(app, "/") {
+		// Dotted path relative to the project root
+		appDir := filepath.Join(dir, filepath.FromSlash(strings.ReplaceAll(app, ".", "/")))
+		if info, err := os.Stat(appDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("package directory %s not found", appDir)
+		}
+		return nil
+	}
+
+	if modulePath != "" && strings.HasPrefix(app, modulePath+"/") {
+		appDir := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(app, modulePath+"/")))
+		if info, err := os.Stat(appDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("package directory %s not found", appDir)
+		}
+		return nil
+	}
+
+	// External package - ask the Go toolchain
+	goCmd := exec.Command("go", "list", "-find", app)
+	goCmd.Dir = dir
+	if output, err := goCmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cannot import package: %s", strings.TrimSpace(string(output)))
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// readModulePath returns the module path declared in go.mod, if any
+func readModulePath(goModPath string) string {
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module "))
+		}
+	}
+	return ""
+}
+
+// migrationFilePattern matches NNNN_name.sql, NNNN_name_up.sql and NNNN_name_down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+?)(_up|_down)?\.sql$`)
+
+// checkMigrations verifies migration IDs are sequential and up/down files are paired
+func checkMigrations(migrationsDir string) checkResult {
+	entries, err := os.ReadDir(migrationsDir)
+	if os.IsNotExist(err) {
+		return checkResult{"migrations", checkPass, "no migrations directory"}
+	}
+	if err != nil {
+		return checkResult{"migrations", checkFail, fmt.Sprintf("cannot read %s: %v", migrationsDir, err)}
+	}
+
+	type migrationFiles struct {
+		name       string
+		up, down   bool
+		standalone bool
+	}
+	migrations := make(map[int]*migrationFiles)
+
+	var problems []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			problems = append(problems, fmt.Sprintf("%s does not follow the NNNN_name.sql naming scheme", entry.Name()))
+			continue
+		}
+
+		// Squashed migrations share the ID of the last migration they replace
+		if strings.HasPrefix(match[2], db.SquashedMigrationPrefix) {
+			continue
+		}
+
+		id, _ := strconv.Atoi(match[1])
+		files, exists := migrations[id]
+		if !exists {
+			files = &migrationFiles{name: match[2]}
+			migrations[id] = files
+		} else if files.name != match[2] {
+			problems = append(problems, fmt.Sprintf("duplicate migration ID %04d (%s and %s)", id, files.name, match[2]))
+			continue
+		}
+
+		switch match[3] {
+		case "_up":
+			files.up = true
+		case "_down":
+			files.down = true
+		default:
+			files.standalone = true
+		}
+	}
+
+	ids := make([]int, 0, len(migrations))
+	for id := range migrations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for i, id := range ids {
+		if id != i+1 {
+			problems = append(problems, fmt.Sprintf("migration IDs are not sequential: expected %04d, found %04d", i+1, id))
+			break
+		}
+	}
+
+	for _, id := range ids {
+		files := migrations[id]
+		if files.up && !files.down {
+			problems = append(problems, fmt.Sprintf("%04d_%s has no matching _down.sql", id, files.name))
+		}
+		if files.down && !files.up && !files.standalone {
+			problems = append(problems, fmt.Sprintf("%04d_%s has a _down.sql but no up migration", id, files.name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return checkResult{"migrations", checkFail, strings.Join(problems, "; ")}
+	}
+	return checkResult{"migrations", checkPass, fmt.Sprintf("%d migrations well-formed", len(ids))}
+}
+
+// checkDatabaseConfig verifies the database settings produce a valid DSN
+func checkDatabaseConfig(settings *gojango.SettingsStack) checkResult {
+	config, err := databaseConfigFromSettings(settings)
+	if err != nil {
+		return checkResult{"database", checkFail, err.Error()}
+	}
+
+	dsn, err := config.BuildDSN()
+	if err != nil {
+		return checkResult{"database", checkFail, fmt.Sprintf("invalid database configuration: %v", err)}
+	}
+	if dsn == "" {
+		return checkResult{"database", checkFail, "database configuration produced an empty DSN"}
+	}
+
+	return checkResult{"database", checkPass, fmt.Sprintf("%s configuration is valid", config.Driver)}
+}
+
+// databaseConfigFromSettings builds a db.Config from DATABASES["default"],
+// as the application does, DATABASE_URL or the DATABASE_DRIVER/DATABASE_NAME
+// settings
+func databaseConfigFromSettings(settings *gojango.SettingsStack) (*db.Config, error) {
+	if settings.Has("DATABASES") {
+		return settings.GetDatabaseConfig("default")
+	}
+
+	if rawURL := settings.GetString("DATABASE_URL"); rawURL != "" {
+		return db.ParseURL(rawURL)
+	}
+
+	config := db.DefaultConfig()
+	if driver := settings.GetString("DATABASE_DRIVER"); driver != "" {
+		config.Driver = normalizeDriver(driver)
+	}
+	config.Database = settings.GetString("DATABASE_NAME", config.Database)
+	return config, nil
+}
+
+// normalizeDriver maps common engine names onto db drivers, keeping unknown
+// names so the database check can report them
+func normalizeDriver(engine string) db.Driver {
+	if driver, err := db.ParseDriver(engine); err == nil {
+		return driver
+	}
+	return db.Driver(engine)
+}
+
+// checkDeploySettings warns about settings that are unsafe in production
+func checkDeploySettings(settings gojango.Settings) []checkResult {
+	var results []checkResult
+
+	if settings.GetBool("DEBUG") {
+		results = append(results, checkResult{"deploy", checkWarn, "DEBUG is enabled; set DEBUG=false in production"})
+	} else {
+		results = append(results, checkResult{"deploy", checkPass, "DEBUG is disabled"})
+	}
+
+	secret := settings.GetString("SECRET_KEY")
+	switch {
+	case secret == "":
+		results = append(results, checkResult{"deploy", checkWarn, "SECRET_KEY is not set"})
+	case len(secret) < 32 || placeholderSecretPattern.MatchString(secret):
+		results = append(results, checkResult{"deploy", checkWarn, "SECRET_KEY looks like a placeholder; use a long random value"})
+	default:
+		results = append(results, checkResult{"deploy", checkPass, "SECRET_KEY looks strong"})
+	}
+
+	return results
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+func TestCheckDatabaseConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		status   checkStatus
+		message  string
+	}{
+		{
+			name:     "default sqlite",
+			settings: map[string]interface{}{},
+			status:   checkPass,
+			message:  "sqlite",
+		},
+		{
+			name:     "driver alias",
+			settings: map[string]interface{}{"DATABASE_DRIVER": "postgresql", "DATABASE_NAME": "blog"},
+			status:   checkPass,
+			message:  "postgres",
+		},
+		{
+			name:     "unknown driver",
+			settings: map[string]interface{}{"DATABASE_DRIVER": "oracle"},
+			status:   checkFail,
+			message:  "oracle",
+		},
+		{
+			name: "DATABASES postgres",
+			settings: map[string]interface{}{"DATABASES": map[string]interface{}{
+				"default": map[string]interface{}{"engine": "postgres", "host": "db", "port": 5433, "name": "blog", "user": "blog"},
+			}},
+			status:  checkPass,
+			message: "postgres",
+		},
+		{
+			name: "DATABASES sqlite",
+			settings: map[string]interface{}{"DATABASES": map[string]interface{}{
+				"default": map[string]interface{}{"engine": "sqlite3", "name": "blog.db"},
+			}},
+			status:  checkPass,
+			message: "sqlite",
+		},
+		{
+			name: "DATABASES unknown engine",
+			settings: map[string]interface{}{"DATABASES": map[string]interface{}{
+				"default": map[string]interface{}{"engine": "oracle", "name": "blog"},
+			}},
+			status:  checkFail,
+			message: "oracle",
+		},
+		{
+			name: "DATABASES without default",
+			settings: map[string]interface{}{"DATABASES": map[string]interface{}{
+				"replica": map[string]interface{}{"engine": "sqlite", "name": "blog.db"},
+			}},
+			status:  checkFail,
+			message: "default",
+		},
+		{
+			name:     "DATABASE_URL postgres",
+			settings: map[string]interface{}{"DATABASE_URL": "postgres://blog:secret@db:5432/blog?sslmode=disable"},
+			status:   checkPass,
+			message:  "postgres",
+		},
+		{
+			name:     "DATABASE_URL sqlite",
+			settings: map[string]interface{}{"DATABASE_URL": "sqlite:///data/blog.db"},
+			status:   checkPass,
+			message:  "sqlite",
+		},
+		{
+			name:     "DATABASE_URL unknown scheme",
+			settings: map[string]interface{}{"DATABASE_URL": "oracle://db/blog"},
+			status:   checkFail,
+			message:  "oracle",
+		},
+		{
+			name:     "DATABASE_URL not a URL",
+			settings: map[string]interface{}{"DATABASE_URL": "blog.db"},
+			status:   checkFail,
+			message:  "DATABASE_URL",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := gojango.NewSettingsStack()
+			for key, value := range tc.settings {
+				settings.Set(key, value)
+			}
+
+			result := checkDatabaseConfig(settings)
+			if result.Status != tc.status {
+				t.Errorf("Expected status %d, got %d: %s", tc.status, result.Status, result.Message)
+			}
+			if !strings.Contains(result.Message, tc.message) {
+				t.Errorf("Expected message to mention %q, got: %s", tc.message, result.Message)
+			}
+		})
+	}
+}
+
+func TestDatabaseConfigFromSettingsMatchesApplication(t *testing.T) {
+	settings := gojango.NewSettingsStack()
+	settings.Set("DATABASES", map[string]interface{}{
+		"default": map[string]interface{}{
+			"ENGINE":   "postgresql",
+			"HOST":     "db",
+			"PORT":     int64(5433),
+			"NAME":     "blog",
+			"USER":     "blog",
+			"sslmode":  "require",
+			"password": "secret",
+		},
+	})
+
+	config, err := databaseConfigFromSettings(settings)
+	if err != nil {
+		t.Fatalf("Failed to build the database config: %v", err)
+	}
+	if config.Driver != db.DriverPostgres || config.Host != "db" || config.Port != 5433 || config.Database != "blog" {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+	if config.SSLMode != "require" {
+		t.Errorf("Expected sslmode require, got %q", config.SSLMode)
+	}
+}
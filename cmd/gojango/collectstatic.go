@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/staticfiles"
+	"github.com/spf13/cobra"
+)
+
+func newCollectStaticCmd() *cobra.Command {
+	var root string
+	var settingsFile string
+
+	cmd := &cobra.Command{
+		Use:   "collectstatic",
+		Short: "Collect static files with fingerprinted names",
+		Long: `Copy the project's static files and each app's static files into
+STATIC_ROOT, next to copies named after a hash of their content, and write
+the staticfiles.json manifest. Outside debug mode the {{ static }} template
+function then returns fingerprinted URLs, which are served with long-lived
+cache headers.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if root == "" {
+				root = "./staticfiles"
+				if _, err := os.Stat(settingsFile); err == nil {
+					settings := gojango.NewSettingsStack()
+					if err := settings.LoadFile(settingsFile); err != nil {
+						return fmt.Errorf("failed to load settings: %w", err)
+					}
+					settings.LoadEnv()
+					root = settings.GetString("STATIC_ROOT", root)
+				}
+			}
+
+			sources, err := staticfiles.ProjectSources(".")
+			if err != nil {
+				return err
+			}
+			if len(sources) == 0 {
+				fmt.Println("No static directories found")
+				return nil
+			}
+
+			manifest, err := staticfiles.Collect(root, sources...)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%d static files collected into %s\n", len(manifest.Paths), root)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&root, "root", "", "Directory to collect into (default STATIC_ROOT or ./staticfiles)")
+	cmd.Flags().StringVar(&settingsFile, "settings", filepath.Join("config", "settings.star"), "Settings file to read STATIC_ROOT from")
+
+	return cmd
+}
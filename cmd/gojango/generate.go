@@ -2,175 +2,98 @@ package main
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"path/filepath"
 
+	"github.com/epuerta9/gojango/internal/project"
 	"github.com/epuerta9/gojango/pkg/gojango/codegen"
 	"github.com/spf13/cobra"
 )
 
 func newGenerateCmd() *cobra.Command {
+	var entPackage string
+
 	cmd := &cobra.Command{
 		Use:   "generate [type]",
 		Short: "Generate code from schemas",
-		Long: `Generate code from Ent schemas and other definitions.
+		Long: `Generate code from the Ent schemas of your apps (apps/*/schema).
 
 Available generators:
-  ent     - Generate Ent ORM code
-  proto   - Generate protobuf files from schemas
-  openapi - Generate OpenAPI spec from schemas
-  all     - Generate all code`,
-		Args: cobra.ExactArgs(1),
+  ent     - Generate each app's Ent package from its schema
+  proto   - Generate protobuf definitions, then clients with buf if buf.gen.yaml exists
+  openapi - Generate openapi.yaml
+  postman - Generate postman_collection.json
+  ts      - Generate TypeScript interfaces in web/src/types/models.ts
+  admin   - Generate apps/<app>/admin.go registrations from app schemas
+  all     - Run ent, proto and openapi in order`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: project.EnterRoot,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			genType := args[0]
+			generator := codegen.NewProjectGenerator(".")
 
 			switch genType {
 			case "ent":
-				return generateEnt()
+				return generator.Ent()
 			case "proto":
-				return generateProto()
+				return generator.Proto()
 			case "openapi":
-				return generateOpenAPI()
+				return generator.OpenAPI()
+			case "postman":
+				return generator.Postman()
+			case "ts":
+				return generator.TypeScript()
+			case "admin":
+				return generateAdmin(entPackage)
 			case "all":
-				if err := generateEnt(); err != nil {
-					return err
-				}
-				if err := generateProto(); err != nil {
-					return err
-				}
-				return generateOpenAPI()
+				return generator.All()
 			default:
 				return fmt.Errorf("unknown generation type: %s", genType)
 			}
 		},
 	}
 
+	cmd.Flags().StringVar(&entPackage, "ent-package", "", "Import path of the generated Ent package (default: <module>/apps/<app>/ent)")
+
 	return cmd
 }
 
-func generateEnt() error {
-	fmt.Println("🔧 Generating Ent code...")
-	
-	// Find schema directories
-	schemaDirs := []string{"schema", "internal/ent/schema"}
-	var schemaDir string
-	
-	for _, dir := range schemaDirs {
-		if _, err := os.Stat(dir); err == nil {
-			schemaDir = dir
-			break
-		}
-	}
-	
-	if schemaDir == "" {
-		return fmt.Errorf("no schema directory found (tried: %v)", schemaDirs)
-	}
+func generateAdmin(entPackage string) error {
+	fmt.Println("🔧 Generating admin registrations...")
 
-	entCmd := exec.Command("go", "run", "-mod=mod", "entgo.io/ent/cmd/ent", "generate", "./"+schemaDir)
-	entCmd.Stdout = os.Stdout
-	entCmd.Stderr = os.Stderr
-	
-	if err := entCmd.Run(); err != nil {
-		return fmt.Errorf("failed to generate Ent code: %w", err)
+	schemaDirs, _ := filepath.Glob("apps/*/schema")
+	if len(schemaDirs) == 0 {
+		return fmt.Errorf("no app schema directories found (tried: apps/*/schema)")
 	}
 
-	fmt.Println("✅ Ent code generated")
-	return nil
-}
+	modulePath := readModulePath("go.mod")
+	generated := 0
 
-func generateProto() error {
-	fmt.Println("🔧 Generating protobuf files...")
-	
-	// Find schema directories
-	schemaDirs := []string{"schema", "apps/*/schema", "internal/ent/schema"}
-	var schemaDir string
-	
-	for _, pattern := range schemaDirs {
-		matches, _ := filepath.Glob(pattern)
-		for _, match := range matches {
-			if stat, err := os.Stat(match); err == nil && stat.IsDir() {
-				schemaDir = match
-				break
-			}
-		}
-		if schemaDir != "" {
-			break
+	for _, schemaDir := range schemaDirs {
+		app := codegen.AppScaffold{
+			Name:       filepath.Base(filepath.Dir(schemaDir)),
+			ModulePath: modulePath,
 		}
-	}
-	
-	if schemaDir == "" {
-		return fmt.Errorf("no schema directory found (tried: %v)", schemaDirs)
-	}
 
-	// Analyze schemas
-	analyzer := codegen.NewSchemaAnalyzer(schemaDir)
-	if err := analyzer.Analyze(); err != nil {
-		return fmt.Errorf("failed to analyze schemas: %w", err)
-	}
-
-	models := analyzer.GetModels()
-	if len(models) == 0 {
-		fmt.Println("⚠️  No models found in schema directory")
-		return nil
-	}
-
-	// Generate protobuf files
-	protoGenerator := codegen.NewProtoGenerator(analyzer)
-	outputDir := "internal/proto"
-	
-	if err := protoGenerator.Generate(outputDir); err != nil {
-		return fmt.Errorf("failed to generate protobuf files: %w", err)
-	}
-
-	fmt.Printf("✅ Generated protobuf files for %d models in %s\n", len(models), outputDir)
-	return nil
-}
-
-func generateOpenAPI() error {
-	fmt.Println("🔧 Generating OpenAPI specification...")
-	
-	// Find schema directories
-	schemaDirs := []string{"schema", "apps/*/schema", "internal/ent/schema"}
-	var schemaDir string
-	
-	for _, pattern := range schemaDirs {
-		matches, _ := filepath.Glob(pattern)
-		for _, match := range matches {
-			if stat, err := os.Stat(match); err == nil && stat.IsDir() {
-				schemaDir = match
-				break
-			}
+		analyzer := codegen.NewEntSchemaAnalyzer(schemaDir)
+		if err := analyzer.Analyze(); err != nil {
+			return fmt.Errorf("failed to analyze schemas for %s: %w", app.Name, err)
 		}
-		if schemaDir != "" {
-			break
+		if len(analyzer.GetModels()) == 0 {
+			continue
 		}
-	}
-	
-	if schemaDir == "" {
-		return fmt.Errorf("no schema directory found (tried: %v)", schemaDirs)
-	}
 
-	// Analyze schemas
-	analyzer := codegen.NewSchemaAnalyzer(schemaDir)
-	if err := analyzer.Analyze(); err != nil {
-		return fmt.Errorf("failed to analyze schemas: %w", err)
-	}
+		adminGenerator := codegen.NewAdminGenerator(analyzer)
+		if err := adminGenerator.Generate(codegen.DirTarget("."), app, entPackage); err != nil {
+			fmt.Printf("⚠️  Skipping %s: %v\n", app.Name, err)
+			continue
+		}
 
-	models := analyzer.GetModels()
-	if len(models) == 0 {
-		fmt.Println("⚠️  No models found in schema directory")
-		return nil
+		fmt.Printf("✅ Generated %s/admin.go for %d models\n", app.Dir(), len(analyzer.GetModels()))
+		generated++
 	}
 
-	// Generate OpenAPI specification
-	openAPIGenerator := codegen.NewOpenAPIGenerator(analyzer)
-	outputFile := "openapi.yaml"
-	
-	if err := openAPIGenerator.Generate(outputFile); err != nil {
-		return fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+	if generated == 0 {
+		fmt.Println("⚠️  No admin registrations generated")
 	}
-
-	fmt.Printf("✅ Generated OpenAPI specification for %d models in %s\n", len(models), outputFile)
 	return nil
-}
\ No newline at end of file
+}
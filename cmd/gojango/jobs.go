@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/epuerta9/gojango/internal/project"
+	"github.com/spf13/cobra"
+)
+
+func newJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Background job management",
+	}
+
+	cmd.AddCommand(newJobsWorkerCmd())
+	return cmd
+}
+
+func newJobsWorkerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "worker [flags]",
+		Short: "Run a background job worker for the current project",
+		Long: `Run a background job worker for the current project.
+
+Tasks are registered by the project's apps, so the worker runs through the
+project's manage.go ("go run manage.go jobs worker"). Flags such as
+--concurrency and --max-retries are passed through to it.`,
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, arg := range args {
+				if arg == "-h" || arg == "--help" {
+					return cmd.Help()
+				}
+			}
+
+			// Checked here rather than in PreRunE so --help works anywhere
+			if err := project.EnterRoot(cmd, args); err != nil {
+				return err
+			}
+
+			goArgs := append([]string{"run", "manage.go", "jobs", "worker"}, args...)
+			goCmd := exec.Command("go", goArgs...)
+			goCmd.Stdout = os.Stdout
+			goCmd.Stderr = os.Stderr
+			goCmd.Stdin = os.Stdin
+
+			return goCmd.Run()
+		},
+	}
+
+	return cmd
+}
@@ -30,6 +30,10 @@ Features:
 	rootCmd.AddCommand(newNewCmd())
 	rootCmd.AddCommand(newVersionCmd())
 	rootCmd.AddCommand(newCheckCmd())
+	rootCmd.AddCommand(newCollectStaticCmd())
+	rootCmd.AddCommand(newStartAppCmd())
+	rootCmd.AddCommand(newJobsCmd())
+	rootCmd.AddCommand(newShellCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -206,7 +206,7 @@ require (
     connectrpc.com/connect v1.18.1
 {{- end}}
 {{- if .HasAuth}}
-    github.com/golang-jwt/jwt/v5 v5.0.0
+    github.com/golang-jwt/jwt/v5 v5.2.2
 {{- end}}
 )
 
@@ -251,6 +251,9 @@ import (
 	"log"
 
 	"github.com/epuerta9/gojango/pkg/gojango"
+{{- if .HasJobs}}
+	"github.com/epuerta9/gojango/pkg/gojango/jobs"
+{{- end}}
 	
 	// Import apps to register them
 	_ "{{.ModulePath}}/apps/core"
@@ -272,9 +275,18 @@ func Run() error {
 	if err := app.SetupDatabase(); err != nil {
 		return err
 	}
+{{- if .HasJobs}}
+
+	// Enqueue background jobs on the queue configured in settings
+	if _, err := jobs.Configure("config/settings.star"); err != nil {
+		return err
+	}
+{{- end}}
 
 	// Setup admin interface
-	app.SetupAdmin()
+	if err := app.SetupAdmin(); err != nil {
+		return err
+	}
 
 	log.Println("Starting {{.Name}} server on http://localhost:8080")
 	log.Println("Admin interface: http://localhost:8080/admin")
@@ -283,7 +295,15 @@ func Run() error {
 }
 `
 	
-	return executeTemplate(tmpl, opts)
+	data := struct {
+		ProjectOptions
+		HasJobs bool
+	}{
+		opts,
+		contains(opts.Features, "jobs"),
+	}
+	
+	return executeTemplate(tmpl, data)
 }
 
 func generateSettings(opts ProjectOptions) string {
@@ -305,6 +325,8 @@ DATABASES = {
         "name": env.get("DB_NAME", "{{.Name}}"),
         "user": env.get("DB_USER", "{{.DatabaseUser}}"),
         "password": env.get("DB_PASSWORD", ""),
+        # Retry while the database starts up, e.g. under docker compose
+        "connect_retries": env.int("DB_CONNECT_RETRIES", 5),
     }
 }
 
@@ -322,6 +344,23 @@ INSTALLED_APPS = [
 GRPC_PORT = env.int("GRPC_PORT", 9000)
 {{- end}}
 
+# TLS (HTTPS with HTTP/2 is served when a certificate and key are set)
+TLS_CERT_FILE = env.get("TLS_CERT_FILE", "")
+TLS_KEY_FILE = env.get("TLS_KEY_FILE", "")
+TLS_MIN_VERSION = "1.2"
+
+# Server timeouts, as durations such as "30s" ("0s" disables a timeout)
+READ_TIMEOUT = "30s"
+READ_HEADER_TIMEOUT = "10s"
+WRITE_TIMEOUT = "0s"
+IDLE_TIMEOUT = "120s"
+{{- if .HasJobs}}
+
+# Background jobs ("memory" runs jobs only in the enqueuing process)
+JOBS_BACKEND = env.get("JOBS_BACKEND", "redis")
+REDIS_URL = env.get("REDIS_URL", "redis://localhost:6379/0")
+{{- end}}
+
 # Static files
 STATIC_URL = "/static/"
 STATIC_ROOT = "./staticfiles"
@@ -338,12 +377,14 @@ ADMIN_INDEX_TITLE = "{{.Name}} Admin"
 		ProjectOptions
 		HasAuth        bool
 		HasGRPC        bool
+		HasJobs        bool
 		DatabasePort   int
 		DatabaseUser   string
 	}{
 		opts,
 		contains(opts.Features, "auth"),
 		opts.API == "grpc" || opts.API == "all",
+		contains(opts.Features, "jobs"),
 		getDatabasePort(opts.Database),
 		getDatabaseUser(opts.Database),
 	}
@@ -409,6 +450,9 @@ services:
     environment:
       - DEBUG=true
       - DB_HOST=db
+{{- if .HasRedis}}
+      - REDIS_URL=redis://redis:6379/0
+{{- end}}
     depends_on:
       - db
 {{- if .HasRedis}}
@@ -633,7 +677,12 @@ import (
 
 	"github.com/epuerta9/gojango/pkg/gojango"
 	"github.com/epuerta9/gojango/pkg/gojango/codegen"
+	gojangodb "github.com/epuerta9/gojango/pkg/gojango/db"
+{{- if .HasJobs}}
+	"github.com/epuerta9/gojango/pkg/gojango/jobs"
+{{- end}}
 	"github.com/epuerta9/gojango/pkg/gojango/migrations"
+	"github.com/epuerta9/gojango/pkg/gojango/shell"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/spf13/cobra"
 	
@@ -660,10 +709,17 @@ This provides project-specific commands with full application context:
 	// Add project-specific commands (Django manage.py equivalent)
 	rootCmd.AddCommand(newRunServerCmd())
 	rootCmd.AddCommand(newMigrationCmd())
+	rootCmd.AddCommand(newSeedCmd())
 	rootCmd.AddCommand(newStartAppCmd())
 	rootCmd.AddCommand(newGenerateCmd())
-	rootCmd.AddCommand(newShellCmd())
+	rootCmd.AddCommand(shell.NewCommand())
 	rootCmd.AddCommand(newTestCmd())
+{{- if .HasJobs}}
+	rootCmd.AddCommand(jobs.NewCommand())
+{{- end}}
+
+	// Add the management commands of your apps, e.g. "manage.go blog:send_digest"
+	gojango.GetRegistry().AddCommands(rootCmd)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\\n", err)
@@ -742,15 +798,67 @@ func newMigrationCmd() *cobra.Command {
 	return cmd
 }
 
+func newSeedCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "seed [name...]",
+		Short: "Run registered database seeders",
+		Long: ` + "`" + `Run Go seeders registered with gojangodb.RegisterSeeder.
+
+Without arguments every registered seeder is run. Seeders that have
+already been applied are skipped, so seeding is safe to repeat.` + "`" + `,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := gojango.NewSettingsStack()
+			if err := settings.LoadFile("config/settings.star"); err != nil {
+				return fmt.Errorf("failed to load settings: %w", err)
+			}
+			settings.LoadEnv()
+
+			config, err := settings.GetDatabaseConfig("default")
+			if err != nil {
+				return err
+			}
+			conn, err := gojangodb.Open(config)
+			if err != nil {
+				return fmt.Errorf("failed to connect to database: %w", err)
+			}
+			defer conn.Close()
+
+			ran, err := gojangodb.RunSeeders(context.Background(), conn, args...)
+			if err != nil {
+				return err
+			}
+
+			if len(ran) == 0 {
+				fmt.Println("✅ No seeders to run")
+			} else {
+				fmt.Printf("✅ Ran %d seeders\n", len(ran))
+			}
+			return nil
+		},
+	}
+
+	return cmd
+}
+
 func newStartAppCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "startapp [app-name]",
 		Short: "Create a new Django-style app",
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			appName := args[0]
-			fmt.Printf("Creating app '%s'...\\n", appName)
-			fmt.Println("✅ App creation functionality will be implemented")
+			app := codegen.AppScaffold{
+				Name:       args[0],
+				ModulePath: "{{.ModulePath}}",
+			}
+
+			fmt.Printf("Creating app '%s'...\\n", app.Name)
+			if err := codegen.ScaffoldApp(codegen.DirTarget("."), app); err != nil {
+				return err
+			}
+
+			fmt.Printf("✅ Created app '%s' in %s\\n", app.Name, app.Dir())
+			fmt.Printf("Add this import to cmd/server/main.go:\\n\\n\\t%s\\n\\n", app.ImportLine())
+			fmt.Printf("Then add 'apps.%s' to INSTALLED_APPS in config/settings.star\\n", app.Name)
 			return nil
 		},
 	}
@@ -759,32 +867,73 @@ func newStartAppCmd() *cobra.Command {
 }
 
 func newGenerateCmd() *cobra.Command {
+	var entPackage string
+
 	cmd := &cobra.Command{
 		Use:   "generate [type]",
 		Short: "Generate code from schemas",
+		Long: ` + "`" + `Generate code from your app schemas (apps/*/schema).
+
+Available generators:
+  ent     - Generate each app's Ent package from its schema
+  proto   - Generate protobuf definitions, then clients with buf if buf.gen.yaml exists
+  openapi - Generate openapi.yaml
+  postman - Generate postman_collection.json
+  ts      - Generate TypeScript interfaces in web/src/types/models.ts
+  admin   - Generate apps/<app>/admin.go registrations for each app's models
+  all     - Run ent, proto and openapi in order` + "`" + `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Printf("Code generation for %s...\\n", args[0])
-			fmt.Println("✅ Code generation functionality will be implemented")
-			return nil
+			generator := codegen.NewProjectGenerator(".")
+			switch args[0] {
+			case "ent":
+				return generator.Ent()
+			case "proto":
+				return generator.Proto()
+			case "openapi":
+				return generator.OpenAPI()
+			case "postman":
+				return generator.Postman()
+			case "ts":
+				return generator.TypeScript()
+			case "all":
+				return generator.All()
+			case "admin":
+				return generateAdmin(entPackage)
+			default:
+				return fmt.Errorf("unknown generator: %s", args[0])
+			}
 		},
 	}
 
+	cmd.Flags().StringVar(&entPackage, "ent-package", "", "Import path of the generated Ent package (default: {{.ModulePath}}/apps/<app>/ent)")
+
 	return cmd
 }
 
-func newShellCmd() *cobra.Command {
-	cmd := &cobra.Command{
-		Use:   "shell",
-		Short: "Interactive project shell",
-		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Println("{{.Name}} Interactive Shell")
-			fmt.Println("🚧 Interactive shell coming soon!")
-			return nil
-		},
-	}
+func generateAdmin(entPackage string) error {
+	schemaDirs, _ := filepath.Glob("apps/*/schema")
+	for _, schemaDir := range schemaDirs {
+		app := codegen.AppScaffold{
+			Name:       filepath.Base(filepath.Dir(schemaDir)),
+			ModulePath: "{{.ModulePath}}",
+		}
 
-	return cmd
+		analyzer := codegen.NewEntSchemaAnalyzer(schemaDir)
+		if err := analyzer.Analyze(); err != nil {
+			return fmt.Errorf("failed to analyze schemas for %s: %w", app.Name, err)
+		}
+		if len(analyzer.GetModels()) == 0 {
+			continue
+		}
+
+		if err := codegen.NewAdminGenerator(analyzer).Generate(codegen.DirTarget("."), app, entPackage); err != nil {
+			fmt.Printf("⚠️  Skipping %s: %v\n", app.Name, err)
+			continue
+		}
+		fmt.Printf("✅ Generated %s/admin.go for %d models\n", app.Dir(), len(analyzer.GetModels()))
+	}
+	return nil
 }
 
 func newTestCmd() *cobra.Command {
@@ -809,9 +958,11 @@ func newTestCmd() *cobra.Command {
 	data := struct {
 		ProjectOptions
 		Version string
+		HasJobs bool
 	}{
 		opts,
 		"1.0.0",
+		contains(opts.Features, "jobs"),
 	}
 	
 	return executeTemplate(tmpl, data)
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/internal/project"
+	"github.com/spf13/cobra"
+)
+
+func TestProjectCommandsOutsideProject(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	commands := map[string]struct {
+		cmd  *cobra.Command
+		args []string
+	}{
+		"startapp":    {newStartAppCmd(), []string{"blog"}},
+		"generate":    {newGenerateCmd(), []string{"all"}},
+		"jobs worker": {newJobsCmd(), []string{"worker"}},
+	}
+	for name, tc := range commands {
+		tc.cmd.SetArgs(tc.args)
+		tc.cmd.SetOut(io.Discard)
+		tc.cmd.SetErr(io.Discard)
+
+		err := tc.cmd.Execute()
+		var notInProject *project.NotInProjectError
+		if !errors.As(err, &notInProject) {
+			t.Errorf("%s: expected NotInProjectError, got %v", name, err)
+		}
+	}
+}
+
+func TestStartAppFromProjectSubdirectory(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+	files := map[string]string{
+		"manage.go": "package main\n",
+		"go.mod":    "module example.com/mysite\n\ngo 1.21\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	subdir := filepath.Join(root, "config")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatalf("Failed to create subdir: %v", err)
+	}
+	t.Chdir(subdir)
+
+	cmd := newStartAppCmd()
+	cmd.SetArgs([]string{"blog"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("startapp failed inside a project: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "apps", "blog", "app.go")); err != nil {
+		t.Errorf("Expected the app to be created at the project root: %v", err)
+	}
+}
+
+func TestGenerateManageGoAddsAppCommands(t *testing.T) {
+	for _, features := range [][]string{nil, {"jobs"}} {
+		src := generateManageGo(ProjectOptions{Name: "mysite", ModulePath: "example.com/mysite", Features: features})
+		if _, err := parser.ParseFile(token.NewFileSet(), "manage.go", src, 0); err != nil {
+			t.Fatalf("Generated manage.go with features %v doesn't parse: %v", features, err)
+		}
+		if !strings.Contains(src, "gojango.GetRegistry().AddCommands(rootCmd)") {
+			t.Errorf("Expected manage.go with features %v to add the apps' commands", features)
+		}
+	}
+}
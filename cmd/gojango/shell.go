@@ -1,67 +1,44 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
-	"strings"
+	"os/exec"
 
 	"github.com/spf13/cobra"
 )
 
 func newShellCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "shell",
+		Use:   "shell [flags]",
 		Short: "Interactive Django-style shell",
 		Long: `Start an interactive shell with access to your application context.
 
-This provides a REPL environment where you can interact with your models,
-test queries, and debug your application.`,
+The shell needs the project's registered apps, so it runs through the
+project's manage.go ("go run manage.go shell"). It is a Starlark REPL with
+settings, db, apps() and models() defined. Flags such as -c "expr" are
+passed through to it.`,
+		DisableFlagParsing: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Println("Gojango Interactive Shell")
-			fmt.Println("Type 'help' for available commands, 'exit' to quit")
-			
-			reader := bufio.NewReader(os.Stdin)
-			
-			for {
-				fmt.Print(">>> ")
-				input, err := reader.ReadString('\n')
-				if err != nil {
-					return err
-				}
-				
-				input = strings.TrimSpace(input)
-				
-				switch input {
-				case "exit", "quit":
-					fmt.Println("Goodbye!")
-					return nil
-				case "help":
-					printShellHelp()
-				case "":
-					continue
-				default:
-					fmt.Printf("Command not implemented: %s\n", input)
-					fmt.Println("This shell is a placeholder. Full implementation coming soon!")
+			for _, arg := range args {
+				if arg == "-h" || arg == "--help" {
+					return cmd.Help()
 				}
 			}
+
+			if _, err := os.Stat("manage.go"); os.IsNotExist(err) {
+				return fmt.Errorf("no manage.go found: run this command from the root of a Gojango project")
+			}
+
+			goArgs := append([]string{"run", "manage.go", "shell"}, args...)
+			goCmd := exec.Command("go", goArgs...)
+			goCmd.Stdout = os.Stdout
+			goCmd.Stderr = os.Stderr
+			goCmd.Stdin = os.Stdin
+
+			return goCmd.Run()
 		},
 	}
 
 	return cmd
 }
-
-func printShellHelp() {
-	fmt.Println(`
-Available commands:
-  help    - Show this help message
-  exit    - Exit the shell
-  quit    - Exit the shell
-
-This interactive shell is under development. Future features will include:
-- Model queries and manipulation
-- Database inspection
-- Application context access
-- Debug utilities
-`)
-}
\ No newline at end of file
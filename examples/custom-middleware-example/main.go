@@ -95,6 +95,7 @@ func main() {
 	// Load settings for all apps
 	settings := gojango.NewBasicSettings()
 	settings.Set("PROJECT_NAME", "middleware-example")
+	settings.Set("SECRET_KEY", "example-secret-key")
 	
 	for _, exampleApp := range []*gojango.Application{minimalApp, devApp, customApp, app} {
 		if err := exampleApp.LoadSettings(settings); err != nil {
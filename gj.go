@@ -29,6 +29,7 @@ type (
 	AppContext    = gojango.AppContext
 	App           = gojango.App
 	AppRoute      = gojango.Route
+	AppRouteGroup = gojango.RouteGroup
 	Option        = gojango.Option
 	AppRegistry   = gojango.Registry
 	Settings      = gojango.Settings
@@ -109,8 +110,9 @@ var (
 
 // Routing Types and Functions
 type (
-	Router = routing.Router
-	Route  = routing.Route
+	Router     = routing.Router
+	Route      = routing.Route
+	RouteGroup = routing.RouteGroup
 )
 
 // Routing Functions
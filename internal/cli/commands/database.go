@@ -3,13 +3,15 @@ package commands
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/epuerta9/gojango/internal/cli/ui"
+	"github.com/epuerta9/gojango/internal/project"
 	"github.com/epuerta9/gojango/pkg/gojango/db"
 	"github.com/spf13/cobra"
 )
@@ -17,53 +19,120 @@ import (
 // NewDatabaseCmd creates the database management command
 func NewDatabaseCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "db",
-		Aliases: []string{"database"},
-		Short:   "Database management commands",
+		Use:               "db",
+		Aliases:           []string{"database"},
+		Short:             "Database management commands",
+		PersistentPreRunE: enterProjectRootWithSettings,
 		Long: `Database management commands for Gojango applications.
 		
 This command provides utilities for managing database connections,
-running migrations, and interacting with your database.`,
+running migrations, and interacting with your database.
+
+The database is DATABASES["default"] in config/settings.star (or the file
+given with --settings), falling back to database.yml, then to DATABASE_URL
+or DB_* variables in .env.`,
 		Example: `  # Run all pending migrations
   gojango db migrate
 
+  # Migrate forward or backward to migration 3
+  gojango db migrate --to 3
+
   # Create a new migration
   gojango db makemigration create_users
 
+  # Create a migration from changes to the app schemas
+  gojango db makemigrations
+
   # Check migration status
   gojango db showmigrations
 
   # Rollback last migration
   gojango db rollback
 
+  # Squash migrations 1 through 5 into one
+  gojango db squashmigrations 1 5
+
   # Open database shell
-  gojango db dbshell`,
+  gojango db dbshell
+
+  # Dump tables to a fixture and load it back
+  gojango db dumpdata users posts --output fixture.json
+  gojango db loaddata fixture.json`,
 	}
 
+	cmd.PersistentFlags().StringVar(&databaseSettingsFile, "settings", databaseSettingsFile, "Settings file with the DATABASES configuration")
+
 	// Add subcommands
 	cmd.AddCommand(newMigrateCmd())
 	cmd.AddCommand(newMakeMigrationCmd())
+	cmd.AddCommand(newMakeMigrationsCmd())
 	cmd.AddCommand(newShowMigrationsCmd())
 	cmd.AddCommand(newRollbackCmd())
+	cmd.AddCommand(newSquashMigrationsCmd())
 	cmd.AddCommand(newResetCmd())
 	cmd.AddCommand(newDBShellCmd())
+	cmd.AddCommand(newDumpDataCmd())
+	cmd.AddCommand(newLoadDataCmd())
 
 	return cmd
 }
 
+// enterProjectRootWithSettings enters the project root like
+// project.EnterRoot, first resolving a --settings path given relative to the
+// working directory
+func enterProjectRootWithSettings(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("settings") {
+		settingsPath, err := filepath.Abs(databaseSettingsFile)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(settingsPath); err != nil {
+			return fmt.Errorf("settings file not found: %s", databaseSettingsFile)
+		}
+		databaseSettingsFile = settingsPath
+	}
+
+	return project.EnterRoot(cmd, args)
+}
+
 // newMigrateCmd creates the migrate command
 func newMigrateCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Run database migrations",
 		Long: `Run all pending database migrations.
 		
 This command will apply all migrations that haven't been run yet,
-in order from lowest to highest migration number.`,
+in order from lowest to highest migration number.
+
+With --atomic all pending migrations run in a single transaction, so a
+failure rolls back the whole batch (Postgres and SQLite; MySQL commits
+DDL statements implicitly).
+
+With --fake <id> the given migration is recorded as applied without
+running its SQL, for schemas that were already changed by other means.
+
+With --to <id> the database is migrated forward or backward to the given
+migration: pending migrations up to it are applied and applied migrations
+after it are rolled back. --to 0 rolls back every migration.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runMigrations(cmd.Context())
+			if fakeID, _ := cmd.Flags().GetInt("fake"); fakeID > 0 {
+				return fakeMigration(cmd.Context(), fakeID)
+			}
+			if cmd.Flags().Changed("to") {
+				targetID, _ := cmd.Flags().GetInt("to")
+				return migrateTo(cmd.Context(), targetID)
+			}
+			atomic, _ := cmd.Flags().GetBool("atomic")
+			return runMigrations(cmd.Context(), atomic)
 		},
 	}
+
+	cmd.Flags().Bool("atomic", false, "Apply all pending migrations in a single transaction")
+	cmd.Flags().Int("fake", 0, "Mark the migration with this ID as applied without running it")
+	cmd.Flags().Int("to", 0, "Migrate forward or backward to the migration with this ID")
+
+	return cmd
 }
 
 // newMakeMigrationCmd creates the makemigration command
@@ -95,7 +164,8 @@ func newShowMigrationsCmd() *cobra.Command {
 		Long: `Show the status of all migrations.
 		
 This command displays which migrations have been applied
-and which are still pending.`,
+and which are still pending. Applied migrations whose files were
+edited after they were applied are flagged.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return showMigrations(cmd.Context())
 		},
@@ -117,6 +187,34 @@ the migration as not applied.`,
 	}
 }
 
+// newSquashMigrationsCmd creates the squashmigrations command
+func newSquashMigrationsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "squashmigrations [from] [to]",
+		Short: "Squash a range of migrations into one",
+		Long: `Squash the migrations with IDs from through to into a single migration.
+		
+The up SQL of the range is concatenated into a new NNNN_squashed_*_up.sql
+file and the down SQL into a matching _down.sql file in reverse order.
+The original files are kept and marked as replaced: databases that already
+applied them treat the squashed migration as applied, while fresh databases
+only run the squashed migration. Once every database has migrated past the
+range, the original files can be deleted.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid migration ID %q: %w", args[0], err)
+			}
+			to, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid migration ID %q: %w", args[1], err)
+			}
+			return squashMigrations(from, to)
+		},
+	}
+}
+
 // newResetCmd creates the reset command
 func newResetCmd() *cobra.Command {
 	return &cobra.Command{
@@ -147,8 +245,45 @@ with the connection parameters from your configuration.`,
 	}
 }
 
+// newDumpDataCmd creates the dumpdata command
+func newDumpDataCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dumpdata [model...]",
+		Short: "Dump database contents to a JSON fixture",
+		Long: `Serialize the rows of the given models to a JSON fixture.
+		
+Models are table names (e.g. "users" for the User schema). When no
+models are given, all tables are dumped. Output goes to stdout unless
+--output is set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+			return dumpData(cmd.Context(), args, output)
+		},
+	}
+
+	cmd.Flags().StringP("output", "o", "", "Write the fixture to this file instead of stdout")
+
+	return cmd
+}
+
+// newLoadDataCmd creates the loaddata command
+func newLoadDataCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "loaddata [fixture...]",
+		Short: "Load JSON fixtures into the database",
+		Long: `Load one or more JSON fixtures created by dumpdata.
+		
+Objects are inserted in foreign key dependency order, and each
+fixture is loaded in a single transaction.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return loadData(cmd.Context(), args)
+		},
+	}
+}
+
 // runMigrations executes all pending migrations
-func runMigrations(ctx context.Context) error {
+func runMigrations(ctx context.Context, atomic bool) error {
 	config, err := loadDatabaseConfig()
 	if err != nil {
 		return fmt.Errorf("failed to load database configuration: %w", err)
@@ -161,6 +296,7 @@ func runMigrations(ctx context.Context) error {
 	defer conn.Close()
 
 	migrator := db.NewMigrator(conn, "migrations")
+	migrator.SetAtomic(atomic)
 	
 	if err := migrator.Initialize(ctx); err != nil {
 		return fmt.Errorf("failed to initialize migrator: %w", err)
@@ -169,10 +305,79 @@ func runMigrations(ctx context.Context) error {
 	return migrator.Apply(ctx)
 }
 
+// fakeMigration records a migration as applied without running it
+func fakeMigration(ctx context.Context, id int) error {
+	config, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	conn, err := db.Open(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close()
+
+	migrator := db.NewMigrator(conn, "migrations")
+	
+	if err := migrator.Initialize(ctx); err != nil {
+		return fmt.Errorf("failed to initialize migrator: %w", err)
+	}
+
+	if err := migrator.Fake(ctx, id); err != nil {
+		return err
+	}
+
+	fmt.Printf("Marked migration %04d as applied (fake)\n", id)
+	return nil
+}
+
+// migrateTo migrates the database forward or backward to a target migration
+func migrateTo(ctx context.Context, targetID int) error {
+	if targetID < 0 {
+		return fmt.Errorf("invalid target migration ID: %d", targetID)
+	}
+
+	config, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	conn, err := db.Open(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close()
+
+	migrator := db.NewMigrator(conn, "migrations")
+	
+	if err := migrator.Initialize(ctx); err != nil {
+		return fmt.Errorf("failed to initialize migrator: %w", err)
+	}
+
+	if err := migrator.MigrateTo(ctx, targetID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Database is at migration %04d\n", targetID)
+	return nil
+}
+
 // createMigration creates a new migration file
 func createMigration(name string) error {
+	upSQL := `-- Add your SQL statements here
+-- Example: CREATE TABLE users (id SERIAL PRIMARY KEY, email VARCHAR(255));
+`
+	downSQL := `-- Add your rollback SQL statements here
+-- Example: DROP TABLE IF EXISTS users;
+`
+	return writeMigrationFiles("migrations", name, upSQL, downSQL)
+}
+
+// writeMigrationFiles writes the up and down files of a migration named
+// name with the next sequential ID
+func writeMigrationFiles(migrationsDir, name, upSQL, downSQL string) error {
 	// Ensure migrations directory exists
-	migrationsDir := "migrations"
 	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
 		return fmt.Errorf("failed to create migrations directory: %w", err)
 	}
@@ -199,9 +404,7 @@ func createMigration(name string) error {
 -- Created: %s
 -- Description: %s
 
--- Add your SQL statements here
--- Example: CREATE TABLE users (id SERIAL PRIMARY KEY, email VARCHAR(255));
-`, name, time.Now().Format("2006-01-02 15:04:05"), name)
+%s`, name, time.Now().Format("2006-01-02 15:04:05"), name, upSQL)
 
 	if err := os.WriteFile(upFile, []byte(upContent), 0644); err != nil {
 		return fmt.Errorf("failed to create up migration file: %w", err)
@@ -212,9 +415,7 @@ func createMigration(name string) error {
 -- Created: %s
 -- Description: Rollback %s
 
--- Add your rollback SQL statements here
--- Example: DROP TABLE IF EXISTS users;
-`, name, time.Now().Format("2006-01-02 15:04:05"), name)
+%s`, name, time.Now().Format("2006-01-02 15:04:05"), name, downSQL)
 
 	if err := os.WriteFile(downFile, []byte(downContent), 0644); err != nil {
 		return fmt.Errorf("failed to create down migration file: %w", err)
@@ -281,13 +482,27 @@ func showMigrations(ctx context.Context) error {
 		return fmt.Errorf("failed to get migration status: %w", err)
 	}
 
+	mismatches, err := migrator.Verify(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to verify migration checksums: %w", err)
+	}
+	modified := make(map[string]bool)
+	for _, mismatch := range mismatches {
+		modified[mismatch.Migration.Filename] = true
+	}
+
 	fmt.Printf("Migration Status\n")
 	fmt.Printf("================\n\n")
 
 	if len(status.Applied) > 0 {
 		fmt.Printf("Applied Migrations (%d):\n", len(status.Applied))
 		for _, migration := range status.Applied {
-			fmt.Printf("  ✓ %04d_%s (applied: %s)\n", 
+			marker := "✓"
+			if modified[migration.Filename] {
+				marker = "✗"
+			}
+			fmt.Printf("  %s %04d_%s (applied: %s)\n", 
+				marker,
 				migration.ID, 
 				migration.Name, 
 				migration.AppliedAt.Format("2006-01-02 15:04:05"))
@@ -295,6 +510,16 @@ func showMigrations(ctx context.Context) error {
 		fmt.Println()
 	}
 
+	if len(mismatches) > 0 {
+		ui.Error(fmt.Sprintf("Modified Migrations (%d):", len(mismatches)))
+		for _, mismatch := range mismatches {
+			ui.Error(fmt.Sprintf("  ✗ %s changed after it was applied (checksum %.12s, recorded %.12s)",
+				mismatch.Migration.Filename, mismatch.Current, mismatch.Recorded))
+		}
+		ui.Error("  Restore the original files and create a new migration for further changes")
+		fmt.Println()
+	}
+
 	if len(status.Pending) > 0 {
 		fmt.Printf("Pending Migrations (%d):\n", len(status.Pending))
 		for _, migration := range status.Pending {
@@ -303,6 +528,16 @@ func showMigrations(ctx context.Context) error {
 		fmt.Println()
 	}
 
+	if len(status.Conflicts) > 0 {
+		ui.Error(fmt.Sprintf("Out-of-order Migrations (%d):", len(status.Conflicts)))
+		for _, migration := range status.Conflicts {
+			ui.Error(fmt.Sprintf("  ! %04d_%s is pending but %04d_%s is already applied",
+				migration.ID, migration.Name, status.LastApplied.ID, status.LastApplied.Name))
+		}
+		ui.Error("  Apply them with 'gojango db migrate' or mark them with 'gojango db migrate --fake <id>'")
+		fmt.Println()
+	}
+
 	if len(status.Applied) == 0 && len(status.Pending) == 0 {
 		fmt.Println("No migrations found.")
 	} else {
@@ -334,6 +569,25 @@ func rollbackMigration(ctx context.Context) error {
 	return migrator.Rollback(ctx)
 }
 
+// squashMigrations squashes a range of migration files into one
+func squashMigrations(from, to int) error {
+	// Squashing only reads and writes files, so no database connection is needed
+	migrator := db.NewMigrator(nil, "migrations")
+
+	squashed, err := migrator.Squash(from, to)
+	if err != nil {
+		return fmt.Errorf("failed to squash migrations: %w", err)
+	}
+
+	fmt.Printf("Created squashed migration replacing %d migrations:\n", len(squashed.Replaces))
+	fmt.Printf("  %s\n", filepath.Join("migrations", squashed.Filename))
+	for _, replaced := range squashed.Replaces {
+		fmt.Printf("  replaces %s\n", replaced)
+	}
+
+	return nil
+}
+
 // resetMigrations rolls back all migrations
 func resetMigrations(ctx context.Context) error {
 	config, err := loadDatabaseConfig()
@@ -356,40 +610,179 @@ func resetMigrations(ctx context.Context) error {
 	return migrator.Reset(ctx)
 }
 
-// openDatabaseShell opens an interactive database shell
-func openDatabaseShell() error {
-	fmt.Println("Database shell functionality will be implemented based on your database configuration.")
-	fmt.Println("This would typically launch psql, sqlite3, or mysql client.")
+// dumpData writes a JSON fixture of the given models
+func dumpData(ctx context.Context, models []string, output string) error {
+	config, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	conn, err := db.Open(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close()
+
+	if output == "" {
+		return db.DumpData(ctx, conn, models, os.Stdout)
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create fixture file: %w", err)
+	}
+	defer file.Close()
+
+	if err := db.DumpData(ctx, conn, models, file); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote fixture to %s\n", output)
 	return nil
 }
 
-// loadDatabaseConfig loads database configuration from the current project
-func loadDatabaseConfig() (*db.Config, error) {
-	// This is a simplified version - in a real implementation,
-	// you would load from a configuration file or environment
-	// For now, return a default SQLite configuration
-	config := db.DefaultConfig()
-	
-	// Check if we're in a Gojango project directory
-	if _, err := os.Stat("go.mod"); err != nil {
-		return nil, fmt.Errorf("not in a Gojango project directory")
+// loadData loads the given JSON fixture files
+func loadData(ctx context.Context, fixtures []string) error {
+	config, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	conn, err := db.Open(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close()
+
+	total := 0
+	for _, fixture := range fixtures {
+		file, err := os.Open(fixture)
+		if err != nil {
+			return fmt.Errorf("failed to open fixture %s: %w", fixture, err)
+		}
+
+		count, err := db.LoadData(ctx, conn, file)
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("failed to load fixture %s: %w", fixture, err)
+		}
+		total += count
+	}
+
+	fmt.Printf("Installed %d object(s) from %d fixture(s)\n", total, len(fixtures))
+	return nil
+}
+
+// openDatabaseShell runs the database's command-line client on the
+// configured database, attached to the terminal
+func openDatabaseShell() error {
+	config, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	argv, env, err := dbShellCommand(config)
+	if err != nil {
+		return err
 	}
 
-	// Try to find database configuration in common locations
-	configFiles := []string{
-		"database.yml",
-		"config/database.yml", 
-		"db.yml",
-		".env",
+	client, err := exec.LookPath(argv[0])
+	if err != nil {
+		return fmt.Errorf("%s not found on PATH: install the %s client to use dbshell", argv[0], config.Driver)
 	}
 
-	for _, configFile := range configFiles {
-		if _, err := os.Stat(configFile); err == nil {
-			log.Printf("Found configuration file: %s", configFile)
-			// TODO: Parse configuration file
-			break
+	shell := exec.Command(client, argv[1:]...)
+	shell.Env = append(os.Environ(), env...)
+	shell.Stdin = os.Stdin
+	shell.Stdout = os.Stdout
+	shell.Stderr = os.Stderr
+
+	return shell.Run()
+}
+
+// dbShellCommand returns the client command line for a database and the
+// environment variables to add for it. Passwords go in the environment
+// (PGPASSWORD, MYSQL_PWD) so they don't show up in the process list.
+func dbShellCommand(config *db.Config) ([]string, []string, error) {
+	var argv, env []string
+
+	switch config.Driver {
+	case db.DriverSQLite:
+		path := config.Database
+		if path == "" {
+			path = config.DSN
 		}
+		// Drop the file: scheme and connection options of SQLite DSNs
+		path = strings.TrimPrefix(path, "file:")
+		if i := strings.Index(path, "?"); i >= 0 {
+			path = path[:i]
+		}
+		if path == "" {
+			path = ":memory:"
+		}
+		argv = []string{"sqlite3", path}
+
+	case db.DriverPostgres:
+		argv = []string{"psql"}
+		if config.DSN != "" && config.Database == "" {
+			// psql accepts a connection string in place of the database name
+			return append(argv, config.DSN), nil, nil
+		}
+		if config.Host != "" {
+			argv = append(argv, "-h", config.Host)
+		}
+		if config.Port > 0 {
+			argv = append(argv, "-p", strconv.Itoa(config.Port))
+		}
+		if config.Username != "" {
+			argv = append(argv, "-U", config.Username)
+		}
+		if config.Database != "" {
+			argv = append(argv, config.Database)
+		}
+		if config.Password != "" {
+			env = append(env, "PGPASSWORD="+config.Password)
+		}
+		if config.SSLMode != "" {
+			env = append(env, "PGSSLMODE="+config.SSLMode)
+		}
+
+	case db.DriverMySQL:
+		if config.DSN != "" && config.Database == "" {
+			return nil, nil, fmt.Errorf("dbshell needs the MySQL host, user and database settings rather than a DSN")
+		}
+		argv = []string{"mysql"}
+		if config.Host != "" {
+			// --protocol=tcp stops "localhost" from meaning the local socket
+			argv = append(argv, "-h", config.Host, "--protocol=tcp")
+		}
+		if config.Port > 0 {
+			argv = append(argv, "-P", strconv.Itoa(config.Port))
+		}
+		if config.Username != "" {
+			argv = append(argv, "-u", config.Username)
+		}
+		if config.Database != "" {
+			argv = append(argv, config.Database)
+		}
+		if config.Password != "" {
+			env = append(env, "MYSQL_PWD="+config.Password)
+		}
+
+	default:
+		return nil, nil, fmt.Errorf("dbshell does not support the %q driver", config.Driver)
+	}
+
+	return argv, env, nil
+}
+
+// loadDatabaseConfig loads the database configuration of the current
+// project from its settings file (see projectDatabaseConfig)
+func loadDatabaseConfig() (*db.Config, error) {
+	projectDir, err := project.RequireRoot()
+	if err != nil {
+		return nil, err
 	}
 
-	return config, nil
+	return projectDatabaseConfig(projectDir, databaseSettingsFile)
 }
\ No newline at end of file
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"gopkg.in/yaml.v3"
+)
+
+// databaseSettingsFile is the settings file the db commands read the
+// database configuration from, set by their --settings flag
+var databaseSettingsFile = filepath.Join("config", "settings.star")
+
+// databaseYAMLFiles are where a database.yml may be found in a project
+var databaseYAMLFiles = []string{
+	"database.yml",
+	filepath.Join("config", "database.yml"),
+	"db.yml",
+}
+
+// projectDatabaseConfig reads the database configuration of the project in
+// dir. It uses the first of:
+//
+//   - DATABASES["default"] or DATABASE_URL in the settings file, with
+//     environment variables applied as for the server
+//   - a database.yml with db.Config's fields (driver, host, port, ...)
+//   - DATABASE_URL or DB_ENGINE, DB_NAME, DB_HOST, DB_PORT, DB_USER and
+//     DB_PASSWORD in a .env file
+//
+// and falls back to the default SQLite database when none is configured.
+func projectDatabaseConfig(dir, settingsFile string) (*db.Config, error) {
+	settingsPath := settingsFile
+	if !filepath.IsAbs(settingsPath) {
+		settingsPath = filepath.Join(dir, settingsFile)
+	}
+
+	if _, err := os.Stat(settingsPath); err == nil {
+		settings := gojango.NewSettingsStack()
+		if err := settings.LoadFile(settingsPath); err != nil {
+			return nil, err
+		}
+		settings.LoadEnv()
+
+		if settings.Has("DATABASES") {
+			config, err := settings.GetDatabaseConfig("default")
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", settingsFile, err)
+			}
+			return config, nil
+		}
+		if rawURL := settings.GetString("DATABASE_URL"); rawURL != "" {
+			return db.ParseURL(rawURL)
+		}
+	}
+
+	for _, name := range databaseYAMLFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return databaseYAMLConfig(path)
+		}
+	}
+
+	envPath := filepath.Join(dir, ".env")
+	if _, err := os.Stat(envPath); err == nil {
+		config, err := dotEnvDatabaseConfig(envPath)
+		if err != nil || config != nil {
+			return config, err
+		}
+	}
+
+	return db.DefaultConfig(), nil
+}
+
+// databaseYAMLConfig reads a database.yml holding db.Config's fields
+func databaseYAMLConfig(path string) (*db.Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := db.DefaultConfig()
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	driver, err := db.ParseDriver(string(config.Driver))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	config.Driver = driver
+	if config.Driver == db.DriverPostgres && config.SSLMode == "" {
+		config.SSLMode = "disable"
+	}
+	return config, nil
+}
+
+// dotEnvDatabaseConfig reads the database variables of a .env file,
+// returning nil if it sets none
+func dotEnvDatabaseConfig(path string) (*db.Config, error) {
+	vars, err := parseDotEnv(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawURL := vars["DATABASE_URL"]; rawURL != "" {
+		return db.ParseURL(rawURL)
+	}
+	if vars["DB_ENGINE"] == "" {
+		return nil, nil
+	}
+
+	// Build the DATABASES entry the settings file would have
+	entry := map[string]interface{}{"engine": vars["DB_ENGINE"]}
+	for key, option := range map[string]string{
+		"DB_NAME":     "name",
+		"DB_HOST":     "host",
+		"DB_PORT":     "port",
+		"DB_USER":     "user",
+		"DB_PASSWORD": "password",
+		"DB_SSLMODE":  "sslmode",
+	} {
+		if value, ok := vars[key]; ok {
+			entry[option] = value
+		}
+	}
+
+	settings := gojango.NewSettingsStack()
+	settings.Set("DATABASES", map[string]interface{}{"default": entry})
+	config, err := settings.GetDatabaseConfig("default")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// parseDotEnv reads KEY=value lines, skipping blank lines and comments and
+// allowing an "export " prefix and quoted values
+func parseDotEnv(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("%s:%d: expected KEY=value", path, lineNumber)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+func TestDBShellCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *db.Config
+		argv   []string
+		env    []string
+	}{
+		{
+			name:   "sqlite",
+			config: db.SQLiteConfig("data/app.db"),
+			argv:   []string{"sqlite3", "data/app.db"},
+		},
+		{
+			name:   "sqlite dsn",
+			config: &db.Config{Driver: db.DriverSQLite, DSN: "file:app.db?_foreign_keys=on"},
+			argv:   []string{"sqlite3", "app.db"},
+		},
+		{
+			name:   "sqlite in memory",
+			config: &db.Config{Driver: db.DriverSQLite},
+			argv:   []string{"sqlite3", ":memory:"},
+		},
+		{
+			name:   "postgres",
+			config: db.PostgresConfig("db.internal", "shop", "admin", "s3cret"),
+			argv:   []string{"psql", "-h", "db.internal", "-p", "5432", "-U", "admin", "shop"},
+			env:    []string{"PGPASSWORD=s3cret", "PGSSLMODE=disable"},
+		},
+		{
+			name:   "postgres without password",
+			config: &db.Config{Driver: db.DriverPostgres, Database: "shop"},
+			argv:   []string{"psql", "shop"},
+		},
+		{
+			name:   "postgres dsn",
+			config: &db.Config{Driver: db.DriverPostgres, DSN: "postgres://admin@db.internal/shop"},
+			argv:   []string{"psql", "postgres://admin@db.internal/shop"},
+		},
+		{
+			name:   "mysql",
+			config: &db.Config{Driver: db.DriverMySQL, Host: "localhost", Port: 3306, Username: "root", Password: "pw", Database: "shop"},
+			argv:   []string{"mysql", "-h", "localhost", "--protocol=tcp", "-P", "3306", "-u", "root", "shop"},
+			env:    []string{"MYSQL_PWD=pw"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			argv, env, err := dbShellCommand(tt.config)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(argv, tt.argv) {
+				t.Errorf("Expected argv %v, got: %v", tt.argv, argv)
+			}
+			if !reflect.DeepEqual(env, tt.env) {
+				t.Errorf("Expected env %v, got: %v", tt.env, env)
+			}
+		})
+	}
+}
+
+func TestDBShellCommandErrors(t *testing.T) {
+	configs := map[string]*db.Config{
+		"unknown driver": {Driver: "oracle"},
+		"mysql dsn":      {Driver: db.DriverMySQL, DSN: "root:pw@tcp(localhost)/shop"},
+	}
+
+	for name, config := range configs {
+		if _, _, err := dbShellCommand(config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// writeProjectFiles writes files into a temp project dir and returns it
+func writeProjectFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestProjectDatabaseConfigFromSettings(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"config/settings.star": `
+DATABASES = {
+    "default": {
+        "engine": "postgresql",
+        "host": "db.internal",
+        "port": 5433,
+        "name": "shop",
+        "user": "shop",
+        "password": "s3cret",
+    },
+}
+`,
+		// Ignored: the settings file configures the database
+		"database.yml": "driver: sqlite3\ndatabase: other.db\n",
+	})
+
+	config, err := projectDatabaseConfig(dir, filepath.Join("config", "settings.star"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := db.PostgresConfig("db.internal", "shop", "shop", "s3cret")
+	expected.Port = 5433
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("Expected %+v, got: %+v", expected, config)
+	}
+}
+
+func TestProjectDatabaseConfigAlternateSettings(t *testing.T) {
+	dir := writeProjectFiles(t, map[string]string{
+		"config/settings.star":      `DATABASES = {"default": {"engine": "sqlite", "name": "dev.db"}}`,
+		"config/settings_prod.star": `DATABASE_URL = "postgres://app:pw@prod.internal/app?sslmode=require"`,
+	})
+
+	config, err := projectDatabaseConfig(dir, filepath.Join(dir, "config", "settings_prod.star"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Driver != db.DriverPostgres || config.Host != "prod.internal" || config.Port != 5432 ||
+		config.Database != "app" || config.Username != "app" || config.Password != "pw" || config.SSLMode != "require" {
+		t.Errorf("Expected the production database, got: %+v", config)
+	}
+}
+
+func TestProjectDatabaseConfigFallbacks(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		driver   db.Driver
+		database string
+		host     string
+		port     int
+	}{
+		{
+			name:     "database.yml",
+			files:    map[string]string{"config/database.yml": "driver: postgres\nhost: yml.internal\nport: 5432\ndatabase: blog\n"},
+			driver:   db.DriverPostgres,
+			database: "blog",
+			host:     "yml.internal",
+			port:     5432,
+		},
+		{
+			name:     "settings without DATABASES",
+			files:    map[string]string{"config/settings.star": "DEBUG = True", ".env": "DATABASE_URL=mysql://root:pw@localhost/shop\n"},
+			driver:   db.DriverMySQL,
+			database: "shop",
+			host:     "localhost",
+			port:     3306,
+		},
+		{
+			name:     ".env variables",
+			files:    map[string]string{".env": "# local database\nexport DB_ENGINE=postgres\nDB_NAME=\"blog\"\nDB_HOST=localhost\nDB_PORT=5434\n"},
+			driver:   db.DriverPostgres,
+			database: "blog",
+			host:     "localhost",
+			port:     5434,
+		},
+		{
+			name:     ".env sqlite url",
+			files:    map[string]string{".env": "DATABASE_URL=sqlite:///data/app.db\n"},
+			driver:   db.DriverSQLite,
+			database: "data/app.db",
+		},
+		{
+			name:     "default",
+			files:    map[string]string{".env": "DEBUG=true\n"},
+			driver:   db.DriverSQLite,
+			database: "gojango.db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeProjectFiles(t, tt.files)
+			config, err := projectDatabaseConfig(dir, filepath.Join("config", "settings.star"))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if config.Driver != tt.driver || config.Database != tt.database || config.Host != tt.host || config.Port != tt.port {
+				t.Errorf("Expected %s %s on %s:%d, got: %+v", tt.driver, tt.database, tt.host, tt.port, config)
+			}
+		})
+	}
+}
+
+func TestProjectDatabaseConfigErrors(t *testing.T) {
+	tests := map[string]map[string]string{
+		"bad engine":   {"config/settings.star": `DATABASES = {"default": {"engine": "oracle"}}`},
+		"no default":   {"config/settings.star": `DATABASES = {"replica": {"engine": "sqlite"}}`},
+		"bad yaml":     {"database.yml": "driver: [postgres"},
+		"bad .env":     {".env": "DB_ENGINE\n"},
+		"bad url":      {".env": "DATABASE_URL=oracle://localhost/db\n"},
+		"bad settings": {"config/settings.star": `DATABASES = `},
+	}
+
+	for name, files := range tests {
+		dir := writeProjectFiles(t, files)
+		if _, err := projectDatabaseConfig(dir, filepath.Join("config", "settings.star")); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,14 +1,16 @@
 package commands
 
 import (
+	"github.com/epuerta9/gojango/internal/project"
 	"github.com/spf13/cobra"
 )
 
 // NewGenerateCmd creates the 'generate' command for code generation
 func NewGenerateCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "generate",
-		Short: "Generate code and assets",
+		Use:               "generate",
+		Short:             "Generate code and assets",
+		PersistentPreRunE: project.EnterRoot,
 		Long: `Generate various types of code and assets for your Gojango project.
 
 This command provides code generation capabilities including:
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/epuerta9/gojango/pkg/gojango/codegen"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/spf13/cobra"
+)
+
+// newMakeMigrationsCmd creates the makemigrations command
+func newMakeMigrationsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "makemigrations [name]",
+		Short: "Create a migration from changes to the app schemas",
+		Long: `Create a migration from the changes between the Ent schemas of your
+apps (apps/*/schema) and the database.
+
+New tables and new columns are detected and written as up and down SQL in
+the migrations/ directory. Dropped or changed tables and columns are not
+detected: write those migrations with makemigration.
+
+All existing migrations must be applied first, so the database reflects
+them. When nothing changed, no migration is written.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			dryRun, _ := cmd.Flags().GetBool("dry-run")
+			return makeMigrations(cmd.Context(), name, dryRun)
+		},
+	}
+
+	cmd.Flags().Bool("dry-run", false, "Print the migration SQL without writing it")
+
+	return cmd
+}
+
+// makeMigrations writes a migration for the changes between the apps' Ent
+// schemas and the database
+func makeMigrations(ctx context.Context, name string, dryRun bool) error {
+	schema, err := codegen.NewProjectGenerator(".").Schema()
+	if err != nil {
+		return err
+	}
+
+	config, err := loadDatabaseConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load database configuration: %w", err)
+	}
+
+	conn, err := db.Open(config)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer conn.Close()
+
+	migrator := db.NewMigrator(conn, "migrations")
+	if err := migrator.Initialize(ctx); err != nil {
+		return fmt.Errorf("failed to initialize migrator: %w", err)
+	}
+	status, err := migrator.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+	if len(status.Pending) > 0 {
+		return fmt.Errorf("%d migration(s) are not applied yet: run 'gojango db migrate' first", len(status.Pending))
+	}
+
+	diff, up, down, err := schemaMigration(ctx, conn, schema.Tables())
+	if err != nil {
+		return err
+	}
+	if diff.Empty() {
+		fmt.Println("No changes detected")
+		return nil
+	}
+
+	fmt.Println("Changes detected:")
+	for _, change := range diff.Summary() {
+		fmt.Printf("  - %s\n", change)
+	}
+	if dryRun {
+		fmt.Printf("\n%s", up)
+		return nil
+	}
+
+	if name == "" {
+		name = migrationName(diff)
+	}
+	return writeMigrationFiles("migrations", name, up, down)
+}
+
+// schemaMigration diffs the database against the desired tables and
+// renders the up and down SQL of the changes
+func schemaMigration(ctx context.Context, conn *db.Connection, tables []db.TableSchema) (*db.SchemaDiff, string, string, error) {
+	current, err := db.InspectSchema(ctx, conn)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to inspect the database schema: %w", err)
+	}
+
+	diff := db.DiffSchema(current, tables)
+	if diff.Empty() {
+		return diff, "", "", nil
+	}
+	up, down, err := diff.SQL(conn.Driver())
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to render migration SQL: %w", err)
+	}
+	return diff, up, down, nil
+}
+
+// migrationName names a migration after its changes when there is a single
+// table involved, like create_posts or add_title_to_posts
+func migrationName(diff *db.SchemaDiff) string {
+	switch {
+	case len(diff.AddedTables) == 1 && len(diff.AddedColumns) == 0:
+		return "create_" + diff.AddedTables[0].Name
+	case len(diff.AddedTables) == 0 && len(diff.AddedColumns) == 1:
+		added := diff.AddedColumns[0]
+		return fmt.Sprintf("add_%s_to_%s", added.Column.Name, added.Table)
+	case len(diff.AddedTables) == 0:
+		table := diff.AddedColumns[0].Table
+		for _, added := range diff.AddedColumns {
+			if added.Table != table {
+				return "auto"
+			}
+		}
+		return "alter_" + table
+	}
+	return "auto"
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/codegen"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+const postSchemaBefore = `package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+type Post struct {
+	ent.Schema
+}
+
+func (Post) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title"),
+	}
+}
+`
+
+const postSchemaAfter = `package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+type Post struct {
+	ent.Schema
+}
+
+func (Post) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title"),
+		field.Bool("published").Default(false),
+	}
+}
+
+type Category struct {
+	ent.Schema
+}
+
+func (Category) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").Unique(),
+	}
+}
+`
+
+// projectTables analyzes a project with a single blog schema file
+func projectTables(t *testing.T, schema string) []db.TableSchema {
+	t.Helper()
+	dir := writeProjectFiles(t, map[string]string{"apps/blog/schema/post.go": schema})
+	analyzer, err := codegen.NewProjectGenerator(dir).Schema()
+	if err != nil {
+		t.Fatalf("Failed to analyze schemas: %v", err)
+	}
+	return analyzer.Tables()
+}
+
+func TestSchemaMigration(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "app.db")))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	// The first migration creates the posts table
+	diff, up, _, err := schemaMigration(ctx, conn, projectTables(t, postSchemaBefore))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name := migrationName(diff); name != "create_posts" {
+		t.Errorf("Expected migration create_posts, got: %s", name)
+	}
+	if _, err := conn.DB().ExecContext(ctx, up); err != nil {
+		t.Fatalf("Failed to apply migration: %v\n%s", err, up)
+	}
+
+	// Without schema changes there is nothing to migrate
+	diff, _, _, err = schemaMigration(ctx, conn, projectTables(t, postSchemaBefore))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("Expected no changes, got: %v", diff.Summary())
+	}
+
+	// A new model and a new field are picked up
+	diff, up, down, err := schemaMigration(ctx, conn, projectTables(t, postSchemaAfter))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "Create table categories\nAdd column posts.published"
+	if summary := strings.Join(diff.Summary(), "\n"); summary != expected {
+		t.Errorf("Expected changes:\n%s\ngot:\n%s", expected, summary)
+	}
+	if name := migrationName(diff); name != "auto" {
+		t.Errorf("Expected migration auto, got: %s", name)
+	}
+	for _, statement := range []string{
+		"CREATE TABLE categories (\n    id INTEGER PRIMARY KEY AUTOINCREMENT,\n    name TEXT NOT NULL UNIQUE\n);",
+		"ALTER TABLE posts ADD COLUMN published BOOLEAN NOT NULL DEFAULT FALSE;",
+	} {
+		if !strings.Contains(up, statement) {
+			t.Errorf("Expected the migration to contain %q, got:\n%s", statement, up)
+		}
+	}
+	if _, err := conn.DB().ExecContext(ctx, up); err != nil {
+		t.Fatalf("Failed to apply migration: %v\n%s", err, up)
+	}
+	if _, err := conn.DB().ExecContext(ctx, down); err != nil {
+		t.Fatalf("Failed to roll back migration: %v\n%s", err, down)
+	}
+}
+
+func TestMigrationName(t *testing.T) {
+	tests := map[string]*db.SchemaDiff{
+		"create_tags":       {AddedTables: []db.TableSchema{{Name: "tags"}}},
+		"add_slug_to_posts": {AddedColumns: []db.AddedColumn{{Table: "posts", Column: db.ColumnSchema{Name: "slug"}}}},
+		"alter_posts":       {AddedColumns: []db.AddedColumn{{Table: "posts", Column: db.ColumnSchema{Name: "slug"}}, {Table: "posts", Column: db.ColumnSchema{Name: "views"}}}},
+		"auto":              {AddedColumns: []db.AddedColumn{{Table: "posts"}, {Table: "tags"}}},
+	}
+
+	for expected, diff := range tests {
+		if name := migrationName(diff); name != expected {
+			t.Errorf("Expected %s, got: %s", expected, name)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/epuerta9/gojango/internal/project"
+)
+
+// newTestProject creates a project root containing the given marker file
+func newTestProject(t *testing.T, marker string) string {
+	t.Helper()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	files := map[string]string{
+		marker:   "",
+		"go.mod": "module example.com/mysite\n\ngo 1.21\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return root
+}
+
+func TestProjectCommandsOutsideProject(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	commands := map[string][]string{
+		"startapp": {"blog"},
+		"generate": {"all"},
+		"db":       {"showmigrations"},
+	}
+	for name, args := range commands {
+		cmd := NewStartAppCmd()
+		switch name {
+		case "generate":
+			cmd = NewGenerateCmd()
+		case "db":
+			cmd = NewDatabaseCmd()
+		}
+		cmd.SetArgs(args)
+		cmd.SetOut(io.Discard)
+		cmd.SetErr(io.Discard)
+
+		err := cmd.Execute()
+		var notInProject *project.NotInProjectError
+		if !errors.As(err, &notInProject) {
+			t.Errorf("%s: expected NotInProjectError, got %v", name, err)
+		}
+	}
+}
+
+func TestStartAppFromProjectSubdirectory(t *testing.T) {
+	root := newTestProject(t, "manage.go")
+	subdir := filepath.Join(root, "config")
+	if err := os.MkdirAll(subdir, 0755); err != nil {
+		t.Fatalf("Failed to create subdir: %v", err)
+	}
+	t.Chdir(subdir)
+
+	cmd := NewStartAppCmd()
+	cmd.SetArgs([]string{"blog"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("startapp failed inside a project: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "apps", "blog", "app.go")); err != nil {
+		t.Errorf("Expected the app to be created at the project root: %v", err)
+	}
+}
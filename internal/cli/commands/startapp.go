@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/epuerta9/gojango/internal/cli/ui"
 	"github.com/epuerta9/gojango/internal/codegen"
+	"github.com/epuerta9/gojango/internal/project"
 )
 
 // NewStartAppCmd creates the 'startapp' command for generating new apps
@@ -32,7 +33,8 @@ It will create a new app directory structure with:
   • Basic tests
 
 The app will be automatically registered in your project's main.go file.`,
-		Args: cobra.ExactArgs(1),
+		Args:    cobra.ExactArgs(1),
+		PreRunE: project.EnterRoot,
 		Example: `  # Create a basic app
   gojango startapp blog
 
@@ -49,11 +51,6 @@ The app will be automatically registered in your project's main.go file.`,
 				return fmt.Errorf("invalid app name: %s\nApp name must be a valid Go package name", appName)
 			}
 
-			// Check if we're in a Gojango project
-			if !isGojangoProject() {
-				return fmt.Errorf("not in a Gojango project directory\nRun this command from the root of a Gojango project")
-			}
-
 			// Check if app already exists
 			if appExists(appName) {
 				return fmt.Errorf("app '%s' already exists in apps/%s", appName, appName)
@@ -149,50 +146,6 @@ func isValidAppName(name string) bool {
 	return true
 }
 
-// isGojangoProject checks if the current directory is a Gojango project
-func isGojangoProject() bool {
-	// Check for gojango.yaml
-	if _, err := os.Stat("gojango.yaml"); err == nil {
-		return true
-	}
-
-	// Check for main.go with gojango import
-	if _, err := os.Stat("main.go"); err == nil {
-		if hasGojangoImport("main.go") {
-			return true
-		}
-	}
-
-	// Check for cmd/server/main.go
-	if _, err := os.Stat("cmd/server/main.go"); err == nil {
-		if hasGojangoImport("cmd/server/main.go") {
-			return true
-		}
-	}
-
-	return false
-}
-
-// hasGojangoImport checks if a Go file imports gojango
-func hasGojangoImport(filename string) bool {
-	fset := token.NewFileSet()
-	node, err := parser.ParseFile(fset, filename, nil, parser.ImportsOnly)
-	if err != nil {
-		return false
-	}
-
-	for _, imp := range node.Imports {
-		if imp.Path != nil {
-			path := strings.Trim(imp.Path.Value, `"`)
-			if strings.Contains(path, "github.com/epuerta9/gojango") {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
 // appExists checks if an app directory already exists
 func appExists(appName string) bool {
 	_, err := os.Stat(fmt.Sprintf("apps/%s", appName))
@@ -0,0 +1,76 @@
+// Package project locates the Gojango project the CLI is run in, so
+// project-scoped commands fail clearly outside of one.
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+// projectMarkers are files whose presence identifies a Gojango project root
+var projectMarkers = []string{
+	"manage.go",
+	filepath.Join("config", "settings.star"),
+	"gojango.yaml",
+}
+
+// NotInProjectError is returned by project-scoped commands run outside a
+// Gojango project
+type NotInProjectError struct {
+	Dir string
+}
+
+func (e *NotInProjectError) Error() string {
+	return fmt.Sprintf("not in a Gojango project directory: no manage.go, config/settings.star or gojango.yaml found in %s or any parent directory\n"+
+		"Run this command from inside a Gojango project, or create one with 'gojango new <project-name>'", e.Dir)
+}
+
+// RequireRoot locates the root of the Gojango project containing the
+// working directory by walking up until a project marker is found
+func RequireRoot() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	dir := cwd
+	for {
+		if isProjectRoot(dir) {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", &NotInProjectError{Dir: cwd}
+		}
+		dir = parent
+	}
+}
+
+// isProjectRoot reports whether dir contains a project marker
+func isProjectRoot(dir string) bool {
+	for _, marker := range projectMarkers {
+		if info, err := os.Stat(filepath.Join(dir, marker)); err == nil && !info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// EnterRoot is a PreRunE for project-scoped commands. It changes to the
+// project root so commands run from a subdirectory resolve project paths
+// such as go.mod and apps/ correctly.
+func EnterRoot(cmd *cobra.Command, args []string) error {
+	projectDir, err := RequireRoot()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chdir(projectDir); err != nil {
+		return fmt.Errorf("failed to change to project root %s: %w", projectDir, err)
+	}
+	return nil
+}
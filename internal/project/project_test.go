@@ -0,0 +1,61 @@
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newTestProject creates a project root containing the given marker file
+func newTestProject(t *testing.T, marker string) string {
+	t.Helper()
+
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to resolve temp dir: %v", err)
+	}
+
+	path := filepath.Join(root, marker)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", marker, err)
+	}
+	return root
+}
+
+func TestRequireRoot(t *testing.T) {
+	for _, marker := range projectMarkers {
+		root := newTestProject(t, marker)
+		nested := filepath.Join(root, "apps", "blog")
+		if err := os.MkdirAll(nested, 0755); err != nil {
+			t.Fatalf("Failed to create nested dir: %v", err)
+		}
+
+		t.Chdir(nested)
+		projectDir, err := RequireRoot()
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", marker, err)
+			continue
+		}
+		if projectDir != root {
+			t.Errorf("%s: expected project root %s, got %s", marker, root, projectDir)
+		}
+	}
+}
+
+func TestRequireRootOutsideProject(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	_, err := RequireRoot()
+	var notInProject *NotInProjectError
+	if !errors.As(err, &notInProject) {
+		t.Fatalf("Expected NotInProjectError, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "gojango new") {
+		t.Errorf("Expected guidance in the error message, got: %v", err)
+	}
+}
@@ -1,13 +1,61 @@
 package gojango
 
 import (
+	"fmt"
+	"io/fs"
+	"log"
+	"time"
+
 	"github.com/epuerta9/gojango/pkg/gojango/admin"
 )
 
-// SetupAdmin sets up the admin interface for the application
-func (app *Application) SetupAdmin() {
+// SetupAdmin sets up the admin interface for the application. It returns
+// an error when the admin embedded with WithEmbeddedAdmin can't be served.
+func (app *Application) SetupAdmin() error {
+	// Apply pagination defaults from settings
+	if app.settings != nil {
+		admin.DefaultSite.SetPaginationDefaults(
+			app.settings.GetInt("ADMIN_LIST_PER_PAGE", admin.DefaultListPerPage),
+			app.settings.GetInt("ADMIN_MAX_PAGE_SIZE", admin.DefaultMaxPageSize),
+		)
+		admin.DefaultSite.SetOmitEmptyFields(app.settings.GetBool("OMIT_EMPTY_FIELDS", false))
+		
+		// Date hierarchies bucket dates in TIME_ZONE, local time when unset
+		if name := app.settings.GetString("TIME_ZONE", ""); name != "" {
+			if loc, err := time.LoadLocation(name); err != nil {
+				log.Printf("Ignoring invalid TIME_ZONE %q: %v", name, err)
+			} else {
+				admin.DefaultSite.SetTimeZone(loc)
+			}
+		}
+		
+		// Files uploaded in the admin are saved in MEDIA_ROOT
+		admin.DefaultSite.SetMedia(app.mediaSettings())
+		
+		// Dashboard counts are cached when CACHES is configured
+		if app.settings.Get("CACHES") != nil {
+			if c, err := app.Cache(); err != nil {
+				log.Printf("Not caching admin dashboard counts: %v", err)
+			} else {
+				admin.DefaultSite.SetCache(c)
+			}
+		}
+	}
+	
+	// Serve the React admin embedded with WithEmbeddedAdmin
+	if app.adminFS != nil {
+		assets, err := fs.Sub(app.adminFS, cleanPrefix(app.adminPrefix))
+		if err != nil {
+			return fmt.Errorf("invalid embedded admin prefix: %w", err)
+		}
+		if err := admin.DefaultSite.SetAssets(assets); err != nil {
+			return err
+		}
+	}
+	
 	// Setup admin routes with the Gin router
 	admin.DefaultSite.SetupRoutes(app.GetRouter())
+	return nil
 }
 
 // RegisterAdminModel registers a model with the admin interface
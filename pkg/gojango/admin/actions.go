@@ -1,21 +1,28 @@
 package admin
 
 import (
+	"encoding/csv"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 
+	"github.com/epuerta9/gojango/pkg/gojango/render"
 	"github.com/gin-gonic/gin"
 )
 
 // ActionRegistry manages all available admin actions
 type ActionRegistry struct {
-	actions map[string]Action
+	actions  map[string]Action
+	extended map[string]ExtendedAction
 }
 
 // NewActionRegistry creates a new action registry
 func NewActionRegistry() *ActionRegistry {
 	registry := &ActionRegistry{
-		actions: make(map[string]Action),
+		actions:  make(map[string]Action),
+		extended: make(map[string]ExtendedAction),
 	}
 	
 	// Register default actions
@@ -41,6 +48,23 @@ func (ar *ActionRegistry) Register(name, description string, handler func(ctx *g
 	}
 }
 
+// RegisterExtended adds an action along with its confirmation settings,
+// permissions and presentation
+func (ar *ActionRegistry) RegisterExtended(action ExtendedAction) {
+	ar.actions[action.Name] = action.Action
+	ar.extended[action.Name] = action
+}
+
+// GetExtended retrieves an action with its extended settings. Actions
+// registered with Register have none.
+func (ar *ActionRegistry) GetExtended(name string) (ExtendedAction, bool) {
+	if extended, exists := ar.extended[name]; exists {
+		return extended, true
+	}
+	action, exists := ar.actions[name]
+	return ExtendedAction{Action: action}, exists
+}
+
 // Get retrieves an action by name
 func (ar *ActionRegistry) Get(name string) (Action, bool) {
 	action, exists := ar.actions[name]
@@ -58,12 +82,21 @@ func (ar *ActionRegistry) GetAll() map[string]Action {
 
 // registerDefaultActions registers the default admin actions
 func (ar *ActionRegistry) registerDefaultActions() {
-	// Delete selected action
-	ar.Register("delete_selected", "Delete selected items", DeleteSelectedAction)
+	// Delete selected action, confirmed before anything is deleted
+	ar.RegisterExtended(ExtendedAction{
+		Action: Action{
+			Name:        "delete_selected",
+			Description: "Delete selected items",
+			Handler:     DeleteSelectedAction,
+		},
+		RequiresConfirmation: true,
+		ConfirmationMessage:  "Are you sure you want to delete the selected items? This cannot be undone.",
+	})
 	
 	// Export actions
 	ar.Register("export_csv", "Export selected items as CSV", ExportCSVAction)
 	ar.Register("export_json", "Export selected items as JSON", ExportJSONAction)
+	ar.Register("export_xlsx", "Export selected items as Excel", ExportXLSXAction)
 	
 	// Status change actions (common for many models)
 	ar.Register("mark_active", "Mark selected items as active", MarkActiveAction)
@@ -78,68 +111,115 @@ func DeleteSelectedAction(ctx *gin.Context, objects []interface{}) (interface{},
 		return gin.H{"message": "No items selected", "count": 0}, nil
 	}
 	
-	// Extract model admin from context (this would be set by the handler)
-	modelAdminInterface, exists := ctx.Get("model_admin")
-	if !exists {
-		return nil, fmt.Errorf("model admin not found in context")
+	count, errors, err := forEachSelected(ctx, objects, "delete", func(ctx *gin.Context, modelAdmin *ModelAdmin, id string) error {
+		return modelAdmin.DeleteObject(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkActionResult(fmt.Sprintf("Successfully deleted %d items", count), count, errors), nil
+}
+
+// RestoreSelectedAction restores the selected soft-deleted objects. It is
+// added to models by SetSoftDelete.
+func RestoreSelectedAction(ctx *gin.Context, objects []interface{}) (interface{}, error) {
+	if len(objects) == 0 {
+		return gin.H{"message": "No items selected", "count": 0}, nil
 	}
 	
-	modelAdmin, ok := modelAdminInterface.(*ModelAdmin)
-	if !ok {
-		return nil, fmt.Errorf("invalid model admin type")
+	count, errors, err := forEachSelected(ctx, objects, "restore", func(ctx *gin.Context, modelAdmin *ModelAdmin, id string) error {
+		return modelAdmin.RestoreObject(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkActionResult(fmt.Sprintf("Successfully restored %d items", count), count, errors), nil
+}
+
+// ExportCSVAction streams the selected objects to the response as CSV. The
+// columns follow the model's list_display when the model admin is available.
+func ExportCSVAction(ctx *gin.Context, objects []interface{}) (interface{}, error) {
+	if len(objects) == 0 {
+		return gin.H{"message": "No items selected for export", "count": 0}, nil
 	}
 	
-	count := 0
-	errors := []string{}
+	modelAdmin := exportModelAdmin(ctx)
+	columns := exportColumns(modelAdmin, objects)
+	
+	// Set headers for file download
+	ctx.Header("Content-Type", render.ContentType("text/csv"))
+	ctx.Header("Content-Disposition", "attachment; filename=\"export.csv\"")
+	ctx.Status(http.StatusOK)
 	
+	writer := csv.NewWriter(ctx.Writer)
+	if err := writer.Write(columns); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	
+	record := make([]string, len(columns))
 	for _, obj := range objects {
-		// Extract ID from object
-		id, err := extractObjectID(obj)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to extract ID from object: %v", err))
-			continue
+		for i, value := range exportRow(modelAdmin, obj, columns) {
+			record[i] = formatExportValue(value)
 		}
-		
-		if err := modelAdmin.DeleteObject(ctx, id); err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to delete object %s: %v", id, err))
-			continue
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
 		}
-		count++
-	}
-	
-	result := gin.H{
-		"message": fmt.Sprintf("Successfully deleted %d items", count),
-		"count":   count,
 	}
 	
-	if len(errors) > 0 {
-		result["errors"] = errors
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
 	}
 	
-	return result, nil
+	return gin.H{
+		"message": fmt.Sprintf("Exported %d items as CSV", len(objects)),
+		"count":   len(objects),
+		"type":    "csv",
+	}, nil
 }
 
-// ExportCSVAction exports selected objects as CSV
-func ExportCSVAction(ctx *gin.Context, objects []interface{}) (interface{}, error) {
+// ExportXLSXAction streams the selected objects to the response as an Excel
+// workbook with the same columns as ExportCSVAction
+func ExportXLSXAction(ctx *gin.Context, objects []interface{}) (interface{}, error) {
 	if len(objects) == 0 {
 		return gin.H{"message": "No items selected for export", "count": 0}, nil
 	}
 	
-	// Generate CSV content
-	csvContent, err := generateCSV(objects)
+	modelAdmin := exportModelAdmin(ctx)
+	columns := exportColumns(modelAdmin, objects)
+	
+	// Set headers for file download
+	ctx.Header("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	ctx.Header("Content-Disposition", "attachment; filename=\"export.xlsx\"")
+	ctx.Status(http.StatusOK)
+	
+	writer, err := newXLSXWriter(ctx.Writer)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate CSV: %w", err)
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
 	}
 	
-	// Set headers for file download
-	ctx.Header("Content-Type", "text/csv")
-	ctx.Header("Content-Disposition", "attachment; filename=\"export.csv\"")
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+	if err := writer.WriteRow(header); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX header: %w", err)
+	}
+	
+	for _, obj := range objects {
+		if err := writer.WriteRow(exportRow(modelAdmin, obj, columns)); err != nil {
+			return nil, fmt.Errorf("failed to write XLSX row: %w", err)
+		}
+	}
+	
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write XLSX: %w", err)
+	}
 	
 	return gin.H{
-		"message": fmt.Sprintf("Exported %d items as CSV", len(objects)),
+		"message": fmt.Sprintf("Exported %d items as XLSX", len(objects)),
 		"count":   len(objects),
-		"data":    csvContent,
-		"type":    "csv",
+		"type":    "xlsx",
 	}, nil
 }
 
@@ -173,62 +253,91 @@ func MarkInactiveAction(ctx *gin.Context, objects []interface{}) (interface{}, e
 
 // Helper functions
 
-// extractObjectID extracts the ID from an object
+// extractObjectID extracts the ID from a map, or from a struct or struct
+// pointer by its ID field or the field tagged json:"id"
 func extractObjectID(obj interface{}) (string, error) {
-	switch o := obj.(type) {
-	case map[string]interface{}:
+	if o, ok := obj.(map[string]interface{}); ok {
 		if id, exists := o["id"]; exists {
 			return fmt.Sprintf("%v", id), nil
 		}
 		return "", fmt.Errorf("no id field found")
-	default:
-		// Use reflection to find ID field
-		// This would be more complex in a real implementation
+	}
+	
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", fmt.Errorf("cannot extract id from nil %T", obj)
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
 		return "", fmt.Errorf("unsupported object type: %T", obj)
 	}
+	
+	id, ok := structIDField(v)
+	if !ok {
+		return "", fmt.Errorf("no id field found on %T", obj)
+	}
+	return formatObjectID(id)
 }
 
-// generateCSV generates CSV content from objects
-func generateCSV(objects []interface{}) (string, error) {
-	if len(objects) == 0 {
-		return "", nil
+// structIDField finds a struct's ID field, preferring a field named ID over
+// one tagged json:"id"
+func structIDField(v reflect.Value) (reflect.Value, bool) {
+	t := v.Type()
+	if field, ok := t.FieldByName("ID"); ok && field.IsExported() {
+		return v.FieldByIndex(field.Index), true
 	}
 	
-	// Get field names from first object
-	var fieldNames []string
-	if firstObj, ok := objects[0].(map[string]interface{}); ok {
-		for key := range firstObj {
-			fieldNames = append(fieldNames, key)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && strings.Split(field.Tag.Get("json"), ",")[0] == "id" {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// formatObjectID stringifies integer, string and UUID-like ID values. Types
+// implementing fmt.Stringer, such as uuid.UUID, use their String method.
+func formatObjectID(id reflect.Value) (string, error) {
+	for id.Kind() == reflect.Ptr {
+		if id.IsNil() {
+			return "", fmt.Errorf("id is nil")
 		}
+		id = id.Elem()
 	}
 	
-	// Build CSV content
-	csv := ""
+	if stringer, ok := id.Interface().(fmt.Stringer); ok {
+		return stringer.String(), nil
+	}
 	
-	// Header row
-	for i, field := range fieldNames {
-		if i > 0 {
-			csv += ","
+	switch id.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(id.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(id.Uint(), 10), nil
+	case reflect.String:
+		return id.String(), nil
+	case reflect.Array:
+		// A 16-byte array without a String method is formatted as a UUID
+		if id.Len() == 16 && id.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, 16)
+			reflect.Copy(reflect.ValueOf(b), id)
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
 		}
-		csv += fmt.Sprintf("\"%s\"", field)
 	}
-	csv += "\n"
-	
-	// Data rows
-	for _, obj := range objects {
-		if objMap, ok := obj.(map[string]interface{}); ok {
-			for i, field := range fieldNames {
-				if i > 0 {
-					csv += ","
-				}
-				value := objMap[field]
-				csv += fmt.Sprintf("\"%v\"", value)
-			}
-			csv += "\n"
+	return "", fmt.Errorf("unsupported id type: %s", id.Type())
+}
+
+// exportModelAdmin returns the model admin set by ExecuteBulkAction, if any
+func exportModelAdmin(ctx *gin.Context) *ModelAdmin {
+	if value, exists := ctx.Get("model_admin"); exists {
+		if modelAdmin, ok := value.(*ModelAdmin); ok {
+			return modelAdmin
 		}
 	}
-	
-	return csv, nil
+	return nil
 }
 
 // updateFieldAction is a helper for actions that update a field
@@ -237,54 +346,73 @@ func updateFieldAction(ctx *gin.Context, objects []interface{}, fieldName string
 		return gin.H{"message": fmt.Sprintf("No items selected to mark as %s", statusName), "count": 0}, nil
 	}
 	
-	// Extract model admin from context
+	count, errors, err := forEachSelected(ctx, objects, "update", func(ctx *gin.Context, modelAdmin *ModelAdmin, id string) error {
+		_, err := modelAdmin.ChangeObject(ctx, id, map[string]interface{}{fieldName: fieldValue})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bulkActionResult(fmt.Sprintf("Successfully marked %d items as %s", count, statusName), count, errors), nil
+}
+
+// forEachSelected applies fn to the ID of each selected object with the
+// model admin the handler stored in ctx. fn gets a copy of ctx, since
+// objects are processed concurrently, which a gin.Context doesn't allow.
+// It returns the number of objects fn succeeded for and the errors of the
+// others, described with verb.
+func forEachSelected(ctx *gin.Context, objects []interface{}, verb string, fn func(ctx *gin.Context, modelAdmin *ModelAdmin, id string) error) (int, []string, error) {
 	modelAdminInterface, exists := ctx.Get("model_admin")
 	if !exists {
-		return nil, fmt.Errorf("model admin not found in context")
+		return 0, nil, fmt.Errorf("model admin not found in context")
 	}
 	
 	modelAdmin, ok := modelAdminInterface.(*ModelAdmin)
 	if !ok {
-		return nil, fmt.Errorf("invalid model admin type")
+		return 0, nil, fmt.Errorf("invalid model admin type")
 	}
 	
-	count := 0
-	errors := []string{}
-	
-	for _, obj := range objects {
-		// Extract ID from object
+	bulkCtx := ctx.Copy()
+	results := modelAdmin.ForEachObject(objects, func(obj interface{}) error {
 		id, err := extractObjectID(obj)
 		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to extract ID from object: %v", err))
-			continue
-		}
-		
-		// Create mock request with form data
-		request := &http.Request{
-			Method: "POST",
-			Header: make(http.Header),
+			return fmt.Errorf("failed to extract ID from object: %w", err)
 		}
-		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-		
-		// Update object
-		_, err = modelAdmin.UpdateObject(ctx, id, request)
-		if err != nil {
-			errors = append(errors, fmt.Sprintf("Failed to update object %s: %v", id, err))
-			continue
+		if err := fn(bulkCtx, modelAdmin, id); err != nil {
+			return fmt.Errorf("failed to %s object %s: %w", verb, id, err)
 		}
-		count++
-	}
-	
+		return nil
+	})
+	count, errors := summarizeBulkResults(results)
+	return count, errors, nil
+}
+
+// bulkActionResult is the response of a bulk action, listing the errors of
+// objects it failed for
+func bulkActionResult(message string, count int, errors []string) gin.H {
 	result := gin.H{
-		"message": fmt.Sprintf("Successfully marked %d items as %s", count, statusName),
+		"message": message,
 		"count":   count,
 	}
-	
 	if len(errors) > 0 {
 		result["errors"] = errors
 	}
-	
-	return result, nil
+	return result
+}
+
+// summarizeBulkResults counts successful objects and collects error messages
+// in object order
+func summarizeBulkResults(results []error) (int, []string) {
+	count := 0
+	errors := []string{}
+	for _, err := range results {
+		if err != nil {
+			errors = append(errors, err.Error())
+			continue
+		}
+		count++
+	}
+	return count, errors
 }
 
 // ActionContext provides additional context for actions
@@ -305,6 +433,44 @@ type ExtendedAction struct {
 	CssClass            string
 }
 
+// DefaultConfirmationMessage is shown for actions requiring confirmation
+// that do not set their own message
+const DefaultConfirmationMessage = "Are you sure you want to perform this action?"
+
+// PendingConfirmation describes an action that was not executed because it
+// requires confirmation
+type PendingConfirmation struct {
+	Action  string
+	Message string
+	Count   int
+}
+
+// pendingConfirmation reports whether a bulk action request must be
+// confirmed before it runs. Requests confirm with confirmed=true.
+func (ma *ModelAdmin) pendingConfirmation(request *http.Request) (*PendingConfirmation, bool) {
+	actionName := request.FormValue("action")
+	action, exists := ma.getExtendedAction(actionName)
+	if !exists || !action.RequiresConfirmation {
+		return nil, false
+	}
+	
+	if confirmed, _ := strconv.ParseBool(request.FormValue("confirmed")); confirmed {
+		return nil, false
+	}
+	
+	// Requests without a selection fail in ExecuteBulkAction instead
+	count := len(request.Form["_selected_action"])
+	if count == 0 {
+		return nil, false
+	}
+	
+	message := action.ConfirmationMessage
+	if message == "" {
+		message = DefaultConfirmationMessage
+	}
+	return &PendingConfirmation{Action: actionName, Message: message, Count: count}, true
+}
+
 // ActionResult represents the result of an action execution
 type ActionResult struct {
 	Success      bool          `json:"success"`
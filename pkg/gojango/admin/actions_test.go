@@ -0,0 +1,283 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type intIDObject struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type stringIDObject struct {
+	Slug string `json:"id"`
+	Name string `json:"name"`
+}
+
+type int64IDObject struct {
+	ID int64 `json:"id,omitempty"`
+}
+
+// testUUID mirrors uuid.UUID, a byte array with a String method
+type testUUID [16]byte
+
+func (u testUUID) String() string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+type uuidIDObject struct {
+	ID testUUID `json:"id,omitempty"`
+}
+
+type rawUUIDObject struct {
+	ID [16]byte `json:"id,omitempty"`
+}
+
+func TestExtractObjectID(t *testing.T) {
+	intObj := intIDObject{ID: 42, Name: "answer"}
+	strObj := stringIDObject{Slug: "hello-world", Name: "Hello"}
+	uuid := testUUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	testCases := []struct {
+		name     string
+		obj      interface{}
+		expected string
+	}{
+		{"map", map[string]interface{}{"id": 7}, "7"},
+		{"int ID struct", intObj, "42"},
+		{"int ID pointer", &intObj, "42"},
+		{"string ID struct", strObj, "hello-world"},
+		{"string ID pointer", &strObj, "hello-world"},
+		{"int64 ID", &int64IDObject{ID: 9007199254740993}, "9007199254740993"},
+		{"UUID ID", &uuidIDObject{ID: uuid}, "123e4567-e89b-12d3-a456-426614174000"},
+		{"raw UUID ID", &rawUUIDObject{ID: uuid}, "123e4567-e89b-12d3-a456-426614174000"},
+		{"test user", &TestUser{ID: 3}, "3"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := extractObjectID(tc.obj)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, id)
+		})
+	}
+}
+
+func TestExtractObjectIDErrors(t *testing.T) {
+	var nilObj *intIDObject
+
+	testCases := []struct {
+		name string
+		obj  interface{}
+	}{
+		{"map without id", map[string]interface{}{"name": "x"}},
+		{"nil pointer", nilObj},
+		{"struct without id", struct{ Name string }{"x"}},
+		{"non-struct", 42},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := extractObjectID(tc.obj)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func postBulkAction(router *gin.Engine, path string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBulkDeleteRequiresConfirmation(t *testing.T) {
+	site := NewSite("test")
+	mockDB := newMockDBInterface()
+	modelName := getModelName(&TestUser{})
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john"},
+		map[string]interface{}{"id": "2", "username": "jane"},
+		map[string]interface{}{"id": "3", "username": "bob"},
+	}
+	admin := NewModelAdmin(&TestUser{}).SetBulkConcurrency(1)
+	admin.SetDatabaseInterface(mockDB)
+	admin.AddAction("delete_selected", "Delete selected items", DeleteSelectedAction)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/admin/:app/:model/action/", site.handleBulkAction)
+	path := "/admin/" + strings.Replace(modelName, ".", "/", 1) + "/action/"
+	form := url.Values{"action": {"delete_selected"}, "_selected_action": {"1", "2"}}
+
+	// The first request only asks for confirmation
+	w := postBulkAction(router, path, form)
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var pending map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &pending))
+	assert.Equal(t, true, pending["requires_confirmation"])
+	assert.Equal(t, "delete_selected", pending["action"])
+	assert.Equal(t, float64(2), pending["count"])
+	assert.Contains(t, pending["message"], "Are you sure")
+	assert.Len(t, mockDB.objects[modelName], 3, "nothing is deleted before confirmation")
+
+	// Confirming runs the action
+	form.Set("confirmed", "true")
+	w = postBulkAction(router, path, form)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Len(t, mockDB.objects[modelName], 1)
+	assert.Equal(t, "3", mockDB.objects[modelName][0].(map[string]interface{})["id"])
+}
+
+func TestMarkActiveActionUpdatesField(t *testing.T) {
+	site := NewSite("test")
+	admin := newValidationAdmin(FieldSchema{Name: "active", Type: "boolean"})
+	mockDB := admin.dbInterface.(*schemaDBInterface).mockDBInterface
+	modelName := getModelName(&TestUser{})
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "active": false},
+		map[string]interface{}{"id": "2", "active": false},
+		map[string]interface{}{"id": "3", "active": false},
+	}
+	admin.AddAction("mark_active", "Mark selected as active", MarkActiveAction)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/admin/:app/:model/action/", site.handleBulkAction)
+	path := "/admin/" + strings.Replace(modelName, ".", "/", 1) + "/action/"
+
+	w := postBulkAction(router, path, url.Values{"action": {"mark_active"}, "_selected_action": {"1", "3"}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Equal(t, float64(2), result["count"], w.Body.String())
+	for i, expected := range []bool{true, false, true} {
+		assert.Equal(t, expected, mockDB.objects[modelName][i].(map[string]interface{})["active"], "object %d", i+1)
+	}
+}
+
+// softDeleteDBInterface is a mock database that honours deleted_at__isnull
+// filters
+type softDeleteDBInterface struct {
+	*mockDBInterface
+}
+
+func (m *softDeleteDBInterface) GetAll(ctx context.Context, model interface{}, filters map[string]interface{}, ordering []string, limit, offset int) ([]interface{}, int, error) {
+	var matched []interface{}
+	for _, obj := range m.objects[getModelName(model)] {
+		if filters["deleted_at__isnull"] == true && obj.(map[string]interface{})["deleted_at"] != nil {
+			continue
+		}
+		matched = append(matched, obj)
+	}
+	return matched, len(matched), nil
+}
+
+func TestSoftDelete(t *testing.T) {
+	site := NewSite("test")
+	mockDB := &softDeleteDBInterface{mockDBInterface: newMockDBInterface()}
+	modelName := getModelName(&TestUser{})
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john", "deleted_at": nil},
+		map[string]interface{}{"id": "2", "username": "jane", "deleted_at": nil},
+	}
+	admin := NewModelAdmin(&TestUser{}).SetSoftDelete("deleted_at")
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	assert.Equal(t, "deleted_at", admin.SoftDeleteField())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/admin/:app/:model/:id/delete/", site.handleModelDelete)
+	router.POST("/admin/:app/:model/action/", site.handleBulkAction)
+	modelPath := "/admin/" + strings.Replace(modelName, ".", "/", 1)
+
+	listIDs := func(query url.Values) []string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		listData, err := admin.GetListData(c, query)
+		require.NoError(t, err)
+		var ids []string
+		for _, obj := range listData.Objects {
+			ids = append(ids, obj.(map[string]interface{})["id"].(string))
+		}
+		return ids
+	}
+
+	// Deleting marks the row instead of removing it, hiding it from the list
+	w := postBulkAction(router, modelPath+"/1/delete/", url.Values{})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Len(t, mockDB.objects[modelName], 2)
+	assert.NotNil(t, mockDB.objects[modelName][0].(map[string]interface{})["deleted_at"])
+	assert.Equal(t, []string{"2"}, listIDs(url.Values{}))
+
+	// show_deleted reveals it
+	assert.Equal(t, []string{"1", "2"}, listIDs(url.Values{"show_deleted": {"1"}}))
+
+	// The restore action clears the field
+	w = postBulkAction(router, modelPath+"/action/", url.Values{"action": {"restore"}, "_selected_action": {"1"}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Nil(t, mockDB.objects[modelName][0].(map[string]interface{})["deleted_at"])
+	assert.Equal(t, []string{"1", "2"}, listIDs(url.Values{}))
+
+	// The gRPC service soft-deletes too
+	parts := strings.SplitN(modelName, ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.DeleteObject(context.Background(), connect.NewRequest(&adminpb.DeleteObjectRequest{App: parts[0], Model: parts[1], Id: "2"}))
+	require.NoError(t, err)
+	assert.True(t, resp.Msg.Success)
+	assert.Len(t, mockDB.objects[modelName], 2)
+	assert.Equal(t, []string{"1"}, listIDs(url.Values{}))
+}
+
+func TestSoftDeleteDisabled(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{}).SetSoftDelete("deleted_at").SetSoftDelete("")
+	_, hasRestore := admin.actions["restore"]
+	assert.False(t, hasRestore)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	assert.Error(t, admin.RestoreObject(c, "1"))
+}
+
+func TestActionsWithoutConfirmationRunImmediately(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+	admin.AddAction("export_json", "Export selected items as JSON", ExportJSONAction)
+	admin.AddExtendedAction(ExtendedAction{
+		Action:               Action{Name: "archive", Description: "Archive", Handler: ExportJSONAction},
+		RequiresConfirmation: true,
+	})
+
+	request := func(action string) *http.Request {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(url.Values{
+			"action":           {action},
+			"_selected_action": {"1"},
+		}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req
+	}
+
+	_, pending := admin.pendingConfirmation(request("export_json"))
+	assert.False(t, pending)
+
+	confirmation, pending := admin.pendingConfirmation(request("archive"))
+	require.True(t, pending)
+	assert.Equal(t, DefaultConfirmationMessage, confirmation.Message)
+	assert.Equal(t, 1, confirmation.Count)
+}
@@ -1,10 +1,26 @@
 package admin
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/epuerta9/gojango/pkg/gojango/admin/proto/protoconnect"
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,25 +56,25 @@ func newMockDBInterface() *mockDBInterface {
 func (m *mockDBInterface) GetAll(ctx context.Context, model interface{}, filters map[string]interface{}, ordering []string, limit, offset int) ([]interface{}, int, error) {
 	modelName := getModelName(model)
 	objects := m.objects[modelName]
-	
+
 	total := len(objects)
 	start := offset
 	end := offset + limit
-	
+
 	if start > total {
 		start = total
 	}
 	if end > total {
 		end = total
 	}
-	
+
 	return objects[start:end], total, nil
 }
 
 func (m *mockDBInterface) GetByID(ctx context.Context, model interface{}, id interface{}) (interface{}, error) {
 	modelName := getModelName(model)
 	objects := m.objects[modelName]
-	
+
 	for _, obj := range objects {
 		if objMap, ok := obj.(map[string]interface{}); ok {
 			if objMap["id"] == id {
@@ -66,18 +82,18 @@ func (m *mockDBInterface) GetByID(ctx context.Context, model interface{}, id int
 			}
 		}
 	}
-	
+
 	return nil, nil
 }
 
 func (m *mockDBInterface) Create(ctx context.Context, model interface{}, data map[string]interface{}) (interface{}, error) {
 	modelName := getModelName(model)
-	
+
 	// Add ID and timestamps
 	data["id"] = len(m.objects[modelName]) + 1
 	data["created_at"] = time.Now()
 	data["updated_at"] = time.Now()
-	
+
 	m.objects[modelName] = append(m.objects[modelName], data)
 	return data, nil
 }
@@ -85,7 +101,7 @@ func (m *mockDBInterface) Create(ctx context.Context, model interface{}, data ma
 func (m *mockDBInterface) Update(ctx context.Context, model interface{}, id interface{}, data map[string]interface{}) (interface{}, error) {
 	modelName := getModelName(model)
 	objects := m.objects[modelName]
-	
+
 	for i, obj := range objects {
 		if objMap, ok := obj.(map[string]interface{}); ok {
 			if objMap["id"] == id {
@@ -99,14 +115,14 @@ func (m *mockDBInterface) Update(ctx context.Context, model interface{}, id inte
 			}
 		}
 	}
-	
+
 	return nil, nil
 }
 
 func (m *mockDBInterface) Delete(ctx context.Context, model interface{}, id interface{}) error {
 	modelName := getModelName(model)
 	objects := m.objects[modelName]
-	
+
 	for i, obj := range objects {
 		if objMap, ok := obj.(map[string]interface{}); ok {
 			if objMap["id"] == id {
@@ -116,7 +132,7 @@ func (m *mockDBInterface) Delete(ctx context.Context, model interface{}, id inte
 			}
 		}
 	}
-	
+
 	return nil
 }
 
@@ -135,23 +151,54 @@ func (m *mockDBInterface) GetSchema(model interface{}) (*ModelSchema, error) {
 
 func TestSiteCreation(t *testing.T) {
 	site := NewSite("test")
-	
+
 	assert.Equal(t, "test", site.name)
 	assert.Equal(t, "Gojango Administration", site.headerTitle)
 	assert.Equal(t, "Site Administration", site.indexTitle)
 	assert.Empty(t, site.models)
 }
 
+func TestSiteEmbeddedAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	require.NoError(t, site.SetAssets(fstest.MapFS{
+		"index.html":      {Data: []byte("<div id=\"root\"></div>")},
+		"assets/index.js": {Data: []byte("render()")},
+	}))
+	router := gin.New()
+	site.SetupRoutes(router)
+
+	for path, expected := range map[string]string{
+		"/admin/":                "<div id=\"root\"></div>",
+		"/admin/dashboard":       "<div id=\"root\"></div>",
+		"/admin/assets/index.js": "render()",
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, path)
+		assert.Equal(t, expected, w.Body.String(), path)
+	}
+}
+
+func TestSiteAssetsWithoutIndex(t *testing.T) {
+	site := NewSite("test")
+	err := site.SetAssets(fstest.MapFS{"assets/index.js": {Data: []byte("render()")}})
+	assert.Error(t, err)
+	assert.Nil(t, site.assets, "invalid assets should not be served")
+}
+
 func TestModelRegistration(t *testing.T) {
 	site := NewSite("test")
-	
+
 	// Register model without admin config
 	err := site.Register(&TestUser{}, nil)
 	require.NoError(t, err)
-	
+
 	models := site.GetRegisteredModels()
 	assert.Contains(t, models, "main.testuser")
-	
+
 	// Get model admin
 	admin, exists := site.GetModelAdmin("main.testuser")
 	assert.True(t, exists)
@@ -166,7 +213,7 @@ func TestModelAdminConfiguration(t *testing.T) {
 		SetListFilter("is_active").
 		SetOrdering("-created_at").
 		SetListPerPage(25)
-	
+
 	assert.Equal(t, []string{"id", "username", "email"}, admin.listDisplay)
 	assert.Equal(t, []string{"username", "email"}, admin.searchFields)
 	assert.Equal(t, []string{"is_active"}, admin.listFilter)
@@ -176,7 +223,7 @@ func TestModelAdminConfiguration(t *testing.T) {
 
 func TestModelAdminActions(t *testing.T) {
 	admin := NewModelAdmin(&TestUser{})
-	
+
 	// Add custom action
 	actionCalled := false
 	admin.AddAction("test_action", "Test Action", func(ctx *gin.Context, objects []interface{}) (interface{}, error) {
@@ -186,22 +233,22 @@ func TestModelAdminActions(t *testing.T) {
 			"count":   len(objects),
 		}, nil
 	})
-	
+
 	// Check action was added
 	assert.Contains(t, admin.actions, "test_action")
 	assert.Equal(t, "Test Action", admin.actions["test_action"].Description)
-	
+
 	// Execute action
 	ctx := &gin.Context{}
 	objects := []interface{}{
 		map[string]interface{}{"id": 1},
 		map[string]interface{}{"id": 2},
 	}
-	
+
 	result, err := admin.actions["test_action"].Handler(ctx, objects)
 	require.NoError(t, err)
 	assert.True(t, actionCalled)
-	
+
 	resultMap := result.(map[string]interface{})
 	assert.Equal(t, "Action executed", resultMap["message"])
 	assert.Equal(t, 2, resultMap["count"])
@@ -211,33 +258,33 @@ func TestModelAdminWithDatabase(t *testing.T) {
 	admin := NewModelAdmin(&TestUser{})
 	mockDB := newMockDBInterface()
 	admin.SetDatabaseInterface(mockDB)
-	
+
 	// Add test data
 	testUsers := []interface{}{
 		map[string]interface{}{"id": 1, "username": "john", "email": "john@example.com", "is_active": true},
 		map[string]interface{}{"id": 2, "username": "jane", "email": "jane@example.com", "is_active": false},
 	}
 	mockDB.objects["main.testuser"] = testUsers
-	
+
 	ctx := context.Background()
-	
+
 	// Test GetAll
 	objects, total, err := mockDB.GetAll(ctx, &TestUser{}, nil, nil, 10, 0)
 	require.NoError(t, err)
 	assert.Equal(t, 2, total)
 	assert.Len(t, objects, 2)
-	
+
 	// Test GetByID
 	obj, err := mockDB.GetByID(ctx, &TestUser{}, 1)
 	require.NoError(t, err)
 	assert.NotNil(t, obj)
 	objMap := obj.(map[string]interface{})
 	assert.Equal(t, "john", objMap["username"])
-	
+
 	// Test Create
 	newUser := map[string]interface{}{
-		"username": "bob",
-		"email":    "bob@example.com",
+		"username":  "bob",
+		"email":     "bob@example.com",
 		"is_active": true,
 	}
 	created, err := mockDB.Create(ctx, &TestUser{}, newUser)
@@ -245,18 +292,18 @@ func TestModelAdminWithDatabase(t *testing.T) {
 	createdMap := created.(map[string]interface{})
 	assert.Equal(t, 3, createdMap["id"])
 	assert.Equal(t, "bob", createdMap["username"])
-	
+
 	// Test Update
 	updateData := map[string]interface{}{"username": "bob_updated"}
 	updated, err := mockDB.Update(ctx, &TestUser{}, 3, updateData)
 	require.NoError(t, err)
 	updatedMap := updated.(map[string]interface{})
 	assert.Equal(t, "bob_updated", updatedMap["username"])
-	
+
 	// Test Delete
 	err = mockDB.Delete(ctx, &TestUser{}, 3)
 	require.NoError(t, err)
-	
+
 	// Verify delete
 	obj, err = mockDB.GetByID(ctx, &TestUser{}, 3)
 	require.NoError(t, err)
@@ -265,9 +312,9 @@ func TestModelAdminWithDatabase(t *testing.T) {
 
 func TestAutoGenerateFilters(t *testing.T) {
 	filters := AutoGenerateFilters(&TestUser{})
-	
+
 	assert.NotEmpty(t, filters)
-	
+
 	// Check for expected filters
 	var boolFilter, textFilter Filter
 	for _, filter := range filters {
@@ -278,10 +325,10 @@ func TestAutoGenerateFilters(t *testing.T) {
 			textFilter = filter
 		}
 	}
-	
+
 	assert.NotNil(t, boolFilter, "Should have boolean filter for is_active")
 	assert.NotNil(t, textFilter, "Should have text filter for username")
-	
+
 	// Test boolean filter choices
 	if boolFilter != nil {
 		choices := boolFilter.Choices()
@@ -293,12 +340,12 @@ func TestAutoGenerateFilters(t *testing.T) {
 
 func TestDefaultActions(t *testing.T) {
 	registry := NewActionRegistry()
-	
+
 	// Check default actions are registered
 	assert.Contains(t, registry.actions, "delete_selected")
 	assert.Contains(t, registry.actions, "export_csv")
 	assert.Contains(t, registry.actions, "export_json")
-	
+
 	deleteAction, exists := registry.Get("delete_selected")
 	assert.True(t, exists)
 	assert.Equal(t, "Delete selected items", deleteAction.Description)
@@ -313,16 +360,491 @@ func TestModelNameExtraction(t *testing.T) {
 		{&TestPost{}, "main.testpost"},
 		{TestUser{}, "main.testuser"},
 	}
-	
+
 	for _, tc := range testCases {
 		name := getModelName(tc.model)
 		assert.Equal(t, tc.expected, name, "Model name extraction failed for %T", tc.model)
 	}
 }
 
+func TestExtractFormDataURLEncoded(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+
+	form := url.Values{}
+	form.Set("username", "john")
+	form.Set("email", "john@example.com")
+	req := httptest.NewRequest("POST", "/admin/main/testuser/add/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	data, err := admin.extractFormData(req)
+	require.NoError(t, err)
+	assert.Equal(t, "john", data["username"])
+	assert.Equal(t, "john@example.com", data["email"])
+}
+
+func TestExtractFormDataJSON(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+
+	body := `{"username": "jane", "is_active": true, "id": 7}`
+	req := httptest.NewRequest("POST", "/admin/api/main/testuser/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	data, err := admin.extractFormData(req)
+	require.NoError(t, err)
+	assert.Equal(t, "jane", data["username"])
+	assert.Equal(t, true, data["is_active"])
+	assert.Equal(t, float64(7), data["id"])
+
+	// Malformed JSON is reported instead of silently ignored
+	req = httptest.NewRequest("POST", "/admin/api/main/testuser/", strings.NewReader("{"))
+	req.Header.Set("Content-Type", "application/json")
+	_, err = admin.extractFormData(req)
+	assert.Error(t, err)
+}
+
+func TestExtractFormDataMultipart(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	require.NoError(t, writer.WriteField("username", "bob"))
+	part, err := writer.CreateFormFile("avatar", "avatar.png")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("fake image"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", "/admin/main/testuser/add/", &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	data, err := admin.extractFormData(req)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", data["username"])
+
+	file, ok := data["avatar"].(*multipart.FileHeader)
+	require.True(t, ok, "expected uploaded file to be a *multipart.FileHeader")
+	assert.Equal(t, "avatar.png", file.Filename)
+}
+
+// schemaDBInterface is a mock database interface with a configurable schema
+type schemaDBInterface struct {
+	*mockDBInterface
+	schema *ModelSchema
+}
+
+func (m *schemaDBInterface) GetSchema(model interface{}) (*ModelSchema, error) {
+	return m.schema, nil
+}
+
+func newValidationAdmin(fields ...FieldSchema) *ModelAdmin {
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(&schemaDBInterface{
+		mockDBInterface: newMockDBInterface(),
+		schema:          &ModelSchema{Fields: fields},
+	})
+	return admin
+}
+
+func requireValidationError(t *testing.T, err error) *ValidationError {
+	t.Helper()
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	return validationErr
+}
+
+func TestValidateDataRequired(t *testing.T) {
+	admin := newValidationAdmin(
+		FieldSchema{Name: "id", Type: "integer", Required: true},
+		FieldSchema{Name: "username", Type: "string", Required: true},
+		FieldSchema{Name: "role", Type: "string", Required: true, Default: "member"},
+	)
+
+	// Missing required field on create
+	err := admin.validateData(map[string]interface{}{}, true)
+	validationErr := requireValidationError(t, err)
+	assert.Equal(t, map[string]string{"username": "This field is required."}, validationErr.Errors)
+
+	// Blank values count as missing
+	err = admin.validateData(map[string]interface{}{"username": "  "}, true)
+	assert.Contains(t, requireValidationError(t, err).Errors, "username")
+
+	// Partial updates may omit required fields but not blank them
+	assert.NoError(t, admin.validateData(map[string]interface{}{}, false))
+	err = admin.validateData(map[string]interface{}{"username": ""}, false)
+	assert.Contains(t, requireValidationError(t, err).Errors, "username")
+
+	assert.NoError(t, admin.validateData(map[string]interface{}{"username": "john"}, true))
+}
+
+func TestValidateDataMaxLength(t *testing.T) {
+	maxLength := 5
+	admin := newValidationAdmin(FieldSchema{Name: "username", Type: "string", MaxLength: &maxLength})
+
+	assert.NoError(t, admin.validateData(map[string]interface{}{"username": "héllo"}, true))
+
+	err := admin.validateData(map[string]interface{}{"username": "toolong"}, true)
+	validationErr := requireValidationError(t, err)
+	assert.Equal(t, "Ensure this value has at most 5 characters (it has 7).", validationErr.Errors["username"])
+}
+
+func TestValidateDataChoices(t *testing.T) {
+	admin := newValidationAdmin(
+		FieldSchema{Name: "status", Type: "string", Choices: []Choice{
+			{Value: "draft", Display: "Draft"},
+			{Value: "published", Display: "Published"},
+		}},
+		FieldSchema{Name: "priority", Type: "integer", Choices: []Choice{
+			{Value: 1, Display: "Low"},
+			{Value: 2, Display: "High"},
+		}},
+	)
+
+	assert.NoError(t, admin.validateData(map[string]interface{}{"status": "draft", "priority": "2"}, true))
+
+	err := admin.validateData(map[string]interface{}{"status": "archived"}, true)
+	assert.Contains(t, requireValidationError(t, err).Errors, "status")
+
+	err = admin.validateData(map[string]interface{}{"priority": 3}, true)
+	assert.Contains(t, requireValidationError(t, err).Errors, "priority")
+}
+
+func TestValidateDataNumeric(t *testing.T) {
+	admin := newValidationAdmin(
+		FieldSchema{Name: "age", Type: "integer"},
+		FieldSchema{Name: "score", Type: "float"},
+	)
+
+	assert.NoError(t, admin.validateData(map[string]interface{}{"age": "42", "score": "9.5"}, true))
+	assert.NoError(t, admin.validateData(map[string]interface{}{"age": float64(42), "score": 9}, true))
+
+	err := admin.validateData(map[string]interface{}{"age": "forty", "score": "high"}, true)
+	validationErr := requireValidationError(t, err)
+	assert.Equal(t, "Enter a whole number.", validationErr.Errors["age"])
+	assert.Equal(t, "Enter a number.", validationErr.Errors["score"])
+
+	err = admin.validateData(map[string]interface{}{"age": 4.2}, true)
+	assert.Contains(t, requireValidationError(t, err).Errors, "age")
+}
+
+func TestValidateDataBoolean(t *testing.T) {
+	admin := newValidationAdmin(FieldSchema{Name: "is_active", Type: "boolean"})
+
+	for _, value := range []interface{}{true, false, "true", "on", "0"} {
+		assert.NoError(t, admin.validateData(map[string]interface{}{"is_active": value}, true), "value %v", value)
+	}
+
+	err := admin.validateData(map[string]interface{}{"is_active": "maybe"}, true)
+	assert.Equal(t, "Enter a valid boolean.", requireValidationError(t, err).Errors["is_active"])
+}
+
+func TestValidateDataDateTime(t *testing.T) {
+	admin := newValidationAdmin(FieldSchema{Name: "created_at", Type: "datetime"})
+
+	for _, value := range []interface{}{time.Now(), "2024-01-02T15:04:05Z", "2024-01-02T15:04", "2024-01-02"} {
+		assert.NoError(t, admin.validateData(map[string]interface{}{"created_at": value}, true), "value %v", value)
+	}
+
+	err := admin.validateData(map[string]interface{}{"created_at": "yesterday"}, true)
+	assert.Equal(t, "Enter a valid date/time.", requireValidationError(t, err).Errors["created_at"])
+}
+
+func TestCreateObjectValidationError(t *testing.T) {
+	admin := newValidationAdmin(FieldSchema{Name: "username", Type: "string", Required: true})
+
+	req := httptest.NewRequest("POST", "/admin/main/testuser/add/", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err := admin.CreateObject(&gin.Context{}, req)
+	validationErr := requireValidationError(t, err)
+	assert.Equal(t, "This field is required.", validationErr.Errors["username"])
+}
+
+// denyPermissions is a PermissionChecker that denies selected operations
+type denyPermissions struct {
+	AllowAllPermissions
+	denyAdd, denyChange, denyDelete bool
+	lastUser                        interface{}
+}
+
+func (p *denyPermissions) HasAddPermission(user interface{}, model string) bool {
+	p.lastUser = user
+	return !p.denyAdd
+}
+
+func (p *denyPermissions) HasChangePermission(user interface{}, obj interface{}) bool {
+	p.lastUser = user
+	return !p.denyChange
+}
+
+func (p *denyPermissions) HasDeletePermission(user interface{}, obj interface{}) bool {
+	p.lastUser = user
+	return !p.denyDelete
+}
+
+func newPermissionTestRouter(site *Site) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(UserContextKey, "alice")
+		c.Next()
+	})
+	router.POST("/admin/:app/:model/add/", site.handleModelCreate)
+	router.POST("/admin/:app/:model/:id/change/", site.handleModelUpdate)
+	router.POST("/admin/:app/:model/:id/delete/", site.handleModelDelete)
+	return router
+}
+
+func TestSitePermissionEnforcement(t *testing.T) {
+	site := NewSite("test")
+	admin := newValidationAdmin(FieldSchema{Name: "username", Type: "string"})
+	require.NoError(t, site.Register(&TestUser{}, admin))
+
+	checker := &denyPermissions{denyAdd: true, denyChange: true, denyDelete: true}
+	site.SetPermissionChecker(checker)
+	router := newPermissionTestRouter(site)
+
+	modelPath := "/admin/" + strings.Replace(getModelName(&TestUser{}), ".", "/", 1)
+	for _, path := range []string{modelPath + "/add/", modelPath + "/1/change/", modelPath + "/1/delete/"} {
+		req := httptest.NewRequest("POST", path, strings.NewReader(`{"username": "bob"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "expected %s to be forbidden", path)
+		assert.Equal(t, "alice", checker.lastUser)
+	}
+
+	// Restoring the default checker allows everything again
+	site.SetPermissionChecker(nil)
+	req := httptest.NewRequest("POST", modelPath+"/add/", strings.NewReader(`{"username": "bob"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestGRPCPermissionEnforcement(t *testing.T) {
+	site := NewSite("test")
+	require.NoError(t, site.Register(&TestUser{}, nil))
+	parts := strings.SplitN(getModelName(&TestUser{}), ".", 2)
+
+	checker := &denyPermissions{denyAdd: true, denyChange: true, denyDelete: true}
+	site.SetPermissionChecker(checker)
+	handler := NewAdminServiceHandler(site, nil)
+	ctx := ContextWithUser(context.Background(), "alice")
+
+	_, err := handler.CreateObject(ctx, connect.NewRequest(&adminpb.CreateObjectRequest{App: parts[0], Model: parts[1]}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	assert.Equal(t, "alice", checker.lastUser)
+
+	_, err = handler.UpdateObject(ctx, connect.NewRequest(&adminpb.UpdateObjectRequest{App: parts[0], Model: parts[1], Id: "1"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = handler.DeleteObject(ctx, connect.NewRequest(&adminpb.DeleteObjectRequest{App: parts[0], Model: parts[1], Id: "1"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = handler.DeleteObjects(ctx, connect.NewRequest(&adminpb.DeleteObjectsRequest{App: parts[0], Model: parts[1], Ids: []string{"1"}}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	// Unknown models are reported as not found before permissions are checked
+	_, err = handler.CreateObject(ctx, connect.NewRequest(&adminpb.CreateObjectRequest{App: "main", Model: "missing"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// With the default checker the request passes the permission check
+	site.SetPermissionChecker(nil)
+	_, err = handler.CreateObject(ctx, connect.NewRequest(&adminpb.CreateObjectRequest{App: parts[0], Model: parts[1]}))
+	assert.NotEqual(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestSitePaginationDefaults(t *testing.T) {
+	site := NewSite("test")
+
+	defaultAdmin := NewModelAdmin(&TestUser{})
+	overrideAdmin := NewModelAdmin(&TestPost{}).SetListPerPage(10).SetMaxShowAll(50)
+	require.NoError(t, site.Register(&TestUser{}, defaultAdmin))
+	require.NoError(t, site.Register(&TestPost{}, overrideAdmin))
+
+	assert.Equal(t, DefaultListPerPage, defaultAdmin.GetListPerPage())
+	assert.Equal(t, DefaultMaxPageSize, defaultAdmin.GetMaxShowAll())
+
+	// Global defaults apply to models that don't set their own page size
+	site.SetPaginationDefaults(25, 75)
+	assert.Equal(t, 25, defaultAdmin.GetListPerPage())
+	assert.Equal(t, 75, defaultAdmin.GetMaxShowAll())
+
+	// Per-model settings win
+	assert.Equal(t, 10, overrideAdmin.GetListPerPage())
+	assert.Equal(t, 50, overrideAdmin.GetMaxShowAll())
+
+	// Models registered after the defaults change pick them up too
+	site.Unregister(&TestUser{})
+	lateAdmin := NewModelAdmin(&TestUser{})
+	require.NoError(t, site.Register(&TestUser{}, lateAdmin))
+	assert.Equal(t, 25, lateAdmin.GetListPerPage())
+	assert.Equal(t, 75, lateAdmin.GetMaxShowAll())
+}
+
+func TestGRPCListObjectsMaxPageSize(t *testing.T) {
+	site := NewSite("test")
+	require.NoError(t, site.Register(&TestUser{}, nil))
+	site.SetPaginationDefaults(20, 30)
+	parts := strings.SplitN(getModelName(&TestUser{}), ".", 2)
+
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+		App: parts[0], Model: parts[1], PageSize: 1000,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(30), resp.Msg.PageSize)
+
+	resp, err = handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+		App: parts[0], Model: parts[1],
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int32(20), resp.Msg.PageSize)
+}
+
+// keysetDBInterface is a mock database that honours id__gt and id__lt
+// filters and id ordering, enough for cursor pagination
+type keysetDBInterface struct {
+	*mockDBInterface
+	offsetQueries int
+}
+
+func (m *keysetDBInterface) GetAll(ctx context.Context, model interface{}, filters map[string]interface{}, ordering []string, limit, offset int) ([]interface{}, int, error) {
+	if offset > 0 {
+		m.offsetQueries++
+	}
+	var matched []interface{}
+	for _, obj := range m.objects[getModelName(model)] {
+		id := obj.(*TestUser).ID
+		if gt, ok := filters["id__gt"]; ok && id <= atoiOrZero(gt) {
+			continue
+		}
+		if lt, ok := filters["id__lt"]; ok && id >= atoiOrZero(lt) {
+			continue
+		}
+		matched = append(matched, obj)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if len(ordering) > 0 && ordering[0] == "-id" {
+			return matched[i].(*TestUser).ID > matched[j].(*TestUser).ID
+		}
+		return matched[i].(*TestUser).ID < matched[j].(*TestUser).ID
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, len(matched), nil
+}
+
+func atoiOrZero(value interface{}) int {
+	n, _ := strconv.Atoi(fmt.Sprint(value))
+	return n
+}
+
+func TestGRPCListObjectsCursorPagination(t *testing.T) {
+	site := NewSite("test")
+	modelAdmin := NewModelAdmin(&TestUser{}).SetCursorPagination("-id")
+	require.NoError(t, site.Register(&TestUser{}, modelAdmin))
+	assert.Equal(t, "-id", modelAdmin.CursorPaginationField())
+
+	mockDB := &keysetDBInterface{mockDBInterface: newMockDBInterface()}
+	modelName := getModelName(&TestUser{})
+	for id := 1; id <= 5; id++ {
+		mockDB.objects[modelName] = append(mockDB.objects[modelName], &TestUser{ID: id, Username: fmt.Sprintf("user%d", id)})
+	}
+	modelAdmin.SetDatabaseInterface(mockDB)
+
+	parts := strings.SplitN(modelName, ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	list := func(cursor string) *adminpb.ListObjectsResponse {
+		resp, err := handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+			App: parts[0], Model: parts[1], PageSize: 2, Cursor: cursor,
+		}))
+		require.NoError(t, err)
+		return resp.Msg
+	}
+	ids := func(resp *adminpb.ListObjectsResponse) []string {
+		var ids []string
+		for _, object := range resp.Objects {
+			ids = append(ids, object.Id)
+		}
+		return ids
+	}
+
+	first := list("")
+	assert.Equal(t, []string{"5", "4"}, ids(first))
+	assert.True(t, first.HasNext)
+	assert.False(t, first.HasPrevious)
+	assert.NotEmpty(t, first.NextCursor)
+
+	// A new user doesn't shift the following page
+	mockDB.objects[modelName] = append(mockDB.objects[modelName], &TestUser{ID: 6, Username: "user6"})
+	second := list(first.NextCursor)
+	assert.Equal(t, []string{"3", "2"}, ids(second))
+	assert.True(t, second.HasPrevious)
+
+	third := list(second.NextCursor)
+	assert.Equal(t, []string{"1"}, ids(third))
+	assert.False(t, third.HasNext)
+	assert.Empty(t, third.NextCursor)
+
+	assert.Equal(t, []string{"3", "2"}, ids(list(third.PrevCursor)))
+	assert.Zero(t, mockDB.offsetQueries)
+
+	// Client filters on the cursor field don't override the cursor
+	resp, err := handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+		App: parts[0], Model: parts[1], PageSize: 2, Cursor: first.NextCursor,
+		Filters: map[string]string{"id__lt": "100"},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3", "2"}, ids(resp.Msg))
+
+	_, err = handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+		App: parts[0], Model: parts[1], Cursor: "bogus!",
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestGRPCListObjectsUsesDatabase(t *testing.T) {
+	site := NewSite("test")
+	modelAdmin := NewModelAdmin(&TestUser{})
+	require.NoError(t, site.Register(&TestUser{}, modelAdmin))
+
+	mockDB := &keysetDBInterface{mockDBInterface: newMockDBInterface()}
+	modelName := getModelName(&TestUser{})
+	for id := 1; id <= 5; id++ {
+		mockDB.objects[modelName] = append(mockDB.objects[modelName], &TestUser{ID: id, Username: fmt.Sprintf("user%d", id)})
+	}
+	modelAdmin.SetDatabaseInterface(mockDB)
+
+	parts := strings.SplitN(modelName, ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+		App: parts[0], Model: parts[1], PageSize: 10,
+		Filters: map[string]string{"id__gt": "3"},
+	}))
+	require.NoError(t, err)
+
+	var ids []string
+	for _, object := range resp.Msg.Objects {
+		ids = append(ids, object.Id)
+	}
+	assert.Equal(t, []string{"4", "5"}, ids)
+	assert.Equal(t, int32(2), resp.Msg.TotalCount)
+
+	// Later pages are requested by offset
+	_, err = handler.ListObjects(context.Background(), connect.NewRequest(&adminpb.ListObjectsRequest{
+		App: parts[0], Model: parts[1], Page: 2, PageSize: 2,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockDB.offsetQueries)
+}
+
 func BenchmarkModelRegistration(b *testing.B) {
 	site := NewSite("benchmark")
-	
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		site.Register(&TestUser{}, nil)
@@ -333,9 +855,352 @@ func BenchmarkModelRegistration(b *testing.B) {
 func BenchmarkGetModelAdmin(b *testing.B) {
 	site := NewSite("benchmark")
 	site.Register(&TestUser{}, nil)
-	
+
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		_, _ = site.GetModelAdmin("main.testuser")
 	}
-}
\ No newline at end of file
+}
+
+// ownedPost is a model instance owned by a user
+type ownedPost struct {
+	ID    string `json:"id"`
+	Owner string `json:"owner"`
+	Title string `json:"title"`
+}
+
+// ownedDBInterface stores ownedPost objects by ID
+type ownedDBInterface struct {
+	*mockDBInterface
+	posts map[string]*ownedPost
+}
+
+func (m *ownedDBInterface) GetByID(ctx context.Context, model interface{}, id interface{}) (interface{}, error) {
+	if post, exists := m.posts[fmt.Sprint(id)]; exists {
+		return post, nil
+	}
+	return nil, nil
+}
+
+func (m *ownedDBInterface) Update(ctx context.Context, model interface{}, id interface{}, data map[string]interface{}) (interface{}, error) {
+	post := m.posts[fmt.Sprint(id)]
+	if title, ok := data["title"].(string); ok {
+		post.Title = title
+	}
+	return post, nil
+}
+
+func (m *ownedDBInterface) Delete(ctx context.Context, model interface{}, id interface{}) error {
+	delete(m.posts, fmt.Sprint(id))
+	return nil
+}
+
+func (m *ownedDBInterface) GetSchema(model interface{}) (*ModelSchema, error) {
+	return &ModelSchema{Fields: []FieldSchema{{Name: "title", Type: "string"}}}, nil
+}
+
+// ownerPermissions only permits access to objects owned by the user
+type ownerPermissions struct {
+	AllowAllPermissions
+}
+
+func ownsObject(user interface{}, obj interface{}) bool {
+	post, ok := obj.(*ownedPost)
+	if !ok {
+		// Model-level checks pass; row-level checks decide
+		return true
+	}
+	return post.Owner == user
+}
+
+func (ownerPermissions) HasChangePermission(user interface{}, obj interface{}) bool {
+	return ownsObject(user, obj)
+}
+
+func (ownerPermissions) HasDeletePermission(user interface{}, obj interface{}) bool {
+	return ownsObject(user, obj)
+}
+
+func (ownerPermissions) HasViewPermission(user interface{}, obj interface{}) bool {
+	return ownsObject(user, obj)
+}
+
+func newOwnershipSite(t *testing.T) (*Site, *ownedDBInterface) {
+	db := &ownedDBInterface{
+		mockDBInterface: newMockDBInterface(),
+		posts: map[string]*ownedPost{
+			"1": {ID: "1", Owner: "alice", Title: "Alice's post"},
+			"2": {ID: "2", Owner: "bob", Title: "Bob's post"},
+		},
+	}
+
+	site := NewSite("test")
+	admin := NewModelAdmin(&TestPost{})
+	admin.SetDatabaseInterface(db)
+	require.NoError(t, site.Register(&TestPost{}, admin))
+	site.SetPermissionChecker(ownerPermissions{})
+	return site, db
+}
+
+func TestSiteObjectPermissionEnforcement(t *testing.T) {
+	site, db := newOwnershipSite(t)
+	router := newPermissionTestRouter(site)
+	router.GET("/admin/:app/:model/:id/", site.handleModelDetail)
+	modelPath := "/admin/" + strings.Replace(getModelName(&TestPost{}), ".", "/", 1)
+
+	doRequest := func(method, path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(`{"title": "changed"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Objects owned by someone else are forbidden
+	assert.Equal(t, http.StatusForbidden, doRequest("GET", modelPath+"/2/").Code)
+	assert.Equal(t, http.StatusForbidden, doRequest("POST", modelPath+"/2/change/").Code)
+	assert.Equal(t, http.StatusForbidden, doRequest("POST", modelPath+"/2/delete/").Code)
+	assert.Equal(t, "Bob's post", db.posts["2"].Title)
+	assert.Contains(t, db.posts, "2")
+
+	// Missing objects are reported as not found
+	assert.Equal(t, http.StatusNotFound, doRequest("POST", modelPath+"/3/change/").Code)
+
+	// The owner may change and delete their own object
+	assert.Equal(t, http.StatusOK, doRequest("POST", modelPath+"/1/change/").Code)
+	assert.Equal(t, "changed", db.posts["1"].Title)
+	assert.Equal(t, http.StatusOK, doRequest("POST", modelPath+"/1/delete/").Code)
+	assert.NotContains(t, db.posts, "1")
+}
+
+func TestGRPCObjectPermissionEnforcement(t *testing.T) {
+	site, _ := newOwnershipSite(t)
+	handler := NewAdminServiceHandler(site, nil)
+	ctx := ContextWithUser(context.Background(), "alice")
+	parts := strings.SplitN(getModelName(&TestPost{}), ".", 2)
+
+	resp, err := handler.GetObject(ctx, connect.NewRequest(&adminpb.GetObjectRequest{App: parts[0], Model: parts[1], Id: "1"}))
+	require.NoError(t, err)
+	assert.Equal(t, "1", resp.Msg.Object.Id)
+	assert.Equal(t, "Alice's post", resp.Msg.Object.Fields["title"].GetStringValue())
+
+	_, err = handler.GetObject(ctx, connect.NewRequest(&adminpb.GetObjectRequest{App: parts[0], Model: parts[1], Id: "2"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = handler.UpdateObject(ctx, connect.NewRequest(&adminpb.UpdateObjectRequest{App: parts[0], Model: parts[1], Id: "2"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = handler.DeleteObject(ctx, connect.NewRequest(&adminpb.DeleteObjectRequest{App: parts[0], Model: parts[1], Id: "2"}))
+	assert.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+
+	_, err = handler.GetObject(ctx, connect.NewRequest(&adminpb.GetObjectRequest{App: parts[0], Model: parts[1], Id: "3"}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// The owner passes the row-level check
+	_, err = handler.DeleteObject(ctx, connect.NewRequest(&adminpb.DeleteObjectRequest{App: parts[0], Model: parts[1], Id: "1"}))
+	assert.NotEqual(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+// fakeEntClient stands in for a generated Ent client
+type fakeEntClient struct {
+	name string
+}
+
+func TestConnectHandlerPicksUpLateEntClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	require.NoError(t, site.Register(&TestUser{}, nil))
+	parts := strings.SplitN(getModelName(&TestUser{}), ".", 2)
+
+	router := gin.New()
+	handler := site.registerConnectHandlers(router.Group(""))
+
+	listObjects := func() {
+		body := fmt.Sprintf(`{"app": %q, "model": %q}`, parts[0], parts[1])
+		req := httptest.NewRequest(http.MethodPost, protoconnect.AdminServiceListObjectsProcedure, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Requests before a client is set run without one
+	listObjects()
+	assert.Nil(t, handler.bridge.client)
+
+	// A client set after registration is used by the next request
+	client := &fakeEntClient{name: "primary"}
+	site.SetEntClient(client)
+	listObjects()
+	assert.Same(t, client, handler.bridge.client)
+
+	// Replacing the client replaces the bridge
+	replacement := &fakeEntClient{name: "replacement"}
+	site.SetEntClient(replacement)
+	listObjects()
+	assert.Same(t, replacement, handler.bridge.client)
+
+	// A handler-level client overrides the site's
+	override := &fakeEntClient{name: "override"}
+	handler.SetEntClient(override)
+	listObjects()
+	assert.Same(t, override, handler.bridge.client)
+}
+
+func TestEntBridgeConcurrentClientChanges(t *testing.T) {
+	site := NewSite("test")
+	handler := NewAdminServiceHandler(site, nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			site.SetEntClient(&fakeEntClient{name: fmt.Sprintf("client%d", i)})
+		}(i)
+		go func() {
+			defer wg.Done()
+			handler.entBridge()
+		}()
+	}
+	wg.Wait()
+
+	assert.Same(t, site.EntClient(), handler.entBridge().client)
+}
+
+// recordingDBInterface stores submitted data as-is, without managing timestamps
+type recordingDBInterface struct {
+	*mockDBInterface
+	rows map[string]map[string]interface{}
+}
+
+func (m *recordingDBInterface) Create(ctx context.Context, model interface{}, data map[string]interface{}) (interface{}, error) {
+	id := strconv.Itoa(len(m.rows) + 1)
+	row := map[string]interface{}{"id": id}
+	for key, value := range data {
+		row[key] = value
+	}
+	m.rows[id] = row
+	return row, nil
+}
+
+func (m *recordingDBInterface) Update(ctx context.Context, model interface{}, id interface{}, data map[string]interface{}) (interface{}, error) {
+	row, exists := m.rows[fmt.Sprint(id)]
+	if !exists {
+		return nil, fmt.Errorf("object %v not found", id)
+	}
+	for key, value := range data {
+		row[key] = value
+	}
+	return row, nil
+}
+
+func (m *recordingDBInterface) GetSchema(model interface{}) (*ModelSchema, error) {
+	return &ModelSchema{
+		Fields: []FieldSchema{
+			{Name: "title", Type: "string"},
+			{Name: "created_at", Type: "datetime", Required: true},
+			{Name: "updated_at", Type: "datetime", Required: true},
+		},
+	}, nil
+}
+
+func TestAutoTimestamps(t *testing.T) {
+	db := &recordingDBInterface{mockDBInterface: newMockDBInterface(), rows: make(map[string]map[string]interface{})}
+	admin := NewModelAdmin(&TestPost{}).SetAutoNowAdd("created_at").SetAutoNow("updated_at")
+	admin.SetDatabaseInterface(db)
+
+	jsonRequest := func(body string) *http.Request {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return req
+	}
+
+	before := time.Now()
+	_, err := admin.CreateObject(&gin.Context{}, jsonRequest(`{"title": "Hello", "created_at": "2001-01-01T00:00:00Z", "updated_at": "2001-01-01T00:00:00Z"}`))
+	require.NoError(t, err)
+
+	row := db.rows["1"]
+	createdAt, ok := row["created_at"].(time.Time)
+	require.True(t, ok, "created_at should be set server-side on create")
+	firstUpdatedAt, ok := row["updated_at"].(time.Time)
+	require.True(t, ok, "updated_at should be set server-side on create")
+	assert.False(t, createdAt.Before(before), "client-supplied created_at must be ignored")
+	assert.False(t, firstUpdatedAt.Before(before), "client-supplied updated_at must be ignored")
+
+	time.Sleep(2 * time.Millisecond)
+	_, err = admin.UpdateObject(&gin.Context{}, "1", jsonRequest(`{"title": "Edited", "created_at": "2001-01-01T00:00:00Z"}`))
+	require.NoError(t, err)
+
+	row = db.rows["1"]
+	assert.Equal(t, "Edited", row["title"])
+	assert.Equal(t, createdAt, row["created_at"], "created_at must stay fixed on update")
+	updatedAt, ok := row["updated_at"].(time.Time)
+	require.True(t, ok)
+	assert.True(t, updatedAt.After(firstUpdatedAt), "updated_at must be refreshed on update")
+
+	assert.ElementsMatch(t, []string{"created_at", "updated_at"}, admin.readonlyFields())
+}
+
+func TestModelAdminSendsSignals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockDB := newMockDBInterface()
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(mockDB)
+
+	var events []string
+	record := func(name string) signals.Receiver {
+		return func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+			assert.IsType(t, &TestUser{}, sender)
+			events = append(events, fmt.Sprintf("%s created=%v", name, kwargs["created"]))
+			return nil
+		}
+	}
+	for signal, name := range map[*signals.Signal]string{
+		signals.PreSave:    "pre_save",
+		signals.PostSave:   "post_save",
+		signals.PreDelete:  "pre_delete",
+		signals.PostDelete: "post_delete",
+	} {
+		id := signal.Connect(record(name))
+		defer signal.Disconnect(id)
+	}
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	req := httptest.NewRequest("POST", "/", strings.NewReader("username=john&email=john@example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err := admin.CreateObject(ctx, req)
+	require.NoError(t, err)
+
+	require.NoError(t, admin.DeleteObject(ctx, "1"))
+
+	assert.Equal(t, []string{
+		"pre_save created=true",
+		"post_save created=true",
+		"pre_delete created=<nil>",
+		"post_delete created=<nil>",
+	}, events)
+}
+
+func TestPreSaveReceiverAbortsCreate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockDB := newMockDBInterface()
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(mockDB)
+
+	errReserved := errors.New("username is reserved")
+	id := signals.PreSave.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		if kwargs["data"].(map[string]interface{})["username"] == "admin" {
+			return errReserved
+		}
+		return nil
+	})
+	defer signals.PreSave.Disconnect(id)
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	req := httptest.NewRequest("POST", "/", strings.NewReader("username=admin&email=admin@example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err := admin.CreateObject(ctx, req)
+	assert.ErrorIs(t, err, errReserved)
+	assert.Empty(t, mockDB.objects[getModelName(&TestUser{})])
+}
@@ -22,13 +22,6 @@ func (Config) Name() string {
 	return "AdminConfig"
 }
 
-// Fieldset represents a grouped set of fields in admin forms
-type Fieldset struct {
-	Name    string
-	Fields  []string
-	Classes []string
-}
-
 // AdminAction represents a bulk action in admin
 type AdminAction struct {
 	Name        string
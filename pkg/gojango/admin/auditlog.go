@@ -0,0 +1,351 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultAuditLogTable is the table admin actions are recorded in
+const DefaultAuditLogTable = "gojango_admin_log"
+
+// AuditLog records who did what to which object through the admin, like
+// Django's LogEntry. Its table is created on first use.
+type AuditLog struct {
+	conn      *db.Connection
+	tableName string
+
+	mu          sync.Mutex
+	initialized bool
+}
+
+// LogFilter selects audit log entries. Empty fields match every entry.
+type LogFilter struct {
+	Model  string
+	User   string
+	Limit  int
+	Offset int
+}
+
+// NewAuditLog creates an audit log stored through conn
+func NewAuditLog(conn *db.Connection) *AuditLog {
+	return &AuditLog{
+		conn:      conn,
+		tableName: DefaultAuditLogTable,
+	}
+}
+
+// SetLogTable sets a custom audit log table name
+func (l *AuditLog) SetLogTable(tableName string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tableName = tableName
+	l.initialized = false
+}
+
+// Initialize creates the audit log table if it doesn't exist
+func (l *AuditLog) Initialize(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.initialize(ctx)
+}
+
+// initialize creates the table once; l.mu must be held
+func (l *AuditLog) initialize(ctx context.Context) error {
+	if l.initialized {
+		return nil
+	}
+
+	var createTableSQL string
+	switch l.conn.Driver() {
+	case db.DriverPostgres:
+		createTableSQL = fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				id SERIAL PRIMARY KEY,
+				action_time TIMESTAMP WITH TIME ZONE NOT NULL,
+				user_name VARCHAR(255) NOT NULL,
+				model VARCHAR(255) NOT NULL,
+				object_id TEXT NOT NULL,
+				action VARCHAR(255) NOT NULL,
+				message TEXT NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS idx_%s_action_time ON %s (action_time);
+		`, l.tableName, l.tableName, l.tableName)
+	case db.DriverSQLite:
+		createTableSQL = fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				id INTEGER PRIMARY KEY AUTOINCREMENT,
+				action_time DATETIME NOT NULL,
+				user_name TEXT NOT NULL,
+				model TEXT NOT NULL,
+				object_id TEXT NOT NULL,
+				action TEXT NOT NULL,
+				message TEXT NOT NULL
+			);
+			CREATE INDEX IF NOT EXISTS idx_%s_action_time ON %s (action_time);
+		`, l.tableName, l.tableName, l.tableName)
+	case db.DriverMySQL:
+		createTableSQL = fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				id INT AUTO_INCREMENT PRIMARY KEY,
+				action_time TIMESTAMP NOT NULL,
+				user_name VARCHAR(255) NOT NULL,
+				model VARCHAR(255) NOT NULL,
+				object_id TEXT NOT NULL,
+				action VARCHAR(255) NOT NULL,
+				message TEXT NOT NULL,
+				INDEX idx_%s_action_time (action_time)
+			);
+		`, l.tableName, l.tableName)
+	default:
+		return fmt.Errorf("unsupported database driver: %s", l.conn.Driver())
+	}
+
+	if _, err := l.conn.DB().ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("failed to create audit log table: %w", err)
+	}
+	l.initialized = true
+	return nil
+}
+
+// table returns the table name, creating the table on first use
+func (l *AuditLog) table(ctx context.Context) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.initialize(ctx); err != nil {
+		return "", err
+	}
+	return l.tableName, nil
+}
+
+// Record adds an entry to the log, timestamped now unless it has a time
+func (l *AuditLog) Record(ctx context.Context, entry LogEntry) error {
+	table, err := l.table(ctx)
+	if err != nil {
+		return err
+	}
+	if entry.ActionTime.IsZero() {
+		entry.ActionTime = time.Now()
+	}
+
+	query := l.placeholders(fmt.Sprintf(`INSERT INTO %s (action_time, user_name, model, object_id, action, message) VALUES (?, ?, ?, ?, ?, ?)`, table))
+	if _, err := l.conn.DB().ExecContext(ctx, query, entry.ActionTime, entry.User, entry.Model, entry.ObjectID, entry.Action, entry.Message); err != nil {
+		return fmt.Errorf("failed to record admin action: %w", err)
+	}
+	return nil
+}
+
+// Entries returns the entries matching filter, newest first, and the total
+// number of matching entries
+func (l *AuditLog) Entries(ctx context.Context, filter LogFilter) ([]LogEntry, int, error) {
+	table, err := l.table(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	if filter.Model != "" {
+		conditions = append(conditions, "model = ?")
+		args = append(args, filter.Model)
+	}
+	if filter.User != "" {
+		conditions = append(conditions, "user_name = ?")
+		args = append(args, filter.User)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := l.placeholders(fmt.Sprintf(`SELECT COUNT(*) FROM %s%s`, table, where))
+	if err := l.conn.DB().QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count admin actions: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultListPerPage
+	}
+	query := l.placeholders(fmt.Sprintf(`SELECT id, action_time, user_name, model, object_id, action, message FROM %s%s ORDER BY action_time DESC, id DESC LIMIT %d OFFSET %d`,
+		table, where, limit, filter.Offset))
+	rows, err := l.conn.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read admin actions: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []LogEntry{}
+	for rows.Next() {
+		var entry LogEntry
+		if err := rows.Scan(&entry.ID, &entry.ActionTime, &entry.User, &entry.Model, &entry.ObjectID, &entry.Action, &entry.Message); err != nil {
+			return nil, 0, fmt.Errorf("failed to read admin action: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
+
+// RecentActions returns the latest entries, for the dashboard
+func (l *AuditLog) RecentActions(ctx context.Context, limit int) ([]LogEntry, error) {
+	entries, _, err := l.Entries(ctx, LogFilter{Limit: limit})
+	return entries, err
+}
+
+// placeholders replaces ? placeholders with $n for drivers that use them
+func (l *AuditLog) placeholders(query string) string {
+	if l.conn.Driver() != db.DriverPostgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SetAuditLog records admin actions of every model in log, which also
+// provides the dashboard's recent actions. Passing nil stops recording.
+func (s *Site) SetAuditLog(auditLog *AuditLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.auditLog = auditLog
+	// A nil *AuditLog would make a non-nil ActionLog
+	s.actionLog = nil
+	if auditLog != nil {
+		s.actionLog = auditLog
+	}
+	for _, admin := range s.models {
+		admin.auditLog = auditLog
+	}
+}
+
+// AuditLog returns the site's audit log, or nil if actions aren't recorded
+func (s *Site) AuditLog() *AuditLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.auditLog
+}
+
+// logAction records an action of the current user on objects of the model,
+// if the model has an audit log. Failing to record doesn't undo the action,
+// so errors are only logged.
+func (ma *ModelAdmin) logAction(ctx context.Context, action, objectID, message string) {
+	if ma.auditLog == nil {
+		return
+	}
+
+	var user interface{}
+	if c, ok := ctx.(*gin.Context); ok {
+		user = CurrentUser(c)
+	} else {
+		user = UserFromContext(ctx)
+	}
+
+	entry := LogEntry{
+		User:     userName(user),
+		Model:    ma.modelName,
+		ObjectID: objectID,
+		Action:   action,
+		Message:  message,
+	}
+	if err := ma.auditLog.Record(ctx, entry); err != nil {
+		log.Printf("admin: %v", err)
+	}
+}
+
+// userName identifies a user in the audit log by username when it has one
+func userName(user interface{}) string {
+	switch u := user.(type) {
+	case nil:
+		return ""
+	case string:
+		return u
+	case interface{ GetUsername() string }:
+		return u.GetUsername()
+	}
+	return fmt.Sprint(user)
+}
+
+// changeMessage summarizes the fields submitted in an update
+func changeMessage(data map[string]interface{}) string {
+	if len(data) == 0 {
+		return "No fields changed."
+	}
+
+	fields := make([]string, 0, len(data))
+	for field := range data {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	if len(fields) == 1 {
+		return fmt.Sprintf("Changed %s.", fields[0])
+	}
+	return fmt.Sprintf("Changed %s and %s.", strings.Join(fields[:len(fields)-1], ", "), fields[len(fields)-1])
+}
+
+// handleAPILog lists audit log entries a page at a time, optionally
+// filtered by the model and user parameters
+func (s *Site) handleAPILog(c *gin.Context) {
+	auditLog := s.AuditLog()
+	if auditLog == nil {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Audit log not configured"})
+		return
+	}
+	if !s.canView(CurrentUser(c), &LogEntry{}) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+
+	s.mu.RLock()
+	perPage, maxPageSize := s.listPerPage, s.maxPageSize
+	s.mu.RUnlock()
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	if page < 1 {
+		page = 1
+	}
+	if p, err := strconv.Atoi(c.Query("per_page")); err == nil && p > 0 && p <= maxPageSize {
+		perPage = p
+	}
+
+	entries, total, err := auditLog.Entries(c, LogFilter{
+		Model:  c.Query("model"),
+		User:   c.Query("user"),
+		Limit:  perPage,
+		Offset: (page - 1) * perPage,
+	})
+	if err != nil {
+		render.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	numPages := (total + perPage - 1) / perPage
+	render.JSON(c, http.StatusOK, gin.H{
+		"results":   entries,
+		"count":     total,
+		"page":      page,
+		"per_page":  perPage,
+		"num_pages": numPages,
+		"has_next":  page < numPages,
+		"has_prev":  page > 1,
+	})
+}
@@ -0,0 +1,137 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// auditUser mimics an auth user
+type auditUser struct{ username string }
+
+func (u *auditUser) GetUsername() string { return u.username }
+
+func newAuditLog(t *testing.T) *AuditLog {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "admin.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return NewAuditLog(conn)
+}
+
+func TestAuditLogRecordsCRUD(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	auditLog := newAuditLog(t)
+	site.SetAuditLog(auditLog)
+
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(newMockDBInterface())
+	require.NoError(t, site.Register(&TestUser{}, admin))
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Set(UserContextKey, &auditUser{username: "alice"})
+	req := httptest.NewRequest("POST", "/", strings.NewReader("username=john&email=john@example.com"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err := admin.CreateObject(ctx, req)
+	require.NoError(t, err)
+
+	_, err = admin.updateObject(ContextWithUser(context.Background(), "bob"), "1", map[string]interface{}{
+		"username": "johnny",
+		"email":    "johnny@example.com",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, admin.DeleteObject(ctx, "1"))
+
+	entries, total, err := auditLog.Entries(context.Background(), LogFilter{})
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Len(t, entries, 3)
+
+	// Newest first
+	deleted, updated, created := entries[0], entries[1], entries[2]
+	modelName := getModelName(&TestUser{})
+	assert.Equal(t, "create", created.Action)
+	assert.Equal(t, "alice", created.User)
+	assert.Equal(t, modelName, created.Model)
+	assert.Equal(t, "1", created.ObjectID)
+	assert.Equal(t, "Added.", created.Message)
+	assert.False(t, created.ActionTime.IsZero())
+	assert.NotZero(t, created.ID)
+
+	assert.Equal(t, "update", updated.Action)
+	assert.Equal(t, "bob", updated.User)
+	assert.Equal(t, "1", updated.ObjectID)
+	assert.Equal(t, "Changed email and username.", updated.Message)
+
+	assert.Equal(t, "delete", deleted.Action)
+	assert.Equal(t, "alice", deleted.User)
+	assert.Equal(t, modelName, deleted.Model)
+	assert.Equal(t, "1", deleted.ObjectID)
+	assert.Equal(t, "Deleted.", deleted.Message)
+
+	// The dashboard lists recorded actions
+	dashboard, err := site.Dashboard(context.Background(), nil, 2)
+	require.NoError(t, err)
+	require.Len(t, dashboard.RecentActions, 2)
+	assert.Equal(t, "delete", dashboard.RecentActions[0].Action)
+}
+
+func TestAuditLogAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	router := gin.New()
+	site.SetupRoutes(router)
+
+	get := func(url string) (int, map[string]interface{}) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		return w.Code, body
+	}
+
+	code, _ := get("/admin/api/log/")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	auditLog := newAuditLog(t)
+	site.SetAuditLog(auditLog)
+	ctx := context.Background()
+	for _, entry := range []LogEntry{
+		{User: "alice", Model: "main.post", ObjectID: "1", Action: "create", Message: "Added."},
+		{User: "bob", Model: "main.post", ObjectID: "1", Action: "update", Message: "Changed title."},
+		{User: "alice", Model: "main.comment", ObjectID: "4", Action: "create", Message: "Added."},
+		{User: "alice", Model: "main.post", ObjectID: "2", Action: "create", Message: "Added."},
+	} {
+		require.NoError(t, auditLog.Record(ctx, entry))
+	}
+
+	code, body := get("/admin/api/log/?model=main.post&user=alice&per_page=1")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(2), body["count"])
+	assert.Equal(t, float64(2), body["num_pages"])
+	assert.Equal(t, true, body["has_next"])
+	results := body["results"].([]interface{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "2", results[0].(map[string]interface{})["object_id"])
+
+	code, body = get("/admin/api/log/?model=main.post&user=alice&per_page=1&page=2")
+	require.Equal(t, http.StatusOK, code)
+	results = body["results"].([]interface{})
+	require.Len(t, results, 1)
+	assert.Equal(t, "1", results[0].(map[string]interface{})["object_id"])
+	assert.Equal(t, false, body["has_next"])
+
+	code, body = get("/admin/api/log/")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(4), body["count"])
+}
@@ -143,6 +143,12 @@ func (r *EntModelReflector) isFieldEditable(field reflect.StructField) bool {
 
 // ConvertEntObjectToObjectData converts an Ent model instance to protobuf ObjectData
 func ConvertEntObjectToObjectData(obj interface{}) (*adminpb.ObjectData, error) {
+	return convertEntObject(obj, false)
+}
+
+// convertEntObject converts an Ent model instance to protobuf ObjectData,
+// leaving out empty fields when omitEmpty is set
+func convertEntObject(obj interface{}, omitEmpty bool) (*adminpb.ObjectData, error) {
 	if obj == nil {
 		return nil, fmt.Errorf("object is nil")
 	}
@@ -194,6 +200,10 @@ func ConvertEntObjectToObjectData(obj interface{}) (*adminpb.ObjectData, error)
 			}
 		}
 
+		if omitEmpty && isEmptyFieldValue(fieldValue.Interface()) {
+			continue
+		}
+
 		// Convert field value to protobuf Value
 		pbValue, err := convertToProtobufValue(fieldValue.Interface())
 		if err != nil {
@@ -218,6 +228,14 @@ func convertToProtobufValue(val interface{}) (*structpb.Value, error) {
 		return structpb.NewNullValue(), nil
 	}
 
+	// Optional Ent fields are pointers; nil means the value is absent
+	if rv := reflect.ValueOf(val); rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return structpb.NewNullValue(), nil
+		}
+		return convertToProtobufValue(rv.Elem().Interface())
+	}
+
 	switch v := val.(type) {
 	case string:
 		return structpb.NewStringValue(v), nil
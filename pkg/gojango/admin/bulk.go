@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"sync"
+)
+
+// DefaultBulkConcurrency is how many objects bulk operations process at once
+// for models that don't set their own limit
+const DefaultBulkConcurrency = 4
+
+// ConnectionPoolSizer is implemented by database interfaces that can report
+// the size of their connection pool, e.g. from sql.DBStats.MaxOpenConnections.
+// Bulk operations then use at most one worker less than the pool size, so a
+// large action never holds every connection and starves other requests.
+type ConnectionPoolSizer interface {
+	MaxOpenConnections() int
+}
+
+// SetBulkConcurrency sets how many objects bulk operations on this model
+// process at once. Non-positive values restore DefaultBulkConcurrency.
+func (ma *ModelAdmin) SetBulkConcurrency(workers int) *ModelAdmin {
+	ma.bulkConcurrency = workers
+	return ma
+}
+
+// BulkConcurrency returns the number of workers bulk operations use: the
+// configured concurrency, capped to leave a free connection in the database
+// pool when the database interface reports its size
+func (ma *ModelAdmin) BulkConcurrency() int {
+	workers := ma.bulkConcurrency
+	if workers <= 0 {
+		workers = DefaultBulkConcurrency
+	}
+
+	if sizer, ok := ma.dbInterface.(ConnectionPoolSizer); ok {
+		if poolSize := sizer.MaxOpenConnections(); poolSize > 0 {
+			limit := poolSize - 1
+			if limit < 1 {
+				limit = 1
+			}
+			if workers > limit {
+				workers = limit
+			}
+		}
+	}
+
+	return workers
+}
+
+// ForEachObject calls fn for every object using at most BulkConcurrency()
+// concurrent calls and waits for all of them. The returned slice holds the
+// error for each object by index; nil entries succeeded. As fn runs on
+// several goroutines, it must use a copy of a handler's gin.Context.
+func (ma *ModelAdmin) ForEachObject(objects []interface{}, fn func(obj interface{}) error) []error {
+	errs := make([]error, len(objects))
+	runBounded(ma.BulkConcurrency(), len(objects), func(i int) {
+		errs[i] = fn(objects[i])
+	})
+	return errs
+}
+
+// runBounded calls fn for each index in [0, count) from at most workers
+// goroutines and returns once every call has finished
+func runBounded(workers, count int, fn func(i int)) {
+	if workers > count {
+		workers = count
+	}
+	if workers <= 1 {
+		for i := 0; i < count; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
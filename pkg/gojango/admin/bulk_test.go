@@ -0,0 +1,156 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gojangodb "github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// concurrencyDBInterface tracks how many operations run at the same time
+type concurrencyDBInterface struct {
+	*mockDBInterface
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
+	deleted     map[string]bool
+	poolSize    int
+}
+
+func newConcurrencyDBInterface(poolSize int) *concurrencyDBInterface {
+	return &concurrencyDBInterface{
+		mockDBInterface: newMockDBInterface(),
+		deleted:         make(map[string]bool),
+		poolSize:        poolSize,
+	}
+}
+
+func (m *concurrencyDBInterface) track() func() {
+	m.mu.Lock()
+	m.inFlight++
+	if m.inFlight > m.maxInFlight {
+		m.maxInFlight = m.inFlight
+	}
+	m.mu.Unlock()
+
+	time.Sleep(2 * time.Millisecond)
+	return func() {
+		m.mu.Lock()
+		m.inFlight--
+		m.mu.Unlock()
+	}
+}
+
+func (m *concurrencyDBInterface) GetByID(ctx context.Context, model interface{}, id interface{}) (interface{}, error) {
+	defer m.track()()
+	return map[string]interface{}{"id": id}, nil
+}
+
+func (m *concurrencyDBInterface) Delete(ctx context.Context, model interface{}, id interface{}) error {
+	defer m.track()()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.deleted[fmt.Sprint(id)] = true
+	return nil
+}
+
+func (m *concurrencyDBInterface) MaxOpenConnections() int {
+	return m.poolSize
+}
+
+func runBulkDelete(t *testing.T, admin *ModelAdmin, count int) interface{} {
+	form := url.Values{"action": {"delete_selected"}}
+	for i := 1; i <= count; i++ {
+		form.Add("_selected_action", fmt.Sprint(i))
+	}
+	req := httptest.NewRequest("POST", "/admin/main/testuser/action/", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	require.NoError(t, req.ParseForm())
+
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = req
+	result, err := admin.ExecuteBulkAction(ctx, req)
+	require.NoError(t, err)
+	return result
+}
+
+func TestBulkActionConcurrencyCap(t *testing.T) {
+	db := newConcurrencyDBInterface(0)
+	admin := NewModelAdmin(&TestUser{}).SetBulkConcurrency(3)
+	admin.SetDatabaseInterface(db)
+	admin.AddAction("delete_selected", "Delete selected items", DeleteSelectedAction)
+
+	result := runBulkDelete(t, admin, 40)
+
+	assert.Equal(t, 40, result.(gin.H)["count"])
+	assert.Len(t, db.deleted, 40, "every selected object should be processed")
+	assert.LessOrEqual(t, db.maxInFlight, 3, "bulk operations must respect the concurrency cap")
+	assert.Greater(t, db.maxInFlight, 1, "bulk operations should run concurrently")
+}
+
+func TestBulkConcurrencyRespectsConnectionPool(t *testing.T) {
+	db := newConcurrencyDBInterface(3)
+	admin := NewModelAdmin(&TestUser{}).SetBulkConcurrency(10)
+	admin.SetDatabaseInterface(db)
+	admin.AddAction("delete_selected", "Delete selected items", DeleteSelectedAction)
+
+	// One connection is kept free for other requests
+	assert.Equal(t, 2, admin.BulkConcurrency())
+
+	runBulkDelete(t, admin, 20)
+	assert.Len(t, db.deleted, 20)
+	assert.LessOrEqual(t, db.maxInFlight, 2)
+
+	// A single-connection pool processes objects one at a time
+	db.poolSize = 1
+	assert.Equal(t, 1, admin.BulkConcurrency())
+
+	// Without a configured limit the default applies
+	assert.Equal(t, DefaultBulkConcurrency, NewModelAdmin(&TestUser{}).BulkConcurrency())
+}
+
+func TestForEachObjectReportsErrorsByIndex(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{}).SetBulkConcurrency(4)
+	objects := []interface{}{1, 2, 3, 4, 5}
+
+	errs := admin.ForEachObject(objects, func(obj interface{}) error {
+		if obj.(int)%2 == 0 {
+			return fmt.Errorf("object %d failed", obj)
+		}
+		return nil
+	})
+
+	require.Len(t, errs, 5)
+	for i, err := range errs {
+		if (i+1)%2 == 0 {
+			assert.EqualError(t, err, fmt.Sprintf("object %d failed", i+1))
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestEntDatabaseInterfacePoolSize(t *testing.T) {
+	config := gojangodb.SQLiteConfig(":memory:")
+	config.MaxOpenConns = 5
+	conn, err := gojangodb.Open(config)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	entDB := NewEntDatabaseInterface(nil)
+	assert.Equal(t, 0, entDB.MaxOpenConnections(), "without a connection the pool size is unknown")
+
+	admin := NewModelAdmin(&TestUser{}).SetBulkConcurrency(10)
+	admin.SetDatabaseInterface(entDB.SetConnection(conn))
+	assert.Equal(t, 5, entDB.MaxOpenConnections())
+	assert.Equal(t, 4, admin.BulkConcurrency())
+}
@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrStaleObject is returned by updates of an object that was changed since
+// the client loaded it
+var ErrStaleObject = errors.New("object was changed since it was loaded")
+
+// VersionedUpdater is implemented by database interfaces that can update an
+// object only while its version field still holds the version the client
+// loaded, e.g. with UPDATE ... WHERE id = ? AND version = ?. The version is
+// as the client submitted it, such as form text or a JSON number. The same
+// statement moves the version on: integers are incremented and timestamps
+// set to the current time. It returns ErrStaleObject when no row matched.
+type VersionedUpdater interface {
+	UpdateIfVersion(ctx context.Context, model interface{}, id interface{}, versionField string, version interface{}, data map[string]interface{}) (interface{}, error)
+}
+
+// SetVersionField enables optimistic concurrency control on updates using
+// field, an integer version or a timestamp such as "updated_at". Updates
+// must submit the version they loaded, and fail with ErrStaleObject,
+// reported as 409 Conflict, when the object has changed since. Every update
+// moves the version on. The field is read-only in the schema, so forms send
+// it back as is. ChangeObject uses the version of the object as loaded
+// instead. The database interface must implement VersionedUpdater.
+func (ma *ModelAdmin) SetVersionField(field string) *ModelAdmin {
+	ma.versionField = field
+	return ma
+}
+
+// VersionField returns the optimistic concurrency field, or "" when updates
+// are not checked
+func (ma *ModelAdmin) VersionField() string {
+	return ma.versionField
+}
+
+// takeVersion removes the version the client loaded from submitted data,
+// since it is compared rather than saved. Data without a version fails
+// validation.
+func (ma *ModelAdmin) takeVersion(data map[string]interface{}) (interface{}, error) {
+	known := data[ma.versionField]
+	delete(data, ma.versionField)
+	if isEmptyValue(known) {
+		validationErr := NewValidationError()
+		validationErr.Add(ma.versionField, "This field is required.")
+		return nil, validationErr
+	}
+	return known, nil
+}
+
+// saveVersioned updates an object through db only while its version is
+// still the one the client loaded
+func (ma *ModelAdmin) saveVersioned(ctx context.Context, db DatabaseInterface, id string, data map[string]interface{}, known interface{}) (interface{}, error) {
+	updater, ok := db.(VersionedUpdater)
+	if !ok {
+		return nil, fmt.Errorf("database interface %T does not support versioned updates", db)
+	}
+	obj, err := updater.UpdateIfVersion(ctx, ma.model, id, ma.versionField, known, data)
+	if errors.Is(err, ErrStaleObject) {
+		return nil, fmt.Errorf("%s %s: %w", ma.verboseName, id, err)
+	}
+	return obj, err
+}
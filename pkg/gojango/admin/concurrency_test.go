@@ -0,0 +1,345 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/gin-gonic/gin"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// versionedDBInterface updates only while the version matches, like
+// UPDATE ... WHERE id = ? AND version = ?
+type versionedDBInterface struct {
+	*mockDBInterface
+	// concurrentWrite changes the stored version right before the update,
+	// simulating another admin saving in between
+	concurrentWrite bool
+}
+
+func (m *versionedDBInterface) UpdateIfVersion(ctx context.Context, model interface{}, id interface{}, versionField string, version interface{}, data map[string]interface{}) (interface{}, error) {
+	obj, _ := m.GetByID(ctx, model, id)
+	if obj == nil {
+		return nil, ErrStaleObject
+	}
+	stored := obj.(map[string]interface{})
+	if m.concurrentWrite {
+		stored[versionField] = stored[versionField].(int) + 1
+	}
+	if fmt.Sprint(stored[versionField]) != fmt.Sprint(version) {
+		return nil, ErrStaleObject
+	}
+	data[versionField] = stored[versionField].(int) + 1
+	return m.Update(ctx, model, id, data)
+}
+
+func newVersionedAdmin(t *testing.T, db DatabaseInterface) (*Site, *ModelAdmin, string) {
+	site := NewSite("test")
+	admin := NewModelAdmin(&TestUser{}).SetVersionField("version")
+	admin.SetDatabaseInterface(db)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	return site, admin, getModelName(&TestUser{})
+}
+
+func TestUpdateObjectVersionConflict(t *testing.T) {
+	mockDB := &versionedDBInterface{mockDBInterface: newMockDBInterface()}
+	site, admin, modelName := newVersionedAdmin(t, mockDB)
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john", "email": "john@example.com", "version": 1},
+	}
+	assert.Equal(t, "version", admin.VersionField())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/admin/:app/:model/:id/", site.handleModelUpdate)
+	path := "/admin/" + strings.Replace(modelName, ".", "/", 1) + "/1/"
+
+	// The first admin saves with the version they loaded, bumping it
+	w := postBulkAction(router, path, url.Values{"username": {"jim"}, "email": {"john@example.com"}, "version": {"1"}})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	stored := mockDB.objects[modelName][0].(map[string]interface{})
+	assert.Equal(t, 2, stored["version"])
+	assert.Equal(t, "jim", stored["username"])
+
+	// The second admin still holds version 1
+	w = postBulkAction(router, path, url.Values{"username": {"jack"}, "email": {"john@example.com"}, "version": {"1"}})
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	assert.Equal(t, "jim", stored["username"])
+	assert.Equal(t, 2, stored["version"])
+
+	// Over gRPC the conflict is reported as aborted
+	parts := strings.SplitN(modelName, ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	_, err := handler.UpdateObject(context.Background(), connect.NewRequest(&adminpb.UpdateObjectRequest{
+		App:   parts[0],
+		Model: parts[1],
+		Id:    "1",
+		Data: map[string]*_struct.Value{
+			"username": {Kind: &_struct.Value_StringValue{StringValue: "jack"}},
+			"email":    {Kind: &_struct.Value_StringValue{StringValue: "john@example.com"}},
+			"version":  {Kind: &_struct.Value_NumberValue{NumberValue: 1}},
+		},
+	}))
+	require.Error(t, err)
+	assert.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+}
+
+func TestUpdateObjectVersionCheckedInUpdate(t *testing.T) {
+	mockDB := &versionedDBInterface{mockDBInterface: newMockDBInterface(), concurrentWrite: true}
+	_, admin, modelName := newVersionedAdmin(t, mockDB)
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john", "email": "john@example.com", "version": 1},
+	}
+
+	_, err := admin.updateObject(context.Background(), "1", map[string]interface{}{"username": "jack", "email": "john@example.com", "version": "1"})
+	assert.ErrorIs(t, err, ErrStaleObject)
+	assert.Equal(t, "john", mockDB.objects[modelName][0].(map[string]interface{})["username"])
+}
+
+func TestVersionFieldIsReadonly(t *testing.T) {
+	mockDB := newMockDBInterface()
+	_, admin, _ := newVersionedAdmin(t, mockDB)
+	admin.SetVersionField("created_at")
+
+	assert.Contains(t, admin.readonlyFields(), "created_at")
+	for _, field := range admin.GetSchema().Fields {
+		assert.Equal(t, field.Name == "created_at", field.Readonly, field.Name)
+	}
+}
+
+func TestChangeObjectUsesLoadedVersion(t *testing.T) {
+	mockDB := &versionedDBInterface{mockDBInterface: newMockDBInterface()}
+	_, admin, modelName := newVersionedAdmin(t, mockDB)
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john", "email": "john@example.com", "version": 1},
+	}
+
+	_, err := admin.ChangeObject(context.Background(), "1", map[string]interface{}{"username": "jack"})
+	require.NoError(t, err)
+	stored := mockDB.objects[modelName][0].(map[string]interface{})
+	assert.Equal(t, "jack", stored["username"])
+	assert.Equal(t, 2, stored["version"])
+
+	// A version passed explicitly is still checked
+	_, err = admin.ChangeObject(context.Background(), "1", map[string]interface{}{"username": "jim", "version": 1})
+	assert.ErrorIs(t, err, ErrStaleObject)
+	assert.Equal(t, "jack", stored["username"])
+}
+
+func TestUpdateObjectRequiresVersion(t *testing.T) {
+	mockDB := &versionedDBInterface{mockDBInterface: newMockDBInterface()}
+	_, admin, modelName := newVersionedAdmin(t, mockDB)
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john", "email": "john@example.com", "version": 1},
+	}
+
+	_, err := admin.updateObject(context.Background(), "1", map[string]interface{}{"username": "jack", "email": "john@example.com"})
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Errors, "version")
+	assert.Equal(t, "john", mockDB.objects[modelName][0].(map[string]interface{})["username"])
+
+	// Databases that can't check the version in the update are refused
+	_, admin, _ = newVersionedAdmin(t, newMockDBInterface())
+	_, err = admin.updateObject(context.Background(), "1", map[string]interface{}{"username": "jack", "version": "1"})
+	assert.ErrorContains(t, err, "versioned updates")
+}
+
+// Document mimics an Ent entity with an integer version
+type Document struct {
+	ID      int     `json:"id,omitempty"`
+	Title   string  `json:"title,omitempty"`
+	Version int     `json:"version,omitempty"`
+	Summary *string `json:"summary,omitempty"`
+}
+
+// documentPredicate mimics an Ent-generated predicate type
+type documentPredicate func(*entsql.Selector)
+
+// documentMutation mimics an Ent mutation, which only takes field values
+// of the field's type
+type documentMutation struct {
+	set     map[string]ent.Value
+	add     map[string]ent.Value
+	cleared []string
+}
+
+func (m *documentMutation) SetField(name string, value ent.Value) error {
+	if _, ok := value.(string); !ok || (name != "title" && name != "summary") {
+		return fmt.Errorf("unknown field %s or type %T", name, value)
+	}
+	m.set[name] = value
+	return nil
+}
+
+func (m *documentMutation) ClearField(name string) error {
+	if name != "summary" {
+		return fmt.Errorf("unknown nullable field %s", name)
+	}
+	m.cleared = append(m.cleared, name)
+	return nil
+}
+
+func (m *documentMutation) AddField(name string, value ent.Value) error {
+	if _, ok := value.(int); !ok || name != "version" {
+		return fmt.Errorf("unknown numeric field %s or type %T", name, value)
+	}
+	m.add[name] = value
+	return nil
+}
+
+// documentSelector selects from the SQLite documents table with predicates
+func documentSelector(predicates []documentPredicate) *entsql.Selector {
+	selector := entsql.Dialect(dialect.SQLite).Select("id", "title", "version", "summary").From(entsql.Table("documents"))
+	for _, p := range predicates {
+		p(selector)
+	}
+	return selector
+}
+
+// documentConn is a database or a transaction on it
+type documentConn interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type documentUpdate struct {
+	db         documentConn
+	predicates []documentPredicate
+	mutation   *documentMutation
+}
+
+func (u *documentUpdate) Where(ps ...documentPredicate) *documentUpdate {
+	u.predicates = append(u.predicates, ps...)
+	return u
+}
+
+func (u *documentUpdate) Mutation() *documentMutation { return u.mutation }
+
+func (u *documentUpdate) Save(ctx context.Context) (int, error) {
+	update := entsql.Dialect(dialect.SQLite).Update("documents").Where(documentSelector(u.predicates).P())
+	for name, value := range u.mutation.set {
+		update.Set(name, value)
+	}
+	for name, value := range u.mutation.add {
+		update.Add(name, value)
+	}
+	for _, name := range u.mutation.cleared {
+		update.SetNull(name)
+	}
+	query, args := update.Query()
+	result, err := u.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return int(n), err
+}
+
+type documentQuery struct {
+	db         documentConn
+	predicates []documentPredicate
+}
+
+func (q *documentQuery) Where(ps ...documentPredicate) *documentQuery {
+	q.predicates = append(q.predicates, ps...)
+	return q
+}
+
+func (q *documentQuery) Count(ctx context.Context) (int, error) {
+	query, args := documentSelector(q.predicates).Count().Query()
+	var n int
+	err := q.db.QueryRowContext(ctx, query, args...).Scan(&n)
+	return n, err
+}
+
+func (q *documentQuery) Only(ctx context.Context) (*Document, error) {
+	query, args := documentSelector(q.predicates).Query()
+	var doc Document
+	if err := q.db.QueryRowContext(ctx, query, args...).Scan(&doc.ID, &doc.Title, &doc.Version, &doc.Summary); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+type documentClient struct{ db documentConn }
+
+func (c *documentClient) Update() *documentUpdate {
+	return &documentUpdate{db: c.db, mutation: &documentMutation{set: map[string]ent.Value{}, add: map[string]ent.Value{}}}
+}
+
+func (c *documentClient) Query() *documentQuery { return &documentQuery{db: c.db} }
+
+// documentEntClient mimics an Ent client of documents that starts
+// transactions
+type documentEntClient struct {
+	Document *documentClient
+	db       *sql.DB
+}
+
+func (c *documentEntClient) Tx(ctx context.Context) (*documentTx, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &documentTx{Tx: tx}, nil
+}
+
+// documentTx mimics an Ent transaction
+type documentTx struct {
+	*sql.Tx
+}
+
+func (tx *documentTx) Client() *documentEntClient {
+	return &documentEntClient{Document: &documentClient{db: tx.Tx}}
+}
+
+// newDocumentDB stores a draft document with version 3 and a summary in an
+// in-memory SQLite table
+func newDocumentDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec("CREATE TABLE documents (id INTEGER PRIMARY KEY, title TEXT, version INTEGER, summary TEXT)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO documents (id, title, version, summary) VALUES (1, 'draft', 3, 'first')")
+	require.NoError(t, err)
+	return db
+}
+
+func TestEntDatabaseInterfaceUpdateIfVersion(t *testing.T) {
+	db := newDocumentDB(t)
+	entDB := NewEntDatabaseInterface(&documentEntClient{Document: &documentClient{db: db}, db: db})
+	ctx := context.Background()
+
+	// Form text is converted to the fields' types, and nillable fields
+	// are cleared
+	obj, err := entDB.UpdateIfVersion(ctx, &Document{}, "1", "version", "3", map[string]interface{}{"title": "final", "summary": nil})
+	require.NoError(t, err)
+	assert.Equal(t, &Document{ID: 1, Title: "final", Version: 4}, obj)
+
+	_, err = entDB.UpdateIfVersion(ctx, &Document{}, "1", "version", 4, map[string]interface{}{"title": nil})
+	assert.ErrorContains(t, err, "can't be cleared")
+
+	// The old version no longer matches in the UPDATE
+	_, err = entDB.UpdateIfVersion(ctx, &Document{}, "1", "version", 3.0, map[string]interface{}{"title": "stale"})
+	assert.ErrorIs(t, err, ErrStaleObject)
+
+	var title string
+	require.NoError(t, db.QueryRow("SELECT title FROM documents WHERE id = 1").Scan(&title))
+	assert.Equal(t, "final", title)
+}
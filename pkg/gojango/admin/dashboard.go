@@ -0,0 +1,264 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/epuerta9/gojango/pkg/gojango/cache"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultDashboardRecent is the number of recently created objects listed
+// per model on the dashboard when the request doesn't set one
+const DefaultDashboardRecent = 5
+
+// DashboardCountTTL is how long the dashboard's model counts are cached
+const DashboardCountTTL = time.Minute
+
+// Dashboard summarizes the registered models for the admin index
+type Dashboard struct {
+	Models        []DashboardModel `json:"models"`
+	RecentActions []LogEntry       `json:"recent_actions"`
+}
+
+// DashboardModel holds the row count and most recently created objects of
+// a model
+type DashboardModel struct {
+	App               string        `json:"app"`
+	Model             string        `json:"model"`
+	VerboseNamePlural string        `json:"verbose_name_plural"`
+	Count             int           `json:"count"`
+	Recent            []interface{} `json:"recent"`
+}
+
+// LogEntry records an action an admin user took on an object
+type LogEntry struct {
+	ID         int64     `json:"id,omitempty"`
+	ActionTime time.Time `json:"action_time"`
+	User       string    `json:"user"`
+	Model      string    `json:"model"`
+	ObjectID   string    `json:"object_id"`
+	Action     string    `json:"action"`
+	Message    string    `json:"message"`
+}
+
+// ActionLog provides the recent admin actions shown on the dashboard
+type ActionLog interface {
+	RecentActions(ctx context.Context, limit int) ([]LogEntry, error)
+}
+
+// SetActionLog sets the log of admin actions shown on the dashboard. Without
+// one the dashboard lists no recent actions.
+func (s *Site) SetActionLog(log ActionLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.actionLog = log
+}
+
+// ActionLog returns the site's log of admin actions, or nil if none is set
+func (s *Site) ActionLog() ActionLog {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.actionLog
+}
+
+// SetCache sets the cache the dashboard keeps model counts in for
+// DashboardCountTTL, so counts may lag behind by that long. Passing nil
+// counts on every request.
+func (s *Site) SetCache(c cache.Cache) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = c
+}
+
+// Cache returns the site's cache, or nil if none is set
+func (s *Site) Cache() cache.Cache {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cache
+}
+
+// Dashboard counts the objects of each model user may view and lists up to
+// recent of the most recently created ones, querying through the site's Ent
+// client. recent defaults to DefaultDashboardRecent and is capped at the
+// site's maximum page size. Models the client can't query, and every model
+// when no client is set, report zero objects.
+func (s *Site) Dashboard(ctx context.Context, user interface{}, recent int) (*Dashboard, error) {
+	s.mu.RLock()
+	if recent <= 0 {
+		recent = DefaultDashboardRecent
+	}
+	if s.maxPageSize > 0 && recent > s.maxPageSize {
+		recent = s.maxPageSize
+	}
+	client := s.entClient
+	countCache := s.cache
+	keys := make([]string, 0, len(s.models))
+	for key := range s.models {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	admins := make([]*ModelAdmin, len(keys))
+	for i, key := range keys {
+		admins[i] = s.models[key]
+	}
+	s.mu.RUnlock()
+
+	dashboard := &Dashboard{Models: []DashboardModel{}, RecentActions: []LogEntry{}}
+	for i, admin := range admins {
+		if !s.canView(user, admin.model) {
+			continue
+		}
+
+		app, model := keys[i], keys[i]
+		if parts := strings.SplitN(keys[i], ".", 2); len(parts) == 2 {
+			app, model = parts[0], parts[1]
+		}
+		entry := DashboardModel{
+			App:               app,
+			Model:             model,
+			VerboseNamePlural: admin.verboseNamePlural,
+			Recent:            []interface{}{},
+		}
+		if client != nil {
+			entry.Count = cachedModelCount(ctx, countCache, keys[i], client, admin.model)
+			if objects, err := entRecentObjects(ctx, client, admin.model, admin.createdField(), recent); err == nil {
+				entry.Recent = objects
+			}
+		}
+		dashboard.Models = append(dashboard.Models, entry)
+	}
+
+	if log := s.ActionLog(); log != nil {
+		actions, err := log.RecentActions(ctx, recent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get recent actions: %w", err)
+		}
+		dashboard.RecentActions = actions
+	}
+	return dashboard, nil
+}
+
+// createdField returns the field recording when objects were created: the
+// first auto-now-add field, created_at when the model has one, or the ID
+func (ma *ModelAdmin) createdField() string {
+	if len(ma.autoNowAdd) > 0 {
+		return ma.autoNowAdd[0]
+	}
+	modelType := reflect.TypeOf(ma.model)
+	for modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType != nil && modelType.Kind() == reflect.Struct {
+		if _, ok := modelType.FieldByName("CreatedAt"); ok {
+			return "created_at"
+		}
+	}
+	return "id"
+}
+
+// cachedModelCount counts the objects of a model through the cache, or
+// directly when c is nil. Failed counts are zero and aren't cached.
+func cachedModelCount(ctx context.Context, c cache.Cache, modelKey string, client interface{}, model interface{}) int {
+	if c == nil {
+		count, _ := entModelCount(ctx, client, model)
+		return count
+	}
+
+	value, err := c.GetOrSet(ctx, "admin:count:"+modelKey, DashboardCountTTL, func() ([]byte, error) {
+		count, err := entModelCount(ctx, client, model)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(strconv.Itoa(count)), nil
+	})
+	if err != nil {
+		return 0
+	}
+	count, _ := strconv.Atoi(string(value))
+	return count
+}
+
+// entModelCount counts all objects of a model
+func entModelCount(ctx context.Context, client interface{}, model interface{}) (int, error) {
+	query, err := entModelQuery(client, model)
+	if err != nil {
+		return 0, err
+	}
+	count, err := callEntQuery(query, "Count", ctx)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := count.(int)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count type %T", count)
+	}
+	return n, nil
+}
+
+// entRecentObjects returns up to limit objects of a model, newest first by
+// the given column
+func entRecentObjects(ctx context.Context, client interface{}, model interface{}, column string, limit int) ([]interface{}, error) {
+	query, err := entModelQuery(client, model)
+	if err != nil {
+		return nil, err
+	}
+
+	order := query.MethodByName("Order")
+	if !order.IsValid() || order.Type().NumIn() != 1 || !order.Type().IsVariadic() {
+		return nil, fmt.Errorf("query %s has no Order method", query.Type())
+	}
+	// Ent order options are named func(*sql.Selector) types, like predicates
+	optionType := order.Type().In(0).Elem()
+	option := reflect.ValueOf(entsql.OrderByField(column, entsql.OrderDesc()).ToFunc())
+	if !option.Type().ConvertibleTo(optionType) {
+		return nil, fmt.Errorf("unsupported order option type %s", optionType)
+	}
+	query = order.Call([]reflect.Value{option.Convert(optionType)})[0]
+
+	limitMethod := query.MethodByName("Limit")
+	if !limitMethod.IsValid() || limitMethod.Type().NumIn() != 1 || limitMethod.Type().In(0).Kind() != reflect.Int {
+		return nil, fmt.Errorf("query %s has no Limit method", query.Type())
+	}
+	query = limitMethod.Call([]reflect.Value{reflect.ValueOf(limit)})[0]
+
+	all, err := callEntQuery(query, "All", ctx)
+	if err != nil {
+		return nil, err
+	}
+	objects := reflect.ValueOf(all)
+	if objects.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice of objects, got %T", all)
+	}
+	recent := make([]interface{}, objects.Len())
+	for i := range recent {
+		recent[i] = objects.Index(i).Interface()
+	}
+	return recent, nil
+}
+
+// handleAPIDashboard returns the dashboard, listing the number of recently
+// created objects per model given by the recent parameter
+func (s *Site) handleAPIDashboard(c *gin.Context) {
+	recent, _ := strconv.Atoi(c.Query("recent"))
+	dashboard, err := s.Dashboard(c, CurrentUser(c), recent)
+	if err != nil {
+		render.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i, model := range dashboard.Models {
+		if admin, ok := s.GetModelAdmin(model.App + "." + model.Model); ok {
+			dashboard.Models[i].Recent = admin.SerializeObjects(model.Recent)
+		}
+	}
+	render.JSON(c, http.StatusOK, dashboard)
+}
@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/epuerta9/gojango/pkg/gojango/cache"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Note mimics an Ent entity with a creation time
+type Note struct {
+	ID        int       `json:"id,omitempty"`
+	Text      string    `json:"text,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// noteOrderOption mimics an Ent-generated order option type
+type noteOrderOption func(*entsql.Selector)
+
+type noteQuery struct {
+	notes []*Note
+	order []noteOrderOption
+	limit int
+}
+
+func (q *noteQuery) Order(o ...noteOrderOption) *noteQuery {
+	q.order = append(q.order, o...)
+	return q
+}
+
+func (q *noteQuery) Limit(limit int) *noteQuery {
+	q.limit = limit
+	return q
+}
+
+func (q *noteQuery) Count(ctx context.Context) (int, error) {
+	return len(q.notes), nil
+}
+
+// All supports ordering newest first by created_at only
+func (q *noteQuery) All(ctx context.Context) ([]*Note, error) {
+	selector := entsql.Dialect(dialect.SQLite).Select("*").From(entsql.Table("notes"))
+	for _, o := range q.order {
+		o(selector)
+	}
+	if query, _ := selector.Query(); query != "SELECT * FROM `notes` ORDER BY `notes`.`created_at` DESC" {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+
+	notes := append([]*Note(nil), q.notes...)
+	sort.Slice(notes, func(i, j int) bool { return notes[i].CreatedAt.After(notes[j].CreatedAt) })
+	if q.limit < len(notes) {
+		notes = notes[:q.limit]
+	}
+	return notes, nil
+}
+
+type noteClient struct{ notes []*Note }
+
+func (c *noteClient) Query() *noteQuery { return &noteQuery{notes: c.notes} }
+
+// dashboardEntClient mimics an Ent client without an Author model
+type dashboardEntClient struct {
+	Note *noteClient
+}
+
+type stubActionLog struct{ entries []LogEntry }
+
+func (l *stubActionLog) RecentActions(ctx context.Context, limit int) ([]LogEntry, error) {
+	if limit < len(l.entries) {
+		return l.entries[:limit], nil
+	}
+	return l.entries, nil
+}
+
+func newDashboardSite(t *testing.T) *Site {
+	site := NewSite("test")
+	require.NoError(t, site.Register(&Note{}, nil))
+	require.NoError(t, site.Register(&Author{}, nil))
+	return site
+}
+
+func TestDashboardWithoutEntClient(t *testing.T) {
+	site := newDashboardSite(t)
+
+	dashboard, err := site.Dashboard(context.Background(), nil, 0)
+	require.NoError(t, err)
+	require.Len(t, dashboard.Models, 2)
+	for _, model := range dashboard.Models {
+		assert.Zero(t, model.Count)
+		assert.Empty(t, model.Recent)
+	}
+	assert.Empty(t, dashboard.RecentActions)
+}
+
+func TestDashboard(t *testing.T) {
+	site := newDashboardSite(t)
+	now := time.Now()
+	var notes []*Note
+	for i := 1; i <= 7; i++ {
+		notes = append(notes, &Note{ID: i, Text: fmt.Sprintf("note %d", i), CreatedAt: now.Add(time.Duration(i) * time.Minute)})
+	}
+	site.SetEntClient(&dashboardEntClient{Note: &noteClient{notes: notes}})
+	site.SetActionLog(&stubActionLog{entries: []LogEntry{
+		{ActionTime: now, User: "admin", Model: getModelName(&Note{}), ObjectID: "7", Action: "create", Message: "Added."},
+	}})
+
+	dashboard, err := site.Dashboard(context.Background(), nil, 3)
+	require.NoError(t, err)
+	require.Len(t, dashboard.Models, 2)
+	author, note := dashboard.Models[0], dashboard.Models[1]
+	assert.Equal(t, "author", author.Model)
+	assert.Zero(t, author.Count, "models the client can't query report zero")
+	assert.Equal(t, "note", note.Model)
+	assert.Equal(t, 7, note.Count)
+	require.Len(t, note.Recent, 3)
+	assert.Equal(t, 7, note.Recent[0].(*Note).ID)
+	assert.Equal(t, 5, note.Recent[2].(*Note).ID)
+	require.Len(t, dashboard.RecentActions, 1)
+
+	// The REST endpoint
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	site.SetupRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/api/dashboard/?recent=2", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body struct {
+		Models []struct {
+			App    string                   `json:"app"`
+			Model  string                   `json:"model"`
+			Count  int                      `json:"count"`
+			Recent []map[string]interface{} `json:"recent"`
+		} `json:"models"`
+		RecentActions []map[string]interface{} `json:"recent_actions"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Models, 2)
+	assert.Equal(t, 7, body.Models[1].Count)
+	require.Len(t, body.Models[1].Recent, 2)
+	assert.Equal(t, "note 7", body.Models[1].Recent[0]["text"])
+	require.Len(t, body.RecentActions, 1)
+	assert.Equal(t, "create", body.RecentActions[0]["action"])
+
+	// The gRPC method
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.GetDashboard(context.Background(), connect.NewRequest(&adminpb.GetDashboardRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Models, 2)
+	assert.Equal(t, int32(7), resp.Msg.Models[1].Count)
+	require.Len(t, resp.Msg.Models[1].RecentObjects, DefaultDashboardRecent)
+	assert.Equal(t, "7", resp.Msg.Models[1].RecentObjects[0].Id)
+	require.Len(t, resp.Msg.RecentActions, 1)
+	assert.Equal(t, "7", resp.Msg.RecentActions[0].ObjectId)
+}
+
+func TestDashboardCachesCounts(t *testing.T) {
+	site := newDashboardSite(t)
+	client := &noteClient{notes: []*Note{{ID: 1, CreatedAt: time.Now()}}}
+	site.SetEntClient(&dashboardEntClient{Note: client})
+	c := cache.NewMemoryCache(0)
+	site.SetCache(c)
+
+	dashboard, err := site.Dashboard(context.Background(), nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dashboard.Models[1].Count)
+
+	client.notes = append(client.notes, &Note{ID: 2, CreatedAt: time.Now()})
+	dashboard, err = site.Dashboard(context.Background(), nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, dashboard.Models[1].Count, "counts are served from the cache")
+	assert.Len(t, dashboard.Models[1].Recent, 2, "recent objects aren't cached")
+
+	require.NoError(t, c.Delete(context.Background(), "admin:count:"+getModelName(&Note{})))
+	dashboard, err = site.Dashboard(context.Background(), nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, dashboard.Models[1].Count)
+
+	_, cached, _ := c.Get(context.Background(), "admin:count:"+getModelName(&Author{}))
+	assert.False(t, cached, "failed counts aren't cached")
+}
+
+func TestDashboardHidesModelsUserCannotView(t *testing.T) {
+	site := newDashboardSite(t)
+	site.SetPermissionChecker(&modelPermissionChecker{viewable: getModelName(&Note{})})
+
+	dashboard, err := site.Dashboard(context.Background(), "staff", 0)
+	require.NoError(t, err)
+	require.Len(t, dashboard.Models, 1)
+	assert.Equal(t, "note", dashboard.Models[0].Model)
+}
+
+// modelPermissionChecker allows viewing a single model
+type modelPermissionChecker struct {
+	AllowAllPermissions
+	viewable string
+}
+
+func (c *modelPermissionChecker) HasViewPermission(user interface{}, obj interface{}) bool {
+	return getModelName(obj) == c.viewable
+}
@@ -0,0 +1,381 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+// Levels of the date hierarchy
+const (
+	DateHierarchyYear  = "year"
+	DateHierarchyMonth = "month"
+	DateHierarchyDay   = "day"
+)
+
+// DateHierarchy lists the years of a model's date hierarchy field, or the
+// months or days under the selected year and month, with object counts
+type DateHierarchy struct {
+	Field   string       `json:"field"`
+	Level   string       `json:"level"`
+	Year    int          `json:"year,omitempty"`
+	Month   int          `json:"month,omitempty"`
+	Buckets []DateBucket `json:"buckets"`
+}
+
+// DateBucket counts the objects of a year, month or day. Params are the
+// list parameters selecting it.
+type DateBucket struct {
+	Value  int               `json:"value"`
+	Label  string            `json:"label"`
+	Count  int               `json:"count"`
+	Params map[string]string `json:"params"`
+}
+
+// DateCounter is implemented by database interfaces that count a model's
+// objects matching filters per year, month or day of a date field in the
+// database, e.g. with GROUP BY on the date truncated in loc. It returns
+// ErrDateGroupingUnsupported when the database can't bucket dates in loc.
+// Without it, dates are bucketed after loading every matching object.
+type DateCounter interface {
+	CountByDate(ctx context.Context, model interface{}, filters map[string]interface{}, field, level string, loc *time.Location) (map[int]int, error)
+}
+
+// ErrDateGroupingUnsupported is returned by a DateCounter that can't group
+// dates in a time zone, falling back to bucketing loaded objects
+var ErrDateGroupingUnsupported = errors.New("date grouping not supported")
+
+// SetDateHierarchy sets the date field the list view drills down by year,
+// month and day, like Django's date_hierarchy. The list is filtered by the
+// <field>__year, <field>__month and <field>__day parameters.
+func (ma *ModelAdmin) SetDateHierarchy(field string) *ModelAdmin {
+	ma.dateHierarchy = field
+	return ma
+}
+
+// DateHierarchy returns the date hierarchy field, or "" when there is none
+func (ma *ModelAdmin) DateHierarchy() string {
+	return ma.dateHierarchy
+}
+
+// timeZone returns the location dates are bucketed and selected in
+func (ma *ModelAdmin) timeZone() *time.Location {
+	if ma.location != nil {
+		return ma.location
+	}
+	return time.Local
+}
+
+// dateHierarchyParam returns the list parameter selecting a level
+func (ma *ModelAdmin) dateHierarchyParam(level string) string {
+	return ma.dateHierarchy + "__" + level
+}
+
+// dateHierarchySelection parses the selected year, month and day. A month
+// counts only with a year and a day only with a month, and out of range
+// values are ignored.
+func (ma *ModelAdmin) dateHierarchySelection(query url.Values) (year, month, day int) {
+	year, err := strconv.Atoi(query.Get(ma.dateHierarchyParam(DateHierarchyYear)))
+	if err != nil || year < 1 {
+		return 0, 0, 0
+	}
+	month, err = strconv.Atoi(query.Get(ma.dateHierarchyParam(DateHierarchyMonth)))
+	if err != nil || month < 1 || month > 12 {
+		return year, 0, 0
+	}
+	day, err = strconv.Atoi(query.Get(ma.dateHierarchyParam(DateHierarchyDay)))
+	if err != nil || day < 1 || day > daysIn(year, time.Month(month)) {
+		return year, month, 0
+	}
+	return year, month, day
+}
+
+// addDateHierarchyFilters adds the range of the selected year, month or day
+// in the site's time zone
+func (ma *ModelAdmin) addDateHierarchyFilters(filters map[string]interface{}, query url.Values) {
+	if ma.dateHierarchy == "" {
+		return
+	}
+	year, month, day := ma.dateHierarchySelection(query)
+	if year == 0 {
+		return
+	}
+
+	loc := ma.timeZone()
+	var start, end time.Time
+	switch {
+	case day != 0:
+		start = time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+		end = start.AddDate(0, 0, 1)
+	case month != 0:
+		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, loc)
+		end = start.AddDate(0, 1, 0)
+	default:
+		start = time.Date(year, 1, 1, 0, 0, 0, 0, loc)
+		end = start.AddDate(1, 0, 0)
+	}
+	filters[ma.dateHierarchy+"__gte"] = start
+	filters[ma.dateHierarchy+"__lt"] = end
+}
+
+// isDateHierarchyParam reports whether key is a date hierarchy parameter
+func (ma *ModelAdmin) isDateHierarchyParam(key string) bool {
+	if ma.dateHierarchy == "" {
+		return false
+	}
+	switch key {
+	case ma.dateHierarchyParam(DateHierarchyYear), ma.dateHierarchyParam(DateHierarchyMonth), ma.dateHierarchyParam(DateHierarchyDay):
+		return true
+	}
+	return false
+}
+
+// GetDateHierarchy counts the objects matching the list filters and search
+// in query by year, or by month or day under the year and month selected in
+// query. Days are listed when a day is selected, so the other days of its
+// month stay reachable.
+func (ma *ModelAdmin) GetDateHierarchy(ctx context.Context, query url.Values) (*DateHierarchy, error) {
+	if ma.dateHierarchy == "" {
+		return nil, fmt.Errorf("%s has no date hierarchy", ma.verboseName)
+	}
+	if ma.dbInterface == nil {
+		return nil, fmt.Errorf("database interface not set")
+	}
+
+	year, month, _ := ma.dateHierarchySelection(query)
+	hierarchy := &DateHierarchy{Field: ma.dateHierarchy, Level: DateHierarchyYear, Year: year, Month: month, Buckets: []DateBucket{}}
+	switch {
+	case month != 0:
+		hierarchy.Level = DateHierarchyDay
+	case year != 0:
+		hierarchy.Level = DateHierarchyMonth
+	}
+
+	selection := url.Values{}
+	for key, values := range query {
+		if key != ma.dateHierarchyParam(DateHierarchyDay) {
+			selection[key] = values
+		}
+	}
+	filters := ma.listFilters(selection)
+
+	counts, err := ma.countByDate(ctx, filters, hierarchy.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]int, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Ints(values)
+	for _, value := range values {
+		bucket := DateBucket{
+			Value:  value,
+			Count:  counts[value],
+			Params: map[string]string{},
+		}
+		switch hierarchy.Level {
+		case DateHierarchyYear:
+			bucket.Label = strconv.Itoa(value)
+			bucket.Params[ma.dateHierarchyParam(DateHierarchyYear)] = strconv.Itoa(value)
+		case DateHierarchyMonth:
+			bucket.Label = time.Month(value).String()
+			bucket.Params[ma.dateHierarchyParam(DateHierarchyYear)] = strconv.Itoa(year)
+			bucket.Params[ma.dateHierarchyParam(DateHierarchyMonth)] = strconv.Itoa(value)
+		case DateHierarchyDay:
+			bucket.Label = fmt.Sprintf("%s %d", time.Month(month), value)
+			bucket.Params[ma.dateHierarchyParam(DateHierarchyYear)] = strconv.Itoa(year)
+			bucket.Params[ma.dateHierarchyParam(DateHierarchyMonth)] = strconv.Itoa(month)
+			bucket.Params[ma.dateHierarchyParam(DateHierarchyDay)] = strconv.Itoa(value)
+		}
+		hierarchy.Buckets = append(hierarchy.Buckets, bucket)
+	}
+	return hierarchy, nil
+}
+
+// countByDate counts the objects matching filters by the year, month or day
+// of their date in the site's time zone, grouped in the database when it
+// supports it
+func (ma *ModelAdmin) countByDate(ctx context.Context, filters map[string]interface{}, level string) (map[int]int, error) {
+	loc := ma.timeZone()
+	if counter, ok := ma.dbInterface.(DateCounter); ok {
+		counts, err := counter.CountByDate(ctx, ma.model, filters, ma.dateHierarchy, level, loc)
+		if !errors.Is(err, ErrDateGroupingUnsupported) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to count objects: %w", err)
+			}
+			return counts, nil
+		}
+	}
+
+	counts := make(map[int]int)
+	for offset := 0; ; {
+		objects, total, err := ma.dbInterface.GetAll(ctx, ma.model, filters, nil, DefaultMaxPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get objects: %w", err)
+		}
+		for _, obj := range objects {
+			fields, ok := objectFields(obj)
+			if !ok {
+				continue
+			}
+			date, ok := dateValue(fields[ma.dateHierarchy])
+			if !ok {
+				continue
+			}
+			date = date.In(loc)
+			switch level {
+			case DateHierarchyYear:
+				counts[date.Year()]++
+			case DateHierarchyMonth:
+				counts[int(date.Month())]++
+			case DateHierarchyDay:
+				counts[date.Day()]++
+			}
+		}
+		// The database may return fewer objects than asked for
+		offset += len(objects)
+		if len(objects) == 0 || offset >= total {
+			break
+		}
+	}
+
+	return counts, nil
+}
+
+// dateValue converts a date field value, which may be a time, a pointer to
+// one or an RFC 3339 or YYYY-MM-DD string
+func dateValue(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, !v.IsZero()
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t, true
+		}
+		if t, err := time.Parse(DateRangeFilterLayout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// daysIn returns the number of days in a month
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// SetTimeZone sets the time zone date hierarchies bucket and select dates
+// in, for registered and future models. Without one the local time zone is
+// used.
+func (s *Site) SetTimeZone(loc *time.Location) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.location = loc
+	for _, admin := range s.models {
+		admin.location = loc
+	}
+}
+
+// TimeZone returns the site's time zone, or nil when it uses local time
+func (s *Site) TimeZone() *time.Location {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.location
+}
+
+// handleAPIDateHierarchy returns the date hierarchy buckets of a model for
+// the list parameters of the request
+func (s *Site) handleAPIDateHierarchy(c *gin.Context) {
+	modelKey := fmt.Sprintf("%s.%s", c.Param("app"), c.Param("model"))
+
+	admin, exists := s.GetModelAdmin(modelKey)
+	if !exists {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+	if admin.DateHierarchy() == "" {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model has no date hierarchy"})
+		return
+	}
+	if !s.canView(CurrentUser(c), admin.model) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+
+	hierarchy, err := admin.GetDateHierarchy(c, c.Request.URL.Query())
+	if err != nil {
+		render.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	render.JSON(c, http.StatusOK, hierarchy)
+}
+
+// GetDateHierarchy returns the date hierarchy buckets of a model
+func (h *AdminServiceHandler) GetDateHierarchy(
+	ctx context.Context,
+	req *connect.Request[adminpb.GetDateHierarchyRequest],
+) (*connect.Response[adminpb.GetDateHierarchyResponse], error) {
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	if modelAdmin.DateHierarchy() == "" {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, fmt.Errorf("%s has no date hierarchy", modelAdmin.modelName))
+	}
+	if !h.site.canView(UserFromContext(ctx), modelAdmin.model) {
+		return nil, permissionDenied("view", modelAdmin)
+	}
+
+	query := url.Values{}
+	for key, value := range req.Msg.Filters {
+		query.Set(key, value)
+	}
+	if req.Msg.Search != "" {
+		query.Set("q", req.Msg.Search)
+	}
+	if req.Msg.Year > 0 {
+		query.Set(modelAdmin.dateHierarchyParam(DateHierarchyYear), strconv.Itoa(int(req.Msg.Year)))
+		if req.Msg.Month > 0 {
+			query.Set(modelAdmin.dateHierarchyParam(DateHierarchyMonth), strconv.Itoa(int(req.Msg.Month)))
+		}
+	}
+
+	hierarchy, err := modelAdmin.GetDateHierarchy(ctx, query)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get date hierarchy of %s: %w", modelAdmin.modelName, err))
+	}
+
+	response := &adminpb.GetDateHierarchyResponse{
+		Field: hierarchy.Field,
+		Level: hierarchy.Level,
+		Year:  int32(hierarchy.Year),
+		Month: int32(hierarchy.Month),
+	}
+	for _, bucket := range hierarchy.Buckets {
+		response.Buckets = append(response.Buckets, &adminpb.DateBucket{
+			Value:  int32(bucket.Value),
+			Label:  bucket.Label,
+			Count:  int32(bucket.Count),
+			Params: bucket.Params,
+		})
+	}
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,271 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Story mimics an Ent entity with a publication date
+type Story struct {
+	ID          int       `json:"id"`
+	Title       string    `json:"title"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// dateDBInterface is a mock database that honours search, exact-match and
+// __gte/__lt time filters, and returns small pages
+type dateDBInterface struct {
+	*mockDBInterface
+}
+
+func (m *dateDBInterface) GetAll(ctx context.Context, model interface{}, filters map[string]interface{}, ordering []string, limit, offset int) ([]interface{}, int, error) {
+	var matched []interface{}
+	for _, obj := range m.objects[getModelName(model)] {
+		story := obj.(*Story)
+		matches := true
+		for key, value := range filters {
+			switch key {
+			case "__search":
+				query := value.(map[string]interface{})["title__icontains"].(string)
+				matches = matches && strings.Contains(strings.ToLower(story.Title), strings.ToLower(query))
+			case "published_at__gte":
+				matches = matches && !story.PublishedAt.Before(value.(time.Time))
+			case "published_at__lt":
+				matches = matches && story.PublishedAt.Before(value.(time.Time))
+			case "title":
+				matches = matches && story.Title == value
+			}
+		}
+		if matches {
+			matched = append(matched, obj)
+		}
+	}
+
+	total := len(matched)
+	if limit > 2 {
+		limit = 2
+	}
+	start, end := offset, offset+limit
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+	return matched[start:end], total, nil
+}
+
+func newDateHierarchySite(t *testing.T) (*Site, *ModelAdmin) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	mockDB := &dateDBInterface{mockDBInterface: newMockDBInterface()}
+	for i, published := range []string{
+		"2022-06-15T12:00:00Z",
+		"2023-03-01T12:00:00Z",
+		"2023-03-20T12:00:00Z",
+		"2023-11-05T12:00:00Z",
+		// New Year's Eve in New York
+		"2024-01-01T03:30:00Z",
+		"2024-02-10T12:00:00Z",
+		"2024-02-10T18:00:00Z",
+		"2024-02-11T12:00:00Z",
+	} {
+		date, err := time.Parse(time.RFC3339, published)
+		require.NoError(t, err)
+		title := "news"
+		if i%2 == 1 {
+			title = "opinion"
+		}
+		mockDB.objects[getModelName(&Story{})] = append(mockDB.objects[getModelName(&Story{})], &Story{ID: i + 1, Title: title, PublishedAt: date})
+	}
+
+	site := NewSite("test")
+	site.SetTimeZone(newYork)
+	admin := NewModelAdmin(&Story{}).SetDateHierarchy("published_at").SetSearchFields("title")
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&Story{}, admin))
+	return site, admin
+}
+
+func bucketCounts(hierarchy *DateHierarchy) map[int]int {
+	counts := make(map[int]int)
+	for _, bucket := range hierarchy.Buckets {
+		counts[bucket.Value] = bucket.Count
+	}
+	return counts
+}
+
+func TestDateHierarchyBuckets(t *testing.T) {
+	_, admin := newDateHierarchySite(t)
+	ctx := context.Background()
+
+	years, err := admin.GetDateHierarchy(ctx, url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, DateHierarchyYear, years.Level)
+	require.Len(t, years.Buckets, 3)
+	assert.Equal(t, map[int]int{2022: 1, 2023: 4, 2024: 3}, bucketCounts(years), "dates are bucketed in the site's time zone")
+	assert.Equal(t, "2023", years.Buckets[1].Label)
+	assert.Equal(t, map[string]string{"published_at__year": "2023"}, years.Buckets[1].Params)
+
+	months, err := admin.GetDateHierarchy(ctx, url.Values{"published_at__year": {"2023"}})
+	require.NoError(t, err)
+	assert.Equal(t, DateHierarchyMonth, months.Level)
+	assert.Equal(t, map[int]int{3: 2, 11: 1, 12: 1}, bucketCounts(months))
+	assert.Equal(t, "March", months.Buckets[0].Label)
+
+	days, err := admin.GetDateHierarchy(ctx, url.Values{"published_at__year": {"2024"}, "published_at__month": {"2"}, "published_at__day": {"10"}})
+	require.NoError(t, err)
+	assert.Equal(t, DateHierarchyDay, days.Level)
+	assert.Equal(t, map[int]int{10: 2, 11: 1}, bucketCounts(days), "the selected day doesn't hide the rest of its month")
+	assert.Equal(t, "February 10", days.Buckets[0].Label)
+
+	// Counts compose with filters and search
+	searched, err := admin.GetDateHierarchy(ctx, url.Values{"q": {"opinion"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{2023: 2, 2024: 2}, bucketCounts(searched))
+	filtered, err := admin.GetDateHierarchy(ctx, url.Values{"filter_title": {"news"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{2022: 1, 2023: 2, 2024: 1}, bucketCounts(filtered))
+
+	// The list view is filtered by the selection
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	list, err := admin.GetListData(c, url.Values{"published_at__year": {"2024"}, "published_at__month": {"2"}, "published_at__day": {"10"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+	list, err = admin.GetListData(c, url.Values{"published_at__year": {"2023"}, "q": {"opinion"}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, list.Total)
+}
+
+func TestDateHierarchyAPI(t *testing.T) {
+	site, _ := newDateHierarchySite(t)
+	parts := strings.SplitN(getModelName(&Story{}), ".", 2)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	site.SetupRoutes(router)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/api/date_hierarchy/"+parts[0]+"/"+parts[1]+"?published_at__year=2024", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var hierarchy DateHierarchy
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hierarchy))
+	assert.Equal(t, "published_at", hierarchy.Field)
+	assert.Equal(t, DateHierarchyMonth, hierarchy.Level)
+	assert.Equal(t, map[int]int{2: 3}, bucketCounts(&hierarchy))
+
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.GetDateHierarchy(context.Background(), connect.NewRequest(&adminpb.GetDateHierarchyRequest{App: parts[0], Model: parts[1]}))
+	require.NoError(t, err)
+	assert.Equal(t, DateHierarchyYear, resp.Msg.Level)
+	require.Len(t, resp.Msg.Buckets, 3)
+	assert.Equal(t, int32(2023), resp.Msg.Buckets[1].Value)
+	assert.Equal(t, int32(4), resp.Msg.Buckets[1].Count)
+
+	// Models without a date hierarchy
+	require.NoError(t, site.Register(&Note{}, nil))
+	noteParts := strings.SplitN(getModelName(&Note{}), ".", 2)
+	_, err = handler.GetDateHierarchy(context.Background(), connect.NewRequest(&adminpb.GetDateHierarchyRequest{App: noteParts[0], Model: noteParts[1]}))
+	assert.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+}
+
+// storyPredicate and storyAggregate mimic Ent-generated predicate and
+// aggregate function types
+type storyPredicate func(*entsql.Selector)
+
+type storyAggregate func(*entsql.Selector) string
+
+// storyQuery runs aggregate queries against a SQLite stories table, as
+// Ent's generated Select builders do
+type storyQuery struct {
+	client     *storyClient
+	predicates []storyPredicate
+	fns        []storyAggregate
+}
+
+func (q *storyQuery) Where(ps ...storyPredicate) *storyQuery {
+	q.predicates = append(q.predicates, ps...)
+	return q
+}
+
+func (q *storyQuery) Aggregate(fns ...storyAggregate) *storyQuery {
+	q.fns = append(q.fns, fns...)
+	return q
+}
+
+func (q *storyQuery) Scan(ctx context.Context, v any) error {
+	selector := entsql.Dialect(dialect.SQLite).Select().From(entsql.Table("stories"))
+	for _, p := range q.predicates {
+		p(selector)
+	}
+	var columns []string
+	for _, fn := range q.fns {
+		columns = append(columns, fn(selector))
+	}
+	query, args := selector.Select(columns...).Query()
+
+	q.client.queries++
+	rows, err := q.client.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return entsql.ScanSlice(rows, v)
+}
+
+type storyClient struct {
+	db      *sql.DB
+	queries int
+}
+
+func (c *storyClient) Query() *storyQuery { return &storyQuery{client: c} }
+
+func TestDateHierarchyGroupsInDatabase(t *testing.T) {
+	_, admin := newDateHierarchySite(t)
+	admin.location = time.UTC
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	_, err = db.Exec("CREATE TABLE stories (id INTEGER PRIMARY KEY, title TEXT, published_at DATETIME)")
+	require.NoError(t, err)
+	for _, obj := range admin.dbInterface.(*dateDBInterface).objects[getModelName(&Story{})] {
+		story := obj.(*Story)
+		_, err := db.Exec("INSERT INTO stories (id, title, published_at) VALUES (?, ?, ?)", story.ID, story.Title, story.PublishedAt)
+		require.NoError(t, err)
+	}
+	stories := &storyClient{db: db}
+	admin.SetDatabaseInterface(NewEntDatabaseInterface(&struct{ Story *storyClient }{stories}))
+
+	hierarchy, err := admin.GetDateHierarchy(context.Background(), url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{2022: 1, 2023: 3, 2024: 4}, bucketCounts(hierarchy))
+
+	hierarchy, err = admin.GetDateHierarchy(context.Background(), url.Values{"published_at__year": {"2023"}, "q": {"opinion"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{3: 1, 11: 1}, bucketCounts(hierarchy))
+	assert.Equal(t, 2, stories.queries, "each level should be counted in one grouped query")
+
+	// SQLite can't group dates in other time zones, which are counted after
+	// loading the objects
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	_, err = admin.dbInterface.(DateCounter).CountByDate(context.Background(), &Story{}, nil, "published_at", DateHierarchyYear, newYork)
+	assert.ErrorIs(t, err, ErrDateGroupingUnsupported)
+}
@@ -0,0 +1,432 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	gojangodb "github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+// SetConnection sets the connection the Ent client was opened on, which
+// bulk operations size their workers by
+func (db *EntDatabaseInterface) SetConnection(conn *gojangodb.Connection) *EntDatabaseInterface {
+	db.conn = conn
+	return db
+}
+
+// MaxOpenConnections implements ConnectionPoolSizer from the connection's
+// pool statistics. It returns 0, leaving bulk operations uncapped, without
+// a connection.
+func (db *EntDatabaseInterface) MaxOpenConnections() int {
+	if db.conn == nil {
+		return 0
+	}
+	return db.conn.Stats().MaxOpenConnections
+}
+
+// InTransaction implements TransactionalDatabase with the Ent client's Tx
+// method. fn writes through an interface over the transaction's client,
+// and the transaction is rolled back when fn returns an error.
+func (db *EntDatabaseInterface) InTransaction(ctx context.Context, fn func(tx DatabaseInterface) error) error {
+	begin := reflect.ValueOf(db.client).MethodByName("Tx")
+	if !begin.IsValid() || begin.Type().NumIn() != 1 || begin.Type().NumOut() != 2 {
+		return fmt.Errorf("client %T has no Tx method", db.client)
+	}
+	out := begin.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	tx := out[0]
+
+	txClient := tx.MethodByName("Client")
+	commit, _ := tx.Interface().(interface{ Commit() error })
+	rollback, _ := tx.Interface().(interface{ Rollback() error })
+	if !txClient.IsValid() || txClient.Type().NumIn() != 0 || txClient.Type().NumOut() != 1 || commit == nil || rollback == nil {
+		return fmt.Errorf("%s is not an Ent transaction", tx.Type())
+	}
+
+	txDB := &EntDatabaseInterface{client: txClient.Call(nil)[0].Interface(), conn: db.conn}
+	if err := fn(txDB); err != nil {
+		rollback.Rollback()
+		return err
+	}
+	if err := commit.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// BulkCreate implements BulkInserter with db.BulkInsert on the connection
+// into the model's table. It returns ErrBulkInsertUnsupported without a
+// connection, since the client doesn't expose the one it was opened on.
+func (db *EntDatabaseInterface) BulkCreate(ctx context.Context, model interface{}, rows []map[string]interface{}) (int, error) {
+	if db.conn == nil {
+		return 0, ErrBulkInsertUnsupported
+	}
+	table, err := entModelTable(ctx, db.client, model)
+	if err != nil {
+		return 0, err
+	}
+	return gojangodb.BulkInsert(ctx, db.conn, table, rows, gojangodb.BulkInsertOptions{})
+}
+
+// entModelTable returns the table of a model. Only the selector Ent builds
+// queries with knows it, so it runs a query matching no rows to read it.
+func entModelTable(ctx context.Context, client interface{}, model interface{}) (string, error) {
+	query, err := entModelQuery(client, model)
+	if err != nil {
+		return "", err
+	}
+	var table string
+	query, err = entWhere(query, func(s *entsql.Selector) {
+		table = s.TableName()
+		s.Where(entsql.False())
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := callEntQuery(query, "Count", ctx); err != nil {
+		return "", err
+	}
+	if table == "" {
+		return "", fmt.Errorf("failed to find the table of %T", model)
+	}
+	return table, nil
+}
+
+// timeZoneNamePattern matches time zone names safe to quote in SQL
+var timeZoneNamePattern = regexp.MustCompile(`^[A-Za-z0-9_+\-/]+$`)
+
+// CountByDate implements DateCounter with a single Ent aggregate query,
+// SELECT <part of the date>, COUNT(*) ... GROUP BY <part of the date>,
+// extracting the year, month or day in loc. PostgreSQL and MySQL convert
+// dates to loc by name, which excludes the unnamed local time zone, and
+// SQLite only groups dates in UTC.
+func (db *EntDatabaseInterface) CountByDate(ctx context.Context, model interface{}, filters map[string]interface{}, field, level string, loc *time.Location) (map[int]int, error) {
+	builder, err := gojangodb.NewEntPredicateBuilder(model)
+	if err != nil {
+		return nil, err
+	}
+	predicates, err := builder.Build(filters)
+	if err != nil {
+		return nil, err
+	}
+	if !builder.HasField(field) {
+		return nil, fmt.Errorf("unknown date field: %s", field)
+	}
+
+	query, err := entModelQuery(db.client, model)
+	if err != nil {
+		return nil, err
+	}
+	for _, predicate := range predicates {
+		if query, err = entWhere(query, predicate); err != nil {
+			return nil, err
+		}
+	}
+
+	aggregate := query.MethodByName("Aggregate")
+	if !aggregate.IsValid() || aggregate.Type().NumIn() != 1 || !aggregate.Type().IsVariadic() || aggregate.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("query %s has no Aggregate method", query.Type())
+	}
+	aggregateType := aggregate.Type().In(0).Elem()
+
+	// The bucket expression depends on the dialect, which only the selector
+	// Ent builds the query with knows
+	var unsupported bool
+	bucket := reflect.ValueOf(func(s *entsql.Selector) string {
+		expr, ok := dateBucketExpr(s.Dialect(), s.C(field), level, loc)
+		if !ok {
+			// Skip the rows, which are counted after loading them instead
+			unsupported = true
+			s.Where(entsql.False())
+			expr = "NULL"
+		}
+		s.GroupBy(expr)
+		return entsql.As(expr, "bucket")
+	})
+	count := reflect.ValueOf(func(*entsql.Selector) string {
+		return entsql.As(entsql.Count("*"), "count")
+	})
+	if !bucket.Type().ConvertibleTo(aggregateType) {
+		return nil, fmt.Errorf("unsupported aggregate type %s", aggregateType)
+	}
+	selected := aggregate.Call([]reflect.Value{bucket.Convert(aggregateType), count.Convert(aggregateType)})[0]
+
+	scan := selected.MethodByName("Scan")
+	if !scan.IsValid() || scan.Type().NumIn() != 2 || scan.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("%s has no Scan method", selected.Type())
+	}
+	var rows []struct {
+		Bucket *int `sql:"bucket"`
+		Count  int  `sql:"count"`
+	}
+	out := scan.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(&rows)})
+	if unsupported {
+		return nil, ErrDateGroupingUnsupported
+	}
+	if err, _ := out[0].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(rows))
+	for _, row := range rows {
+		// Objects without a date aren't listed
+		if row.Bucket != nil {
+			counts[*row.Bucket] += row.Count
+		}
+	}
+	return counts, nil
+}
+
+// dateBucketExpr returns the SQL extracting the year, month or day of a
+// date column in loc, reporting false when the dialect can't convert dates
+// to loc
+func dateBucketExpr(dialectName, column, level string, loc *time.Location) (string, bool) {
+	name := loc.String()
+	switch dialectName {
+	case dialect.Postgres:
+		if name == "Local" || !timeZoneNamePattern.MatchString(name) {
+			return "", false
+		}
+		part := map[string]string{DateHierarchyYear: "YEAR", DateHierarchyMonth: "MONTH", DateHierarchyDay: "DAY"}[level]
+		return fmt.Sprintf("CAST(EXTRACT(%s FROM %s AT TIME ZONE '%s') AS INTEGER)", part, column, name), part != ""
+	case dialect.MySQL:
+		if name == "Local" || !timeZoneNamePattern.MatchString(name) {
+			return "", false
+		}
+		fn := map[string]string{DateHierarchyYear: "YEAR", DateHierarchyMonth: "MONTH", DateHierarchyDay: "DAYOFMONTH"}[level]
+		return fmt.Sprintf("%s(CONVERT_TZ(%s, '+00:00', '%s'))", fn, column, name), fn != ""
+	case dialect.SQLite:
+		if loc != time.UTC {
+			return "", false
+		}
+		format := map[string]string{DateHierarchyYear: "%Y", DateHierarchyMonth: "%m", DateHierarchyDay: "%d"}[level]
+		return fmt.Sprintf("CAST(strftime('%s', %s) AS INTEGER)", format, column), format != ""
+	}
+	return "", false
+}
+
+// entFieldMutation is the part of an Ent mutation that sets fields by name
+type entFieldMutation interface {
+	SetField(name string, value ent.Value) error
+	AddField(name string, value ent.Value) error
+	ClearField(name string) error
+}
+
+// UpdateIfVersion implements VersionedUpdater with a single Ent update,
+// UPDATE ... SET ..., version = version + 1 WHERE id = ? AND version = ?,
+// setting timestamp versions to the current time instead. It returns
+// ErrStaleObject when no row matched.
+func (db *EntDatabaseInterface) UpdateIfVersion(ctx context.Context, model interface{}, id interface{}, versionField string, version interface{}, data map[string]interface{}) (interface{}, error) {
+	idValue, err := entFieldValue(model, "id", id)
+	if err != nil {
+		return nil, err
+	}
+	versionValue, err := entFieldValue(model, versionField, version)
+	if err != nil {
+		return nil, err
+	}
+
+	update, err := entModelBuilder(db.client, model, "Update")
+	if err != nil {
+		return nil, err
+	}
+	update, err = entWhere(update, func(s *entsql.Selector) {
+		s.Where(entsql.And(entsql.EQ(s.C("id"), idValue), entsql.EQ(s.C(versionField), versionValue)))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mutationMethod := update.MethodByName("Mutation")
+	if !mutationMethod.IsValid() || mutationMethod.Type().NumIn() != 0 || mutationMethod.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("%s has no Mutation method", update.Type())
+	}
+	mutation, ok := mutationMethod.Call(nil)[0].Interface().(entFieldMutation)
+	if !ok {
+		return nil, fmt.Errorf("%s does not set fields by name", mutationMethod.Type().Out(0))
+	}
+	for field, value := range data {
+		if field == "id" || field == versionField {
+			continue
+		}
+		converted, err := entFieldValue(model, field, value)
+		if err != nil {
+			return nil, err
+		}
+		if converted == nil {
+			// Ent sets nillable fields to NULL by clearing them
+			if fieldType, _ := entFieldType(model, field); fieldType.Kind() != reflect.Ptr {
+				return nil, fmt.Errorf("%s can't be cleared", field)
+			}
+			err = mutation.ClearField(field)
+		} else {
+			err = mutation.SetField(field, converted)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if next, ok := versionIncrement(reflect.TypeOf(versionValue)); ok {
+		err = mutation.AddField(versionField, next)
+	} else {
+		err = mutation.SetField(versionField, time.Now())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	save := update.MethodByName("Save")
+	if !save.IsValid() || save.Type().NumIn() != 1 || save.Type().NumOut() != 2 {
+		return nil, fmt.Errorf("%s has no Save method", update.Type())
+	}
+	out := save.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	if n, _ := out[0].Interface().(int); n == 0 {
+		return nil, ErrStaleObject
+	}
+
+	query, err := entModelQuery(db.client, model)
+	if err != nil {
+		return nil, err
+	}
+	query, err = entWhere(query, func(s *entsql.Selector) {
+		s.Where(entsql.EQ(s.C("id"), idValue))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return callEntQuery(query, "Only", ctx)
+}
+
+// entWhere calls the Where method of an Ent builder with a predicate, which
+// Ent generates as a named func(*sql.Selector) type per model
+func entWhere(builder reflect.Value, predicate func(*entsql.Selector)) (reflect.Value, error) {
+	where := builder.MethodByName("Where")
+	if !where.IsValid() || where.Type().NumIn() != 1 || !where.Type().IsVariadic() || where.Type().NumOut() != 1 {
+		return reflect.Value{}, fmt.Errorf("%s has no Where method", builder.Type())
+	}
+	predicateType := where.Type().In(0).Elem()
+	p := reflect.ValueOf(predicate)
+	if !p.Type().ConvertibleTo(predicateType) {
+		return reflect.Value{}, fmt.Errorf("unsupported predicate type %s", predicateType)
+	}
+	return where.Call([]reflect.Value{p.Convert(predicateType)})[0], nil
+}
+
+// versionIncrement returns 1 of an integer version type
+func versionIncrement(t reflect.Type) (interface{}, bool) {
+	if t == nil {
+		return nil, false
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.ValueOf(1).Convert(t).Interface(), true
+	}
+	return nil, false
+}
+
+// entFieldType returns the type of the model's field with the given JSON
+// name, a pointer for nillable fields
+func entFieldType(model interface{}, name string) (reflect.Type, error) {
+	modelType := reflect.TypeOf(model)
+	for modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model is not a struct: %T", model)
+	}
+
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		tagName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if tagName == name || (tagName == "" && strings.EqualFold(field.Name, name)) {
+			return field.Type, nil
+		}
+	}
+	return nil, fmt.Errorf("%s has no %s field", modelType.Name(), name)
+}
+
+// entFieldValue converts a submitted value, such as form text or a JSON
+// number, to the type of the model's field with the given JSON name, which
+// Ent mutations require
+func entFieldValue(model interface{}, name string, value interface{}) (interface{}, error) {
+	fieldType, err := entFieldType(model, name)
+	if err != nil {
+		return nil, err
+	}
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	v := reflect.ValueOf(derefValue(value))
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Type().AssignableTo(fieldType) {
+		return v.Interface(), nil
+	}
+
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		t, err := time.Parse(time.RFC3339Nano, fmt.Sprint(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		return t, nil
+	}
+	if v.Kind() == reflect.String {
+		text := strings.TrimSpace(v.String())
+		var parsed interface{}
+		var err error
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			parsed, err = strconv.ParseInt(text, 10, 64)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			parsed, err = strconv.ParseUint(text, 10, 64)
+		case reflect.Float32, reflect.Float64:
+			parsed, err = strconv.ParseFloat(text, 64)
+		case reflect.Bool:
+			parsed, err = strconv.ParseBool(text)
+		default:
+			return nil, fmt.Errorf("cannot convert %s to %s", name, fieldType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		v = reflect.ValueOf(parsed)
+	}
+	if fieldType.Kind() == reflect.String {
+		return reflect.ValueOf(fmt.Sprint(v.Interface())).Convert(fieldType).Interface(), nil
+	}
+	if !v.Type().ConvertibleTo(fieldType) {
+		return nil, fmt.Errorf("cannot convert %s from %T to %s", name, value, fieldType)
+	}
+	return v.Convert(fieldType).Interface(), nil
+}
+
+// derefValue follows pointers, returning nil for nil pointers
+func derefValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
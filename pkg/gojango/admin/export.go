@@ -0,0 +1,240 @@
+package admin
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// exportColumns returns the ordered columns of an export. The model's
+// list_display is used when set; otherwise the fields of the first object
+// are used, in declaration order for structs and sorted order for maps.
+func exportColumns(ma *ModelAdmin, objects []interface{}) []string {
+	if ma != nil && len(ma.listDisplay) > 0 {
+		columns := make([]string, 0, len(ma.listDisplay))
+		for _, name := range ma.listDisplay {
+			if name != "__str__" {
+				columns = append(columns, name)
+			}
+		}
+		if len(columns) > 0 {
+			return columns
+		}
+	}
+
+	if len(objects) == 0 {
+		return nil
+	}
+	return objectFieldNames(objects[0])
+}
+
+// objectFieldNames returns the field names of a map or struct object as
+// they are keyed by objectFields
+func objectFieldNames(obj interface{}) []string {
+	if m, ok := obj.(map[string]interface{}); ok {
+		names := make([]string, 0, len(m))
+		for name := range m {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t := reflect.TypeOf(obj)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+			tagName := strings.Split(jsonTag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		// Ent keeps loaded relations under edges, which has no flat value
+		if name == "edges" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// exportRow extracts the values of the given columns from an object.
+// Columns naming a list method are computed by it.
+func exportRow(ma *ModelAdmin, obj interface{}, columns []string) []interface{} {
+	fields, _ := objectFields(obj)
+
+	row := make([]interface{}, len(columns))
+	for i, column := range columns {
+		if ma != nil {
+			if method, ok := ma.listMethods[column]; ok {
+				row[i] = method(obj)
+				continue
+			}
+		}
+		row[i] = fields[column]
+	}
+	return row
+}
+
+// exportValue dereferences pointers so nil and optional values export as
+// the value they hold
+func exportValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// formatExportValue formats a value as exported text
+func formatExportValue(value interface{}) string {
+	switch v := exportValue(value).(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// xlsxWriter streams a single-sheet XLSX workbook. Rows are written to the
+// worksheet as they arrive, so large exports are not held in memory.
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+// xlsxStaticParts are the package parts that do not depend on the data
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Export" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+// newXLSXWriter writes the workbook structure and opens the worksheet
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+	for _, part := range xlsxStaticParts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`); err != nil {
+		return nil, err
+	}
+
+	return &xlsxWriter{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row. Numbers and booleans are written as typed cells,
+// everything else as text.
+func (x *xlsxWriter) WriteRow(values []interface{}) error {
+	x.rows++
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, x.rows)
+	for i, value := range values {
+		ref := xlsxColumnName(i) + strconv.Itoa(x.rows)
+		switch v := exportValue(value).(type) {
+		case nil:
+			continue
+		case bool:
+			cell := "0"
+			if v {
+				cell = "1"
+			}
+			fmt.Fprintf(&b, `<c r="%s" t="b"><v>%s</v></c>`, ref, cell)
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			fmt.Fprintf(&b, `<c r="%s"><v>%v</v></c>`, ref, v)
+		default:
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+			xml.EscapeText(&b, []byte(formatExportValue(v)))
+			b.WriteString(`</t></is></c>`)
+		}
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(x.sheet, b.String())
+	return err
+}
+
+// Close finishes the worksheet and the workbook archive
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, `</sheetData></worksheet>`); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// xlsxColumnName converts a zero-based column index to its letters, e.g. 27 -> "AB"
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"fmt"
+
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+)
+
+// Fieldset represents a grouped set of fields in admin forms, like Django's
+// fieldsets. Collapsed sections start folded.
+type Fieldset struct {
+	Name        string   `json:"name"`
+	Fields      []string `json:"fields"`
+	Classes     []string `json:"classes,omitempty"`
+	Collapsed   bool     `json:"collapsed,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// SetFieldsets groups the change form into sections. Fields not in any
+// fieldset are shown in an unnamed section after them. Register checks that
+// the fieldsets name fields of the model.
+func (ma *ModelAdmin) SetFieldsets(fieldsets []Fieldset) *ModelAdmin {
+	ma.fieldsets = make([]Fieldset, len(fieldsets))
+	for i, fieldset := range fieldsets {
+		fieldset.Fields = append([]string(nil), fieldset.Fields...)
+		fieldset.Classes = append([]string(nil), fieldset.Classes...)
+		ma.fieldsets[i] = fieldset
+	}
+	return ma
+}
+
+// Fieldsets returns the configured fieldsets
+func (ma *ModelAdmin) Fieldsets() []Fieldset {
+	return append([]Fieldset(nil), ma.fieldsets...)
+}
+
+// validateFieldsets checks that the fieldsets name fields or inlines of the
+// model, each at most once. Models whose fields can't be listed aren't
+// checked.
+func (ma *ModelAdmin) validateFieldsets() error {
+	if len(ma.fieldsets) == 0 {
+		return nil
+	}
+	names := objectFieldNames(ma.model)
+	if names == nil {
+		return nil
+	}
+
+	known := make(map[string]bool, len(names)+len(ma.inlines))
+	for _, name := range names {
+		known[name] = true
+	}
+	for _, inline := range ma.inlines {
+		known[inline.Name] = true
+	}
+
+	seen := make(map[string]string)
+	for _, fieldset := range ma.fieldsets {
+		for _, field := range fieldset.Fields {
+			if !known[field] {
+				return fmt.Errorf("fieldset %q of %s names unknown field %q", fieldset.Name, ma.modelName, field)
+			}
+			if other, ok := seen[field]; ok {
+				return fmt.Errorf("field %q of %s is in fieldsets %q and %q", field, ma.modelName, other, fieldset.Name)
+			}
+			seen[field] = fieldset.Name
+		}
+	}
+	return nil
+}
+
+// fieldsetsFor returns the fieldsets of a form with the given fields, ending
+// with an unnamed fieldset of the fields not in any, or nil when no
+// fieldsets are configured
+func (ma *ModelAdmin) fieldsetsFor(fields []string) []Fieldset {
+	if len(ma.fieldsets) == 0 {
+		return nil
+	}
+
+	grouped := make(map[string]bool)
+	fieldsets := ma.Fieldsets()
+	for _, fieldset := range fieldsets {
+		for _, field := range fieldset.Fields {
+			grouped[field] = true
+		}
+	}
+
+	var rest []string
+	for _, field := range fields {
+		if !grouped[field] {
+			rest = append(rest, field)
+		}
+	}
+	if len(rest) > 0 {
+		fieldsets = append(fieldsets, Fieldset{Fields: rest})
+	}
+	return fieldsets
+}
+
+// fieldsetsProto converts fieldsets for the gRPC schema
+func fieldsetsProto(fieldsets []Fieldset) []*adminpb.Fieldset {
+	var converted []*adminpb.Fieldset
+	for _, fieldset := range fieldsets {
+		converted = append(converted, &adminpb.Fieldset{
+			Name:        fieldset.Name,
+			Fields:      fieldset.Fields,
+			Collapsed:   fieldset.Collapsed,
+			Description: fieldset.Description,
+			Classes:     fieldset.Classes,
+		})
+	}
+	return converted
+}
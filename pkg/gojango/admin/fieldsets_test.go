@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Profile mimics an Ent entity with a long change form
+type Profile struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Bio      string `json:"bio"`
+	Website  string `json:"website"`
+	IsActive bool   `json:"is_active"`
+}
+
+func TestFieldsetsInSchema(t *testing.T) {
+	site := NewSite("test")
+	admin := NewModelAdmin(&Profile{}).SetFieldsets([]Fieldset{
+		{Name: "Contact", Fields: []string{"name", "email"}},
+		{Name: "About", Fields: []string{"bio", "website"}, Collapsed: true, Description: "Shown on the public profile"},
+	})
+	require.NoError(t, site.Register(&Profile{}, admin))
+
+	parts := strings.SplitN(getModelName(&Profile{}), ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.GetModelSchema(context.Background(), connect.NewRequest(&adminpb.GetModelSchemaRequest{App: parts[0], Model: parts[1]}))
+	require.NoError(t, err)
+
+	fieldsets := resp.Msg.Fieldsets
+	require.Len(t, fieldsets, 3)
+	assert.Equal(t, "Contact", fieldsets[0].Name)
+	assert.Equal(t, []string{"name", "email"}, fieldsets[0].Fields)
+	assert.False(t, fieldsets[0].Collapsed)
+	assert.Equal(t, "About", fieldsets[1].Name)
+	assert.Equal(t, []string{"bio", "website"}, fieldsets[1].Fields)
+	assert.True(t, fieldsets[1].Collapsed)
+	assert.Equal(t, "Shown on the public profile", fieldsets[1].Description)
+	assert.Equal(t, "", fieldsets[2].Name, "the remaining fields form a default group")
+	assert.Equal(t, []string{"id", "is_active"}, fieldsets[2].Fields)
+
+	// The REST schema groups the fields the database describes
+	admin.SetDatabaseInterface(newMockDBInterface())
+	schema := admin.GetSchema()
+	require.Len(t, schema.Fieldsets, 3)
+	assert.Equal(t, "About", schema.Fieldsets[1].Name)
+	assert.Equal(t, []string{"id", "username", "is_active", "created_at"}, schema.Fieldsets[2].Fields)
+}
+
+func TestFieldsetsWithoutConfiguration(t *testing.T) {
+	site := NewSite("test")
+	require.NoError(t, site.Register(&Profile{}, nil))
+
+	parts := strings.SplitN(getModelName(&Profile{}), ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.GetModelSchema(context.Background(), connect.NewRequest(&adminpb.GetModelSchemaRequest{App: parts[0], Model: parts[1]}))
+	require.NoError(t, err)
+	assert.Empty(t, resp.Msg.Fieldsets)
+}
+
+func TestFieldsetsValidation(t *testing.T) {
+	site := NewSite("test")
+
+	err := site.Register(&Profile{}, NewModelAdmin(&Profile{}).SetFieldsets([]Fieldset{
+		{Name: "Contact", Fields: []string{"name", "phone"}},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "phone"`)
+
+	err = site.Register(&Profile{}, NewModelAdmin(&Profile{}).SetFieldsets([]Fieldset{
+		{Name: "Contact", Fields: []string{"name", "email"}},
+		{Name: "Other", Fields: []string{"email"}},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"email"`)
+
+	_, registered := site.GetModelAdmin(getModelName(&Profile{}))
+	assert.False(t, registered)
+}
@@ -175,6 +175,78 @@ func (f *BooleanFilter) GetWidget() FilterWidget {
 	}
 }
 
+// RelationFilter provides filtering by a related object, e.g. a post's
+// author. Choices come from a data source that lists the related objects;
+// Site wires one up from its Ent client when none is set.
+type RelationFilter struct {
+	*BaseFilter
+	relatedModel interface{}
+	dataSource   func() []FilterChoice
+}
+
+// NewRelationFilter creates a new relation filter for the edge field, whose
+// foreign key column is field + "_id"
+func NewRelationFilter(field, title string, relatedModel interface{}) *RelationFilter {
+	return &RelationFilter{
+		BaseFilter:   NewBaseFilter(field, title),
+		relatedModel: relatedModel,
+	}
+}
+
+// SetDataSource sets the function that lists the related objects as
+// choices, with the related primary key as value
+func (f *RelationFilter) SetDataSource(source func() []FilterChoice) *RelationFilter {
+	f.dataSource = source
+	return f
+}
+
+// HasDataSource reports whether a data source has been set
+func (f *RelationFilter) HasDataSource() bool {
+	return f.dataSource != nil
+}
+
+// RelatedModel returns the model the filter's field points to
+func (f *RelationFilter) RelatedModel() interface{} {
+	return f.relatedModel
+}
+
+// Column returns the foreign key column the filter compares against
+func (f *RelationFilter) Column() string {
+	if strings.HasSuffix(f.field, "_id") {
+		return f.field
+	}
+	return f.field + "_id"
+}
+
+func (f *RelationFilter) Choices() []FilterChoice {
+	choices := []FilterChoice{{Value: "", Display: "All"}}
+	if f.dataSource != nil {
+		choices = append(choices, f.dataSource()...)
+	}
+	return choices
+}
+
+func (f *RelationFilter) ApplyFilter(query url.Values) map[string]interface{} {
+	value := query.Get(f.parameter)
+	if value == "" {
+		return nil
+	}
+	
+	return map[string]interface{}{
+		f.Column(): value,
+	}
+}
+
+func (f *RelationFilter) GetWidget() FilterWidget {
+	return FilterWidget{
+		Type:    "select",
+		Choices: f.Choices(),
+		Config: map[string]interface{}{
+			"related_model": getModelName(f.relatedModel),
+		},
+	}
+}
+
 // DateFilter provides filtering by date ranges
 type DateFilter struct {
 	*BaseFilter
@@ -242,6 +314,100 @@ func (f *DateFilter) GetWidget() FilterWidget {
 	}
 }
 
+// DateRangeFilterLayout is the format of the from/to date parameters
+const DateRangeFilterLayout = "2006-01-02"
+
+// DateRangeFilter provides filtering by an explicit date range read from the
+// filter_<field>_from and filter_<field>_to parameters
+type DateRangeFilter struct {
+	*BaseFilter
+}
+
+// NewDateRangeFilter creates a new date range filter
+func NewDateRangeFilter(field, title string) *DateRangeFilter {
+	return &DateRangeFilter{
+		BaseFilter: NewBaseFilter(field, title),
+	}
+}
+
+func (f *DateRangeFilter) Choices() []FilterChoice {
+	return []FilterChoice{} // Range filters don't have predefined choices
+}
+
+func (f *DateRangeFilter) IsActive(query url.Values) bool {
+	return query.Get(f.parameter+"_from") != "" || query.Get(f.parameter+"_to") != ""
+}
+
+func (f *DateRangeFilter) GetActiveValue(query url.Values) interface{} {
+	return map[string]string{
+		"from": query.Get(f.parameter + "_from"),
+		"to":   query.Get(f.parameter + "_to"),
+	}
+}
+
+// ApplyFilter returns __gte/__lte conditions for the supplied bounds. The to
+// date is inclusive, so its condition is the last instant of that day.
+// Reversed bounds are swapped and unparseable bounds are ignored.
+func (f *DateRangeFilter) ApplyFilter(query url.Values) map[string]interface{} {
+	from, hasFrom := f.parseDate(query.Get(f.parameter + "_from"))
+	to, hasTo := f.parseDate(query.Get(f.parameter + "_to"))
+	
+	if hasFrom && hasTo && from.After(to) {
+		from, to = to, from
+	}
+	
+	filters := make(map[string]interface{})
+	if hasFrom {
+		filters[f.field+"__gte"] = from
+	}
+	if hasTo {
+		filters[f.field+"__lte"] = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	
+	if len(filters) == 0 {
+		return nil
+	}
+	
+	return filters
+}
+
+func (f *DateRangeFilter) activeDisplay(query url.Values) string {
+	fromValue, toValue := query.Get(f.parameter+"_from"), query.Get(f.parameter+"_to")
+	
+	// Show reversed bounds the way ApplyFilter uses them
+	from, hasFrom := f.parseDate(fromValue)
+	to, hasTo := f.parseDate(toValue)
+	if hasFrom && hasTo && from.After(to) {
+		fromValue, toValue = toValue, fromValue
+	}
+	
+	return rangeDisplay(fromValue, toValue)
+}
+
+// parseDate parses a YYYY-MM-DD bound in local time
+func (f *DateRangeFilter) parseDate(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	date, err := time.ParseInLocation(DateRangeFilterLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func (f *DateRangeFilter) GetWidget() FilterWidget {
+	return FilterWidget{
+		Type:    "date_range",
+		Choices: f.Choices(),
+		Config: map[string]interface{}{
+			"from_parameter": f.parameter + "_from",
+			"to_parameter":   f.parameter + "_to",
+			"format":         DateRangeFilterLayout,
+		},
+	}
+}
+
 // TextFilter provides text-based filtering
 type TextFilter struct {
 	*BaseFilter
@@ -303,6 +469,21 @@ func (f *NumericRangeFilter) Choices() []FilterChoice {
 	return []FilterChoice{} // Range filters don't have predefined choices
 }
 
+func (f *NumericRangeFilter) IsActive(query url.Values) bool {
+	return query.Get(f.parameter+"_min") != "" || query.Get(f.parameter+"_max") != ""
+}
+
+func (f *NumericRangeFilter) GetActiveValue(query url.Values) interface{} {
+	return map[string]string{
+		"min": query.Get(f.parameter + "_min"),
+		"max": query.Get(f.parameter + "_max"),
+	}
+}
+
+func (f *NumericRangeFilter) activeDisplay(query url.Values) string {
+	return rangeDisplay(query.Get(f.parameter+"_min"), query.Get(f.parameter+"_max"))
+}
+
 func (f *NumericRangeFilter) ApplyFilter(query url.Values) map[string]interface{} {
 	minValue := query.Get(f.parameter + "_min")
 	maxValue := query.Get(f.parameter + "_max")
@@ -470,6 +651,63 @@ func (fs *FilterSet) ApplyFilters(query url.Values) map[string]interface{} {
 	return allFilters
 }
 
+// activeDisplayer is implemented by filters whose active value is not a
+// single choice, such as ranges
+type activeDisplayer interface {
+	activeDisplay(query url.Values) string
+}
+
+// GetActiveFilterSummary returns a human-readable "Title: value" entry for
+// each active filter, e.g. "Status: Published" or "Price: 10–100". Choice
+// values show their display text; values without a matching choice are
+// shown as submitted.
+func (fs *FilterSet) GetActiveFilterSummary(query url.Values) []string {
+	var summary []string
+	
+	for _, filter := range fs.filters {
+		if !filter.IsActive(query) {
+			continue
+		}
+		
+		var display string
+		if displayer, ok := filter.(activeDisplayer); ok {
+			display = displayer.activeDisplay(query)
+		} else {
+			display = choiceDisplay(filter.Choices(), fmt.Sprint(filter.GetActiveValue(query)))
+		}
+		if display != "" {
+			summary = append(summary, fmt.Sprintf("%s: %s", filter.Title(), display))
+		}
+	}
+	
+	return summary
+}
+
+// choiceDisplay returns the display text of the choice with the given value,
+// or the value itself when no choice matches
+func choiceDisplay(choices []FilterChoice, value string) string {
+	for _, choice := range choices {
+		if choice.Value != "" && choice.Value == value {
+			return choice.Display
+		}
+	}
+	return value
+}
+
+// rangeDisplay summarizes range bounds as "min–max", "≥ min" or "≤ max"
+func rangeDisplay(min, max string) string {
+	switch {
+	case min != "" && max != "":
+		return min + "–" + max
+	case min != "":
+		return "≥ " + min
+	case max != "":
+		return "≤ " + max
+	default:
+		return ""
+	}
+}
+
 // GetFilterData returns filter data for the frontend
 func (fs *FilterSet) GetFilterData(query url.Values) []map[string]interface{} {
 	var filterData []map[string]interface{}
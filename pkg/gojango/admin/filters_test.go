@@ -0,0 +1,245 @@
+package admin
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func localDate(t *testing.T, value string) time.Time {
+	date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	require.NoError(t, err)
+	return date
+}
+
+func endOfDay(t *testing.T, value string) time.Time {
+	return localDate(t, value).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
+
+func TestDateRangeFilter(t *testing.T) {
+	filter := NewDateRangeFilter("created_at", "")
+
+	tests := []struct {
+		name     string
+		query    url.Values
+		active   bool
+		expected map[string]interface{}
+	}{
+		{
+			name:   "no bounds",
+			query:  url.Values{},
+			active: false,
+		},
+		{
+			name:   "both bounds",
+			query:  url.Values{"filter_created_at_from": {"2024-01-01"}, "filter_created_at_to": {"2024-01-31"}},
+			active: true,
+			expected: map[string]interface{}{
+				"created_at__gte": localDate(t, "2024-01-01"),
+				"created_at__lte": endOfDay(t, "2024-01-31"),
+			},
+		},
+		{
+			name:     "from only",
+			query:    url.Values{"filter_created_at_from": {"2024-01-01"}},
+			active:   true,
+			expected: map[string]interface{}{"created_at__gte": localDate(t, "2024-01-01")},
+		},
+		{
+			name:     "to only",
+			query:    url.Values{"filter_created_at_to": {"2024-01-31"}},
+			active:   true,
+			expected: map[string]interface{}{"created_at__lte": endOfDay(t, "2024-01-31")},
+		},
+		{
+			name:   "reversed bounds are swapped",
+			query:  url.Values{"filter_created_at_from": {"2024-01-31"}, "filter_created_at_to": {"2024-01-01"}},
+			active: true,
+			expected: map[string]interface{}{
+				"created_at__gte": localDate(t, "2024-01-01"),
+				"created_at__lte": endOfDay(t, "2024-01-31"),
+			},
+		},
+		{
+			name:   "same day",
+			query:  url.Values{"filter_created_at_from": {"2024-01-15"}, "filter_created_at_to": {"2024-01-15"}},
+			active: true,
+			expected: map[string]interface{}{
+				"created_at__gte": localDate(t, "2024-01-15"),
+				"created_at__lte": endOfDay(t, "2024-01-15"),
+			},
+		},
+		{
+			name:     "invalid bound is ignored",
+			query:    url.Values{"filter_created_at_from": {"01/01/2024"}, "filter_created_at_to": {"2024-01-31"}},
+			active:   true,
+			expected: map[string]interface{}{"created_at__lte": endOfDay(t, "2024-01-31")},
+		},
+		{
+			name:   "invalid bounds only",
+			query:  url.Values{"filter_created_at_from": {"soon"}},
+			active: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.active, filter.IsActive(tt.query))
+
+			conditions := filter.ApplyFilter(tt.query)
+			if tt.expected == nil {
+				assert.Nil(t, conditions)
+				return
+			}
+			assert.Equal(t, tt.expected, conditions)
+		})
+	}
+}
+
+func TestDateRangeFilterWidget(t *testing.T) {
+	filter := NewDateRangeFilter("published_at", "")
+
+	widget := filter.GetWidget()
+	assert.Equal(t, "date_range", widget.Type)
+	assert.Empty(t, widget.Choices)
+	assert.Empty(t, filter.Choices())
+	assert.Equal(t, "filter_published_at_from", widget.Config["from_parameter"])
+	assert.Equal(t, "filter_published_at_to", widget.Config["to_parameter"])
+	assert.Equal(t, "Published At", filter.Title())
+
+	query := url.Values{"filter_published_at_to": {"2024-02-01"}}
+	assert.Equal(t, map[string]string{"from": "", "to": "2024-02-01"}, filter.GetActiveValue(query))
+
+	set := NewFilterSet()
+	set.AddFilter(filter)
+	assert.Equal(t, map[string]interface{}{"published_at__lte": endOfDay(t, "2024-02-01")}, set.ApplyFilters(query))
+}
+
+func TestRelationFilter(t *testing.T) {
+	filter := NewRelationFilter("author", "", &TestUser{})
+	assert.False(t, filter.HasDataSource())
+	assert.Equal(t, []FilterChoice{{Value: "", Display: "All"}}, filter.Choices())
+
+	filter.SetDataSource(func() []FilterChoice {
+		return []FilterChoice{
+			{Value: "1", Display: "john", Count: 3},
+			{Value: "2", Display: "jane", Count: 1},
+		}
+	})
+	assert.True(t, filter.HasDataSource())
+
+	widget := filter.GetWidget()
+	assert.Equal(t, "select", widget.Type)
+	require.Len(t, widget.Choices, 3)
+	assert.Equal(t, FilterChoice{Value: "1", Display: "john", Count: 3}, widget.Choices[1])
+	assert.Equal(t, getModelName(&TestUser{}), widget.Config["related_model"])
+
+	assert.Equal(t, "author_id", filter.Column())
+	assert.Nil(t, filter.ApplyFilter(url.Values{}))
+	assert.Equal(t, map[string]interface{}{"author_id": "2"}, filter.ApplyFilter(url.Values{"filter_author": {"2"}}))
+
+	// A field that already names the foreign key column is used as-is
+	assert.Equal(t, "author_id", NewRelationFilter("author_id", "", &TestUser{}).Column())
+}
+
+// filterRecordingDB records the filters passed to GetAll
+type filterRecordingDB struct {
+	*mockDBInterface
+	filters map[string]interface{}
+}
+
+func (m *filterRecordingDB) GetAll(ctx context.Context, model interface{}, filters map[string]interface{}, ordering []string, limit, offset int) ([]interface{}, int, error) {
+	m.filters = filters
+	return nil, 0, nil
+}
+
+func TestListDataUsesRelationFilter(t *testing.T) {
+	db := &filterRecordingDB{mockDBInterface: newMockDBInterface()}
+	admin := NewModelAdmin(&TestPost{}).
+		AddListFilter(NewRelationFilter("author", "", &TestUser{}).SetDataSource(func() []FilterChoice {
+			return []FilterChoice{{Value: "7", Display: "john"}}
+		})).
+		AddListFilter(NewDateRangeFilter("published_at", ""))
+	admin.SetDatabaseInterface(db)
+	assert.Equal(t, []string{"author", "published_at"}, admin.listFilter)
+
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	query := url.Values{
+		"filter_author":            {"7"},
+		"filter_published_at_from": {"2024-01-01"},
+		"filter_title":             {"Hello"},
+	}
+	listData, err := admin.GetListData(c, query)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]interface{}{
+		"author_id":         "7",
+		"published_at__gte": localDate(t, "2024-01-01"),
+		"title":             "Hello",
+	}, db.filters)
+
+	assert.Equal(t, []string{"Author: john", "Published At: ≥ 2024-01-01"}, listData.ActiveFilters)
+
+	filters := listData.Filters.(map[string]interface{})
+	author := filters["author"].(map[string]interface{})
+	assert.Equal(t, "select", author["type"])
+	assert.Len(t, author["choices"], 2)
+}
+
+func TestGetActiveFilterSummary(t *testing.T) {
+	set := NewFilterSet()
+	set.AddFilter(NewChoiceFilter("status", "", []FilterChoice{
+		{Value: "draft", Display: "Draft"},
+		{Value: "published", Display: "Published"},
+	}))
+	set.AddFilter(NewDateFilter("created_at", "Created"))
+	set.AddFilter(NewBooleanFilter("is_featured", ""))
+	set.AddFilter(NewTextFilter("title", "", false))
+	set.AddFilter(NewNumericRangeFilter("price", "", nil, nil))
+	set.AddFilter(NewDateRangeFilter("published_at", "Published"))
+
+	assert.Empty(t, set.GetActiveFilterSummary(url.Values{}))
+
+	summary := set.GetActiveFilterSummary(url.Values{
+		"filter_status":      {"published"},
+		"filter_created_at":  {"week"},
+		"filter_is_featured": {"false"},
+		"filter_title":       {"go"},
+		"filter_price_min":   {"10"},
+		"filter_price_max":   {"100"},
+	})
+	assert.Equal(t, []string{
+		"Status: Published",
+		"Created: Past 7 days",
+		"Is Featured: No",
+		"Title: go",
+		"Price: 10–100",
+	}, summary)
+}
+
+func TestGetActiveFilterSummaryEdgeCases(t *testing.T) {
+	set := NewFilterSet()
+	set.AddFilter(NewChoiceFilter("status", "", []FilterChoice{{Value: "draft", Display: "Draft"}}))
+	set.AddFilter(NewNumericRangeFilter("price", "", nil, nil))
+	set.AddFilter(NewDateRangeFilter("published_at", "Published"))
+
+	// Unknown choice values are shown raw instead of being dropped
+	assert.Equal(t, []string{"Status: archived"}, set.GetActiveFilterSummary(url.Values{"filter_status": {"archived"}}))
+
+	// Open-ended ranges
+	assert.Equal(t, []string{"Price: ≥ 10"}, set.GetActiveFilterSummary(url.Values{"filter_price_min": {"10"}}))
+	assert.Equal(t, []string{"Price: ≤ 100"}, set.GetActiveFilterSummary(url.Values{"filter_price_max": {"100"}}))
+
+	// Reversed date bounds are summarized in order
+	assert.Equal(t, []string{"Published: 2024-01-01–2024-01-31"}, set.GetActiveFilterSummary(url.Values{
+		"filter_published_at_from": {"2024-01-31"},
+		"filter_published_at_to":   {"2024-01-01"},
+	}))
+}
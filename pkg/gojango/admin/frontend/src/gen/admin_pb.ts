@@ -600,6 +600,13 @@ export class ListObjectsRequest extends Message<ListObjectsRequest> {
    */
   search = "";
 
+  /**
+   * Cursor from a previous response, for models using cursor pagination
+   *
+   * @generated from field: string cursor = 8;
+   */
+  cursor = "";
+
   constructor(data?: PartialMessage<ListObjectsRequest>) {
     super();
     proto3.util.initPartial(data, this);
@@ -615,6 +622,7 @@ export class ListObjectsRequest extends Message<ListObjectsRequest> {
     { no: 5, name: "ordering", kind: "scalar", T: 9 /* ScalarType.STRING */ },
     { no: 6, name: "filters", kind: "map", K: 9 /* ScalarType.STRING */, V: {kind: "scalar", T: 9 /* ScalarType.STRING */} },
     { no: 7, name: "search", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 8, name: "cursor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListObjectsRequest {
@@ -678,6 +686,18 @@ export class ListObjectsResponse extends Message<ListObjectsResponse> {
    */
   displayFields: string[] = [];
 
+  /**
+   * Cursors of the next and previous pages, for models using cursor pagination
+   *
+   * @generated from field: string next_cursor = 9;
+   */
+  nextCursor = "";
+
+  /**
+   * @generated from field: string prev_cursor = 10;
+   */
+  prevCursor = "";
+
   constructor(data?: PartialMessage<ListObjectsResponse>) {
     super();
     proto3.util.initPartial(data, this);
@@ -694,6 +714,8 @@ export class ListObjectsResponse extends Message<ListObjectsResponse> {
     { no: 6, name: "has_previous", kind: "scalar", T: 8 /* ScalarType.BOOL */ },
     { no: 7, name: "total_pages", kind: "scalar", T: 5 /* ScalarType.INT32 */ },
     { no: 8, name: "display_fields", kind: "scalar", T: 9 /* ScalarType.STRING */, repeated: true },
+    { no: 9, name: "next_cursor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
+    { no: 10, name: "prev_cursor", kind: "scalar", T: 9 /* ScalarType.STRING */ },
   ]);
 
   static fromBinary(bytes: Uint8Array, options?: Partial<BinaryReadOptions>): ListObjectsResponse {
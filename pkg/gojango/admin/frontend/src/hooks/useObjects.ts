@@ -1,3 +1,4 @@
+import { useEffect } from 'react'
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query'
 import { adminClient } from '@/services/client'
 
@@ -11,6 +12,9 @@ export function useListObjects(
     filters?: Record<string, string>
   } = {}
 ) {
+  // Refresh the list whenever the model changes on the server
+  useModelStream(app, model)
+
   return useQuery({
     queryKey: ['objects', app, model, options],
     queryFn: async () => {
@@ -28,6 +32,29 @@ export function useListObjects(
   })
 }
 
+// Subscribes to the model's live-update stream and refetches its objects
+// whenever one is created, updated or deleted
+export function useModelStream(app: string, model: string) {
+  const queryClient = useQueryClient()
+
+  useEffect(() => {
+    if (!app || !model || typeof EventSource === 'undefined') {
+      return
+    }
+
+    const source = new EventSource(`/admin/api/stream/${app}/${model}`)
+    source.addEventListener('change', (event) => {
+      const change = JSON.parse((event as MessageEvent).data)
+      queryClient.invalidateQueries({ queryKey: ['objects', app, model] })
+      if (change.id) {
+        queryClient.invalidateQueries({ queryKey: ['object', app, model, change.id] })
+      }
+    })
+
+    return () => source.close()
+  }, [app, model, queryClient])
+}
+
 export function useGetObject(app: string, model: string, id: string) {
   return useQuery({
     queryKey: ['object', app, model, id],
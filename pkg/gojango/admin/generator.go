@@ -8,12 +8,14 @@ import (
 	"strings"
 	"time"
 
+	gojangodb "github.com/epuerta9/gojango/pkg/gojango/db"
 	"github.com/gin-gonic/gin"
 )
 
 // EntDatabaseInterface implements DatabaseInterface for Ent models
 type EntDatabaseInterface struct {
 	client interface{} // Generic Ent client
+	conn   *gojangodb.Connection
 }
 
 // NewEntDatabaseInterface creates a new Ent database interface
@@ -2,37 +2,77 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"connectrpc.com/connect"
 	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AdminServiceHandler implements the gRPC AdminService
 type AdminServiceHandler struct {
 	site      *Site
+	mu        sync.Mutex
 	bridge    *EntBridge
-	entClient interface{} // Generic Ent client interface
+	entClient interface{} // Overrides the site's Ent client when set
 }
 
-// NewAdminServiceHandler creates a new admin service handler
+// NewAdminServiceHandler creates a new admin service handler. bridge may be
+// nil, in which case one is created for the Ent client on first use.
 func NewAdminServiceHandler(site *Site, bridge *EntBridge) *AdminServiceHandler {
 	return &AdminServiceHandler{
-		site:      site,
-		bridge:    bridge,
-		entClient: nil, // Will be set when an Ent client is available
+		site:   site,
+		bridge: bridge,
 	}
 }
 
-// SetEntClient sets the Ent client for database operations
+// SetEntClient sets an Ent client for this handler, overriding the site's
 func (h *AdminServiceHandler) SetEntClient(client interface{}) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.entClient = client
 }
 
+// entBridge returns the bridge for the current Ent client. The client is
+// resolved per call, from the handler override or else the site, and the
+// bridge is rebuilt when it changes, so a client set after the handler was
+// registered is used by subsequent requests.
+func (h *AdminServiceHandler) entBridge() *EntBridge {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	client := h.entClient
+	if client == nil {
+		client = h.site.EntClient()
+	}
+
+	if h.bridge == nil || !sameClient(h.bridge.client, client) {
+		h.bridge = NewEntBridge(client)
+	}
+	return h.bridge
+}
+
+// sameClient reports whether two Ent clients are the same value. Clients of
+// non-comparable types are never considered the same.
+func sameClient(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
+	}
+	return a == b
+}
+
 // ListModels returns all registered models and their configuration
 func (h *AdminServiceHandler) ListModels(
 	ctx context.Context,
@@ -68,12 +108,13 @@ func (h *AdminServiceHandler) ListModels(
 			ListDisplay:          modelAdmin.listDisplay,
 			SearchFields:         modelAdmin.searchFields,
 			ListFilter:           modelAdmin.listFilter,
-			ReadonlyFields:       modelAdmin.readonly,
+			ReadonlyFields:       modelAdmin.readonlyFields(),
 			Exclude:              modelAdmin.exclude,
 			Actions:              actions,
 			ListPerPage:          int32(modelAdmin.listPerPage),
 			Ordering:             strings.Join(modelAdmin.ordering, ","),
 			ShowFullResultCount:  true,
+			ListEditable:         modelAdmin.listEditable,
 			Permissions: &adminpb.ModelPermissions{
 				Add:    true,
 				Change: true,
@@ -121,11 +162,12 @@ func (h *AdminServiceHandler) GetModelSchema(
 		ListDisplay:         modelAdmin.listDisplay,
 		SearchFields:        modelAdmin.searchFields,
 		ListFilter:          modelAdmin.listFilter,
-		ReadonlyFields:      modelAdmin.readonly,
+		ReadonlyFields:      modelAdmin.readonlyFields(),
 		Exclude:             modelAdmin.exclude,
 		ListPerPage:         int32(modelAdmin.listPerPage),
 		Ordering:            strings.Join(modelAdmin.ordering, ","),
 		ShowFullResultCount: true,
+		ListEditable:        modelAdmin.listEditable,
 		Permissions: &adminpb.ModelPermissions{
 			Add:    true,
 			Change: true,
@@ -158,14 +200,34 @@ func (h *AdminServiceHandler) GetModelSchema(
 				Unique:       fieldInfo.Unique,
 				RelatedModel: fieldInfo.RelatedModel,
 				WidgetType:   fieldInfo.WidgetType,
+				PrepopulatedFrom: modelAdmin.prepopulatedFields[fieldInfo.Name],
 			}
 			fields = append(fields, field)
 		}
 	}
+	
+	// Inlines are listed as fields holding the related objects
+	for _, inline := range modelAdmin.inlines {
+		fields = append(fields, &adminpb.FieldInfo{
+			Name:         inline.Name,
+			FieldType:    "inline",
+			VerboseName:  inline.Name,
+			Editable:     true,
+			Blank:        true,
+			RelatedModel: getModelName(inline.Model),
+			WidgetType:   "inline",
+		})
+	}
 
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	
 	response := &adminpb.GetModelSchemaResponse{
 		ModelInfo: modelInfo,
 		Fields:    fields,
+		Fieldsets: fieldsetsProto(modelAdmin.fieldsetsFor(names)),
 	}
 
 	return connect.NewResponse(response), nil
@@ -195,31 +257,44 @@ func (h *AdminServiceHandler) ListObjects(
 	if pageSize < 1 {
 		pageSize = int32(modelAdmin.listPerPage)
 	}
-	if pageSize > 200 { // Max page size
-		pageSize = 200
+	if maxPageSize := int32(modelAdmin.maxShowAll); maxPageSize > 0 && pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	if modelAdmin.cursorField != "" && modelAdmin.dbInterface != nil {
+		return h.listObjectsByCursor(ctx, modelAdmin, req.Msg, pageSize)
 	}
 
-	// TODO: Implement real Ent database queries when client is available
 	var objects []*adminpb.ObjectData
 	var totalCount int32
-	
-	if h.entClient != nil {
-		// When Ent client is available, implement real database queries here
-		// This would involve:
-		// 1. Using reflection to call the appropriate Query() method on the Ent client
-		// 2. Applying filters, search, and ordering
-		// 3. Handling pagination with Offset() and Limit()
-		// 4. Converting Ent objects to protobuf ObjectData using ConvertEntObjectToObjectData
-		
-		// For now, fall back to mock data
-		objects = h.getMockObjects(req.Msg.App, req.Msg.Model, int(page), int(pageSize))
-		totalCount = int32(len(objects) * 10)
+	if modelAdmin.dbInterface != nil {
+		offset := int((page - 1) * pageSize)
+		items, total, err := modelAdmin.dbInterface.GetAll(ctx, modelAdmin.model, modelAdmin.rpcListFilters(req.Msg), modelAdmin.ordering, int(pageSize), offset)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list %s: %w", modelAdmin.modelName, err))
+		}
+		for _, obj := range items {
+			objectData, err := modelAdmin.ObjectData(obj)
+			if err != nil {
+				return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert object: %w", err))
+			}
+			objects = append(objects, objectData)
+		}
+		totalCount = int32(total)
 	} else {
-		// Return mock data when no Ent client is available
+		// Return mock data when no database is configured, keeping the
+		// bridge current for a client set after registration
+		h.entBridge()
 		objects = h.getMockObjects(req.Msg.App, req.Msg.Model, int(page), int(pageSize))
 		totalCount = int32(len(objects) * 10)
 	}
 
+	if modelAdmin.OmitEmptyFields() {
+		for _, object := range objects {
+			omitEmptyProtoFields(object.Fields)
+		}
+	}
+
 	response := &adminpb.ListObjectsResponse{
 		Objects:       objects,
 		TotalCount:    totalCount,
@@ -234,6 +309,75 @@ func (h *AdminServiceHandler) ListObjects(
 	return connect.NewResponse(response), nil
 }
 
+// listObjectsByCursor lists a model using cursor pagination. It returns
+// the cursors of the neighbouring pages instead of page counts, since
+// counting is what makes deep offset pages slow.
+func (h *AdminServiceHandler) listObjectsByCursor(
+	ctx context.Context,
+	modelAdmin *ModelAdmin,
+	req *adminpb.ListObjectsRequest,
+	pageSize int32,
+) (*connect.Response[adminpb.ListObjectsResponse], error) {
+	filters := modelAdmin.rpcListFilters(req)
+	query := func(ctx context.Context, keyset map[string]interface{}, ordering []string, limit int) ([]interface{}, error) {
+		// The keyset is applied last so client filters can't override the cursor
+		merged := make(map[string]interface{}, len(filters)+len(keyset))
+		for key, value := range filters {
+			merged[key] = value
+		}
+		for key, value := range keyset {
+			merged[key] = value
+		}
+		objects, _, err := modelAdmin.dbInterface.GetAll(ctx, modelAdmin.model, merged, ordering, limit, 0)
+		return objects, err
+	}
+	
+	page, err := db.Paginate(ctx, query, req.Cursor, int(pageSize), modelAdmin.cursorField)
+	if errors.Is(err, db.ErrInvalidCursor) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to list %s: %w", modelAdmin.modelName, err))
+	}
+	
+	objects := make([]*adminpb.ObjectData, 0, len(page.Items))
+	for _, obj := range page.Items {
+		objectData, err := modelAdmin.ObjectData(obj)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert object: %w", err))
+		}
+		objects = append(objects, objectData)
+	}
+	
+	return connect.NewResponse(&adminpb.ListObjectsResponse{
+		Objects:       objects,
+		PageSize:      pageSize,
+		HasNext:       page.HasNext(),
+		HasPrevious:   page.HasPrev(),
+		NextCursor:    page.NextCursor,
+		PrevCursor:    page.PrevCursor,
+		DisplayFields: modelAdmin.listDisplay,
+	}), nil
+}
+
+// rpcListFilters builds the filters of a list request: its field filters,
+// search, and the soft delete and date hierarchy selections
+func (ma *ModelAdmin) rpcListFilters(req *adminpb.ListObjectsRequest) map[string]interface{} {
+	filters := make(map[string]interface{}, len(req.Filters))
+	selection := url.Values{}
+	for key, value := range req.Filters {
+		if ma.isDateHierarchyParam(key) {
+			selection.Set(key, value)
+		} else if key != "show_deleted" {
+			filters[key] = value
+		}
+	}
+	ma.addSearchFilters(filters, req.Search)
+	ma.addSoftDeleteFilter(filters, req.Filters["show_deleted"])
+	ma.addDateHierarchyFilters(filters, selection)
+	return filters
+}
+
 // getMockObjects returns mock data for testing
 func (h *AdminServiceHandler) getMockObjects(app, model string, page, pageSize int) []*adminpb.ObjectData {
 	var objects []*adminpb.ObjectData
@@ -296,8 +440,40 @@ func (h *AdminServiceHandler) GetObject(
 	ctx context.Context,
 	req *connect.Request[adminpb.GetObjectRequest],
 ) (*connect.Response[adminpb.GetObjectResponse], error) {
-	// TODO: Implement get single object
-	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("GetObject not implemented yet"))
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	
+	obj, err := h.loadObject(ctx, modelAdmin, req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !h.site.canView(UserFromContext(ctx), obj) {
+		return nil, permissionDenied("view", modelAdmin)
+	}
+	
+	objectData, err := modelAdmin.ObjectData(obj)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert object: %w", err))
+	}
+	
+	inlines, err := modelAdmin.GetInlineObjects(ctx, req.Msg.Id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	for name, objects := range inlines {
+		if objectData.Fields == nil {
+			objectData.Fields = make(map[string]*structpb.Value)
+		}
+		objectData.Fields[name] = inlineObjectsValue(objects)
+	}
+	
+	response := &adminpb.GetObjectResponse{
+		Object: objectData,
+	}
+	
+	return connect.NewResponse(response), nil
 }
 
 // CreateObject creates a new object
@@ -305,6 +481,15 @@ func (h *AdminServiceHandler) CreateObject(
 	ctx context.Context,
 	req *connect.Request[adminpb.CreateObjectRequest],
 ) (*connect.Response[adminpb.CreateObjectResponse], error) {
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	modelKey := fmt.Sprintf("%s.%s", req.Msg.App, req.Msg.Model)
+	if !h.site.canAdd(UserFromContext(ctx), modelKey) {
+		return nil, permissionDenied("add", modelAdmin)
+	}
+	
 	// TODO: Implement create object
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("CreateObject not implemented yet"))
 }
@@ -314,8 +499,49 @@ func (h *AdminServiceHandler) UpdateObject(
 	ctx context.Context,
 	req *connect.Request[adminpb.UpdateObjectRequest],
 ) (*connect.Response[adminpb.UpdateObjectResponse], error) {
-	// TODO: Implement update object
-	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("UpdateObject not implemented yet"))
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	user := UserFromContext(ctx)
+	if !h.site.canChange(user, modelAdmin.model) {
+		return nil, permissionDenied("change", modelAdmin)
+	}
+	
+	obj, err := h.loadObject(ctx, modelAdmin, req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !h.site.canChange(user, obj) {
+		return nil, permissionDenied("change", modelAdmin)
+	}
+	
+	data := make(map[string]interface{}, len(req.Msg.Data))
+	for key, value := range req.Msg.Data {
+		data[key] = value.AsInterface()
+	}
+	
+	updated, err := modelAdmin.updateObject(ctx, req.Msg.Id, data)
+	var validationErr *ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response := &adminpb.UpdateObjectResponse{}
+		for field, message := range validationErr.Errors {
+			response.Errors = append(response.Errors, &adminpb.ValidationError{Field: field, Message: message, Code: "invalid"})
+		}
+		sort.Slice(response.Errors, func(i, j int) bool { return response.Errors[i].Field < response.Errors[j].Field })
+		return connect.NewResponse(response), nil
+	case errors.Is(err, ErrStaleObject):
+		return nil, connect.NewError(connect.CodeAborted, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to update %s %s: %w", modelAdmin.modelName, req.Msg.Id, err))
+	}
+	
+	objectData, err := modelAdmin.ObjectData(updated)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert object: %w", err))
+	}
+	return connect.NewResponse(&adminpb.UpdateObjectResponse{Object: objectData, Success: true}), nil
 }
 
 // DeleteObject deletes a single object
@@ -323,8 +549,32 @@ func (h *AdminServiceHandler) DeleteObject(
 	ctx context.Context,
 	req *connect.Request[adminpb.DeleteObjectRequest],
 ) (*connect.Response[adminpb.DeleteObjectResponse], error) {
-	// TODO: Implement delete object
-	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DeleteObject not implemented yet"))
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	user := UserFromContext(ctx)
+	if !h.site.canDelete(user, modelAdmin.model) {
+		return nil, permissionDenied("delete", modelAdmin)
+	}
+	
+	obj, err := h.loadObject(ctx, modelAdmin, req.Msg.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !h.site.canDelete(user, obj) {
+		return nil, permissionDenied("delete", modelAdmin)
+	}
+	
+	if err := modelAdmin.deleteObject(ctx, req.Msg.Id); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to delete %s %s: %w", modelAdmin.modelName, req.Msg.Id, err))
+	}
+	
+	message := fmt.Sprintf("%s %s deleted", modelAdmin.verboseName, req.Msg.Id)
+	if modelAdmin.softDeleteField != "" {
+		message = fmt.Sprintf("%s %s moved to deleted items", modelAdmin.verboseName, req.Msg.Id)
+	}
+	return connect.NewResponse(&adminpb.DeleteObjectResponse{Success: true, Message: message}), nil
 }
 
 // DeleteObjects deletes multiple objects
@@ -332,6 +582,14 @@ func (h *AdminServiceHandler) DeleteObjects(
 	ctx context.Context,
 	req *connect.Request[adminpb.DeleteObjectsRequest],
 ) (*connect.Response[adminpb.DeleteObjectsResponse], error) {
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	if !h.site.canDelete(UserFromContext(ctx), modelAdmin.model) {
+		return nil, permissionDenied("delete", modelAdmin)
+	}
+	
 	// TODO: Implement bulk delete
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DeleteObjects not implemented yet"))
 }
@@ -345,6 +603,32 @@ func (h *AdminServiceHandler) ExecuteAction(
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("ExecuteAction not implemented yet"))
 }
 
+// getModelAdmin looks up a registered model, returning CodeNotFound if missing
+func (h *AdminServiceHandler) getModelAdmin(app, model string) (*ModelAdmin, error) {
+	modelKey := fmt.Sprintf("%s.%s", app, model)
+	
+	modelAdmin, exists := h.site.GetModelAdmin(modelKey)
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("model %s not found", modelKey))
+	}
+	return modelAdmin, nil
+}
+
+// loadObject fetches an object for a row-level permission check, returning
+// CodeNotFound if it cannot be loaded
+func (h *AdminServiceHandler) loadObject(ctx context.Context, modelAdmin *ModelAdmin, id string) (interface{}, error) {
+	obj, err := modelAdmin.loadObject(ctx, id)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("%s %s not found: %w", modelAdmin.modelName, id, err))
+	}
+	return obj, nil
+}
+
+// permissionDenied builds a CodePermissionDenied error for a model operation
+func permissionDenied(perm string, modelAdmin *ModelAdmin) error {
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("permission denied: cannot %s %s", perm, modelAdmin.modelName))
+}
+
 // ListActions returns available actions for a model
 func (h *AdminServiceHandler) ListActions(
 	ctx context.Context,
@@ -382,4 +666,45 @@ func (h *AdminServiceHandler) SearchObjects(
 ) (*connect.Response[adminpb.SearchObjectsResponse], error) {
 	// TODO: Implement search functionality
 	return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("SearchObjects not implemented yet"))
-}
\ No newline at end of file
+}
+
+// GetDashboard returns the object counts and recently created objects of the
+// models the user may view, and the recent admin actions
+func (h *AdminServiceHandler) GetDashboard(
+	ctx context.Context,
+	req *connect.Request[adminpb.GetDashboardRequest],
+) (*connect.Response[adminpb.GetDashboardResponse], error) {
+	dashboard, err := h.site.Dashboard(ctx, UserFromContext(ctx), int(req.Msg.RecentLimit))
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	
+	response := &adminpb.GetDashboardResponse{}
+	for _, model := range dashboard.Models {
+		entry := &adminpb.DashboardModel{
+			App:               model.App,
+			Model:             model.Model,
+			VerboseNamePlural: model.VerboseNamePlural,
+			Count:             int32(model.Count),
+		}
+		modelAdmin, _ := h.site.GetModelAdmin(model.App + "." + model.Model)
+		for _, obj := range model.Recent {
+			if objectData, err := modelAdmin.ObjectData(obj); err == nil {
+				entry.RecentObjects = append(entry.RecentObjects, objectData)
+			}
+		}
+		response.Models = append(response.Models, entry)
+	}
+	for _, action := range dashboard.RecentActions {
+		response.RecentActions = append(response.RecentActions, &adminpb.AdminLogEntry{
+			Action:     action.Action,
+			Model:      action.Model,
+			ObjectId:   action.ObjectID,
+			User:       action.User,
+			ActionTime: timestamppb.New(action.ActionTime),
+			Message:    action.Message,
+		})
+	}
+	
+	return connect.NewResponse(response), nil
+}
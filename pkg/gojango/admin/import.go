@@ -0,0 +1,630 @@
+package admin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultImportPreviewRows is the number of rows shown in an import preview
+const DefaultImportPreviewRows = 10
+
+// maxImportSize caps the size of uploaded import files
+const maxImportSize = 32 << 20
+
+// maxImportPartSize caps the decompressed size of each XML part of an
+// uploaded workbook
+const maxImportPartSize = 64 << 20
+
+// BulkInserter is implemented by database interfaces that insert many rows
+// of a model at once, e.g. with db.BulkInsert. It returns
+// ErrBulkInsertUnsupported when it can't, and imports then create rows one
+// at a time.
+type BulkInserter interface {
+	BulkCreate(ctx context.Context, model interface{}, rows []map[string]interface{}) (int, error)
+}
+
+// ErrBulkInsertUnsupported is returned by a BulkInserter that can't insert
+// rows in bulk, falling back to creating them one at a time
+var ErrBulkInsertUnsupported = errors.New("bulk insert not supported")
+
+// ImportPreview describes an uploaded spreadsheet before it is imported.
+// Mapping maps each header to the model field it was detected as, or ""
+// when the column is ignored; clients may change it before importing.
+type ImportPreview struct {
+	Headers   []string          `json:"headers"`
+	Mapping   map[string]string `json:"mapping"`
+	Fields    []string          `json:"fields"`
+	Rows      [][]string        `json:"rows"`
+	TotalRows int               `json:"total_rows"`
+}
+
+// ImportRowError lists the validation errors of a row, by its line in the
+// uploaded file
+type ImportRowError struct {
+	Line   int               `json:"line"`
+	Errors map[string]string `json:"errors"`
+}
+
+// ImportResult reports an import. Rows with errors are skipped; in a dry
+// run Imported counts the rows that would be imported.
+type ImportResult struct {
+	DryRun    bool             `json:"dry_run"`
+	TotalRows int              `json:"total_rows"`
+	Imported  int              `json:"imported"`
+	Failed    int              `json:"failed"`
+	Errors    []ImportRowError `json:"errors"`
+}
+
+// importRecord is a row of an uploaded file and the line it started on
+type importRecord struct {
+	line   int
+	values []string
+}
+
+// SetImportTable makes imports insert rows into table with db.BulkInsert.
+// Without it imports insert through the database interface, in bulk when
+// it is a BulkInserter.
+func (ma *ModelAdmin) SetImportTable(conn *db.Connection, table string) *ModelAdmin {
+	ma.importConn = conn
+	ma.importTable = table
+	return ma
+}
+
+// importFields returns the schema of the fields rows can be imported into:
+// every field that isn't read-only
+func (ma *ModelAdmin) importFields() ([]FieldSchema, error) {
+	if ma.dbInterface == nil {
+		return nil, fmt.Errorf("database interface not set")
+	}
+	schema, err := ma.dbInterface.GetSchema(ma.model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema: %w", err)
+	}
+
+	readonly := make(map[string]bool)
+	for _, field := range ma.readonlyFields() {
+		readonly[field] = true
+	}
+	var fields []FieldSchema
+	for _, field := range schema.Fields {
+		if !readonly[field.Name] {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// PreviewImport reads an uploaded CSV or Excel file, detecting which field
+// each header names by field name or verbose name
+func (ma *ModelAdmin) PreviewImport(filename string, r io.Reader) (*ImportPreview, error) {
+	fields, err := ma.importFields()
+	if err != nil {
+		return nil, err
+	}
+	headers, records, err := readImportFile(filename, r)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ImportPreview{
+		Headers:   headers,
+		Mapping:   detectImportMapping(headers, fields),
+		Fields:    make([]string, len(fields)),
+		Rows:      [][]string{},
+		TotalRows: len(records),
+	}
+	for i, field := range fields {
+		preview.Fields[i] = field.Name
+	}
+	for i := 0; i < len(records) && i < DefaultImportPreviewRows; i++ {
+		preview.Rows = append(preview.Rows, records[i].values)
+	}
+	return preview, nil
+}
+
+// Import validates the rows of an uploaded CSV or Excel file against the
+// schema and inserts the valid ones. mapping maps headers to fields as in
+// PreviewImport, which detects it when nil. A dry run only validates.
+// Like CreateObject, it fills in prepopulated fields and sends PreSave and
+// PostSave for each row, though rows inserted in bulk have no instance.
+func (ma *ModelAdmin) Import(ctx context.Context, filename string, r io.Reader, mapping map[string]string, dryRun bool) (*ImportResult, error) {
+	fields, err := ma.importFields()
+	if err != nil {
+		return nil, err
+	}
+	headers, records, err := readImportFile(filename, r)
+	if err != nil {
+		return nil, err
+	}
+	if mapping == nil {
+		mapping = detectImportMapping(headers, fields)
+	}
+
+	// Resolve the field of each column
+	known := make(map[string]FieldSchema, len(fields))
+	for _, field := range fields {
+		known[field.Name] = field
+	}
+	columns := make([]*FieldSchema, len(headers))
+	mapped := make(map[string]string)
+	for i, header := range headers {
+		name := mapping[header]
+		if name == "" {
+			continue
+		}
+		field, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("column %q is mapped to unknown field %q", header, name)
+		}
+		if other, ok := mapped[name]; ok {
+			return nil, fmt.Errorf("columns %q and %q are both mapped to %s", other, header, name)
+		}
+		mapped[name] = header
+		columns[i] = &field
+	}
+	if len(mapped) == 0 {
+		return nil, fmt.Errorf("no columns are mapped to fields")
+	}
+
+	result := &ImportResult{DryRun: dryRun, TotalRows: len(records), Errors: []ImportRowError{}}
+	var rows []map[string]interface{}
+	slugs := make(map[string]map[string]bool)
+	for _, record := range records {
+		data := make(map[string]interface{}, len(mapped))
+		for i, field := range columns {
+			// Blank cells are left out, so the column's default applies
+			if field != nil && i < len(record.values) && strings.TrimSpace(record.values[i]) != "" {
+				data[field.Name] = record.values[i]
+			}
+		}
+		ma.applyAutoTimestamps(data, true)
+		if err := ma.prepopulate(ctx, data, slugs); err != nil {
+			return nil, err
+		}
+
+		if err := ma.validateData(data, true); err != nil {
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				return nil, err
+			}
+			result.Errors = append(result.Errors, ImportRowError{Line: record.line, Errors: validationErr.Errors})
+			continue
+		}
+
+		for name, value := range data {
+			if s, ok := value.(string); ok {
+				data[name] = convertImportValue(known[name], s)
+			}
+		}
+		rows = append(rows, data)
+	}
+	result.Failed = len(result.Errors)
+
+	if dryRun {
+		result.Imported = len(rows)
+		return result, nil
+	}
+	if len(rows) > 0 {
+		for _, data := range rows {
+			if err := signals.PreSave.Send(ctx, ma.model, map[string]interface{}{"data": data, "created": true}); err != nil {
+				return nil, err
+			}
+		}
+		objects, err := ma.insertImportRows(ctx, rows)
+		if err != nil {
+			return nil, err
+		}
+		for i, data := range rows {
+			if err := signals.PostSave.Send(ctx, ma.model, map[string]interface{}{"instance": objects[i], "data": data, "created": true}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	result.Imported = len(rows)
+	ma.logAction(ctx, "import", "", fmt.Sprintf("Imported %d rows from %s.", len(rows), path.Base(filename)))
+	return result, nil
+}
+
+// insertImportRows inserts validated rows through the import table, or
+// through the database interface when none is set. It returns the created
+// objects, which are nil for rows inserted in bulk. Bulk inserts take rows
+// with the same columns, so rows are inserted in a batch per set of filled
+// in columns.
+func (ma *ModelAdmin) insertImportRows(ctx context.Context, rows []map[string]interface{}) ([]interface{}, error) {
+	objects := make([]interface{}, len(rows))
+	if ma.importConn != nil {
+		for _, batch := range importBatches(rows) {
+			if _, err := db.BulkInsert(ctx, ma.importConn, ma.importTable, batch, db.BulkInsertOptions{}); err != nil {
+				return nil, fmt.Errorf("failed to import rows: %w", err)
+			}
+		}
+		return objects, nil
+	}
+	if inserter, ok := ma.dbInterface.(BulkInserter); ok {
+		var err error
+		for _, batch := range importBatches(rows) {
+			if _, err = inserter.BulkCreate(ctx, ma.model, batch); err != nil {
+				break
+			}
+		}
+		if !errors.Is(err, ErrBulkInsertUnsupported) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to import rows: %w", err)
+			}
+			return objects, nil
+		}
+	}
+
+	err := ma.inTransaction(ctx, func(tx DatabaseInterface) error {
+		for i, row := range rows {
+			obj, err := tx.Create(ctx, ma.model, row)
+			if err != nil {
+				return fmt.Errorf("failed to import row %d: %w", i+1, err)
+			}
+			objects[i] = obj
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// importBatches groups rows by their columns, keeping the order of the
+// rows within each group
+func importBatches(rows []map[string]interface{}) [][]map[string]interface{} {
+	var batches [][]map[string]interface{}
+	index := make(map[string]int)
+	for _, row := range rows {
+		columns := make([]string, 0, len(row))
+		for column := range row {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		key := strings.Join(columns, ",")
+
+		i, ok := index[key]
+		if !ok {
+			i = len(batches)
+			index[key] = i
+			batches = append(batches, nil)
+		}
+		batches[i] = append(batches[i], row)
+	}
+	return batches
+}
+
+// detectImportMapping maps each header to the field whose name or verbose
+// name it matches, ignoring case, spaces and hyphens
+func detectImportMapping(headers []string, fields []FieldSchema) map[string]string {
+	byName := make(map[string]string, len(fields)*2)
+	for _, field := range fields {
+		if field.Verbose != "" {
+			byName[normalizeImportHeader(field.Verbose)] = field.Name
+		}
+	}
+	for _, field := range fields {
+		byName[normalizeImportHeader(field.Name)] = field.Name
+	}
+
+	mapping := make(map[string]string, len(headers))
+	used := make(map[string]bool)
+	for _, header := range headers {
+		name := byName[normalizeImportHeader(header)]
+		if used[name] {
+			name = ""
+		}
+		if name != "" {
+			used[name] = true
+		}
+		mapping[header] = name
+	}
+	return mapping
+}
+
+// normalizeImportHeader lowercases a header and turns spaces and hyphens
+// into underscores, so "Is Active" matches is_active
+func normalizeImportHeader(header string) string {
+	header = strings.ToLower(strings.TrimSpace(header))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(header)
+}
+
+// convertImportValue converts a validated cell to the type of its field
+func convertImportValue(field FieldSchema, value string) interface{} {
+	value = strings.TrimSpace(value)
+	switch field.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "float":
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case "boolean":
+		switch strings.ToLower(value) {
+		case "true", "on", "1", "yes":
+			return true
+		case "false", "off", "0", "no":
+			return false
+		}
+	case "datetime":
+		for _, layout := range dateTimeLayouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t
+			}
+		}
+	}
+	return value
+}
+
+// readImportFile reads the header row and the non-blank rows of a CSV file,
+// or of the first worksheet of an Excel workbook
+func readImportFile(filename string, r io.Reader) ([]string, []importRecord, error) {
+	content, err := io.ReadAll(io.LimitReader(r, maxImportSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+	if len(content) > maxImportSize {
+		return nil, nil, fmt.Errorf("import file is larger than %d bytes", maxImportSize)
+	}
+
+	var records []importRecord
+	if strings.EqualFold(path.Ext(filename), ".xlsx") || bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		records, err = readXLSXRecords(content)
+	} else {
+		records, err = readCSVRecords(content)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("import file has no header row")
+	}
+
+	headers := records[0].values
+	for i, header := range headers {
+		headers[i] = strings.TrimSpace(header)
+	}
+	var rows []importRecord
+	for _, record := range records[1:] {
+		blank := true
+		for _, value := range record.values {
+			if strings.TrimSpace(value) != "" {
+				blank = false
+				break
+			}
+		}
+		if !blank {
+			rows = append(rows, record)
+		}
+	}
+	return headers, rows, nil
+}
+
+// readCSVRecords reads CSV rows with the line each starts on
+func readCSVRecords(content []byte) ([]importRecord, error) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	var records []importRecord
+	for {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		records = append(records, importRecord{line: line, values: values})
+	}
+}
+
+// xlsxSheet is the part of a worksheet read on import
+type xlsxSheet struct {
+	Rows []struct {
+		Number int `xml:"r,attr"`
+		Cells  []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXRecords reads the rows of the first worksheet of a workbook, with
+// their row numbers as lines
+func readXLSXRecords(content []byte) ([]importRecord, error) {
+	zr, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Excel file: %w", err)
+	}
+
+	var sharedStrings []string
+	var sheet *xlsxSheet
+	for _, f := range zr.File {
+		switch {
+		case f.Name == "xl/sharedStrings.xml":
+			var table struct {
+				Items []struct {
+					Text string   `xml:"t"`
+					Runs []string `xml:"r>t"`
+				} `xml:"si"`
+			}
+			if err := decodeZipXML(f, &table); err != nil {
+				return nil, err
+			}
+			for _, item := range table.Items {
+				sharedStrings = append(sharedStrings, item.Text+strings.Join(item.Runs, ""))
+			}
+		case strings.HasPrefix(f.Name, "xl/worksheets/sheet") && (sheet == nil || f.Name == "xl/worksheets/sheet1.xml"):
+			sheet = &xlsxSheet{}
+			if err := decodeZipXML(f, sheet); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if sheet == nil {
+		return nil, fmt.Errorf("invalid Excel file: no worksheet")
+	}
+
+	records := make([]importRecord, 0, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		record := importRecord{line: row.Number}
+		if record.line == 0 {
+			record.line = i + 1
+		}
+		for j, cell := range row.Cells {
+			column := xlsxColumnIndex(cell.Ref)
+			if column < 0 {
+				column = j
+			}
+			for len(record.values) <= column {
+				record.values = append(record.values, "")
+			}
+
+			value := cell.Value
+			switch cell.Type {
+			case "s":
+				if n, err := strconv.Atoi(value); err == nil && n >= 0 && n < len(sharedStrings) {
+					value = sharedStrings[n]
+				}
+			case "inlineStr":
+				value = cell.Inline
+			case "b":
+				value = strconv.FormatBool(value == "1")
+			}
+			record.values[column] = value
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// decodeZipXML decodes an XML part of a workbook, reading at most
+// maxImportPartSize bytes of it whatever size the archive claims
+func decodeZipXML(f *zip.File, v interface{}) error {
+	if f.UncompressedSize64 > maxImportPartSize {
+		return fmt.Errorf("invalid Excel file: %s is larger than %d bytes", f.Name, maxImportPartSize)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("invalid Excel file: %w", err)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, maxImportPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("invalid Excel file: %s: %w", f.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumnIndex converts a cell reference to its zero-based column, e.g.
+// "AB3" -> 27, or returns -1 when it has no column letters
+func xlsxColumnIndex(ref string) int {
+	index := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		index = index*26 + int(r-'A') + 1
+		letters++
+	}
+	if letters == 0 {
+		return -1
+	}
+	return index - 1
+}
+
+// handleAPIImportPreview previews an uploaded import file
+func (s *Site) handleAPIImportPreview(c *gin.Context) {
+	admin, ok := s.importModelAdmin(c)
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	defer file.Close()
+
+	preview, err := admin.PreviewImport(header.Filename, file)
+	if err != nil {
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	render.JSON(c, http.StatusOK, preview)
+}
+
+// handleAPIImport imports an uploaded file, using the header to field
+// mapping posted as JSON in mapping when given. dry_run only validates.
+func (s *Site) handleAPIImport(c *gin.Context) {
+	admin, ok := s.importModelAdmin(c)
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	defer file.Close()
+
+	var mapping map[string]string
+	if raw := c.Request.FormValue("mapping"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &mapping); err != nil {
+			render.JSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid mapping: %v", err)})
+			return
+		}
+	}
+	dryRun, _ := strconv.ParseBool(c.Request.FormValue("dry_run"))
+
+	result, err := admin.Import(c, header.Filename, file, mapping, dryRun)
+	if err != nil {
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	render.JSON(c, http.StatusOK, result)
+}
+
+// importModelAdmin looks up the model of an import request, responding with
+// an error when it doesn't exist or the user may not add to it
+func (s *Site) importModelAdmin(c *gin.Context) (*ModelAdmin, bool) {
+	modelKey := fmt.Sprintf("%s.%s", c.Param("app"), c.Param("model"))
+
+	admin, exists := s.GetModelAdmin(modelKey)
+	if !exists {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return nil, false
+	}
+	if !s.canAdd(CurrentUser(c), modelKey) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return nil, false
+	}
+	return admin, true
+}
@@ -0,0 +1,285 @@
+package admin
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importCSV = "Username,Email,Is Active,Notes\n" +
+	"john,john@example.com,yes,first\n" +
+	"jane,,no,missing email\n" +
+	"\n" +
+	"\"bob\nsmith\",bob@example.com,1,multiline\n"
+
+func TestPreviewImport(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(newMockDBInterface())
+
+	preview, err := admin.PreviewImport("users.csv", strings.NewReader("\ufeff"+importCSV))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Username", "Email", "Is Active", "Notes"}, preview.Headers)
+	assert.Equal(t, map[string]string{
+		"Username":  "username",
+		"Email":     "email",
+		"Is Active": "is_active",
+		"Notes":     "",
+	}, preview.Mapping)
+	assert.Equal(t, 3, preview.TotalRows, "blank rows are skipped")
+	assert.Equal(t, []string{"john", "john@example.com", "yes", "first"}, preview.Rows[0])
+	assert.Contains(t, preview.Fields, "created_at")
+}
+
+func TestImportDryRunAndCommit(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+	mockDB := newMockDBInterface()
+	admin.SetDatabaseInterface(mockDB)
+	modelName := getModelName(&TestUser{})
+
+	result, err := admin.Import(context.Background(), "users.csv", strings.NewReader(importCSV), nil, true)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 3, result.TotalRows)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 1, result.Failed)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 3, result.Errors[0].Line)
+	assert.Equal(t, map[string]string{"email": "This field is required."}, result.Errors[0].Errors)
+	assert.Empty(t, mockDB.objects[modelName], "a dry run imports nothing")
+
+	result, err = admin.Import(context.Background(), "users.csv", strings.NewReader(importCSV), nil, false)
+	require.NoError(t, err)
+	assert.False(t, result.DryRun)
+	assert.Equal(t, 2, result.Imported)
+
+	users := mockDB.objects[modelName]
+	require.Len(t, users, 2)
+	assert.Equal(t, "john", users[0].(map[string]interface{})["username"])
+	assert.Equal(t, true, users[0].(map[string]interface{})["is_active"])
+	assert.Equal(t, "bob\nsmith", users[1].(map[string]interface{})["username"])
+}
+
+func TestImportMapping(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(newMockDBInterface())
+	csv := "Login,Mail\njohn,john@example.com\n"
+
+	_, err := admin.Import(context.Background(), "users.csv", strings.NewReader(csv), nil, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no columns are mapped")
+
+	result, err := admin.Import(context.Background(), "users.csv", strings.NewReader(csv),
+		map[string]string{"Login": "username", "Mail": "email"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+
+	_, err = admin.Import(context.Background(), "users.csv", strings.NewReader(csv),
+		map[string]string{"Login": "username", "Mail": "username"}, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "both mapped")
+
+	_, err = admin.Import(context.Background(), "users.csv", strings.NewReader(csv),
+		map[string]string{"Login": "password"}, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown field")
+}
+
+func TestImportBulkInsert(t *testing.T) {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "import.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_, err = conn.DB().Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT, email TEXT, is_active BOOLEAN, created_at DATETIME)`)
+	require.NoError(t, err)
+
+	admin := NewModelAdmin(&TestUser{}).SetImportTable(conn, "users")
+	admin.SetDatabaseInterface(newMockDBInterface())
+
+	var buf bytes.Buffer
+	writer, err := newXLSXWriter(&buf)
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteRow([]interface{}{"username", "email", "is_active"}))
+	require.NoError(t, writer.WriteRow([]interface{}{"john", "john@example.com", true}))
+	require.NoError(t, writer.WriteRow([]interface{}{"jane", "jane@example.com", false}))
+	require.NoError(t, writer.WriteRow([]interface{}{"", "nobody@example.com", nil}))
+	require.NoError(t, writer.Close())
+
+	result, err := admin.Import(context.Background(), "users.xlsx", &buf, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 4, result.Errors[0].Line)
+
+	var count, active int
+	require.NoError(t, conn.DB().QueryRow(`SELECT COUNT(*), SUM(is_active) FROM users`).Scan(&count, &active))
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 1, active)
+}
+
+func TestImportBlankCellsUseDefaults(t *testing.T) {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "import.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_, err = conn.DB().Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY, username TEXT, email TEXT, is_active BOOLEAN NOT NULL DEFAULT 1)`)
+	require.NoError(t, err)
+
+	admin := NewModelAdmin(&TestUser{}).SetImportTable(conn, "users")
+	admin.SetDatabaseInterface(newMockDBInterface())
+
+	csv := "username,email,is_active\njohn,john@example.com,\njane,jane@example.com,no\n"
+	result, err := admin.Import(context.Background(), "users.csv", strings.NewReader(csv), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+
+	var count, active int
+	require.NoError(t, conn.DB().QueryRow(`SELECT COUNT(*), SUM(is_active) FROM users`).Scan(&count, &active))
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 1, active, "the blank cell should get the column default")
+}
+
+func TestImportSignalsAndPrepopulates(t *testing.T) {
+	admin := newValidationAdmin(
+		FieldSchema{Name: "title", Type: "string", Required: true},
+		FieldSchema{Name: "slug", Type: "string", Required: true},
+	)
+	admin.SetPrepopulatedFields(map[string][]string{"slug": {"title"}})
+	mockDB := admin.dbInterface.(*schemaDBInterface).mockDBInterface
+
+	var preSaves, postSaves int
+	count := func(n *int) signals.Receiver {
+		return func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+			if _, ok := sender.(*TestUser); ok {
+				*n++
+			}
+			return nil
+		}
+	}
+	preID := signals.PreSave.Connect(count(&preSaves))
+	postID := signals.PostSave.Connect(count(&postSaves))
+	t.Cleanup(func() {
+		signals.PreSave.Disconnect(preID)
+		signals.PostSave.Disconnect(postID)
+	})
+
+	result, err := admin.Import(context.Background(), "posts.csv", strings.NewReader("Title\nHello World\nHello World\n"), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, 2, preSaves)
+	assert.Equal(t, 2, postSaves)
+
+	// Rows imported together get slugs of their own
+	objects := mockDB.objects[getModelName(&TestUser{})]
+	require.Len(t, objects, 2)
+	assert.Equal(t, "hello-world", objects[0].(map[string]interface{})["slug"])
+	assert.Equal(t, "hello-world-2", objects[1].(map[string]interface{})["slug"])
+}
+
+func TestImportEntBulkCreate(t *testing.T) {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "import.db")))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	_, err = conn.DB().Exec(`CREATE TABLE documents (id INTEGER PRIMARY KEY, title TEXT, version INTEGER)`)
+	require.NoError(t, err)
+
+	// Without SetImportTable, rows go to the table the Ent client queries
+	client := &documentEntClient{Document: &documentClient{db: conn.DB()}, db: conn.DB()}
+	admin := NewModelAdmin(&Document{})
+	admin.SetDatabaseInterface(NewEntDatabaseInterface(client).SetConnection(conn))
+
+	result, err := admin.Import(context.Background(), "documents.csv", strings.NewReader("Title,Version\ndraft,1\nfinal,2\n"), nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+
+	var count, versions int
+	require.NoError(t, conn.DB().QueryRow(`SELECT COUNT(*), SUM(version) FROM documents`).Scan(&count, &versions))
+	assert.Equal(t, 2, count)
+	assert.Equal(t, 3, versions)
+
+	// Without a connection the Ent interface can't insert in bulk
+	_, err = NewEntDatabaseInterface(client).BulkCreate(context.Background(), &Document{}, nil)
+	assert.ErrorIs(t, err, ErrBulkInsertUnsupported)
+}
+
+func TestImportRejectsLargeWorkbookParts(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	// The header claims a sheet larger than imports decompress
+	w, err := zw.CreateRaw(&zip.FileHeader{
+		Name:               "xl/worksheets/sheet1.xml",
+		Method:             zip.Store,
+		CompressedSize64:   2,
+		UncompressedSize64: maxImportPartSize + 1,
+	})
+	require.NoError(t, err)
+	_, err = w.Write([]byte("<>"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(newMockDBInterface())
+	_, err = admin.PreviewImport("users.xlsx", &buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is larger than")
+}
+
+func TestImportAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	admin := NewModelAdmin(&TestUser{})
+	mockDB := newMockDBInterface()
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+
+	router := gin.New()
+	site.SetupRoutes(router)
+	parts := strings.SplitN(getModelName(&TestUser{}), ".", 2)
+	upload := func(url string, fields map[string]string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		file, err := form.CreateFormFile("file", "users.csv")
+		require.NoError(t, err)
+		file.Write([]byte(importCSV))
+		for name, value := range fields {
+			form.WriteField(name, value)
+		}
+		require.NoError(t, form.Close())
+
+		req := httptest.NewRequest(http.MethodPost, url, &body)
+		req.Header.Set("Content-Type", form.FormDataContentType())
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload("/admin/api/import/"+parts[0]+"/"+parts[1]+"/preview", nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var preview ImportPreview
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &preview))
+	assert.Equal(t, "email", preview.Mapping["Email"])
+
+	w = upload("/admin/api/import/"+parts[0]+"/"+parts[1], map[string]string{
+		"mapping": `{"Username": "username", "Email": "email"}`,
+		"dry_run": "true",
+	})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var result ImportResult
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 2, result.Imported)
+	assert.Empty(t, mockDB.objects[getModelName(&TestUser{})])
+
+	w = upload("/admin/api/import/"+parts[0]+"/missing", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,310 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// inlineDeleteKey marks a submitted related object for deletion
+const inlineDeleteKey = "_delete"
+
+// InlineAdmin edits the objects of a related model on the change form of
+// their parent, like Django's inlines. Only one-to-many relations are
+// supported: each related object points at its parent through FKField.
+type InlineAdmin struct {
+	Model   interface{} // Related model, e.g. &Comment{}
+	FKField string      // Field of the related model holding the parent's ID, e.g. "post_id"
+	Extra   int         // Number of blank forms shown for new related objects
+	Name    string      // Key of the related objects in forms and responses, "<model>_set" by default
+	MaxNum  int         // Most related objects loaded, DefaultMaxPageSize when 0
+}
+
+// InlineSchema describes the related objects edited on a parent's form
+type InlineSchema struct {
+	Name    string        `json:"name"`
+	Model   string        `json:"model"`
+	FKField string        `json:"fk_field"`
+	Extra   int           `json:"extra"`
+	Fields  []FieldSchema `json:"fields"`
+}
+
+// TransactionalDatabase is implemented by database interfaces that can make
+// several writes atomically. fn makes them through tx, and all of them are
+// rolled back when it returns an error. Without it, the writes of an update
+// with inlines are made one at a time.
+type TransactionalDatabase interface {
+	InTransaction(ctx context.Context, fn func(tx DatabaseInterface) error) error
+}
+
+// AddInline edits the related objects of inline on this model's change
+// form. Schemas and objects include them under the inline's name, and
+// updates save a submitted list of them: rows without an id are created,
+// rows with an id are updated and rows with "_delete" set are deleted,
+// together with the parent in one transaction when the database interface
+// supports it. Blank rows, such as unused extra forms, are ignored.
+func (ma *ModelAdmin) AddInline(inline InlineAdmin) *ModelAdmin {
+	if inline.Name == "" {
+		modelName := getModelName(inline.Model)
+		inline.Name = modelName[strings.LastIndex(modelName, ".")+1:] + "_set"
+	}
+	ma.inlines = append(ma.inlines, inline)
+	return ma
+}
+
+// Inlines returns the inlines edited on this model's change form
+func (ma *ModelAdmin) Inlines() []InlineAdmin {
+	return append([]InlineAdmin(nil), ma.inlines...)
+}
+
+// GetInlineObjects returns the related objects of each inline for the
+// object with the given ID, keyed by inline name
+func (ma *ModelAdmin) GetInlineObjects(ctx context.Context, id string) (map[string][]interface{}, error) {
+	if len(ma.inlines) == 0 {
+		return nil, nil
+	}
+	if ma.dbInterface == nil {
+		return nil, fmt.Errorf("database interface not set")
+	}
+
+	related := make(map[string][]interface{}, len(ma.inlines))
+	for _, inline := range ma.inlines {
+		limit := inline.MaxNum
+		if limit <= 0 {
+			limit = DefaultMaxPageSize
+		}
+		objects, _, err := ma.dbInterface.GetAll(ctx, inline.Model, map[string]interface{}{inline.FKField: id}, nil, limit, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s: %w", inline.Name, err)
+		}
+		related[inline.Name] = objects
+	}
+	return related, nil
+}
+
+// inlineSchemas returns the schemas of the inlines, leaving out the foreign
+// key, which is set from the parent
+func (ma *ModelAdmin) inlineSchemas() []InlineSchema {
+	var schemas []InlineSchema
+	for _, inline := range ma.inlines {
+		inlineSchema := InlineSchema{
+			Name:    inline.Name,
+			Model:   getModelName(inline.Model),
+			FKField: inline.FKField,
+			Extra:   inline.Extra,
+			Fields:  []FieldSchema{},
+		}
+		if schema, err := ma.dbInterface.GetSchema(inline.Model); err == nil && schema != nil {
+			for _, field := range schema.Fields {
+				if field.Name != inline.FKField {
+					inlineSchema.Fields = append(inlineSchema.Fields, field)
+				}
+			}
+		}
+		schemas = append(schemas, inlineSchema)
+	}
+	return schemas
+}
+
+// inlineChange is a submitted related object of an inline
+type inlineChange struct {
+	inline InlineAdmin
+	field  string // Key of the row in validation errors, e.g. "comment_set.0"
+	id     string // "" for new objects
+	delete bool
+	data   map[string]interface{}
+}
+
+// extractInlineChanges removes the submitted related objects of each inline
+// from data. Lists may be submitted as JSON text, e.g. from forms.
+func (ma *ModelAdmin) extractInlineChanges(data map[string]interface{}) ([]inlineChange, error) {
+	var changes []inlineChange
+	validationErr := NewValidationError()
+	for _, inline := range ma.inlines {
+		value, present := data[inline.Name]
+		if !present {
+			continue
+		}
+		delete(data, inline.Name)
+
+		if text, ok := value.(string); ok {
+			if err := json.Unmarshal([]byte(text), &value); err != nil {
+				validationErr.Add(inline.Name, "Enter a list of objects.")
+				continue
+			}
+		}
+		rows, ok := value.([]interface{})
+		if !ok && value != nil {
+			validationErr.Add(inline.Name, "Enter a list of objects.")
+			continue
+		}
+
+		for i, value := range rows {
+			row, ok := value.(map[string]interface{})
+			field := fmt.Sprintf("%s.%d", inline.Name, i)
+			if !ok {
+				validationErr.Add(field, "Enter an object.")
+				continue
+			}
+
+			change := inlineChange{inline: inline, field: field, data: make(map[string]interface{}, len(row))}
+			for key, value := range row {
+				switch key {
+				case "id":
+					if !isEmptyValue(value) {
+						change.id = fmt.Sprint(value)
+					}
+				case inlineDeleteKey:
+					change.delete = isTrue(value)
+				case inline.FKField:
+					// Related objects always belong to the parent being saved
+				default:
+					change.data[key] = value
+				}
+			}
+
+			if change.id == "" && (change.delete || isBlankRow(change.data)) {
+				continue
+			}
+			changes = append(changes, change)
+		}
+	}
+
+	if validationErr.HasErrors() {
+		return nil, validationErr
+	}
+	return changes, nil
+}
+
+// validateInlineChanges validates created and updated related objects
+// against the related model's schema, reporting errors per row
+func (ma *ModelAdmin) validateInlineChanges(parentID string, changes []inlineChange) error {
+	validationErr := NewValidationError()
+	for _, change := range changes {
+		if change.delete {
+			continue
+		}
+
+		data := make(map[string]interface{}, len(change.data)+1)
+		for key, value := range change.data {
+			data[key] = value
+		}
+		if change.id == "" {
+			data[change.inline.FKField] = parentID
+		}
+
+		related := &ModelAdmin{model: change.inline.Model, dbInterface: ma.dbInterface}
+		err := related.validateData(data, change.id == "")
+		if rowErr, ok := err.(*ValidationError); ok {
+			for field, message := range rowErr.Errors {
+				validationErr.Add(change.field+"."+field, message)
+			}
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if validationErr.HasErrors() {
+		return validationErr
+	}
+	return nil
+}
+
+// saveInlineChanges creates, updates and deletes the submitted related
+// objects of the parent through db. Rows naming an object of another parent
+// fail validation, so a form can't change objects it doesn't list.
+func saveInlineChanges(ctx context.Context, db DatabaseInterface, parentID string, changes []inlineChange) error {
+	for _, change := range changes {
+		if change.id != "" {
+			if err := checkInlineParent(ctx, db, parentID, change); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		switch {
+		case change.delete:
+			err = db.Delete(ctx, change.inline.Model, change.id)
+		case change.id == "":
+			change.data[change.inline.FKField] = parentID
+			_, err = db.Create(ctx, change.inline.Model, change.data)
+		default:
+			_, err = db.Update(ctx, change.inline.Model, change.id, change.data)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save %s: %w", change.field, err)
+		}
+	}
+	return nil
+}
+
+// checkInlineParent fails validation unless the existing related object of
+// a change belongs to the parent
+func checkInlineParent(ctx context.Context, db DatabaseInterface, parentID string, change inlineChange) error {
+	obj, err := db.GetByID(ctx, change.inline.Model, change.id)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", change.field, err)
+	}
+	fields, _ := objectFields(obj)
+	if parent, ok := fields[change.inline.FKField]; ok && fmt.Sprint(derefValue(parent)) == parentID {
+		return nil
+	}
+
+	validationErr := NewValidationError()
+	validationErr.Add(change.field+".id", "Select a valid choice. That choice is not one of the available choices.")
+	return validationErr
+}
+
+// inTransaction runs fn in a transaction when the database interface
+// supports them, and directly otherwise
+func (ma *ModelAdmin) inTransaction(ctx context.Context, fn func(db DatabaseInterface) error) error {
+	if txDB, ok := ma.dbInterface.(TransactionalDatabase); ok {
+		return txDB.InTransaction(ctx, fn)
+	}
+	return fn(ma.dbInterface)
+}
+
+// isBlankRow reports whether every value of a submitted row is empty
+func isBlankRow(row map[string]interface{}) bool {
+	for _, value := range row {
+		if !isEmptyValue(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// isTrue reports whether a submitted flag is set
+func isTrue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		set, _ := strconv.ParseBool(strings.TrimSpace(v))
+		return set || v == "on"
+	}
+	return false
+}
+
+// inlineObjectsValue converts related objects to a protobuf list of structs
+func inlineObjectsValue(objects []interface{}) *structpb.Value {
+	values := make([]*structpb.Value, 0, len(objects))
+	for _, obj := range objects {
+		fields, ok := objectFields(obj)
+		if !ok {
+			continue
+		}
+		row := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(fields))}
+		for name, value := range fields {
+			if pbValue, err := convertToProtobufValue(value); err == nil {
+				row.Fields[name] = pbValue
+			}
+		}
+		values = append(values, structpb.NewStructValue(row))
+	}
+	return structpb.NewListValue(&structpb.ListValue{Values: values})
+}
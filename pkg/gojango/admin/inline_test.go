@@ -0,0 +1,223 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// inlineDBInterface is a mock database that honours exact-match filters,
+// describes posts with their own schema and records transactions
+type inlineDBInterface struct {
+	*mockDBInterface
+	transactions int
+}
+
+func (m *inlineDBInterface) GetAll(ctx context.Context, model interface{}, filters map[string]interface{}, ordering []string, limit, offset int) ([]interface{}, int, error) {
+	var matched []interface{}
+	for _, obj := range m.objects[getModelName(model)] {
+		row := obj.(map[string]interface{})
+		matches := true
+		for field, value := range filters {
+			if fmt.Sprint(row[field]) != fmt.Sprint(value) {
+				matches = false
+			}
+		}
+		if matches {
+			matched = append(matched, obj)
+		}
+	}
+	return matched, len(matched), nil
+}
+
+func (m *inlineDBInterface) Create(ctx context.Context, model interface{}, data map[string]interface{}) (interface{}, error) {
+	modelName := getModelName(model)
+	data["id"] = fmt.Sprint(len(m.objects[modelName]) + 1)
+	m.objects[modelName] = append(m.objects[modelName], data)
+	return data, nil
+}
+
+func (m *inlineDBInterface) GetSchema(model interface{}) (*ModelSchema, error) {
+	if _, ok := model.(*TestPost); ok {
+		return &ModelSchema{
+			Fields: []FieldSchema{
+				{Name: "id", Type: "integer", Required: true, Unique: true},
+				{Name: "title", Type: "string", Required: true},
+				{Name: "content", Type: "string"},
+				{Name: "author_id", Type: "integer", Required: true},
+			},
+		}, nil
+	}
+	return m.mockDBInterface.GetSchema(model)
+}
+
+func (m *inlineDBInterface) InTransaction(ctx context.Context, fn func(tx DatabaseInterface) error) error {
+	m.transactions++
+	return fn(m)
+}
+
+func newInlineAdmin(t *testing.T) (*Site, *ModelAdmin, *inlineDBInterface) {
+	site := NewSite("test")
+	mockDB := &inlineDBInterface{mockDBInterface: newMockDBInterface()}
+	mockDB.objects[getModelName(&TestUser{})] = []interface{}{
+		map[string]interface{}{"id": "1", "username": "john", "email": "john@example.com"},
+		map[string]interface{}{"id": "2", "username": "jane", "email": "jane@example.com"},
+	}
+	mockDB.objects[getModelName(&TestPost{})] = []interface{}{
+		map[string]interface{}{"id": "1", "title": "Hello", "author_id": "1"},
+		map[string]interface{}{"id": "2", "title": "Elsewhere", "author_id": "2"},
+		map[string]interface{}{"id": "3", "title": "Again", "author_id": "1"},
+	}
+
+	admin := NewModelAdmin(&TestUser{}).AddInline(InlineAdmin{Model: &TestPost{}, FKField: "author_id", Extra: 2})
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	return site, admin, mockDB
+}
+
+func TestInlineSchemaAndObjects(t *testing.T) {
+	site, admin, _ := newInlineAdmin(t)
+	require.Len(t, admin.Inlines(), 1)
+	assert.Equal(t, "testpost_set", admin.Inlines()[0].Name)
+
+	schema := admin.GetSchema()
+	require.Len(t, schema.Inlines, 1)
+	inline := schema.Inlines[0]
+	assert.Equal(t, getModelName(&TestPost{}), inline.Model)
+	assert.Equal(t, "author_id", inline.FKField)
+	assert.Equal(t, 2, inline.Extra)
+	var names []string
+	for _, field := range inline.Fields {
+		names = append(names, field.Name)
+	}
+	assert.Equal(t, []string{"id", "title", "content"}, names)
+
+	related, err := admin.GetInlineObjects(context.Background(), "1")
+	require.NoError(t, err)
+	require.Len(t, related["testpost_set"], 2)
+	assert.Equal(t, "Hello", related["testpost_set"][0].(map[string]interface{})["title"])
+	assert.Equal(t, "Again", related["testpost_set"][1].(map[string]interface{})["title"])
+
+	// The gRPC schema lists the inline as a field
+	parts := strings.SplitN(getModelName(&TestUser{}), ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.GetModelSchema(context.Background(), connect.NewRequest(&adminpb.GetModelSchemaRequest{App: parts[0], Model: parts[1]}))
+	require.NoError(t, err)
+	var inlineField *adminpb.FieldInfo
+	for _, field := range resp.Msg.Fields {
+		if field.Name == "testpost_set" {
+			inlineField = field
+		}
+	}
+	require.NotNil(t, inlineField)
+	assert.Equal(t, "inline", inlineField.FieldType)
+	assert.Equal(t, getModelName(&TestPost{}), inlineField.RelatedModel)
+
+	// Related objects are returned as a list of structs
+	value := inlineObjectsValue(related["testpost_set"])
+	rows := value.GetListValue().GetValues()
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Hello", rows[0].GetStructValue().Fields["title"].GetStringValue())
+}
+
+func TestUpdateObjectSavesInlines(t *testing.T) {
+	_, admin, mockDB := newInlineAdmin(t)
+	posts := func() []interface{} { return mockDB.objects[getModelName(&TestPost{})] }
+
+	_, err := admin.updateObject(context.Background(), "1", map[string]interface{}{
+		"username": "johnny",
+		"testpost_set": []interface{}{
+			map[string]interface{}{"id": "1", "title": "Hello again", "author_id": "2"},
+			map[string]interface{}{"id": "3", "_delete": true},
+			map[string]interface{}{"title": "New post", "content": "Body"},
+			map[string]interface{}{"title": "", "content": ""},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, mockDB.transactions)
+	assert.Equal(t, "johnny", mockDB.objects[getModelName(&TestUser{})][0].(map[string]interface{})["username"])
+
+	require.Len(t, posts(), 3)
+	updated := posts()[0].(map[string]interface{})
+	assert.Equal(t, "Hello again", updated["title"])
+	assert.Equal(t, "1", updated["author_id"], "related objects cannot be moved to another parent")
+	created := posts()[2].(map[string]interface{})
+	assert.Equal(t, "New post", created["title"])
+	assert.Equal(t, "1", created["author_id"])
+
+	related, err := admin.GetInlineObjects(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Len(t, related["testpost_set"], 2)
+}
+
+func TestUpdateObjectInlineOtherParent(t *testing.T) {
+	_, admin, mockDB := newInlineAdmin(t)
+	post := mockDB.objects[getModelName(&TestPost{})][1].(map[string]interface{})
+
+	// Post 2 belongs to user 2, so user 1's form can't change or delete it
+	for _, row := range []map[string]interface{}{
+		{"id": "2", "title": "Taken over"},
+		{"id": "2", "_delete": true},
+	} {
+		_, err := admin.updateObject(context.Background(), "1", map[string]interface{}{
+			"testpost_set": []interface{}{row},
+		})
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr), err)
+		assert.Contains(t, validationErr.Errors, "testpost_set.0.id")
+	}
+	assert.Len(t, mockDB.objects[getModelName(&TestPost{})], 3)
+	assert.Equal(t, "Elsewhere", post["title"])
+}
+
+func TestUpdateObjectInlineValidation(t *testing.T) {
+	_, admin, mockDB := newInlineAdmin(t)
+
+	_, err := admin.updateObject(context.Background(), "1", map[string]interface{}{
+		"testpost_set": `[{"content": "No title"}]`,
+	})
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), err)
+	assert.Equal(t, map[string]string{"testpost_set.0.title": "This field is required."}, validationErr.Errors)
+	assert.Len(t, mockDB.objects[getModelName(&TestPost{})], 3)
+	assert.Equal(t, 0, mockDB.transactions)
+
+	_, err = admin.updateObject(context.Background(), "1", map[string]interface{}{"testpost_set": "not json"})
+	require.True(t, errors.As(err, &validationErr), err)
+	assert.Contains(t, validationErr.Errors, "testpost_set")
+}
+
+func TestEntDatabaseInterfaceInTransaction(t *testing.T) {
+	db := newDocumentDB(t)
+	entDB := NewEntDatabaseInterface(&documentEntClient{Document: &documentClient{db: db}, db: db})
+	ctx := context.Background()
+	title := func() string {
+		var title string
+		require.NoError(t, db.QueryRow("SELECT title FROM documents WHERE id = 1").Scan(&title))
+		return title
+	}
+
+	// A failing write rolls back the earlier ones
+	err := entDB.InTransaction(ctx, func(tx DatabaseInterface) error {
+		if _, err := tx.(VersionedUpdater).UpdateIfVersion(ctx, &Document{}, "1", "version", "3", map[string]interface{}{"title": "rolled back"}); err != nil {
+			return err
+		}
+		return errors.New("inline failed")
+	})
+	assert.EqualError(t, err, "inline failed")
+	assert.Equal(t, "draft", title())
+
+	err = entDB.InTransaction(ctx, func(tx DatabaseInterface) error {
+		_, err := tx.(VersionedUpdater).UpdateIfVersion(ctx, &Document{}, "1", "version", "3", map[string]interface{}{"title": "committed"})
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "committed", title())
+}
@@ -0,0 +1,299 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
+	"github.com/gin-gonic/gin"
+)
+
+// ListEditResult reports whether the list view edits of an object were
+// saved. Errors holds per-field validation messages.
+type ListEditResult struct {
+	ID      string            `json:"id"`
+	Success bool              `json:"success"`
+	Error   string            `json:"error,omitempty"`
+	Errors  map[string]string `json:"errors,omitempty"`
+	Object  interface{}       `json:"object,omitempty"`
+}
+
+// SetListEditable sets the fields edited in place in the list view, like
+// Django's list_editable. Register checks that they are displayed in the
+// list and not read-only.
+func (ma *ModelAdmin) SetListEditable(fields ...string) *ModelAdmin {
+	ma.listEditable = fields
+	return ma
+}
+
+// ListEditable returns the fields edited in place in the list view
+func (ma *ModelAdmin) ListEditable() []string {
+	return append([]string(nil), ma.listEditable...)
+}
+
+// validateListEditable checks that list editable fields are in the list
+// display and can be changed
+func (ma *ModelAdmin) validateListEditable() error {
+	displayed := make(map[string]bool, len(ma.listDisplay))
+	for _, field := range ma.listDisplay {
+		displayed[field] = true
+	}
+	readonly := make(map[string]bool)
+	for _, field := range ma.readonlyFields() {
+		readonly[field] = true
+	}
+
+	for _, field := range ma.listEditable {
+		if !displayed[field] {
+			return fmt.Errorf("list editable field %q of %s is not in the list display", field, ma.modelName)
+		}
+		if readonly[field] {
+			return fmt.Errorf("list editable field %q of %s is read-only", field, ma.modelName)
+		}
+	}
+	return nil
+}
+
+// listEdit is a validated edit waiting to be saved
+type listEdit struct {
+	result  *ListEditResult
+	data    map[string]interface{}
+	known   interface{}
+	message string
+}
+
+// SaveListEdits applies the changed fields of each object, keyed by object
+// ID, and reports the outcome per object in ID order. Objects whose edits
+// are invalid, or that canChange rejects, are skipped. When the database
+// interface is a TransactionalDatabase, the rest are saved in one
+// transaction, so a database error fails all of them; otherwise each is
+// saved on its own and fails alone. A nil canChange allows every object.
+func (ma *ModelAdmin) SaveListEdits(ctx context.Context, rows map[string]map[string]interface{}, canChange func(obj interface{}) bool) ([]ListEditResult, error) {
+	if ma.dbInterface == nil {
+		return nil, fmt.Errorf("database interface not set")
+	}
+
+	ids := make([]string, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	editable := make(map[string]bool, len(ma.listEditable))
+	for _, field := range ma.listEditable {
+		editable[field] = true
+	}
+
+	results := make([]ListEditResult, len(ids))
+	var pending []*listEdit
+	for i, id := range ids {
+		results[i].ID = id
+		edit := &listEdit{result: &results[i], data: make(map[string]interface{}, len(rows[id]))}
+		for field, value := range rows[id] {
+			edit.data[field] = value
+		}
+
+		validationErr := NewValidationError()
+		if ma.versionField != "" {
+			known, err := ma.takeVersion(edit.data)
+			if errors.As(err, &validationErr) {
+				results[i].Errors = validationErr.Errors
+				continue
+			}
+			edit.known = known
+		}
+
+		for field := range edit.data {
+			if !editable[field] {
+				validationErr.Add(field, "This field is not editable in the list.")
+			}
+		}
+		if len(validationErr.Errors) > 0 {
+			results[i].Errors = validationErr.Errors
+			continue
+		}
+
+		obj, err := ma.loadObject(ctx, id)
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		if canChange != nil && !canChange(obj) {
+			results[i].Error = "Permission denied"
+			continue
+		}
+
+		edit.message = changeMessage(edit.data)
+		ma.applyAutoTimestamps(edit.data, false)
+		if err := ma.validateData(edit.data, false); err != nil {
+			if errors.As(err, &validationErr) {
+				results[i].Errors = validationErr.Errors
+			} else {
+				results[i].Error = err.Error()
+			}
+			continue
+		}
+
+		if err := signals.PreSave.Send(ctx, ma.model, map[string]interface{}{"id": id, "data": edit.data, "created": false}); err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		pending = append(pending, edit)
+	}
+
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	if _, ok := ma.dbInterface.(TransactionalDatabase); ok {
+		err := ma.inTransaction(ctx, func(db DatabaseInterface) error {
+			for _, edit := range pending {
+				obj, err := ma.saveListEdit(ctx, db, edit)
+				if err != nil {
+					return err
+				}
+				edit.result.Object = obj
+			}
+			return nil
+		})
+		if err != nil {
+			for _, edit := range pending {
+				edit.result.Object = nil
+				edit.result.Error = err.Error()
+			}
+			return results, nil
+		}
+	} else {
+		for _, edit := range pending {
+			obj, err := ma.saveListEdit(ctx, ma.dbInterface, edit)
+			if err != nil {
+				edit.result.Error = err.Error()
+				continue
+			}
+			edit.result.Object = obj
+		}
+	}
+
+	for _, edit := range pending {
+		if edit.result.Error != "" {
+			continue
+		}
+		id := edit.result.ID
+		if err := signals.PostSave.Send(ctx, ma.model, map[string]interface{}{"id": id, "instance": edit.result.Object, "data": edit.data, "created": false}); err != nil {
+			edit.result.Error = err.Error()
+			continue
+		}
+		edit.result.Success = true
+		ma.logAction(ctx, "update", id, edit.message)
+	}
+	return results, nil
+}
+
+// saveListEdit saves the edits of an object through db
+func (ma *ModelAdmin) saveListEdit(ctx context.Context, db DatabaseInterface, edit *listEdit) (interface{}, error) {
+	var obj interface{}
+	var err error
+	if ma.versionField != "" {
+		obj, err = ma.saveVersioned(ctx, db, edit.result.ID, edit.data, edit.known)
+	} else {
+		obj, err = db.Update(ctx, ma.model, edit.result.ID, edit.data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save %s %s: %w", ma.verboseName, edit.result.ID, err)
+	}
+	return obj, nil
+}
+
+// handleAPIListEdit saves list view edits sent as a JSON object mapping
+// object IDs to their changed fields
+func (s *Site) handleAPIListEdit(c *gin.Context) {
+	modelKey := fmt.Sprintf("%s.%s", c.Param("app"), c.Param("model"))
+
+	admin, exists := s.GetModelAdmin(modelKey)
+	if !exists {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+	user := CurrentUser(c)
+	if !s.canChange(user, admin.model) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+
+	var rows map[string]map[string]interface{}
+	if err := json.NewDecoder(c.Request.Body).Decode(&rows); err != nil {
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid edits: %v", err)})
+		return
+	}
+
+	results, err := admin.SaveListEdits(c, rows, func(obj interface{}) bool { return s.canChange(user, obj) })
+	if err != nil {
+		render.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	saved := 0
+	for i, result := range results {
+		if result.Success {
+			saved++
+			results[i].Object = admin.SerializeObject(result.Object)
+		}
+	}
+	render.JSON(c, http.StatusOK, gin.H{
+		"results": results,
+		"saved":   saved,
+		"failed":  len(results) - saved,
+	})
+}
+
+// SaveListEdits saves edits made in the list view
+func (h *AdminServiceHandler) SaveListEdits(
+	ctx context.Context,
+	req *connect.Request[adminpb.SaveListEditsRequest],
+) (*connect.Response[adminpb.SaveListEditsResponse], error) {
+	modelAdmin, err := h.getModelAdmin(req.Msg.App, req.Msg.Model)
+	if err != nil {
+		return nil, err
+	}
+	user := UserFromContext(ctx)
+	if !h.site.canChange(user, modelAdmin.model) {
+		return nil, permissionDenied("change", modelAdmin)
+	}
+
+	rows := make(map[string]map[string]interface{}, len(req.Msg.Rows))
+	for id, row := range req.Msg.Rows {
+		rows[id] = row.AsMap()
+	}
+
+	results, err := modelAdmin.SaveListEdits(ctx, rows, func(obj interface{}) bool { return h.site.canChange(user, obj) })
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to save %s: %w", modelAdmin.modelName, err))
+	}
+
+	response := &adminpb.SaveListEditsResponse{}
+	for _, result := range results {
+		converted := &adminpb.ListEditResult{
+			Id:      result.ID,
+			Success: result.Success,
+			Error:   result.Error,
+		}
+		for field, message := range result.Errors {
+			converted.Errors = append(converted.Errors, &adminpb.ValidationError{Field: field, Message: message, Code: "invalid"})
+		}
+		sort.Slice(converted.Errors, func(i, j int) bool { return converted.Errors[i].Field < converted.Errors[j].Field })
+		if result.Success {
+			if objectData, err := modelAdmin.ObjectData(result.Object); err == nil {
+				converted.Object = objectData
+			}
+		}
+		response.Results = append(response.Results, converted)
+	}
+	return connect.NewResponse(response), nil
+}
@@ -0,0 +1,170 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingUpdateDB is a mock database whose updates of one object fail
+type failingUpdateDB struct {
+	*inlineDBInterface
+	failID string
+}
+
+func (m *failingUpdateDB) Update(ctx context.Context, model interface{}, id interface{}, data map[string]interface{}) (interface{}, error) {
+	if id == m.failID {
+		return nil, errors.New("disk full")
+	}
+	return m.inlineDBInterface.Update(ctx, model, id, data)
+}
+
+func (m *failingUpdateDB) InTransaction(ctx context.Context, fn func(tx DatabaseInterface) error) error {
+	m.transactions++
+	return fn(m)
+}
+
+func newListEditableAdmin(t *testing.T) (*Site, *ModelAdmin, *inlineDBInterface) {
+	site, _, mockDB := newInlineAdmin(t)
+	admin := NewModelAdmin(&TestUser{}).
+		SetListDisplay("username", "email", "is_active").
+		SetListEditable("email", "is_active")
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	return site, admin, mockDB
+}
+
+func TestSaveListEdits(t *testing.T) {
+	site, admin, mockDB := newListEditableAdmin(t)
+	assert.Equal(t, []string{"email", "is_active"}, admin.ListEditable())
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	site.SetupRoutes(router)
+	parts := strings.SplitN(getModelName(&TestUser{}), ".", 2)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/api/list_edit/"+parts[0]+"/"+parts[1],
+		strings.NewReader(`{"1": {"is_active": false}, "2": {"email": "jane@example.org", "is_active": true}}`)))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var body struct {
+		Results []ListEditResult `json:"results"`
+		Saved   int              `json:"saved"`
+		Failed  int              `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, 2, body.Saved)
+	assert.Equal(t, 0, body.Failed)
+	require.Len(t, body.Results, 2)
+	assert.Equal(t, "1", body.Results[0].ID)
+	assert.True(t, body.Results[0].Success)
+	assert.True(t, body.Results[1].Success)
+	assert.Equal(t, 1, mockDB.transactions, "all rows are saved in one transaction")
+
+	users := mockDB.objects[getModelName(&TestUser{})]
+	assert.Equal(t, false, users[0].(map[string]interface{})["is_active"])
+	assert.Equal(t, "jane@example.org", users[1].(map[string]interface{})["email"])
+}
+
+func TestSaveListEditsPartialFailure(t *testing.T) {
+	_, admin, mockDB := newListEditableAdmin(t)
+
+	results, err := admin.SaveListEdits(context.Background(), map[string]map[string]interface{}{
+		"1": {"email": "johnny@example.com"},
+		"2": {"email": ""},
+		"3": {"is_active": true},
+		"4": {"username": "hacker"},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	assert.True(t, results[0].Success)
+	assert.Empty(t, results[0].Error)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, map[string]string{"email": "This field is required."}, results[1].Errors)
+	assert.False(t, results[2].Success)
+	assert.Contains(t, results[2].Error, "not found")
+	assert.False(t, results[3].Success)
+	assert.Equal(t, map[string]string{"username": "This field is not editable in the list."}, results[3].Errors)
+
+	users := mockDB.objects[getModelName(&TestUser{})]
+	assert.Equal(t, "johnny@example.com", users[0].(map[string]interface{})["email"])
+	assert.Equal(t, "jane@example.com", users[1].(map[string]interface{})["email"], "invalid rows are not saved")
+
+	// A database error fails every row of the transaction
+	failing := &failingUpdateDB{inlineDBInterface: mockDB, failID: "2"}
+	admin.SetDatabaseInterface(failing)
+	results, err = admin.SaveListEdits(context.Background(), map[string]map[string]interface{}{
+		"1": {"is_active": true},
+		"2": {"is_active": true},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.False(t, result.Success)
+		assert.Contains(t, result.Error, "disk full")
+	}
+}
+
+// plainDB hides the transactions of a database interface
+type plainDB struct {
+	DatabaseInterface
+}
+
+func TestSaveListEditsWithoutTransactions(t *testing.T) {
+	_, admin, mockDB := newListEditableAdmin(t)
+	admin.SetDatabaseInterface(plainDB{&failingUpdateDB{inlineDBInterface: mockDB, failID: "2"}})
+
+	// Each row is saved on its own, so one failing leaves the other saved
+	results, err := admin.SaveListEdits(context.Background(), map[string]map[string]interface{}{
+		"1": {"is_active": false},
+		"2": {"is_active": false},
+	}, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success)
+	assert.Contains(t, results[1].Error, "disk full")
+	assert.Equal(t, 0, mockDB.transactions)
+	assert.Equal(t, false, mockDB.objects[getModelName(&TestUser{})][0].(map[string]interface{})["is_active"])
+}
+
+func TestSaveListEditsChecksObjectPermissions(t *testing.T) {
+	_, admin, mockDB := newListEditableAdmin(t)
+
+	results, err := admin.SaveListEdits(context.Background(), map[string]map[string]interface{}{
+		"1": {"email": "johnny@example.com"},
+		"2": {"email": "janet@example.com"},
+	}, func(obj interface{}) bool {
+		return obj.(map[string]interface{})["username"] != "jane"
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Success)
+	assert.False(t, results[1].Success)
+	assert.Equal(t, "Permission denied", results[1].Error)
+	assert.Equal(t, "jane@example.com", mockDB.objects[getModelName(&TestUser{})][1].(map[string]interface{})["email"])
+}
+
+func TestListEditableValidation(t *testing.T) {
+	site := NewSite("test")
+
+	err := site.Register(&TestUser{}, NewModelAdmin(&TestUser{}).SetListDisplay("username").SetListEditable("email"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not in the list display")
+
+	err = site.Register(&TestUser{}, NewModelAdmin(&TestUser{}).
+		SetListDisplay("username", "created_at").
+		SetAutoNowAdd("created_at").
+		SetListEditable("created_at"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "read-only")
+}
@@ -2,13 +2,22 @@ package admin
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/epuerta9/gojango/pkg/gojango/admin/widgets"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
 	"github.com/gin-gonic/gin"
 )
 
@@ -23,6 +32,7 @@ type ModelAdmin struct {
 	listDisplay        []string
 	listDisplayLinks   []string
 	listFilter         []string
+	filterSet          *FilterSet
 	searchFields       []string
 	ordering           []string
 	
@@ -31,15 +41,66 @@ type ModelAdmin struct {
 	exclude            []string
 	readonly           []string
 	
+	// Timestamps managed server-side
+	autoNowAdd         []string
+	autoNow            []string
+	
 	// Permissions
 	permissions        map[string]bool
 	
 	// Pagination
 	listPerPage        int
 	maxShowAll         int
+	listPerPageSet     bool // true when listPerPage overrides the site default
+	maxShowAllSet      bool // true when maxShowAll overrides the site default
+	cursorField        string // order field of cursor pagination, "" for page numbers
+	
+	// Soft delete
+	softDeleteField    string // timestamp set on delete instead of removing the row, "" to hard delete
+	
+	// Optimistic concurrency
+	versionField       string // version or timestamp checked on update, "" to not check
+	
+	// Related objects edited on the change form
+	inlines            []InlineAdmin
+	
+	// Audit log of admin actions, set by the site
+	auditLog           *AuditLog
+	
+	// Table rows are bulk inserted into on import, nil to create them one at a time
+	importConn         *db.Connection
+	importTable        string
+	
+	// Fields edited in place in the list view
+	listEditable       []string
+	
+	// Sections of the change form
+	fieldsets          []Fieldset
+	
+	// Fields filled in from others when adding objects, e.g. slug from title
+	prepopulatedFields map[string][]string
+	
+	// Date drill-down of the list view
+	dateHierarchy      string
+	location           *time.Location // time zone of the date hierarchy, set by the site
+	
+	// Widgets of form fields, by field name
+	fieldWidgets       map[string]widgets.Widget
+	
+	// Uploaded files, set by the site
+	mediaRoot          string // directory uploads are saved in
+	mediaURL           string // URL uploads are served at
+	
+	// Bulk operations
+	bulkConcurrency    int
+	
+	// Serialization
+	omitEmptyFields    bool
+	omitEmptyFieldsSet bool // true when omitEmptyFields overrides the site default
 	
 	// Actions
 	actions            map[string]Action
+	extendedActions    map[string]ExtendedAction
 	actionsOnTop       bool
 	actionsOnBottom    bool
 	
@@ -65,6 +126,8 @@ type DatabaseInterface interface {
 type ModelSchema struct {
 	Fields    []FieldSchema `json:"fields"`
 	Relations []RelationSchema `json:"relations"`
+	Inlines   []InlineSchema   `json:"inlines,omitempty"`
+	Fieldsets []Fieldset       `json:"fieldsets,omitempty"`
 }
 
 // FieldSchema represents a database field
@@ -78,6 +141,9 @@ type FieldSchema struct {
 	Default      interface{} `json:"default,omitempty"`
 	HelpText     string      `json:"help_text,omitempty"`
 	Verbose      string      `json:"verbose_name,omitempty"`
+	Readonly     bool        `json:"readonly,omitempty"`
+	PrepopulatedFrom []string `json:"prepopulated_from,omitempty"`
+	Widget       *widgets.WidgetConfig `json:"widget,omitempty"`
 }
 
 // RelationSchema represents a database relation
@@ -111,6 +177,7 @@ type ListData struct {
 	HasPrev    bool         `json:"has_prev"`
 	NumPages   int          `json:"num_pages"`
 	Filters    interface{}  `json:"filters"`
+	ActiveFilters []string  `json:"active_filters"`
 	Query      string       `json:"query"`
 }
 
@@ -128,15 +195,17 @@ func NewModelAdmin(model interface{}) *ModelAdmin {
 		listDisplay:        []string{"__str__"},
 		listDisplayLinks:   []string{},
 		listFilter:         []string{},
+		filterSet:          NewFilterSet(),
 		searchFields:       []string{},
 		ordering:           []string{},
 		fields:             []string{},
 		exclude:            []string{},
 		readonly:           []string{},
 		permissions:        make(map[string]bool),
-		listPerPage:        100,
-		maxShowAll:         200,
+		listPerPage:        DefaultListPerPage,
+		maxShowAll:         DefaultMaxPageSize,
 		actions:            make(map[string]Action),
+		extendedActions:    make(map[string]ExtendedAction),
 		actionsOnTop:       false,
 		actionsOnBottom:    true,
 		listMethods:        make(map[string]func(obj interface{}) interface{}),
@@ -169,24 +238,7 @@ func (ma *ModelAdmin) GetListData(ctx *gin.Context, query url.Values) (*ListData
 	}
 	
 	searchQuery := query.Get("q")
-	
-	// Build filters
-	filters := make(map[string]interface{})
-	for key, values := range query {
-		if strings.HasPrefix(key, "filter_") && len(values) > 0 {
-			fieldName := strings.TrimPrefix(key, "filter_")
-			filters[fieldName] = values[0]
-		}
-	}
-	
-	// Add search filters
-	if searchQuery != "" && len(ma.searchFields) > 0 {
-		searchFilters := make(map[string]interface{})
-		for _, field := range ma.searchFields {
-			searchFilters[field+"__icontains"] = searchQuery
-		}
-		filters["__search"] = searchFilters
-	}
+	filters := ma.listFilters(query)
 	
 	offset := (page - 1) * perPage
 	objects, total, err := ma.dbInterface.GetAll(ctx, ma.model, filters, ma.ordering, perPage, offset)
@@ -197,7 +249,7 @@ func (ma *ModelAdmin) GetListData(ctx *gin.Context, query url.Values) (*ListData
 	numPages := (total + perPage - 1) / perPage
 	
 	return &ListData{
-		Objects:  objects,
+		Objects:  ma.applyListMethods(objects),
 		Total:    total,
 		Page:     page,
 		PerPage:  perPage,
@@ -206,9 +258,51 @@ func (ma *ModelAdmin) GetListData(ctx *gin.Context, query url.Values) (*ListData
 		NumPages: numPages,
 		Query:    searchQuery,
 		Filters:  ma.getFilterData(ctx),
+		ActiveFilters: ma.filterSet.GetActiveFilterSummary(query),
 	}, nil
 }
 
+// listFilters builds the filters of the list view from its query parameters
+func (ma *ModelAdmin) listFilters(query url.Values) map[string]interface{} {
+	// Parameters of configured filters are translated by the filter
+	filters := ma.filterSet.ApplyFilters(query)
+	for key, values := range query {
+		if strings.HasPrefix(key, "filter_") && len(values) > 0 {
+			fieldName := strings.TrimPrefix(key, "filter_")
+			if ma.hasFilterParameter(fieldName) {
+				continue
+			}
+			filters[fieldName] = values[0]
+		}
+	}
+	
+	ma.addSearchFilters(filters, query.Get("q"))
+	ma.addSoftDeleteFilter(filters, query.Get("show_deleted"))
+	ma.addDateHierarchyFilters(filters, query)
+	return filters
+}
+
+// addSearchFilters adds a filter matching searchQuery in any search field
+func (ma *ModelAdmin) addSearchFilters(filters map[string]interface{}, searchQuery string) {
+	if searchQuery != "" && len(ma.searchFields) > 0 {
+		searchFilters := make(map[string]interface{})
+		for _, field := range ma.searchFields {
+			searchFilters[field+"__icontains"] = searchQuery
+		}
+		filters["__search"] = searchFilters
+	}
+}
+
+// addSoftDeleteFilter hides soft-deleted objects unless showDeleted is true
+func (ma *ModelAdmin) addSoftDeleteFilter(filters map[string]interface{}, showDeleted string) {
+	if ma.softDeleteField == "" {
+		return
+	}
+	if show, _ := strconv.ParseBool(showDeleted); !show {
+		filters[ma.softDeleteField+"__isnull"] = true
+	}
+}
+
 // GetAPIData retrieves data for API endpoints
 func (ma *ModelAdmin) GetAPIData(ctx *gin.Context, query url.Values) (interface{}, error) {
 	listData, err := ma.GetListData(ctx, query)
@@ -217,7 +311,7 @@ func (ma *ModelAdmin) GetAPIData(ctx *gin.Context, query url.Values) (interface{
 	}
 	
 	return gin.H{
-		"results":     listData.Objects,
+		"results":     ma.SerializeObjects(listData.Objects),
 		"count":       listData.Total,
 		"page":        listData.Page,
 		"per_page":    listData.PerPage,
@@ -226,20 +320,36 @@ func (ma *ModelAdmin) GetAPIData(ctx *gin.Context, query url.Values) (interface{
 		"has_prev":    listData.HasPrev,
 		"query":       listData.Query,
 		"filters":     listData.Filters,
+		"active_filters": listData.ActiveFilters,
 		"list_display": ma.listDisplay,
 		"search_fields": ma.searchFields,
 		"list_filter":  ma.listFilter,
+		"list_editable": ma.listEditable,
+		"date_hierarchy": ma.dateHierarchy,
+		"widgets":      ma.objectsWidgets(listData.Objects),
 		"actions":      ma.getActionsList(),
 	}, nil
 }
 
 // GetObject retrieves a single object by ID
 func (ma *ModelAdmin) GetObject(ctx *gin.Context, id string) (interface{}, error) {
+	return ma.loadObject(ctx, id)
+}
+
+// loadObject retrieves a single object by ID, treating a nil result as not found
+func (ma *ModelAdmin) loadObject(ctx context.Context, id string) (interface{}, error) {
 	if ma.dbInterface == nil {
 		return nil, fmt.Errorf("database interface not set")
 	}
 	
-	return ma.dbInterface.GetByID(ctx, ma.model, id)
+	obj, err := ma.dbInterface.GetByID(ctx, ma.model, id)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, fmt.Errorf("object %s not found", id)
+	}
+	return obj, nil
 }
 
 // CreateObject creates a new object
@@ -253,12 +363,39 @@ func (ma *ModelAdmin) CreateObject(ctx *gin.Context, request *http.Request) (int
 		return nil, fmt.Errorf("failed to extract form data: %w", err)
 	}
 	
+	ma.applyAutoTimestamps(data, true)
+	if err := ma.prepopulate(ctx, data, nil); err != nil {
+		return nil, err
+	}
+	
 	// Validate data
 	if err := ma.validateData(data, true); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 	
-	return ma.dbInterface.Create(ctx, ma.model, data)
+	uploads, err := ma.saveUploads(data)
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := signals.PreSave.Send(ctx, ma.model, map[string]interface{}{"data": data, "created": true}); err != nil {
+		ma.removeUploads(uploads)
+		return nil, err
+	}
+	
+	obj, err := ma.dbInterface.Create(ctx, ma.model, data)
+	if err != nil {
+		ma.removeUploads(uploads)
+		return nil, err
+	}
+	
+	if err := signals.PostSave.Send(ctx, ma.model, map[string]interface{}{"instance": obj, "data": data, "created": true}); err != nil {
+		return nil, err
+	}
+	
+	objectID, _ := extractObjectID(obj)
+	ma.logAction(ctx, "create", objectID, "Added.")
+	return obj, nil
 }
 
 // UpdateObject updates an existing object
@@ -272,21 +409,161 @@ func (ma *ModelAdmin) UpdateObject(ctx *gin.Context, id string, request *http.Re
 		return nil, fmt.Errorf("failed to extract form data: %w", err)
 	}
 	
+	return ma.updateObject(ctx, id, data)
+}
+
+// ChangeObject validates and saves data for an existing object on the
+// server's behalf, e.g. from admin actions, like a submitted form would.
+// Unlike clients, it needn't pass the version of versioned models: data
+// without one is saved against the version of the object as loaded.
+func (ma *ModelAdmin) ChangeObject(ctx context.Context, id string, data map[string]interface{}) (interface{}, error) {
+	if ma.versionField != "" && isEmptyValue(data[ma.versionField]) {
+		obj, err := ma.loadObject(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		fields, _ := objectFields(obj)
+		withVersion := make(map[string]interface{}, len(data)+1)
+		for field, value := range data {
+			withVersion[field] = value
+		}
+		withVersion[ma.versionField] = derefValue(fields[ma.versionField])
+		data = withVersion
+	}
+	return ma.updateObject(ctx, id, data)
+}
+
+// updateObject validates and saves submitted data for an existing object
+func (ma *ModelAdmin) updateObject(ctx context.Context, id string, data map[string]interface{}) (interface{}, error) {
+	if ma.dbInterface == nil {
+		return nil, fmt.Errorf("database interface not set")
+	}
+	
+	var known interface{}
+	if ma.versionField != "" {
+		var err error
+		if known, err = ma.takeVersion(data); err != nil {
+			return nil, fmt.Errorf("validation failed: %w", err)
+		}
+	}
+	
+	// Related objects are saved separately, after the object
+	changes, err := ma.extractInlineChanges(data)
+	if err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	
+	message := changeMessage(data)
+	ma.applyAutoTimestamps(data, false)
+	
 	// Validate data
 	if err := ma.validateData(data, false); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
+	if err := ma.validateInlineChanges(id, changes); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+	
+	uploads, err := ma.saveUploads(data)
+	if err != nil {
+		return nil, err
+	}
 	
-	return ma.dbInterface.Update(ctx, ma.model, id, data)
+	if err := signals.PreSave.Send(ctx, ma.model, map[string]interface{}{"id": id, "data": data, "created": false}); err != nil {
+		ma.removeUploads(uploads)
+		return nil, err
+	}
+	
+	var obj interface{}
+	err = ma.inTransaction(ctx, func(db DatabaseInterface) error {
+		var err error
+		if ma.versionField != "" {
+			obj, err = ma.saveVersioned(ctx, db, id, data, known)
+		} else {
+			obj, err = db.Update(ctx, ma.model, id, data)
+		}
+		if err != nil {
+			return err
+		}
+		return saveInlineChanges(ctx, db, id, changes)
+	})
+	if err != nil {
+		ma.removeUploads(uploads)
+		return nil, err
+	}
+	
+	if err := signals.PostSave.Send(ctx, ma.model, map[string]interface{}{"id": id, "instance": obj, "data": data, "created": false}); err != nil {
+		return nil, err
+	}
+	
+	ma.logAction(ctx, "update", id, message)
+	return obj, nil
 }
 
-// DeleteObject deletes an object
+// DeleteObject deletes an object, or marks it deleted when the model uses
+// soft delete
 func (ma *ModelAdmin) DeleteObject(ctx *gin.Context, id string) error {
+	return ma.deleteObject(ctx, id)
+}
+
+// deleteObject deletes an object. Delete signals of soft deletes carry
+// "soft": true.
+func (ma *ModelAdmin) deleteObject(ctx context.Context, id string) error {
 	if ma.dbInterface == nil {
 		return fmt.Errorf("database interface not set")
 	}
 	
-	return ma.dbInterface.Delete(ctx, ma.model, id)
+	kwargs := map[string]interface{}{"id": id}
+	if ma.softDeleteField != "" {
+		kwargs["soft"] = true
+	}
+	
+	if err := signals.PreDelete.Send(ctx, ma.model, kwargs); err != nil {
+		return err
+	}
+	
+	if ma.softDeleteField != "" {
+		if _, err := ma.dbInterface.Update(ctx, ma.model, id, map[string]interface{}{ma.softDeleteField: time.Now()}); err != nil {
+			return err
+		}
+	} else if err := ma.dbInterface.Delete(ctx, ma.model, id); err != nil {
+		return err
+	}
+	
+	if err := signals.PostDelete.Send(ctx, ma.model, kwargs); err != nil {
+		return err
+	}
+	
+	ma.logAction(ctx, "delete", id, "Deleted.")
+	return nil
+}
+
+// RestoreObject clears the soft delete timestamp of an object, so it is
+// listed again
+func (ma *ModelAdmin) RestoreObject(ctx *gin.Context, id string) error {
+	if ma.softDeleteField == "" {
+		return fmt.Errorf("%s does not use soft delete", ma.verboseName)
+	}
+	if ma.dbInterface == nil {
+		return fmt.Errorf("database interface not set")
+	}
+	
+	data := map[string]interface{}{ma.softDeleteField: nil}
+	if err := signals.PreSave.Send(ctx, ma.model, map[string]interface{}{"id": id, "data": data, "created": false}); err != nil {
+		return err
+	}
+	
+	obj, err := ma.dbInterface.Update(ctx, ma.model, id, data)
+	if err != nil {
+		return err
+	}
+	
+	if err := signals.PostSave.Send(ctx, ma.model, map[string]interface{}{"id": id, "instance": obj, "data": data, "created": false}); err != nil {
+		return err
+	}
+	
+	ma.logAction(ctx, "restore", id, "Restored.")
+	return nil
 }
 
 // ExecuteBulkAction executes a bulk action on selected objects
@@ -307,27 +584,67 @@ func (ma *ModelAdmin) ExecuteBulkAction(ctx *gin.Context, request *http.Request)
 		return nil, fmt.Errorf("no objects selected")
 	}
 	
-	// Get selected objects
-	var objects []interface{}
-	for _, id := range selectedIDs {
-		obj, err := ma.GetObject(ctx, id)
+	// Get selected objects, loading them with bounded concurrency on a copy
+	// of the context, as a gin.Context can't be shared between goroutines
+	objects := make([]interface{}, len(selectedIDs))
+	errs := make([]error, len(selectedIDs))
+	loadCtx := ctx.Copy()
+	runBounded(ma.BulkConcurrency(), len(selectedIDs), func(i int) {
+		objects[i], errs[i] = ma.GetObject(loadCtx, selectedIDs[i])
+	})
+	for i, err := range errs {
 		if err != nil {
-			return nil, fmt.Errorf("failed to get object %s: %w", id, err)
+			return nil, fmt.Errorf("failed to get object %s: %w", selectedIDs[i], err)
 		}
-		objects = append(objects, obj)
 	}
 	
-	return action.Handler(ctx, objects)
+	// Default actions look up the model admin from the context
+	ctx.Set("model_admin", ma)
+	
+	result, err := action.Handler(ctx, objects)
+	if err != nil {
+		return nil, err
+	}
+	
+	ma.logAction(ctx, actionName, strings.Join(selectedIDs, ","), fmt.Sprintf("Ran %s on %d objects.", actionName, len(selectedIDs)))
+	return result, nil
 }
 
-// GetSchema returns the model schema
+// GetSchema returns the model schema, marking the fields clients cannot set
+// as read-only
 func (ma *ModelAdmin) GetSchema() *ModelSchema {
 	if ma.dbInterface == nil {
 		return &ModelSchema{}
 	}
 	
 	schema, _ := ma.dbInterface.GetSchema(ma.model)
-	return schema
+	if schema == nil {
+		return schema
+	}
+	
+	readonly := make(map[string]bool)
+	for _, field := range ma.readonlyFields() {
+		readonly[field] = true
+	}
+	marked := *schema
+	marked.Fields = make([]FieldSchema, len(schema.Fields))
+	for i, field := range schema.Fields {
+		field.Readonly = readonly[field.Name]
+		field.PrepopulatedFrom = ma.prepopulatedFields[field.Name]
+		field.Widget = ma.widgetConfig(field.Name)
+		marked.Fields[i] = field
+	}
+	marked.Inlines = ma.inlineSchemas()
+	
+	names := make([]string, 0, len(marked.Fields)+len(marked.Inlines))
+	for _, field := range marked.Fields {
+		names = append(names, field.Name)
+	}
+	for _, inline := range marked.Inlines {
+		names = append(names, inline.Name)
+	}
+	marked.Fieldsets = ma.fieldsetsFor(names)
+	return &marked
 }
 
 // GetPermissions returns the permissions for the current user
@@ -352,6 +669,21 @@ func (ma *ModelAdmin) SetListFilter(fields ...string) *ModelAdmin {
 	return ma
 }
 
+// AddListFilter adds a configured filter, such as a RelationFilter, to the
+// list view. Its name is appended to list_filter.
+func (ma *ModelAdmin) AddListFilter(filter Filter) *ModelAdmin {
+	if _, exists := ma.filterSet.GetFilter(filter.Name()); !exists {
+		ma.listFilter = append(ma.listFilter, filter.Name())
+	}
+	ma.filterSet.AddFilter(filter)
+	return ma
+}
+
+// GetListFilter returns the configured filter for a list_filter field
+func (ma *ModelAdmin) GetListFilter(name string) (Filter, bool) {
+	return ma.filterSet.GetFilter(name)
+}
+
 func (ma *ModelAdmin) SetSearchFields(fields ...string) *ModelAdmin {
 	ma.searchFields = fields
 	return ma
@@ -362,11 +694,131 @@ func (ma *ModelAdmin) SetOrdering(fields ...string) *ModelAdmin {
 	return ma
 }
 
+// SetAutoNowAdd sets fields that are set to the current time when an object
+// is created and never changed afterwards, like Django's auto_now_add.
+// Client-supplied values for these fields are ignored.
+func (ma *ModelAdmin) SetAutoNowAdd(fields ...string) *ModelAdmin {
+	ma.autoNowAdd = fields
+	return ma
+}
+
+// SetAutoNow sets fields that are set to the current time whenever an object
+// is created or updated, like Django's auto_now. Client-supplied values for
+// these fields are ignored.
+func (ma *ModelAdmin) SetAutoNow(fields ...string) *ModelAdmin {
+	ma.autoNow = fields
+	return ma
+}
+
+// readonlyFields returns the read-only fields, including auto timestamp
+// fields and the version field, which clients cannot set
+func (ma *ModelAdmin) readonlyFields() []string {
+	fields := append([]string{}, ma.readonly...)
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		seen[field] = true
+	}
+	managed := append(append([]string{}, ma.autoNowAdd...), ma.autoNow...)
+	if ma.versionField != "" {
+		managed = append(managed, ma.versionField)
+	}
+	for _, field := range managed {
+		if !seen[field] {
+			seen[field] = true
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// applyAutoTimestamps replaces client-supplied auto timestamp values with
+// the current time: auto-now-add fields on create only, auto-now fields on
+// every write
+func (ma *ModelAdmin) applyAutoTimestamps(data map[string]interface{}, isCreate bool) {
+	now := time.Now()
+	
+	for _, field := range ma.autoNowAdd {
+		delete(data, field)
+		if isCreate {
+			data[field] = now
+		}
+	}
+	for _, field := range ma.autoNow {
+		data[field] = now
+	}
+}
+
+// SetListPerPage sets the page size for this model, overriding the site default
 func (ma *ModelAdmin) SetListPerPage(count int) *ModelAdmin {
 	ma.listPerPage = count
+	ma.listPerPageSet = true
+	return ma
+}
+
+// SetMaxShowAll sets the largest page size clients may request for this model,
+// overriding the site default
+func (ma *ModelAdmin) SetMaxShowAll(count int) *ModelAdmin {
+	ma.maxShowAll = count
+	ma.maxShowAllSet = true
+	return ma
+}
+
+// GetListPerPage returns the page size used for this model
+func (ma *ModelAdmin) GetListPerPage() int {
+	return ma.listPerPage
+}
+
+// GetMaxShowAll returns the largest page size clients may request for this model
+func (ma *ModelAdmin) GetMaxShowAll() int {
+	return ma.maxShowAll
+}
+
+// SetCursorPagination lists this model a page at a time after a cursor,
+// ordered by field, e.g. "id" or "-created_at", instead of by page number.
+// Deep pages of large tables stay fast and pages don't shift as objects are
+// added. The field must be unique, such as the primary key, and the model's
+// database interface must support its "__gt" and "__lt" filters.
+func (ma *ModelAdmin) SetCursorPagination(field string) *ModelAdmin {
+	ma.cursorField = field
+	return ma
+}
+
+// CursorPaginationField returns the order field of cursor pagination, or
+// "" when the model is paginated by page number
+func (ma *ModelAdmin) CursorPaginationField() string {
+	return ma.cursorField
+}
+
+// SetSoftDelete makes deleting an object set the timestamp field, such as
+// "deleted_at", instead of removing its row. Lists hide soft-deleted objects
+// unless the show_deleted parameter is set, and the restore action brings
+// them back. An empty field restores hard deletes.
+func (ma *ModelAdmin) SetSoftDelete(field string) *ModelAdmin {
+	ma.softDeleteField = field
+	if field != "" {
+		ma.AddAction("restore", "Restore selected items", RestoreSelectedAction)
+	} else {
+		delete(ma.actions, "restore")
+	}
 	return ma
 }
 
+// SoftDeleteField returns the soft delete timestamp field, or "" when
+// objects are deleted for good
+func (ma *ModelAdmin) SoftDeleteField() string {
+	return ma.softDeleteField
+}
+
+// applyPaginationDefaults applies site-wide defaults unless the model overrides them
+func (ma *ModelAdmin) applyPaginationDefaults(listPerPage, maxShowAll int) {
+	if !ma.listPerPageSet {
+		ma.listPerPage = listPerPage
+	}
+	if !ma.maxShowAllSet {
+		ma.maxShowAll = maxShowAll
+	}
+}
+
 func (ma *ModelAdmin) AddAction(name, description string, handler func(ctx *gin.Context, objects []interface{}) (interface{}, error)) *ModelAdmin {
 	ma.actions[name] = Action{
 		Name:        name,
@@ -376,8 +828,81 @@ func (ma *ModelAdmin) AddAction(name, description string, handler func(ctx *gin.
 	return ma
 }
 
+// AddExtendedAction adds an action along with its confirmation settings,
+// permissions and presentation
+func (ma *ModelAdmin) AddExtendedAction(action ExtendedAction) *ModelAdmin {
+	ma.actions[action.Name] = action.Action
+	ma.extendedActions[action.Name] = action
+	return ma
+}
+
+// getExtendedAction returns the extended settings of an action. Actions
+// added with AddAction take the settings of the global action of the same
+// name, so a model's delete_selected requires confirmation by default.
+func (ma *ModelAdmin) getExtendedAction(name string) (ExtendedAction, bool) {
+	action, exists := ma.actions[name]
+	if !exists {
+		return ExtendedAction{}, false
+	}
+	if extended, ok := ma.extendedActions[name]; ok {
+		return extended, true
+	}
+	
+	extended, _ := GlobalActionRegistry.GetExtended(name)
+	extended.Action = action
+	return extended, true
+}
+
+// AddListMethod registers a computed column. When name appears in
+// list_display, fn is called for each row and its result is included in the
+// list output under that name.
+func (ma *ModelAdmin) AddListMethod(name string, fn func(obj interface{}) interface{}) *ModelAdmin {
+	ma.listMethods[name] = fn
+	return ma
+}
+
 // Helper methods
+
+// maxMultipartMemory is the amount of a multipart body kept in memory before
+// uploaded files spill over to temporary files on disk
+const maxMultipartMemory = 32 << 20
+
+// extractFormData reads submitted values from JSON, multipart or urlencoded bodies
 func (ma *ModelAdmin) extractFormData(request *http.Request) (map[string]interface{}, error) {
+	mediaType, _, _ := mime.ParseMediaType(request.Header.Get("Content-Type"))
+	
+	switch mediaType {
+	case "application/json":
+		data := make(map[string]interface{})
+		if request.Body == nil || request.Body == http.NoBody {
+			return data, nil
+		}
+		if err := json.NewDecoder(request.Body).Decode(&data); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("invalid JSON body: %w", err)
+		}
+		return data, nil
+		
+	case "multipart/form-data":
+		if err := request.ParseMultipartForm(maxMultipartMemory); err != nil {
+			return nil, err
+		}
+		
+		data := make(map[string]interface{})
+		for key, values := range request.MultipartForm.Value {
+			if len(values) > 0 {
+				data[key] = values[0]
+			}
+		}
+		// Uploaded files are passed through as *multipart.FileHeader so that
+		// FileInput widgets and the database layer can store them as needed
+		for key, files := range request.MultipartForm.File {
+			if len(files) > 0 {
+				data[key] = files[0]
+			}
+		}
+		return data, nil
+	}
+	
 	if err := request.ParseForm(); err != nil {
 		return nil, err
 	}
@@ -392,15 +917,217 @@ func (ma *ModelAdmin) extractFormData(request *http.Request) (map[string]interfa
 	return data, nil
 }
 
+// ValidationError reports per-field validation failures
+type ValidationError struct {
+	Errors map[string]string `json:"errors"`
+}
+
+// NewValidationError creates an empty validation error
+func NewValidationError() *ValidationError {
+	return &ValidationError{
+		Errors: make(map[string]string),
+	}
+}
+
+// Add records a message for a field, keeping the first message per field
+func (e *ValidationError) Add(field, message string) {
+	if _, exists := e.Errors[field]; !exists {
+		e.Errors[field] = message
+	}
+}
+
+// HasErrors returns true if any field failed validation
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Errors) > 0
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	
+	messages := make([]string, 0, len(fields))
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, e.Errors[field]))
+	}
+	return strings.Join(messages, "; ")
+}
+
+// dateTimeLayouts are the datetime formats accepted from forms and JSON
+var dateTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// validateData validates submitted data against the model schema
 func (ma *ModelAdmin) validateData(data map[string]interface{}, isCreate bool) error {
-	// TODO: Implement field validation based on model schema
+	if ma.dbInterface == nil {
+		return nil
+	}
+	
+	schema, err := ma.dbInterface.GetSchema(ma.model)
+	if err != nil {
+		return fmt.Errorf("failed to get schema: %w", err)
+	}
+	
+	validationErr := NewValidationError()
+	for _, field := range schema.Fields {
+		value, present := data[field.Name]
+		
+		if isEmptyValue(value) {
+			// Primary keys and fields with defaults are filled in by the database
+			if field.Required && field.Name != "id" && field.Default == nil && (isCreate || present) {
+				validationErr.Add(field.Name, "This field is required.")
+			}
+			continue
+		}
+		
+		if msg := validateFieldType(field, value); msg != "" {
+			validationErr.Add(field.Name, msg)
+			continue
+		}
+		
+		if field.MaxLength != nil {
+			if str, ok := value.(string); ok && utf8.RuneCountInString(str) > *field.MaxLength {
+				validationErr.Add(field.Name, fmt.Sprintf("Ensure this value has at most %d characters (it has %d).", *field.MaxLength, utf8.RuneCountInString(str)))
+				continue
+			}
+		}
+		
+		if len(field.Choices) > 0 && !isValidChoice(field.Choices, value) {
+			validationErr.Add(field.Name, fmt.Sprintf("Select a valid choice. %v is not one of the available choices.", value))
+		}
+	}
+	
+	if validationErr.HasErrors() {
+		return validationErr
+	}
 	return nil
 }
 
+// isEmptyValue reports whether a submitted value should be treated as missing
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if str, ok := value.(string); ok {
+		return strings.TrimSpace(str) == ""
+	}
+	return false
+}
+
+// validateFieldType checks that a value can be stored in a field of the
+// declared type, returning an error message or an empty string
+func validateFieldType(field FieldSchema, value interface{}) string {
+	switch field.Type {
+	case "integer":
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return ""
+		case float64:
+			if v == float64(int64(v)) {
+				return ""
+			}
+		case float32:
+			if v == float32(int64(v)) {
+				return ""
+			}
+		case string:
+			if _, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64); err == nil {
+				return ""
+			}
+		}
+		return "Enter a whole number."
+		
+	case "float":
+		switch v := value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			return ""
+		case string:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return ""
+			}
+		}
+		return "Enter a number."
+		
+	case "boolean":
+		switch v := value.(type) {
+		case bool:
+			return ""
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "false", "on", "off", "1", "0", "yes", "no":
+				return ""
+			}
+		}
+		return "Enter a valid boolean."
+		
+	case "datetime":
+		switch v := value.(type) {
+		case time.Time:
+			return ""
+		case string:
+			for _, layout := range dateTimeLayouts {
+				if _, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+					return ""
+				}
+			}
+		}
+		return "Enter a valid date/time."
+	}
+	
+	return ""
+}
+
+// isValidChoice reports whether value matches one of the declared choices
+func isValidChoice(choices []Choice, value interface{}) bool {
+	submitted := fmt.Sprint(value)
+	for _, choice := range choices {
+		if fmt.Sprint(choice.Value) == submitted {
+			return true
+		}
+	}
+	return false
+}
+
+// filterParameterSuffixes are appended to a field name by range filters
+var filterParameterSuffixes = []string{"_from", "_to", "_min", "_max"}
+
+// hasFilterParameter reports whether a filter_ parameter belongs to a
+// configured filter, either directly or as one bound of a range
+func (ma *ModelAdmin) hasFilterParameter(name string) bool {
+	if _, exists := ma.filterSet.GetFilter(name); exists {
+		return true
+	}
+	for _, suffix := range filterParameterSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			if _, exists := ma.filterSet.GetFilter(strings.TrimSuffix(name, suffix)); exists {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (ma *ModelAdmin) getFilterData(ctx *gin.Context) interface{} {
-	// TODO: Generate filter widget data based on list_filter
 	filters := make(map[string]interface{})
 	for _, field := range ma.listFilter {
+		if filter, exists := ma.filterSet.GetFilter(field); exists {
+			widget := filter.GetWidget()
+			filters[field] = map[string]interface{}{
+				"type":    widget.Type,
+				"title":   filter.Title(),
+				"choices": widget.Choices,
+				"config":  widget.Config,
+			}
+			continue
+		}
+		// TODO: Generate filter widget data for plain list_filter fields
 		filters[field] = map[string]interface{}{
 			"type": "text",
 			"choices": []Choice{},
@@ -412,9 +1139,12 @@ func (ma *ModelAdmin) getFilterData(ctx *gin.Context) interface{} {
 func (ma *ModelAdmin) getActionsList() []map[string]interface{} {
 	var actions []map[string]interface{}
 	for name, action := range ma.actions {
+		extended, _ := ma.getExtendedAction(name)
 		actions = append(actions, map[string]interface{}{
-			"name":        name,
-			"description": action.Description,
+			"name":                  name,
+			"description":           action.Description,
+			"requires_confirmation": extended.RequiresConfirmation,
+			"confirmation_message":  extended.ConfirmationMessage,
 		})
 	}
 	return actions
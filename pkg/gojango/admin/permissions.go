@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserContextKey is the gin context key the admin reads the current user from.
+// Authentication middleware should call c.Set(admin.UserContextKey, user).
+const UserContextKey = "user"
+
+// userContextKey is the context.Context key for the current user
+type userContextKey struct{}
+
+// ContextWithUser returns a copy of ctx carrying the current user, for use by
+// callers of the gRPC admin service
+func ContextWithUser(ctx context.Context, user interface{}) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the current user stored in ctx, if any
+func UserFromContext(ctx context.Context) interface{} {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Value(userContextKey{})
+}
+
+// CurrentUser returns the current user from the gin context, falling back
+// to the request context
+func CurrentUser(c *gin.Context) interface{} {
+	if user, exists := c.Get(UserContextKey); exists {
+		return user
+	}
+	if c.Request != nil {
+		return UserFromContext(c.Request.Context())
+	}
+	return nil
+}
+
+// AllowAllPermissions is a PermissionChecker that grants every permission.
+// It is installed by default so the admin works before a checker is configured.
+type AllowAllPermissions struct{}
+
+func (AllowAllPermissions) HasPermission(user interface{}, perm string, obj interface{}) bool {
+	return true
+}
+
+func (AllowAllPermissions) HasAddPermission(user interface{}, model string) bool {
+	return true
+}
+
+func (AllowAllPermissions) HasChangePermission(user interface{}, obj interface{}) bool {
+	return true
+}
+
+func (AllowAllPermissions) HasDeletePermission(user interface{}, obj interface{}) bool {
+	return true
+}
+
+func (AllowAllPermissions) HasViewPermission(user interface{}, obj interface{}) bool {
+	return true
+}
+
+// SetPermissionChecker installs the permission checker used by the admin
+// handlers. Passing nil restores the default allow-all checker.
+func (s *Site) SetPermissionChecker(checker PermissionChecker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if checker == nil {
+		checker = AllowAllPermissions{}
+	}
+	s.permissions = checker
+}
+
+// GetPermissionChecker returns the permission checker in use
+func (s *Site) GetPermissionChecker() PermissionChecker {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.permissions == nil {
+		return AllowAllPermissions{}
+	}
+	return s.permissions
+}
+
+// canAdd checks whether user may create objects of the given model
+func (s *Site) canAdd(user interface{}, modelKey string) bool {
+	return s.GetPermissionChecker().HasAddPermission(user, modelKey)
+}
+
+// canChange checks whether user may modify obj
+func (s *Site) canChange(user interface{}, obj interface{}) bool {
+	return s.GetPermissionChecker().HasChangePermission(user, obj)
+}
+
+// canView checks whether user may view obj
+func (s *Site) canView(user interface{}, obj interface{}) bool {
+	return s.GetPermissionChecker().HasViewPermission(user, obj)
+}
+
+// canDelete checks whether user may delete obj
+func (s *Site) canDelete(user interface{}, obj interface{}) bool {
+	return s.GetPermissionChecker().HasDeletePermission(user, obj)
+}
@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SetPrepopulatedFields sets fields filled in from others when adding
+// objects, like Django's prepopulated_fields, e.g. {"slug": {"title"}}. The
+// form fills them in as the user types; CreateObject slugifies the source
+// fields when one is submitted empty.
+func (ma *ModelAdmin) SetPrepopulatedFields(fields map[string][]string) *ModelAdmin {
+	ma.prepopulatedFields = make(map[string][]string, len(fields))
+	for field, sources := range fields {
+		ma.prepopulatedFields[field] = append([]string(nil), sources...)
+	}
+	return ma
+}
+
+// PrepopulatedFields returns the prepopulated fields and the fields each is
+// derived from
+func (ma *ModelAdmin) PrepopulatedFields() map[string][]string {
+	fields := make(map[string][]string, len(ma.prepopulatedFields))
+	for field, sources := range ma.prepopulatedFields {
+		fields[field] = append([]string(nil), sources...)
+	}
+	return fields
+}
+
+// prepopulate fills in empty prepopulated fields with a slug of their source
+// fields that no other object uses. When taken is not nil, it also avoids
+// the slugs it holds per field and records the ones it assigns, for objects
+// that are saved together.
+func (ma *ModelAdmin) prepopulate(ctx context.Context, data map[string]interface{}, taken map[string]map[string]bool) error {
+	for field, sources := range ma.prepopulatedFields {
+		if !isEmptyValue(data[field]) {
+			continue
+		}
+
+		var parts []string
+		for _, source := range sources {
+			if value, ok := data[source]; ok && !isEmptyValue(value) {
+				parts = append(parts, fmt.Sprint(value))
+			}
+		}
+		slug := slugify(strings.Join(parts, " "))
+		if slug == "" {
+			continue
+		}
+
+		unique, err := ma.uniqueSlug(ctx, field, slug, taken[field])
+		if err != nil {
+			return fmt.Errorf("failed to prepopulate %s: %w", field, err)
+		}
+		if taken != nil {
+			if taken[field] == nil {
+				taken[field] = make(map[string]bool)
+			}
+			taken[field][unique] = true
+		}
+		data[field] = unique
+	}
+	return nil
+}
+
+// uniqueSlug returns slug, or slug with the first -N suffix from -2 no
+// object has in field and that isn't taken
+func (ma *ModelAdmin) uniqueSlug(ctx context.Context, field, slug string, taken map[string]bool) (string, error) {
+	candidate := slug
+	for n := 2; ; n++ {
+		if taken[candidate] {
+			candidate = fmt.Sprintf("%s-%d", slug, n)
+			continue
+		}
+		_, total, err := ma.dbInterface.GetAll(ctx, ma.model, map[string]interface{}{field: candidate}, nil, 1, 0)
+		if err != nil {
+			return "", err
+		}
+		if total == 0 {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", slug, n)
+	}
+}
+
+// slugify lowercases s, drops everything but ASCII letters, digits, spaces,
+// hyphens and underscores, and joins the remaining words with single hyphens
+func slugify(s string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(r)
+		case r == ' ', r == '\t', r == '\n', r == '-', r == '_':
+			pendingHyphen = true
+		}
+	}
+	return b.String()
+}
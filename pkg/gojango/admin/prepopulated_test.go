@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Page mimics an Ent entity with a slug
+type Page struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// slugDBInterface is a mock database that honours exact-match filters and
+// describes pages
+type slugDBInterface struct {
+	*inlineDBInterface
+}
+
+func (m *slugDBInterface) GetSchema(model interface{}) (*ModelSchema, error) {
+	return &ModelSchema{
+		Fields: []FieldSchema{
+			{Name: "id", Type: "integer", Required: true, Unique: true},
+			{Name: "title", Type: "string", Required: true},
+			{Name: "slug", Type: "string", Required: true, Unique: true},
+		},
+	}, nil
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Hello World":             "hello-world",
+		"  Hello,   World!  ":     "hello-world",
+		"Don't Stop -- Believin'": "dont-stop-believin",
+		"snake_case_title":        "snake-case-title",
+		"Go 1.24 Released":        "go-124-released",
+		"Ünïcödé":                 "ncd",
+		"!!!":                     "",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, slugify(input), input)
+	}
+}
+
+func TestCreateObjectPrepopulatesSlug(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	mockDB := &slugDBInterface{&inlineDBInterface{mockDBInterface: newMockDBInterface()}}
+	admin := NewModelAdmin(&Page{}).SetPrepopulatedFields(map[string][]string{"slug": {"title"}})
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&Page{}, admin))
+
+	create := func(form string) map[string]interface{} {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		req := httptest.NewRequest("POST", "/", strings.NewReader(form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		obj, err := admin.CreateObject(ctx, req)
+		require.NoError(t, err)
+		return obj.(map[string]interface{})
+	}
+
+	assert.Equal(t, "hello-world", create("title=Hello,+World!")["slug"])
+	assert.Equal(t, "hello-world-2", create("title=Hello+World")["slug"], "taken slugs get a suffix")
+	assert.Equal(t, "hello-world-3", create("title=hello+world&slug=")["slug"])
+	assert.Equal(t, "custom", create("title=Hello+World&slug=custom")["slug"], "submitted slugs are kept")
+
+	// The schema tells the form which field derives from which
+	for _, field := range admin.GetSchema().Fields {
+		if field.Name == "slug" {
+			assert.Equal(t, []string{"title"}, field.PrepopulatedFrom)
+		} else {
+			assert.Empty(t, field.PrepopulatedFrom)
+		}
+	}
+
+	parts := strings.SplitN(getModelName(&Page{}), ".", 2)
+	handler := NewAdminServiceHandler(site, nil)
+	resp, err := handler.GetModelSchema(context.Background(), connect.NewRequest(&adminpb.GetModelSchemaRequest{App: parts[0], Model: parts[1]}))
+	require.NoError(t, err)
+	var slugField *adminpb.FieldInfo
+	for _, field := range resp.Msg.Fields {
+		if field.Name == "slug" {
+			slugField = field
+		}
+	}
+	require.NotNil(t, slugField)
+	assert.Equal(t, []string{"title"}, slugField.PrepopulatedFrom)
+}
@@ -41,8 +41,10 @@ type ModelInfo struct {
 	ListPerPage         int32                  `protobuf:"varint,12,opt,name=list_per_page,json=listPerPage,proto3" json:"list_per_page,omitempty"`
 	Ordering            string                 `protobuf:"bytes,13,opt,name=ordering,proto3" json:"ordering,omitempty"`
 	ShowFullResultCount bool                   `protobuf:"varint,14,opt,name=show_full_result_count,json=showFullResultCount,proto3" json:"show_full_result_count,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// Fields edited in place in the list view
+	ListEditable  []string `protobuf:"bytes,15,rep,name=list_editable,json=listEditable,proto3" json:"list_editable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ModelInfo) Reset() {
@@ -173,6 +175,13 @@ func (x *ModelInfo) GetShowFullResultCount() bool {
 	return false
 }
 
+func (x *ModelInfo) GetListEditable() []string {
+	if x != nil {
+		return x.ListEditable
+	}
+	return nil
+}
+
 type ModelPermissions struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Add           bool                   `protobuf:"varint,1,opt,name=add,proto3" json:"add,omitempty"`
@@ -311,23 +320,25 @@ func (x *AdminAction) GetPermissions() []string {
 
 // Field metadata for forms and display
 type FieldInfo struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	FieldType     string                 `protobuf:"bytes,2,opt,name=field_type,json=fieldType,proto3" json:"field_type,omitempty"`
-	VerboseName   string                 `protobuf:"bytes,3,opt,name=verbose_name,json=verboseName,proto3" json:"verbose_name,omitempty"`
-	HelpText      string                 `protobuf:"bytes,4,opt,name=help_text,json=helpText,proto3" json:"help_text,omitempty"`
-	Required      bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
-	Editable      bool                   `protobuf:"varint,6,opt,name=editable,proto3" json:"editable,omitempty"`
-	Blank         bool                   `protobuf:"varint,7,opt,name=blank,proto3" json:"blank,omitempty"`
-	Null          bool                   `protobuf:"varint,8,opt,name=null,proto3" json:"null,omitempty"`
-	DefaultValue  *any1.Any              `protobuf:"bytes,9,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
-	Choices       []string               `protobuf:"bytes,10,rep,name=choices,proto3" json:"choices,omitempty"`
-	MaxLength     int32                  `protobuf:"varint,11,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
-	Unique        bool                   `protobuf:"varint,12,opt,name=unique,proto3" json:"unique,omitempty"`
-	RelatedModel  string                 `protobuf:"bytes,13,opt,name=related_model,json=relatedModel,proto3" json:"related_model,omitempty"`
-	WidgetType    string                 `protobuf:"bytes,14,opt,name=widget_type,json=widgetType,proto3" json:"widget_type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Name         string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	FieldType    string                 `protobuf:"bytes,2,opt,name=field_type,json=fieldType,proto3" json:"field_type,omitempty"`
+	VerboseName  string                 `protobuf:"bytes,3,opt,name=verbose_name,json=verboseName,proto3" json:"verbose_name,omitempty"`
+	HelpText     string                 `protobuf:"bytes,4,opt,name=help_text,json=helpText,proto3" json:"help_text,omitempty"`
+	Required     bool                   `protobuf:"varint,5,opt,name=required,proto3" json:"required,omitempty"`
+	Editable     bool                   `protobuf:"varint,6,opt,name=editable,proto3" json:"editable,omitempty"`
+	Blank        bool                   `protobuf:"varint,7,opt,name=blank,proto3" json:"blank,omitempty"`
+	Null         bool                   `protobuf:"varint,8,opt,name=null,proto3" json:"null,omitempty"`
+	DefaultValue *any1.Any              `protobuf:"bytes,9,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	Choices      []string               `protobuf:"bytes,10,rep,name=choices,proto3" json:"choices,omitempty"`
+	MaxLength    int32                  `protobuf:"varint,11,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	Unique       bool                   `protobuf:"varint,12,opt,name=unique,proto3" json:"unique,omitempty"`
+	RelatedModel string                 `protobuf:"bytes,13,opt,name=related_model,json=relatedModel,proto3" json:"related_model,omitempty"`
+	WidgetType   string                 `protobuf:"bytes,14,opt,name=widget_type,json=widgetType,proto3" json:"widget_type,omitempty"`
+	// Fields this field is filled in from as the user types, e.g. a slug's title
+	PrepopulatedFrom []string `protobuf:"bytes,15,rep,name=prepopulated_from,json=prepopulatedFrom,proto3" json:"prepopulated_from,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *FieldInfo) Reset() {
@@ -458,6 +469,13 @@ func (x *FieldInfo) GetWidgetType() string {
 	return ""
 }
 
+func (x *FieldInfo) GetPrepopulatedFrom() []string {
+	if x != nil {
+		return x.PrepopulatedFrom
+	}
+	return nil
+}
+
 // Requests and responses
 type ListModelsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -660,9 +678,11 @@ func (x *GetModelSchemaRequest) GetModel() string {
 }
 
 type GetModelSchemaResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ModelInfo     *ModelInfo             `protobuf:"bytes,1,opt,name=model_info,json=modelInfo,proto3" json:"model_info,omitempty"`
-	Fields        []*FieldInfo           `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ModelInfo *ModelInfo             `protobuf:"bytes,1,opt,name=model_info,json=modelInfo,proto3" json:"model_info,omitempty"`
+	Fields    []*FieldInfo           `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// Sections of the change form, empty for a flat list of fields
+	Fieldsets     []*Fieldset `protobuf:"bytes,3,rep,name=fieldsets,proto3" json:"fieldsets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -711,22 +731,108 @@ func (x *GetModelSchemaResponse) GetFields() []*FieldInfo {
 	return nil
 }
 
-type ListObjectsRequest struct {
+func (x *GetModelSchemaResponse) GetFieldsets() []*Fieldset {
+	if x != nil {
+		return x.Fieldsets
+	}
+	return nil
+}
+
+// Section of the change form
+type Fieldset struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	App           string                 `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
-	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Ordering      string                 `protobuf:"bytes,5,opt,name=ordering,proto3" json:"ordering,omitempty"`
-	Filters       map[string]string      `protobuf:"bytes,6,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Search        string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fields        []string               `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Collapsed     bool                   `protobuf:"varint,3,opt,name=collapsed,proto3" json:"collapsed,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Classes       []string               `protobuf:"bytes,5,rep,name=classes,proto3" json:"classes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fieldset) Reset() {
+	*x = Fieldset{}
+	mi := &file_proto_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fieldset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fieldset) ProtoMessage() {}
+
+func (x *Fieldset) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fieldset.ProtoReflect.Descriptor instead.
+func (*Fieldset) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Fieldset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Fieldset) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Fieldset) GetCollapsed() bool {
+	if x != nil {
+		return x.Collapsed
+	}
+	return false
+}
+
+func (x *Fieldset) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Fieldset) GetClasses() []string {
+	if x != nil {
+		return x.Classes
+	}
+	return nil
+}
+
+type ListObjectsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	App      string                 `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	Model    string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Page     int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Ordering string                 `protobuf:"bytes,5,opt,name=ordering,proto3" json:"ordering,omitempty"`
+	Filters  map[string]string      `protobuf:"bytes,6,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Search   string                 `protobuf:"bytes,7,opt,name=search,proto3" json:"search,omitempty"`
+	// Cursor from a previous response, for models using cursor pagination
+	Cursor        string `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListObjectsRequest) Reset() {
 	*x = ListObjectsRequest{}
-	mi := &file_proto_admin_proto_msgTypes[9]
+	mi := &file_proto_admin_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -738,7 +844,7 @@ func (x *ListObjectsRequest) String() string {
 func (*ListObjectsRequest) ProtoMessage() {}
 
 func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[9]
+	mi := &file_proto_admin_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,7 +857,7 @@ func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
 func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{9}
+	return file_proto_admin_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListObjectsRequest) GetApp() string {
@@ -803,6 +909,13 @@ func (x *ListObjectsRequest) GetSearch() string {
 	return ""
 }
 
+func (x *ListObjectsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type ListObjectsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Objects       []*ObjectData          `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
@@ -813,13 +926,16 @@ type ListObjectsResponse struct {
 	HasPrevious   bool                   `protobuf:"varint,6,opt,name=has_previous,json=hasPrevious,proto3" json:"has_previous,omitempty"`
 	TotalPages    int32                  `protobuf:"varint,7,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
 	DisplayFields []string               `protobuf:"bytes,8,rep,name=display_fields,json=displayFields,proto3" json:"display_fields,omitempty"`
+	// Cursors of the next and previous pages, for models using cursor pagination
+	NextCursor    string `protobuf:"bytes,9,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	PrevCursor    string `protobuf:"bytes,10,opt,name=prev_cursor,json=prevCursor,proto3" json:"prev_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListObjectsResponse) Reset() {
 	*x = ListObjectsResponse{}
-	mi := &file_proto_admin_proto_msgTypes[10]
+	mi := &file_proto_admin_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -831,7 +947,7 @@ func (x *ListObjectsResponse) String() string {
 func (*ListObjectsResponse) ProtoMessage() {}
 
 func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[10]
+	mi := &file_proto_admin_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,7 +960,7 @@ func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
 func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{10}
+	return file_proto_admin_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListObjectsResponse) GetObjects() []*ObjectData {
@@ -903,6 +1019,20 @@ func (x *ListObjectsResponse) GetDisplayFields() []string {
 	return nil
 }
 
+func (x *ListObjectsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListObjectsResponse) GetPrevCursor() string {
+	if x != nil {
+		return x.PrevCursor
+	}
+	return ""
+}
+
 type ObjectData struct {
 	state             protoimpl.MessageState    `protogen:"open.v1"`
 	Id                string                    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -916,7 +1046,7 @@ type ObjectData struct {
 
 func (x *ObjectData) Reset() {
 	*x = ObjectData{}
-	mi := &file_proto_admin_proto_msgTypes[11]
+	mi := &file_proto_admin_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -928,7 +1058,7 @@ func (x *ObjectData) String() string {
 func (*ObjectData) ProtoMessage() {}
 
 func (x *ObjectData) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[11]
+	mi := &file_proto_admin_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -941,7 +1071,7 @@ func (x *ObjectData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ObjectData.ProtoReflect.Descriptor instead.
 func (*ObjectData) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{11}
+	return file_proto_admin_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ObjectData) GetId() string {
@@ -990,7 +1120,7 @@ type GetObjectRequest struct {
 
 func (x *GetObjectRequest) Reset() {
 	*x = GetObjectRequest{}
-	mi := &file_proto_admin_proto_msgTypes[12]
+	mi := &file_proto_admin_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1002,7 +1132,7 @@ func (x *GetObjectRequest) String() string {
 func (*GetObjectRequest) ProtoMessage() {}
 
 func (x *GetObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[12]
+	mi := &file_proto_admin_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1015,7 +1145,7 @@ func (x *GetObjectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetObjectRequest.ProtoReflect.Descriptor instead.
 func (*GetObjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{12}
+	return file_proto_admin_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetObjectRequest) GetApp() string {
@@ -1049,7 +1179,7 @@ type GetObjectResponse struct {
 
 func (x *GetObjectResponse) Reset() {
 	*x = GetObjectResponse{}
-	mi := &file_proto_admin_proto_msgTypes[13]
+	mi := &file_proto_admin_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1061,7 +1191,7 @@ func (x *GetObjectResponse) String() string {
 func (*GetObjectResponse) ProtoMessage() {}
 
 func (x *GetObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[13]
+	mi := &file_proto_admin_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1074,7 +1204,7 @@ func (x *GetObjectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetObjectResponse.ProtoReflect.Descriptor instead.
 func (*GetObjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{13}
+	return file_proto_admin_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetObjectResponse) GetObject() *ObjectData {
@@ -1102,7 +1232,7 @@ type CreateObjectRequest struct {
 
 func (x *CreateObjectRequest) Reset() {
 	*x = CreateObjectRequest{}
-	mi := &file_proto_admin_proto_msgTypes[14]
+	mi := &file_proto_admin_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1114,7 +1244,7 @@ func (x *CreateObjectRequest) String() string {
 func (*CreateObjectRequest) ProtoMessage() {}
 
 func (x *CreateObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[14]
+	mi := &file_proto_admin_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1127,7 +1257,7 @@ func (x *CreateObjectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateObjectRequest.ProtoReflect.Descriptor instead.
 func (*CreateObjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{14}
+	return file_proto_admin_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CreateObjectRequest) GetApp() string {
@@ -1162,7 +1292,7 @@ type CreateObjectResponse struct {
 
 func (x *CreateObjectResponse) Reset() {
 	*x = CreateObjectResponse{}
-	mi := &file_proto_admin_proto_msgTypes[15]
+	mi := &file_proto_admin_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1174,7 +1304,7 @@ func (x *CreateObjectResponse) String() string {
 func (*CreateObjectResponse) ProtoMessage() {}
 
 func (x *CreateObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[15]
+	mi := &file_proto_admin_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1187,7 +1317,7 @@ func (x *CreateObjectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateObjectResponse.ProtoReflect.Descriptor instead.
 func (*CreateObjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{15}
+	return file_proto_admin_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CreateObjectResponse) GetObject() *ObjectData {
@@ -1223,7 +1353,7 @@ type UpdateObjectRequest struct {
 
 func (x *UpdateObjectRequest) Reset() {
 	*x = UpdateObjectRequest{}
-	mi := &file_proto_admin_proto_msgTypes[16]
+	mi := &file_proto_admin_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1235,7 +1365,7 @@ func (x *UpdateObjectRequest) String() string {
 func (*UpdateObjectRequest) ProtoMessage() {}
 
 func (x *UpdateObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[16]
+	mi := &file_proto_admin_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1248,7 +1378,7 @@ func (x *UpdateObjectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateObjectRequest.ProtoReflect.Descriptor instead.
 func (*UpdateObjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{16}
+	return file_proto_admin_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UpdateObjectRequest) GetApp() string {
@@ -1290,7 +1420,7 @@ type UpdateObjectResponse struct {
 
 func (x *UpdateObjectResponse) Reset() {
 	*x = UpdateObjectResponse{}
-	mi := &file_proto_admin_proto_msgTypes[17]
+	mi := &file_proto_admin_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1302,7 +1432,7 @@ func (x *UpdateObjectResponse) String() string {
 func (*UpdateObjectResponse) ProtoMessage() {}
 
 func (x *UpdateObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[17]
+	mi := &file_proto_admin_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1315,7 +1445,7 @@ func (x *UpdateObjectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateObjectResponse.ProtoReflect.Descriptor instead.
 func (*UpdateObjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{17}
+	return file_proto_admin_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UpdateObjectResponse) GetObject() *ObjectData {
@@ -1350,7 +1480,7 @@ type DeleteObjectRequest struct {
 
 func (x *DeleteObjectRequest) Reset() {
 	*x = DeleteObjectRequest{}
-	mi := &file_proto_admin_proto_msgTypes[18]
+	mi := &file_proto_admin_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1362,7 +1492,7 @@ func (x *DeleteObjectRequest) String() string {
 func (*DeleteObjectRequest) ProtoMessage() {}
 
 func (x *DeleteObjectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[18]
+	mi := &file_proto_admin_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1375,7 +1505,7 @@ func (x *DeleteObjectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteObjectRequest.ProtoReflect.Descriptor instead.
 func (*DeleteObjectRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{18}
+	return file_proto_admin_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DeleteObjectRequest) GetApp() string {
@@ -1409,7 +1539,7 @@ type DeleteObjectResponse struct {
 
 func (x *DeleteObjectResponse) Reset() {
 	*x = DeleteObjectResponse{}
-	mi := &file_proto_admin_proto_msgTypes[19]
+	mi := &file_proto_admin_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1421,7 +1551,7 @@ func (x *DeleteObjectResponse) String() string {
 func (*DeleteObjectResponse) ProtoMessage() {}
 
 func (x *DeleteObjectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[19]
+	mi := &file_proto_admin_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1434,7 +1564,7 @@ func (x *DeleteObjectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteObjectResponse.ProtoReflect.Descriptor instead.
 func (*DeleteObjectResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{19}
+	return file_proto_admin_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *DeleteObjectResponse) GetSuccess() bool {
@@ -1462,7 +1592,7 @@ type DeleteObjectsRequest struct {
 
 func (x *DeleteObjectsRequest) Reset() {
 	*x = DeleteObjectsRequest{}
-	mi := &file_proto_admin_proto_msgTypes[20]
+	mi := &file_proto_admin_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1474,7 +1604,7 @@ func (x *DeleteObjectsRequest) String() string {
 func (*DeleteObjectsRequest) ProtoMessage() {}
 
 func (x *DeleteObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[20]
+	mi := &file_proto_admin_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,7 +1617,7 @@ func (x *DeleteObjectsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteObjectsRequest.ProtoReflect.Descriptor instead.
 func (*DeleteObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{20}
+	return file_proto_admin_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DeleteObjectsRequest) GetApp() string {
@@ -1523,7 +1653,7 @@ type DeleteObjectsResponse struct {
 
 func (x *DeleteObjectsResponse) Reset() {
 	*x = DeleteObjectsResponse{}
-	mi := &file_proto_admin_proto_msgTypes[21]
+	mi := &file_proto_admin_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1535,7 +1665,7 @@ func (x *DeleteObjectsResponse) String() string {
 func (*DeleteObjectsResponse) ProtoMessage() {}
 
 func (x *DeleteObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[21]
+	mi := &file_proto_admin_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1548,7 +1678,7 @@ func (x *DeleteObjectsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteObjectsResponse.ProtoReflect.Descriptor instead.
 func (*DeleteObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{21}
+	return file_proto_admin_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *DeleteObjectsResponse) GetDeletedCount() int32 {
@@ -1592,7 +1722,7 @@ type ExecuteActionRequest struct {
 
 func (x *ExecuteActionRequest) Reset() {
 	*x = ExecuteActionRequest{}
-	mi := &file_proto_admin_proto_msgTypes[22]
+	mi := &file_proto_admin_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1604,7 +1734,7 @@ func (x *ExecuteActionRequest) String() string {
 func (*ExecuteActionRequest) ProtoMessage() {}
 
 func (x *ExecuteActionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[22]
+	mi := &file_proto_admin_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1617,7 +1747,7 @@ func (x *ExecuteActionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteActionRequest.ProtoReflect.Descriptor instead.
 func (*ExecuteActionRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{22}
+	return file_proto_admin_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ExecuteActionRequest) GetApp() string {
@@ -1667,7 +1797,7 @@ type ExecuteActionResponse struct {
 
 func (x *ExecuteActionResponse) Reset() {
 	*x = ExecuteActionResponse{}
-	mi := &file_proto_admin_proto_msgTypes[23]
+	mi := &file_proto_admin_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1679,7 +1809,7 @@ func (x *ExecuteActionResponse) String() string {
 func (*ExecuteActionResponse) ProtoMessage() {}
 
 func (x *ExecuteActionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[23]
+	mi := &file_proto_admin_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1692,7 +1822,7 @@ func (x *ExecuteActionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExecuteActionResponse.ProtoReflect.Descriptor instead.
 func (*ExecuteActionResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{23}
+	return file_proto_admin_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ExecuteActionResponse) GetSuccess() bool {
@@ -1733,7 +1863,7 @@ type ListActionsRequest struct {
 
 func (x *ListActionsRequest) Reset() {
 	*x = ListActionsRequest{}
-	mi := &file_proto_admin_proto_msgTypes[24]
+	mi := &file_proto_admin_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1745,7 +1875,7 @@ func (x *ListActionsRequest) String() string {
 func (*ListActionsRequest) ProtoMessage() {}
 
 func (x *ListActionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[24]
+	mi := &file_proto_admin_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1758,7 +1888,7 @@ func (x *ListActionsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListActionsRequest.ProtoReflect.Descriptor instead.
 func (*ListActionsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{24}
+	return file_proto_admin_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ListActionsRequest) GetApp() string {
@@ -1784,7 +1914,7 @@ type ListActionsResponse struct {
 
 func (x *ListActionsResponse) Reset() {
 	*x = ListActionsResponse{}
-	mi := &file_proto_admin_proto_msgTypes[25]
+	mi := &file_proto_admin_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1796,7 +1926,7 @@ func (x *ListActionsResponse) String() string {
 func (*ListActionsResponse) ProtoMessage() {}
 
 func (x *ListActionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[25]
+	mi := &file_proto_admin_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1809,7 +1939,7 @@ func (x *ListActionsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListActionsResponse.ProtoReflect.Descriptor instead.
 func (*ListActionsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{25}
+	return file_proto_admin_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *ListActionsResponse) GetActions() []*AdminAction {
@@ -1831,7 +1961,7 @@ type SearchObjectsRequest struct {
 
 func (x *SearchObjectsRequest) Reset() {
 	*x = SearchObjectsRequest{}
-	mi := &file_proto_admin_proto_msgTypes[26]
+	mi := &file_proto_admin_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1843,7 +1973,7 @@ func (x *SearchObjectsRequest) String() string {
 func (*SearchObjectsRequest) ProtoMessage() {}
 
 func (x *SearchObjectsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[26]
+	mi := &file_proto_admin_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1856,7 +1986,7 @@ func (x *SearchObjectsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchObjectsRequest.ProtoReflect.Descriptor instead.
 func (*SearchObjectsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{26}
+	return file_proto_admin_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *SearchObjectsRequest) GetApp() string {
@@ -1897,7 +2027,7 @@ type SearchObjectsResponse struct {
 
 func (x *SearchObjectsResponse) Reset() {
 	*x = SearchObjectsResponse{}
-	mi := &file_proto_admin_proto_msgTypes[27]
+	mi := &file_proto_admin_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1909,7 +2039,7 @@ func (x *SearchObjectsResponse) String() string {
 func (*SearchObjectsResponse) ProtoMessage() {}
 
 func (x *SearchObjectsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[27]
+	mi := &file_proto_admin_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1922,7 +2052,7 @@ func (x *SearchObjectsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchObjectsResponse.ProtoReflect.Descriptor instead.
 func (*SearchObjectsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{27}
+	return file_proto_admin_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *SearchObjectsResponse) GetObjects() []*ObjectData {
@@ -1950,7 +2080,7 @@ type ValidationError struct {
 
 func (x *ValidationError) Reset() {
 	*x = ValidationError{}
-	mi := &file_proto_admin_proto_msgTypes[28]
+	mi := &file_proto_admin_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1962,7 +2092,7 @@ func (x *ValidationError) String() string {
 func (*ValidationError) ProtoMessage() {}
 
 func (x *ValidationError) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[28]
+	mi := &file_proto_admin_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1975,7 +2105,7 @@ func (x *ValidationError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
 func (*ValidationError) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{28}
+	return file_proto_admin_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ValidationError) GetField() string {
@@ -2011,7 +2141,7 @@ type FilterOption struct {
 
 func (x *FilterOption) Reset() {
 	*x = FilterOption{}
-	mi := &file_proto_admin_proto_msgTypes[29]
+	mi := &file_proto_admin_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2023,7 +2153,7 @@ func (x *FilterOption) String() string {
 func (*FilterOption) ProtoMessage() {}
 
 func (x *FilterOption) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[29]
+	mi := &file_proto_admin_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2036,7 +2166,7 @@ func (x *FilterOption) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FilterOption.ProtoReflect.Descriptor instead.
 func (*FilterOption) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{29}
+	return file_proto_admin_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *FilterOption) GetName() string {
@@ -2072,7 +2202,7 @@ type FilterSpec struct {
 
 func (x *FilterSpec) Reset() {
 	*x = FilterSpec{}
-	mi := &file_proto_admin_proto_msgTypes[30]
+	mi := &file_proto_admin_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2084,7 +2214,7 @@ func (x *FilterSpec) String() string {
 func (*FilterSpec) ProtoMessage() {}
 
 func (x *FilterSpec) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_admin_proto_msgTypes[30]
+	mi := &file_proto_admin_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2097,7 +2227,7 @@ func (x *FilterSpec) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FilterSpec.ProtoReflect.Descriptor instead.
 func (*FilterSpec) Descriptor() ([]byte, []int) {
-	return file_proto_admin_proto_rawDescGZIP(), []int{30}
+	return file_proto_admin_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *FilterSpec) GetField() string {
@@ -2128,38 +2258,712 @@ func (x *FilterSpec) GetOptions() []*FilterOption {
 	return nil
 }
 
-var File_proto_admin_proto protoreflect.FileDescriptor
+// Dashboard of the admin index
+type GetDashboardRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of recently created objects returned per model, 5 when unset
+	RecentLimit   int32 `protobuf:"varint,1,opt,name=recent_limit,json=recentLimit,proto3" json:"recent_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_admin_proto_rawDesc = "" +
-	"\n" +
-	"\x11proto/admin.proto\x12\rgojango.admin\x1a\x19google/protobuf/any.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x04\n" +
-	"\tModelInfo\x12\x10\n" +
-	"\x03app\x18\x01 \x01(\tR\x03app\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
-	"\fverbose_name\x18\x03 \x01(\tR\vverboseName\x12.\n" +
-	"\x13verbose_name_plural\x18\x04 \x01(\tR\x11verboseNamePlural\x12!\n" +
-	"\flist_display\x18\x05 \x03(\tR\vlistDisplay\x12#\n" +
-	"\rsearch_fields\x18\x06 \x03(\tR\fsearchFields\x12\x1f\n" +
-	"\vlist_filter\x18\a \x03(\tR\n" +
-	"listFilter\x12'\n" +
-	"\x0freadonly_fields\x18\b \x03(\tR\x0ereadonlyFields\x12\x18\n" +
-	"\aexclude\x18\t \x03(\tR\aexclude\x12A\n" +
-	"\vpermissions\x18\n" +
-	" \x01(\v2\x1f.gojango.admin.ModelPermissionsR\vpermissions\x124\n" +
-	"\aactions\x18\v \x03(\v2\x1a.gojango.admin.AdminActionR\aactions\x12\"\n" +
-	"\rlist_per_page\x18\f \x01(\x05R\vlistPerPage\x12\x1a\n" +
-	"\bordering\x18\r \x01(\tR\bordering\x123\n" +
-	"\x16show_full_result_count\x18\x0e \x01(\bR\x13showFullResultCount\"h\n" +
-	"\x10ModelPermissions\x12\x10\n" +
-	"\x03add\x18\x01 \x01(\bR\x03add\x12\x16\n" +
-	"\x06change\x18\x02 \x01(\bR\x06change\x12\x16\n" +
-	"\x06delete\x18\x03 \x01(\bR\x06delete\x12\x12\n" +
-	"\x04view\x18\x04 \x01(\bR\x04view\"\x9a\x01\n" +
-	"\vAdminAction\x12\x12\n" +
+func (x *GetDashboardRequest) Reset() {
+	*x = GetDashboardRequest{}
+	mi := &file_proto_admin_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDashboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDashboardRequest) ProtoMessage() {}
+
+func (x *GetDashboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDashboardRequest.ProtoReflect.Descriptor instead.
+func (*GetDashboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetDashboardRequest) GetRecentLimit() int32 {
+	if x != nil {
+		return x.RecentLimit
+	}
+	return 0
+}
+
+type GetDashboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*DashboardModel      `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	RecentActions []*AdminLogEntry       `protobuf:"bytes,2,rep,name=recent_actions,json=recentActions,proto3" json:"recent_actions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDashboardResponse) Reset() {
+	*x = GetDashboardResponse{}
+	mi := &file_proto_admin_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDashboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDashboardResponse) ProtoMessage() {}
+
+func (x *GetDashboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDashboardResponse.ProtoReflect.Descriptor instead.
+func (*GetDashboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetDashboardResponse) GetModels() []*DashboardModel {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+func (x *GetDashboardResponse) GetRecentActions() []*AdminLogEntry {
+	if x != nil {
+		return x.RecentActions
+	}
+	return nil
+}
+
+type DashboardModel struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	App               string                 `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	Model             string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	VerboseNamePlural string                 `protobuf:"bytes,3,opt,name=verbose_name_plural,json=verboseNamePlural,proto3" json:"verbose_name_plural,omitempty"`
+	Count             int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	RecentObjects     []*ObjectData          `protobuf:"bytes,5,rep,name=recent_objects,json=recentObjects,proto3" json:"recent_objects,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DashboardModel) Reset() {
+	*x = DashboardModel{}
+	mi := &file_proto_admin_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DashboardModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DashboardModel) ProtoMessage() {}
+
+func (x *DashboardModel) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DashboardModel.ProtoReflect.Descriptor instead.
+func (*DashboardModel) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DashboardModel) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *DashboardModel) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *DashboardModel) GetVerboseNamePlural() string {
+	if x != nil {
+		return x.VerboseNamePlural
+	}
+	return ""
+}
+
+func (x *DashboardModel) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DashboardModel) GetRecentObjects() []*ObjectData {
+	if x != nil {
+		return x.RecentObjects
+	}
+	return nil
+}
+
+type AdminLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	ObjectId      string                 `protobuf:"bytes,3,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
+	User          string                 `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	ActionTime    *timestamp.Timestamp   `protobuf:"bytes,5,opt,name=action_time,json=actionTime,proto3" json:"action_time,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminLogEntry) Reset() {
+	*x = AdminLogEntry{}
+	mi := &file_proto_admin_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminLogEntry) ProtoMessage() {}
+
+func (x *AdminLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminLogEntry.ProtoReflect.Descriptor instead.
+func (*AdminLogEntry) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *AdminLogEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AdminLogEntry) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *AdminLogEntry) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *AdminLogEntry) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *AdminLogEntry) GetActionTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.ActionTime
+	}
+	return nil
+}
+
+func (x *AdminLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Date hierarchy drill-down
+type GetDateHierarchyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	App   string                 `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	Model string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// Selected year, 0 to list years
+	Year int32 `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	// Selected month of the year, 0 to list months
+	Month         int32             `protobuf:"varint,4,opt,name=month,proto3" json:"month,omitempty"`
+	Filters       map[string]string `protobuf:"bytes,5,rep,name=filters,proto3" json:"filters,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Search        string            `protobuf:"bytes,6,opt,name=search,proto3" json:"search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDateHierarchyRequest) Reset() {
+	*x = GetDateHierarchyRequest{}
+	mi := &file_proto_admin_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDateHierarchyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDateHierarchyRequest) ProtoMessage() {}
+
+func (x *GetDateHierarchyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDateHierarchyRequest.ProtoReflect.Descriptor instead.
+func (*GetDateHierarchyRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetDateHierarchyRequest) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *GetDateHierarchyRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *GetDateHierarchyRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *GetDateHierarchyRequest) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *GetDateHierarchyRequest) GetFilters() map[string]string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *GetDateHierarchyRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+type GetDateHierarchyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Field string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Level of the buckets: "year", "month" or "day"
+	Level         string        `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Year          int32         `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32         `protobuf:"varint,4,opt,name=month,proto3" json:"month,omitempty"`
+	Buckets       []*DateBucket `protobuf:"bytes,5,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDateHierarchyResponse) Reset() {
+	*x = GetDateHierarchyResponse{}
+	mi := &file_proto_admin_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDateHierarchyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDateHierarchyResponse) ProtoMessage() {}
+
+func (x *GetDateHierarchyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDateHierarchyResponse.ProtoReflect.Descriptor instead.
+func (*GetDateHierarchyResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetDateHierarchyResponse) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GetDateHierarchyResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *GetDateHierarchyResponse) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *GetDateHierarchyResponse) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *GetDateHierarchyResponse) GetBuckets() []*DateBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type DateBucket struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value int32                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"`
+	Label string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Count int32                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	// List parameters selecting the bucket
+	Params        map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DateBucket) Reset() {
+	*x = DateBucket{}
+	mi := &file_proto_admin_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DateBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DateBucket) ProtoMessage() {}
+
+func (x *DateBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DateBucket.ProtoReflect.Descriptor instead.
+func (*DateBucket) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DateBucket) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *DateBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DateBucket) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *DateBucket) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// Edits made in the list view
+type SaveListEditsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	App   string                 `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	Model string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// Changed fields of each edited object, keyed by object ID
+	Rows          map[string]*_struct.Struct `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveListEditsRequest) Reset() {
+	*x = SaveListEditsRequest{}
+	mi := &file_proto_admin_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveListEditsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveListEditsRequest) ProtoMessage() {}
+
+func (x *SaveListEditsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveListEditsRequest.ProtoReflect.Descriptor instead.
+func (*SaveListEditsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SaveListEditsRequest) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *SaveListEditsRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *SaveListEditsRequest) GetRows() map[string]*_struct.Struct {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type SaveListEditsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*ListEditResult      `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveListEditsResponse) Reset() {
+	*x = SaveListEditsResponse{}
+	mi := &file_proto_admin_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveListEditsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveListEditsResponse) ProtoMessage() {}
+
+func (x *SaveListEditsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveListEditsResponse.ProtoReflect.Descriptor instead.
+func (*SaveListEditsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SaveListEditsResponse) GetResults() []*ListEditResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ListEditResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Errors        []*ValidationError     `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	Object        *ObjectData            `protobuf:"bytes,5,opt,name=object,proto3" json:"object,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEditResult) Reset() {
+	*x = ListEditResult{}
+	mi := &file_proto_admin_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEditResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEditResult) ProtoMessage() {}
+
+func (x *ListEditResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_admin_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEditResult.ProtoReflect.Descriptor instead.
+func (*ListEditResult) Descriptor() ([]byte, []int) {
+	return file_proto_admin_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListEditResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListEditResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListEditResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListEditResult) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ListEditResult) GetObject() *ObjectData {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
+var File_proto_admin_proto protoreflect.FileDescriptor
+
+const file_proto_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x11proto/admin.proto\x12\rgojango.admin\x1a\x19google/protobuf/any.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc3\x04\n" +
+	"\tModelInfo\x12\x10\n" +
+	"\x03app\x18\x01 \x01(\tR\x03app\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fverbose_name\x18\x03 \x01(\tR\vverboseName\x12.\n" +
+	"\x13verbose_name_plural\x18\x04 \x01(\tR\x11verboseNamePlural\x12!\n" +
+	"\flist_display\x18\x05 \x03(\tR\vlistDisplay\x12#\n" +
+	"\rsearch_fields\x18\x06 \x03(\tR\fsearchFields\x12\x1f\n" +
+	"\vlist_filter\x18\a \x03(\tR\n" +
+	"listFilter\x12'\n" +
+	"\x0freadonly_fields\x18\b \x03(\tR\x0ereadonlyFields\x12\x18\n" +
+	"\aexclude\x18\t \x03(\tR\aexclude\x12A\n" +
+	"\vpermissions\x18\n" +
+	" \x01(\v2\x1f.gojango.admin.ModelPermissionsR\vpermissions\x124\n" +
+	"\aactions\x18\v \x03(\v2\x1a.gojango.admin.AdminActionR\aactions\x12\"\n" +
+	"\rlist_per_page\x18\f \x01(\x05R\vlistPerPage\x12\x1a\n" +
+	"\bordering\x18\r \x01(\tR\bordering\x123\n" +
+	"\x16show_full_result_count\x18\x0e \x01(\bR\x13showFullResultCount\x12#\n" +
+	"\rlist_editable\x18\x0f \x03(\tR\flistEditable\"h\n" +
+	"\x10ModelPermissions\x12\x10\n" +
+	"\x03add\x18\x01 \x01(\bR\x03add\x12\x16\n" +
+	"\x06change\x18\x02 \x01(\bR\x06change\x12\x16\n" +
+	"\x06delete\x18\x03 \x01(\bR\x06delete\x12\x12\n" +
+	"\x04view\x18\x04 \x01(\bR\x04view\"\x9a\x01\n" +
+	"\vAdminAction\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x123\n" +
 	"\x15confirmation_required\x18\x03 \x01(\bR\x14confirmationRequired\x12 \n" +
-	"\vpermissions\x18\x04 \x03(\tR\vpermissions\"\xb2\x03\n" +
+	"\vpermissions\x18\x04 \x03(\tR\vpermissions\"\xdf\x03\n" +
 	"\tFieldInfo\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
@@ -2178,7 +2982,8 @@ const file_proto_admin_proto_rawDesc = "" +
 	"\x06unique\x18\f \x01(\bR\x06unique\x12#\n" +
 	"\rrelated_model\x18\r \x01(\tR\frelatedModel\x12\x1f\n" +
 	"\vwidget_type\x18\x0e \x01(\tR\n" +
-	"widgetType\"\x13\n" +
+	"widgetType\x12+\n" +
+	"\x11prepopulated_from\x18\x0f \x03(\tR\x10prepopulatedFrom\"\x13\n" +
 	"\x11ListModelsRequest\"\xdd\x01\n" +
 	"\x12ListModelsResponse\x12E\n" +
 	"\x06models\x18\x01 \x03(\v2-.gojango.admin.ListModelsResponse.ModelsEntryR\x06models\x12+\n" +
@@ -2193,11 +2998,18 @@ const file_proto_admin_proto_rawDesc = "" +
 	"indexTitle\"?\n" +
 	"\x15GetModelSchemaRequest\x12\x10\n" +
 	"\x03app\x18\x01 \x01(\tR\x03app\x12\x14\n" +
-	"\x05model\x18\x02 \x01(\tR\x05model\"\x83\x01\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\"\xba\x01\n" +
 	"\x16GetModelSchemaResponse\x127\n" +
 	"\n" +
 	"model_info\x18\x01 \x01(\v2\x18.gojango.admin.ModelInfoR\tmodelInfo\x120\n" +
-	"\x06fields\x18\x02 \x03(\v2\x18.gojango.admin.FieldInfoR\x06fields\"\xa7\x02\n" +
+	"\x06fields\x18\x02 \x03(\v2\x18.gojango.admin.FieldInfoR\x06fields\x125\n" +
+	"\tfieldsets\x18\x03 \x03(\v2\x17.gojango.admin.FieldsetR\tfieldsets\"\x90\x01\n" +
+	"\bFieldset\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06fields\x18\x02 \x03(\tR\x06fields\x12\x1c\n" +
+	"\tcollapsed\x18\x03 \x01(\bR\tcollapsed\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
+	"\aclasses\x18\x05 \x03(\tR\aclasses\"\xbf\x02\n" +
 	"\x12ListObjectsRequest\x12\x10\n" +
 	"\x03app\x18\x01 \x01(\tR\x03app\x12\x14\n" +
 	"\x05model\x18\x02 \x01(\tR\x05model\x12\x12\n" +
@@ -2205,10 +3017,11 @@ const file_proto_admin_proto_rawDesc = "" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1a\n" +
 	"\bordering\x18\x05 \x01(\tR\bordering\x12H\n" +
 	"\afilters\x18\x06 \x03(\v2..gojango.admin.ListObjectsRequest.FiltersEntryR\afilters\x12\x16\n" +
-	"\x06search\x18\a \x01(\tR\x06search\x1a:\n" +
+	"\x06search\x18\a \x01(\tR\x06search\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\x1a:\n" +
 	"\fFiltersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x02\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe4\x02\n" +
 	"\x13ListObjectsResponse\x123\n" +
 	"\aobjects\x18\x01 \x03(\v2\x19.gojango.admin.ObjectDataR\aobjects\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
@@ -2219,7 +3032,12 @@ const file_proto_admin_proto_rawDesc = "" +
 	"\fhas_previous\x18\x06 \x01(\bR\vhasPrevious\x12\x1f\n" +
 	"\vtotal_pages\x18\a \x01(\x05R\n" +
 	"totalPages\x12%\n" +
-	"\x0edisplay_fields\x18\b \x03(\tR\rdisplayFields\"\xd3\x02\n" +
+	"\x0edisplay_fields\x18\b \x03(\tR\rdisplayFields\x12\x1f\n" +
+	"\vnext_cursor\x18\t \x01(\tR\n" +
+	"nextCursor\x12\x1f\n" +
+	"\vprev_cursor\x18\n" +
+	" \x01(\tR\n" +
+	"prevCursor\"\xd3\x02\n" +
 	"\n" +
 	"ObjectData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12=\n" +
@@ -2325,7 +3143,66 @@ const file_proto_admin_proto_rawDesc = "" +
 	"\vlookup_type\x18\x02 \x01(\tR\n" +
 	"lookupType\x12\x14\n" +
 	"\x05title\x18\x03 \x01(\tR\x05title\x125\n" +
-	"\aoptions\x18\x04 \x03(\v2\x1b.gojango.admin.FilterOptionR\aoptions2\xdb\a\n" +
+	"\aoptions\x18\x04 \x03(\v2\x1b.gojango.admin.FilterOptionR\aoptions\"8\n" +
+	"\x13GetDashboardRequest\x12!\n" +
+	"\frecent_limit\x18\x01 \x01(\x05R\vrecentLimit\"\x92\x01\n" +
+	"\x14GetDashboardResponse\x125\n" +
+	"\x06models\x18\x01 \x03(\v2\x1d.gojango.admin.DashboardModelR\x06models\x12C\n" +
+	"\x0erecent_actions\x18\x02 \x03(\v2\x1c.gojango.admin.AdminLogEntryR\rrecentActions\"\xc0\x01\n" +
+	"\x0eDashboardModel\x12\x10\n" +
+	"\x03app\x18\x01 \x01(\tR\x03app\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12.\n" +
+	"\x13verbose_name_plural\x18\x03 \x01(\tR\x11verboseNamePlural\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\x12@\n" +
+	"\x0erecent_objects\x18\x05 \x03(\v2\x19.gojango.admin.ObjectDataR\rrecentObjects\"\xc5\x01\n" +
+	"\rAdminLogEntry\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x1b\n" +
+	"\tobject_id\x18\x03 \x01(\tR\bobjectId\x12\x12\n" +
+	"\x04user\x18\x04 \x01(\tR\x04user\x12;\n" +
+	"\vaction_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"actionTime\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"\x8e\x02\n" +
+	"\x17GetDateHierarchyRequest\x12\x10\n" +
+	"\x03app\x18\x01 \x01(\tR\x03app\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x04 \x01(\x05R\x05month\x12M\n" +
+	"\afilters\x18\x05 \x03(\v23.gojango.admin.GetDateHierarchyRequest.FiltersEntryR\afilters\x12\x16\n" +
+	"\x06search\x18\x06 \x01(\tR\x06search\x1a:\n" +
+	"\fFiltersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x01\n" +
+	"\x18GetDateHierarchyResponse\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x04 \x01(\x05R\x05month\x123\n" +
+	"\abuckets\x18\x05 \x03(\v2\x19.gojango.admin.DateBucketR\abuckets\"\xc8\x01\n" +
+	"\n" +
+	"DateBucket\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x05R\x05value\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\x12=\n" +
+	"\x06params\x18\x04 \x03(\v2%.gojango.admin.DateBucket.ParamsEntryR\x06params\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x01\n" +
+	"\x14SaveListEditsRequest\x12\x10\n" +
+	"\x03app\x18\x01 \x01(\tR\x03app\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12A\n" +
+	"\x04rows\x18\x03 \x03(\v2-.gojango.admin.SaveListEditsRequest.RowsEntryR\x04rows\x1aP\n" +
+	"\tRowsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x05value:\x028\x01\"P\n" +
+	"\x15SaveListEditsResponse\x127\n" +
+	"\aresults\x18\x01 \x03(\v2\x1d.gojango.admin.ListEditResultR\aresults\"\xbb\x01\n" +
+	"\x0eListEditResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x126\n" +
+	"\x06errors\x18\x04 \x03(\v2\x1e.gojango.admin.ValidationErrorR\x06errors\x121\n" +
+	"\x06object\x18\x05 \x01(\v2\x19.gojango.admin.ObjectDataR\x06object2\xf5\t\n" +
 	"\fAdminService\x12Q\n" +
 	"\n" +
 	"ListModels\x12 .gojango.admin.ListModelsRequest\x1a!.gojango.admin.ListModelsResponse\x12]\n" +
@@ -2336,9 +3213,12 @@ const file_proto_admin_proto_rawDesc = "" +
 	"\fUpdateObject\x12\".gojango.admin.UpdateObjectRequest\x1a#.gojango.admin.UpdateObjectResponse\x12W\n" +
 	"\fDeleteObject\x12\".gojango.admin.DeleteObjectRequest\x1a#.gojango.admin.DeleteObjectResponse\x12Z\n" +
 	"\rDeleteObjects\x12#.gojango.admin.DeleteObjectsRequest\x1a$.gojango.admin.DeleteObjectsResponse\x12Z\n" +
+	"\rSaveListEdits\x12#.gojango.admin.SaveListEditsRequest\x1a$.gojango.admin.SaveListEditsResponse\x12Z\n" +
 	"\rExecuteAction\x12#.gojango.admin.ExecuteActionRequest\x1a$.gojango.admin.ExecuteActionResponse\x12T\n" +
 	"\vListActions\x12!.gojango.admin.ListActionsRequest\x1a\".gojango.admin.ListActionsResponse\x12Z\n" +
-	"\rSearchObjects\x12#.gojango.admin.SearchObjectsRequest\x1a$.gojango.admin.SearchObjectsResponseB5Z3github.com/epuerta9/gojango/pkg/gojango/admin/protob\x06proto3"
+	"\rSearchObjects\x12#.gojango.admin.SearchObjectsRequest\x1a$.gojango.admin.SearchObjectsResponse\x12c\n" +
+	"\x10GetDateHierarchy\x12&.gojango.admin.GetDateHierarchyRequest\x1a'.gojango.admin.GetDateHierarchyResponse\x12W\n" +
+	"\fGetDashboard\x12\".gojango.admin.GetDashboardRequest\x1a#.gojango.admin.GetDashboardResponseB5Z3github.com/epuerta9/gojango/pkg/gojango/admin/protob\x06proto3"
 
 var (
 	file_proto_admin_proto_rawDescOnce sync.Once
@@ -2352,107 +3232,141 @@ func file_proto_admin_proto_rawDescGZIP() []byte {
 	return file_proto_admin_proto_rawDescData
 }
 
-var file_proto_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
+var file_proto_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
 var file_proto_admin_proto_goTypes = []any{
-	(*ModelInfo)(nil),              // 0: gojango.admin.ModelInfo
-	(*ModelPermissions)(nil),       // 1: gojango.admin.ModelPermissions
-	(*AdminAction)(nil),            // 2: gojango.admin.AdminAction
-	(*FieldInfo)(nil),              // 3: gojango.admin.FieldInfo
-	(*ListModelsRequest)(nil),      // 4: gojango.admin.ListModelsRequest
-	(*ListModelsResponse)(nil),     // 5: gojango.admin.ListModelsResponse
-	(*SiteInfo)(nil),               // 6: gojango.admin.SiteInfo
-	(*GetModelSchemaRequest)(nil),  // 7: gojango.admin.GetModelSchemaRequest
-	(*GetModelSchemaResponse)(nil), // 8: gojango.admin.GetModelSchemaResponse
-	(*ListObjectsRequest)(nil),     // 9: gojango.admin.ListObjectsRequest
-	(*ListObjectsResponse)(nil),    // 10: gojango.admin.ListObjectsResponse
-	(*ObjectData)(nil),             // 11: gojango.admin.ObjectData
-	(*GetObjectRequest)(nil),       // 12: gojango.admin.GetObjectRequest
-	(*GetObjectResponse)(nil),      // 13: gojango.admin.GetObjectResponse
-	(*CreateObjectRequest)(nil),    // 14: gojango.admin.CreateObjectRequest
-	(*CreateObjectResponse)(nil),   // 15: gojango.admin.CreateObjectResponse
-	(*UpdateObjectRequest)(nil),    // 16: gojango.admin.UpdateObjectRequest
-	(*UpdateObjectResponse)(nil),   // 17: gojango.admin.UpdateObjectResponse
-	(*DeleteObjectRequest)(nil),    // 18: gojango.admin.DeleteObjectRequest
-	(*DeleteObjectResponse)(nil),   // 19: gojango.admin.DeleteObjectResponse
-	(*DeleteObjectsRequest)(nil),   // 20: gojango.admin.DeleteObjectsRequest
-	(*DeleteObjectsResponse)(nil),  // 21: gojango.admin.DeleteObjectsResponse
-	(*ExecuteActionRequest)(nil),   // 22: gojango.admin.ExecuteActionRequest
-	(*ExecuteActionResponse)(nil),  // 23: gojango.admin.ExecuteActionResponse
-	(*ListActionsRequest)(nil),     // 24: gojango.admin.ListActionsRequest
-	(*ListActionsResponse)(nil),    // 25: gojango.admin.ListActionsResponse
-	(*SearchObjectsRequest)(nil),   // 26: gojango.admin.SearchObjectsRequest
-	(*SearchObjectsResponse)(nil),  // 27: gojango.admin.SearchObjectsResponse
-	(*ValidationError)(nil),        // 28: gojango.admin.ValidationError
-	(*FilterOption)(nil),           // 29: gojango.admin.FilterOption
-	(*FilterSpec)(nil),             // 30: gojango.admin.FilterSpec
-	nil,                            // 31: gojango.admin.ListModelsResponse.ModelsEntry
-	nil,                            // 32: gojango.admin.ListObjectsRequest.FiltersEntry
-	nil,                            // 33: gojango.admin.ObjectData.FieldsEntry
-	nil,                            // 34: gojango.admin.CreateObjectRequest.DataEntry
-	nil,                            // 35: gojango.admin.UpdateObjectRequest.DataEntry
-	nil,                            // 36: gojango.admin.ExecuteActionRequest.ParametersEntry
-	(*any1.Any)(nil),               // 37: google.protobuf.Any
-	(*timestamp.Timestamp)(nil),    // 38: google.protobuf.Timestamp
-	(*_struct.Value)(nil),          // 39: google.protobuf.Value
+	(*ModelInfo)(nil),                // 0: gojango.admin.ModelInfo
+	(*ModelPermissions)(nil),         // 1: gojango.admin.ModelPermissions
+	(*AdminAction)(nil),              // 2: gojango.admin.AdminAction
+	(*FieldInfo)(nil),                // 3: gojango.admin.FieldInfo
+	(*ListModelsRequest)(nil),        // 4: gojango.admin.ListModelsRequest
+	(*ListModelsResponse)(nil),       // 5: gojango.admin.ListModelsResponse
+	(*SiteInfo)(nil),                 // 6: gojango.admin.SiteInfo
+	(*GetModelSchemaRequest)(nil),    // 7: gojango.admin.GetModelSchemaRequest
+	(*GetModelSchemaResponse)(nil),   // 8: gojango.admin.GetModelSchemaResponse
+	(*Fieldset)(nil),                 // 9: gojango.admin.Fieldset
+	(*ListObjectsRequest)(nil),       // 10: gojango.admin.ListObjectsRequest
+	(*ListObjectsResponse)(nil),      // 11: gojango.admin.ListObjectsResponse
+	(*ObjectData)(nil),               // 12: gojango.admin.ObjectData
+	(*GetObjectRequest)(nil),         // 13: gojango.admin.GetObjectRequest
+	(*GetObjectResponse)(nil),        // 14: gojango.admin.GetObjectResponse
+	(*CreateObjectRequest)(nil),      // 15: gojango.admin.CreateObjectRequest
+	(*CreateObjectResponse)(nil),     // 16: gojango.admin.CreateObjectResponse
+	(*UpdateObjectRequest)(nil),      // 17: gojango.admin.UpdateObjectRequest
+	(*UpdateObjectResponse)(nil),     // 18: gojango.admin.UpdateObjectResponse
+	(*DeleteObjectRequest)(nil),      // 19: gojango.admin.DeleteObjectRequest
+	(*DeleteObjectResponse)(nil),     // 20: gojango.admin.DeleteObjectResponse
+	(*DeleteObjectsRequest)(nil),     // 21: gojango.admin.DeleteObjectsRequest
+	(*DeleteObjectsResponse)(nil),    // 22: gojango.admin.DeleteObjectsResponse
+	(*ExecuteActionRequest)(nil),     // 23: gojango.admin.ExecuteActionRequest
+	(*ExecuteActionResponse)(nil),    // 24: gojango.admin.ExecuteActionResponse
+	(*ListActionsRequest)(nil),       // 25: gojango.admin.ListActionsRequest
+	(*ListActionsResponse)(nil),      // 26: gojango.admin.ListActionsResponse
+	(*SearchObjectsRequest)(nil),     // 27: gojango.admin.SearchObjectsRequest
+	(*SearchObjectsResponse)(nil),    // 28: gojango.admin.SearchObjectsResponse
+	(*ValidationError)(nil),          // 29: gojango.admin.ValidationError
+	(*FilterOption)(nil),             // 30: gojango.admin.FilterOption
+	(*FilterSpec)(nil),               // 31: gojango.admin.FilterSpec
+	(*GetDashboardRequest)(nil),      // 32: gojango.admin.GetDashboardRequest
+	(*GetDashboardResponse)(nil),     // 33: gojango.admin.GetDashboardResponse
+	(*DashboardModel)(nil),           // 34: gojango.admin.DashboardModel
+	(*AdminLogEntry)(nil),            // 35: gojango.admin.AdminLogEntry
+	(*GetDateHierarchyRequest)(nil),  // 36: gojango.admin.GetDateHierarchyRequest
+	(*GetDateHierarchyResponse)(nil), // 37: gojango.admin.GetDateHierarchyResponse
+	(*DateBucket)(nil),               // 38: gojango.admin.DateBucket
+	(*SaveListEditsRequest)(nil),     // 39: gojango.admin.SaveListEditsRequest
+	(*SaveListEditsResponse)(nil),    // 40: gojango.admin.SaveListEditsResponse
+	(*ListEditResult)(nil),           // 41: gojango.admin.ListEditResult
+	nil,                              // 42: gojango.admin.ListModelsResponse.ModelsEntry
+	nil,                              // 43: gojango.admin.ListObjectsRequest.FiltersEntry
+	nil,                              // 44: gojango.admin.ObjectData.FieldsEntry
+	nil,                              // 45: gojango.admin.CreateObjectRequest.DataEntry
+	nil,                              // 46: gojango.admin.UpdateObjectRequest.DataEntry
+	nil,                              // 47: gojango.admin.ExecuteActionRequest.ParametersEntry
+	nil,                              // 48: gojango.admin.GetDateHierarchyRequest.FiltersEntry
+	nil,                              // 49: gojango.admin.DateBucket.ParamsEntry
+	nil,                              // 50: gojango.admin.SaveListEditsRequest.RowsEntry
+	(*any1.Any)(nil),                 // 51: google.protobuf.Any
+	(*timestamp.Timestamp)(nil),      // 52: google.protobuf.Timestamp
+	(*_struct.Value)(nil),            // 53: google.protobuf.Value
+	(*_struct.Struct)(nil),           // 54: google.protobuf.Struct
 }
 var file_proto_admin_proto_depIdxs = []int32{
 	1,  // 0: gojango.admin.ModelInfo.permissions:type_name -> gojango.admin.ModelPermissions
 	2,  // 1: gojango.admin.ModelInfo.actions:type_name -> gojango.admin.AdminAction
-	37, // 2: gojango.admin.FieldInfo.default_value:type_name -> google.protobuf.Any
-	31, // 3: gojango.admin.ListModelsResponse.models:type_name -> gojango.admin.ListModelsResponse.ModelsEntry
+	51, // 2: gojango.admin.FieldInfo.default_value:type_name -> google.protobuf.Any
+	42, // 3: gojango.admin.ListModelsResponse.models:type_name -> gojango.admin.ListModelsResponse.ModelsEntry
 	6,  // 4: gojango.admin.ListModelsResponse.site:type_name -> gojango.admin.SiteInfo
 	0,  // 5: gojango.admin.GetModelSchemaResponse.model_info:type_name -> gojango.admin.ModelInfo
 	3,  // 6: gojango.admin.GetModelSchemaResponse.fields:type_name -> gojango.admin.FieldInfo
-	32, // 7: gojango.admin.ListObjectsRequest.filters:type_name -> gojango.admin.ListObjectsRequest.FiltersEntry
-	11, // 8: gojango.admin.ListObjectsResponse.objects:type_name -> gojango.admin.ObjectData
-	33, // 9: gojango.admin.ObjectData.fields:type_name -> gojango.admin.ObjectData.FieldsEntry
-	38, // 10: gojango.admin.ObjectData.created_at:type_name -> google.protobuf.Timestamp
-	38, // 11: gojango.admin.ObjectData.updated_at:type_name -> google.protobuf.Timestamp
-	11, // 12: gojango.admin.GetObjectResponse.object:type_name -> gojango.admin.ObjectData
-	3,  // 13: gojango.admin.GetObjectResponse.form_fields:type_name -> gojango.admin.FieldInfo
-	34, // 14: gojango.admin.CreateObjectRequest.data:type_name -> gojango.admin.CreateObjectRequest.DataEntry
-	11, // 15: gojango.admin.CreateObjectResponse.object:type_name -> gojango.admin.ObjectData
-	28, // 16: gojango.admin.CreateObjectResponse.errors:type_name -> gojango.admin.ValidationError
-	35, // 17: gojango.admin.UpdateObjectRequest.data:type_name -> gojango.admin.UpdateObjectRequest.DataEntry
-	11, // 18: gojango.admin.UpdateObjectResponse.object:type_name -> gojango.admin.ObjectData
-	28, // 19: gojango.admin.UpdateObjectResponse.errors:type_name -> gojango.admin.ValidationError
-	36, // 20: gojango.admin.ExecuteActionRequest.parameters:type_name -> gojango.admin.ExecuteActionRequest.ParametersEntry
-	28, // 21: gojango.admin.ExecuteActionResponse.errors:type_name -> gojango.admin.ValidationError
-	2,  // 22: gojango.admin.ListActionsResponse.actions:type_name -> gojango.admin.AdminAction
-	11, // 23: gojango.admin.SearchObjectsResponse.objects:type_name -> gojango.admin.ObjectData
-	29, // 24: gojango.admin.FilterSpec.options:type_name -> gojango.admin.FilterOption
-	0,  // 25: gojango.admin.ListModelsResponse.ModelsEntry.value:type_name -> gojango.admin.ModelInfo
-	39, // 26: gojango.admin.ObjectData.FieldsEntry.value:type_name -> google.protobuf.Value
-	39, // 27: gojango.admin.CreateObjectRequest.DataEntry.value:type_name -> google.protobuf.Value
-	39, // 28: gojango.admin.UpdateObjectRequest.DataEntry.value:type_name -> google.protobuf.Value
-	39, // 29: gojango.admin.ExecuteActionRequest.ParametersEntry.value:type_name -> google.protobuf.Value
-	4,  // 30: gojango.admin.AdminService.ListModels:input_type -> gojango.admin.ListModelsRequest
-	7,  // 31: gojango.admin.AdminService.GetModelSchema:input_type -> gojango.admin.GetModelSchemaRequest
-	9,  // 32: gojango.admin.AdminService.ListObjects:input_type -> gojango.admin.ListObjectsRequest
-	12, // 33: gojango.admin.AdminService.GetObject:input_type -> gojango.admin.GetObjectRequest
-	14, // 34: gojango.admin.AdminService.CreateObject:input_type -> gojango.admin.CreateObjectRequest
-	16, // 35: gojango.admin.AdminService.UpdateObject:input_type -> gojango.admin.UpdateObjectRequest
-	18, // 36: gojango.admin.AdminService.DeleteObject:input_type -> gojango.admin.DeleteObjectRequest
-	20, // 37: gojango.admin.AdminService.DeleteObjects:input_type -> gojango.admin.DeleteObjectsRequest
-	22, // 38: gojango.admin.AdminService.ExecuteAction:input_type -> gojango.admin.ExecuteActionRequest
-	24, // 39: gojango.admin.AdminService.ListActions:input_type -> gojango.admin.ListActionsRequest
-	26, // 40: gojango.admin.AdminService.SearchObjects:input_type -> gojango.admin.SearchObjectsRequest
-	5,  // 41: gojango.admin.AdminService.ListModels:output_type -> gojango.admin.ListModelsResponse
-	8,  // 42: gojango.admin.AdminService.GetModelSchema:output_type -> gojango.admin.GetModelSchemaResponse
-	10, // 43: gojango.admin.AdminService.ListObjects:output_type -> gojango.admin.ListObjectsResponse
-	13, // 44: gojango.admin.AdminService.GetObject:output_type -> gojango.admin.GetObjectResponse
-	15, // 45: gojango.admin.AdminService.CreateObject:output_type -> gojango.admin.CreateObjectResponse
-	17, // 46: gojango.admin.AdminService.UpdateObject:output_type -> gojango.admin.UpdateObjectResponse
-	19, // 47: gojango.admin.AdminService.DeleteObject:output_type -> gojango.admin.DeleteObjectResponse
-	21, // 48: gojango.admin.AdminService.DeleteObjects:output_type -> gojango.admin.DeleteObjectsResponse
-	23, // 49: gojango.admin.AdminService.ExecuteAction:output_type -> gojango.admin.ExecuteActionResponse
-	25, // 50: gojango.admin.AdminService.ListActions:output_type -> gojango.admin.ListActionsResponse
-	27, // 51: gojango.admin.AdminService.SearchObjects:output_type -> gojango.admin.SearchObjectsResponse
-	41, // [41:52] is the sub-list for method output_type
-	30, // [30:41] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	9,  // 7: gojango.admin.GetModelSchemaResponse.fieldsets:type_name -> gojango.admin.Fieldset
+	43, // 8: gojango.admin.ListObjectsRequest.filters:type_name -> gojango.admin.ListObjectsRequest.FiltersEntry
+	12, // 9: gojango.admin.ListObjectsResponse.objects:type_name -> gojango.admin.ObjectData
+	44, // 10: gojango.admin.ObjectData.fields:type_name -> gojango.admin.ObjectData.FieldsEntry
+	52, // 11: gojango.admin.ObjectData.created_at:type_name -> google.protobuf.Timestamp
+	52, // 12: gojango.admin.ObjectData.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 13: gojango.admin.GetObjectResponse.object:type_name -> gojango.admin.ObjectData
+	3,  // 14: gojango.admin.GetObjectResponse.form_fields:type_name -> gojango.admin.FieldInfo
+	45, // 15: gojango.admin.CreateObjectRequest.data:type_name -> gojango.admin.CreateObjectRequest.DataEntry
+	12, // 16: gojango.admin.CreateObjectResponse.object:type_name -> gojango.admin.ObjectData
+	29, // 17: gojango.admin.CreateObjectResponse.errors:type_name -> gojango.admin.ValidationError
+	46, // 18: gojango.admin.UpdateObjectRequest.data:type_name -> gojango.admin.UpdateObjectRequest.DataEntry
+	12, // 19: gojango.admin.UpdateObjectResponse.object:type_name -> gojango.admin.ObjectData
+	29, // 20: gojango.admin.UpdateObjectResponse.errors:type_name -> gojango.admin.ValidationError
+	47, // 21: gojango.admin.ExecuteActionRequest.parameters:type_name -> gojango.admin.ExecuteActionRequest.ParametersEntry
+	29, // 22: gojango.admin.ExecuteActionResponse.errors:type_name -> gojango.admin.ValidationError
+	2,  // 23: gojango.admin.ListActionsResponse.actions:type_name -> gojango.admin.AdminAction
+	12, // 24: gojango.admin.SearchObjectsResponse.objects:type_name -> gojango.admin.ObjectData
+	30, // 25: gojango.admin.FilterSpec.options:type_name -> gojango.admin.FilterOption
+	34, // 26: gojango.admin.GetDashboardResponse.models:type_name -> gojango.admin.DashboardModel
+	35, // 27: gojango.admin.GetDashboardResponse.recent_actions:type_name -> gojango.admin.AdminLogEntry
+	12, // 28: gojango.admin.DashboardModel.recent_objects:type_name -> gojango.admin.ObjectData
+	52, // 29: gojango.admin.AdminLogEntry.action_time:type_name -> google.protobuf.Timestamp
+	48, // 30: gojango.admin.GetDateHierarchyRequest.filters:type_name -> gojango.admin.GetDateHierarchyRequest.FiltersEntry
+	38, // 31: gojango.admin.GetDateHierarchyResponse.buckets:type_name -> gojango.admin.DateBucket
+	49, // 32: gojango.admin.DateBucket.params:type_name -> gojango.admin.DateBucket.ParamsEntry
+	50, // 33: gojango.admin.SaveListEditsRequest.rows:type_name -> gojango.admin.SaveListEditsRequest.RowsEntry
+	41, // 34: gojango.admin.SaveListEditsResponse.results:type_name -> gojango.admin.ListEditResult
+	29, // 35: gojango.admin.ListEditResult.errors:type_name -> gojango.admin.ValidationError
+	12, // 36: gojango.admin.ListEditResult.object:type_name -> gojango.admin.ObjectData
+	0,  // 37: gojango.admin.ListModelsResponse.ModelsEntry.value:type_name -> gojango.admin.ModelInfo
+	53, // 38: gojango.admin.ObjectData.FieldsEntry.value:type_name -> google.protobuf.Value
+	53, // 39: gojango.admin.CreateObjectRequest.DataEntry.value:type_name -> google.protobuf.Value
+	53, // 40: gojango.admin.UpdateObjectRequest.DataEntry.value:type_name -> google.protobuf.Value
+	53, // 41: gojango.admin.ExecuteActionRequest.ParametersEntry.value:type_name -> google.protobuf.Value
+	54, // 42: gojango.admin.SaveListEditsRequest.RowsEntry.value:type_name -> google.protobuf.Struct
+	4,  // 43: gojango.admin.AdminService.ListModels:input_type -> gojango.admin.ListModelsRequest
+	7,  // 44: gojango.admin.AdminService.GetModelSchema:input_type -> gojango.admin.GetModelSchemaRequest
+	10, // 45: gojango.admin.AdminService.ListObjects:input_type -> gojango.admin.ListObjectsRequest
+	13, // 46: gojango.admin.AdminService.GetObject:input_type -> gojango.admin.GetObjectRequest
+	15, // 47: gojango.admin.AdminService.CreateObject:input_type -> gojango.admin.CreateObjectRequest
+	17, // 48: gojango.admin.AdminService.UpdateObject:input_type -> gojango.admin.UpdateObjectRequest
+	19, // 49: gojango.admin.AdminService.DeleteObject:input_type -> gojango.admin.DeleteObjectRequest
+	21, // 50: gojango.admin.AdminService.DeleteObjects:input_type -> gojango.admin.DeleteObjectsRequest
+	39, // 51: gojango.admin.AdminService.SaveListEdits:input_type -> gojango.admin.SaveListEditsRequest
+	23, // 52: gojango.admin.AdminService.ExecuteAction:input_type -> gojango.admin.ExecuteActionRequest
+	25, // 53: gojango.admin.AdminService.ListActions:input_type -> gojango.admin.ListActionsRequest
+	27, // 54: gojango.admin.AdminService.SearchObjects:input_type -> gojango.admin.SearchObjectsRequest
+	36, // 55: gojango.admin.AdminService.GetDateHierarchy:input_type -> gojango.admin.GetDateHierarchyRequest
+	32, // 56: gojango.admin.AdminService.GetDashboard:input_type -> gojango.admin.GetDashboardRequest
+	5,  // 57: gojango.admin.AdminService.ListModels:output_type -> gojango.admin.ListModelsResponse
+	8,  // 58: gojango.admin.AdminService.GetModelSchema:output_type -> gojango.admin.GetModelSchemaResponse
+	11, // 59: gojango.admin.AdminService.ListObjects:output_type -> gojango.admin.ListObjectsResponse
+	14, // 60: gojango.admin.AdminService.GetObject:output_type -> gojango.admin.GetObjectResponse
+	16, // 61: gojango.admin.AdminService.CreateObject:output_type -> gojango.admin.CreateObjectResponse
+	18, // 62: gojango.admin.AdminService.UpdateObject:output_type -> gojango.admin.UpdateObjectResponse
+	20, // 63: gojango.admin.AdminService.DeleteObject:output_type -> gojango.admin.DeleteObjectResponse
+	22, // 64: gojango.admin.AdminService.DeleteObjects:output_type -> gojango.admin.DeleteObjectsResponse
+	40, // 65: gojango.admin.AdminService.SaveListEdits:output_type -> gojango.admin.SaveListEditsResponse
+	24, // 66: gojango.admin.AdminService.ExecuteAction:output_type -> gojango.admin.ExecuteActionResponse
+	26, // 67: gojango.admin.AdminService.ListActions:output_type -> gojango.admin.ListActionsResponse
+	28, // 68: gojango.admin.AdminService.SearchObjects:output_type -> gojango.admin.SearchObjectsResponse
+	37, // 69: gojango.admin.AdminService.GetDateHierarchy:output_type -> gojango.admin.GetDateHierarchyResponse
+	33, // 70: gojango.admin.AdminService.GetDashboard:output_type -> gojango.admin.GetDashboardResponse
+	57, // [57:71] is the sub-list for method output_type
+	43, // [43:57] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_proto_admin_proto_init() }
@@ -2466,7 +3380,7 @@ func file_proto_admin_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_admin_proto_rawDesc), len(file_proto_admin_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   37,
+			NumMessages:   51,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
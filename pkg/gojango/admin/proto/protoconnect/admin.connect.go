@@ -55,6 +55,9 @@ const (
 	// AdminServiceDeleteObjectsProcedure is the fully-qualified name of the AdminService's
 	// DeleteObjects RPC.
 	AdminServiceDeleteObjectsProcedure = "/gojango.admin.AdminService/DeleteObjects"
+	// AdminServiceSaveListEditsProcedure is the fully-qualified name of the AdminService's
+	// SaveListEdits RPC.
+	AdminServiceSaveListEditsProcedure = "/gojango.admin.AdminService/SaveListEdits"
 	// AdminServiceExecuteActionProcedure is the fully-qualified name of the AdminService's
 	// ExecuteAction RPC.
 	AdminServiceExecuteActionProcedure = "/gojango.admin.AdminService/ExecuteAction"
@@ -64,6 +67,12 @@ const (
 	// AdminServiceSearchObjectsProcedure is the fully-qualified name of the AdminService's
 	// SearchObjects RPC.
 	AdminServiceSearchObjectsProcedure = "/gojango.admin.AdminService/SearchObjects"
+	// AdminServiceGetDateHierarchyProcedure is the fully-qualified name of the AdminService's
+	// GetDateHierarchy RPC.
+	AdminServiceGetDateHierarchyProcedure = "/gojango.admin.AdminService/GetDateHierarchy"
+	// AdminServiceGetDashboardProcedure is the fully-qualified name of the AdminService's GetDashboard
+	// RPC.
+	AdminServiceGetDashboardProcedure = "/gojango.admin.AdminService/GetDashboard"
 )
 
 // AdminServiceClient is a client for the gojango.admin.AdminService service.
@@ -78,11 +87,15 @@ type AdminServiceClient interface {
 	UpdateObject(context.Context, *connect.Request[proto.UpdateObjectRequest]) (*connect.Response[proto.UpdateObjectResponse], error)
 	DeleteObject(context.Context, *connect.Request[proto.DeleteObjectRequest]) (*connect.Response[proto.DeleteObjectResponse], error)
 	DeleteObjects(context.Context, *connect.Request[proto.DeleteObjectsRequest]) (*connect.Response[proto.DeleteObjectsResponse], error)
+	SaveListEdits(context.Context, *connect.Request[proto.SaveListEditsRequest]) (*connect.Response[proto.SaveListEditsResponse], error)
 	// Admin actions
 	ExecuteAction(context.Context, *connect.Request[proto.ExecuteActionRequest]) (*connect.Response[proto.ExecuteActionResponse], error)
 	ListActions(context.Context, *connect.Request[proto.ListActionsRequest]) (*connect.Response[proto.ListActionsResponse], error)
 	// Search and filtering
 	SearchObjects(context.Context, *connect.Request[proto.SearchObjectsRequest]) (*connect.Response[proto.SearchObjectsResponse], error)
+	GetDateHierarchy(context.Context, *connect.Request[proto.GetDateHierarchyRequest]) (*connect.Response[proto.GetDateHierarchyResponse], error)
+	// Dashboard
+	GetDashboard(context.Context, *connect.Request[proto.GetDashboardRequest]) (*connect.Response[proto.GetDashboardResponse], error)
 }
 
 // NewAdminServiceClient constructs a client for the gojango.admin.AdminService service. By default,
@@ -144,6 +157,12 @@ func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(adminServiceMethods.ByName("DeleteObjects")),
 			connect.WithClientOptions(opts...),
 		),
+		saveListEdits: connect.NewClient[proto.SaveListEditsRequest, proto.SaveListEditsResponse](
+			httpClient,
+			baseURL+AdminServiceSaveListEditsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SaveListEdits")),
+			connect.WithClientOptions(opts...),
+		),
 		executeAction: connect.NewClient[proto.ExecuteActionRequest, proto.ExecuteActionResponse](
 			httpClient,
 			baseURL+AdminServiceExecuteActionProcedure,
@@ -162,22 +181,37 @@ func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(adminServiceMethods.ByName("SearchObjects")),
 			connect.WithClientOptions(opts...),
 		),
+		getDateHierarchy: connect.NewClient[proto.GetDateHierarchyRequest, proto.GetDateHierarchyResponse](
+			httpClient,
+			baseURL+AdminServiceGetDateHierarchyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetDateHierarchy")),
+			connect.WithClientOptions(opts...),
+		),
+		getDashboard: connect.NewClient[proto.GetDashboardRequest, proto.GetDashboardResponse](
+			httpClient,
+			baseURL+AdminServiceGetDashboardProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetDashboard")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // adminServiceClient implements AdminServiceClient.
 type adminServiceClient struct {
-	listModels     *connect.Client[proto.ListModelsRequest, proto.ListModelsResponse]
-	getModelSchema *connect.Client[proto.GetModelSchemaRequest, proto.GetModelSchemaResponse]
-	listObjects    *connect.Client[proto.ListObjectsRequest, proto.ListObjectsResponse]
-	getObject      *connect.Client[proto.GetObjectRequest, proto.GetObjectResponse]
-	createObject   *connect.Client[proto.CreateObjectRequest, proto.CreateObjectResponse]
-	updateObject   *connect.Client[proto.UpdateObjectRequest, proto.UpdateObjectResponse]
-	deleteObject   *connect.Client[proto.DeleteObjectRequest, proto.DeleteObjectResponse]
-	deleteObjects  *connect.Client[proto.DeleteObjectsRequest, proto.DeleteObjectsResponse]
-	executeAction  *connect.Client[proto.ExecuteActionRequest, proto.ExecuteActionResponse]
-	listActions    *connect.Client[proto.ListActionsRequest, proto.ListActionsResponse]
-	searchObjects  *connect.Client[proto.SearchObjectsRequest, proto.SearchObjectsResponse]
+	listModels       *connect.Client[proto.ListModelsRequest, proto.ListModelsResponse]
+	getModelSchema   *connect.Client[proto.GetModelSchemaRequest, proto.GetModelSchemaResponse]
+	listObjects      *connect.Client[proto.ListObjectsRequest, proto.ListObjectsResponse]
+	getObject        *connect.Client[proto.GetObjectRequest, proto.GetObjectResponse]
+	createObject     *connect.Client[proto.CreateObjectRequest, proto.CreateObjectResponse]
+	updateObject     *connect.Client[proto.UpdateObjectRequest, proto.UpdateObjectResponse]
+	deleteObject     *connect.Client[proto.DeleteObjectRequest, proto.DeleteObjectResponse]
+	deleteObjects    *connect.Client[proto.DeleteObjectsRequest, proto.DeleteObjectsResponse]
+	saveListEdits    *connect.Client[proto.SaveListEditsRequest, proto.SaveListEditsResponse]
+	executeAction    *connect.Client[proto.ExecuteActionRequest, proto.ExecuteActionResponse]
+	listActions      *connect.Client[proto.ListActionsRequest, proto.ListActionsResponse]
+	searchObjects    *connect.Client[proto.SearchObjectsRequest, proto.SearchObjectsResponse]
+	getDateHierarchy *connect.Client[proto.GetDateHierarchyRequest, proto.GetDateHierarchyResponse]
+	getDashboard     *connect.Client[proto.GetDashboardRequest, proto.GetDashboardResponse]
 }
 
 // ListModels calls gojango.admin.AdminService.ListModels.
@@ -220,6 +254,11 @@ func (c *adminServiceClient) DeleteObjects(ctx context.Context, req *connect.Req
 	return c.deleteObjects.CallUnary(ctx, req)
 }
 
+// SaveListEdits calls gojango.admin.AdminService.SaveListEdits.
+func (c *adminServiceClient) SaveListEdits(ctx context.Context, req *connect.Request[proto.SaveListEditsRequest]) (*connect.Response[proto.SaveListEditsResponse], error) {
+	return c.saveListEdits.CallUnary(ctx, req)
+}
+
 // ExecuteAction calls gojango.admin.AdminService.ExecuteAction.
 func (c *adminServiceClient) ExecuteAction(ctx context.Context, req *connect.Request[proto.ExecuteActionRequest]) (*connect.Response[proto.ExecuteActionResponse], error) {
 	return c.executeAction.CallUnary(ctx, req)
@@ -235,6 +274,16 @@ func (c *adminServiceClient) SearchObjects(ctx context.Context, req *connect.Req
 	return c.searchObjects.CallUnary(ctx, req)
 }
 
+// GetDateHierarchy calls gojango.admin.AdminService.GetDateHierarchy.
+func (c *adminServiceClient) GetDateHierarchy(ctx context.Context, req *connect.Request[proto.GetDateHierarchyRequest]) (*connect.Response[proto.GetDateHierarchyResponse], error) {
+	return c.getDateHierarchy.CallUnary(ctx, req)
+}
+
+// GetDashboard calls gojango.admin.AdminService.GetDashboard.
+func (c *adminServiceClient) GetDashboard(ctx context.Context, req *connect.Request[proto.GetDashboardRequest]) (*connect.Response[proto.GetDashboardResponse], error) {
+	return c.getDashboard.CallUnary(ctx, req)
+}
+
 // AdminServiceHandler is an implementation of the gojango.admin.AdminService service.
 type AdminServiceHandler interface {
 	// Model introspection
@@ -247,11 +296,15 @@ type AdminServiceHandler interface {
 	UpdateObject(context.Context, *connect.Request[proto.UpdateObjectRequest]) (*connect.Response[proto.UpdateObjectResponse], error)
 	DeleteObject(context.Context, *connect.Request[proto.DeleteObjectRequest]) (*connect.Response[proto.DeleteObjectResponse], error)
 	DeleteObjects(context.Context, *connect.Request[proto.DeleteObjectsRequest]) (*connect.Response[proto.DeleteObjectsResponse], error)
+	SaveListEdits(context.Context, *connect.Request[proto.SaveListEditsRequest]) (*connect.Response[proto.SaveListEditsResponse], error)
 	// Admin actions
 	ExecuteAction(context.Context, *connect.Request[proto.ExecuteActionRequest]) (*connect.Response[proto.ExecuteActionResponse], error)
 	ListActions(context.Context, *connect.Request[proto.ListActionsRequest]) (*connect.Response[proto.ListActionsResponse], error)
 	// Search and filtering
 	SearchObjects(context.Context, *connect.Request[proto.SearchObjectsRequest]) (*connect.Response[proto.SearchObjectsResponse], error)
+	GetDateHierarchy(context.Context, *connect.Request[proto.GetDateHierarchyRequest]) (*connect.Response[proto.GetDateHierarchyResponse], error)
+	// Dashboard
+	GetDashboard(context.Context, *connect.Request[proto.GetDashboardRequest]) (*connect.Response[proto.GetDashboardResponse], error)
 }
 
 // NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -309,6 +362,12 @@ func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(adminServiceMethods.ByName("DeleteObjects")),
 		connect.WithHandlerOptions(opts...),
 	)
+	adminServiceSaveListEditsHandler := connect.NewUnaryHandler(
+		AdminServiceSaveListEditsProcedure,
+		svc.SaveListEdits,
+		connect.WithSchema(adminServiceMethods.ByName("SaveListEdits")),
+		connect.WithHandlerOptions(opts...),
+	)
 	adminServiceExecuteActionHandler := connect.NewUnaryHandler(
 		AdminServiceExecuteActionProcedure,
 		svc.ExecuteAction,
@@ -327,6 +386,18 @@ func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(adminServiceMethods.ByName("SearchObjects")),
 		connect.WithHandlerOptions(opts...),
 	)
+	adminServiceGetDateHierarchyHandler := connect.NewUnaryHandler(
+		AdminServiceGetDateHierarchyProcedure,
+		svc.GetDateHierarchy,
+		connect.WithSchema(adminServiceMethods.ByName("GetDateHierarchy")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetDashboardHandler := connect.NewUnaryHandler(
+		AdminServiceGetDashboardProcedure,
+		svc.GetDashboard,
+		connect.WithSchema(adminServiceMethods.ByName("GetDashboard")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/gojango.admin.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AdminServiceListModelsProcedure:
@@ -345,12 +416,18 @@ func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOpti
 			adminServiceDeleteObjectHandler.ServeHTTP(w, r)
 		case AdminServiceDeleteObjectsProcedure:
 			adminServiceDeleteObjectsHandler.ServeHTTP(w, r)
+		case AdminServiceSaveListEditsProcedure:
+			adminServiceSaveListEditsHandler.ServeHTTP(w, r)
 		case AdminServiceExecuteActionProcedure:
 			adminServiceExecuteActionHandler.ServeHTTP(w, r)
 		case AdminServiceListActionsProcedure:
 			adminServiceListActionsHandler.ServeHTTP(w, r)
 		case AdminServiceSearchObjectsProcedure:
 			adminServiceSearchObjectsHandler.ServeHTTP(w, r)
+		case AdminServiceGetDateHierarchyProcedure:
+			adminServiceGetDateHierarchyHandler.ServeHTTP(w, r)
+		case AdminServiceGetDashboardProcedure:
+			adminServiceGetDashboardHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -392,6 +469,10 @@ func (UnimplementedAdminServiceHandler) DeleteObjects(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gojango.admin.AdminService.DeleteObjects is not implemented"))
 }
 
+func (UnimplementedAdminServiceHandler) SaveListEdits(context.Context, *connect.Request[proto.SaveListEditsRequest]) (*connect.Response[proto.SaveListEditsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gojango.admin.AdminService.SaveListEdits is not implemented"))
+}
+
 func (UnimplementedAdminServiceHandler) ExecuteAction(context.Context, *connect.Request[proto.ExecuteActionRequest]) (*connect.Response[proto.ExecuteActionResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gojango.admin.AdminService.ExecuteAction is not implemented"))
 }
@@ -403,3 +484,11 @@ func (UnimplementedAdminServiceHandler) ListActions(context.Context, *connect.Re
 func (UnimplementedAdminServiceHandler) SearchObjects(context.Context, *connect.Request[proto.SearchObjectsRequest]) (*connect.Response[proto.SearchObjectsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gojango.admin.AdminService.SearchObjects is not implemented"))
 }
+
+func (UnimplementedAdminServiceHandler) GetDateHierarchy(context.Context, *connect.Request[proto.GetDateHierarchyRequest]) (*connect.Response[proto.GetDateHierarchyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gojango.admin.AdminService.GetDateHierarchy is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetDashboard(context.Context, *connect.Request[proto.GetDashboardRequest]) (*connect.Response[proto.GetDashboardResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("gojango.admin.AdminService.GetDashboard is not implemented"))
+}
@@ -0,0 +1,180 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// wireRelationFilters gives relation filters without a data source one that
+// lists the related objects through the site's Ent client. The client is
+// read each time choices are built, so it may be set after registration.
+func (s *Site) wireRelationFilters(admin *ModelAdmin) {
+	for _, filter := range admin.filterSet.GetAllFilters() {
+		relation, ok := filter.(*RelationFilter)
+		if !ok || relation.HasDataSource() {
+			continue
+		}
+
+		relation.SetDataSource(func() []FilterChoice {
+			choices, err := entRelationChoices(context.Background(), s.EntClient(), admin.model, relation)
+			if err != nil {
+				return nil
+			}
+			return choices
+		})
+	}
+}
+
+// entRelationChoices queries the related objects of a relation filter and
+// counts the model's objects pointing at each of them
+func entRelationChoices(ctx context.Context, client interface{}, model interface{}, filter *RelationFilter) ([]FilterChoice, error) {
+	if client == nil {
+		return nil, nil
+	}
+
+	relatedQuery, err := entModelQuery(client, filter.RelatedModel())
+	if err != nil {
+		return nil, err
+	}
+	related, err := callEntQuery(relatedQuery, "All", ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := reflect.ValueOf(related)
+	if objects.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("expected a slice of related objects, got %T", related)
+	}
+
+	// Choices are still listed when they can't be counted
+	counts, _ := entRelationCounts(ctx, client, model, filter.Column())
+
+	choices := make([]FilterChoice, 0, objects.Len())
+	for i := 0; i < objects.Len(); i++ {
+		obj := objects.Index(i).Interface()
+		pk, err := extractObjectID(obj)
+		if err != nil {
+			continue
+		}
+
+		choices = append(choices, FilterChoice{
+			Value:   pk,
+			Display: fmt.Sprint(obj),
+			Count:   counts[pk],
+		})
+	}
+
+	return choices, nil
+}
+
+// entRelationCounts counts the model's objects by the value of column in a
+// single query, SELECT column, COUNT(*) ... GROUP BY column, keyed by the
+// value as formatted for filter choices
+func entRelationCounts(ctx context.Context, client interface{}, model interface{}, column string) (map[string]int, error) {
+	query, err := entModelQuery(client, model)
+	if err != nil {
+		return nil, err
+	}
+
+	groupBy := query.MethodByName("GroupBy")
+	if !groupBy.IsValid() || groupBy.Type().NumIn() < 1 || groupBy.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("query %s has no GroupBy method", query.Type())
+	}
+	grouped := groupBy.Call([]reflect.Value{reflect.ValueOf(column)})[0]
+
+	aggregate := grouped.MethodByName("Aggregate")
+	if !aggregate.IsValid() || aggregate.Type().NumIn() != 1 || !aggregate.Type().IsVariadic() {
+		return nil, fmt.Errorf("%s has no Aggregate method", grouped.Type())
+	}
+
+	// Ent aggregate functions are named func(*sql.Selector) string types
+	aggregateType := aggregate.Type().In(0).Elem()
+	count := reflect.ValueOf(func(*entsql.Selector) string {
+		return entsql.As(entsql.Count("*"), "count")
+	})
+	if !count.Type().ConvertibleTo(aggregateType) {
+		return nil, fmt.Errorf("unsupported aggregate type %s", aggregateType)
+	}
+	grouped = aggregate.Call([]reflect.Value{count.Convert(aggregateType)})[0]
+
+	scan := grouped.MethodByName("Scan")
+	if !scan.IsValid() || scan.Type().NumIn() != 2 || scan.Type().NumOut() != 1 {
+		return nil, fmt.Errorf("%s has no Scan method", grouped.Type())
+	}
+
+	// Rows are scanned by column name, which is only known at runtime
+	rowType := reflect.StructOf([]reflect.StructField{
+		{Name: "Value", Type: reflect.TypeOf((*interface{})(nil)).Elem(), Tag: reflect.StructTag(fmt.Sprintf(`sql:%q`, column))},
+		{Name: "Count", Type: reflect.TypeOf(0), Tag: `sql:"count"`},
+	})
+	rows := reflect.New(reflect.SliceOf(rowType))
+	out := scan.Call([]reflect.Value{reflect.ValueOf(ctx), rows})
+	if err, _ := out[0].Interface().(error); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, rows.Elem().Len())
+	for i := 0; i < rows.Elem().Len(); i++ {
+		row := rows.Elem().Index(i)
+		value := row.Field(0).Interface()
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		counts[fmt.Sprint(value)] = int(row.Field(1).Int())
+	}
+	return counts, nil
+}
+
+// entModelQuery returns client.<Model>.Query() for an Ent client
+func entModelQuery(client interface{}, model interface{}) (reflect.Value, error) {
+	return entModelBuilder(client, model, "Query")
+}
+
+// entModelBuilder calls a builder method without arguments, such as Query or
+// Update, on the model's client of an Ent client
+func entModelBuilder(client interface{}, model interface{}, method string) (reflect.Value, error) {
+	modelType := reflect.TypeOf(model)
+	if modelType == nil {
+		return reflect.Value{}, fmt.Errorf("model is nil")
+	}
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+
+	c := reflect.ValueOf(client)
+	for c.Kind() == reflect.Ptr {
+		c = c.Elem()
+	}
+	if c.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("client is not an Ent client: %T", client)
+	}
+
+	modelClient := c.FieldByName(modelType.Name())
+	if !modelClient.IsValid() || (modelClient.Kind() == reflect.Ptr && modelClient.IsNil()) {
+		return reflect.Value{}, fmt.Errorf("client has no %s model", modelType.Name())
+	}
+
+	builder := modelClient.MethodByName(method)
+	if !builder.IsValid() || builder.Type().NumIn() != 0 || builder.Type().NumOut() != 1 {
+		return reflect.Value{}, fmt.Errorf("%s client has no %s method", modelType.Name(), method)
+	}
+	return builder.Call(nil)[0], nil
+}
+
+// callEntQuery calls a query method taking a context and returning a value
+// and an error, such as All or Count
+func callEntQuery(query reflect.Value, method string, ctx context.Context) (interface{}, error) {
+	m := query.MethodByName(method)
+	if !m.IsValid() || m.Type().NumIn() != 1 || m.Type().NumOut() != 2 {
+		return nil, fmt.Errorf("query %s has no %s method", query.Type(), method)
+	}
+
+	out := m.Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := out[1].Interface().(error); err != nil {
+		return nil, err
+	}
+	return out[0].Interface(), nil
+}
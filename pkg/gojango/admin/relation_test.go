@@ -0,0 +1,143 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Author and Article mimic Ent entities with an article -> author edge
+type Author struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func (a *Author) String() string {
+	return a.Name
+}
+
+type Article struct {
+	ID       int `json:"id,omitempty"`
+	AuthorID int `json:"author_id,omitempty"`
+}
+
+// articleAggregate mimics an Ent-generated aggregate function type
+type articleAggregate func(*entsql.Selector) string
+
+type authorQuery struct {
+	authors []*Author
+}
+
+func (q *authorQuery) All(ctx context.Context) ([]*Author, error) {
+	return q.authors, nil
+}
+
+type articleQuery struct {
+	client *articleClient
+}
+
+func (q *articleQuery) GroupBy(field string, fields ...string) *articleGroupBy {
+	return &articleGroupBy{client: q.client, fields: append([]string{field}, fields...)}
+}
+
+// articleGroupBy runs grouped queries against a SQLite articles table, as
+// Ent's generated GroupBy builders do
+type articleGroupBy struct {
+	client *articleClient
+	fields []string
+	fns    []articleAggregate
+}
+
+func (g *articleGroupBy) Aggregate(fns ...articleAggregate) *articleGroupBy {
+	g.fns = append(g.fns, fns...)
+	return g
+}
+
+func (g *articleGroupBy) Scan(ctx context.Context, v any) error {
+	selector := entsql.Dialect(dialect.SQLite).Select().From(entsql.Table("articles"))
+	columns := append([]string{}, g.fields...)
+	for _, fn := range g.fns {
+		columns = append(columns, fn(selector))
+	}
+	query, args := selector.Select(columns...).GroupBy(g.fields...).Query()
+
+	g.client.queries++
+	rows, err := g.client.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	return entsql.ScanSlice(rows, v)
+}
+
+type authorClient struct{ authors []*Author }
+
+func (c *authorClient) Query() *authorQuery { return &authorQuery{authors: c.authors} }
+
+type articleClient struct {
+	db      *sql.DB
+	queries int
+}
+
+func (c *articleClient) Query() *articleQuery { return &articleQuery{client: c} }
+
+// newArticleClient stores articles in an in-memory SQLite table
+func newArticleClient(t *testing.T, articles ...*Article) *articleClient {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec("CREATE TABLE articles (id INTEGER PRIMARY KEY, author_id INTEGER)")
+	require.NoError(t, err)
+	for _, article := range articles {
+		_, err := db.Exec("INSERT INTO articles (id, author_id) VALUES (?, ?)", article.ID, article.AuthorID)
+		require.NoError(t, err)
+	}
+	return &articleClient{db: db}
+}
+
+// relationEntClient mimics an Ent client with a field per model
+type relationEntClient struct {
+	Author  *authorClient
+	Article *articleClient
+}
+
+func TestRelationFilterEntDataSource(t *testing.T) {
+	site := NewSite("test")
+	filter := NewRelationFilter("author", "", &Author{})
+	require.NoError(t, site.Register(&Article{}, NewModelAdmin(&Article{}).AddListFilter(filter)))
+
+	// Without a client there are no related choices yet
+	assert.True(t, filter.HasDataSource())
+	assert.Len(t, filter.Choices(), 1)
+
+	articles := newArticleClient(t, &Article{ID: 1, AuthorID: 1}, &Article{ID: 2, AuthorID: 1}, &Article{ID: 3, AuthorID: 2})
+	site.SetEntClient(&relationEntClient{
+		Author:  &authorClient{authors: []*Author{{ID: 1, Name: "john"}, {ID: 2, Name: "jane"}, {ID: 3, Name: "jim"}}},
+		Article: articles,
+	})
+
+	assert.Equal(t, []FilterChoice{
+		{Value: "", Display: "All"},
+		{Value: "1", Display: "john", Count: 2},
+		{Value: "2", Display: "jane", Count: 1},
+		{Value: "3", Display: "jim"},
+	}, filter.Choices())
+	assert.Equal(t, 1, articles.queries, "articles should be counted in one grouped query")
+}
+
+func TestRelationFilterKeepsCustomDataSource(t *testing.T) {
+	site := NewSite("test")
+	custom := []FilterChoice{{Value: "9", Display: "custom"}}
+	filter := NewRelationFilter("author", "", &Author{}).SetDataSource(func() []FilterChoice { return custom })
+	require.NoError(t, site.Register(&Article{}, NewModelAdmin(&Article{}).AddListFilter(filter)))
+
+	assert.Equal(t, custom, filter.Choices()[1:])
+}
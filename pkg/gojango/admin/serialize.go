@@ -0,0 +1,187 @@
+package admin
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	adminpb "github.com/epuerta9/gojango/pkg/gojango/admin/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// SetOmitEmptyFields controls whether serialized objects leave out fields
+// that are null or empty. It overrides the site-wide OMIT_EMPTY_FIELDS
+// setting for this model.
+func (ma *ModelAdmin) SetOmitEmptyFields(omit bool) *ModelAdmin {
+	ma.omitEmptyFields = omit
+	ma.omitEmptyFieldsSet = true
+	return ma
+}
+
+// OmitEmptyFields reports whether serialized objects leave out empty fields
+func (ma *ModelAdmin) OmitEmptyFields() bool {
+	return ma.omitEmptyFields
+}
+
+// applyOmitEmptyDefault applies the site-wide default unless the model overrides it
+func (ma *ModelAdmin) applyOmitEmptyDefault(omit bool) {
+	if !ma.omitEmptyFieldsSet {
+		ma.omitEmptyFields = omit
+	}
+}
+
+// SerializeObject prepares an object for a JSON response. Objects are
+// returned unchanged unless empty fields are omitted, in which case maps and
+// structs become a map without their empty fields.
+func (ma *ModelAdmin) SerializeObject(obj interface{}) interface{} {
+	if !ma.omitEmptyFields || obj == nil {
+		return obj
+	}
+
+	if fields, ok := objectFields(obj); ok {
+		for name, value := range fields {
+			if isEmptyFieldValue(value) {
+				delete(fields, name)
+			}
+		}
+		return fields
+	}
+	return obj
+}
+
+// SerializeObjects applies SerializeObject to each object
+func (ma *ModelAdmin) SerializeObjects(objects []interface{}) []interface{} {
+	if !ma.omitEmptyFields {
+		return objects
+	}
+
+	serialized := make([]interface{}, len(objects))
+	for i, obj := range objects {
+		serialized[i] = ma.SerializeObject(obj)
+	}
+	return serialized
+}
+
+// ObjectData converts an object to protobuf ObjectData, leaving out empty
+// fields when the model omits them
+func (ma *ModelAdmin) ObjectData(obj interface{}) (*adminpb.ObjectData, error) {
+	return convertEntObject(obj, ma.omitEmptyFields)
+}
+
+// applyListMethods adds the computed columns of list_display entries that
+// name a list method to each row. Rows are returned unchanged when no list
+// methods are displayed.
+func (ma *ModelAdmin) applyListMethods(objects []interface{}) []interface{} {
+	var methods []string
+	for _, name := range ma.listDisplay {
+		if _, ok := ma.listMethods[name]; ok {
+			methods = append(methods, name)
+		}
+	}
+	if len(methods) == 0 {
+		return objects
+	}
+
+	rows := make([]interface{}, len(objects))
+	for i, obj := range objects {
+		fields, ok := objectFields(obj)
+		if !ok {
+			rows[i] = obj
+			continue
+		}
+		for _, name := range methods {
+			fields[name] = ma.listMethods[name](obj)
+		}
+		rows[i] = fields
+	}
+	return rows
+}
+
+// objectFields returns a copy of a map object's entries or a struct's
+// exported fields keyed by their JSON names
+func objectFields(obj interface{}) (map[string]interface{}, bool) {
+	if m, ok := obj.(map[string]interface{}); ok {
+		fields := make(map[string]interface{}, len(m))
+		for name, value := range m {
+			fields[name] = value
+		}
+		return fields, true
+	}
+
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	fields := make(map[string]interface{})
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if jsonTag := field.Tag.Get("json"); jsonTag != "" {
+			tagName := strings.Split(jsonTag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		fields[name] = v.Field(i).Interface()
+	}
+	return fields, true
+}
+
+// isEmptyFieldValue reports whether a field value is absent or empty: nil,
+// a nil pointer, an empty string, slice or map, or a zero time. Numbers and
+// booleans are never empty since zero and false are meaningful values, and
+// a non-nil pointer to an empty value is an intentional empty, so it is kept.
+func isEmptyFieldValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.IsZero()
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.String, reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// omitEmptyProtoFields removes null and empty string, list and struct values
+func omitEmptyProtoFields(fields map[string]*structpb.Value) {
+	for name, value := range fields {
+		switch kind := value.GetKind().(type) {
+		case nil, *structpb.Value_NullValue:
+			delete(fields, name)
+		case *structpb.Value_StringValue:
+			if kind.StringValue == "" {
+				delete(fields, name)
+			}
+		case *structpb.Value_ListValue:
+			if len(kind.ListValue.GetValues()) == 0 {
+				delete(fields, name)
+			}
+		case *structpb.Value_StructValue:
+			if len(kind.StructValue.GetFields()) == 0 {
+				delete(fields, name)
+			}
+		}
+	}
+}
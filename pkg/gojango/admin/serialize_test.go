@@ -0,0 +1,197 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// profile has optional fields the way Ent generates them
+type profile struct {
+	ID        int       `json:"id,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Bio       *string   `json:"bio,omitempty"`
+	Nickname  *string   `json:"nickname,omitempty"`
+	IsActive  bool      `json:"is_active"`
+	Score     int       `json:"score"`
+	Tags      []string  `json:"tags"`
+	LastLogin time.Time `json:"last_login"`
+	internal  string
+}
+
+func newProfile() *profile {
+	empty := ""
+	return &profile{ID: 1, Email: "john@example.com", Nickname: &empty}
+}
+
+// jsonKeys marshals a value and returns the keys of the resulting object
+func jsonKeys(t *testing.T, value interface{}) map[string]interface{} {
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	var keys map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &keys))
+	return keys
+}
+
+func TestSerializeObjectOmitEmpty(t *testing.T) {
+	admin := NewModelAdmin(&profile{})
+
+	// Default keeps the object unchanged
+	assert.False(t, admin.OmitEmptyFields())
+	obj := newProfile()
+	assert.Same(t, obj, admin.SerializeObject(obj))
+
+	admin.SetOmitEmptyFields(true)
+	keys := jsonKeys(t, admin.SerializeObject(newProfile()))
+
+	for _, kept := range []string{"id", "email", "nickname", "is_active", "score"} {
+		assert.Contains(t, keys, kept)
+	}
+	for _, omitted := range []string{"name", "bio", "tags", "last_login", "internal"} {
+		assert.NotContains(t, keys, omitted)
+	}
+	assert.Equal(t, false, keys["is_active"])
+	assert.Equal(t, "", keys["nickname"], "a pointer to an empty string is an intentional empty")
+
+	// Map objects from database interfaces are handled too
+	keys = jsonKeys(t, admin.SerializeObject(map[string]interface{}{
+		"id": 1, "username": "", "email": nil, "is_active": false,
+	}))
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "is_active": false}, keys)
+}
+
+func TestSiteOmitEmptyFieldsDefault(t *testing.T) {
+	site := NewSite("test")
+	defaultAdmin := NewModelAdmin(&TestUser{})
+	overrideAdmin := NewModelAdmin(&TestPost{}).SetOmitEmptyFields(false)
+	require.NoError(t, site.Register(&TestUser{}, defaultAdmin))
+	require.NoError(t, site.Register(&TestPost{}, overrideAdmin))
+
+	site.SetOmitEmptyFields(true)
+	assert.True(t, defaultAdmin.OmitEmptyFields())
+	assert.False(t, overrideAdmin.OmitEmptyFields())
+
+	site.Unregister(&TestUser{})
+	lateAdmin := NewModelAdmin(&TestUser{})
+	require.NoError(t, site.Register(&TestUser{}, lateAdmin))
+	assert.True(t, lateAdmin.OmitEmptyFields())
+}
+
+func TestAPIModelDataOmitEmptyFields(t *testing.T) {
+	site := NewSite("test")
+	mockDB := newMockDBInterface()
+	modelName := getModelName(&TestUser{})
+	mockDB.objects[modelName] = []interface{}{
+		map[string]interface{}{"id": 1, "username": "john", "email": "", "is_active": false, "created_at": nil},
+	}
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(mockDB)
+	require.NoError(t, site.Register(&TestUser{}, admin))
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/admin/api/:app/:model/", site.handleAPIModelData)
+
+	fetch := func() map[string]interface{} {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/api/"+strings.Replace(modelName, ".", "/", 1)+"/", nil))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		var body struct {
+			Results []map[string]interface{} `json:"results"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		require.Len(t, body.Results, 1)
+		return body.Results[0]
+	}
+
+	// All fields are kept by default
+	assert.Len(t, fetch(), 5)
+
+	site.SetOmitEmptyFields(true)
+	result := fetch()
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "username": "john", "is_active": false}, result)
+}
+
+func TestObjectDataOmitEmptyFields(t *testing.T) {
+	admin := NewModelAdmin(&profile{})
+
+	data, err := admin.ObjectData(newProfile())
+	require.NoError(t, err)
+	assert.Contains(t, data.Fields, "bio")
+	assert.Nil(t, data.Fields["bio"].AsInterface(), "nil pointers are null, not a pointer string")
+
+	admin.SetOmitEmptyFields(true)
+	data, err = admin.ObjectData(newProfile())
+	require.NoError(t, err)
+	for _, kept := range []string{"id", "email", "nickname", "is_active", "score"} {
+		assert.Contains(t, data.Fields, kept)
+	}
+	for _, omitted := range []string{"name", "bio", "tags", "last_login"} {
+		assert.NotContains(t, data.Fields, omitted)
+	}
+	assert.Equal(t, false, data.Fields["is_active"].GetBoolValue())
+}
+
+func TestListMethodsAddComputedColumns(t *testing.T) {
+	mockDB := newMockDBInterface()
+	modelName := getModelName(&TestUser{})
+	mockDB.objects[modelName] = []interface{}{
+		&TestUser{ID: 1, Username: "john", Email: "john@example.com", IsActive: true},
+		&TestUser{ID: 2, Username: "jane", Email: "jane@example.com"},
+	}
+
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(mockDB)
+	admin.SetListDisplay("username", "email_domain", "is_active")
+	admin.AddListMethod("email_domain", func(obj interface{}) interface{} {
+		email := obj.(*TestUser).Email
+		return email[strings.Index(email, "@")+1:]
+	})
+	admin.AddListMethod("not_displayed", func(obj interface{}) interface{} {
+		return "hidden"
+	})
+
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("GET", "/", nil)
+
+	data, err := admin.GetAPIData(ctx, nil)
+	require.NoError(t, err)
+
+	keys := jsonKeys(t, data)
+	results, ok := keys["results"].([]interface{})
+	require.True(t, ok)
+	require.Len(t, results, 2)
+
+	first := results[0].(map[string]interface{})
+	assert.Equal(t, "john", first["username"])
+	assert.Equal(t, "john@example.com", first["email"])
+	assert.Equal(t, true, first["is_active"])
+	assert.Equal(t, "example.com", first["email_domain"])
+	assert.NotContains(t, first, "not_displayed")
+
+	second := results[1].(map[string]interface{})
+	assert.Equal(t, "jane", second["username"])
+	assert.Equal(t, "example.com", second["email_domain"])
+}
+
+func TestListMethodsLeaveRowsUnchangedWhenNotDisplayed(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetListDisplay("username")
+	admin.AddListMethod("email_domain", func(obj interface{}) interface{} {
+		return "example.com"
+	})
+
+	user := &TestUser{ID: 1, Username: "john"}
+	rows := admin.applyListMethods([]interface{}{user})
+	assert.Same(t, user, rows[0])
+}
@@ -6,15 +6,20 @@
 package admin
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"os"
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/epuerta9/gojango/pkg/gojango/admin/proto/protoconnect"
+	"github.com/epuerta9/gojango/pkg/gojango/cache"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
 )
 
 // Site represents the admin site that manages all registered models
@@ -28,8 +33,25 @@ type Site struct {
 	enableLogin  bool
 	permissions  PermissionChecker
 	entClient    interface{} // Global Ent client for database operations
+	listPerPage  int         // Default page size for models that don't set one
+	maxPageSize  int         // Default largest page size clients may request
+	omitEmptyFields bool     // Default for leaving empty fields out of serialized objects
+	assets       fs.FS       // Built React admin, served instead of the files on disk
+	assetDirs    map[string]fs.FS // Subdirectories of assets served under /admin
+	actionLog    ActionLog   // Recent admin actions shown on the dashboard
+	auditLog     *AuditLog   // Records admin actions of every model, nil to not record
+	location     *time.Location // Time zone of date hierarchies, nil for local time
+	cache        cache.Cache    // Caches dashboard counts, nil to count on every request
+	mediaRoot    string         // Directory uploaded files are saved in
+	mediaURL     string         // URL uploaded files are served at
 }
 
+// Pagination defaults used when neither the site nor the model configures them
+const (
+	DefaultListPerPage = 100
+	DefaultMaxPageSize = 200
+)
+
 // PermissionChecker defines interface for checking admin permissions
 type PermissionChecker interface {
 	HasPermission(user interface{}, perm string, obj interface{}) bool
@@ -48,17 +70,35 @@ func NewSite(name string) *Site {
 		indexTitle:  "Site Administration",
 		siteURL:     "/",
 		enableLogin: true,
+		permissions: AllowAllPermissions{},
+		listPerPage: DefaultListPerPage,
+		maxPageSize: DefaultMaxPageSize,
+		mediaRoot:   DefaultMediaRoot,
+		mediaURL:    DefaultMediaURL,
 	}
 }
 
 // DefaultSite is the default admin site instance
 var DefaultSite = NewSite("admin")
 
-// SetEntClient sets the Ent client for database operations
+// SetEntClient sets the Ent client for database operations on the default site
 func SetEntClient(client interface{}) {
-	DefaultSite.mu.Lock()
-	defer DefaultSite.mu.Unlock()
-	DefaultSite.entClient = client
+	DefaultSite.SetEntClient(client)
+}
+
+// SetEntClient sets the Ent client for database operations. Handlers read the
+// client at request time, so it can be set after routes are registered.
+func (s *Site) SetEntClient(client interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entClient = client
+}
+
+// EntClient returns the site's Ent client, or nil if none has been set
+func (s *Site) EntClient() interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.entClient
 }
 
 // Register registers a model with its admin configuration
@@ -76,11 +116,57 @@ func (s *Site) Register(model interface{}, admin *ModelAdmin) error {
 	}
 	admin.model = model
 	admin.modelName = modelName
+	if err := admin.validateFieldsets(); err != nil {
+		return err
+	}
+	if err := admin.validateListEditable(); err != nil {
+		return err
+	}
+	admin.applyPaginationDefaults(s.listPerPage, s.maxPageSize)
+	admin.applyOmitEmptyDefault(s.omitEmptyFields)
+	admin.auditLog = s.auditLog
+	admin.location = s.location
+	admin.mediaRoot = s.mediaRoot
+	admin.mediaURL = s.mediaURL
+	admin.applyMediaURL()
+	s.wireRelationFilters(admin)
 
 	s.models[modelName] = admin
 	return nil
 }
 
+// SetPaginationDefaults sets the site-wide page size and maximum page size.
+// They apply to registered and future models that don't override them.
+// Non-positive values leave the corresponding default unchanged.
+func (s *Site) SetPaginationDefaults(listPerPage, maxPageSize int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if listPerPage > 0 {
+		s.listPerPage = listPerPage
+	}
+	if maxPageSize > 0 {
+		s.maxPageSize = maxPageSize
+	}
+	
+	for _, admin := range s.models {
+		admin.applyPaginationDefaults(s.listPerPage, s.maxPageSize)
+	}
+}
+
+// SetOmitEmptyFields sets whether serialized objects leave out null and
+// empty fields. It applies to registered and future models that don't
+// override it.
+func (s *Site) SetOmitEmptyFields(omit bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	s.omitEmptyFields = omit
+	for _, admin := range s.models {
+		admin.applyOmitEmptyDefault(omit)
+	}
+}
+
 // Unregister removes a model from the admin site
 func (s *Site) Unregister(model interface{}) {
 	s.mu.Lock()
@@ -111,6 +197,31 @@ func (s *Site) GetRegisteredModels() []string {
 	return models
 }
 
+// SetAssets serves the React admin from fsys instead of from disk, e.g. an
+// embedded copy of its build for single-binary deploys. fsys holds the
+// build's index.html and assets directory, and optionally a static
+// directory. Call it before SetupRoutes. It returns an error when fsys
+// has no index.html.
+func (s *Site) SetAssets(fsys fs.FS) error {
+	if _, err := fs.Stat(fsys, "index.html"); err != nil {
+		return fmt.Errorf("invalid admin assets: %w", err)
+	}
+	dirs := make(map[string]fs.FS, 2)
+	for _, dir := range []string{"static", "assets"} {
+		sub, err := fs.Sub(fsys, dir)
+		if err != nil {
+			return fmt.Errorf("invalid admin assets %s: %w", dir, err)
+		}
+		dirs[dir] = sub
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assets = fsys
+	s.assetDirs = dirs
+	return nil
+}
+
 // SetupRoutes configures admin routes with the given Gin router
 func (s *Site) SetupRoutes(router gin.IRouter) {
 	adminGroup := router.Group("/admin")
@@ -118,9 +229,18 @@ func (s *Site) SetupRoutes(router gin.IRouter) {
 	// Setup basic API routes for testing
 	s.setupBasicAPIRoutes(adminGroup)
 	
-	// Static files for React admin (using relative path from project root)
-	adminGroup.StaticFS("/static", http.Dir("../../pkg/gojango/admin/templates/static"))
-	adminGroup.StaticFS("/assets", http.Dir("../../pkg/gojango/admin/templates/dist/assets"))
+	// Static files for React admin, embedded or using relative path from
+	// project root
+	s.mu.RLock()
+	assetDirs := s.assetDirs
+	s.mu.RUnlock()
+	if assetDirs != nil {
+		adminGroup.StaticFS("/static", http.FS(assetDirs["static"]))
+		adminGroup.StaticFS("/assets", http.FS(assetDirs["assets"]))
+	} else {
+		adminGroup.StaticFS("/static", http.Dir("../../pkg/gojango/admin/templates/static"))
+		adminGroup.StaticFS("/assets", http.Dir("../../pkg/gojango/admin/templates/dist/assets"))
+	}
 	
 	// Serve the React app for specific admin paths (avoid conflicts with /api)
 	adminGroup.GET("/", s.handleReactApp)
@@ -151,18 +271,41 @@ func (s *Site) setupBasicAPIRoutes(adminGroup gin.IRouter) {
 	// Models endpoint  
 	apiGroup.GET("/models/", s.handleAPIModelsList)
 	
+	// Dashboard of the admin index
+	apiGroup.GET("/dashboard/", s.handleAPIDashboard)
+	
+	// Audit log of admin actions
+	apiGroup.GET("/log/", s.handleAPILog)
+	
+	// Live updates for model lists, as Server-Sent Events
+	apiGroup.GET("/stream/:app/:model", s.handleAPIModelStream)
+	
+	// Date hierarchy drill-down of model lists
+	apiGroup.GET("/date_hierarchy/:app/:model", s.handleAPIDateHierarchy)
+	
+	// Saving edits made in model lists
+	apiGroup.POST("/list_edit/:app/:model", s.handleAPIListEdit)
+	
+	// Importing rows from CSV and Excel files
+	apiGroup.POST("/import/:app/:model/preview", s.handleAPIImportPreview)
+	apiGroup.POST("/import/:app/:model", s.handleAPIImport)
+	
+	// Adding and changing objects from JSON or multipart forms, which may
+	// upload files
+	apiGroup.POST("/objects/:app/:model", s.handleAPIModelCreate)
+	apiGroup.POST("/objects/:app/:model/:id", s.handleAPIModelUpdate)
+	
 	// gRPC-Web endpoints for Connect protocol  
 	if routerGroup, ok := adminGroup.(*gin.RouterGroup); ok {
 		s.registerConnectHandlers(routerGroup)
 	}
 }
 
-// registerConnectHandlers registers the Connect-Web gRPC handlers
-func (s *Site) registerConnectHandlers(group *gin.RouterGroup) {
-	// Create the gRPC service handler with Ent client
-	bridge := NewEntBridge(s.entClient)
-	handler := NewAdminServiceHandler(s, bridge)
-	handler.SetEntClient(s.entClient)
+// registerConnectHandlers registers the Connect-Web gRPC handlers. The
+// handler reads the Ent client from the site on each request, so a client set
+// after registration is picked up.
+func (s *Site) registerConnectHandlers(group *gin.RouterGroup) *AdminServiceHandler {
+	handler := NewAdminServiceHandler(s, nil)
 	
 	// Create the Connect service
 	path, connectHandler := protoconnect.NewAdminServiceHandler(handler)
@@ -171,21 +314,30 @@ func (s *Site) registerConnectHandlers(group *gin.RouterGroup) {
 	// Connect uses POST requests for all RPCs
 	group.POST(path+"*method", gin.WrapH(connectHandler))
 	group.GET(path+"*method", gin.WrapH(connectHandler))  // For some Connect clients
+	
+	return handler
 }
 
 // handleReactApp serves the React admin application
 func (s *Site) handleReactApp(c *gin.Context) {
 	// Read and serve the built index.html file
-	indexPath := "../../pkg/gojango/admin/templates/dist/index.html"
-	htmlContent, err := os.ReadFile(indexPath)
+	s.mu.RLock()
+	assets := s.assets
+	s.mu.RUnlock()
+	
+	var htmlContent []byte
+	var err error
+	if assets != nil {
+		htmlContent, err = fs.ReadFile(assets, "index.html")
+	} else {
+		htmlContent, err = os.ReadFile("../../pkg/gojango/admin/templates/dist/index.html")
+	}
 	if err != nil {
-		c.String(http.StatusInternalServerError, "Failed to load admin interface: %v", err)
+		render.String(c, http.StatusInternalServerError, "Failed to load admin interface: %v", err)
 		return
 	}
 
-	c.Header("Content-Type", "text/html; charset=utf-8")
-	c.Status(http.StatusOK)
-	c.Writer.Write(htmlContent)
+	render.HTML(c, http.StatusOK, string(htmlContent))
 }
 
 func (s *Site) handleModelList(c *gin.Context) {
@@ -195,8 +347,7 @@ func (s *Site) handleModelList(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.HTML(http.StatusNotFound, "", gin.H{})
-		c.Writer.WriteString(fmt.Sprintf(`
+		render.HTML(c, http.StatusNotFound, fmt.Sprintf(`
 		<html><head><title>Model Not Found</title></head><body>
 		<h1>Model Not Found</h1>
 		<p>The model "%s" was not found.</p>
@@ -365,7 +516,7 @@ func (s *Site) handleModelList(c *gin.Context) {
 </body>
 </html>`
 	
-	c.Header("Content-Type", "text/html; charset=utf-8")
+	c.Header("Content-Type", render.ContentType("text/html"))
 	c.Status(http.StatusOK)
 	// Generate complete sidebar navigation with all models
 	currentModelKey := fmt.Sprintf("%s.%s", app, model)
@@ -426,11 +577,11 @@ func (s *Site) handleModelAdd(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
 		return
 	}
 	
-	c.HTML(http.StatusOK, "admin/change_form.html", gin.H{
+	render.Template(c, http.StatusOK, "admin/change_form.html", gin.H{
 		"admin": admin,
 		"app":   app,
 		"model": model,
@@ -445,18 +596,23 @@ func (s *Site) handleModelCreate(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+	
+	if !s.canAdd(CurrentUser(c), modelKey) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
 		return
 	}
 	
 	// Create new instance through model admin
 	obj, err := admin.CreateObject(c, c.Request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondWithError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusCreated, gin.H{"object": obj})
+	render.JSON(c, http.StatusCreated, gin.H{"object": admin.SerializeObject(obj), "widgets": admin.ObjectWidgets(obj)})
 }
 
 func (s *Site) handleModelDetail(c *gin.Context) {
@@ -467,22 +623,35 @@ func (s *Site) handleModelDetail(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
 		return
 	}
 	
 	obj, err := admin.GetObject(c, id)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Object not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Object not found"})
 		return
 	}
 	
-	c.HTML(http.StatusOK, "admin/change_form.html", gin.H{
-		"admin":  admin,
-		"object": obj,
-		"app":    app,
-		"model":  model,
-		"isAdd":  false,
+	if !s.canView(CurrentUser(c), obj) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+	
+	inlines, err := admin.GetInlineObjects(c, id)
+	if err != nil {
+		render.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	
+	render.Template(c, http.StatusOK, "admin/change_form.html", gin.H{
+		"admin":   admin,
+		"object":  obj,
+		"widgets": admin.ObjectWidgets(obj),
+		"inlines": inlines,
+		"app":     app,
+		"model":   model,
+		"isAdd":   false,
 	})
 }
 
@@ -499,17 +668,34 @@ func (s *Site) handleModelUpdate(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+	
+	user := CurrentUser(c)
+	if !s.canChange(user, admin.model) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+	
+	// Row-level check against the stored object, e.g. for ownership
+	existing, err := admin.GetObject(c, id)
+	if err != nil {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Object not found"})
+		return
+	}
+	if !s.canChange(user, existing) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
 		return
 	}
 	
 	obj, err := admin.UpdateObject(c, id, c.Request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		respondWithError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{"object": obj})
+	render.JSON(c, http.StatusOK, gin.H{"object": admin.SerializeObject(obj), "widgets": admin.ObjectWidgets(obj)})
 }
 
 func (s *Site) handleModelDelete(c *gin.Context) {
@@ -520,17 +706,34 @@ func (s *Site) handleModelDelete(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+	
+	user := CurrentUser(c)
+	if !s.canDelete(user, admin.model) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
 		return
 	}
 	
-	err := admin.DeleteObject(c, id)
+	// Row-level check against the stored object, e.g. for ownership
+	existing, err := admin.GetObject(c, id)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Object not found"})
+		return
+	}
+	if !s.canDelete(user, existing) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+	
+	err = admin.DeleteObject(c, id)
+	if err != nil {
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{"deleted": true})
+	render.JSON(c, http.StatusOK, gin.H{"deleted": true})
 }
 
 func (s *Site) handleBulkAction(c *gin.Context) {
@@ -540,17 +743,37 @@ func (s *Site) handleBulkAction(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+	
+	// Destructive actions run only once the request confirms them
+	if pending, ok := admin.pendingConfirmation(c.Request); ok {
+		render.JSON(c, http.StatusConflict, gin.H{
+			"requires_confirmation": true,
+			"action":                pending.Action,
+			"message":               pending.Message,
+			"count":                 pending.Count,
+		})
 		return
 	}
 	
 	result, err := admin.ExecuteBulkAction(c, c.Request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		if c.Writer.Written() {
+			c.Error(err)
+			return
+		}
+		render.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	// Export actions stream their file as the response
+	if c.Writer.Written() {
 		return
 	}
 	
-	c.JSON(http.StatusOK, result)
+	render.JSON(c, http.StatusOK, result)
 }
 
 // API handlers for React frontend
@@ -576,11 +799,12 @@ func (s *Site) handleAPIModelsList(c *gin.Context) {
 			"list_display":       admin.listDisplay,
 			"search_fields":      admin.searchFields,
 			"list_filter":        admin.listFilter,
+			"list_editable":      admin.listEditable,
 			"permissions":        admin.GetPermissions(c),
 		}
 	}
 	
-	c.JSON(http.StatusOK, gin.H{
+	render.JSON(c, http.StatusOK, gin.H{
 		"models": models,
 		"site": gin.H{
 			"name":         s.name,
@@ -597,17 +821,17 @@ func (s *Site) handleAPIModelData(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
 		return
 	}
 	
 	data, err := admin.GetAPIData(c, c.Request.URL.Query())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		render.JSON(c, http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 	
-	c.JSON(http.StatusOK, data)
+	render.JSON(c, http.StatusOK, data)
 }
 
 func (s *Site) handleAPIModelSchema(c *gin.Context) {
@@ -617,12 +841,12 @@ func (s *Site) handleAPIModelSchema(c *gin.Context) {
 	
 	admin, exists := s.GetModelAdmin(modelKey)
 	if !exists {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Model not found"})
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
 		return
 	}
 	
 	schema := admin.GetSchema()
-	c.JSON(http.StatusOK, schema)
+	render.JSON(c, http.StatusOK, schema)
 }
 
 func (s *Site) handleAPIModelCreate(c *gin.Context) {
@@ -638,6 +862,34 @@ func (s *Site) handleAPIModelDelete(c *gin.Context) {
 }
 
 // Helper functions
+
+// respondWithError writes an error response, returning per-field messages for
+// validation errors, 409 Conflict for updates of stale objects and 413 for
+// bodies over the limit of middleware.MaxBodySize
+func respondWithError(c *gin.Context, err error) {
+	if errors.Is(err, ErrStaleObject) {
+		render.JSON(c, http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		render.JSON(c, http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large"})
+		return
+	}
+	
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		render.JSON(c, http.StatusBadRequest, gin.H{
+			"error":  "Validation failed",
+			"errors": validationErr.Errors,
+		})
+		return
+	}
+	
+	render.JSON(c, http.StatusBadRequest, gin.H{"error": err.Error()})
+}
+
 func getModelName(model interface{}) string {
 	if model == nil {
 		return ""
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/epuerta9/gojango/pkg/gojango/routing"
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
+	"github.com/gin-gonic/gin"
+)
+
+// streamBuffer is how many change events may queue for a slow client before
+// further events are dropped. Events only tell the client to refresh, so
+// dropping some while a refresh is pending loses nothing.
+const streamBuffer = 16
+
+// ChangeEvent is sent on a model's live-update stream when an object is
+// created, updated or deleted
+type ChangeEvent struct {
+	Action string `json:"action"` // "created", "updated" or "deleted"
+	Model  string `json:"model"`  // app.model
+	ID     string `json:"id,omitempty"`
+}
+
+// handleAPIModelStream streams a ChangeEvent for every change to a model as
+// Server-Sent Events, so the admin UI can refresh lists as they change
+func (s *Site) handleAPIModelStream(c *gin.Context) {
+	modelKey := fmt.Sprintf("%s.%s", c.Param("app"), c.Param("model"))
+
+	admin, exists := s.GetModelAdmin(modelKey)
+	if !exists {
+		render.JSON(c, http.StatusNotFound, gin.H{"error": "Model not found"})
+		return
+	}
+
+	if !s.canView(CurrentUser(c), admin.model) {
+		render.JSON(c, http.StatusForbidden, gin.H{"error": "Permission denied"})
+		return
+	}
+
+	events := make(chan routing.Event, streamBuffer)
+	notify := func(change ChangeEvent) {
+		select {
+		case events <- routing.Event{Event: "change", Data: change}:
+		default:
+		}
+	}
+
+	saved := signals.PostSave.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		if !admin.isSender(sender) {
+			return nil
+		}
+		change := ChangeEvent{Action: "updated", Model: modelKey, ID: changedObjectID(kwargs)}
+		if created, _ := kwargs["created"].(bool); created {
+			change.Action = "created"
+		}
+		notify(change)
+		return nil
+	})
+	defer signals.PostSave.Disconnect(saved)
+
+	deleted := signals.PostDelete.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		if admin.isSender(sender) {
+			notify(ChangeEvent{Action: "deleted", Model: modelKey, ID: changedObjectID(kwargs)})
+		}
+		return nil
+	})
+	defer signals.PostDelete.Disconnect(deleted)
+
+	routing.SSE(func(stream *routing.EventStream) {
+		stream.Stream(events)
+	})(c)
+}
+
+// isSender reports whether a signal was sent for this admin's model
+func (ma *ModelAdmin) isSender(sender interface{}) bool {
+	return ma.model != nil && reflect.TypeOf(sender) == reflect.TypeOf(ma.model)
+}
+
+// changedObjectID returns the id of the object a save or delete signal is
+// about, from its id or, for newly created objects, its instance
+func changedObjectID(kwargs map[string]interface{}) string {
+	if id, ok := kwargs["id"]; ok {
+		return fmt.Sprintf("%v", id)
+	}
+	if instance, ok := kwargs["instance"]; ok {
+		if id, err := extractObjectID(instance); err == nil {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,142 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readChange reads SSE frames until a change event arrives
+func readChange(t *testing.T, reader *bufio.Reader) ChangeEvent {
+	t.Helper()
+	var name, data string
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "" && name != "":
+			assert.Equal(t, "change", name)
+			var change ChangeEvent
+			require.NoError(t, json.Unmarshal([]byte(data), &change))
+			return change
+		}
+	}
+}
+
+func TestModelStreamSendsChanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	admin := NewModelAdmin(&TestUser{})
+	admin.SetDatabaseInterface(newMockDBInterface())
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	modelKey := getModelName(&TestUser{})
+
+	router := gin.New()
+	router.GET("/admin/api/stream/:app/:model", site.handleAPIModelStream)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	url := server.URL + "/admin/api/stream/" + strings.Replace(modelKey, ".", "/", 1)
+	req, _ := http.NewRequestWithContext(ctx, "GET", url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	changes := make(chan ChangeEvent, 2)
+	go func() {
+		reader := bufio.NewReader(resp.Body)
+		for i := 0; i < 2; i++ {
+			changes <- readChange(t, reader)
+		}
+	}()
+
+	gc, _ := gin.CreateTestContext(httptest.NewRecorder())
+	create := httptest.NewRequest("POST", "/", strings.NewReader("username=john&email=john@example.com"))
+	create.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	_, err = admin.CreateObject(gc, create)
+	require.NoError(t, err)
+	require.NoError(t, admin.DeleteObject(gc, "1"))
+
+	for _, expected := range []ChangeEvent{
+		{Action: "created", Model: modelKey},
+		{Action: "deleted", Model: modelKey, ID: "1"},
+	} {
+		select {
+		case change := <-changes:
+			assert.Equal(t, expected.Action, change.Action)
+			assert.Equal(t, expected.Model, change.Model)
+			if expected.ID != "" {
+				assert.Equal(t, expected.ID, change.ID)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected a %s event", expected.Action)
+		}
+	}
+}
+
+func TestModelStreamIgnoresOtherModels(t *testing.T) {
+	admin := NewModelAdmin(&TestUser{})
+	assert.True(t, admin.isSender(&TestUser{}))
+	assert.False(t, admin.isSender(&struct{ ID int }{}))
+	assert.False(t, admin.isSender(nil))
+}
+
+func TestModelStreamUnknownModel(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	router := gin.New()
+	router.GET("/admin/api/stream/:app/:model", site.handleAPIModelStream)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/admin/api/stream/blog/missing", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+// userViewPermissions lets users view TestUser objects only, checking the
+// type of the model it is given
+type userViewPermissions struct {
+	AllowAllPermissions
+}
+
+func (userViewPermissions) HasViewPermission(user interface{}, obj interface{}) bool {
+	_, ok := obj.(*TestUser)
+	return ok
+}
+
+func TestModelStreamChecksModelPermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	site := NewSite("test")
+	site.SetPermissionChecker(userViewPermissions{})
+	require.NoError(t, site.Register(&TestUser{}, NewModelAdmin(&TestUser{})))
+	require.NoError(t, site.Register(&TestPost{}, NewModelAdmin(&TestPost{})))
+	router := gin.New()
+	router.GET("/admin/api/stream/:app/:model", site.handleAPIModelStream)
+
+	// A closed request ends the stream right after the permission check
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for model, expected := range map[interface{}]int{&TestUser{}: http.StatusOK, &TestPost{}: http.StatusForbidden} {
+		path := "/admin/api/stream/" + strings.Replace(getModelName(model), ".", "/", 1)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil).WithContext(ctx))
+		assert.Equal(t, expected, w.Code, path)
+	}
+}
@@ -0,0 +1,318 @@
+package admin
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango/admin/widgets"
+)
+
+// Media defaults used when the site doesn't configure MEDIA_ROOT and MEDIA_URL
+const (
+	DefaultMediaRoot = "media"
+	DefaultMediaURL  = "/media/"
+)
+
+// fileWidget is implemented by widgets of uploaded files, such as
+// widgets.FileInput and widgets.ImageField
+type fileWidget interface {
+	widgets.Widget
+	Accept() string
+	Accepts(filename, contentType string) bool
+	SetMediaURL(url string)
+}
+
+// uploadProcessor is implemented by file widgets that derive files from
+// saved uploads, such as the thumbnails of widgets.ImageField
+type uploadProcessor interface {
+	ProcessUpload(root, name string) ([]string, error)
+}
+
+// SetWidget sets the widget a field is edited with. Files uploaded to
+// fields with a widgets.FileInput or widgets.ImageField are saved under the
+// site's media root, and the field stores their path relative to it.
+func (ma *ModelAdmin) SetWidget(field string, widget widgets.Widget) *ModelAdmin {
+	if ma.fieldWidgets == nil {
+		ma.fieldWidgets = make(map[string]widgets.Widget)
+	}
+	ma.fieldWidgets[field] = widget
+	ma.applyMediaURL()
+	return ma
+}
+
+// applyMediaURL tells file widgets the URL uploads are served at
+func (ma *ModelAdmin) applyMediaURL() {
+	for _, widget := range ma.fieldWidgets {
+		if fw, ok := widget.(fileWidget); ok {
+			fw.SetMediaURL(ma.mediaURL)
+		}
+	}
+}
+
+// Widget returns the widget set for a field
+func (ma *ModelAdmin) Widget(field string) (widgets.Widget, bool) {
+	widget, ok := ma.fieldWidgets[field]
+	return widget, ok
+}
+
+// widgetConfig renders the widget of a field for the schema, nil when the
+// field has none
+func (ma *ModelAdmin) widgetConfig(field string) *widgets.WidgetConfig {
+	widget, ok := ma.fieldWidgets[field]
+	if !ok {
+		return nil
+	}
+	config := widget.Render(field, nil, nil)
+	return &config
+}
+
+// ObjectWidgets renders the widgets of an object's fields with its values,
+// e.g. for links to uploaded files and previews of their thumbnails. It
+// returns nil when no field has a widget.
+func (ma *ModelAdmin) ObjectWidgets(obj interface{}) map[string]widgets.WidgetConfig {
+	if len(ma.fieldWidgets) == 0 {
+		return nil
+	}
+	fields, _ := objectFields(obj)
+	configs := make(map[string]widgets.WidgetConfig, len(ma.fieldWidgets))
+	for field, widget := range ma.fieldWidgets {
+		configs[field] = widget.Render(field, fields[field], nil)
+	}
+	return configs
+}
+
+// objectsWidgets applies ObjectWidgets to each object, nil when no field
+// has a widget
+func (ma *ModelAdmin) objectsWidgets(objects []interface{}) []map[string]widgets.WidgetConfig {
+	if len(ma.fieldWidgets) == 0 {
+		return nil
+	}
+	configs := make([]map[string]widgets.WidgetConfig, len(objects))
+	for i, obj := range objects {
+		configs[i] = ma.ObjectWidgets(obj)
+	}
+	return configs
+}
+
+// saveUploads streams the files uploaded to file fields into the media
+// root and replaces them in data with their paths relative to it. Files of
+// types a widget doesn't accept fail validation. It returns the paths
+// saved, for removeUploads to clean up when saving the object fails.
+func (ma *ModelAdmin) saveUploads(data map[string]interface{}) ([]string, error) {
+	var saved []string
+	validationErr := NewValidationError()
+	for field, widget := range ma.fieldWidgets {
+		fw, ok := widget.(fileWidget)
+		if !ok {
+			continue
+		}
+		header, ok := data[field].(*multipart.FileHeader)
+		if !ok {
+			continue
+		}
+
+		name, err := ma.saveUpload(fw, header)
+		if errors.Is(err, errFileType) {
+			validationErr.Add(field, fmt.Sprintf("Upload a file of an accepted type (%s).", fw.Accept()))
+			continue
+		}
+		if err != nil {
+			ma.removeUploads(saved)
+			return nil, fmt.Errorf("failed to save %s: %w", field, err)
+		}
+		saved = append(saved, name)
+		data[field] = name
+
+		if processor, ok := widget.(uploadProcessor); ok {
+			derived, err := processor.ProcessUpload(ma.mediaRoot, name)
+			if errors.Is(err, widgets.ErrInvalidImage) {
+				validationErr.Add(field, "Upload a valid image. The file you uploaded was either not an image or a corrupted image.")
+				continue
+			}
+			if err != nil {
+				ma.removeUploads(saved)
+				return nil, fmt.Errorf("failed to process %s: %w", field, err)
+			}
+			saved = append(saved, derived...)
+		}
+	}
+
+	if validationErr.HasErrors() {
+		ma.removeUploads(saved)
+		return nil, validationErr
+	}
+	return saved, nil
+}
+
+// errFileType reports an upload of a type its widget doesn't accept
+var errFileType = errors.New("file type not accepted")
+
+// saveUpload checks an uploaded file against its widget and copies it to a
+// new file in the model's upload directory, returning its relative path
+func (ma *ModelAdmin) saveUpload(widget fileWidget, header *multipart.FileHeader) (string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	// The content type is sniffed rather than taken on the client's word
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	head = head[:n]
+	if !widget.Accepts(header.Filename, uploadContentType(head, header.Header.Get("Content-Type"))) {
+		return "", errFileType
+	}
+
+	dir := ma.uploadDir()
+	out, name, err := createUploadFile(filepath.Join(ma.mediaRoot, filepath.FromSlash(dir)), header.Filename)
+	if err != nil {
+		return "", err
+	}
+	_, err = io.Copy(out, io.MultiReader(bytes.NewReader(head), file))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(out.Name())
+		return "", err
+	}
+	return path.Join(dir, name), nil
+}
+
+// uploadContentType returns the content type sniffed from the start of a
+// file, or the type the client declared when nothing could be sniffed or
+// it is a more specific type of the same kind, e.g. text/csv for text/plain
+func uploadContentType(head []byte, declared string) string {
+	sniffed := http.DetectContentType(head)
+	declaredType, _, err := mime.ParseMediaType(declared)
+	if err != nil {
+		return sniffed
+	}
+	sniffedType, _, _ := mime.ParseMediaType(sniffed)
+	if sniffedType == "application/octet-stream" {
+		return declaredType
+	}
+	sniffedKind, _, _ := strings.Cut(sniffedType, "/")
+	declaredKind, _, _ := strings.Cut(declaredType, "/")
+	if sniffedKind == declaredKind {
+		return declaredType
+	}
+	return sniffedType
+}
+
+// uploadDir returns the directory files uploaded to the model are saved in,
+// relative to the media root, e.g. blog/post
+func (ma *ModelAdmin) uploadDir() string {
+	app, model, found := strings.Cut(ma.modelName, ".")
+	if !found || app == "" || model == "" {
+		return "uploads"
+	}
+	return path.Join(app, model)
+}
+
+// createUploadFile creates a file in dir named after an uploaded file,
+// adding a random suffix when the name is taken
+func createUploadFile(dir, filename string) (*os.File, string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, "", err
+	}
+
+	name := cleanUploadName(filename)
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for attempt := 0; ; attempt++ {
+		candidate := name
+		if attempt > 0 {
+			suffix := make([]byte, 4)
+			if _, err := rand.Read(suffix); err != nil {
+				return nil, "", err
+			}
+			candidate = base + "_" + hex.EncodeToString(suffix) + ext
+		}
+		out, err := os.OpenFile(filepath.Join(dir, candidate), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) && attempt < 10 {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return out, candidate, nil
+	}
+}
+
+// cleanUploadName reduces the name of an uploaded file to its base name of
+// ASCII letters, digits, dots, hyphens and underscores
+func cleanUploadName(filename string) string {
+	// Browsers on Windows may send full paths
+	filename = filename[strings.LastIndexAny(filename, `/\`)+1:]
+
+	var b strings.Builder
+	for _, r := range strings.TrimSpace(filename) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('_')
+		}
+	}
+	name := strings.TrimLeft(b.String(), ".")
+	if name == "" {
+		return "upload"
+	}
+	return name
+}
+
+// removeUploads removes saved uploads of an object that failed to save
+func (ma *ModelAdmin) removeUploads(names []string) {
+	for _, name := range names {
+		if err := os.Remove(filepath.Join(ma.mediaRoot, filepath.FromSlash(name))); err != nil && !os.IsNotExist(err) {
+			log.Printf("Failed to remove upload %s: %v", name, err)
+		}
+	}
+}
+
+// SetMedia sets the directory uploaded files are saved in and the URL they
+// are served at, for registered and future models. Serving the directory is
+// left to the application, which serves MEDIA_ROOT at MEDIA_URL.
+func (s *Site) SetMedia(root, url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mediaRoot = root
+	s.mediaURL = url
+	for _, admin := range s.models {
+		admin.mediaRoot = root
+		admin.mediaURL = url
+		admin.applyMediaURL()
+	}
+}
+
+// MediaRoot returns the directory uploaded files are saved in
+func (s *Site) MediaRoot() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mediaRoot
+}
+
+// MediaURL returns the URL uploaded files are served at
+func (s *Site) MediaURL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.mediaURL
+}
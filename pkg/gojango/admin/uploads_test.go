@@ -0,0 +1,314 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/admin/widgets"
+	"github.com/epuerta9/gojango/pkg/gojango/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pngHeader is the signature PNG files are sniffed by
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+// newUploadSite returns a site saving uploads in a temporary media root,
+// with TestUser's avatar edited with a file input accepting accept
+func newUploadSite(t *testing.T, accept string) (*Site, string) {
+	t.Helper()
+	root := t.TempDir()
+	site := NewSite("test")
+	site.SetMedia(root, "/media/")
+
+	admin := newValidationAdmin(
+		FieldSchema{Name: "username", Type: "string", Required: true},
+		FieldSchema{Name: "avatar", Type: "string"},
+	)
+	admin.SetWidget("avatar", widgets.NewFileInput().SetAccept(accept))
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	return site, root
+}
+
+// newUploadRouter returns a router serving the site's routes behind the
+// given middleware
+func newUploadRouter(site *Site, middleware ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware...)
+	site.SetupRoutes(router)
+	return router
+}
+
+// newUploadRequest builds a multipart request adding a user with a file
+func newUploadRequest(t *testing.T, filename, contentType string, content []byte) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	require.NoError(t, writer.WriteField("username", "bob"))
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="avatar"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	path := "/admin/api/objects/" + strings.Replace(getModelName(&TestUser{}), ".", "/", 1)
+	req := httptest.NewRequest("POST", path, &buf)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadSavesFile(t *testing.T) {
+	site, root := newUploadSite(t, "image/*,.pdf")
+	router := newUploadRouter(site)
+	content := []byte(pngHeader + "image data")
+
+	saved := make([]string, 2)
+	for i := range saved {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newUploadRequest(t, `C:\photos\my avatar.png`, "image/png", content))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		var response struct {
+			Object map[string]interface{} `json:"object"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		saved[i], _ = response.Object["avatar"].(string)
+	}
+
+	dir := strings.Replace(getModelName(&TestUser{}), ".", "/", 1)
+	assert.Equal(t, dir+"/my_avatar.png", saved[0])
+	assert.NotEqual(t, saved[0], saved[1], "a second upload of the same name should get its own file")
+	assert.True(t, strings.HasPrefix(saved[1], dir+"/my_avatar_") && strings.HasSuffix(saved[1], ".png"), saved[1])
+	for _, name := range saved {
+		stored, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, content, stored)
+	}
+}
+
+func TestUploadRejectsUnacceptedTypes(t *testing.T) {
+	site, root := newUploadSite(t, "image/*,.pdf")
+	router := newUploadRouter(site)
+
+	// The declared type isn't trusted over the content
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, "avatar.png", "image/png", []byte("#!/bin/sh\necho hi\n")))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response struct {
+		Errors map[string]string `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Contains(t, response.Errors["avatar"], "image/*,.pdf")
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "rejected uploads should not be saved")
+
+	// Extensions in accept match by name
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, "resume.pdf", "application/pdf", []byte("%PDF-1.4\n")))
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+func TestUploadBodySizeLimit(t *testing.T) {
+	site, root := newUploadSite(t, "")
+	router := newUploadRouter(site, middleware.MaxBodySize(1024))
+
+	content := bytes.Repeat([]byte("x"), 4096)
+	for _, streamed := range []bool{false, true} {
+		req := newUploadRequest(t, "big.txt", "text/plain", content)
+		if streamed {
+			// Without a Content-Length the limit applies while parsing the form
+			req.ContentLength = -1
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, "streamed: %v", streamed)
+	}
+
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "uploads over the limit should not be saved")
+}
+
+func TestUploadWidgetSchema(t *testing.T) {
+	site, _ := newUploadSite(t, "image/*")
+	admin, ok := site.GetModelAdmin(getModelName(&TestUser{}))
+	require.True(t, ok)
+
+	for _, field := range admin.GetSchema().Fields {
+		if field.Name != "avatar" {
+			assert.Nil(t, field.Widget, field.Name)
+			continue
+		}
+		require.NotNil(t, field.Widget)
+		assert.Equal(t, "file", field.Widget.Type)
+		assert.Equal(t, "image/*", field.Widget.Attributes["accept"])
+		assert.Equal(t, "/media/", field.Widget.Config["media_url"])
+	}
+}
+
+func TestFileInputAccepts(t *testing.T) {
+	input := widgets.NewFileInput().SetAccept("image/*, application/pdf, .CSV")
+	tests := []struct {
+		filename, contentType string
+		accepted              bool
+	}{
+		{"a.png", "image/png", true},
+		{"a.bin", "application/pdf", true},
+		{"data.csv", "text/plain; charset=utf-8", true},
+		{"a.txt", "text/plain; charset=utf-8", false},
+		{"a.png", "application/octet-stream", false},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.accepted, input.Accepts(tc.filename, tc.contentType), "%s %s", tc.filename, tc.contentType)
+	}
+	assert.True(t, widgets.NewFileInput().Accepts("anything", "application/x-anything"))
+}
+
+// encodePNG returns a PNG of the given size, red on the left half and blue
+// on the right
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.NRGBA{B: 255, A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestImageFieldThumbnails(t *testing.T) {
+	root := t.TempDir()
+	site := NewSite("test")
+	site.SetMedia(root, "/media/")
+	admin := newValidationAdmin(FieldSchema{Name: "username", Type: "string"}, FieldSchema{Name: "avatar", Type: "string"})
+	admin.SetWidget("avatar", widgets.NewImageField().AddThumbnail("small", 16, 16).AddThumbnail("banner", 32, 8))
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	router := newUploadRouter(site)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, "photo.png", "image/png", encodePNG(t, 64, 32)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	var response struct {
+		Object  map[string]interface{}          `json:"object"`
+		Widgets map[string]widgets.WidgetConfig `json:"widgets"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	dir := strings.Replace(getModelName(&TestUser{}), ".", "/", 1)
+	assert.Equal(t, dir+"/photo.png", response.Object["avatar"])
+
+	config := response.Widgets["avatar"]
+	assert.Equal(t, "image", config.Type)
+	assert.Equal(t, "/media/"+dir+"/photo.png", config.Config["url"])
+	assert.Equal(t, map[string]interface{}{
+		"small":  "/media/" + dir + "/photo_small.png",
+		"banner": "/media/" + dir + "/photo_banner.png",
+	}, config.Config["thumbnails"])
+
+	for name, size := range map[string]image.Point{"photo_small.png": {16, 16}, "photo_banner.png": {32, 8}} {
+		file, err := os.Open(filepath.Join(root, filepath.FromSlash(dir), name))
+		require.NoError(t, err)
+		thumbnail, err := png.Decode(file)
+		file.Close()
+		require.NoError(t, err)
+		assert.Equal(t, size, thumbnail.Bounds().Size(), name)
+
+		// The thumbnail keeps the red and blue halves of the original
+		r, _, b, _ := thumbnail.At(0, 0).RGBA()
+		assert.True(t, r > 0xf000 && b < 0x1000, "%s: expected red on the left", name)
+		r, _, b, _ = thumbnail.At(size.X-1, size.Y-1).RGBA()
+		assert.True(t, b > 0xf000 && r < 0x1000, "%s: expected blue on the right", name)
+	}
+}
+
+func TestImageFieldKeepsFilesNamedLikeThumbnails(t *testing.T) {
+	root := t.TempDir()
+	site := NewSite("test")
+	site.SetMedia(root, "/media/")
+	admin := newValidationAdmin(FieldSchema{Name: "username", Type: "string"}, FieldSchema{Name: "avatar", Type: "string"})
+	admin.SetWidget("avatar", widgets.NewImageField().AddThumbnail("small", 16, 16))
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	router := newUploadRouter(site)
+
+	// An upload named like the thumbnail of a later one
+	original := encodePNG(t, 8, 8)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, "photo_small.png", "image/png", original))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, "photo.png", "image/png", encodePNG(t, 64, 32)))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	dir := filepath.Join(root, filepath.FromSlash(strings.Replace(getModelName(&TestUser{}), ".", "/", 1)))
+	stored, err := os.ReadFile(filepath.Join(dir, "photo_small.png"))
+	require.NoError(t, err)
+	assert.Equal(t, original, stored, "the earlier upload should not be overwritten")
+
+	thumbnails, err := filepath.Glob(filepath.Join(dir, "photo_small_*.png"))
+	require.NoError(t, err)
+	assert.Len(t, thumbnails, 2, "expected the thumbnails of both uploads under new names")
+}
+
+func TestImageFieldRejectsNonImages(t *testing.T) {
+	root := t.TempDir()
+	site := NewSite("test")
+	site.SetMedia(root, "/media/")
+	admin := newValidationAdmin(FieldSchema{Name: "username", Type: "string"}, FieldSchema{Name: "avatar", Type: "string"})
+	admin.SetWidget("avatar", widgets.NewImageField())
+	require.NoError(t, site.Register(&TestUser{}, admin))
+	router := newUploadRouter(site)
+
+	uploads := map[string][]byte{
+		"notes.png":  []byte("just some text"),
+		"broken.png": []byte(pngHeader + "truncated"),
+	}
+	for filename, content := range uploads {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newUploadRequest(t, filename, "image/png", content))
+		require.Equal(t, http.StatusBadRequest, w.Code, filename)
+
+		var response struct {
+			Errors map[string]string `json:"errors"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.NotEmpty(t, response.Errors["avatar"], filename)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(root, strings.Replace(getModelName(&TestUser{}), ".", "/", 1)))
+	if err == nil {
+		assert.Empty(t, entries, "rejected images should not be saved")
+	}
+
+	// Fields that aren't image-only store other files without thumbnails
+	admin.SetWidget("avatar", widgets.NewImageField().SetAccept("").SetImageOnly(false))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUploadRequest(t, "notes.txt", "text/plain", []byte("just some text")))
+	assert.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
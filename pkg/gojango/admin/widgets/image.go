@@ -0,0 +1,258 @@
+package widgets
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidImage reports an upload to an image-only ImageField that isn't
+// a JPEG or PNG image it can read
+var ErrInvalidImage = errors.New("not a valid JPEG or PNG image")
+
+// maxImagePixels bounds the size of images decoded for thumbnails, so a
+// small file declaring huge dimensions can't exhaust memory
+const maxImagePixels = 50 << 20
+
+// Thumbnail is a size of thumbnail generated for uploaded images
+type Thumbnail struct {
+	Name   string `json:"name"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// DefaultThumbnail is generated by image fields that don't add thumbnails
+var DefaultThumbnail = Thumbnail{Name: "thumb", Width: 150, Height: 150}
+
+// ImageField widget, a file input for images that generates thumbnails of
+// uploaded JPEG and PNG images next to the original
+type ImageField struct {
+	*FileInput
+	thumbnails []Thumbnail
+	imageOnly  bool
+}
+
+// NewImageField creates an image field accepting only JPEG and PNG images
+func NewImageField() *ImageField {
+	return &ImageField{
+		FileInput: NewFileInput().SetAccept("image/jpeg,image/png"),
+		imageOnly: true,
+	}
+}
+
+// SetAccept sets the files the widget accepts, see FileInput.SetAccept
+func (w *ImageField) SetAccept(accept string) *ImageField {
+	w.FileInput.SetAccept(accept)
+	return w
+}
+
+// SetImageOnly sets whether only JPEG and PNG images are accepted. Other
+// files accepted otherwise are stored without thumbnails.
+func (w *ImageField) SetImageOnly(imageOnly bool) *ImageField {
+	w.imageOnly = imageOnly
+	return w
+}
+
+// AddThumbnail adds a size of thumbnail to generate, named for its file and
+// in the widget config. Thumbnails are scaled to cover width x height and
+// cropped to it evenly from both sides.
+func (w *ImageField) AddThumbnail(name string, width, height int) *ImageField {
+	w.thumbnails = append(w.thumbnails, Thumbnail{Name: name, Width: width, Height: height})
+	return w
+}
+
+// Thumbnails returns the sizes of thumbnails generated, DefaultThumbnail
+// when none were added
+func (w *ImageField) Thumbnails() []Thumbnail {
+	if len(w.thumbnails) == 0 {
+		return []Thumbnail{DefaultThumbnail}
+	}
+	return append([]Thumbnail(nil), w.thumbnails...)
+}
+
+// ThumbnailPath returns the path of a thumbnail of an uploaded image, next
+// to it with the thumbnail's name appended, e.g. blog/cover_thumb.png.
+// ProcessUpload adds a random suffix when another file has that path.
+func (w *ImageField) ThumbnailPath(name string, thumbnail Thumbnail) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "_" + thumbnail.Name + ext
+}
+
+// Accepts reports whether a file matches the widget's accept list and, for
+// image-only fields, is a JPEG or PNG image
+func (w *ImageField) Accepts(filename, contentType string) bool {
+	if w.imageOnly {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType != "image/jpeg" && mediaType != "image/png" {
+			return false
+		}
+	}
+	return w.FileInput.Accepts(filename, contentType)
+}
+
+func (w *ImageField) Render(name string, value interface{}, attrs map[string]interface{}) WidgetConfig {
+	config := w.FileInput.Render(name, value, attrs)
+	config.Type = "image"
+	config.Config["thumbnail_sizes"] = w.Thumbnails()
+
+	if path, ok := value.(string); ok && path != "" {
+		urls := make(map[string]string)
+		for _, thumbnail := range w.Thumbnails() {
+			urls[thumbnail.Name] = w.URL(w.ThumbnailPath(path, thumbnail))
+		}
+		config.Config["thumbnails"] = urls
+	}
+	return config
+}
+
+// ProcessUpload generates the thumbnails of an image saved at name under
+// root and returns their paths. Files that aren't images are left alone,
+// unless the field is image-only, where they fail with ErrInvalidImage.
+func (w *ImageField) ProcessUpload(root, name string) ([]string, error) {
+	file, err := os.Open(filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config, format, err := image.DecodeConfig(file)
+	if err != nil || (format != "jpeg" && format != "png") {
+		if w.imageOnly {
+			return nil, ErrInvalidImage
+		}
+		return nil, nil
+	}
+	if config.Width*config.Height > maxImagePixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels is too large", ErrInvalidImage, config.Width, config.Height)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	var created []string
+	for _, thumbnail := range w.Thumbnails() {
+		thumbPath, err := writeThumbnail(root, w.ThumbnailPath(name, thumbnail), format, img, thumbnail)
+		if err != nil {
+			for _, path := range created {
+				os.Remove(filepath.Join(root, filepath.FromSlash(path)))
+			}
+			return nil, fmt.Errorf("failed to create thumbnail %s: %w", thumbnail.Name, err)
+		}
+		created = append(created, thumbPath)
+	}
+	return created, nil
+}
+
+// writeThumbnail writes a thumbnail of img to a new file at name under
+// root, in the format of the original, and returns its path. A file already
+// at name, such as another upload named like a thumbnail, is left alone and
+// the thumbnail gets a random suffix instead.
+func writeThumbnail(root, name, format string, img image.Image, thumbnail Thumbnail) (string, error) {
+	if thumbnail.Width <= 0 || thumbnail.Height <= 0 {
+		return "", fmt.Errorf("invalid size %dx%d", thumbnail.Width, thumbnail.Height)
+	}
+	scaled := scaleToCover(img, thumbnail.Width, thumbnail.Height)
+
+	out, name, err := createThumbnailFile(root, name)
+	if err != nil {
+		return "", err
+	}
+	if format == "jpeg" {
+		err = jpeg.Encode(out, scaled, &jpeg.Options{Quality: 85})
+	} else {
+		err = png.Encode(out, scaled)
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(out.Name())
+		return "", err
+	}
+	return name, nil
+}
+
+// createThumbnailFile creates a file at name under root, adding a random
+// suffix when the name is taken
+func createThumbnailFile(root, name string) (*os.File, string, error) {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for attempt := 0; ; attempt++ {
+		candidate := name
+		if attempt > 0 {
+			suffix := make([]byte, 4)
+			if _, err := rand.Read(suffix); err != nil {
+				return nil, "", err
+			}
+			candidate = base + "_" + hex.EncodeToString(suffix) + ext
+		}
+		out, err := os.OpenFile(filepath.Join(root, filepath.FromSlash(candidate)), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, os.ErrExist) && attempt < 10 {
+			continue
+		}
+		if err != nil {
+			return nil, "", err
+		}
+		return out, candidate, nil
+	}
+}
+
+// scaleToCover scales the largest centered region of img with the aspect
+// ratio of width x height to that size. Each pixel averages the source
+// pixels it covers, or takes the nearest one when enlarging.
+func scaleToCover(img image.Image, width, height int) *image.NRGBA {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	cropWidth, cropHeight := srcWidth, srcWidth*height/width
+	if cropHeight > srcHeight {
+		cropWidth, cropHeight = srcHeight*width/height, srcHeight
+	}
+	cropWidth, cropHeight = max(cropWidth, 1), max(cropHeight, 1)
+	left := bounds.Min.X + (srcWidth-cropWidth)/2
+	top := bounds.Min.Y + (srcHeight-cropHeight)/2
+
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := top + y*cropHeight/height
+		y1 := max(top+(y+1)*cropHeight/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := left + x*cropWidth/width
+			x1 := max(left+(x+1)*cropWidth/width, x0+1)
+
+			// Colors are premultiplied by alpha, so they average directly
+			var r, g, b, a uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+				}
+			}
+			if a == 0 {
+				continue
+			}
+			n := uint64((x1 - x0) * (y1 - y0))
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r * 0xffff / a >> 8),
+				G: uint8(g * 0xffff / a >> 8),
+				B: uint8(b * 0xffff / a >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
@@ -7,8 +7,11 @@ package widgets
 
 import (
 	"fmt"
+	"mime"
+	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -474,7 +477,8 @@ func (w *DateTimeInput) Render(name string, value interface{}, attrs map[string]
 // FileInput widget
 type FileInput struct {
 	*BaseWidget
-	accept string
+	accept   string
+	mediaURL string
 }
 
 // NewFileInput creates a new file input widget
@@ -484,11 +488,66 @@ func NewFileInput() *FileInput {
 	}
 }
 
+// SetAccept sets the files the widget accepts, in the format of the HTML
+// accept attribute: a comma-separated list of extensions such as .pdf, MIME
+// types such as application/pdf, and wildcards such as image/*
 func (w *FileInput) SetAccept(accept string) *FileInput {
 	w.accept = accept
 	return w
 }
 
+// SetMediaURL sets the URL uploaded files are served at, which the admin
+// sets to its MEDIA_URL
+func (w *FileInput) SetMediaURL(url string) {
+	w.mediaURL = url
+}
+
+// URL returns the URL of an uploaded file from its path relative to the
+// media root
+func (w *FileInput) URL(name string) string {
+	if w.mediaURL == "" {
+		return name
+	}
+	return strings.TrimSuffix(w.mediaURL, "/") + "/" + strings.TrimPrefix(name, "/")
+}
+
+// Accept returns the files the widget accepts, "" for any file
+func (w *FileInput) Accept() string {
+	return w.accept
+}
+
+// Accepts reports whether a file with the given name and content type
+// matches the widget's accept list. Every file matches an empty list.
+func (w *FileInput) Accepts(filename, contentType string) bool {
+	if strings.TrimSpace(w.accept) == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	for _, entry := range strings.Split(w.accept, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+		case strings.HasPrefix(entry, "."):
+			if ext == entry {
+				return true
+			}
+		case strings.HasSuffix(entry, "/*"):
+			if strings.HasPrefix(mediaType, strings.TrimSuffix(entry, "*")) {
+				return true
+			}
+		case entry == mediaType:
+			return true
+		}
+	}
+	return false
+}
+
 func (w *FileInput) Render(name string, value interface{}, attrs map[string]interface{}) WidgetConfig {
 	mergedAttrs := make(map[string]interface{})
 
@@ -503,11 +562,18 @@ func (w *FileInput) Render(name string, value interface{}, attrs map[string]inte
 		mergedAttrs["accept"] = w.accept
 	}
 
+	// The current file is linked to rather than set as the value
+	config := map[string]interface{}{"media_url": w.mediaURL}
+	if path, ok := value.(string); ok && path != "" {
+		config["url"] = w.URL(path)
+	}
+
 	return WidgetConfig{
 		Type:       "file",
 		Name:       name,
 		Value:      nil, // File inputs don't have values for security reasons
 		Attributes: mergedAttrs,
+		Config:     config,
 	}
 }
 
@@ -596,6 +662,7 @@ var WidgetRegistry = map[string]func() Widget{
 	"url":      func() Widget { return NewTextInput() },
 	"password": func() Widget { return NewPasswordInput() },
 	"file":     func() Widget { return NewFileInput() },
+	"image":    func() Widget { return NewImageField() },
 	"hidden":   func() Widget { return NewHiddenInput() },
 	"select":   func() Widget { return NewSelect() },
 	"multiple": func() Widget { return NewSelectMultiple() },
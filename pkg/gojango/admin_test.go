@@ -0,0 +1,86 @@
+package gojango
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/epuerta9/gojango/pkg/gojango/admin"
+)
+
+type adminSettingsModel struct {
+	ID int `json:"id"`
+}
+
+type adminSettingsOverrideModel struct {
+	ID int `json:"id"`
+}
+
+func TestSetupAdminPaginationSettings(t *testing.T) {
+	defer admin.DefaultSite.SetPaginationDefaults(admin.DefaultListPerPage, admin.DefaultMaxPageSize)
+
+	defaultAdmin := admin.NewModelAdmin(&adminSettingsModel{})
+	overrideAdmin := admin.NewModelAdmin(&adminSettingsOverrideModel{}).SetListPerPage(5)
+	if err := admin.Register(&adminSettingsModel{}, defaultAdmin); err != nil {
+		t.Fatalf("Failed to register model: %v", err)
+	}
+	defer admin.Unregister(&adminSettingsModel{})
+	if err := admin.Register(&adminSettingsOverrideModel{}, overrideAdmin); err != nil {
+		t.Fatalf("Failed to register model: %v", err)
+	}
+	defer admin.Unregister(&adminSettingsOverrideModel{})
+
+	settings := NewBasicSettings()
+	settings.Set("ADMIN_LIST_PER_PAGE", 30)
+	settings.Set("ADMIN_MAX_PAGE_SIZE", 60)
+
+	app := New()
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.SetupAdmin(); err != nil {
+		t.Fatalf("Failed to set up admin: %v", err)
+	}
+
+	if defaultAdmin.GetListPerPage() != 30 {
+		t.Errorf("Expected list per page 30 from settings, got %d", defaultAdmin.GetListPerPage())
+	}
+	if defaultAdmin.GetMaxShowAll() != 60 {
+		t.Errorf("Expected max page size 60 from settings, got %d", defaultAdmin.GetMaxShowAll())
+	}
+	if overrideAdmin.GetListPerPage() != 5 {
+		t.Errorf("Expected overriding model to keep list per page 5, got %d", overrideAdmin.GetListPerPage())
+	}
+	if overrideAdmin.GetMaxShowAll() != 60 {
+		t.Errorf("Expected overriding model to inherit max page size 60, got %d", overrideAdmin.GetMaxShowAll())
+	}
+}
+
+func TestSetupAdminTimeZoneSetting(t *testing.T) {
+	defer admin.DefaultSite.SetTimeZone(nil)
+
+	settings := NewBasicSettings()
+	settings.Set("TIME_ZONE", "America/New_York")
+
+	app := New()
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.SetupAdmin(); err != nil {
+		t.Fatalf("Failed to set up admin: %v", err)
+	}
+
+	if loc := admin.DefaultSite.TimeZone(); loc == nil || loc.String() != "America/New_York" {
+		t.Errorf("Expected admin time zone America/New_York from settings, got %v", loc)
+	}
+}
+
+func TestSetupAdminInvalidEmbeddedPrefix(t *testing.T) {
+	assets := fstest.MapFS{"admin/index.html": {Data: []byte("<div id=\"root\"></div>")}}
+	app := New(WithEmbeddedAdmin(assets, "../admin"))
+	if err := app.LoadSettings(NewBasicSettings()); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.SetupAdmin(); err == nil {
+		t.Error("Expected an error for an invalid embedded admin prefix")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/cobra"
 )
 
 // App is the core interface that all Gojango applications must implement.
@@ -28,6 +29,14 @@ type Route struct {
 	Name    string
 }
 
+// RouteGroup is a set of routes mounted under a shared path prefix within
+// the app, with middleware that runs only for those routes
+type RouteGroup struct {
+	Prefix     string
+	Middleware []gin.HandlerFunc
+	Routes     []Route
+}
+
 // Optional interfaces that apps can implement for additional functionality
 
 // RouterProvider allows apps to define HTTP routes
@@ -35,6 +44,12 @@ type RouterProvider interface {
 	Routes() []Route
 }
 
+// RouteGroupProvider allows apps to define route groups, e.g. API routes
+// behind authentication middleware. It can be combined with RouterProvider.
+type RouteGroupProvider interface {
+	RouteGroups() []RouteGroup
+}
+
 // ModelProvider allows apps to register database models
 type ModelProvider interface {
 	Models() []interface{}
@@ -55,6 +70,18 @@ type SignalProvider interface {
 	Signals() []SignalHandler
 }
 
+// CommandProvider allows apps to add management commands to manage.go,
+// like the commands Django apps ship for manage.py
+type CommandProvider interface {
+	Commands() []*cobra.Command
+}
+
+// ShutdownableApp allows apps to release resources, such as connection
+// pools or job workers, when the application shuts down
+type ShutdownableApp interface {
+	Shutdown(ctx context.Context) error
+}
+
 // AppConfig defines application metadata and configuration
 type AppConfig struct {
 	// Name is the unique identifier for the app
@@ -66,7 +93,8 @@ type AppConfig struct {
 	// Version is the app version
 	Version string
 
-	// Dependencies lists other apps this app depends on
+	// Dependencies lists other apps this app depends on, which are
+	// initialized before it
 	Dependencies []string
 
 	// Settings contains app-specific settings
@@ -86,6 +114,10 @@ type AppContext struct {
 
 	// Registry provides access to the global app registry
 	Registry *Registry
+
+	// Events is the event bus shared by all apps, for publishing and
+	// subscribing to domain events such as "post.published"
+	Events *EventBus
 }
 
 // BaseApp provides a basic implementation that apps can embed
@@ -135,4 +167,14 @@ type Settings interface {
 	GetString(key string, defaultValue ...string) string
 	GetInt(key string, defaultValue ...int) int
 	GetBool(key string, defaultValue ...bool) bool
+	
+	// Dotted path lookups into nested settings, e.g. "DATABASES.default.host"
+	GetPath(path string, defaultValue ...interface{}) interface{}
+	GetPathString(path string, defaultValue ...string) string
+	GetPathInt(path string, defaultValue ...int) int
+	GetPathBool(path string, defaultValue ...bool) bool
+	
+	// Validate checks the settings against a schema, reporting every
+	// invalid setting in one error
+	Validate(schema SettingsSchema) error
 }
\ No newline at end of file
@@ -2,17 +2,26 @@ package gojango
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/epuerta9/gojango/pkg/gojango/cache"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/epuerta9/gojango/pkg/gojango/i18n"
 	"github.com/epuerta9/gojango/pkg/gojango/middleware"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
 	"github.com/epuerta9/gojango/pkg/gojango/routing"
+	"github.com/epuerta9/gojango/pkg/gojango/staticfiles"
 	"github.com/epuerta9/gojango/pkg/gojango/templates"
 	"github.com/epuerta9/gojango/pkg/gojango/version"
 	"github.com/gin-gonic/gin"
@@ -28,10 +37,42 @@ type Application struct {
 	templates *templates.Engine
 	server   *http.Server
 	middleware *middleware.Registry
+	health   *HealthRegistry
+	database *db.Connection
+	client   interface{}
+	cache    cache.Cache
+	
+	// staticFS and adminFS hold embedded assets served instead of the
+	// files on disk
+	staticFS     fs.FS
+	staticPrefix string
+	adminFS      fs.FS
+	adminPrefix  string
+	
+	// staticManifest maps static files to their fingerprinted names once
+	// they are collected, and collectedStatic serves them
+	staticManifest  *staticfiles.Manifest
+	collectedStatic http.FileSystem
+	
+	// errorTemplates is the template directory 404.html and 500.html are
+	// looked up in
+	errorTemplates string
+	
+	// customMiddleware is set when the stack was chosen explicitly, so the
+	// MIDDLEWARE_PRESET setting doesn't replace it
+	customMiddleware bool
+	
+	// extraMiddleware is added with AddMiddleware and applied after the stack
+	extraMiddleware *middleware.Registry
 	
 	// Options
 	debug bool
+	host  string
 	port  string
+	listener net.Listener
+	tls   tlsOptions
+	timeouts serverTimeouts
+	shutdownTimeout time.Duration
 }
 
 // Option is a function that configures the Application
@@ -58,22 +99,44 @@ func WithPort(port string) Option {
 	}
 }
 
-// WithMiddleware sets a custom middleware registry
+// WithMiddleware sets a custom middleware registry. It takes precedence over
+// the MIDDLEWARE_PRESET setting and the debug default.
 func WithMiddleware(middlewareRegistry *middleware.Registry) Option {
 	return func(app *Application) {
 		app.middleware = middlewareRegistry
+		app.customMiddleware = true
+	}
+}
+
+// WithShutdownTimeout sets how long a graceful shutdown may take, for
+// draining requests and running the apps' shutdown hooks. Defaults to 30s.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(app *Application) {
+		app.shutdownTimeout = timeout
+	}
+}
+
+// WithDatabase sets the application's database connection, which the
+// health checks ping
+func WithDatabase(conn *db.Connection) Option {
+	return func(app *Application) {
+		app.database = conn
 	}
 }
 
 // New creates a new Gojango application
 func New(opts ...Option) *Application {
 	app := &Application{
-		name:      "gojango-app",
-		registry:  GetRegistry(),
-		router:    routing.NewRouter(),
-		templates: templates.NewEngine(),
-		debug:     false,
-		port:      "8080",
+		name:            "gojango-app",
+		registry:        GetRegistry(),
+		router:          routing.NewRouter(),
+		templates:       templates.NewEngine(),
+		health:          NewHealthRegistry(),
+		debug:           false,
+		port:            "8080",
+		timeouts:        unsetServerTimeouts,
+		shutdownTimeout: 30 * time.Second,
+		extraMiddleware: middleware.NewRegistry(),
 	}
 	
 	// Apply options
@@ -81,7 +144,8 @@ func New(opts ...Option) *Application {
 		opt(app)
 	}
 	
-	// Set default middleware if none provided
+	// Set default middleware if none provided; the MIDDLEWARE_PRESET setting
+	// can still replace it in LoadSettings
 	if app.middleware == nil {
 		if app.debug {
 			app.middleware = middleware.GetDevelopment()
@@ -90,9 +154,11 @@ func New(opts ...Option) *Application {
 		}
 	}
 	
-	// Configure Gin based on debug mode
+	// Configure Gin based on debug mode; in debug mode edited templates are
+	// picked up without a restart
 	if app.debug {
 		gin.SetMode(gin.DebugMode)
+		app.templates.SetAutoReload(true)
 	} else {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -100,20 +166,42 @@ func New(opts ...Option) *Application {
 	return app
 }
 
-// LoadSettings loads configuration from the provided settings implementation
+// LoadSettings loads configuration from the provided settings implementation,
+// such as a SettingsStack layering a settings file and environment variables.
+// Unless a middleware registry was set explicitly, the MIDDLEWARE_PRESET
+// setting ("minimal", "development" or "production") selects the middleware
+// stack in place of the debug default.
 func (app *Application) LoadSettings(settings Settings) error {
 	app.settings = settings
+	
+	if name := settings.GetString("MIDDLEWARE_PRESET", ""); name != "" && !app.customMiddleware {
+		registry, err := middleware.Preset(name)
+		if err != nil {
+			return fmt.Errorf("invalid MIDDLEWARE_PRESET: %w", err)
+		}
+		app.middleware = registry
+	}
+	
 	return nil
 }
 
-// AddMiddleware adds a middleware function to the application
+// SetMiddleware replaces the middleware stack after construction. Like
+// WithMiddleware it takes precedence over the MIDDLEWARE_PRESET setting.
+// Middleware added with AddMiddleware is kept and still applied after it.
+func (app *Application) SetMiddleware(middlewareRegistry *middleware.Registry) {
+	app.middleware = middlewareRegistry
+	app.customMiddleware = true
+}
+
+// AddMiddleware adds a middleware function to the application, applied after
+// the middleware stack
 func (app *Application) AddMiddleware(middleware middleware.MiddlewareFunc) {
-	app.middleware.Add(middleware)
+	app.extraMiddleware.Add(middleware)
 }
 
 // AddGinMiddleware adds a Gin HandlerFunc directly as middleware
 func (app *Application) AddGinMiddleware(handler gin.HandlerFunc) {
-	app.middleware.AddGin(handler)
+	app.extraMiddleware.AddGin(handler)
 }
 
 // GetRouter returns the underlying Gin router engine
@@ -121,22 +209,76 @@ func (app *Application) GetRouter() *gin.Engine {
 	return app.router.GetEngine()
 }
 
+// GetTemplates returns the template engine, for rendering templates and
+// registering Templ components
+func (app *Application) GetTemplates() *templates.Engine {
+	return app.templates
+}
+
+// Health returns the health check registry served under /health, for
+// registering checks of the services the application depends on
+func (app *Application) Health() *HealthRegistry {
+	return app.health
+}
+
 // Initialize initializes all registered apps
 func (app *Application) Initialize(ctx context.Context) error {
 	if app.settings == nil {
 		return fmt.Errorf("settings not loaded - call LoadSettings() first")
 	}
 	
+	if err := app.settings.Validate(DefaultSettingsSchema()); err != nil {
+		return fmt.Errorf("invalid settings: %w", err)
+	}
+	
 	log.Printf("Initializing Gojango application: %s", app.name)
 	
 	// Setup middleware
 	app.setupMiddleware()
 	
+	// Failed health checks only report their errors in debug mode
+	app.health.SetDebug(app.settings.GetBool("DEBUG", app.debug))
+	
+	// Ping the database in readiness checks when there is a connection
+	if app.database != nil {
+		conn := app.database
+		app.health.Register("database", func(ctx context.Context) error {
+			return conn.DB().PingContext(ctx)
+		})
+	}
+	
+	// Pretty-print JSON responses when JSON_INDENT is set, following DEBUG by default
+	debug := app.settings.GetBool("DEBUG", app.debug)
+	render.SetIndentJSON(app.settings.GetBool("JSON_INDENT", debug))
+	render.SetDefaultCharset(app.settings.GetString("DEFAULT_CHARSET", render.DefaultCharset))
+	
+	// Make the cache configured by CACHES the default cache
+	if app.settings.Get("CACHES") != nil {
+		if _, err := app.Cache(); err != nil {
+			return fmt.Errorf("failed to setup cache: %w", err)
+		}
+	}
+	
 	// Setup template functions (needs to be before app initialization)
 	app.templates.AddFuncs(app.router.TemplateFuncs())
+	if err := app.setupStaticManifest(debug); err != nil {
+		return err
+	}
+	
+	// Setup translations, which add locale middleware and template functions
+	if app.settings.GetBool("USE_I18N", true) {
+		if err := app.setupI18n(debug); err != nil {
+			return fmt.Errorf("failed to setup translations: %w", err)
+		}
+	}
 	
-	// Initialize the registry with all registered apps
-	if err := app.registry.Initialize(ctx, app.settings); err != nil {
+	// Initialize the registry with all registered apps, in INSTALLED_APPS
+	// order when it is set
+	installed, err := installedApps(app.settings)
+	if err != nil {
+		return err
+	}
+	if err := app.registry.InitializeInOrder(ctx, app.settings, installed); err != nil {
 		return fmt.Errorf("failed to initialize app registry: %w", err)
 	}
 	
@@ -154,10 +296,86 @@ func (app *Application) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// installedApps returns the app names listed in INSTALLED_APPS, or nil if it
+// isn't set. Entries are package paths ("apps.blog" or
+// "github.com/acme/site/apps/blog") and name the app registered under their
+// last segment. Framework entries ("gojango.contrib.admin") are skipped,
+// since they aren't registered apps.
+func installedApps(settings Settings) ([]string, error) {
+	var entries []string
+	switch value := settings.Get("INSTALLED_APPS").(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		entries = value
+	case []interface{}:
+		for _, entry := range value {
+			str, ok := entry.(string)
+			if !ok {
+				return nil, fmt.Errorf("INSTALLED_APPS entry %v is not a string", entry)
+			}
+			entries = append(entries, str)
+		}
+	default:
+		return nil, fmt.Errorf("INSTALLED_APPS must be a list, got %T", value)
+	}
+	
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry, "gojango.contrib.") {
+			continue
+		}
+		name := entry[strings.LastIndexAny(entry, "./")+1:]
+		if name == "" {
+			return nil, fmt.Errorf("invalid INSTALLED_APPS entry '%s'", entry)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 // setupMiddleware configures the middleware stack
 func (app *Application) setupMiddleware() {
-	// Apply middleware from the registry
+	// Make the application's resources available to every handler
+	app.router.GetEngine().Use(app.requestScopeMiddleware())
+	
+	// Apply middleware from the registry, then any added individually
 	app.middleware.Apply(app.router.GetEngine())
+	app.extraMiddleware.Apply(app.router.GetEngine())
+	
+	// Render error pages for panics in handlers and later middleware
+	app.router.GetEngine().Use(middleware.RecoveryWithConfig(middleware.RecoveryConfig{
+		Handler: app.handlePanic,
+	}))
+	
+	if app.settings.GetBool("CSRF_ENABLED", false) {
+		app.router.GetEngine().Use(middleware.CSRF(app.csrfConfig()))
+	}
+}
+
+// setupI18n loads translations from LOCALE_DIR (default "locale") into the
+// default catalog, falling back to LANGUAGE_CODE (default "en"), and adds
+// the locale middleware and the trans template functions
+func (app *Application) setupI18n(debug bool) error {
+	catalog := i18n.NewCatalog(app.settings.GetString("LANGUAGE_CODE", "en"))
+	catalog.SetDebug(debug)
+	if err := catalog.LoadDir(app.settings.GetString("LOCALE_DIR", "locale")); err != nil {
+		return err
+	}
+	i18n.SetDefault(catalog)
+	
+	app.router.GetEngine().Use(i18n.Middleware(catalog))
+	app.templates.AddContextFuncs(i18n.TemplateFuncs(catalog))
+	return nil
+}
+
+// csrfConfig builds the CSRF configuration from settings
+func (app *Application) csrfConfig() middleware.CSRFConfig {
+	return middleware.CSRFConfig{
+		CookieName:   app.settings.GetString("CSRF_COOKIE_NAME", ""),
+		HeaderName:   app.settings.GetString("CSRF_HEADER_NAME", ""),
+		CookieSecure: app.settings.GetBool("CSRF_COOKIE_SECURE", false),
+	}
 }
 
 // setupRouting registers routes from all apps
@@ -169,18 +387,7 @@ func (app *Application) setupRouting() error {
 	allRoutes := app.registry.GetAllRoutes()
 	for appName, routes := range allRoutes {
 		if len(routes) > 0 {
-			// Convert gojango.Route to routing.Route
-			routingRoutes := make([]routing.Route, len(routes))
-			for i, route := range routes {
-				routingRoutes[i] = routing.Route{
-					Method:  route.Method,
-					Path:    route.Path,
-					Handler: route.Handler,
-					Name:    route.Name,
-				}
-			}
-			
-			err := app.router.RegisterRoutes(appName, routingRoutes)
+			err := app.router.RegisterRoutes(appName, toRoutingRoutes(routes))
 			if err != nil {
 				return fmt.Errorf("failed to register routes for app '%s': %w", appName, err)
 			}
@@ -192,49 +399,106 @@ func (app *Application) setupRouting() error {
 		}
 	}
 	
-	// Setup static file serving
-	app.setupStaticFiles()
+	// Register route groups, each with its own middleware
+	for appName, groups := range app.registry.GetAllRouteGroups() {
+		for _, group := range groups {
+			err := app.router.RegisterGroup(appName, routing.RouteGroup{
+				Prefix:     group.Prefix,
+				Middleware: group.Middleware,
+				Routes:     toRoutingRoutes(group.Routes),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to register route group '%s' for app '%s': %w", group.Prefix, appName, err)
+			}
+			
+			log.Printf("App '%s' registered %d routes in group %s", appName, len(group.Routes), group.Prefix)
+			for _, route := range group.Routes {
+				log.Printf("  %s /%s%s%s -> %s:%s", route.Method, appName, group.Prefix, route.Path, appName, route.Name)
+			}
+		}
+	}
 	
+	// Setup static and media file serving
+	if err := app.setupStaticFiles(); err != nil {
+		return err
+	}
+	app.setupMediaFiles()
 	return nil
 }
 
-// setupTemplates loads templates from all apps
+// setupTemplates loads templates from all apps. Every template is parsed up
+// front so broken templates are reported at startup rather than on first
+// render: parse errors fail startup in release mode and are logged as
+// warnings in debug mode.
 func (app *Application) setupTemplates() error {
+	var parseErrs templates.LoadErrors
+	
 	// Load global templates if they exist
 	if err := app.templates.LoadGlobalTemplates("templates"); err != nil {
-		log.Printf("Warning: failed to load global templates: %v", err)
+		if !collectTemplateErrors(&parseErrs, err) {
+			log.Printf("Warning: failed to load global templates: %v", err)
+		}
 	}
 	
 	// Load templates from each app
 	for _, appName := range app.registry.GetAppNames() {
 		templateDir := filepath.Join("apps", appName, "templates")
 		if err := app.templates.LoadAppTemplates(appName, templateDir); err != nil {
-			log.Printf("Warning: failed to load templates for app '%s': %v", appName, err)
+			if !collectTemplateErrors(&parseErrs, err) {
+				log.Printf("Warning: failed to load templates for app '%s': %v", appName, err)
+			}
+		}
+	}
+	
+	if len(parseErrs) > 0 {
+		if app.settings.GetBool("DEBUG", app.debug) {
+			log.Printf("Warning: %v", parseErrs)
+			return nil
 		}
+		return parseErrs
 	}
 	
 	return nil
 }
 
+// collectTemplateErrors appends template parse errors to errs and reports
+// whether err was one
+func collectTemplateErrors(errs *templates.LoadErrors, err error) bool {
+	var loadErrs templates.LoadErrors
+	if !errors.As(err, &loadErrs) {
+		return false
+	}
+	*errs = append(*errs, loadErrs...)
+	return true
+}
+
 // addBuiltinRoutes adds framework built-in routes
 func (app *Application) addBuiltinRoutes() {
 	engine := app.router.GetEngine()
 	
-	// Health check endpoint
-	engine.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "ok",
-			"app":    app.name,
-		})
-	})
+	// Health check endpoints: /health/live for liveness probes, /health and
+	// /health/ready for readiness probes
+	engine.GET("/health", app.health.ReadyHandler(app.name))
+	engine.GET("/health/ready", app.health.ReadyHandler(app.name))
+	engine.GET("/health/live", app.health.LiveHandler(app.name))
+	
+	// CSRF token endpoint for SPA clients
+	if app.settings.GetBool("CSRF_ENABLED", false) {
+		tokenPath := app.settings.GetString("CSRF_TOKEN_URL", middleware.DefaultCSRFTokenPath)
+		engine.GET(tokenPath, middleware.CSRFTokenHandler(app.csrfConfig()))
+	}
+	
+	// Error page for unmatched routes
+	engine.NoRoute(app.handleNotFound)
 	
 	// Root welcome page
 	engine.GET("/", func(c *gin.Context) {
 		apps := app.registry.GetAppNames()
 		routes := app.router.GetRoutes()
 		
-		// Try to render template, fall back to basic HTML
-		if app.templates.Has("index.html") {
+		// Try to render the project's template, fall back to basic HTML.
+		// An app's index.html is only rendered under its own name.
+		if name, err := app.templates.Resolve("index.html"); err == nil && name == "index.html" {
 			html, err := app.templates.Render("index.html", gin.H{
 				"AppName":    app.name,
 				"Apps":       apps,
@@ -242,14 +506,12 @@ func (app *Application) addBuiltinRoutes() {
 				"RouteCount": len(routes),
 			})
 			if err == nil {
-				c.Header("Content-Type", "text/html")
-				c.String(200, html)
+				render.HTML(c, 200, html)
 				return
 			}
 		}
 		
 		// Fallback HTML response
-		c.Header("Content-Type", "text/html")
 		html := fmt.Sprintf(`<!DOCTYPE html>
 <html>
 <head>
@@ -280,69 +542,123 @@ func (app *Application) addBuiltinRoutes() {
 </body>
 </html>`, len(routes))
 		
-		c.String(200, html)
+		render.HTML(c, 200, html)
 	})
 }
 
-// setupStaticFiles configures static file serving
-func (app *Application) setupStaticFiles() {
-	engine := app.router.GetEngine()
-	
-	// Serve global static files
-	engine.Static("/static", "./static")
-	
-	// Serve app-specific static files
-	for _, appName := range app.registry.GetAppNames() {
-		staticPath := filepath.Join("apps", appName, "static")
-		if _, err := os.Stat(staticPath); err == nil {
-			engine.Static("/"+appName+"/static", staticPath)
+// toRoutingRoutes converts gojango.Route values to routing.Route
+func toRoutingRoutes(routes []Route) []routing.Route {
+	routingRoutes := make([]routing.Route, len(routes))
+	for i, route := range routes {
+		routingRoutes[i] = routing.Route{
+			Method:  route.Method,
+			Path:    route.Path,
+			Handler: route.Handler,
+			Name:    route.Name,
 		}
 	}
+	return routingRoutes
 }
 
 // setupHTTPServer sets up the HTTP server with Gin
 func (app *Application) setupHTTPServer() error {
+	if err := app.loadTLSSettings(); err != nil {
+		return err
+	}
+	if err := app.loadTimeoutSettings(); err != nil {
+		return err
+	}
+	
 	app.server = &http.Server{
-		Addr:    ":" + app.port,
-		Handler: app.router,
+		Addr:              app.Addr(),
+		Handler:           app.router,
+		ReadTimeout:       app.timeouts.read,
+		ReadHeaderTimeout: app.timeouts.readHeader,
+		WriteTimeout:      app.timeouts.write,
+		IdleTimeout:       app.timeouts.idle,
+	}
+	if app.TLSEnabled() {
+		app.server.TLSConfig = app.tlsConfig()
 	}
 	
 	return nil
 }
 
-// Run starts the application server
+// Run starts the application server on the listener set with WithListener,
+// or else on the configured address
 func (app *Application) Run(ctx context.Context) error {
+	if app.listener != nil {
+		return app.Serve(ctx, app.listener)
+	}
+	
+	listener, err := app.listen()
+	if err != nil {
+		return err
+	}
+	
+	return app.Serve(ctx, listener)
+}
+
+// Serve starts the application server on the given listener. It serves
+// HTTPS with HTTP/2 when a certificate is configured via WithTLS or the
+// TLS_CERT_FILE and TLS_KEY_FILE settings, and plain HTTP otherwise. Serve
+// shuts down gracefully when ctx is cancelled or on SIGINT/SIGTERM, then
+// calls the Shutdown hooks of apps implementing ShutdownableApp.
+func (app *Application) Serve(ctx context.Context, listener net.Listener) error {
 	// Initialize the application
 	if err := app.Initialize(ctx); err != nil {
+		listener.Close()
 		return fmt.Errorf("failed to initialize application: %w", err)
 	}
 	
 	// Setup HTTP server
 	if err := app.setupHTTPServer(); err != nil {
+		listener.Close()
 		return fmt.Errorf("failed to setup HTTP server: %w", err)
 	}
 	
 	// Start server in a goroutine
+	serveErr := make(chan error, 1)
 	go func() {
-		log.Printf("Starting server on http://localhost:%s", app.port)
-		if err := app.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server failed to start: %v", err)
+		var err error
+		if app.TLSEnabled() {
+			log.Printf("Starting server on https://%s", listener.Addr())
+			err = app.server.ServeTLS(listener, app.tls.certFile, app.tls.keyFile)
+		} else {
+			log.Printf("Starting server on http://%s", listener.Addr())
+			err = app.server.Serve(listener)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			serveErr <- err
 		}
 	}()
 	
-	// Wait for interrupt signal
+	// Wait for interrupt signal or cancellation
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	defer signal.Stop(quit)
+	
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("server failed to start: %w", err)
+	case <-quit:
+	case <-ctx.Done():
+	}
 	
 	log.Println("Shutting down server...")
 	
-	// Graceful shutdown
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Graceful shutdown: drain requests, then let apps clean up, sharing
+	// the shutdown timeout
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), app.shutdownTimeout)
 	defer cancel()
 	
-	if err := app.server.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
+	serverErr := app.server.Shutdown(shutdownCtx)
+	appsErr := app.registry.Shutdown(shutdownCtx)
+	if serverErr != nil {
+		return fmt.Errorf("server forced to shutdown: %w", serverErr)
+	}
+	if appsErr != nil {
+		return fmt.Errorf("app shutdown failed: %w", appsErr)
 	}
 	
 	log.Println("Server exited")
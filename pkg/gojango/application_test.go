@@ -2,7 +2,20 @@ package gojango
 
 import (
 	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/middleware"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
 )
 
 func TestApplicationCreation(t *testing.T) {
@@ -43,6 +56,13 @@ func TestApplicationOptions(t *testing.T) {
 	if app.debug != true {
 		t.Error("Expected debug to be true")
 	}
+
+	if !app.templates.AutoReload() {
+		t.Error("Expected template auto-reload in debug mode")
+	}
+	if New().templates.AutoReload() {
+		t.Error("Expected templates to be parsed once outside debug mode")
+	}
 }
 
 func TestApplicationSettingsRequired(t *testing.T) {
@@ -71,7 +91,7 @@ func TestApplicationInitialization(t *testing.T) {
 	}
 
 	// Create settings
-	settings := NewBasicSettings()
+	settings := newTestSettings()
 	settings.Set("TEST", true)
 
 	// Load settings
@@ -106,8 +126,8 @@ func TestApplicationCommands(t *testing.T) {
 		routes:   make(map[string][]Route),
 		services: make(map[string]Service),
 	}
-	
-	settings := NewBasicSettings()
+
+	settings := newTestSettings()
 	if err := app.LoadSettings(settings); err != nil {
 		t.Fatalf("Failed to load settings: %v", err)
 	}
@@ -133,7 +153,7 @@ func TestApplicationCommands(t *testing.T) {
 	}
 	testApp := &TestApp{name: "test"}
 	app2.registry.RegisterApp(testApp)
-	
+
 	err = app2.RunCommand(ctx, "apps", []string{})
 	if err != nil {
 		t.Errorf("Apps command failed: %v", err)
@@ -155,9 +175,9 @@ func TestApplicationWithApps(t *testing.T) {
 		routes:   make(map[string][]Route),
 		services: make(map[string]Service),
 	}
-	
+
 	// Create settings
-	settings := NewBasicSettings()
+	settings := newTestSettings()
 	settings.Set("DEBUG", true)
 	if err := app.LoadSettings(settings); err != nil {
 		t.Fatalf("Failed to load settings: %v", err)
@@ -166,7 +186,7 @@ func TestApplicationWithApps(t *testing.T) {
 	// Register multiple apps with dependencies
 	coreApp := &TestApp{name: "core"}
 	blogApp := &TestApp{name: "blog", deps: []string{"core"}}
-	
+
 	app.registry.RegisterApp(coreApp)
 	app.registry.RegisterApp(blogApp)
 
@@ -257,4 +277,425 @@ func TestAppWithRoutes(t *testing.T) {
 	if !createFound {
 		t.Error("Create route not found")
 	}
-}
\ No newline at end of file
+}
+
+func TestApplicationJSONIndentSetting(t *testing.T) {
+	defer render.SetIndentJSON(false)
+
+	testCases := []struct {
+		name     string
+		settings map[string]interface{}
+		expected bool
+	}{
+		{"follows debug", map[string]interface{}{"DEBUG": true}, true},
+		{"compact in release", map[string]interface{}{"DEBUG": false}, false},
+		{"explicit override", map[string]interface{}{"DEBUG": true, "JSON_INDENT": false}, false},
+		{"explicit enable", map[string]interface{}{"JSON_INDENT": "true"}, true},
+	}
+
+	for _, tc := range testCases {
+		app := New()
+		app.registry = &Registry{
+			apps:     make(map[string]App),
+			models:   make(map[string]ModelMeta),
+			routes:   make(map[string][]Route),
+			services: make(map[string]Service),
+		}
+
+		settings := newTestSettings()
+		for key, value := range tc.settings {
+			settings.Set(key, value)
+		}
+		if err := app.LoadSettings(settings); err != nil {
+			t.Fatalf("Failed to load settings: %v", err)
+		}
+		if err := app.Initialize(context.Background()); err != nil {
+			t.Fatalf("Application initialization failed: %v", err)
+		}
+
+		if render.IndentJSON() != tc.expected {
+			t.Errorf("%s: expected JSON indentation %v, got %v", tc.name, tc.expected, render.IndentJSON())
+		}
+	}
+}
+
+func TestApplicationCSRFTokenEndpoint(t *testing.T) {
+	app := New()
+	app.registry = &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+
+	settings := newTestSettings()
+	settings.Set("CSRF_ENABLED", true)
+	settings.Set("CSRF_TOKEN_URL", "/api/csrf")
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/csrf", nil)
+	app.GetRouter().ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Fatalf("Expected CSRF token endpoint to respond 200, got: %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "csrf_token") {
+		t.Errorf("Expected CSRF token in response, got: %s", w.Body.String())
+	}
+	if len(w.Result().Cookies()) == 0 {
+		t.Error("Expected CSRF cookie to be set")
+	}
+}
+
+func TestApplicationDefaultCharsetSetting(t *testing.T) {
+	defer render.SetDefaultCharset("")
+
+	app := New()
+	app.registry = &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+
+	settings := newTestSettings()
+	settings.Set("DEFAULT_CHARSET", "windows-1252")
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	app.GetRouter().ServeHTTP(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "text/html; charset=windows-1252" {
+		t.Errorf("Expected configured charset on HTML response, got: %s", ct)
+	}
+}
+
+func TestApplicationTemplateWarmup(t *testing.T) {
+	dir := t.TempDir()
+	templateDir := filepath.Join(dir, "templates")
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	for name, content := range map[string]string{
+		"index.html":  "<h1>{{.Title}}</h1>",
+		"broken.html": "<h1>{{if .Title}}</h1>",
+	} {
+		if err := os.WriteFile(filepath.Join(templateDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+	t.Chdir(dir)
+
+	newApp := func(debug bool) *Application {
+		app := New(WithName("test-app"))
+		app.registry = &Registry{
+			apps:     make(map[string]App),
+			models:   make(map[string]ModelMeta),
+			routes:   make(map[string][]Route),
+			services: make(map[string]Service),
+		}
+		settings := newTestSettings()
+		settings.Set("DEBUG", debug)
+		app.LoadSettings(settings)
+		return app
+	}
+
+	// Release mode fails fast and names the broken file
+	err := newApp(false).Initialize(context.Background())
+	if err == nil {
+		t.Fatal("Expected initialization to fail with a broken template in release mode")
+	}
+	if !strings.Contains(err.Error(), "broken.html") {
+		t.Errorf("Expected error to name broken.html, got: %v", err)
+	}
+
+	// Debug mode warns and keeps serving the valid templates
+	app := newApp(true)
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Expected debug initialization to continue, got: %v", err)
+	}
+	if !app.templates.Has("index.html") {
+		t.Error("Expected index.html to be loaded in debug mode")
+	}
+}
+
+func TestRootPageIgnoresAppIndexTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templateDir := filepath.Join(dir, "apps", "blog", "templates")
+	if err := os.MkdirAll(templateDir, 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, "index.html"), []byte("blog index"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	t.Chdir(dir)
+
+	app := New(WithName("test-app"))
+	app.registry = NewRegistry()
+	app.registry.RegisterApp(&TestApp{name: "blog"})
+	if err := app.LoadSettings(newTestSettings()); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+
+	// The blog's template is reachable by name, but doesn't replace the
+	// welcome page
+	if html, err := app.templates.Render("blog/index.html", nil); err != nil || html != "blog index" {
+		t.Errorf("Expected the blog's index.html, got %q: %v", html, err)
+	}
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	app.GetRouter().ServeHTTP(w, req)
+	if !strings.Contains(w.Body.String(), "Welcome to test-app") {
+		t.Errorf("Expected the welcome page, got: %s", w.Body.String())
+	}
+}
+
+func TestApplicationMiddlewarePresetSetting(t *testing.T) {
+	testCases := []struct {
+		preset   string
+		debug    bool
+		expected int
+	}{
+		{"", true, middleware.GetDevelopment().Count()},
+		{"", false, middleware.GetDefaults().Count()},
+		{"production", true, middleware.GetDefaults().Count()},
+		{"development", false, middleware.GetDevelopment().Count()},
+		{"minimal", true, middleware.Minimal().Count()},
+	}
+
+	for _, tc := range testCases {
+		app := New(WithDebug(tc.debug))
+		settings := newTestSettings()
+		if tc.preset != "" {
+			settings.Set("MIDDLEWARE_PRESET", tc.preset)
+		}
+		if err := app.LoadSettings(settings); err != nil {
+			t.Fatalf("%q: failed to load settings: %v", tc.preset, err)
+		}
+
+		if app.middleware.Count() != tc.expected {
+			t.Errorf("preset %q, debug %v: expected %d middlewares, got %d", tc.preset, tc.debug, tc.expected, app.middleware.Count())
+		}
+	}
+}
+
+func TestApplicationMiddlewarePresetUnknown(t *testing.T) {
+	app := New()
+	settings := newTestSettings()
+	settings.Set("MIDDLEWARE_PRESET", "staging")
+
+	err := app.LoadSettings(settings)
+	if err == nil || !strings.Contains(err.Error(), "MIDDLEWARE_PRESET") {
+		t.Errorf("Expected an invalid MIDDLEWARE_PRESET error, got: %v", err)
+	}
+}
+
+func TestApplicationExplicitMiddlewareOverridesPreset(t *testing.T) {
+	settings := newTestSettings()
+	settings.Set("MIDDLEWARE_PRESET", "minimal")
+
+	custom := middleware.NewRegistry()
+	app := New(WithMiddleware(custom))
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if app.middleware != custom {
+		t.Error("Expected WithMiddleware to take precedence over MIDDLEWARE_PRESET")
+	}
+
+	// SetMiddleware replaces the stack after construction, keeping
+	// individually added middleware
+	app = New()
+	app.AddGinMiddleware(func(c *gin.Context) {
+		c.Header("X-Added", "true")
+		c.Next()
+	})
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	app.SetMiddleware(custom)
+	app.registry = &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/health", nil)
+	app.GetRouter().ServeHTTP(w, req)
+
+	if w.Header().Get("X-Added") != "true" {
+		t.Error("Expected middleware added with AddGinMiddleware to be applied")
+	}
+	if w.Header().Get("X-Request-ID") != "" {
+		t.Error("Expected the minimal preset to be replaced by SetMiddleware")
+	}
+}
+
+// GroupedTestApp is a test app with flat routes and a route group
+type GroupedTestApp struct {
+	TestApp
+}
+
+func (app *GroupedTestApp) Routes() []Route {
+	return []Route{
+		{Method: "GET", Path: "/", Name: "index", Handler: func(c *gin.Context) { c.String(200, "public") }},
+	}
+}
+
+func (app *GroupedTestApp) RouteGroups() []RouteGroup {
+	return []RouteGroup{{
+		Prefix: "/api",
+		Middleware: []gin.HandlerFunc{func(c *gin.Context) {
+			c.Header("X-Group", "api")
+			c.Next()
+		}},
+		Routes: []Route{
+			{Method: "GET", Path: "/items", Name: "api-items", Handler: func(c *gin.Context) { c.String(200, "items") }},
+		},
+	}}
+}
+
+func TestApplicationRouteGroups(t *testing.T) {
+	app := New()
+	app.registry = &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+	app.registry.RegisterApp(&GroupedTestApp{TestApp: TestApp{name: "shop"}})
+
+	if err := app.LoadSettings(newTestSettings()); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+
+	for path, expected := range map[string]struct{ body, group string }{
+		"/shop/":          {"public", ""},
+		"/shop/api/items": {"items", "api"},
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		app.GetRouter().ServeHTTP(w, req)
+
+		if w.Body.String() != expected.body {
+			t.Errorf("%s: expected body %q, got %q", path, expected.body, w.Body.String())
+		}
+		if w.Header().Get("X-Group") != expected.group {
+			t.Errorf("%s: expected X-Group %q, got %q", path, expected.group, w.Header().Get("X-Group"))
+		}
+	}
+}
+
+// newTestSettings returns settings that pass the default settings schema
+func newTestSettings() *BasicSettings {
+	settings := NewBasicSettings()
+	settings.Set("SECRET_KEY", "test-secret-key")
+	return settings
+}
+
+func TestApplicationValidatesSettings(t *testing.T) {
+	app := New()
+	settings := NewBasicSettings()
+	settings.Set("DATABASE_DRIVER", "oracle")
+	settings.Set("PORT", "eighty")
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	err := app.Initialize(context.Background())
+	var settingsErrs SettingsErrors
+	if !errors.As(err, &settingsErrs) {
+		t.Fatalf("Expected SettingsErrors, got: %v", err)
+	}
+	if len(settingsErrs) != 3 {
+		t.Errorf("Expected every invalid setting to be reported, got: %v", err)
+	}
+}
+
+func TestInstalledApps(t *testing.T) {
+	settings := NewBasicSettings()
+	if names, err := installedApps(settings); err != nil || names != nil {
+		t.Errorf("Expected no order without INSTALLED_APPS, got: %v, %v", names, err)
+	}
+
+	settings.Set("INSTALLED_APPS", []interface{}{
+		"gojango.contrib.admin",
+		"apps.core",
+		"github.com/acme/site/apps/blog",
+		"shop",
+	})
+	names, err := installedApps(settings)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := strings.Join(names, ","); got != "core,blog,shop" {
+		t.Errorf("Expected core,blog,shop, got: %s", got)
+	}
+
+	settings.Set("INSTALLED_APPS", []interface{}{"apps.core", 42})
+	if _, err := installedApps(settings); err == nil {
+		t.Error("Expected an error for a non-string entry")
+	}
+}
+
+func TestServeRunsShutdownHooks(t *testing.T) {
+	app := New(WithShutdownTimeout(time.Second))
+	app.registry = NewRegistry()
+	var mu sync.Mutex
+	var shutdown []string
+	app.registry.RegisterApp(&shutdownTestApp{TestApp: TestApp{name: "jobs"}, log: &shutdown, mu: &mu})
+	app.LoadSettings(newTestSettings())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Serve(ctx, listener)
+	}()
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	if resp, err := client.Get("http://" + listener.Addr().String() + "/health"); err == nil {
+		resp.Body.Close()
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Server did not shut down")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(shutdown) != 1 || shutdown[0] != "jobs" {
+		t.Errorf("Expected the jobs app to shut down, got: %v", shutdown)
+	}
+}
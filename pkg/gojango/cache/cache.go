@@ -0,0 +1,140 @@
+// Package cache provides a key-value cache with in-memory and Redis
+// backends, in the style of Django's cache framework.
+//
+// Values are byte slices; callers encode what they store:
+//
+//	count, err := c.GetOrSet(ctx, "posts:count", time.Minute, func() ([]byte, error) {
+//		n, err := countPosts(ctx)
+//		return []byte(strconv.Itoa(n)), err
+//	})
+//
+// CacheView caches whole responses of gin handlers in the default cache,
+// and InvalidateOnWrite drops them when models are saved:
+//
+//	router.GET("/posts", cache.CacheView(time.Minute, cache.ByQuery("posts:list", "page"))(listPosts))
+//	cache.InvalidateOnWrite(&ent.Post{}, "posts:*")
+package cache
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Cache stores values by key. A ttl of zero or less keeps a value until it
+// is deleted or evicted.
+type Cache interface {
+	// Get returns the value stored under key, and false if there is none
+	// or it has expired
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// GetOrSet returns the value stored under key, or stores and returns
+	// the value fn computes. Concurrent misses for the same key in a
+	// process share one call of fn. A value that fails to be stored is
+	// still returned.
+	GetOrSet(ctx context.Context, key string, ttl time.Duration, fn func() ([]byte, error)) ([]byte, error)
+}
+
+// Config selects and configures a cache backend
+type Config struct {
+	// Backend is "memory" (the default) or "redis"
+	Backend string
+
+	// Location is the Redis URL, e.g. redis://localhost:6379/1
+	Location string
+
+	// MaxEntries caps the entries of a memory cache, DefaultMaxEntries
+	// when zero
+	MaxEntries int
+
+	// KeyPrefix prefixes Redis keys, DefaultRedisKeyPrefix when empty
+	KeyPrefix string
+}
+
+// New creates the cache a configuration describes
+func New(config Config) (Cache, error) {
+	switch config.Backend {
+	case "", "memory":
+		return NewMemoryCache(config.MaxEntries), nil
+	case "redis":
+		c, err := NewRedisCache(config.Location)
+		if err != nil {
+			return nil, err
+		}
+		if config.KeyPrefix != "" {
+			c.SetKeyPrefix(config.KeyPrefix)
+		}
+		return c, nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend: %s", config.Backend)
+	}
+}
+
+// getOrSet implements GetOrSet for a backend, computing missing values once
+// per key at a time
+func getOrSet(ctx context.Context, c Cache, flights *flightGroup, key string, ttl time.Duration, fn func() ([]byte, error)) ([]byte, error) {
+	if value, ok, err := c.Get(ctx, key); err != nil || ok {
+		return value, err
+	}
+
+	return flights.do(key, func() ([]byte, error) {
+		// An earlier flight may have stored the value since the miss
+		if value, ok, err := c.Get(ctx, key); err != nil || ok {
+			return value, err
+		}
+
+		value, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Set(ctx, key, value, ttl); err != nil {
+			log.Printf("Error caching %s: %v", key, err)
+		}
+		return value, nil
+	})
+}
+
+// flightGroup runs one call per key at a time, sharing its result with the
+// callers that arrive while it runs
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+func (g *flightGroup) do(key string, fn func() ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+	call.value, call.err = fn()
+	return call.value, call.err
+}
@@ -0,0 +1,249 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMemoryCacheTTL(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(0)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "short", []byte("a"), time.Minute)
+	c.Set(ctx, "forever", []byte("b"), 0)
+
+	if value, ok, _ := c.Get(ctx, "short"); !ok || string(value) != "a" {
+		t.Errorf("Expected a fresh value, got %q, %v", value, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := c.Get(ctx, "short"); ok {
+		t.Error("Value should expire after its TTL")
+	}
+	if value, ok, _ := c.Get(ctx, "forever"); !ok || string(value) != "b" {
+		t.Errorf("Value without a TTL should not expire, got %q, %v", value, ok)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Expired entry should be removed, got %d entries", c.Len())
+	}
+
+	c.Delete(ctx, "forever")
+	if _, ok, _ := c.Get(ctx, "forever"); ok {
+		t.Error("Deleted value should be gone")
+	}
+}
+
+func TestMemoryCacheLRUEviction(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(2)
+
+	c.Set(ctx, "a", []byte("1"), 0)
+	c.Set(ctx, "b", []byte("2"), 0)
+	c.Get(ctx, "a") // a is now more recently used than b
+	c.Set(ctx, "c", []byte("3"), 0)
+
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Error("Least recently used entry should be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok, _ := c.Get(ctx, key); !ok {
+			t.Errorf("Expected %q to stay cached", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", c.Len())
+	}
+}
+
+func TestMemoryCacheCopiesValues(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(0)
+
+	value := []byte("abc")
+	c.Set(ctx, "key", value, 0)
+	value[0] = 'x'
+
+	got, _, _ := c.Get(ctx, "key")
+	got[1] = 'y'
+	if again, _, _ := c.Get(ctx, "key"); string(again) != "abc" {
+		t.Errorf("Cached value should not alias callers' slices, got %q", again)
+	}
+}
+
+func TestGetOrSetSingleFlight(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(0)
+
+	var calls int32
+	release := make(chan struct{})
+	fn := func() ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return []byte("42"), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := c.GetOrSet(ctx, "answer", time.Minute, fn)
+			if err != nil {
+				t.Errorf("GetOrSet failed: %v", err)
+			}
+			results[i] = string(value)
+		}(i)
+	}
+
+	// Let the callers pile up behind the first one
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected fn to run once, ran %d times", calls)
+	}
+	for _, result := range results {
+		if result != "42" {
+			t.Errorf("Expected every caller to get 42, got %q", result)
+		}
+	}
+
+	value, err := c.GetOrSet(ctx, "answer", time.Minute, func() ([]byte, error) {
+		t.Error("Cached value should be returned without calling fn")
+		return nil, nil
+	})
+	if err != nil || string(value) != "42" {
+		t.Errorf("Expected the cached value, got %q, %v", value, err)
+	}
+}
+
+func TestGetOrSetError(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(0)
+
+	_, err := c.GetOrSet(ctx, "key", time.Minute, func() ([]byte, error) {
+		return nil, errors.New("database down")
+	})
+	if err == nil || err.Error() != "database down" {
+		t.Errorf("Expected fn's error, got %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "key"); ok {
+		t.Error("Failed computations should not be cached")
+	}
+}
+
+// unwritableCache is a cache that fails to store values
+type unwritableCache struct {
+	*MemoryCache
+}
+
+func (c unwritableCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("cache full")
+}
+
+func TestGetOrSetStoreError(t *testing.T) {
+	ctx := context.Background()
+	c := unwritableCache{NewMemoryCache(0)}
+
+	value, err := getOrSet(ctx, c, &flightGroup{}, "key", time.Minute, func() ([]byte, error) {
+		return []byte("42"), nil
+	})
+	if err != nil || string(value) != "42" {
+		t.Errorf("Expected the computed value despite the failed store, got %q, %v", value, err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if c, err := New(Config{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	} else if _, ok := c.(*MemoryCache); !ok {
+		t.Errorf("Expected a memory cache by default, got %T", c)
+	}
+
+	c, err := New(Config{Backend: "redis", Location: "redis://cache:6379/1", KeyPrefix: "blog"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if key := c.(*RedisCache).key("posts"); key != "blog:posts" {
+		t.Errorf("Expected prefixed key, got %q", key)
+	}
+
+	if _, err := New(Config{Backend: "memcached"}); err == nil {
+		t.Error("Expected an error for an unknown backend")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := NewMemoryCache(0)
+
+	calls := 0
+	router := gin.New()
+	router.GET("/posts", Middleware(c, time.Minute, "Accept-Language"), func(ctx *gin.Context) {
+		calls++
+		ctx.Header("X-Calls", "counted")
+		ctx.String(http.StatusOK, "posts in %s", ctx.GetHeader("Accept-Language"))
+	})
+	router.GET("/private", Middleware(c, time.Minute), func(ctx *gin.Context) {
+		calls++
+		ctx.Header("Cache-Control", "private")
+		ctx.String(http.StatusOK, "mine")
+	})
+
+	get := func(path, language string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Language", language)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	get("/posts?page=1", "en")
+	w := get("/posts?page=1", "en")
+	if calls != 1 {
+		t.Errorf("Expected the cached response to skip the handler, got %d calls", calls)
+	}
+	if w.Code != http.StatusOK || w.Body.String() != "posts in en" || w.Header().Get("X-Calls") != "counted" {
+		t.Errorf("Unexpected cached response: %d %q %v", w.Code, w.Body.String(), w.Header())
+	}
+
+	if w := get("/posts?page=1", "fr"); w.Body.String() != "posts in fr" {
+		t.Errorf("Vary header should be part of the key, got %q", w.Body.String())
+	}
+	get("/posts?page=2", "en")
+	if calls != 3 {
+		t.Errorf("Expected other URLs and languages to miss, got %d calls", calls)
+	}
+
+	get("/private", "en")
+	get("/private", "en")
+	if calls != 5 {
+		t.Errorf("Private responses should not be cached, got %d calls", calls)
+	}
+
+	// Cached responses are revalidated by their ETag
+	tag := w.Header().Get("ETag")
+	if tag == "" {
+		t.Fatal("Expected the cached response to carry an ETag")
+	}
+	req := httptest.NewRequest(http.MethodGet, "/posts?page=1", nil)
+	req.Header.Set("Accept-Language", "en")
+	req.Header.Set("If-None-Match", tag)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("Expected 304 Not Modified without a body, got %d %q", w.Code, w.Body.String())
+	}
+}
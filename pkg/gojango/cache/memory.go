@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMaxEntries is the size of a memory cache that doesn't set one
+const DefaultMaxEntries = 1000
+
+// MemoryCache keeps values in process memory, evicting the least recently
+// used entry when it is full. Each process has its own cache, so it suits
+// development and single-server deployments.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // Most recently used at the front
+	flights    flightGroup
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key     string
+	value   []byte
+	expires time.Time // Zero for entries that don't expire
+}
+
+// NewMemoryCache creates a cache holding up to maxEntries values, or
+// DefaultMaxEntries when maxEntries is zero or less
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get returns a copy of the value stored under key
+func (c *MemoryCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*memoryEntry)
+	if !entry.expires.IsZero() && !c.now().Before(entry.expires) {
+		c.remove(element)
+		return nil, false, nil
+	}
+	c.order.MoveToFront(element)
+	return append([]byte(nil), entry.value...), true, nil
+}
+
+// Set stores a copy of value, evicting the least recently used entry when
+// the cache is full
+func (c *MemoryCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{key: key, value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expires = c.now().Add(ttl)
+	}
+
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return nil
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+// Delete removes key
+func (c *MemoryCache) Delete(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+// DeletePattern removes the keys matching pattern, in which * matches any
+// run of characters, and returns how many it removed
+func (c *MemoryCache) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for key, element := range c.entries {
+		if matchPattern(pattern, key) {
+			c.remove(element)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// GetOrSet returns the value stored under key, or stores the one fn computes
+func (c *MemoryCache) GetOrSet(ctx context.Context, key string, ttl time.Duration, fn func() ([]byte, error)) ([]byte, error) {
+	return getOrSet(ctx, c, &c.flights, key, ttl, fn)
+}
+
+// Len returns the number of entries, including expired ones not yet removed
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,182 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cachedResponse is a response stored by Middleware and CacheView
+type cachedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   []byte      `json:"body"`
+}
+
+// Middleware caches successful GET responses of the routes it wraps for
+// ttl, keyed by the request URL and the values of the vary headers, e.g.
+// "Accept-Language". Responses are cached and revalidated like CacheView's.
+// Cache errors are logged and the request is served uncached.
+func Middleware(c Cache, ttl time.Duration, vary ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method != http.MethodGet {
+			ctx.Next()
+			return
+		}
+		serveCached(ctx, c, responseKey(ctx.Request, vary), ttl, ctx.Next)
+	}
+}
+
+// serveCached serves the response stored under key, or runs next with the
+// response held back and stores it for ttl. Responses that set cookies or
+// are marked private or no-store aren't stored. Stored responses carry an
+// ETag, and a max-age matching ttl unless next sets Cache-Control, so
+// conditional requests for an unchanged response get 304 Not Modified.
+func serveCached(ctx *gin.Context, c Cache, key string, ttl time.Duration, next func()) {
+	data, ok, err := c.Get(ctx, key)
+	if err != nil {
+		log.Printf("Error reading cached response: %v", err)
+	}
+	if ok {
+		var response cachedResponse
+		if err := json.Unmarshal(data, &response); err == nil {
+			serveResponse(ctx, &response)
+			return
+		}
+	}
+
+	writer := &bufferingWriter{ResponseWriter: ctx.Writer}
+	ctx.Writer = writer
+	next()
+	ctx.Writer = writer.ResponseWriter
+
+	response := &cachedResponse{
+		Status: writer.Status(),
+		Header: writer.Header(),
+		Body:   writer.body.Bytes(),
+	}
+	if cacheable(response) {
+		response.Header.Set("ETag", etag(response.Body))
+		if response.Header.Get("Cache-Control") == "" && ttl > 0 {
+			response.Header.Set("Cache-Control", "max-age="+strconv.Itoa(int(ttl.Seconds())))
+		}
+		data, err := json.Marshal(response)
+		if err == nil {
+			err = c.Set(ctx, key, data, ttl)
+		}
+		if err != nil {
+			log.Printf("Error caching response: %v", err)
+		}
+	}
+	serveResponse(ctx, response)
+}
+
+// responseKey derives the cache key of a request
+func responseKey(r *http.Request, vary []string) string {
+	h := sha256.New()
+	h.Write([]byte(r.URL.RequestURI()))
+	for _, name := range vary {
+		h.Write([]byte("\n" + http.CanonicalHeaderKey(name) + ": " + r.Header.Get(name)))
+	}
+	return "response:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// cacheable reports whether a response may be shared
+func cacheable(response *cachedResponse) bool {
+	if response.Status != http.StatusOK || response.Header.Get("Set-Cookie") != "" {
+		return false
+	}
+	control := strings.ToLower(response.Header.Get("Cache-Control"))
+	return !strings.Contains(control, "private") && !strings.Contains(control, "no-store")
+}
+
+// serveResponse writes a response, or 304 Not Modified when the request
+// already has it
+func serveResponse(ctx *gin.Context, response *cachedResponse) {
+	header := ctx.Writer.Header()
+	for name, values := range response.Header {
+		header[name] = values
+	}
+
+	if tag := header.Get("ETag"); tag != "" && etagMatches(ctx.GetHeader("If-None-Match"), tag) {
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		ctx.Status(http.StatusNotModified)
+		ctx.Writer.WriteHeaderNow()
+		ctx.Abort()
+		return
+	}
+
+	ctx.Status(response.Status)
+	ctx.Writer.Write(response.Body)
+	ctx.Abort()
+}
+
+// etag returns a strong entity tag for a response body
+func etag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header lists tag, comparing
+// weakly as RFC 9110 requires for GET
+func etagMatches(ifNoneMatch, tag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferingWriter holds back a response until the handler is done, so its
+// headers can still be changed
+type bufferingWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferingWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferingWriter) WriteHeaderNow() {}
+
+func (w *bufferingWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferingWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferingWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *bufferingWriter) Size() int {
+	return w.body.Len()
+}
+
+func (w *bufferingWriter) Written() bool {
+	return w.status != 0 || w.body.Len() > 0
+}
@@ -0,0 +1,130 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/internal/redis"
+)
+
+// DefaultRedisKeyPrefix prefixes the keys used by RedisCache
+const DefaultRedisKeyPrefix = "gojango:cache"
+
+// RedisCache stores values in Redis, so every server shares them. Expiry is
+// left to Redis.
+type RedisCache struct {
+	client  *redis.Client
+	prefix  string
+	flights flightGroup
+}
+
+// NewRedisCache creates a cache for a Redis URL such as
+// redis://:password@localhost:6379/1. The connection is opened on first use.
+func NewRedisCache(redisURL string) (*RedisCache, error) {
+	client, err := redis.NewClient(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisCache{client: client, prefix: DefaultRedisKeyPrefix}, nil
+}
+
+// SetKeyPrefix changes the prefix of the cache's keys, so several projects
+// can share a Redis database
+func (c *RedisCache) SetKeyPrefix(prefix string) *RedisCache {
+	c.prefix = prefix
+	return c
+}
+
+func (c *RedisCache) key(name string) string {
+	return c.prefix + ":" + name
+}
+
+// Get returns the value stored under key
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.client.Do(ctx, "GET", c.key(key))
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+	value, ok := reply.(string)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected GET reply: %T", reply)
+	}
+	return []byte(value), true, nil
+}
+
+// Set stores value under key, expiring it after ttl
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", c.key(key), string(value)}
+	if ttl > 0 {
+		ms := ttl.Milliseconds()
+		if ms == 0 {
+			ms = 1
+		}
+		args = append(args, "PX", strconv.FormatInt(ms, 10))
+	}
+	_, err := c.client.Do(ctx, args...)
+	return err
+}
+
+// Delete removes key
+func (c *RedisCache) Delete(ctx context.Context, key string) error {
+	_, err := c.client.Do(ctx, "DEL", c.key(key))
+	return err
+}
+
+// DeletePattern removes the keys matching pattern, in which * matches any
+// run of characters, and returns how many it removed. Keys are found with
+// SCAN, so keys set meanwhile may survive.
+func (c *RedisCache) DeletePattern(ctx context.Context, pattern string) (int, error) {
+	match := redisGlobEscaper.Replace(c.key(pattern))
+	deleted := 0
+	cursor := "0"
+	for {
+		reply, err := c.client.Do(ctx, "SCAN", cursor, "MATCH", match, "COUNT", "100")
+		if err != nil {
+			return deleted, err
+		}
+		items, ok := reply.([]interface{})
+		if !ok || len(items) != 2 {
+			return deleted, fmt.Errorf("unexpected SCAN reply: %v", reply)
+		}
+		cursor, _ = items[0].(string)
+		keys, _ := items[1].([]interface{})
+
+		if len(keys) > 0 {
+			args := []string{"DEL"}
+			for _, key := range keys {
+				if s, ok := key.(string); ok {
+					args = append(args, s)
+				}
+			}
+			n, err := c.client.Do(ctx, args...)
+			if err != nil {
+				return deleted, err
+			}
+			if count, ok := n.(int64); ok {
+				deleted += int(count)
+			}
+		}
+		if cursor == "0" || cursor == "" {
+			return deleted, nil
+		}
+	}
+}
+
+// redisGlobEscaper escapes the glob characters other than * in a pattern
+var redisGlobEscaper = strings.NewReplacer(`\`, `\\`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// GetOrSet returns the value stored under key, or stores the one fn
+// computes. Only misses in the same process share a call of fn.
+func (c *RedisCache) GetOrSet(ctx context.Context, key string, ttl time.Duration, fn func() ([]byte, error)) ([]byte, error) {
+	return getOrSet(ctx, c, &c.flights, key, ttl, fn)
+}
+
+// Close closes the Redis connection
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
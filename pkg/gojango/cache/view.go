@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
+	"github.com/gin-gonic/gin"
+)
+
+// viewKeyPrefix prefixes the keys CacheView stores responses under
+const viewKeyPrefix = "view:"
+
+// userContextKey is the gin context key authentication middleware stores
+// the current user under
+const userContextKey = "user"
+
+var (
+	defaultCacheMu sync.RWMutex
+	defaultCache   Cache = NewMemoryCache(0)
+)
+
+// SetDefault sets the cache used by CacheView and Invalidate
+func SetDefault(c Cache) {
+	defaultCacheMu.Lock()
+	defer defaultCacheMu.Unlock()
+	defaultCache = c
+}
+
+// Default returns the cache used by CacheView and Invalidate. It is an
+// in-memory cache unless configured otherwise.
+func Default() Cache {
+	defaultCacheMu.RLock()
+	defer defaultCacheMu.RUnlock()
+	return defaultCache
+}
+
+// PatternDeleter is implemented by caches that can delete every key
+// matching a pattern in which * matches any run of characters
+type PatternDeleter interface {
+	DeletePattern(ctx context.Context, pattern string) (int, error)
+}
+
+// KeyFunc derives the key a view's response is cached under
+type KeyFunc func(c *gin.Context) string
+
+// ByQuery keys a view by name and the given query parameters, or every
+// query parameter when none are given, e.g. "posts:list?page=2"
+func ByQuery(name string, params ...string) KeyFunc {
+	return func(c *gin.Context) string {
+		query := c.Request.URL.Query()
+		if len(params) > 0 {
+			selected := make(map[string][]string, len(params))
+			for _, param := range params {
+				if values, ok := query[param]; ok {
+					selected[param] = values
+				}
+			}
+			query = selected
+		}
+		if len(query) == 0 {
+			return name
+		}
+		return name + "?" + query.Encode()
+	}
+}
+
+// ByUser extends a key with the ID of the authenticated user, for views
+// whose content depends on who is viewing. Anonymous requests share a key.
+func ByUser(keyFn KeyFunc) KeyFunc {
+	return func(c *gin.Context) string {
+		key := keyFn(c) + "|user="
+		user, ok := c.Get(userContextKey)
+		if !ok || user == nil {
+			return key
+		}
+		if identified, ok := user.(interface{ GetID() string }); ok {
+			return key + identified.GetID()
+		}
+		return key + fmt.Sprint(user)
+	}
+}
+
+// CacheView wraps a GET handler so its successful responses are cached in
+// the default cache for ttl under the key keyFn returns, until they expire
+// or are invalidated. Responses that set cookies or are marked private or
+// no-store aren't cached. Responses carry an ETag, and a max-age matching
+// ttl unless the handler sets Cache-Control, so conditional requests for
+// an unchanged response get 304 Not Modified.
+func CacheView(ttl time.Duration, keyFn KeyFunc) func(gin.HandlerFunc) gin.HandlerFunc {
+	return func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(ctx *gin.Context) {
+			if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+				handler(ctx)
+				return
+			}
+
+			serveCached(ctx, Default(), viewKeyPrefix+keyFn(ctx), ttl, func() { handler(ctx) })
+		}
+	}
+}
+
+// Invalidate removes the cached views whose keys match pattern, in which *
+// matches any run of characters, e.g. "posts:*"
+func Invalidate(ctx context.Context, pattern string) error {
+	deleter, ok := Default().(PatternDeleter)
+	if !ok {
+		return fmt.Errorf("cache %T can't delete keys by pattern", Default())
+	}
+	_, err := deleter.DeletePattern(ctx, viewKeyPrefix+pattern)
+	return err
+}
+
+// InvalidateOnWrite invalidates the views matching patterns whenever an
+// object of model's type is saved or deleted, as signalled by PostSave and
+// PostDelete. A nil model matches every model. It returns a function that
+// stops invalidating.
+func InvalidateOnWrite(model interface{}, patterns ...string) func() {
+	receiver := func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		if model != nil && reflect.TypeOf(sender) != reflect.TypeOf(model) {
+			return nil
+		}
+		for _, pattern := range patterns {
+			if err := Invalidate(ctx, pattern); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	saveID := signals.PostSave.Connect(receiver)
+	deleteID := signals.PostDelete.Connect(receiver)
+	return func() {
+		signals.PostSave.Disconnect(saveID)
+		signals.PostDelete.Disconnect(deleteID)
+	}
+}
+
+// matchPattern reports whether key matches pattern, in which * matches any
+// run of characters
+func matchPattern(pattern, key string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == key
+	}
+	if !strings.HasPrefix(key, parts[0]) {
+		return false
+	}
+	key = key[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(key, part)
+		if i < 0 {
+			return false
+		}
+		key = key[i+len(part):]
+	}
+	return strings.HasSuffix(key, parts[len(parts)-1])
+}
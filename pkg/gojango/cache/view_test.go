@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/signals"
+	"github.com/gin-gonic/gin"
+)
+
+// post mimics a model sent with save signals
+type post struct{ ID int }
+
+// viewUser mimics an authenticated user
+type viewUser struct{ id string }
+
+func (u *viewUser) GetID() string { return u.id }
+
+func useMemoryCache(t *testing.T) *MemoryCache {
+	previous := Default()
+	c := NewMemoryCache(0)
+	SetDefault(c)
+	t.Cleanup(func() { SetDefault(previous) })
+	return c
+}
+
+func TestCacheView(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useMemoryCache(t)
+
+	calls := 0
+	posts := "first"
+	router := gin.New()
+	router.GET("/posts", CacheView(time.Minute, ByQuery("posts:list", "page"))(func(c *gin.Context) {
+		calls++
+		c.String(http.StatusOK, "%s page %s", posts, c.DefaultQuery("page", "1"))
+	}))
+	stop := InvalidateOnWrite(&post{}, "posts:*")
+	defer stop()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	first := get("/posts?page=2&utm=mail")
+	second := get("/posts?page=2")
+	if calls != 1 {
+		t.Errorf("Expected a cached hit to skip the handler, got %d calls", calls)
+	}
+	if second.Body.String() != "first page 2" || second.Header().Get("ETag") != first.Header().Get("ETag") {
+		t.Errorf("Unexpected cached response: %q %v", second.Body.String(), second.Header())
+	}
+	if control := second.Header().Get("Cache-Control"); control != "max-age=60" {
+		t.Errorf("Expected max-age from the TTL, got %q", control)
+	}
+
+	// Saving another model leaves the views alone
+	signals.PostSave.Send(context.Background(), &struct{ Name string }{}, nil)
+	get("/posts?page=2")
+	if calls != 1 {
+		t.Errorf("Writes to other models should not invalidate, got %d calls", calls)
+	}
+
+	posts = "second"
+	if err := signals.PostSave.Send(context.Background(), &post{ID: 1}, map[string]interface{}{"created": true}); err != nil {
+		t.Fatalf("PostSave failed: %v", err)
+	}
+	if w := get("/posts?page=2"); w.Body.String() != "second page 2" {
+		t.Errorf("Expected a write to invalidate the view, got %q", w.Body.String())
+	}
+	if calls != 2 {
+		t.Errorf("Expected the handler to run again, got %d calls", calls)
+	}
+
+	stop()
+	posts = "third"
+	signals.PostDelete.Send(context.Background(), &post{ID: 1}, nil)
+	if w := get("/posts?page=2"); w.Body.String() != "second page 2" {
+		t.Errorf("Stopped invalidation should keep the view cached, got %q", w.Body.String())
+	}
+}
+
+func TestCacheViewConditionalRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	useMemoryCache(t)
+
+	calls := 0
+	router := gin.New()
+	router.GET("/feed", CacheView(time.Minute, ByQuery("feed"))(func(c *gin.Context) {
+		calls++
+		c.Header("Cache-Control", "public, max-age=5")
+		c.JSON(http.StatusOK, gin.H{"items": []string{"a", "b"}})
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feed", nil))
+	tag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || tag == "" {
+		t.Fatalf("Expected a 200 with an ETag, got %d %v", w.Code, w.Header())
+	}
+	if control := w.Header().Get("Cache-Control"); control != "public, max-age=5" {
+		t.Errorf("Handler's Cache-Control should be kept, got %q", control)
+	}
+
+	for _, ifNoneMatch := range []string{tag, `"other", W/` + tag, "*"} {
+		req := httptest.NewRequest(http.MethodGet, "/feed", nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: expected an empty 304, got %d %q", ifNoneMatch, w.Code, w.Body.String())
+		}
+		if w.Header().Get("ETag") != tag {
+			t.Errorf("If-None-Match %s: 304 should repeat the ETag", ifNoneMatch)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/feed", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("A stale ETag should get the full response, got %d", w.Code)
+	}
+	if calls != 1 {
+		t.Errorf("Expected every response after the first to come from the cache, got %d calls", calls)
+	}
+}
+
+func TestCacheViewSkipsFailures(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c := useMemoryCache(t)
+
+	router := gin.New()
+	router.GET("/missing", CacheView(time.Minute, ByQuery("missing"))(func(c *gin.Context) {
+		c.String(http.StatusNotFound, "not here")
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if w.Code != http.StatusNotFound || w.Body.String() != "not here" {
+		t.Errorf("Expected the handler's response, got %d %q", w.Code, w.Body.String())
+	}
+	if c.Len() != 0 {
+		t.Errorf("Failed responses should not be cached, got %d entries", c.Len())
+	}
+}
+
+func TestKeyFuncs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/posts?tag=go&page=2&utm=x", nil)
+
+	if key := ByQuery("posts", "page", "tag")(ctx); key != "posts?page=2&tag=go" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+	if key := ByQuery("posts")(ctx); key != "posts?page=2&tag=go&utm=x" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+	if key := ByQuery("posts", "sort")(ctx); key != "posts" {
+		t.Errorf("Unexpected key: %s", key)
+	}
+
+	perUser := ByUser(ByQuery("posts", "page"))
+	if key := perUser(ctx); key != "posts?page=2|user=" {
+		t.Errorf("Unexpected anonymous key: %s", key)
+	}
+	ctx.Set("user", &viewUser{id: "7"})
+	if key := perUser(ctx); key != "posts?page=2|user=7" {
+		t.Errorf("Unexpected user key: %s", key)
+	}
+}
+
+func TestDeletePattern(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(0)
+	for _, key := range []string{"view:posts:list", "view:posts:detail?id=1", "view:users:list", "posts"} {
+		c.Set(ctx, key, []byte("x"), 0)
+	}
+
+	deleted, err := c.DeletePattern(ctx, "view:posts:*")
+	if err != nil || deleted != 2 {
+		t.Errorf("Expected 2 keys deleted, got %d, %v", deleted, err)
+	}
+	if _, ok, _ := c.Get(ctx, "view:users:list"); !ok {
+		t.Error("Keys not matching the pattern should stay")
+	}
+
+	for _, tc := range []struct {
+		pattern, key string
+		match        bool
+	}{
+		{"posts:*", "posts:list", true},
+		{"*:list", "posts:list", true},
+		{"posts:*:page=*", "posts:list:page=2", true},
+		{"posts:*", "users:list", false},
+		{"posts", "posts:list", false},
+		{"a*a", "a", false},
+	} {
+		if got := matchPattern(tc.pattern, tc.key); got != tc.match {
+			t.Errorf("matchPattern(%q, %q) = %v", tc.pattern, tc.key, got)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// maxListDisplayFields caps the inferred list_display columns, not counting id
+const maxListDisplayFields = 4
+
+// sensitiveFieldMarkers are substrings of field names never shown or searched in the admin
+var sensitiveFieldMarkers = []string{"password", "secret", "token", "hash", "salt"}
+
+// AdminGenerator generates admin registration boilerplate from analyzed schemas
+type AdminGenerator struct {
+	analyzer *SchemaAnalyzer
+}
+
+// NewAdminGenerator creates a new admin registration generator
+func NewAdminGenerator(analyzer *SchemaAnalyzer) *AdminGenerator {
+	return &AdminGenerator{
+		analyzer: analyzer,
+	}
+}
+
+// AdminRegistration is the admin configuration generated for a single model
+type AdminRegistration struct {
+	Model        string
+	ListDisplay  []string
+	ListFilter   []string
+	SearchFields []string
+	Ordering     []string
+	AutoNowAdd   []string
+	AutoNow      []string
+}
+
+// Registrations returns the admin configuration inferred for each analyzed model
+func (g *AdminGenerator) Registrations() []AdminRegistration {
+	models := g.analyzer.GetModels()
+	registrations := make([]AdminRegistration, 0, len(models))
+	for _, model := range models {
+		registrations = append(registrations, inferAdminRegistration(model))
+	}
+	return registrations
+}
+
+// Render returns the formatted source of the app's admin.go. entPackage is
+// the import path of the app's generated Ent package; when empty it defaults
+// to the ent directory inside the app.
+func (g *AdminGenerator) Render(app AppScaffold, entPackage string) ([]byte, error) {
+	if entPackage == "" {
+		entPackage = app.ImportPath() + "/ent"
+	}
+
+	data := struct {
+		App           AppScaffold
+		EntPackage    string
+		EntAlias      bool
+		Registrations []AdminRegistration
+	}{
+		App:           app,
+		EntPackage:    entPackage,
+		EntAlias:      path.Base(entPackage) != "ent",
+		Registrations: g.Registrations(),
+	}
+
+	tmpl, err := template.New("admin").Funcs(template.FuncMap{
+		"args": quoteArgs,
+	}).Parse(adminGoTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render admin.go: %w", err)
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format admin.go: %w", err)
+	}
+	return source, nil
+}
+
+// Generate writes apps/<app>/admin.go. It refuses to overwrite an existing
+// file so customizations are never lost.
+func (g *AdminGenerator) Generate(target ScaffoldTarget, app AppScaffold, entPackage string) error {
+	if err := ValidateAppName(app.Name); err != nil {
+		return err
+	}
+
+	file := path.Join(app.Dir(), "admin.go")
+	if target.Exists(file) {
+		return fmt.Errorf("%s already exists", file)
+	}
+
+	source, err := g.Render(app, entPackage)
+	if err != nil {
+		return err
+	}
+
+	if err := target.WriteFile(file, source); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file, err)
+	}
+	return nil
+}
+
+// inferAdminRegistration picks sensible admin defaults from a model's fields.
+// Explicit admin configuration on the schema takes precedence.
+func inferAdminRegistration(model *ModelInfo) AdminRegistration {
+	registration := AdminRegistration{Model: model.Name}
+
+	hasCreatedAt := false
+	for _, field := range model.Fields {
+		switch field.Name {
+		case "created_at":
+			hasCreatedAt = true
+			registration.AutoNowAdd = append(registration.AutoNowAdd, field.Name)
+		case "updated_at":
+			registration.AutoNow = append(registration.AutoNow, field.Name)
+		}
+		if isSensitiveField(field.Name) {
+			continue
+		}
+
+		if field.Name == "id" || len(registration.ListDisplay) <= maxListDisplayFields {
+			registration.ListDisplay = append(registration.ListDisplay, field.Name)
+		}
+
+		switch field.Type {
+		case "string":
+			registration.SearchFields = append(registration.SearchFields, field.Name)
+		case "bool", "enum":
+			registration.ListFilter = append(registration.ListFilter, field.Name)
+		case "time":
+			if field.Name != "updated_at" {
+				registration.ListFilter = append(registration.ListFilter, field.Name)
+			}
+		}
+	}
+
+	if hasCreatedAt {
+		registration.Ordering = []string{"-created_at"}
+	} else {
+		registration.Ordering = []string{"-id"}
+	}
+
+	if config := model.AdminConfig; config != nil {
+		if len(config.ListDisplay) > 0 {
+			registration.ListDisplay = config.ListDisplay
+		}
+		if len(config.SearchFields) > 0 {
+			registration.SearchFields = config.SearchFields
+		}
+	}
+
+	return registration
+}
+
+// isSensitiveField reports whether a field likely holds a credential
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, marker := range sensitiveFieldMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteArgs renders strings as a Go argument list
+func quoteArgs(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+const adminGoTemplate = `package {{.App.Name}}
+
+// Admin registrations generated by "manage.go generate admin".
+// Adjust the options below to customize the admin for each model.
+
+import (
+	"github.com/epuerta9/gojango/pkg/gojango/admin"
+
+	{{if .EntAlias}}ent {{end}}"{{.EntPackage}}"
+)
+
+func init() {
+{{- range .Registrations}}
+	admin.Register(&ent.{{.Model}}{}, admin.NewModelAdmin(&ent.{{.Model}}{}).
+		{{- if .ListDisplay}}
+		SetListDisplay({{args .ListDisplay}}).
+		{{- end}}
+		{{- if .ListFilter}}
+		SetListFilter({{args .ListFilter}}).
+		{{- end}}
+		{{- if .SearchFields}}
+		SetSearchFields({{args .SearchFields}}).
+		{{- end}}
+		{{- if .AutoNowAdd}}
+		SetAutoNowAdd({{args .AutoNowAdd}}).
+		{{- end}}
+		{{- if .AutoNow}}
+		SetAutoNow({{args .AutoNow}}).
+		{{- end}}
+		SetOrdering({{args .Ordering}}))
+{{- end}}
+}
+`
@@ -0,0 +1,203 @@
+package codegen
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const samplePostSchema = `package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// Post holds the schema definition for the Post entity.
+type Post struct {
+	ent.Schema
+}
+
+// Fields of the Post.
+func (Post) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("title"),
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+`
+
+const sampleCommentSchema = `package schema
+
+import "entgo.io/ent"
+
+// Comment holds the schema definition for the Comment entity.
+type Comment struct {
+	ent.Schema
+}
+`
+
+func newSampleAdminGenerator(t *testing.T) *AdminGenerator {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"post.go":    samplePostSchema,
+		"comment.go": sampleCommentSchema,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write schema: %v", err)
+		}
+	}
+
+	analyzer := NewEntSchemaAnalyzer(dir)
+	if err := analyzer.Analyze(); err != nil {
+		t.Fatalf("Failed to analyze schemas: %v", err)
+	}
+	return NewAdminGenerator(analyzer)
+}
+
+// registeredModels returns the ent types passed to admin.Register in a file
+func registeredModels(file *ast.File) []string {
+	var models []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || sel.Sel.Name != "Register" {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "admin" || len(call.Args) != 2 {
+			return true
+		}
+		if unary, ok := call.Args[0].(*ast.UnaryExpr); ok {
+			if lit, ok := unary.X.(*ast.CompositeLit); ok {
+				if typ, ok := lit.Type.(*ast.SelectorExpr); ok {
+					models = append(models, typ.Sel.Name)
+				}
+			}
+		}
+		return true
+	})
+	return models
+}
+
+func TestAdminGeneratorGenerate(t *testing.T) {
+	generator := newSampleAdminGenerator(t)
+	target := newMapTarget()
+	app := AppScaffold{Name: "blog", ModulePath: "example.com/mysite"}
+
+	if err := generator.Generate(target, app, ""); err != nil {
+		t.Fatalf("Failed to generate admin: %v", err)
+	}
+
+	generated, ok := target.files["apps/blog/admin.go"]
+	if !ok {
+		t.Fatal("Expected apps/blog/admin.go to be generated")
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "admin.go", generated.Data, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Generated admin.go does not parse: %v\n%s", err, generated.Data)
+	}
+	if file.Name.Name != "blog" {
+		t.Errorf("Expected package blog, got: %s", file.Name.Name)
+	}
+
+	imports := make(map[string]bool)
+	for _, spec := range file.Imports {
+		imports[strings.Trim(spec.Path.Value, `"`)] = true
+	}
+	for _, want := range []string{"github.com/epuerta9/gojango/pkg/gojango/admin", "example.com/mysite/apps/blog/ent"} {
+		if !imports[want] {
+			t.Errorf("Expected generated file to import %s", want)
+		}
+	}
+
+	registered := make(map[string]int)
+	for _, model := range registeredModels(file) {
+		registered[model]++
+	}
+	for _, model := range []string{"Post", "Comment"} {
+		if registered[model] != 1 {
+			t.Errorf("Expected exactly one registration for %s, got %d", model, registered[model])
+		}
+	}
+
+	source := string(generated.Data)
+	for _, want := range []string{
+		`SetListDisplay("id", "title", "created_at", "updated_at")`,
+		`SetAutoNowAdd("created_at")`,
+		`SetAutoNow("updated_at")`,
+		`SetOrdering("-created_at")`,
+	} {
+		if !strings.Contains(source, want) {
+			t.Errorf("Expected generated admin.go to contain %s\n%s", want, source)
+		}
+	}
+}
+
+func TestAdminGeneratorCustomEntPackage(t *testing.T) {
+	generator := newSampleAdminGenerator(t)
+	app := AppScaffold{Name: "blog", ModulePath: "example.com/mysite"}
+
+	source, err := generator.Render(app, "example.com/mysite/internal/models")
+	if err != nil {
+		t.Fatalf("Failed to render admin: %v", err)
+	}
+	if !strings.Contains(string(source), `ent "example.com/mysite/internal/models"`) {
+		t.Errorf("Expected the Ent package to be imported as ent\n%s", source)
+	}
+}
+
+func TestAdminGeneratorRefusesOverwrite(t *testing.T) {
+	generator := newSampleAdminGenerator(t)
+	target := newMapTarget()
+	app := AppScaffold{Name: "blog", ModulePath: "example.com/mysite"}
+	target.WriteFile("apps/blog/admin.go", []byte("package blog\n"))
+
+	if err := generator.Generate(target, app, ""); err == nil {
+		t.Fatal("Expected an error when admin.go already exists")
+	}
+	if string(target.files["apps/blog/admin.go"].Data) != "package blog\n" {
+		t.Error("Existing admin.go must not be overwritten")
+	}
+}
+
+func TestInferAdminRegistration(t *testing.T) {
+	model := &ModelInfo{
+		Name: "User",
+		Fields: []*FieldInfo{
+			{Name: "id", Type: "int"},
+			{Name: "email", Type: "string"},
+			{Name: "password_hash", Type: "string"},
+			{Name: "name", Type: "string"},
+			{Name: "is_active", Type: "bool"},
+			{Name: "bio", Type: "string"},
+			{Name: "nickname", Type: "string"},
+		},
+	}
+
+	registration := inferAdminRegistration(model)
+
+	if got := strings.Join(registration.ListDisplay, ","); got != "id,email,name,is_active,bio" {
+		t.Errorf("Unexpected list display: %s", got)
+	}
+	if got := strings.Join(registration.SearchFields, ","); got != "email,name,bio,nickname" {
+		t.Errorf("Unexpected search fields: %s", got)
+	}
+	if got := strings.Join(registration.ListFilter, ","); got != "is_active" {
+		t.Errorf("Unexpected list filter: %s", got)
+	}
+	if got := strings.Join(registration.Ordering, ","); got != "-id" {
+		t.Errorf("Unexpected ordering: %s", got)
+	}
+}
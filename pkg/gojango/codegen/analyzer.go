@@ -33,9 +33,17 @@ type FieldInfo struct {
 	ProtoType    string
 	JSONTag      string
 	Optional     bool
+	Nillable     bool
 	Unique       bool
 	Default      interface{}
 	Description  string
+
+	// Constraints, from enum values and validators such as MaxLen and Range
+	EnumValues []string
+	MaxLength  int
+	MinLength  int
+	Min        *float64
+	Max        *float64
 }
 
 // EdgeInfo represents model relationships
@@ -55,15 +63,24 @@ type AdminConfig struct {
 	Actions      []string
 }
 
-// NewSchemaAnalyzer creates a new schema analyzer
-func NewSchemaAnalyzer(schemaDir string) *SchemaAnalyzer {
+// NewEntSchemaAnalyzer creates an analyzer for the Ent schema files in
+// schemaDir
+func NewEntSchemaAnalyzer(schemaDir string) *SchemaAnalyzer {
 	return &SchemaAnalyzer{
 		schemaDir: schemaDir,
 		models:    make([]*ModelInfo, 0),
 	}
 }
 
-// Analyze scans and analyzes all Ent schemas
+// NewSchemaAnalyzer creates a new schema analyzer.
+//
+// Deprecated: use NewEntSchemaAnalyzer.
+func NewSchemaAnalyzer(schemaDir string) *SchemaAnalyzer {
+	return NewEntSchemaAnalyzer(schemaDir)
+}
+
+// Analyze scans and analyzes all Ent schemas. Errors name the file and line
+// of the definition that could not be read.
 func (a *SchemaAnalyzer) Analyze() error {
 	// Find all .go files in schema directory
 	files, err := filepath.Glob(filepath.Join(a.schemaDir, "*.go"))
@@ -76,87 +93,55 @@ func (a *SchemaAnalyzer) Analyze() error {
 			continue // Skip test files
 		}
 
-		model, err := a.analyzeFile(file)
+		models, err := a.analyzeFile(file)
 		if err != nil {
-			return fmt.Errorf("failed to analyze %s: %w", file, err)
-		}
-
-		if model != nil {
-			a.models = append(a.models, model)
+			return err
 		}
+		a.models = append(a.models, models...)
 	}
 
 	return nil
 }
 
-// analyzeFile analyzes a single schema file
-func (a *SchemaAnalyzer) analyzeFile(filename string) (*ModelInfo, error) {
+// analyzeFile analyzes the schemas declared in a single file: every exported
+// struct embedding ent.Schema, with the Fields, Edges and Mixin methods
+// declared on it in the same file
+func (a *SchemaAnalyzer) analyzeFile(filename string) ([]*ModelInfo, error) {
 	fset := token.NewFileSet()
 	node, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse file: %w", err)
+		return nil, err
 	}
 
-	var model *ModelInfo
-
-	// Look for struct types that implement ent.Schema
-	ast.Inspect(node, func(n ast.Node) bool {
-		switch x := n.(type) {
-		case *ast.TypeSpec:
-			if x.Name != nil && x.Name.IsExported() {
-				// Check if this is a schema struct
-				if _, ok := x.Type.(*ast.StructType); ok {
-					model = &ModelInfo{
-						Name:        x.Name.Name,
-						PackageName: node.Name.Name,
-						TableName:   toSnakeCase(x.Name.Name),
-						Fields:      make([]*FieldInfo, 0),
-						Edges:       make([]*EdgeInfo, 0),
-					}
-
-					// Look for Fields() and Edges() methods
-					model = a.extractSchemaInfo(node, model)
-				}
-			}
+	methods := schemaMethods(node)
+	var models []*ModelInfo
+	for _, decl := range node.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
 		}
-		return true
-	})
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if !typeSpec.Name.IsExported() || !embedsEntSchema(typeSpec) {
+				continue
+			}
 
-	return model, nil
-}
+			model := &ModelInfo{
+				Name:        typeSpec.Name.Name,
+				PackageName: node.Name.Name,
+				TableName:   entTableName(typeSpec.Name.Name),
+				Fields:      make([]*FieldInfo, 0),
+				Edges:       make([]*EdgeInfo, 0),
+			}
+			schema := &entSchemaParser{fset: fset, model: model}
+			if err := schema.parse(methods[model.Name]); err != nil {
+				return nil, err
+			}
+			models = append(models, model)
+		}
+	}
 
-// extractSchemaInfo extracts field and edge information from schema methods
-func (a *SchemaAnalyzer) extractSchemaInfo(node *ast.File, model *ModelInfo) *ModelInfo {
-	// This is a simplified implementation
-	// In a real implementation, you'd need to analyze the Fields() and Edges() methods
-	// and extract the field definitions, types, and configuration
-
-	// For now, we'll add some common fields that most models have
-	model.Fields = append(model.Fields, []*FieldInfo{
-		{
-			Name:      "id",
-			Type:      "int",
-			GoType:    "int",
-			ProtoType: "int64",
-			JSONTag:   "id",
-		},
-		{
-			Name:      "created_at",
-			Type:      "time",
-			GoType:    "time.Time",
-			ProtoType: "google.protobuf.Timestamp",
-			JSONTag:   "created_at",
-		},
-		{
-			Name:      "updated_at",
-			Type:      "time",
-			GoType:    "time.Time",
-			ProtoType: "google.protobuf.Timestamp",
-			JSONTag:   "updated_at",
-		},
-	}...)
-
-	return model
+	return models, nil
 }
 
 // GetModels returns the analyzed models
@@ -167,19 +152,55 @@ func (a *SchemaAnalyzer) GetModels() []*ModelInfo {
 // getOpenAPIType converts Go types to OpenAPI types
 func (f *FieldInfo) getOpenAPIType() string {
 	switch f.Type {
-	case "int", "int32", "int64":
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
 		return "integer"
-	case "float32", "float64":
+	case "float", "float32", "float64":
 		return "number"
 	case "bool":
 		return "boolean"
-	case "time":
+	case "json":
+		return "object"
+	case "time", "enum", "uuid", "bytes":
 		return "string"
 	default:
 		return "string"
 	}
 }
 
+// getOpenAPIFormat returns the OpenAPI format of a field, from its type or,
+// for strings, its name
+func (f *FieldInfo) getOpenAPIFormat() string {
+	switch f.Type {
+	case "time":
+		return "date-time"
+	case "uuid":
+		return "uuid"
+	case "bytes":
+		return "byte"
+	case "int64", "uint64":
+		return "int64"
+	case "int32", "uint32":
+		return "int32"
+	case "float", "float64":
+		return "double"
+	case "float32":
+		return "float"
+	case "string":
+		name := strings.ToLower(f.Name)
+		switch {
+		case strings.Contains(name, "email"):
+			return "email"
+		case name == "url" || name == "uri" || name == "website" ||
+			strings.HasSuffix(name, "_url") || strings.HasSuffix(name, "_uri"):
+			return "uri"
+		}
+	}
+	if f.Name == "id" {
+		return "int64"
+	}
+	return ""
+}
+
 // toSnakeCase converts CamelCase to snake_case
 func toSnakeCase(input string) string {
 	if len(input) == 0 {
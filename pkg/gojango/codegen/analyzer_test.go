@@ -0,0 +1,164 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleUserSchema = `package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixin.Time{},
+	}
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").Unique().MaxLen(254).Comment("Login address"),
+		field.String("nickname").Optional().Nillable().StructTag(` + "`json:\"nick,omitempty\"`" + `),
+		field.Int("age").Range(0, 150).Optional(),
+		field.Enum("role").Values("admin", "member").Default("member"),
+		field.Bool("active").Default(true),
+		field.Float("score").Positive(),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("posts", Post.Type),
+		edge.From("team", Team.Type).Ref("members").Unique().Field("team_id"),
+	}
+}
+
+// userFields is not a schema
+type userFields struct{}
+`
+
+// writeSchemaFiles writes schema files into a temp dir and returns it
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestEntSchemaAnalyzer(t *testing.T) {
+	dir := writeSchemaFiles(t, map[string]string{
+		"user.go":      sampleUserSchema,
+		"post.go":      samplePostSchema,
+		"user_test.go": "package schema\n",
+	})
+
+	analyzer := NewEntSchemaAnalyzer(dir)
+	if err := analyzer.Analyze(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	models := analyzer.GetModels()
+	var names []string
+	for _, model := range models {
+		names = append(names, model.Name)
+	}
+	if expected := []string{"Post", "User"}; !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected models %v, got: %v", expected, names)
+	}
+
+	user := models[1]
+	if user.PackageName != "schema" || user.TableName != "users" {
+		t.Errorf("Unexpected package or table: %s, %s", user.PackageName, user.TableName)
+	}
+
+	fields := make(map[string]*FieldInfo)
+	var fieldNames []string
+	for _, field := range user.Fields {
+		fields[field.Name] = field
+		fieldNames = append(fieldNames, field.Name)
+	}
+	expectedNames := []string{"id", "create_time", "update_time", "email", "nickname", "age", "role", "active", "score"}
+	if !reflect.DeepEqual(fieldNames, expectedNames) {
+		t.Fatalf("Expected fields %v, got: %v", expectedNames, fieldNames)
+	}
+
+	zero, one, maxAge := 0.0, 1.0, 150.0
+	expected := map[string]*FieldInfo{
+		"id":          {Name: "id", Type: "int", GoType: "int", ProtoType: "int64", JSONTag: "id"},
+		"create_time": {Name: "create_time", Type: "time", GoType: "time.Time", ProtoType: "google.protobuf.Timestamp", JSONTag: "create_time", Default: DefaultExpr("time.Now")},
+		"email":       {Name: "email", Type: "string", GoType: "string", ProtoType: "string", JSONTag: "email", Unique: true, MaxLength: 254, Description: "Login address"},
+		"nickname":    {Name: "nickname", Type: "string", GoType: "string", ProtoType: "string", JSONTag: "nick", Optional: true, Nillable: true},
+		"age":         {Name: "age", Type: "int", GoType: "int", ProtoType: "int64", JSONTag: "age", Optional: true, Min: &zero, Max: &maxAge},
+		"role":        {Name: "role", Type: "enum", GoType: "string", ProtoType: "string", JSONTag: "role", EnumValues: []string{"admin", "member"}, Default: "member"},
+		"active":      {Name: "active", Type: "bool", GoType: "bool", ProtoType: "bool", JSONTag: "active", Default: true},
+		"score":       {Name: "score", Type: "float64", GoType: "float64", ProtoType: "double", JSONTag: "score", Min: &one},
+	}
+	for name, want := range expected {
+		if !reflect.DeepEqual(fields[name], want) {
+			t.Errorf("Field %s: expected %+v, got: %+v", name, want, fields[name])
+		}
+	}
+
+	expectedEdges := []*EdgeInfo{
+		{Name: "posts", Type: "O2M", Target: "Post"},
+		{Name: "team", Type: "M2O", Target: "Team", Field: "team_id", Inverse: "members"},
+	}
+	if !reflect.DeepEqual(user.Edges, expectedEdges) {
+		t.Errorf("Expected edges %+v, got: %+v", expectedEdges, user.Edges)
+	}
+
+	post := models[0]
+	if post.Fields[2].Default != DefaultExpr("time.Now") {
+		t.Errorf("Expected created_at to default to time.Now, got: %v", post.Fields[2].Default)
+	}
+}
+
+func TestEntSchemaAnalyzerErrors(t *testing.T) {
+	tests := map[string]struct {
+		source string
+		line   string
+	}{
+		"syntax error": {
+			source: "package schema\n\ntype Broken struct {",
+			line:   "broken.go:3:",
+		},
+		"computed field name": {
+			source: "package schema\n\nimport \"entgo.io/ent\"\n\ntype Broken struct{ ent.Schema }\n\nfunc (Broken) Fields() []ent.Field {\n\treturn []ent.Field{\n\t\tfield.String(name),\n\t}\n}\n",
+			line:   "broken.go:9:",
+		},
+		"unknown field type": {
+			source: "package schema\n\nimport \"entgo.io/ent\"\n\ntype Broken struct{ ent.Schema }\n\nfunc (Broken) Fields() []ent.Field {\n\treturn []ent.Field{\n\t\tfield.Decimal(\"price\"),\n\t}\n}\n",
+			line:   "broken.go:9:",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeSchemaFiles(t, map[string]string{"broken.go": tt.source})
+			err := NewEntSchemaAnalyzer(dir).Analyze()
+			if err == nil || !strings.Contains(err.Error(), tt.line) {
+				t.Errorf("Expected an error at %s, got: %v", tt.line, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// ScaffoldTarget is the destination generated files are written to. Paths are
+// slash-separated and relative to the project root.
+type ScaffoldTarget interface {
+	Exists(name string) bool
+	MkdirAll(name string) error
+	WriteFile(name string, data []byte) error
+}
+
+// DirTarget writes generated files below a directory on disk
+type DirTarget string
+
+func (d DirTarget) path(name string) string {
+	return filepath.Join(string(d), filepath.FromSlash(name))
+}
+
+// Exists reports whether name exists below the directory
+func (d DirTarget) Exists(name string) bool {
+	_, err := os.Stat(d.path(name))
+	return err == nil
+}
+
+// MkdirAll creates a directory and any missing parents
+func (d DirTarget) MkdirAll(name string) error {
+	return os.MkdirAll(d.path(name), 0755)
+}
+
+// WriteFile writes data to a file, creating parent directories as needed
+func (d DirTarget) WriteFile(name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(d.path(name)), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(d.path(name), data, 0644)
+}
+
+// AppScaffold describes a new app to generate
+type AppScaffold struct {
+	Name       string // Package and app name, e.g. "blog"
+	ModulePath string // Go module path of the project
+}
+
+// TypeName returns the exported app type name, e.g. "BlogApp"
+func (a AppScaffold) TypeName() string {
+	return toPascalCase(a.Name) + "App"
+}
+
+// Title returns a human readable app name, e.g. "Blog"
+func (a AppScaffold) Title() string {
+	return toPascalCase(a.Name)
+}
+
+// Dir returns the app directory relative to the project root
+func (a AppScaffold) Dir() string {
+	return path.Join("apps", a.Name)
+}
+
+// ImportPath returns the Go import path of the app package
+func (a AppScaffold) ImportPath() string {
+	if a.ModulePath == "" {
+		return a.Dir()
+	}
+	return a.ModulePath + "/" + a.Dir()
+}
+
+// ImportLine returns the blank import that registers the app
+func (a AppScaffold) ImportLine() string {
+	return fmt.Sprintf("_ %q", a.ImportPath())
+}
+
+// ValidateAppName checks that name can be used as an app package name
+func ValidateAppName(name string) error {
+	if name == "" {
+		return fmt.Errorf("app name cannot be empty")
+	}
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("app name %q is not a valid Go identifier", name)
+	}
+	if name != strings.ToLower(name) {
+		return fmt.Errorf("app name %q must be lowercase", name)
+	}
+	if name == "main" || name == "gojango" {
+		return fmt.Errorf("app name %q is reserved", name)
+	}
+	return nil
+}
+
+// scaffoldFile is a single generated file
+type scaffoldFile struct {
+	path     string
+	template string
+}
+
+// appScaffoldFiles are the files generated for a new app, relative to its directory
+var appScaffoldFiles = []scaffoldFile{
+	{"app.go", appGoTemplate},
+	{"schema/.gitkeep", ""},
+	{"templates/[[.Name]]/index.html", appIndexTemplate},
+	{"static/[[.Name]]/.gitkeep", ""},
+}
+
+// ScaffoldApp generates a new app below apps/<name>. It refuses to overwrite
+// an existing app.
+func ScaffoldApp(target ScaffoldTarget, app AppScaffold) error {
+	if err := ValidateAppName(app.Name); err != nil {
+		return err
+	}
+	if target.Exists(app.Dir()) {
+		return fmt.Errorf("app %q already exists in %s", app.Name, app.Dir())
+	}
+
+	if err := target.MkdirAll(app.Dir()); err != nil {
+		return fmt.Errorf("failed to create app directory: %w", err)
+	}
+
+	for _, file := range appScaffoldFiles {
+		name, err := renderScaffoldTemplate(file.path, app)
+		if err != nil {
+			return err
+		}
+		content, err := renderScaffoldTemplate(file.template, app)
+		if err != nil {
+			return fmt.Errorf("failed to render %s: %w", name, err)
+		}
+
+		if err := target.WriteFile(path.Join(app.Dir(), name), []byte(content)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// renderScaffoldTemplate renders a template string with the app as data
+func renderScaffoldTemplate(text string, app AppScaffold) (string, error) {
+	// Template delimiters are changed so generated html/template files can
+	// contain {{ }} actions verbatim
+	tmpl, err := template.New("scaffold").Delims("[[", "]]").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, app); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// toPascalCase converts snake_case to PascalCase
+func toPascalCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		if part != "" {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+const appGoTemplate = `package [[.Name]]
+
+import (
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gojango.Register(&[[.TypeName]]{})
+}
+
+// [[.TypeName]] is the [[.Name]] application
+type [[.TypeName]] struct{}
+
+func (app *[[.TypeName]]) Config() gojango.AppConfig {
+	return gojango.AppConfig{
+		Name:    "[[.Name]]",
+		Label:   "[[.Title]] Application",
+		Version: "1.0.0",
+	}
+}
+
+func (app *[[.TypeName]]) Initialize(ctx *gojango.AppContext) error {
+	return nil
+}
+
+func (app *[[.TypeName]]) Routes() []gojango.Route {
+	return []gojango.Route{
+		{
+			Method:  "GET",
+			Path:    "/",
+			Handler: app.IndexView,
+			Name:    "index",
+		},
+	}
+}
+
+func (app *[[.TypeName]]) IndexView(c *gin.Context) {
+	render.Template(c, 200, "[[.Name]]/index.html", gin.H{
+		"title": "[[.Title]]",
+	})
+}
+`
+
+const appIndexTemplate = `<!DOCTYPE html>
+<html>
+<head>
+    <title>{{.title}}</title>
+</head>
+<body>
+    <h1>[[.Title]] App</h1>
+    <p>Welcome to the [[.Name]] application!</p>
+</body>
+</html>
+`
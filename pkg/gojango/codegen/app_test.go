@@ -0,0 +1,107 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// mapTarget collects generated files in an in-memory fs.FS
+type mapTarget struct {
+	files fstest.MapFS
+}
+
+func newMapTarget() *mapTarget {
+	return &mapTarget{files: fstest.MapFS{}}
+}
+
+func (m *mapTarget) Exists(name string) bool {
+	for path := range m.files {
+		if path == name || strings.HasPrefix(path, name+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mapTarget) MkdirAll(name string) error {
+	return nil
+}
+
+func (m *mapTarget) WriteFile(name string, data []byte) error {
+	m.files[name] = &fstest.MapFile{Data: data}
+	return nil
+}
+
+func TestScaffoldApp(t *testing.T) {
+	target := newMapTarget()
+	app := AppScaffold{Name: "blog_posts", ModulePath: "example.com/mysite"}
+
+	if err := ScaffoldApp(target, app); err != nil {
+		t.Fatalf("Failed to scaffold app: %v", err)
+	}
+
+	expectedFiles := []string{
+		"apps/blog_posts/app.go",
+		"apps/blog_posts/schema/.gitkeep",
+		"apps/blog_posts/templates/blog_posts/index.html",
+		"apps/blog_posts/static/blog_posts/.gitkeep",
+	}
+	if err := fstest.TestFS(target.files, expectedFiles...); err != nil {
+		t.Fatalf("Unexpected scaffold output: %v", err)
+	}
+
+	appGo := string(target.files["apps/blog_posts/app.go"].Data)
+	for _, want := range []string{
+		"package blog_posts",
+		"gojango.Register(&BlogPostsApp{})",
+		`Name:    "blog_posts"`,
+	} {
+		if !strings.Contains(appGo, want) {
+			t.Errorf("Expected app.go to contain %q", want)
+		}
+	}
+
+	// html/template actions are left intact for the runtime template engine
+	index := string(target.files["apps/blog_posts/templates/blog_posts/index.html"].Data)
+	if !strings.Contains(index, "{{.title}}") || !strings.Contains(index, "BlogPosts App") {
+		t.Errorf("Unexpected index.html content: %s", index)
+	}
+
+	if line := app.ImportLine(); line != `_ "example.com/mysite/apps/blog_posts"` {
+		t.Errorf("Unexpected import line: %s", line)
+	}
+}
+
+func TestScaffoldAppRefusesOverwrite(t *testing.T) {
+	target := newMapTarget()
+	app := AppScaffold{Name: "blog", ModulePath: "example.com/mysite"}
+
+	if err := ScaffoldApp(target, app); err != nil {
+		t.Fatalf("Failed to scaffold app: %v", err)
+	}
+	target.files["apps/blog/app.go"].Data = []byte("custom")
+
+	if err := ScaffoldApp(target, app); err == nil {
+		t.Error("Expected error when app already exists")
+	}
+	if string(target.files["apps/blog/app.go"].Data) != "custom" {
+		t.Error("Existing app.go should not be overwritten")
+	}
+}
+
+func TestValidateAppName(t *testing.T) {
+	valid := []string{"blog", "blog_posts", "shop2"}
+	for _, name := range valid {
+		if err := ValidateAppName(name); err != nil {
+			t.Errorf("Expected %q to be valid, got: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "2blog", "blog-posts", "Blog", "func", "main", "my app"}
+	for _, name := range invalid {
+		if err := ValidateAppName(name); err == nil {
+			t.Errorf("Expected %q to be invalid", name)
+		}
+	}
+}
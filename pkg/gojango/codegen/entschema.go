@@ -0,0 +1,538 @@
+package codegen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DefaultExpr is a field default computed by Go code, such as time.Now,
+// rather than a literal value
+type DefaultExpr string
+
+// entFieldTypes maps the constructors of Ent's field package to field types
+var entFieldTypes = map[string]string{
+	"String":  "string",
+	"Text":    "string",
+	"Bool":    "bool",
+	"Time":    "time",
+	"Enum":    "enum",
+	"UUID":    "uuid",
+	"Bytes":   "bytes",
+	"Int":     "int",
+	"Int8":    "int8",
+	"Int16":   "int16",
+	"Int32":   "int32",
+	"Int64":   "int64",
+	"Uint":    "uint",
+	"Uint8":   "uint8",
+	"Uint16":  "uint16",
+	"Uint32":  "uint32",
+	"Uint64":  "uint64",
+	"Float":   "float64",
+	"Float32": "float32",
+	"JSON":    "json",
+	"Strings": "json",
+	"Ints":    "json",
+	"Floats":  "json",
+	"Any":     "json",
+}
+
+// goTypes and protoTypes map field types to Go and protobuf types; integer
+// and float types not listed map to themselves in Go
+var (
+	goTypes = map[string]string{
+		"time":  "time.Time",
+		"enum":  "string",
+		"uuid":  "uuid.UUID",
+		"bytes": "[]byte",
+		"json":  "json.RawMessage",
+	}
+	protoTypes = map[string]string{
+		"string":  "string",
+		"bool":    "bool",
+		"time":    "google.protobuf.Timestamp",
+		"enum":    "string",
+		"uuid":    "string",
+		"bytes":   "bytes",
+		"json":    "string",
+		"int":     "int64",
+		"int8":    "int32",
+		"int16":   "int32",
+		"int32":   "int32",
+		"int64":   "int64",
+		"uint":    "uint64",
+		"uint8":   "uint32",
+		"uint16":  "uint32",
+		"uint32":  "uint32",
+		"uint64":  "uint64",
+		"float64": "double",
+		"float32": "float",
+	}
+)
+
+// entTimeMixins are the fields added by the time mixins of entgo.io/ent/schema/mixin
+var entTimeMixins = map[string][]string{
+	"Time":       {"create_time", "update_time"},
+	"CreateTime": {"create_time"},
+	"UpdateTime": {"update_time"},
+}
+
+// entSchemaParser reads the fields and edges of one schema from its methods
+type entSchemaParser struct {
+	fset  *token.FileSet
+	model *ModelInfo
+}
+
+// builderCall is one call in a builder chain such as
+// field.String("name").Optional()
+type builderCall struct {
+	name string
+	args []ast.Expr
+	pos  token.Pos
+}
+
+// parse fills in the model from its Mixin, Fields, Edges and Annotations
+// methods. Ent adds an int id field unless the schema declares its own.
+func (p *entSchemaParser) parse(methods map[string]*ast.FuncDecl) error {
+	for _, expr := range returnedElements(methods["Annotations"]) {
+		p.parseAnnotation(expr)
+	}
+	for _, expr := range returnedElements(methods["Mixin"]) {
+		p.parseMixin(expr)
+	}
+	for _, expr := range returnedElements(methods["Fields"]) {
+		if err := p.parseField(expr); err != nil {
+			return err
+		}
+	}
+	for _, expr := range returnedElements(methods["Edges"]) {
+		if err := p.parseEdge(expr); err != nil {
+			return err
+		}
+	}
+
+	for _, field := range p.model.Fields {
+		if field.Name == "id" {
+			return nil
+		}
+	}
+	p.model.Fields = append([]*FieldInfo{newFieldInfo("id", "int")}, p.model.Fields...)
+	return nil
+}
+
+// parseMixin adds the fields of Ent's time mixins; other mixins live in
+// packages the analyzer does not read
+func (p *entSchemaParser) parseMixin(expr ast.Expr) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok || !isIdent(sel.X, "mixin") {
+		return
+	}
+	for _, name := range entTimeMixins[sel.Sel.Name] {
+		field := newFieldInfo(name, "time")
+		field.Default = DefaultExpr("time.Now")
+		p.model.Fields = append(p.model.Fields, field)
+	}
+}
+
+// parseAnnotation reads the table name of an entsql.Annotation
+func (p *entSchemaParser) parseAnnotation(expr ast.Expr) {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	sel, ok := lit.Type.(*ast.SelectorExpr)
+	if !ok || !isIdent(sel.X, "entsql") || sel.Sel.Name != "Annotation" {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok || !isIdent(kv.Key, "Table") {
+			continue
+		}
+		if table, ok := stringLiteral(kv.Value); ok && table != "" {
+			p.model.TableName = table
+		}
+	}
+}
+
+// parseField adds a field defined with Ent's field package. Definitions
+// built any other way, such as by helper functions, are skipped.
+func (p *entSchemaParser) parseField(expr ast.Expr) error {
+	pkg, calls, ok := builderChain(expr)
+	if !ok || pkg != "field" {
+		return nil
+	}
+	constructor := calls[0]
+	fieldType, ok := entFieldTypes[constructor.name]
+	if !ok {
+		return p.errorf(constructor.pos, "unsupported field type field.%s", constructor.name)
+	}
+	if len(constructor.args) == 0 {
+		return p.errorf(constructor.pos, "field.%s needs a name", constructor.name)
+	}
+	name, ok := stringLiteral(constructor.args[0])
+	if !ok {
+		return p.errorf(constructor.pos, "the name of field.%s must be a string literal", constructor.name)
+	}
+
+	field := newFieldInfo(name, fieldType)
+	for _, call := range calls[1:] {
+		switch call.name {
+		case "Optional":
+			field.Optional = true
+		case "Nillable":
+			field.Nillable = true
+		case "Unique":
+			field.Unique = true
+		case "Default", "DefaultFunc":
+			if len(call.args) == 1 {
+				field.Default = p.defaultValue(call.args[0])
+			}
+		case "Comment":
+			if len(call.args) == 1 {
+				field.Description, _ = stringLiteral(call.args[0])
+			}
+		case "StructTag":
+			if len(call.args) == 1 {
+				if tag, ok := stringLiteral(call.args[0]); ok {
+					if jsonTag := jsonTagName(tag); jsonTag != "" {
+						field.JSONTag = jsonTag
+					}
+				}
+			}
+		case "Values":
+			field.EnumValues = append(field.EnumValues, stringLiterals(call.args)...)
+		case "NamedValues":
+			values := stringLiterals(call.args)
+			for i := 1; i < len(values); i += 2 {
+				field.EnumValues = append(field.EnumValues, values[i])
+			}
+		case "MaxLen":
+			if n, ok := numberLiteral(call.args, 0); ok {
+				field.MaxLength = int(n)
+			}
+		case "MinLen":
+			if n, ok := numberLiteral(call.args, 0); ok {
+				field.MinLength = int(n)
+			}
+		case "NotEmpty":
+			field.MinLength = 1
+		case "Min", "Positive", "NonNegative":
+			if n, ok := numberLiteral(call.args, 0); ok {
+				field.Min = &n
+			} else if call.name != "Min" {
+				n := 0.0
+				if call.name == "Positive" {
+					n = 1
+				}
+				field.Min = &n
+			}
+		case "Max", "Negative":
+			if n, ok := numberLiteral(call.args, 0); ok {
+				field.Max = &n
+			} else if call.name == "Negative" {
+				n := -1.0
+				field.Max = &n
+			}
+		case "Range":
+			min, minOK := numberLiteral(call.args, 0)
+			max, maxOK := numberLiteral(call.args, 1)
+			if minOK && maxOK {
+				field.Min, field.Max = &min, &max
+			}
+		}
+	}
+
+	for i, existing := range p.model.Fields {
+		if existing.Name == name {
+			p.model.Fields[i] = field
+			return nil
+		}
+	}
+	p.model.Fields = append(p.model.Fields, field)
+	return nil
+}
+
+// parseEdge adds an edge defined with edge.To or edge.From
+func (p *entSchemaParser) parseEdge(expr ast.Expr) error {
+	pkg, calls, ok := builderChain(expr)
+	if !ok || pkg != "edge" {
+		return nil
+	}
+	constructor := calls[0]
+	if constructor.name != "To" && constructor.name != "From" {
+		return nil
+	}
+	if len(constructor.args) != 2 {
+		return p.errorf(constructor.pos, "edge.%s needs a name and a target type", constructor.name)
+	}
+	name, ok := stringLiteral(constructor.args[0])
+	if !ok {
+		return p.errorf(constructor.pos, "the name of edge.%s must be a string literal", constructor.name)
+	}
+	target, ok := constructor.args[1].(*ast.SelectorExpr)
+	if !ok || target.Sel.Name != "Type" {
+		return p.errorf(constructor.pos, "the target of edge %q must be a schema's Type", name)
+	}
+	targetName, ok := target.X.(*ast.Ident)
+	if !ok {
+		return p.errorf(constructor.pos, "the target of edge %q must be a schema's Type", name)
+	}
+
+	edge := &EdgeInfo{Name: name, Target: targetName.Name}
+	unique := false
+	for _, call := range calls[1:] {
+		switch call.name {
+		case "Unique":
+			unique = true
+		case "Ref":
+			if len(call.args) == 1 {
+				edge.Inverse, _ = stringLiteral(call.args[0])
+			}
+		case "Field":
+			if len(call.args) == 1 {
+				edge.Field, _ = stringLiteral(call.args[0])
+			}
+		case "Comment":
+			if len(call.args) == 1 {
+				edge.Description, _ = stringLiteral(call.args[0])
+			}
+		}
+	}
+
+	switch {
+	case constructor.name == "To" && unique:
+		edge.Type = "O2O"
+	case constructor.name == "To":
+		edge.Type = "O2M"
+	case unique:
+		edge.Type = "M2O"
+	default:
+		edge.Type = "M2M"
+	}
+	p.model.Edges = append(p.model.Edges, edge)
+	return nil
+}
+
+// defaultValue is the value of a literal default, or the source of a
+// computed one
+func (p *entSchemaParser) defaultValue(expr ast.Expr) interface{} {
+	if value, ok := literalValue(expr); ok {
+		return value
+	}
+	var source strings.Builder
+	if err := printer.Fprint(&source, p.fset, expr); err != nil {
+		return DefaultExpr("")
+	}
+	return DefaultExpr(source.String())
+}
+
+// errorf reports an error at a position in the schema file
+func (p *entSchemaParser) errorf(pos token.Pos, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %s", p.fset.Position(pos), fmt.Sprintf(format, args...))
+}
+
+// entTableName returns the table Ent creates for a schema: its snake case
+// name in plural, following the common English rules
+func entTableName(schema string) string {
+	name := toSnakeCase(schema)
+	switch {
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsAny(name[len(name)-2:len(name)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"),
+		strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// newFieldInfo creates a field with the Go, protobuf and JSON names Ent
+// generates for it
+func newFieldInfo(name, fieldType string) *FieldInfo {
+	goType, ok := goTypes[fieldType]
+	if !ok {
+		goType = fieldType
+	}
+	return &FieldInfo{
+		Name:      name,
+		Type:      fieldType,
+		GoType:    goType,
+		ProtoType: protoTypes[fieldType],
+		JSONTag:   name,
+	}
+}
+
+// schemaMethods indexes the methods declared in a file by receiver type and
+// method name
+func schemaMethods(node *ast.File) map[string]map[string]*ast.FuncDecl {
+	methods := make(map[string]map[string]*ast.FuncDecl)
+	for _, decl := range node.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		ident, ok := recv.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if methods[ident.Name] == nil {
+			methods[ident.Name] = make(map[string]*ast.FuncDecl)
+		}
+		methods[ident.Name][fn.Name.Name] = fn
+	}
+	return methods
+}
+
+// embedsEntSchema reports whether a type is a struct embedding ent.Schema
+func embedsEntSchema(typeSpec *ast.TypeSpec) bool {
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+	for _, field := range structType.Fields.List {
+		if sel, ok := field.Type.(*ast.SelectorExpr); ok && len(field.Names) == 0 &&
+			isIdent(sel.X, "ent") && sel.Sel.Name == "Schema" {
+			return true
+		}
+	}
+	return false
+}
+
+// returnedElements returns the elements of the slice literal a method
+// returns, such as the []ent.Field of Fields
+func returnedElements(fn *ast.FuncDecl) []ast.Expr {
+	if fn == nil || fn.Body == nil {
+		return nil
+	}
+	for _, stmt := range fn.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		if lit, ok := ret.Results[0].(*ast.CompositeLit); ok {
+			return lit.Elts
+		}
+	}
+	return nil
+}
+
+// builderChain unwinds a builder expression such as
+// field.String("name").Optional() into its package and its calls, starting
+// with the constructor
+func builderChain(expr ast.Expr) (string, []builderCall, bool) {
+	var calls []builderCall
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return "", nil, false
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", nil, false
+		}
+		calls = append([]builderCall{{name: sel.Sel.Name, args: call.Args, pos: call.Pos()}}, calls...)
+		if ident, ok := sel.X.(*ast.Ident); ok {
+			return ident.Name, calls, true
+		}
+		expr = sel.X
+	}
+}
+
+// isIdent reports whether expr is the identifier name
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// stringLiteral returns the value of a string literal
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
+
+// stringLiterals returns the values of the string literals among exprs
+func stringLiterals(exprs []ast.Expr) []string {
+	var values []string
+	for _, expr := range exprs {
+		if value, ok := stringLiteral(expr); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// numberLiteral returns the value of the numeric literal args[i]
+func numberLiteral(args []ast.Expr, i int) (float64, bool) {
+	if i >= len(args) {
+		return 0, false
+	}
+	switch value, _ := literalValue(args[i]); n := value.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// literalValue returns the value of a string, number or boolean literal
+func literalValue(expr ast.Expr) (interface{}, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			return stringLiteral(e)
+		case token.INT:
+			n, err := strconv.ParseInt(e.Value, 0, 64)
+			return n, err == nil
+		case token.FLOAT:
+			n, err := strconv.ParseFloat(e.Value, 64)
+			return n, err == nil
+		}
+	case *ast.Ident:
+		if e.Name == "true" || e.Name == "false" {
+			return e.Name == "true", true
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.SUB {
+			switch n, _ := literalValue(e.X); n := n.(type) {
+			case int64:
+				return -n, true
+			case float64:
+				return -n, true
+			}
+		}
+	case *ast.ParenExpr:
+		return literalValue(e.X)
+	}
+	return nil, false
+}
+
+// jsonTagName returns the name in the json key of a struct tag
+func jsonTagName(tag string) string {
+	name, _, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+	return name
+}
@@ -0,0 +1,233 @@
+package codegen
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ProjectGenerator runs the code generators of a project over the Ent
+// schemas of all its apps, as "manage.go generate" does
+type ProjectGenerator struct {
+	// Dir is the project root
+	Dir string
+	// ProtoDir is where protobuf definitions are written, relative to Dir
+	ProtoDir string
+	// OpenAPIFile is where the OpenAPI specification is written, relative to Dir
+	OpenAPIFile string
+	// PostmanFile is where the Postman collection is written, relative to Dir
+	PostmanFile string
+	// TypeScriptFile is where the TypeScript interfaces are written, relative to Dir
+	TypeScriptFile string
+	// Out receives progress messages
+	Out io.Writer
+
+	// run runs an external tool in the project root
+	run func(dir, name string, args ...string) error
+}
+
+// NewProjectGenerator creates a generator for the project in dir
+func NewProjectGenerator(dir string) *ProjectGenerator {
+	return &ProjectGenerator{
+		Dir:            dir,
+		ProtoDir:       "proto",
+		OpenAPIFile:    "openapi.yaml",
+		PostmanFile:    "postman_collection.json",
+		TypeScriptFile: filepath.Join("web", "src", "types", "models.ts"),
+		Out:            os.Stdout,
+		run:            runTool,
+	}
+}
+
+// SchemaDirs returns the Ent schema directories of the project's apps,
+// relative to the project root: every directory named "schema" under apps/
+func (g *ProjectGenerator) SchemaDirs() ([]string, error) {
+	appsDir := filepath.Join(g.Dir, "apps")
+	if _, err := os.Stat(appsDir); err != nil {
+		return nil, fmt.Errorf("no apps directory found in %s", g.Dir)
+	}
+
+	var schemaDirs []string
+	err := filepath.WalkDir(appsDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != appsDir && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		if entry.Name() == "schema" {
+			rel, err := filepath.Rel(g.Dir, path)
+			if err != nil {
+				return err
+			}
+			schemaDirs = append(schemaDirs, rel)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search for schemas: %w", err)
+	}
+	return schemaDirs, nil
+}
+
+// All runs the Ent, protobuf and OpenAPI generators, in that order
+func (g *ProjectGenerator) All() error {
+	if err := g.Ent(); err != nil {
+		return err
+	}
+	if err := g.Proto(); err != nil {
+		return err
+	}
+	return g.OpenAPI()
+}
+
+// Ent runs Ent code generation for each app's schema directory, writing
+// the ent package next to it
+func (g *ProjectGenerator) Ent() error {
+	schemaDirs, err := g.SchemaDirs()
+	if err != nil {
+		return err
+	}
+	if len(schemaDirs) == 0 {
+		fmt.Fprintln(g.Out, "⚠️  No schema directories found under apps/")
+		return nil
+	}
+
+	for _, schemaDir := range schemaDirs {
+		target := filepath.Join(filepath.Dir(schemaDir), "ent")
+		err := g.run(g.Dir, "go", "run", "-mod=mod", "entgo.io/ent/cmd/ent", "generate",
+			"--target", "./"+filepath.ToSlash(target), "./"+filepath.ToSlash(schemaDir))
+		if err != nil {
+			return fmt.Errorf("failed to generate Ent code for %s: %w", schemaDir, err)
+		}
+		fmt.Fprintf(g.Out, "✅ Generated %s from %s\n", target, schemaDir)
+	}
+	return nil
+}
+
+// Proto writes protobuf definitions for every app's models to ProtoDir.
+// When the project has a buf.gen.yaml, buf then generates the Go and
+// TypeScript (connect-web) clients it configures.
+func (g *ProjectGenerator) Proto() error {
+	analyzer, err := g.Schema()
+	if err != nil {
+		return err
+	}
+	if len(analyzer.GetModels()) == 0 {
+		fmt.Fprintln(g.Out, "⚠️  No models found, skipping protobuf generation")
+		return nil
+	}
+
+	if err := NewProtoGenerator(analyzer).Generate(filepath.Join(g.Dir, g.ProtoDir)); err != nil {
+		return fmt.Errorf("failed to generate protobuf files: %w", err)
+	}
+	fmt.Fprintf(g.Out, "✅ Generated %s and %s for %d models\n",
+		filepath.Join(g.ProtoDir, "models.proto"), filepath.Join(g.ProtoDir, "service.proto"), len(analyzer.GetModels()))
+
+	if _, err := os.Stat(filepath.Join(g.Dir, "buf.gen.yaml")); err != nil {
+		fmt.Fprintln(g.Out, "ℹ️  Add a buf.gen.yaml to generate Go and TypeScript clients from the protobuf files")
+		return nil
+	}
+	if err := g.run(g.Dir, "buf", "generate", g.ProtoDir); err != nil {
+		return fmt.Errorf("failed to generate protobuf clients with buf: %w", err)
+	}
+	fmt.Fprintln(g.Out, "✅ Generated protobuf clients from buf.gen.yaml")
+	return nil
+}
+
+// OpenAPI writes an OpenAPI specification for every app's models to
+// OpenAPIFile
+func (g *ProjectGenerator) OpenAPI() error {
+	analyzer, err := g.Schema()
+	if err != nil {
+		return err
+	}
+	if len(analyzer.GetModels()) == 0 {
+		fmt.Fprintln(g.Out, "⚠️  No models found, skipping OpenAPI generation")
+		return nil
+	}
+
+	if err := NewOpenAPIGenerator(analyzer).Generate(filepath.Join(g.Dir, g.OpenAPIFile)); err != nil {
+		return fmt.Errorf("failed to generate OpenAPI spec: %w", err)
+	}
+	fmt.Fprintf(g.Out, "✅ Generated %s for %d models\n", g.OpenAPIFile, len(analyzer.GetModels()))
+	return nil
+}
+
+// Postman writes a Postman collection for every app's models to PostmanFile
+func (g *ProjectGenerator) Postman() error {
+	analyzer, err := g.Schema()
+	if err != nil {
+		return err
+	}
+	if len(analyzer.GetModels()) == 0 {
+		fmt.Fprintln(g.Out, "⚠️  No models found, skipping Postman generation")
+		return nil
+	}
+
+	if err := NewPostmanGenerator(analyzer).Generate(filepath.Join(g.Dir, g.PostmanFile)); err != nil {
+		return fmt.Errorf("failed to generate Postman collection: %w", err)
+	}
+	fmt.Fprintf(g.Out, "✅ Generated %s for %d models\n", g.PostmanFile, len(analyzer.GetModels()))
+	return nil
+}
+
+// TypeScript writes TypeScript interfaces for every app's models to
+// TypeScriptFile
+func (g *ProjectGenerator) TypeScript() error {
+	analyzer, err := g.Schema()
+	if err != nil {
+		return err
+	}
+	if len(analyzer.GetModels()) == 0 {
+		fmt.Fprintln(g.Out, "⚠️  No models found, skipping TypeScript generation")
+		return nil
+	}
+
+	if err := NewTypeScriptGenerator(analyzer).Generate(filepath.Join(g.Dir, g.TypeScriptFile)); err != nil {
+		return fmt.Errorf("failed to generate TypeScript types: %w", err)
+	}
+	fmt.Fprintf(g.Out, "✅ Generated %s for %d models\n", g.TypeScriptFile, len(analyzer.GetModels()))
+	return nil
+}
+
+// Schema analyzes the schemas of all apps into one analyzer
+func (g *ProjectGenerator) Schema() (*SchemaAnalyzer, error) {
+	schemaDirs, err := g.SchemaDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	combined := NewEntSchemaAnalyzer(filepath.Join(g.Dir, "apps"))
+	for _, schemaDir := range schemaDirs {
+		analyzer := NewEntSchemaAnalyzer(filepath.Join(g.Dir, schemaDir))
+		if err := analyzer.Analyze(); err != nil {
+			return nil, fmt.Errorf("failed to analyze schemas in %s: %w", schemaDir, err)
+		}
+		combined.models = append(combined.models, analyzer.GetModels()...)
+	}
+	return combined, nil
+}
+
+// runTool runs an external tool with its output on the terminal, reporting
+// a missing tool clearly
+func runTool(dir, name string, args ...string) error {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return fmt.Errorf("%s not found on PATH", name)
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Dir = dir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,125 @@
+package codegen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newSampleProject creates a project with a blog app holding the sample Post
+// schema and a shop app with a nested schema directory, and returns a generator for it that records the tools it runs
+func newSampleProject(t *testing.T) (*ProjectGenerator, *[]string) {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"apps/blog/schema/post.go":           samplePostSchema,
+		"apps/blog/views.go":                 "package blog\n",
+		"apps/.cache/schema/x.go":            "package schema\n\nimport \"entgo.io/ent\"\n\ntype Ignored struct{ ent.Schema }\n",
+		"apps/shop/models/schema/product.go": "package schema\n\nimport \"entgo.io/ent\"\n\ntype Product struct{ ent.Schema }\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	var ran []string
+	generator := NewProjectGenerator(dir)
+	generator.Out = &bytes.Buffer{}
+	generator.run = func(dir, name string, args ...string) error {
+		ran = append(ran, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	return generator, &ran
+}
+
+func TestProjectGeneratorSchemaDirs(t *testing.T) {
+	generator, _ := newSampleProject(t)
+
+	schemaDirs, err := generator.SchemaDirs()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{filepath.Join("apps", "blog", "schema"), filepath.Join("apps", "shop", "models", "schema")}
+	if !reflect.DeepEqual(schemaDirs, expected) {
+		t.Errorf("Expected schema dirs %v, got: %v", expected, schemaDirs)
+	}
+
+	if _, err := NewProjectGenerator(t.TempDir()).SchemaDirs(); err == nil {
+		t.Error("Expected an error for a project without apps/")
+	}
+}
+
+func TestProjectGeneratorOpenAPI(t *testing.T) {
+	generator, _ := newSampleProject(t)
+
+	if err := generator.OpenAPI(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	spec, err := os.ReadFile(filepath.Join(generator.Dir, "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Expected openapi.yaml to be written: %v", err)
+	}
+	for _, expected := range []string{"openapi: 3.0.3", "  /post:", "  /post/{id}:", "    Post:", "    CreatePostRequest:", "  /product:"} {
+		if !strings.Contains(string(spec), expected) {
+			t.Errorf("Expected the spec to contain %q", expected)
+		}
+	}
+	if strings.Contains(string(spec), "Ignored") {
+		t.Error("Expected schemas in hidden directories to be skipped")
+	}
+
+	if output := generator.Out.(*bytes.Buffer).String(); !strings.Contains(output, "openapi.yaml for 2 models") {
+		t.Errorf("Expected a report of what was written, got: %q", output)
+	}
+}
+
+func TestProjectGeneratorSchemaError(t *testing.T) {
+	generator, _ := newSampleProject(t)
+	broken := filepath.Join(generator.Dir, "apps", "blog", "schema", "broken.go")
+	if err := os.WriteFile(broken, []byte("package schema\n\ntype Broken struct {"), 0644); err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	for name, generate := range map[string]func() error{"openapi": generator.OpenAPI, "proto": generator.Proto} {
+		err := generate()
+		if err == nil || !strings.Contains(err.Error(), "broken.go") {
+			t.Errorf("%s: expected an error naming the broken schema, got: %v", name, err)
+		}
+	}
+}
+
+func TestProjectGeneratorAll(t *testing.T) {
+	generator, ran := newSampleProject(t)
+	if err := os.WriteFile(filepath.Join(generator.Dir, "buf.gen.yaml"), []byte("version: v1\n"), 0644); err != nil {
+		t.Fatalf("Failed to write buf.gen.yaml: %v", err)
+	}
+
+	if err := generator.All(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"go run -mod=mod entgo.io/ent/cmd/ent generate --target ./apps/blog/ent ./apps/blog/schema",
+		"go run -mod=mod entgo.io/ent/cmd/ent generate --target ./apps/shop/models/ent ./apps/shop/models/schema",
+		"buf generate proto",
+	}
+	if !reflect.DeepEqual(*ran, expected) {
+		t.Errorf("Expected tools %v, got: %v", expected, *ran)
+	}
+
+	for _, file := range []string{"proto/models.proto", "proto/service.proto", "openapi.yaml"} {
+		if _, err := os.Stat(filepath.Join(generator.Dir, file)); err != nil {
+			t.Errorf("Expected %s to be written: %v", file, err)
+		}
+	}
+}
@@ -1,234 +1,350 @@
 package codegen
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // OpenAPIGenerator generates OpenAPI specifications from analyzed schemas
 type OpenAPIGenerator struct {
-	analyzer *SchemaAnalyzer
+	analyzer    *SchemaAnalyzer
+	title       string
+	version     string
+	description string
 }
 
 // NewOpenAPIGenerator creates a new OpenAPI generator
 func NewOpenAPIGenerator(analyzer *SchemaAnalyzer) *OpenAPIGenerator {
 	return &OpenAPIGenerator{
-		analyzer: analyzer,
+		analyzer:    analyzer,
+		title:       "Gojango API",
+		version:     "1.0.0",
+		description: "Auto-generated API from Ent schemas",
 	}
 }
 
-// Generate generates OpenAPI specification from analyzed schemas
+// WithInfo sets the title, version and description of the specification
+func (g *OpenAPIGenerator) WithInfo(title, version, description string) *OpenAPIGenerator {
+	g.title = title
+	g.version = version
+	g.description = description
+	return g
+}
+
+// OpenAPISpec is an OpenAPI 3.0 document
+type OpenAPISpec struct {
+	OpenAPI    string                      `json:"openapi" yaml:"openapi"`
+	Info       OpenAPIInfo                 `json:"info" yaml:"info"`
+	Servers    []OpenAPIServer             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]*OpenAPIPathItem `json:"paths" yaml:"paths"`
+	Components OpenAPIComponents           `json:"components" yaml:"components"`
+}
+
+// OpenAPIInfo describes the API
+type OpenAPIInfo struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// OpenAPIServer is a server the API is served from
+type OpenAPIServer struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// OpenAPIPathItem holds the operations on a path
+type OpenAPIPathItem struct {
+	Get    *OpenAPIOperation `json:"get,omitempty" yaml:"get,omitempty"`
+	Post   *OpenAPIOperation `json:"post,omitempty" yaml:"post,omitempty"`
+	Put    *OpenAPIOperation `json:"put,omitempty" yaml:"put,omitempty"`
+	Delete *OpenAPIOperation `json:"delete,omitempty" yaml:"delete,omitempty"`
+}
+
+// OpenAPIOperation is a single API operation
+type OpenAPIOperation struct {
+	Summary     string                     `json:"summary" yaml:"summary"`
+	Tags        []string                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Parameters  []OpenAPIParameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]OpenAPIResponse `json:"responses" yaml:"responses"`
+}
+
+// OpenAPIParameter is a path or query parameter
+type OpenAPIParameter struct {
+	Name     string         `json:"name" yaml:"name"`
+	In       string         `json:"in" yaml:"in"`
+	Required bool           `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema" yaml:"schema"`
+}
+
+// OpenAPIRequestBody is the body of a request
+type OpenAPIRequestBody struct {
+	Required bool                        `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]OpenAPIMediaType `json:"content" yaml:"content"`
+}
+
+// OpenAPIResponse is a response to an operation
+type OpenAPIResponse struct {
+	Description string                      `json:"description" yaml:"description"`
+	Content     map[string]OpenAPIMediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// OpenAPIMediaType is the schema of a body in one media type
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema" yaml:"schema"`
+}
+
+// OpenAPIComponents holds the reusable schemas
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas" yaml:"schemas"`
+}
+
+// OpenAPISchema is a schema object, or a reference to one when Ref is set
+type OpenAPISchema struct {
+	Ref         string                    `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type        string                    `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string                    `json:"format,omitempty" yaml:"format,omitempty"`
+	Description string                    `json:"description,omitempty" yaml:"description,omitempty"`
+	Nullable    bool                      `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Enum        []string                  `json:"enum,omitempty" yaml:"enum,omitempty"`
+	MinLength   *int                      `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength   *int                      `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	Minimum     *float64                  `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum     *float64                  `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Default     interface{}               `json:"default,omitempty" yaml:"default,omitempty"`
+	Items       *OpenAPISchema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties  map[string]*OpenAPISchema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string                  `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// Generate writes the specification to outputFile, as JSON when it ends in
+// .json and as YAML otherwise
 func (g *OpenAPIGenerator) Generate(outputFile string) error {
-	spec := g.buildOpenAPISpec()
-	
-	return os.WriteFile(outputFile, []byte(spec), 0644)
-}
-
-// buildOpenAPISpec builds the OpenAPI specification
-func (g *OpenAPIGenerator) buildOpenAPISpec() string {
-	var content strings.Builder
-	
-	content.WriteString(`openapi: 3.0.3
-info:
-  title: Gojango API
-  description: Auto-generated API from Ent schemas
-  version: 1.0.0
-servers:
-  - url: http://localhost:8080/api/v1
-    description: Development server
-
-paths:
-`)
-
-	models := g.analyzer.GetModels()
-
-	// Generate paths for each model
-	for _, model := range models {
-		modelPath := strings.ToLower(model.Name)
-		content.WriteString(fmt.Sprintf("  /%s:\n", modelPath))
-		content.WriteString("    get:\n")
-		content.WriteString(fmt.Sprintf("      summary: List %s\n", model.Name))
-		content.WriteString("      tags:\n")
-		content.WriteString(fmt.Sprintf("        - %s\n", model.Name))
-		content.WriteString("      parameters:\n")
-		content.WriteString("        - name: page\n")
-		content.WriteString("          in: query\n")
-		content.WriteString("          schema:\n")
-		content.WriteString("            type: integer\n")
-		content.WriteString("            default: 1\n")
-		content.WriteString("        - name: page_size\n")
-		content.WriteString("          in: query\n")
-		content.WriteString("          schema:\n")
-		content.WriteString("            type: integer\n")
-		content.WriteString("            default: 20\n")
-		content.WriteString("        - name: search\n")
-		content.WriteString("          in: query\n")
-		content.WriteString("          schema:\n")
-		content.WriteString("            type: string\n")
-		content.WriteString("      responses:\n")
-		content.WriteString("        '200':\n")
-		content.WriteString("          description: Success\n")
-		content.WriteString("          content:\n")
-		content.WriteString("            application/json:\n")
-		content.WriteString("              schema:\n")
-		content.WriteString(fmt.Sprintf("                $ref: '#/components/schemas/List%sResponse'\n", model.Name))
-		content.WriteString("    post:\n")
-		content.WriteString(fmt.Sprintf("      summary: Create %s\n", model.Name))
-		content.WriteString("      tags:\n")
-		content.WriteString(fmt.Sprintf("        - %s\n", model.Name))
-		content.WriteString("      requestBody:\n")
-		content.WriteString("        required: true\n")
-		content.WriteString("        content:\n")
-		content.WriteString("          application/json:\n")
-		content.WriteString("            schema:\n")
-		content.WriteString(fmt.Sprintf("              $ref: '#/components/schemas/Create%sRequest'\n", model.Name))
-		content.WriteString("      responses:\n")
-		content.WriteString("        '201':\n")
-		content.WriteString("          description: Created\n")
-		content.WriteString("          content:\n")
-		content.WriteString("            application/json:\n")
-		content.WriteString("              schema:\n")
-		content.WriteString(fmt.Sprintf("                $ref: '#/components/schemas/%s'\n", model.Name))
-		
-		content.WriteString(fmt.Sprintf("  /%s/{id}:\n", modelPath))
-		content.WriteString("    get:\n")
-		content.WriteString(fmt.Sprintf("      summary: Get %s by ID\n", model.Name))
-		content.WriteString("      tags:\n")
-		content.WriteString(fmt.Sprintf("        - %s\n", model.Name))
-		content.WriteString("      parameters:\n")
-		content.WriteString("        - name: id\n")
-		content.WriteString("          in: path\n")
-		content.WriteString("          required: true\n")
-		content.WriteString("          schema:\n")
-		content.WriteString("            type: integer\n")
-		content.WriteString("            format: int64\n")
-		content.WriteString("      responses:\n")
-		content.WriteString("        '200':\n")
-		content.WriteString("          description: Success\n")
-		content.WriteString("          content:\n")
-		content.WriteString("            application/json:\n")
-		content.WriteString("              schema:\n")
-		content.WriteString(fmt.Sprintf("                $ref: '#/components/schemas/%s'\n", model.Name))
-		content.WriteString("        '404':\n")
-		content.WriteString("          description: Not found\n")
-		content.WriteString("    put:\n")
-		content.WriteString(fmt.Sprintf("      summary: Update %s\n", model.Name))
-		content.WriteString("      tags:\n")
-		content.WriteString(fmt.Sprintf("        - %s\n", model.Name))
-		content.WriteString("      parameters:\n")
-		content.WriteString("        - name: id\n")
-		content.WriteString("          in: path\n")
-		content.WriteString("          required: true\n")
-		content.WriteString("          schema:\n")
-		content.WriteString("            type: integer\n")
-		content.WriteString("            format: int64\n")
-		content.WriteString("      requestBody:\n")
-		content.WriteString("        required: true\n")
-		content.WriteString("        content:\n")
-		content.WriteString("          application/json:\n")
-		content.WriteString("            schema:\n")
-		content.WriteString(fmt.Sprintf("              $ref: '#/components/schemas/Update%sRequest'\n", model.Name))
-		content.WriteString("      responses:\n")
-		content.WriteString("        '200':\n")
-		content.WriteString("          description: Updated\n")
-		content.WriteString("          content:\n")
-		content.WriteString("            application/json:\n")
-		content.WriteString("              schema:\n")
-		content.WriteString(fmt.Sprintf("                $ref: '#/components/schemas/%s'\n", model.Name))
-		content.WriteString("        '404':\n")
-		content.WriteString("          description: Not found\n")
-		content.WriteString("    delete:\n")
-		content.WriteString(fmt.Sprintf("      summary: Delete %s\n", model.Name))
-		content.WriteString("      tags:\n")
-		content.WriteString(fmt.Sprintf("        - %s\n", model.Name))
-		content.WriteString("      parameters:\n")
-		content.WriteString("        - name: id\n")
-		content.WriteString("          in: path\n")
-		content.WriteString("          required: true\n")
-		content.WriteString("          schema:\n")
-		content.WriteString("            type: integer\n")
-		content.WriteString("            format: int64\n")
-		content.WriteString("      responses:\n")
-		content.WriteString("        '204':\n")
-		content.WriteString("          description: Deleted\n")
-		content.WriteString("        '404':\n")
-		content.WriteString("          description: Not found\n\n")
-	}
-
-	content.WriteString("components:\n")
-	content.WriteString("  schemas:\n")
-	
-	// Generate schema definitions
-	for _, model := range models {
-		// Main model schema
-		content.WriteString(fmt.Sprintf("    %s:\n", model.Name))
-		content.WriteString("      type: object\n")
-		content.WriteString("      properties:\n")
-		
-		for _, field := range model.Fields {
-			content.WriteString(fmt.Sprintf("        %s:\n", field.Name))
-			content.WriteString(fmt.Sprintf("          type: %s\n", field.getOpenAPIType()))
-			if field.Type == "time" {
-				content.WriteString("          format: date-time\n")
-			}
-			if field.Name == "id" {
-				content.WriteString("          format: int64\n")
-			}
+	spec := g.Spec()
+
+	var content []byte
+	var err error
+	if strings.EqualFold(filepath.Ext(outputFile), ".json") {
+		content, err = json.MarshalIndent(spec, "", "  ")
+		content = append(content, '\n')
+	} else {
+		content, err = marshalYAML(spec)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+
+	return os.WriteFile(outputFile, content, 0644)
+}
+
+// Spec builds the OpenAPI specification of the analyzed models
+func (g *OpenAPIGenerator) Spec() *OpenAPISpec {
+	spec := &OpenAPISpec{
+		OpenAPI: "3.0.3",
+		Info: OpenAPIInfo{
+			Title:       g.title,
+			Description: g.description,
+			Version:     g.version,
+		},
+		Servers: []OpenAPIServer{
+			{URL: "http://localhost:8080/api/v1", Description: "Development server"},
+		},
+		Paths: make(map[string]*OpenAPIPathItem),
+		Components: OpenAPIComponents{
+			Schemas: make(map[string]*OpenAPISchema),
+		},
+	}
+
+	for _, model := range g.analyzer.GetModels() {
+		modelPath := "/" + strings.ToLower(model.Name)
+		spec.Paths[modelPath] = g.collectionPath(model)
+		spec.Paths[modelPath+"/{id}"] = g.objectPath(model)
+
+		spec.Components.Schemas[model.Name] = modelSchema(model, false)
+		spec.Components.Schemas["Create"+model.Name+"Request"] = modelSchema(model, true)
+		update := modelSchema(model, true)
+		update.Required = nil
+		spec.Components.Schemas["Update"+model.Name+"Request"] = update
+		spec.Components.Schemas["List"+model.Name+"Response"] = &OpenAPISchema{
+			Type: "object",
+			Properties: map[string]*OpenAPISchema{
+				"items":     {Type: "array", Items: schemaRef(model.Name)},
+				"total":     {Type: "integer"},
+				"page":      {Type: "integer"},
+				"page_size": {Type: "integer"},
+			},
+		}
+	}
+
+	return spec
+}
+
+// collectionPath describes listing and creating a model's objects
+func (g *OpenAPIGenerator) collectionPath(model *ModelInfo) *OpenAPIPathItem {
+	tags := []string{model.Name}
+	return &OpenAPIPathItem{
+		Get: &OpenAPIOperation{
+			Summary: "List " + model.Name,
+			Tags:    tags,
+			Parameters: []OpenAPIParameter{
+				{Name: "page", In: "query", Schema: &OpenAPISchema{Type: "integer", Default: 1}},
+				{Name: "page_size", In: "query", Schema: &OpenAPISchema{Type: "integer", Default: 20}},
+				{Name: "search", In: "query", Schema: &OpenAPISchema{Type: "string"}},
+			},
+			Responses: map[string]OpenAPIResponse{
+				"200": jsonResponse("Success", "List"+model.Name+"Response"),
+			},
+		},
+		Post: &OpenAPIOperation{
+			Summary:     "Create " + model.Name,
+			Tags:        tags,
+			RequestBody: jsonRequestBody("Create" + model.Name + "Request"),
+			Responses: map[string]OpenAPIResponse{
+				"201": jsonResponse("Created", model.Name),
+			},
+		},
+	}
+}
+
+// objectPath describes reading, updating and deleting one object
+func (g *OpenAPIGenerator) objectPath(model *ModelInfo) *OpenAPIPathItem {
+	tags := []string{model.Name}
+	id := []OpenAPIParameter{
+		{Name: "id", In: "path", Required: true, Schema: &OpenAPISchema{Type: "integer", Format: "int64"}},
+	}
+	notFound := OpenAPIResponse{Description: "Not found"}
+
+	return &OpenAPIPathItem{
+		Get: &OpenAPIOperation{
+			Summary:    "Get " + model.Name + " by ID",
+			Tags:       tags,
+			Parameters: id,
+			Responses: map[string]OpenAPIResponse{
+				"200": jsonResponse("Success", model.Name),
+				"404": notFound,
+			},
+		},
+		Put: &OpenAPIOperation{
+			Summary:     "Update " + model.Name,
+			Tags:        tags,
+			Parameters:  id,
+			RequestBody: jsonRequestBody("Update" + model.Name + "Request"),
+			Responses: map[string]OpenAPIResponse{
+				"200": jsonResponse("Updated", model.Name),
+				"404": notFound,
+			},
+		},
+		Delete: &OpenAPIOperation{
+			Summary:    "Delete " + model.Name,
+			Tags:       tags,
+			Parameters: id,
+			Responses: map[string]OpenAPIResponse{
+				"204": {Description: "Deleted"},
+				"404": notFound,
+			},
+		},
+	}
+}
+
+// modelSchema describes a model's fields. Objects always have their
+// non-nillable fields; request schemas leave out the fields the server sets
+// and require the fields that are neither optional nor defaulted.
+func modelSchema(model *ModelInfo, request bool) *OpenAPISchema {
+	schema := &OpenAPISchema{
+		Type:       "object",
+		Properties: make(map[string]*OpenAPISchema),
+	}
+
+	for _, field := range model.Fields {
+		if request && isServerSetField(field.Name) {
+			continue
 		}
-		content.WriteString("\n")
-
-		// Create request schema
-		content.WriteString(fmt.Sprintf("    Create%sRequest:\n", model.Name))
-		content.WriteString("      type: object\n")
-		content.WriteString("      required:\n")
-		content.WriteString("      properties:\n")
-		
-		for _, field := range model.Fields {
-			if field.Name == "id" || field.Name == "created_at" || field.Name == "updated_at" {
-				continue
-			}
-			content.WriteString(fmt.Sprintf("        %s:\n", field.Name))
-			content.WriteString(fmt.Sprintf("          type: %s\n", field.getOpenAPIType()))
-			if field.Type == "time" {
-				content.WriteString("          format: date-time\n")
-			}
+		schema.Properties[field.Name] = fieldSchema(field)
+
+		required := !field.Nillable
+		if request {
+			required = !field.Optional && field.Default == nil
 		}
-		content.WriteString("\n")
-
-		// Update request schema
-		content.WriteString(fmt.Sprintf("    Update%sRequest:\n", model.Name))
-		content.WriteString("      type: object\n")
-		content.WriteString("      properties:\n")
-		
-		for _, field := range model.Fields {
-			if field.Name == "id" || field.Name == "created_at" || field.Name == "updated_at" {
-				continue
-			}
-			content.WriteString(fmt.Sprintf("        %s:\n", field.Name))
-			content.WriteString(fmt.Sprintf("          type: %s\n", field.getOpenAPIType()))
-			if field.Type == "time" {
-				content.WriteString("          format: date-time\n")
-			}
+		if required {
+			schema.Required = append(schema.Required, field.Name)
 		}
-		content.WriteString("\n")
-
-		// List response schema
-		content.WriteString(fmt.Sprintf("    List%sResponse:\n", model.Name))
-		content.WriteString("      type: object\n")
-		content.WriteString("      properties:\n")
-		content.WriteString("        items:\n")
-		content.WriteString("          type: array\n")
-		content.WriteString("          items:\n")
-		content.WriteString(fmt.Sprintf("            $ref: '#/components/schemas/%s'\n", model.Name))
-		content.WriteString("        total:\n")
-		content.WriteString("          type: integer\n")
-		content.WriteString("        page:\n")
-		content.WriteString("          type: integer\n")
-		content.WriteString("        page_size:\n")
-		content.WriteString("          type: integer\n")
-		content.WriteString("\n")
-	}
-
-	return content.String()
-}
\ No newline at end of file
+	}
+	return schema
+}
+
+// fieldSchema describes a single field with its constraints, so clients
+// can validate values before sending them
+func fieldSchema(field *FieldInfo) *OpenAPISchema {
+	schema := &OpenAPISchema{
+		Type:        field.getOpenAPIType(),
+		Format:      field.getOpenAPIFormat(),
+		Description: field.Description,
+		Nullable:    field.Nillable,
+		Enum:        field.EnumValues,
+		Minimum:     field.Min,
+		Maximum:     field.Max,
+	}
+	if field.MinLength > 0 {
+		schema.MinLength = &field.MinLength
+	}
+	if field.MaxLength > 0 {
+		schema.MaxLength = &field.MaxLength
+	}
+	return schema
+}
+
+// isServerSetField reports whether a field is set by the server rather than
+// by requests
+func isServerSetField(name string) bool {
+	switch name {
+	case "id", "created_at", "updated_at", "create_time", "update_time":
+		return true
+	}
+	return false
+}
+
+// schemaRef references a component schema
+func schemaRef(name string) *OpenAPISchema {
+	return &OpenAPISchema{Ref: "#/components/schemas/" + name}
+}
+
+// jsonResponse is a response with a JSON body of a component schema
+func jsonResponse(description, schema string) OpenAPIResponse {
+	return OpenAPIResponse{
+		Description: description,
+		Content: map[string]OpenAPIMediaType{
+			"application/json": {Schema: schemaRef(schema)},
+		},
+	}
+}
+
+// jsonRequestBody is a required JSON body of a component schema
+func jsonRequestBody(schema string) *OpenAPIRequestBody {
+	return &OpenAPIRequestBody{
+		Required: true,
+		Content: map[string]OpenAPIMediaType{
+			"application/json": {Schema: schemaRef(schema)},
+		},
+	}
+}
+
+// marshalYAML encodes a value as YAML indented by two spaces
+func marshalYAML(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
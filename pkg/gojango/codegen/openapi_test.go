@@ -0,0 +1,183 @@
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// newArticleAnalyzer returns an analyzer holding one model with required,
+// optional and defaulted fields
+func newArticleAnalyzer() *SchemaAnalyzer {
+	analyzer := NewEntSchemaAnalyzer("")
+	analyzer.models = []*ModelInfo{{
+		Name: "Article",
+		Fields: []*FieldInfo{
+			{Name: "id", Type: "int"},
+			{Name: "title", Type: "string"},
+			{Name: "summary", Type: "string", Optional: true},
+			{Name: "views", Type: "int", Default: 0},
+			{Name: "published_at", Type: "time"},
+			{Name: "created_at", Type: "time"},
+		},
+	}}
+	return analyzer
+}
+
+func TestOpenAPIGeneratorFormats(t *testing.T) {
+	for _, name := range []string{"openapi.yaml", "openapi.json"} {
+		t.Run(name, func(t *testing.T) {
+			outputFile := filepath.Join(t.TempDir(), name)
+			generator := NewOpenAPIGenerator(newArticleAnalyzer()).WithInfo("Blog API", "2.1.0", "Articles and more")
+			if err := generator.Generate(outputFile); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			content, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatalf("Failed to read spec: %v", err)
+			}
+
+			var spec map[string]interface{}
+			if strings.HasSuffix(name, ".json") {
+				err = json.Unmarshal(content, &spec)
+			} else {
+				err = yaml.Unmarshal(content, &spec)
+			}
+			if err != nil {
+				t.Fatalf("Generated spec is not well-formed: %v\n%s", err, content)
+			}
+
+			info := spec["info"].(map[string]interface{})
+			if info["title"] != "Blog API" || info["version"] != "2.1.0" || info["description"] != "Articles and more" {
+				t.Errorf("Expected custom info, got: %v", info)
+			}
+
+			schemas := spec["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+			create := schemas["CreateArticleRequest"].(map[string]interface{})
+			if required := create["required"]; !reflect.DeepEqual(required, []interface{}{"title", "published_at"}) {
+				t.Errorf("Expected only title and published_at to be required, got: %v", required)
+			}
+			if _, ok := create["properties"].(map[string]interface{})["id"]; ok {
+				t.Error("Expected id to be left out of the create request")
+			}
+			if _, ok := schemas["UpdateArticleRequest"].(map[string]interface{})["required"]; ok {
+				t.Error("Expected no required fields in the update request")
+			}
+
+			// Every reference points at a defined schema
+			refs := collectRefs(spec)
+			if len(refs) == 0 {
+				t.Fatal("Expected references in the spec")
+			}
+			for _, ref := range refs {
+				name := strings.TrimPrefix(ref, "#/components/schemas/")
+				if _, ok := schemas[name]; !ok || name == ref {
+					t.Errorf("Reference %s does not resolve", ref)
+				}
+			}
+		})
+	}
+}
+
+func TestFieldSchema(t *testing.T) {
+	minViews, maxViews := 0.0, 1000000.0
+	tests := []struct {
+		name     string
+		field    *FieldInfo
+		expected *OpenAPISchema
+	}{
+		{
+			name:     "enum",
+			field:    &FieldInfo{Name: "status", Type: "enum", EnumValues: []string{"draft", "published"}},
+			expected: &OpenAPISchema{Type: "string", Enum: []string{"draft", "published"}},
+		},
+		{
+			name:     "string with max length",
+			field:    &FieldInfo{Name: "title", Type: "string", MinLength: 1, MaxLength: 200, Description: "Headline"},
+			expected: &OpenAPISchema{Type: "string", Description: "Headline", MinLength: intPtr(1), MaxLength: intPtr(200)},
+		},
+		{
+			name:     "ranged integer",
+			field:    &FieldInfo{Name: "views", Type: "int64", Min: &minViews, Max: &maxViews},
+			expected: &OpenAPISchema{Type: "integer", Format: "int64", Minimum: &minViews, Maximum: &maxViews},
+		},
+		{
+			name:     "nillable time",
+			field:    &FieldInfo{Name: "published_at", Type: "time", Nillable: true},
+			expected: &OpenAPISchema{Type: "string", Format: "date-time", Nullable: true},
+		},
+		{
+			name:     "email",
+			field:    &FieldInfo{Name: "author_email", Type: "string"},
+			expected: &OpenAPISchema{Type: "string", Format: "email"},
+		},
+		{
+			name:     "url",
+			field:    &FieldInfo{Name: "cover_url", Type: "string"},
+			expected: &OpenAPISchema{Type: "string", Format: "uri"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if schema := fieldSchema(tt.field); !reflect.DeepEqual(schema, tt.expected) {
+				t.Errorf("Expected %+v, got: %+v", tt.expected, schema)
+			}
+		})
+	}
+}
+
+func TestModelSchemaRequired(t *testing.T) {
+	model := &ModelInfo{
+		Name: "Article",
+		Fields: []*FieldInfo{
+			{Name: "id", Type: "int"},
+			{Name: "title", Type: "string"},
+			{Name: "subtitle", Type: "string", Optional: true, Nillable: true},
+			{Name: "status", Type: "enum", EnumValues: []string{"draft", "published"}, Default: "draft"},
+		},
+	}
+
+	object := modelSchema(model, false)
+	if expected := []string{"id", "title", "status"}; !reflect.DeepEqual(object.Required, expected) {
+		t.Errorf("Expected the non-nillable fields %v to be required, got: %v", expected, object.Required)
+	}
+	if !object.Properties["subtitle"].Nullable {
+		t.Error("Expected the nillable field to be nullable")
+	}
+
+	request := modelSchema(model, true)
+	if expected := []string{"title"}; !reflect.DeepEqual(request.Required, expected) {
+		t.Errorf("Expected only %v to be required in requests, got: %v", expected, request.Required)
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// collectRefs returns every $ref in a decoded document
+func collectRefs(value interface{}) []string {
+	var refs []string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if ref, ok := item.(string); ok && key == "$ref" {
+				refs = append(refs, ref)
+				continue
+			}
+			refs = append(refs, collectRefs(item)...)
+		}
+	case []interface{}:
+		for _, item := range v {
+			refs = append(refs, collectRefs(item)...)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,238 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// postmanSchema is the schema URL of Postman v2.1 collections
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+// PostmanGenerator generates Postman collections from analyzed schemas, with
+// the same endpoints as the OpenAPI specification
+type PostmanGenerator struct {
+	analyzer    *SchemaAnalyzer
+	name        string
+	description string
+	baseURL     string
+}
+
+// NewPostmanGenerator creates a new Postman generator
+func NewPostmanGenerator(analyzer *SchemaAnalyzer) *PostmanGenerator {
+	return &PostmanGenerator{
+		analyzer:    analyzer,
+		name:        "Gojango API",
+		description: "Auto-generated API from Ent schemas",
+		baseURL:     "http://localhost:8080/api/v1",
+	}
+}
+
+// WithInfo sets the name and description of the collection
+func (g *PostmanGenerator) WithInfo(name, description string) *PostmanGenerator {
+	g.name = name
+	g.description = description
+	return g
+}
+
+// WithBaseURL sets the default value of the collection's {{baseUrl}} variable
+func (g *PostmanGenerator) WithBaseURL(baseURL string) *PostmanGenerator {
+	g.baseURL = baseURL
+	return g
+}
+
+// PostmanCollection is a Postman v2.1 collection
+type PostmanCollection struct {
+	Info     PostmanInfo       `json:"info"`
+	Item     []PostmanItem     `json:"item"`
+	Variable []PostmanVariable `json:"variable,omitempty"`
+}
+
+// PostmanInfo describes the collection
+type PostmanInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+// PostmanItem is a folder of items, or a request when Request is set
+type PostmanItem struct {
+	Name    string          `json:"name"`
+	Item    []PostmanItem   `json:"item,omitempty"`
+	Request *PostmanRequest `json:"request,omitempty"`
+}
+
+// PostmanRequest is a single request
+type PostmanRequest struct {
+	Method string          `json:"method"`
+	Header []PostmanHeader `json:"header"`
+	URL    PostmanURL      `json:"url"`
+	Body   *PostmanBody    `json:"body,omitempty"`
+}
+
+// PostmanHeader is a request header
+type PostmanHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// PostmanURL is a request URL, both raw and split into parts
+type PostmanURL struct {
+	Raw      string            `json:"raw"`
+	Host     []string          `json:"host"`
+	Path     []string          `json:"path"`
+	Query    []PostmanVariable `json:"query,omitempty"`
+	Variable []PostmanVariable `json:"variable,omitempty"`
+}
+
+// PostmanBody is a raw request body
+type PostmanBody struct {
+	Mode    string                 `json:"mode"`
+	Raw     string                 `json:"raw"`
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// PostmanVariable is a collection variable, path variable or query parameter
+type PostmanVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Generate writes the collection to outputFile as JSON
+func (g *PostmanGenerator) Generate(outputFile string) error {
+	content, err := json.MarshalIndent(g.Collection(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode Postman collection: %w", err)
+	}
+	return os.WriteFile(outputFile, append(content, '\n'), 0644)
+}
+
+// Collection builds the Postman collection of the analyzed models: a folder
+// per model with requests to list, get, create, update and delete objects
+func (g *PostmanGenerator) Collection() *PostmanCollection {
+	collection := &PostmanCollection{
+		Info: PostmanInfo{
+			Name:        g.name,
+			Description: g.description,
+			Schema:      postmanSchema,
+		},
+		Item:     make([]PostmanItem, 0),
+		Variable: []PostmanVariable{{Key: "baseUrl", Value: g.baseURL}},
+	}
+
+	for _, model := range g.analyzer.GetModels() {
+		collection.Item = append(collection.Item, g.modelFolder(model))
+	}
+	return collection
+}
+
+// modelFolder holds the requests on one model
+func (g *PostmanGenerator) modelFolder(model *ModelInfo) PostmanItem {
+	resource := strings.ToLower(model.Name)
+	list := postmanURL(resource)
+	list.Raw += "?page=1&page_size=20"
+	list.Query = []PostmanVariable{{Key: "page", Value: "1"}, {Key: "page_size", Value: "20"}}
+
+	return PostmanItem{
+		Name: model.Name,
+		Item: []PostmanItem{
+			postmanRequest("List "+model.Name, "GET", list, nil),
+			postmanRequest("Get "+model.Name, "GET", postmanObjectURL(resource), nil),
+			postmanRequest("Create "+model.Name, "POST", postmanURL(resource), exampleBody(model)),
+			postmanRequest("Update "+model.Name, "PUT", postmanObjectURL(resource), exampleBody(model)),
+			postmanRequest("Delete "+model.Name, "DELETE", postmanObjectURL(resource), nil),
+		},
+	}
+}
+
+// postmanRequest builds a request item, sending body as JSON when it is set
+func postmanRequest(name, method string, url PostmanURL, body map[string]interface{}) PostmanItem {
+	request := &PostmanRequest{
+		Method: method,
+		Header: []PostmanHeader{{Key: "Accept", Value: "application/json"}},
+		URL:    url,
+	}
+	if body != nil {
+		raw, _ := json.MarshalIndent(body, "", "  ")
+		request.Header = append(request.Header, PostmanHeader{Key: "Content-Type", Value: "application/json"})
+		request.Body = &PostmanBody{
+			Mode:    "raw",
+			Raw:     string(raw),
+			Options: map[string]interface{}{"raw": map[string]string{"language": "json"}},
+		}
+	}
+	return PostmanItem{Name: name, Request: request}
+}
+
+// postmanURL is the URL of a collection under {{baseUrl}}
+func postmanURL(resource string) PostmanURL {
+	return PostmanURL{
+		Raw:  "{{baseUrl}}/" + resource,
+		Host: []string{"{{baseUrl}}"},
+		Path: []string{resource},
+	}
+}
+
+// postmanObjectURL is the URL of one object, with its id as a path variable
+func postmanObjectURL(resource string) PostmanURL {
+	return PostmanURL{
+		Raw:      "{{baseUrl}}/" + resource + "/:id",
+		Host:     []string{"{{baseUrl}}"},
+		Path:     []string{resource, ":id"},
+		Variable: []PostmanVariable{{Key: "id", Value: "1"}},
+	}
+}
+
+// exampleBody is an example request body with a value for every field
+// requests set
+func exampleBody(model *ModelInfo) map[string]interface{} {
+	body := make(map[string]interface{})
+	for _, field := range model.Fields {
+		if isServerSetField(field.Name) {
+			continue
+		}
+		body[field.Name] = exampleValue(field)
+	}
+	return body
+}
+
+// exampleValue is a plausible value for a field, from its default, enum
+// values, type and format
+func exampleValue(field *FieldInfo) interface{} {
+	if _, computed := field.Default.(DefaultExpr); field.Default != nil && !computed {
+		return field.Default
+	}
+	if len(field.EnumValues) > 0 {
+		return field.EnumValues[0]
+	}
+
+	switch field.getOpenAPIType() {
+	case "integer":
+		if field.Min != nil {
+			return int64(*field.Min)
+		}
+		return 0
+	case "number":
+		if field.Min != nil {
+			return *field.Min
+		}
+		return 0.0
+	case "boolean":
+		return false
+	case "object":
+		return map[string]interface{}{}
+	}
+
+	switch field.getOpenAPIFormat() {
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "email":
+		return "user@example.com"
+	case "uri":
+		return "https://example.com"
+	}
+	return field.Name
+}
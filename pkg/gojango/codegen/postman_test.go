@@ -0,0 +1,79 @@
+package codegen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPostmanGenerator(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "postman_collection.json")
+	generator := NewPostmanGenerator(newArticleAnalyzer()).WithInfo("Blog API", "").WithBaseURL("https://api.example.com")
+	if err := generator.Generate(outputFile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read collection: %v", err)
+	}
+	var collection PostmanCollection
+	if err := json.Unmarshal(content, &collection); err != nil {
+		t.Fatalf("Generated collection is not well-formed: %v\n%s", err, content)
+	}
+
+	if collection.Info.Name != "Blog API" || collection.Info.Schema != postmanSchema {
+		t.Errorf("Expected a v2.1 collection named Blog API, got: %+v", collection.Info)
+	}
+	if expected := []PostmanVariable{{Key: "baseUrl", Value: "https://api.example.com"}}; !reflect.DeepEqual(collection.Variable, expected) {
+		t.Errorf("Expected variables %v, got: %v", expected, collection.Variable)
+	}
+
+	if len(collection.Item) != 1 || collection.Item[0].Name != "Article" {
+		t.Fatalf("Expected one Article folder, got: %+v", collection.Item)
+	}
+
+	var methods, urls []string
+	requests := make(map[string]*PostmanRequest)
+	for _, item := range collection.Item[0].Item {
+		methods = append(methods, item.Request.Method)
+		urls = append(urls, item.Request.URL.Raw)
+		requests[item.Name] = item.Request
+	}
+	if expected := []string{"GET", "GET", "POST", "PUT", "DELETE"}; !reflect.DeepEqual(methods, expected) {
+		t.Errorf("Expected methods %v, got: %v", expected, methods)
+	}
+	expectedURLs := []string{
+		"{{baseUrl}}/article?page=1&page_size=20",
+		"{{baseUrl}}/article/:id",
+		"{{baseUrl}}/article",
+		"{{baseUrl}}/article/:id",
+		"{{baseUrl}}/article/:id",
+	}
+	if !reflect.DeepEqual(urls, expectedURLs) {
+		t.Errorf("Expected URLs %v, got: %v", expectedURLs, urls)
+	}
+
+	create := requests["Create Article"]
+	if create == nil || create.Body == nil {
+		t.Fatal("Expected the create request to have a body")
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(create.Body.Raw), &body); err != nil {
+		t.Fatalf("Example body is not JSON: %v", err)
+	}
+	expectedBody := map[string]interface{}{
+		"title":        "title",
+		"summary":      "summary",
+		"views":        float64(0),
+		"published_at": "2024-01-01T00:00:00Z",
+	}
+	if !reflect.DeepEqual(body, expectedBody) {
+		t.Errorf("Expected example body %v, got: %v", expectedBody, body)
+	}
+	if requests["Delete Article"].Body != nil {
+		t.Error("Expected no body on the delete request")
+	}
+}
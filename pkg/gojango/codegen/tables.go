@@ -0,0 +1,38 @@
+package codegen
+
+import (
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+// Tables returns the database tables of the analyzed models as Ent creates
+// them, for diffing against the database. Foreign keys of edges are only
+// included when the edge declares its field.
+func (a *SchemaAnalyzer) Tables() []db.TableSchema {
+	tables := make([]db.TableSchema, 0, len(a.models))
+	for _, model := range a.models {
+		table := db.TableSchema{Name: model.TableName}
+		for _, field := range model.Fields {
+			table.Columns = append(table.Columns, fieldColumn(field))
+		}
+		tables = append(tables, table)
+	}
+	return tables
+}
+
+// fieldColumn describes the column of a field. Optional fields are
+// nullable, as in Ent, and defaults computed in Go have no SQL default.
+func fieldColumn(field *FieldInfo) db.ColumnSchema {
+	column := db.ColumnSchema{
+		Name:       field.Name,
+		Type:       field.Type,
+		Size:       field.MaxLength,
+		Enum:       field.EnumValues,
+		Nullable:   field.Optional || field.Nillable,
+		Unique:     field.Unique,
+		PrimaryKey: field.Name == "id",
+	}
+	if _, computed := field.Default.(DefaultExpr); !computed {
+		column.Default = field.Default
+	}
+	return column
+}
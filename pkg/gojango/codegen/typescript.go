@@ -0,0 +1,101 @@
+package codegen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// TypeScriptGenerator generates TypeScript interfaces from analyzed schemas,
+// matching the JSON shapes of the OpenAPI specification
+type TypeScriptGenerator struct {
+	analyzer *SchemaAnalyzer
+}
+
+// NewTypeScriptGenerator creates a new TypeScript generator
+func NewTypeScriptGenerator(analyzer *SchemaAnalyzer) *TypeScriptGenerator {
+	return &TypeScriptGenerator{
+		analyzer: analyzer,
+	}
+}
+
+// Generate writes the interfaces to outputFile, creating its directory
+func (g *TypeScriptGenerator) Generate(outputFile string) error {
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return os.WriteFile(outputFile, []byte(g.Source()), 0644)
+}
+
+// Source returns the TypeScript source: for each model an interface, the
+// Create and Update request types and the list response
+func (g *TypeScriptGenerator) Source() string {
+	var content strings.Builder
+	content.WriteString("// Code generated by gojango generate ts. DO NOT EDIT.\n")
+
+	for _, model := range g.analyzer.GetModels() {
+		fmt.Fprintf(&content, "\nexport interface %s {\n", model.Name)
+		for _, field := range model.Fields {
+			writeTypeScriptField(&content, field, field.Optional || field.Nillable)
+		}
+		content.WriteString("}\n")
+
+		fmt.Fprintf(&content, "\nexport interface Create%sRequest {\n", model.Name)
+		for _, field := range model.Fields {
+			if isServerSetField(field.Name) {
+				continue
+			}
+			writeTypeScriptField(&content, field, field.Optional || field.Nillable || field.Default != nil)
+		}
+		content.WriteString("}\n")
+
+		fmt.Fprintf(&content, "\nexport type Update%[1]sRequest = Partial<Create%[1]sRequest>;\n", model.Name)
+
+		fmt.Fprintf(&content, "\nexport interface List%sResponse {\n", model.Name)
+		fmt.Fprintf(&content, "  items: %s[];\n", model.Name)
+		content.WriteString("  total: number;\n  page: number;\n  page_size: number;\n}\n")
+	}
+
+	return content.String()
+}
+
+// writeTypeScriptField writes an interface member, marking it with ? when
+// it may be left out
+func writeTypeScriptField(content *strings.Builder, field *FieldInfo, optional bool) {
+	name := field.JSONTag
+	if name == "" {
+		name = field.Name
+	}
+	marker := ""
+	if optional {
+		marker = "?"
+	}
+	fmt.Fprintf(content, "  %s%s: %s;\n", name, marker, field.getTypeScriptType())
+}
+
+// getTypeScriptType converts Go types to TypeScript types; enum fields
+// become a union of their values
+func (f *FieldInfo) getTypeScriptType() string {
+	if len(f.EnumValues) > 0 {
+		values := make([]string, len(f.EnumValues))
+		for i, value := range f.EnumValues {
+			values[i] = strconv.Quote(value)
+		}
+		return strings.Join(values, " | ")
+	}
+
+	switch f.getOpenAPIType() {
+	case "integer", "number":
+		return "number"
+	case "boolean":
+		return "boolean"
+	case "object":
+		return "Record<string, unknown>"
+	}
+	if f.Type == "time" {
+		return "Date"
+	}
+	return "string"
+}
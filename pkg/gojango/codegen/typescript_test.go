@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files with the generated output")
+
+func TestTypeScriptGeneratorGolden(t *testing.T) {
+	analyzer := NewEntSchemaAnalyzer("")
+	analyzer.models = []*ModelInfo{{
+		Name: "Note",
+		Fields: []*FieldInfo{
+			{Name: "title", Type: "string"},
+			{Name: "archived_at", Type: "time", Optional: true, Nillable: true},
+		},
+	}}
+
+	outputFile := filepath.Join(t.TempDir(), "src", "types.ts")
+	if err := NewTypeScriptGenerator(analyzer).Generate(outputFile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	generated, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "note.ts.golden")
+	if *update {
+		if err := os.WriteFile(golden, generated, 0644); err != nil {
+			t.Fatalf("Failed to update %s: %v", golden, err)
+		}
+	}
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", golden, err)
+	}
+	if string(generated) != string(expected) {
+		t.Errorf("Generated TypeScript does not match %s:\n%s", golden, generated)
+	}
+}
+
+func TestTypeScriptType(t *testing.T) {
+	tests := map[string]*FieldInfo{
+		"number":                  {Type: "int64"},
+		"boolean":                 {Type: "bool"},
+		"Date":                    {Type: "time"},
+		"string":                  {Type: "uuid"},
+		"Record<string, unknown>": {Type: "json"},
+		`"draft" | "published"`:   {Type: "enum", EnumValues: []string{"draft", "published"}},
+	}
+
+	for expected, field := range tests {
+		if tsType := field.getTypeScriptType(); tsType != expected {
+			t.Errorf("Expected %s for %s, got: %s", expected, field.Type, tsType)
+		}
+	}
+}
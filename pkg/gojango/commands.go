@@ -0,0 +1,47 @@
+package gojango
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// GetCommands returns the management commands of an app
+func (r *Registry) GetCommands(appName string) []*cobra.Command {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.commands[appName]
+}
+
+// AddCommands adds the management commands of the registered apps to a
+// project's root command, in registration order. Each command runs under
+// its own name, e.g. "manage.go send_digest", and under its app's
+// namespace, "manage.go blog:send_digest". A command whose name is taken by
+// a built-in command or an earlier app's is only added under its namespace.
+func (r *Registry) AddCommands(root *cobra.Command) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	taken := make(map[string]bool)
+	for _, cmd := range root.Commands() {
+		taken[cmd.Name()] = true
+		for _, alias := range cmd.Aliases {
+			taken[alias] = true
+		}
+	}
+
+	for _, appName := range r.registrationOrder() {
+		for _, cmd := range r.commands[appName] {
+			name := cmd.Name()
+			namespaced := appName + ":" + name
+			if taken[name] {
+				cmd.Use = namespaced + strings.TrimPrefix(cmd.Use, name)
+			} else {
+				cmd.Aliases = append(cmd.Aliases, namespaced)
+				taken[name] = true
+			}
+			taken[namespaced] = true
+			root.AddCommand(cmd)
+		}
+	}
+}
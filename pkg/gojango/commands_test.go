@@ -0,0 +1,77 @@
+package gojango
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// commandApp provides management commands
+type commandApp struct {
+	BaseApp
+	name     string
+	commands []*cobra.Command
+}
+
+func (app *commandApp) Config() AppConfig {
+	return AppConfig{Name: app.name}
+}
+
+func (app *commandApp) Commands() []*cobra.Command {
+	return app.commands
+}
+
+func TestRegistryAddCommands(t *testing.T) {
+	registry := NewRegistry()
+
+	sent := ""
+	registry.RegisterApp(&commandApp{name: "blog", commands: []*cobra.Command{
+		{
+			Use: "send_digest [email]",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				sent = args[0]
+				return nil
+			},
+		},
+		{Use: "shell", Run: func(cmd *cobra.Command, args []string) {}},
+	}})
+	registry.RegisterApp(&commandApp{name: "newsletter", commands: []*cobra.Command{
+		{Use: "send_digest", Run: func(cmd *cobra.Command, args []string) {}},
+	}})
+
+	if got := len(registry.GetCommands("blog")); got != 2 {
+		t.Errorf("Expected 2 blog commands, got %d", got)
+	}
+
+	root := &cobra.Command{Use: "manage.go"}
+	root.AddCommand(&cobra.Command{Use: "shell", Run: func(cmd *cobra.Command, args []string) {}})
+	registry.AddCommands(root)
+
+	names := make(map[string]bool)
+	for _, cmd := range root.Commands() {
+		names[cmd.Name()] = true
+	}
+	for _, name := range []string{"shell", "send_digest", "blog:shell", "newsletter:send_digest"} {
+		if !names[name] {
+			t.Errorf("Expected subcommand %q, got %v", name, names)
+		}
+	}
+
+	root.SetArgs([]string{"send_digest", "team@example.com"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if sent != "team@example.com" {
+		t.Errorf("Expected the blog command to run, got %q", sent)
+	}
+
+	// The namespaced name runs the same command
+	sent = ""
+	root.SetArgs([]string{"blog:send_digest", "ops@example.com"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if sent != "ops@example.com" {
+		t.Errorf("Expected the namespaced blog command to run, got %q", sent)
+	}
+}
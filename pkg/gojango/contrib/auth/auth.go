@@ -0,0 +1,180 @@
+// Package auth provides user authentication for Gojango projects, the
+// equivalent of django.contrib.auth.
+//
+// Projects supply their user model through the User interface and a
+// UserStore that loads users, and choose a Backend that remembers logged-in
+// users between requests: server-side sessions referenced by a signed
+// cookie, or stateless JWT bearer tokens.
+//
+//	authn := auth.New(store, auth.NewJWTBackend(auth.JWTConfig{Secret: secret}))
+//	router.Use(authn.Middleware())
+//	authn.RegisterRoutes(router.Group("/auth"))
+//	router.GET("/profile", auth.Required(), profileHandler)
+//
+// The middleware stores the current user under admin.UserContextKey, so the
+// admin's PermissionChecker receives it.
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/epuerta9/gojango/pkg/gojango/admin"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	// ErrUserNotFound is returned by a UserStore when no user matches
+	ErrUserNotFound = errors.New("user not found")
+
+	// ErrInvalidCredentials is returned when a username and password do not
+	// identify an active user
+	ErrInvalidCredentials = errors.New("invalid username or password")
+)
+
+// User is the contract a project's user model implements
+type User interface {
+	// GetID returns the user's primary key as a string
+	GetID() string
+	// GetUsername returns the name the user logs in with
+	GetUsername() string
+	// GetPasswordHash returns the hash created by HashPassword
+	GetPasswordHash() string
+	// IsActive reports whether the user may log in
+	IsActive() bool
+	// IsStaff reports whether the user may use the admin
+	IsStaff() bool
+	// IsSuperuser reports whether the user has every permission
+	IsSuperuser() bool
+}
+
+// UserStore loads users, typically from the database
+type UserStore interface {
+	GetByID(ctx context.Context, id string) (User, error)
+	GetByUsername(ctx context.Context, username string) (User, error)
+}
+
+// Backend remembers which user is logged in between requests
+type Backend interface {
+	// Login records user as authenticated for later requests and returns
+	// values to include in the login response, such as a token
+	Login(c *gin.Context, user User) (map[string]interface{}, error)
+
+	// Logout forgets the authenticated user of the request
+	Logout(c *gin.Context) error
+
+	// UserID returns the ID of the user authenticated by the request, or ""
+	// for anonymous requests
+	UserID(c *gin.Context) (string, error)
+}
+
+// Authenticate checks a username and password against the store. It
+// returns ErrInvalidCredentials for unknown users, wrong passwords and
+// inactive users alike.
+func Authenticate(ctx context.Context, store UserStore, username, password string) (User, error) {
+	user, err := store.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrUserNotFound) {
+			// Hash anyway so response times do not reveal which usernames exist
+			CheckPassword(dummyPasswordHash(), password)
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if !CheckPassword(user.GetPasswordHash(), password) || !user.IsActive() {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// Auth authenticates requests with a user store and backend
+type Auth struct {
+	store   UserStore
+	backend Backend
+}
+
+// New creates an Auth for a user store and backend
+func New(store UserStore, backend Backend) *Auth {
+	return &Auth{store: store, backend: backend}
+}
+
+// Backend returns the backend used to remember logged-in users
+func (a *Auth) Backend() Backend {
+	return a.backend
+}
+
+// Middleware identifies the user of each request and stores it in the gin
+// context and the request context. Anonymous requests pass through; use
+// Required to reject them.
+func (a *Auth) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if user := a.requestUser(c); user != nil {
+			SetUser(c, user)
+		}
+		c.Next()
+	}
+}
+
+// requestUser loads the active user authenticated by the request, if any
+func (a *Auth) requestUser(c *gin.Context) User {
+	id, err := a.backend.UserID(c)
+	if err != nil || id == "" {
+		return nil
+	}
+
+	user, err := a.store.GetByID(c.Request.Context(), id)
+	if err != nil || user == nil || !user.IsActive() {
+		return nil
+	}
+	return user
+}
+
+// Required rejects requests without an authenticated user with 401. It
+// must run after Auth.Middleware.
+func Required() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := CurrentUser(c); !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// StaffRequired rejects requests from anonymous or non-staff users. It must
+// run after Auth.Middleware.
+func StaffRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		if !user.IsStaff() && !user.IsSuperuser() {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Staff access required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// SetUser stores the current user in the gin context under
+// admin.UserContextKey and in the request context
+func SetUser(c *gin.Context, user User) {
+	c.Set(admin.UserContextKey, user)
+	if c.Request != nil {
+		c.Request = c.Request.WithContext(admin.ContextWithUser(c.Request.Context(), user))
+	}
+}
+
+// CurrentUser returns the authenticated user of the request
+func CurrentUser(c *gin.Context) (User, bool) {
+	value, exists := c.Get(admin.UserContextKey)
+	if !exists {
+		return nil, false
+	}
+	user, ok := value.(User)
+	return user, ok && user != nil
+}
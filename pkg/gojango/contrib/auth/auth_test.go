@@ -0,0 +1,240 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/admin"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func init() {
+	// Keep hashing fast in tests
+	PasswordCost = bcrypt.MinCost
+}
+
+type testUser struct {
+	id           string
+	username     string
+	passwordHash string
+	active       bool
+	staff        bool
+}
+
+func (u *testUser) GetID() string           { return u.id }
+func (u *testUser) GetUsername() string     { return u.username }
+func (u *testUser) GetPasswordHash() string { return u.passwordHash }
+func (u *testUser) IsActive() bool          { return u.active }
+func (u *testUser) IsStaff() bool           { return u.staff }
+func (u *testUser) IsSuperuser() bool       { return false }
+
+type testStore struct {
+	users []*testUser
+}
+
+func (s *testStore) GetByID(ctx context.Context, id string) (User, error) {
+	for _, u := range s.users {
+		if u.id == id {
+			return u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (s *testStore) GetByUsername(ctx context.Context, username string) (User, error) {
+	for _, u := range s.users {
+		if u.username == username {
+			return u, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func newTestStore(t *testing.T) *testStore {
+	hash, err := HashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	return &testStore{users: []*testUser{
+		{id: "1", username: "john", passwordHash: hash, active: true, staff: true},
+		{id: "2", username: "inactive", passwordHash: hash, active: false},
+	}}
+}
+
+func newTestRouter(authn *Auth) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(authn.Middleware())
+	authn.RegisterRoutes(router.Group("/auth"))
+	router.GET("/private", Required(), func(c *gin.Context) {
+		user, _ := CurrentUser(c)
+		c.String(http.StatusOK, "hello "+user.GetUsername())
+	})
+	return router
+}
+
+func login(t *testing.T, router *gin.Engine, username, password string) *httptest.ResponseRecorder {
+	body := `{"username":"` + username + `","password":"` + password + `"}`
+	req := httptest.NewRequest("POST", "/auth/login/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthenticate(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+
+	user, err := Authenticate(ctx, store, "john", "s3cret")
+	if err != nil {
+		t.Fatalf("Expected valid credentials to authenticate, got: %v", err)
+	}
+	if user.GetID() != "1" {
+		t.Errorf("Expected user 1, got %s", user.GetID())
+	}
+
+	for _, tc := range []struct{ username, password string }{
+		{"john", "wrong"},
+		{"nobody", "s3cret"},
+		{"inactive", "s3cret"},
+	} {
+		if _, err := Authenticate(ctx, store, tc.username, tc.password); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("%s/%s: expected ErrInvalidCredentials, got %v", tc.username, tc.password, err)
+		}
+	}
+}
+
+func TestRequiredRejectsAnonymousRequests(t *testing.T) {
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret")})
+	router := newTestRouter(New(newTestStore(t), backend))
+
+	for name, header := range map[string]string{
+		"no token":      "",
+		"invalid token": "Bearer not-a-token",
+		"other scheme":  "Basic am9objpzM2NyZXQ=",
+	} {
+		req := httptest.NewRequest("GET", "/private", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, w.Code)
+		}
+	}
+}
+
+func TestJWTLoginFlow(t *testing.T) {
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret")})
+	router := newTestRouter(New(newTestStore(t), backend))
+
+	if w := login(t, router, "john", "wrong"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for a wrong password, got %d", w.Code)
+	}
+
+	w := login(t, router, "john", "s3cret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected login to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	var response struct {
+		Token string                 `json:"token"`
+		User  map[string]interface{} `json:"user"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Invalid login response: %v", err)
+	}
+	if response.Token == "" || response.User["username"] != "john" {
+		t.Fatalf("Expected a token and the user in the response, got %s", w.Body.String())
+	}
+
+	req := httptest.NewRequest("GET", "/private", nil)
+	req.Header.Set("Authorization", "Bearer "+response.Token)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "hello john" {
+		t.Errorf("Expected the token to authenticate, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMiddlewareSetsAdminUser(t *testing.T) {
+	store := newTestStore(t)
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret")})
+	token, _, err := backend.IssueToken(store.users[0])
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(New(store, backend).Middleware())
+
+	var ginUser, requestUser interface{}
+	router.GET("/", func(c *gin.Context) {
+		ginUser, _ = c.Get(admin.UserContextKey)
+		requestUser = admin.UserFromContext(c.Request.Context())
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	if ginUser != store.users[0] || requestUser != store.users[0] {
+		t.Errorf("Expected the user in the gin and request contexts, got %v and %v", ginUser, requestUser)
+	}
+	if !(StaffPermissions{}).HasViewPermission(ginUser, nil) {
+		t.Error("Expected staff permissions for the authenticated staff user")
+	}
+	if (StaffPermissions{}).HasViewPermission(nil, nil) {
+		t.Error("Expected anonymous users to have no admin permissions")
+	}
+}
+
+func TestMiddlewareIgnoresInactiveUsers(t *testing.T) {
+	store := newTestStore(t)
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret")})
+	router := newTestRouter(New(store, backend))
+
+	token, _, err := backend.IssueToken(store.users[1])
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/private", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected an inactive user's token to be rejected, got %d", w.Code)
+	}
+}
+
+func TestStaffRequired(t *testing.T) {
+	store := newTestStore(t)
+	store.users[0].staff = false
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret")})
+	token, _, _ := backend.IssueToken(store.users[0])
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(New(store, backend).Middleware())
+	router.GET("/staff", StaffRequired(), func(c *gin.Context) {})
+
+	req := httptest.NewRequest("GET", "/staff", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a non-staff user, got %d", w.Code)
+	}
+}
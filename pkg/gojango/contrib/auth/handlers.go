@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+// credentials are the login request fields, accepted as JSON or form data
+type credentials struct {
+	Username string `json:"username" form:"username"`
+	Password string `json:"password" form:"password"`
+}
+
+// RegisterRoutes mounts the login, logout and current user handlers:
+// POST login/, POST logout/ and GET me/
+func (a *Auth) RegisterRoutes(routes gin.IRoutes) {
+	routes.POST("/login/", a.LoginHandler())
+	routes.POST("/logout/", a.LogoutHandler())
+	routes.GET("/me/", a.Middleware(), Required(), CurrentUserHandler())
+}
+
+// LoginHandler checks the submitted username and password and logs the
+// user in with the backend. The response describes the user and includes
+// backend values such as a JWT token.
+func (a *Auth) LoginHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var creds credentials
+		if err := c.ShouldBind(&creds); err != nil || creds.Username == "" || creds.Password == "" {
+			render.JSON(c, http.StatusBadRequest, gin.H{"error": "username and password are required"})
+			return
+		}
+
+		user, err := Authenticate(c.Request.Context(), a.store, strings.TrimSpace(creds.Username), creds.Password)
+		if err != nil {
+			if errors.Is(err, ErrInvalidCredentials) {
+				render.JSON(c, http.StatusUnauthorized, gin.H{"error": err.Error()})
+				return
+			}
+			render.JSON(c, http.StatusInternalServerError, gin.H{"error": "authentication failed"})
+			return
+		}
+
+		data, err := a.backend.Login(c, user)
+		if err != nil {
+			render.JSON(c, http.StatusInternalServerError, gin.H{"error": "login failed"})
+			return
+		}
+		SetUser(c, user)
+
+		response := gin.H{"user": userData(user)}
+		for key, value := range data {
+			response[key] = value
+		}
+		render.JSON(c, http.StatusOK, response)
+	}
+}
+
+// LogoutHandler logs the current user out with the backend
+func (a *Auth) LogoutHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := a.backend.Logout(c); err != nil {
+			render.JSON(c, http.StatusInternalServerError, gin.H{"error": "logout failed"})
+			return
+		}
+		render.JSON(c, http.StatusOK, gin.H{"logged_out": true})
+	}
+}
+
+// CurrentUserHandler describes the authenticated user
+func CurrentUserHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := CurrentUser(c)
+		if !ok {
+			render.JSON(c, http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		render.JSON(c, http.StatusOK, gin.H{"user": userData(user)})
+	}
+}
+
+// userData is the public description of a user in responses
+func userData(user User) gin.H {
+	return gin.H{
+		"id":           user.GetID(),
+		"username":     user.GetUsername(),
+		"is_staff":     user.IsStaff(),
+		"is_superuser": user.IsSuperuser(),
+	}
+}
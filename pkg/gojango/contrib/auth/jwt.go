@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// JWTConfig configures the JWT backend
+type JWTConfig struct {
+	// Secret signs tokens with HMAC-SHA256; use the project's SECRET_KEY
+	Secret []byte
+
+	// Issuer is set as the iss claim and required when validating, if set
+	Issuer string
+
+	// TTL is how long a token is valid (default one hour)
+	TTL time.Duration
+}
+
+// JWTBackend authenticates requests with stateless bearer tokens. Login
+// returns a token that clients send back in the Authorization header.
+// Tokens cannot be revoked; logging out is done by the client discarding
+// its token, so keep the TTL short.
+type JWTBackend struct {
+	config JWTConfig
+}
+
+// Claims are the claims of tokens issued by JWTBackend. The user ID is the
+// subject.
+type Claims struct {
+	Username string `json:"username,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTBackend creates a JWT backend. It panics without a secret, since
+// unsigned tokens could be forged.
+func NewJWTBackend(config JWTConfig) *JWTBackend {
+	if len(config.Secret) == 0 {
+		panic("auth: JWTConfig.Secret is required")
+	}
+	if config.TTL == 0 {
+		config.TTL = time.Hour
+	}
+	return &JWTBackend{config: config}
+}
+
+// IssueToken creates a signed token for user
+func (b *JWTBackend) IssueToken(user User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(b.config.TTL)
+
+	claims := Claims{
+		Username: user.GetUsername(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   user.GetID(),
+			Issuer:    b.config.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(b.config.Secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign token: %w", err)
+	}
+	return token, expiresAt, nil
+}
+
+// ValidateToken verifies a token's signature, algorithm, expiry and issuer
+// and returns its claims
+func (b *JWTBackend) ValidateToken(token string) (*Claims, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if b.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(b.config.Issuer))
+	}
+
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return b.config.Secret, nil
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("invalid token: missing subject")
+	}
+	return claims, nil
+}
+
+// Login issues a token for user, returned as "token" and "expires_at"
+func (b *JWTBackend) Login(c *gin.Context, user User) (map[string]interface{}, error) {
+	token, expiresAt, err := b.IssueToken(user)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"token":      token,
+		"token_type": "Bearer",
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// Logout does nothing on the server; clients discard their token
+func (b *JWTBackend) Logout(c *gin.Context) error {
+	return nil
+}
+
+// UserID returns the subject of the request's bearer token. Requests
+// without a token are anonymous; an invalid token is an error.
+func (b *JWTBackend) UserID(c *gin.Context) (string, error) {
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		return "", nil
+	}
+
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", nil
+	}
+
+	claims, err := b.ValidateToken(strings.TrimSpace(token))
+	if err != nil {
+		return "", err
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestIssueAndValidateToken(t *testing.T) {
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret"), Issuer: "myproject"})
+	user := &testUser{id: "42", username: "john", active: true}
+
+	token, expiresAt, err := backend.IssueToken(user)
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+	if until := time.Until(expiresAt); until < 59*time.Minute || until > time.Hour {
+		t.Errorf("Expected the default one hour TTL, expires in %v", until)
+	}
+
+	claims, err := backend.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("Expected the token to validate, got: %v", err)
+	}
+	if claims.Subject != "42" || claims.Username != "john" || claims.Issuer != "myproject" {
+		t.Errorf("Unexpected claims: %+v", claims)
+	}
+	if claims.ID == "" {
+		t.Error("Expected a token ID")
+	}
+}
+
+func TestValidateTokenRejectsInvalidTokens(t *testing.T) {
+	backend := NewJWTBackend(JWTConfig{Secret: []byte("test-secret"), Issuer: "myproject"})
+	user := &testUser{id: "42", username: "john", active: true}
+
+	otherSecret, _, _ := NewJWTBackend(JWTConfig{Secret: []byte("other-secret"), Issuer: "myproject"}).IssueToken(user)
+	otherIssuer, _, _ := NewJWTBackend(JWTConfig{Secret: []byte("test-secret"), Issuer: "other"}).IssueToken(user)
+	expired, _, _ := NewJWTBackend(JWTConfig{Secret: []byte("test-secret"), Issuer: "myproject", TTL: -time.Minute}).IssueToken(user)
+
+	valid, _, _ := backend.IssueToken(user)
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+		Subject:   "42",
+		Issuer:    "myproject",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("Failed to create unsigned token: %v", err)
+	}
+
+	noExpiry, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject: "42",
+		Issuer:  "myproject",
+	}).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	for name, token := range map[string]string{
+		"wrong secret": otherSecret,
+		"wrong issuer": otherIssuer,
+		"expired":      expired,
+		"tampered":     tampered,
+		"alg none":     unsigned,
+		"no expiry":    noExpiry,
+		"garbage":      "not.a.token",
+	} {
+		if _, err := backend.ValidateToken(token); err == nil {
+			t.Errorf("%s: expected the token to be rejected", name)
+		}
+	}
+}
+
+func TestNewJWTBackendRequiresSecret(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected NewJWTBackend to panic without a secret")
+		}
+	}()
+	NewJWTBackend(JWTConfig{})
+}
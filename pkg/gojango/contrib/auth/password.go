@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordCost is the bcrypt cost used by HashPassword
+var PasswordCost = bcrypt.DefaultCost
+
+// dummyPasswordHash is compared against when a user does not exist, so a
+// failed login takes as long for unknown users as for wrong passwords. It is
+// created on first use to keep bcrypt out of program startup.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	return mustHashPassword("gojango-dummy-password")
+})
+
+// HashPassword hashes a password with bcrypt for storage. Passwords longer
+// than 72 bytes are rejected, since bcrypt ignores anything beyond that.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches a hash from HashPassword
+func CheckPassword(hash, password string) bool {
+	if hash == "" {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+func mustHashPassword(password string) string {
+	hash, err := HashPassword(password)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
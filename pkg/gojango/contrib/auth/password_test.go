@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashAndCheckPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+
+	if hash == "correct horse battery staple" {
+		t.Error("Expected the password to be hashed")
+	}
+	if !CheckPassword(hash, "correct horse battery staple") {
+		t.Error("Expected the password to match its hash")
+	}
+	if CheckPassword(hash, "correct horse battery stapler") {
+		t.Error("Expected a different password not to match")
+	}
+	if CheckPassword("", "") {
+		t.Error("Expected an empty hash never to match")
+	}
+
+	other, err := HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	if other == hash {
+		t.Error("Expected hashes of the same password to be salted differently")
+	}
+}
+
+func TestHashPasswordRejectsLongPasswords(t *testing.T) {
+	if _, err := HashPassword(strings.Repeat("a", 73)); err == nil {
+		t.Error("Expected passwords over 72 bytes to be rejected")
+	}
+}
@@ -0,0 +1,39 @@
+package auth
+
+import "github.com/epuerta9/gojango/pkg/gojango/admin"
+
+// StaffPermissions is an admin.PermissionChecker that gives active staff
+// users and superusers full access to the admin and denies everyone else.
+// Install it with site.SetPermissionChecker(auth.StaffPermissions{}).
+type StaffPermissions struct{}
+
+var _ admin.PermissionChecker = StaffPermissions{}
+
+// isStaff reports whether the admin's current user is an active staff user
+func isStaff(user interface{}) bool {
+	u, ok := user.(User)
+	if !ok || u == nil || !u.IsActive() {
+		return false
+	}
+	return u.IsStaff() || u.IsSuperuser()
+}
+
+func (StaffPermissions) HasPermission(user interface{}, perm string, obj interface{}) bool {
+	return isStaff(user)
+}
+
+func (StaffPermissions) HasAddPermission(user interface{}, model string) bool {
+	return isStaff(user)
+}
+
+func (StaffPermissions) HasChangePermission(user interface{}, obj interface{}) bool {
+	return isStaff(user)
+}
+
+func (StaffPermissions) HasDeletePermission(user interface{}, obj interface{}) bool {
+	return isStaff(user)
+}
+
+func (StaffPermissions) HasViewPermission(user interface{}, obj interface{}) bool {
+	return isStaff(user)
+}
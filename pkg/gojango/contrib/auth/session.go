@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Session is the server-side record of a logged-in user
+type Session struct {
+	UserID    string
+	ExpiresAt time.Time
+}
+
+// SessionStore keeps sessions on the server, keyed by session ID
+type SessionStore interface {
+	Get(ctx context.Context, id string) (*Session, error)
+	Save(ctx context.Context, id string, session *Session) error
+	Delete(ctx context.Context, id string) error
+}
+
+// MemorySessionStore keeps sessions in process memory. Sessions are lost on
+// restart and not shared between processes, so it suits development and
+// single-instance deployments.
+type MemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// NewMemorySessionStore creates an empty in-memory session store
+func NewMemorySessionStore() *MemorySessionStore {
+	return &MemorySessionStore{sessions: make(map[string]*Session)}
+}
+
+// Get returns a session, or nil if it does not exist or has expired
+func (s *MemorySessionStore) Get(ctx context.Context, id string) (*Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, exists := s.sessions[id]
+	if !exists {
+		return nil, nil
+	}
+	if time.Now().After(session.ExpiresAt) {
+		delete(s.sessions, id)
+		return nil, nil
+	}
+	return session, nil
+}
+
+// Save stores a session
+func (s *MemorySessionStore) Save(ctx context.Context, id string, session *Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[id] = session
+	return nil
+}
+
+// Delete removes a session
+func (s *MemorySessionStore) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, id)
+	return nil
+}
+
+// SessionConfig configures the session backend
+type SessionConfig struct {
+	// Secret signs session cookies; use the project's SECRET_KEY
+	Secret []byte
+
+	// Store keeps the sessions (default an in-memory store)
+	Store SessionStore
+
+	// CookieName is the session cookie (default "sessionid")
+	CookieName string
+
+	// CookiePath is the cookie path (default "/")
+	CookiePath string
+
+	// MaxAge is how long a session lasts (default two weeks)
+	MaxAge time.Duration
+
+	// CookieSecure marks the cookie as HTTPS only
+	CookieSecure bool
+
+	// CookieSameSite sets the cookie SameSite attribute (default Lax)
+	CookieSameSite http.SameSite
+}
+
+// withDefaults returns the config with unset fields filled in
+func (config SessionConfig) withDefaults() SessionConfig {
+	if config.Store == nil {
+		config.Store = NewMemorySessionStore()
+	}
+	if config.CookieName == "" {
+		config.CookieName = "sessionid"
+	}
+	if config.CookiePath == "" {
+		config.CookiePath = "/"
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = 14 * 24 * time.Hour
+	}
+	if config.CookieSameSite == 0 {
+		config.CookieSameSite = http.SameSiteLaxMode
+	}
+	return config
+}
+
+// SessionBackend keeps logged-in users in server-side sessions. The browser
+// holds only a random session ID signed with the secret, in an HTTP-only
+// cookie, so sessions can be revoked by deleting them from the store.
+type SessionBackend struct {
+	config SessionConfig
+}
+
+// NewSessionBackend creates a session backend. It panics without a secret,
+// since unsigned session cookies could be forged.
+func NewSessionBackend(config SessionConfig) *SessionBackend {
+	if len(config.Secret) == 0 {
+		panic("auth: SessionConfig.Secret is required")
+	}
+	return &SessionBackend{config: config.withDefaults()}
+}
+
+// Login starts a new session for user and sets the session cookie
+func (b *SessionBackend) Login(c *gin.Context, user User) (map[string]interface{}, error) {
+	// Drop any session the client already had, so a session ID set before
+	// login cannot be reused afterwards
+	if id, ok := b.sessionID(c); ok {
+		b.config.Store.Delete(c.Request.Context(), id)
+	}
+
+	id, err := newSessionID()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &Session{UserID: user.GetID(), ExpiresAt: time.Now().Add(b.config.MaxAge)}
+	if err := b.config.Store.Save(c.Request.Context(), id, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	b.setCookie(c, b.sign(id), int(b.config.MaxAge/time.Second))
+	return nil, nil
+}
+
+// Logout deletes the session and clears the cookie
+func (b *SessionBackend) Logout(c *gin.Context) error {
+	if id, ok := b.sessionID(c); ok {
+		if err := b.config.Store.Delete(c.Request.Context(), id); err != nil {
+			return fmt.Errorf("failed to delete session: %w", err)
+		}
+	}
+	b.setCookie(c, "", -1)
+	return nil
+}
+
+// UserID returns the user of the request's session
+func (b *SessionBackend) UserID(c *gin.Context) (string, error) {
+	id, ok := b.sessionID(c)
+	if !ok {
+		return "", nil
+	}
+
+	session, err := b.config.Store.Get(c.Request.Context(), id)
+	if err != nil {
+		return "", fmt.Errorf("failed to load session: %w", err)
+	}
+	if session == nil || time.Now().After(session.ExpiresAt) {
+		return "", nil
+	}
+	return session.UserID, nil
+}
+
+// sessionID returns the session ID from a validly signed cookie
+func (b *SessionBackend) sessionID(c *gin.Context) (string, bool) {
+	value, err := c.Cookie(b.config.CookieName)
+	if err != nil || value == "" {
+		return "", false
+	}
+
+	id, signature, found := strings.Cut(value, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(b.signature(id))) {
+		return "", false
+	}
+	return id, true
+}
+
+func (b *SessionBackend) setCookie(c *gin.Context, value string, maxAge int) {
+	c.SetSameSite(b.config.CookieSameSite)
+	c.SetCookie(b.config.CookieName, value, maxAge, b.config.CookiePath, "", b.config.CookieSecure, true)
+}
+
+// sign appends the signature to a session ID
+func (b *SessionBackend) sign(id string) string {
+	return id + "." + b.signature(id)
+}
+
+func (b *SessionBackend) signature(id string) string {
+	mac := hmac.New(sha256.New, b.config.Secret)
+	mac.Write([]byte("gojango.session." + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// newSessionID returns 32 random bytes, URL-safe encoded
+func newSessionID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate session ID")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func sessionCookie(w *httptest.ResponseRecorder) *http.Cookie {
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == "sessionid" {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func getPrivate(router http.Handler, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/private", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSessionLoginAndLogout(t *testing.T) {
+	store := NewMemorySessionStore()
+	backend := NewSessionBackend(SessionConfig{Secret: []byte("test-secret"), Store: store})
+	router := newTestRouter(New(newTestStore(t), backend))
+
+	w := login(t, router, "john", "s3cret")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected login to succeed, got %d: %s", w.Code, w.Body.String())
+	}
+	cookie := sessionCookie(w)
+	if cookie == nil {
+		t.Fatal("Expected a session cookie")
+	}
+	if !cookie.HttpOnly {
+		t.Error("Expected the session cookie to be HTTP only")
+	}
+
+	if w := getPrivate(router, cookie); w.Code != http.StatusOK || w.Body.String() != "hello john" {
+		t.Errorf("Expected the session to authenticate, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Logging out deletes the server-side session, so the old cookie stops working
+	req := httptest.NewRequest("POST", "/auth/logout/", nil)
+	req.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected logout to succeed, got %d", w.Code)
+	}
+	if cleared := sessionCookie(w); cleared == nil || cleared.MaxAge >= 0 {
+		t.Error("Expected logout to clear the session cookie")
+	}
+
+	if w := getPrivate(router, cookie); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected the logged-out session to be rejected, got %d", w.Code)
+	}
+}
+
+func TestSessionCookieSignature(t *testing.T) {
+	store := NewMemorySessionStore()
+	backend := NewSessionBackend(SessionConfig{Secret: []byte("test-secret"), Store: store})
+	router := newTestRouter(New(newTestStore(t), backend))
+
+	// A session that exists on the server but is presented unsigned, or
+	// signed with another secret, is not accepted
+	store.Save(context.Background(), "known-session", &Session{UserID: "1"})
+	forger := NewSessionBackend(SessionConfig{Secret: []byte("other-secret")})
+
+	for name, value := range map[string]string{
+		"unsigned":     "known-session",
+		"wrong secret": forger.sign("known-session"),
+		"bad format":   "known-session.",
+	} {
+		cookie := &http.Cookie{Name: "sessionid", Value: value}
+		if w := getPrivate(router, cookie); w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, w.Code)
+		}
+	}
+}
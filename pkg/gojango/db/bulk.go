@@ -0,0 +1,239 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxParameters is the most bound parameters a statement may have per
+// driver. SQLite's default limit was 999 before 3.32, and is kept as the
+// safe choice.
+var maxParameters = map[Driver]int{
+	DriverSQLite:   999,
+	DriverPostgres: 65535,
+	DriverMySQL:    65535,
+}
+
+// OnConflict turns a bulk insert into an upsert
+type OnConflict struct {
+	// Columns is the unique key a conflict is detected on, such as the
+	// primary key. PostgreSQL and SQLite require it; MySQL checks every
+	// unique key.
+	Columns []string
+
+	// Update lists the columns set to the inserted row's values on a
+	// conflict. When empty, every inserted column not in Columns is set.
+	Update []string
+
+	// DoNothing keeps existing rows unchanged, skipping conflicting rows
+	DoNothing bool
+}
+
+// BulkInsertOptions configures BulkInsert
+type BulkInsertOptions struct {
+	// BatchSize caps the rows per INSERT statement. Batches are also kept
+	// within the driver's parameter limit, which is the default.
+	BatchSize int
+
+	// OnConflict, when set, updates or skips rows that already exist
+	OnConflict *OnConflict
+}
+
+// bulkStatement is one batch of a bulk insert
+type bulkStatement struct {
+	query string
+	args  []interface{}
+	rows  int
+}
+
+// BulkInsert inserts rows into table with multi-row INSERT statements in a
+// single transaction, in the SQL dialect of conn's driver. Every row must
+// have the same columns. Rows are split into batches that stay within the
+// driver's limit on bound parameters, e.g. 999 for SQLite. It returns the
+// number of rows the database reports as affected, so rows skipped by
+// OnConflict.DoNothing aren't counted. MySQL counts each row an upsert
+// updates twice.
+//
+//	n, err := db.BulkInsert(ctx, conn, "posts", rows, db.BulkInsertOptions{
+//		OnConflict: &db.OnConflict{Columns: []string{"slug"}},
+//	})
+func BulkInsert(ctx context.Context, conn *Connection, table string, rows []map[string]interface{}, opts BulkInsertOptions) (int, error) {
+	statements, err := bulkInsertStatements(conn.Driver(), table, rows, opts)
+	if err != nil {
+		return 0, err
+	}
+	if len(statements) == 0 {
+		return 0, nil
+	}
+
+	tx, err := conn.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	attempted, written := 0, int64(0)
+	for _, statement := range statements {
+		result, err := tx.ExecContext(ctx, statement.query, statement.args...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert rows %d-%d into %s: %w", attempted+1, attempted+statement.rows, table, err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to count rows inserted into %s: %w", table, err)
+		}
+		attempted += statement.rows
+		written += affected
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit bulk insert: %w", err)
+	}
+	return int(written), nil
+}
+
+// bulkInsertStatements builds the batched INSERT statements of a bulk insert
+func bulkInsertStatements(driver Driver, table string, rows []map[string]interface{}, opts BulkInsertOptions) ([]bulkStatement, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid table name: %q", table)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make([]string, 0, len(rows[0]))
+	for column := range rows[0] {
+		if !identifierPattern.MatchString(column) {
+			return nil, fmt.Errorf("invalid column name: %q", column)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("rows have no columns")
+	}
+	sort.Strings(columns)
+	for i, row := range rows[1:] {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("row %d has different columns than the first row", i+2)
+		}
+		for _, column := range columns {
+			if _, ok := row[column]; !ok {
+				return nil, fmt.Errorf("row %d has no %s column", i+2, column)
+			}
+		}
+	}
+
+	conflict, err := conflictClause(driver, columns, opts.OnConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, ok := maxParameters[driver]
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver: %s", driver)
+	}
+	batchSize := limit / len(columns)
+	if batchSize == 0 {
+		return nil, fmt.Errorf("%d columns exceed the %s limit of %d parameters", len(columns), driver, limit)
+	}
+	if opts.BatchSize > 0 && opts.BatchSize < batchSize {
+		batchSize = opts.BatchSize
+	}
+
+	prefix := "INSERT INTO "
+	if driver == DriverMySQL && opts.OnConflict != nil && opts.OnConflict.DoNothing {
+		prefix = "INSERT IGNORE INTO "
+	}
+	prefix += fmt.Sprintf("%s (%s) VALUES ", table, strings.Join(columns, ", "))
+
+	var statements []bulkStatement
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+
+		var query strings.Builder
+		query.WriteString(prefix)
+		args := make([]interface{}, 0, (end-start)*len(columns))
+		for i, row := range rows[start:end] {
+			if i > 0 {
+				query.WriteString(", ")
+			}
+			query.WriteString("(")
+			for j, column := range columns {
+				if j > 0 {
+					query.WriteString(", ")
+				}
+				args = append(args, fixtureValue(row[column]))
+				if driver == DriverPostgres {
+					fmt.Fprintf(&query, "$%d", len(args))
+				} else {
+					query.WriteString("?")
+				}
+			}
+			query.WriteString(")")
+		}
+		query.WriteString(conflict)
+
+		statements = append(statements, bulkStatement{query: query.String(), args: args, rows: end - start})
+	}
+	return statements, nil
+}
+
+// conflictClause returns the upsert clause ending a bulk INSERT: ON
+// CONFLICT for PostgreSQL and SQLite, ON DUPLICATE KEY UPDATE for MySQL
+func conflictClause(driver Driver, columns []string, onConflict *OnConflict) (string, error) {
+	if onConflict == nil {
+		return "", nil
+	}
+	for _, column := range append(append([]string{}, onConflict.Columns...), onConflict.Update...) {
+		if !identifierPattern.MatchString(column) {
+			return "", fmt.Errorf("invalid conflict column name: %q", column)
+		}
+	}
+
+	update := onConflict.Update
+	if len(update) == 0 && !onConflict.DoNothing {
+		target := make(map[string]bool, len(onConflict.Columns))
+		for _, column := range onConflict.Columns {
+			target[column] = true
+		}
+		for _, column := range columns {
+			if !target[column] {
+				update = append(update, column)
+			}
+		}
+	}
+	doNothing := onConflict.DoNothing || len(update) == 0
+
+	if driver == DriverMySQL {
+		if doNothing {
+			// Covered by INSERT IGNORE, unless there was nothing to update
+			if onConflict.DoNothing {
+				return "", nil
+			}
+			return fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", columns[0], columns[0]), nil
+		}
+		assignments := make([]string, len(update))
+		for i, column := range update {
+			assignments[i] = fmt.Sprintf("%s = VALUES(%s)", column, column)
+		}
+		return " ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", "), nil
+	}
+
+	if len(onConflict.Columns) == 0 {
+		if doNothing {
+			return " ON CONFLICT DO NOTHING", nil
+		}
+		return "", fmt.Errorf("an upsert on %s requires conflict columns", driver)
+	}
+	clause := fmt.Sprintf(" ON CONFLICT (%s) DO ", strings.Join(onConflict.Columns, ", "))
+	if doNothing {
+		return clause + "NOTHING", nil
+	}
+	assignments := make([]string, len(update))
+	for i, column := range update {
+		assignments[i] = fmt.Sprintf("%s = excluded.%s", column, column)
+	}
+	return clause + "UPDATE SET " + strings.Join(assignments, ", "), nil
+}
@@ -0,0 +1,171 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// newBulkTestDB opens a SQLite database with an empty posts table
+func newBulkTestDB(t *testing.T) *Connection {
+	t.Helper()
+	conn, err := Open(SQLiteConfig(":memory:"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if _, err := conn.DB().Exec("CREATE TABLE posts (id INTEGER PRIMARY KEY, slug TEXT UNIQUE, title TEXT, views INTEGER)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	return conn
+}
+
+// bulkPosts returns count post rows with IDs from 1
+func bulkPosts(count int) []map[string]interface{} {
+	rows := make([]map[string]interface{}, count)
+	for i := range rows {
+		rows[i] = map[string]interface{}{
+			"id":    i + 1,
+			"slug":  fmt.Sprintf("post-%d", i+1),
+			"title": "Post",
+			"views": 0,
+		}
+	}
+	return rows
+}
+
+func TestBulkInsert(t *testing.T) {
+	ctx := context.Background()
+	conn := newBulkTestDB(t)
+	rows := bulkPosts(600)
+
+	// 4 columns fit 249 rows in SQLite's 999 parameters
+	statements, err := bulkInsertStatements(DriverSQLite, "posts", rows, BulkInsertOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var sizes []int
+	for _, statement := range statements {
+		sizes = append(sizes, statement.rows)
+		if len(statement.args) > 999 {
+			t.Errorf("Expected at most 999 parameters, got: %d", len(statement.args))
+		}
+	}
+	if !equalIDs(sizes, []int{249, 249, 102}) {
+		t.Errorf("Expected batches of [249 249 102] rows, got: %v", sizes)
+	}
+
+	n, err := BulkInsert(ctx, conn, "posts", rows, BulkInsertOptions{})
+	if err != nil {
+		t.Fatalf("Failed to bulk insert: %v", err)
+	}
+	if n != 600 {
+		t.Errorf("Expected 600 rows written, got: %d", n)
+	}
+	if count := countRows(t, conn, "posts"); count != 600 {
+		t.Errorf("Expected 600 rows, got: %d", count)
+	}
+
+	statements, _ = bulkInsertStatements(DriverSQLite, "posts", rows, BulkInsertOptions{BatchSize: 100})
+	if len(statements) != 6 {
+		t.Errorf("Expected 6 batches of 100 rows, got: %d", len(statements))
+	}
+}
+
+func TestBulkInsertRollsBack(t *testing.T) {
+	ctx := context.Background()
+	conn := newBulkTestDB(t)
+
+	// The duplicate is in the second batch, so the first is rolled back
+	rows := bulkPosts(300)
+	rows[299]["slug"] = "post-1"
+	if _, err := BulkInsert(ctx, conn, "posts", rows, BulkInsertOptions{}); err == nil {
+		t.Fatal("Expected a unique constraint error")
+	}
+	if count := countRows(t, conn, "posts"); count != 0 {
+		t.Errorf("Expected no rows after a failed insert, got: %d", count)
+	}
+
+	rows = bulkPosts(2)
+	delete(rows[1], "views")
+	if _, err := BulkInsert(ctx, conn, "posts", rows, BulkInsertOptions{}); err == nil {
+		t.Error("Expected an error for rows with different columns")
+	}
+	if _, err := BulkInsert(ctx, conn, "posts; DROP TABLE posts", bulkPosts(1), BulkInsertOptions{}); err == nil {
+		t.Error("Expected an error for an invalid table name")
+	}
+}
+
+func TestBulkInsertOnConflict(t *testing.T) {
+	ctx := context.Background()
+	conn := newBulkTestDB(t)
+	if _, err := BulkInsert(ctx, conn, "posts", bulkPosts(300), BulkInsertOptions{}); err != nil {
+		t.Fatalf("Failed to bulk insert: %v", err)
+	}
+
+	// Update the existing posts by slug and add 100 new ones
+	rows := bulkPosts(400)
+	for _, row := range rows {
+		row["views"] = 7
+	}
+	_, err := BulkInsert(ctx, conn, "posts", rows, BulkInsertOptions{
+		OnConflict: &OnConflict{Columns: []string{"slug"}, Update: []string{"views"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to upsert: %v", err)
+	}
+	if count := countRows(t, conn, "posts WHERE views = 7"); count != 400 {
+		t.Errorf("Expected 400 updated or inserted rows, got: %d", count)
+	}
+
+	// Conflicting rows are skipped
+	for _, row := range rows {
+		row["views"] = 9
+	}
+	n, err := BulkInsert(ctx, conn, "posts", append(rows, bulkPosts(401)[400]), BulkInsertOptions{
+		OnConflict: &OnConflict{Columns: []string{"id"}, DoNothing: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to insert ignoring conflicts: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected only the new row to be counted, got: %d", n)
+	}
+	if count := countRows(t, conn, "posts WHERE views = 9"); count != 0 {
+		t.Errorf("Expected existing rows to be unchanged, got %d changed", count)
+	}
+}
+
+func TestBulkInsertDialects(t *testing.T) {
+	rows := bulkPosts(2)
+	onConflict := &OnConflict{Columns: []string{"id"}}
+
+	tests := []struct {
+		driver   Driver
+		expected string
+	}{
+		{DriverPostgres, "INSERT INTO posts (id, slug, title, views) VALUES ($1, $2, $3, $4), ($5, $6, $7, $8) ON CONFLICT (id) DO UPDATE SET slug = excluded.slug, title = excluded.title, views = excluded.views"},
+		{DriverSQLite, "INSERT INTO posts (id, slug, title, views) VALUES (?, ?, ?, ?), (?, ?, ?, ?) ON CONFLICT (id) DO UPDATE SET slug = excluded.slug, title = excluded.title, views = excluded.views"},
+		{DriverMySQL, "INSERT INTO posts (id, slug, title, views) VALUES (?, ?, ?, ?), (?, ?, ?, ?) ON DUPLICATE KEY UPDATE slug = VALUES(slug), title = VALUES(title), views = VALUES(views)"},
+	}
+
+	for _, tt := range tests {
+		statements, err := bulkInsertStatements(tt.driver, "posts", rows, BulkInsertOptions{OnConflict: onConflict})
+		if err != nil {
+			t.Fatalf("Unexpected %s error: %v", tt.driver, err)
+		}
+		if len(statements) != 1 || statements[0].query != tt.expected {
+			t.Errorf("Unexpected %s statements: %+v", tt.driver, statements)
+		}
+	}
+
+	statements, _ := bulkInsertStatements(DriverMySQL, "posts", rows, BulkInsertOptions{OnConflict: &OnConflict{DoNothing: true}})
+	if !strings.HasPrefix(statements[0].query, "INSERT IGNORE INTO posts") {
+		t.Errorf("Expected INSERT IGNORE for MySQL, got: %s", statements[0].query)
+	}
+	if _, err := bulkInsertStatements(DriverPostgres, "posts", rows, BulkInsertOptions{OnConflict: &OnConflict{}}); err == nil {
+		t.Error("Expected an error for an upsert without conflict columns")
+	}
+}
@@ -0,0 +1,427 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FixtureObject is a single row in a JSON fixture, in Django's
+// {"model": ..., "pk": ..., "fields": {...}} format. Model is the table name
+// of the Ent model (e.g. "users" for the User schema).
+type FixtureObject struct {
+	Model  string                 `json:"model"`
+	PK     interface{}            `json:"pk"`
+	Fields map[string]interface{} `json:"fields"`
+}
+
+// identifierPattern matches table and column names that are safe to interpolate
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// internalTables are framework bookkeeping tables excluded from full dumps
+var internalTables = map[string]bool{
+	"gojango_migrations": true,
+	"gojango_seeds":      true,
+}
+
+// DumpData serializes every row of the given models (tables) to w as a JSON
+// array of fixture objects. When models is empty all tables are dumped.
+// Models are written in dependency order so the output can be loaded back.
+func DumpData(ctx context.Context, conn *Connection, models []string, w io.Writer) error {
+	if len(models) == 0 {
+		tables, err := listTables(ctx, conn)
+		if err != nil {
+			return err
+		}
+		models = tables
+	}
+
+	ordered, err := orderByForeignKeys(ctx, conn, models)
+	if err != nil {
+		return err
+	}
+
+	objects := make([]FixtureObject, 0)
+	for _, table := range ordered {
+		tableObjects, err := dumpTable(ctx, conn, table)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, tableObjects...)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(objects); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+	return nil
+}
+
+// LoadData reads a JSON fixture from r and inserts its objects in a single
+// transaction. Models are inserted in foreign key dependency order; objects
+// of the same model keep their order in the fixture. It returns the number
+// of objects loaded.
+func LoadData(ctx context.Context, conn *Connection, r io.Reader) (int, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var objects []FixtureObject
+	if err := decoder.Decode(&objects); err != nil {
+		return 0, fmt.Errorf("failed to parse fixture: %w", err)
+	}
+
+	byModel := make(map[string][]FixtureObject)
+	var models []string
+	for _, obj := range objects {
+		if !identifierPattern.MatchString(obj.Model) {
+			return 0, fmt.Errorf("invalid model name in fixture: %q", obj.Model)
+		}
+		if _, exists := byModel[obj.Model]; !exists {
+			models = append(models, obj.Model)
+		}
+		byModel[obj.Model] = append(byModel[obj.Model], obj)
+	}
+
+	ordered, err := orderByForeignKeys(ctx, conn, models)
+	if err != nil {
+		return 0, err
+	}
+
+	// Introspect before starting the transaction; SQLite connections are
+	// limited to one, which the transaction holds until commit
+	pkColumns := make(map[string]string, len(ordered))
+	for _, table := range ordered {
+		pkColumn, err := primaryKeyColumn(ctx, conn, table)
+		if err != nil {
+			return 0, err
+		}
+		pkColumns[table] = pkColumn
+	}
+
+	tx, err := conn.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	loaded := 0
+	for _, table := range ordered {
+		for _, obj := range byModel[table] {
+			if err := insertFixtureObject(ctx, tx, conn.Driver(), table, pkColumns[table], obj); err != nil {
+				return 0, fmt.Errorf("failed to load %s (pk=%v): %w", table, obj.PK, err)
+			}
+			loaded++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit fixture: %w", err)
+	}
+
+	log.Printf("Loaded %d objects from fixture", loaded)
+	return loaded, nil
+}
+
+// dumpTable reads all rows of a table as fixture objects
+func dumpTable(ctx context.Context, conn *Connection, table string) ([]FixtureObject, error) {
+	if !identifierPattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid model name: %q", table)
+	}
+
+	pkColumn, err := primaryKeyColumn(ctx, conn, table)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s", table)
+	if pkColumn != "" {
+		query += fmt.Sprintf(" ORDER BY %s", pkColumn)
+	}
+
+	rows, err := conn.DB().QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+
+	var objects []FixtureObject
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan %s row: %w", table, err)
+		}
+
+		obj := FixtureObject{Model: table, Fields: make(map[string]interface{})}
+		for i, column := range columns {
+			value := values[i]
+			if b, ok := value.([]byte); ok {
+				value = string(b)
+			}
+			if column == pkColumn {
+				obj.PK = value
+				continue
+			}
+			obj.Fields[column] = value
+		}
+		objects = append(objects, obj)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s rows: %w", table, err)
+	}
+	return objects, nil
+}
+
+// insertFixtureObject inserts a single fixture object
+func insertFixtureObject(ctx context.Context, tx *sql.Tx, driver Driver, table, pkColumn string, obj FixtureObject) error {
+	columns := make([]string, 0, len(obj.Fields)+1)
+	for column := range obj.Fields {
+		if !identifierPattern.MatchString(column) {
+			return fmt.Errorf("invalid field name: %q", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	values := make([]interface{}, 0, len(columns)+1)
+	for _, column := range columns {
+		values = append(values, fixtureValue(obj.Fields[column]))
+	}
+	if pkColumn != "" && obj.PK != nil {
+		columns = append([]string{pkColumn}, columns...)
+		values = append([]interface{}{fixtureValue(obj.PK)}, values...)
+	}
+
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		if driver == DriverPostgres {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+		} else {
+			placeholders[i] = "?"
+		}
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+	_, err := tx.ExecContext(ctx, query, values...)
+	return err
+}
+
+// fixtureValue converts decoded JSON values into database driver values
+func fixtureValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}, []interface{}:
+		// Nested JSON is stored as JSON text
+		if data, err := json.Marshal(v); err == nil {
+			return string(data)
+		}
+	}
+	return value
+}
+
+// listTables returns all user tables in the database
+func listTables(ctx context.Context, conn *Connection) ([]string, error) {
+	var query string
+	switch conn.Driver() {
+	case DriverSQLite:
+		query = `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`
+	case DriverPostgres:
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name`
+	case DriverMySQL:
+		query = `SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name`
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", conn.Driver())
+	}
+
+	names, err := queryStrings(ctx, conn, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	tables := make([]string, 0, len(names))
+	for _, name := range names {
+		if !internalTables[name] {
+			tables = append(tables, name)
+		}
+	}
+	return tables, nil
+}
+
+// primaryKeyColumn returns the single-column primary key of a table, or an
+// empty string if the table has none or a composite key
+func primaryKeyColumn(ctx context.Context, conn *Connection, table string) (string, error) {
+	if !identifierPattern.MatchString(table) {
+		return "", fmt.Errorf("invalid model name: %q", table)
+	}
+
+	var columns []string
+	var err error
+	switch conn.Driver() {
+	case DriverSQLite:
+		columns, err = queryStrings(ctx, conn, `SELECT name FROM pragma_table_info(?) WHERE pk > 0 ORDER BY pk`, table)
+	case DriverPostgres:
+		columns, err = queryStrings(ctx, conn, `
+			SELECT kcu.column_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+			WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_name = $1 AND tc.table_schema = current_schema()
+			ORDER BY kcu.ordinal_position`, table)
+	case DriverMySQL:
+		columns, err = queryStrings(ctx, conn, `
+			SELECT column_name FROM information_schema.key_column_usage
+			WHERE table_schema = DATABASE() AND table_name = ? AND constraint_name = 'PRIMARY'
+			ORDER BY ordinal_position`, table)
+	default:
+		return "", fmt.Errorf("unsupported database driver: %s", conn.Driver())
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read primary key of %s: %w", table, err)
+	}
+
+	if len(columns) != 1 {
+		return "", nil
+	}
+	return columns[0], nil
+}
+
+// referencedTables returns the tables a table references through foreign keys
+func referencedTables(ctx context.Context, conn *Connection, table string) ([]string, error) {
+	var tables []string
+	var err error
+	switch conn.Driver() {
+	case DriverSQLite:
+		tables, err = queryStrings(ctx, conn, `SELECT DISTINCT "table" FROM pragma_foreign_key_list(?)`, table)
+	case DriverPostgres:
+		tables, err = queryStrings(ctx, conn, `
+			SELECT DISTINCT ccu.table_name
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.constraint_column_usage ccu
+				ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+			WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name = $1 AND tc.table_schema = current_schema()`, table)
+	case DriverMySQL:
+		tables, err = queryStrings(ctx, conn, `
+			SELECT DISTINCT referenced_table_name FROM information_schema.key_column_usage
+			WHERE table_schema = DATABASE() AND table_name = ? AND referenced_table_name IS NOT NULL`, table)
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %s", conn.Driver())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read foreign keys of %s: %w", table, err)
+	}
+	return tables, nil
+}
+
+// orderByForeignKeys sorts tables so that referenced tables come before the
+// tables that reference them. Self references are ignored.
+func orderByForeignKeys(ctx context.Context, conn *Connection, tables []string) ([]string, error) {
+	deps := make(map[string][]string)
+	for _, table := range tables {
+		if !identifierPattern.MatchString(table) {
+			return nil, fmt.Errorf("invalid model name: %q", table)
+		}
+
+		refs, err := referencedTables(ctx, conn, table)
+		if err != nil {
+			return nil, err
+		}
+		deps[table] = refs
+	}
+
+	return sortTablesByDependencies(tables, deps)
+}
+
+// sortTablesByDependencies topologically sorts tables, keeping the original
+// order where there are no dependencies. Dependencies on tables outside the
+// set are ignored.
+func sortTablesByDependencies(tables []string, deps map[string][]string) ([]string, error) {
+	inSet := make(map[string]bool, len(tables))
+	for _, table := range tables {
+		inSet[table] = true
+	}
+
+	inDegree := make(map[string]int, len(tables))
+	dependents := make(map[string][]string)
+	for _, table := range tables {
+		inDegree[table] = 0
+	}
+	for _, table := range tables {
+		for _, dep := range deps[table] {
+			if dep == table || !inSet[dep] {
+				continue
+			}
+			inDegree[table]++
+			dependents[dep] = append(dependents[dep], table)
+		}
+	}
+
+	var result []string
+	done := make(map[string]bool, len(tables))
+	for len(result) < len(tables) {
+		progressed := false
+		for _, table := range tables {
+			if done[table] || inDegree[table] > 0 {
+				continue
+			}
+			done[table] = true
+			result = append(result, table)
+			for _, dependent := range dependents[table] {
+				inDegree[dependent]--
+			}
+			progressed = true
+		}
+		if !progressed {
+			var cyclic []string
+			for _, table := range tables {
+				if !done[table] {
+					cyclic = append(cyclic, table)
+				}
+			}
+			return nil, fmt.Errorf("circular foreign key dependency between models: %s", strings.Join(cyclic, ", "))
+		}
+	}
+
+	return result, nil
+}
+
+// queryStrings runs a query returning a single string column
+func queryStrings(ctx context.Context, conn *Connection, query string, args ...interface{}) ([]string, error) {
+	rows, err := conn.DB().QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
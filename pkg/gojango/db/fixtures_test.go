@@ -0,0 +1,146 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func setupFixtureConnection(t *testing.T) *Connection {
+	conn, err := Open(SQLiteConfig(filepath.Join(t.TempDir(), "fixtures.db")))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	statements := []string{
+		`PRAGMA foreign_keys = ON`,
+		`CREATE TABLE authors (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`,
+		`CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT NOT NULL, rating REAL, author_id INTEGER NOT NULL REFERENCES authors(id))`,
+	}
+	for _, stmt := range statements {
+		if _, err := conn.DB().Exec(stmt); err != nil {
+			t.Fatalf("Failed to set up schema: %v", err)
+		}
+	}
+	return conn
+}
+
+func TestDumpDataLoadDataRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := setupFixtureConnection(t)
+
+	source.DB().Exec(`INSERT INTO authors (id, name) VALUES (1, 'Ada'), (2, 'Grace')`)
+	source.DB().Exec(`INSERT INTO posts (id, title, rating, author_id) VALUES (10, 'Engines', 4.5, 1), (11, 'Compilers', NULL, 2)`)
+
+	var buf bytes.Buffer
+	if err := DumpData(ctx, source, []string{"posts", "authors"}, &buf); err != nil {
+		t.Fatalf("Failed to dump data: %v", err)
+	}
+
+	var objects []FixtureObject
+	if err := json.Unmarshal(buf.Bytes(), &objects); err != nil {
+		t.Fatalf("Dump is not valid JSON: %v", err)
+	}
+	if len(objects) != 4 {
+		t.Fatalf("Expected 4 objects, got: %d", len(objects))
+	}
+	if objects[0].Model != "authors" {
+		t.Errorf("Expected authors to be dumped before posts, got: %s", objects[0].Model)
+	}
+	if _, ok := objects[0].Fields["id"]; ok {
+		t.Error("Primary key should be stored in pk, not fields")
+	}
+
+	target := setupFixtureConnection(t)
+	count, err := LoadData(ctx, target, &buf)
+	if err != nil {
+		t.Fatalf("Failed to load data: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 objects loaded, got: %d", count)
+	}
+
+	var title string
+	var rating *float64
+	var authorID int
+	err = target.DB().QueryRow(`SELECT title, rating, author_id FROM posts WHERE id = 11`).Scan(&title, &rating, &authorID)
+	if err != nil {
+		t.Fatalf("Failed to query loaded post: %v", err)
+	}
+	if title != "Compilers" || rating != nil || authorID != 2 {
+		t.Errorf("Unexpected loaded post: %s %v %d", title, rating, authorID)
+	}
+}
+
+func TestLoadDataResolvesForeignKeyOrder(t *testing.T) {
+	ctx := context.Background()
+	conn := setupFixtureConnection(t)
+
+	// Posts are listed before the authors they reference
+	fixture := `[
+		{"model": "posts", "pk": 1, "fields": {"title": "Hello", "author_id": 7}},
+		{"model": "authors", "pk": 7, "fields": {"name": "Linus"}}
+	]`
+
+	if _, err := LoadData(ctx, conn, strings.NewReader(fixture)); err != nil {
+		t.Fatalf("Failed to load fixture: %v", err)
+	}
+
+	var name string
+	err := conn.DB().QueryRow(`SELECT a.name FROM posts p JOIN authors a ON a.id = p.author_id WHERE p.id = 1`).Scan(&name)
+	if err != nil {
+		t.Fatalf("Failed to query loaded data: %v", err)
+	}
+	if name != "Linus" {
+		t.Errorf("Expected post author Linus, got: %s", name)
+	}
+}
+
+func TestLoadDataRollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	conn := setupFixtureConnection(t)
+
+	fixture := `[
+		{"model": "authors", "pk": 1, "fields": {"name": "Ada"}},
+		{"model": "authors", "pk": 2, "fields": {"missing_column": "x"}}
+	]`
+
+	if _, err := LoadData(ctx, conn, strings.NewReader(fixture)); err == nil {
+		t.Fatal("Expected error for invalid fixture object")
+	}
+
+	var count int
+	conn.DB().QueryRow(`SELECT COUNT(*) FROM authors`).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected fixture load to be rolled back, found %d authors", count)
+	}
+}
+
+func TestSortTablesByDependencies(t *testing.T) {
+	deps := map[string][]string{
+		"comments": {"posts", "users"},
+		"posts":    {"users", "posts"},
+		"users":    {"groups"},
+	}
+
+	sorted, err := sortTablesByDependencies([]string{"comments", "posts", "users"}, deps)
+	if err != nil {
+		t.Fatalf("Failed to sort tables: %v", err)
+	}
+	expected := []string{"users", "posts", "comments"}
+	for i, table := range expected {
+		if sorted[i] != table {
+			t.Errorf("Expected %v, got: %v", expected, sorted)
+			break
+		}
+	}
+
+	cyclic := map[string][]string{"a": {"b"}, "b": {"a"}}
+	if _, err := sortTablesByDependencies([]string{"a", "b"}, cyclic); err == nil {
+		t.Error("Expected error for circular dependencies")
+	}
+}
@@ -9,6 +9,9 @@ package db
 
 import (
 	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
 	"fmt"
 	"io/fs"
 	"log"
@@ -28,6 +31,46 @@ type Migration struct {
 	AppliedAt   time.Time `json:"applied_at,omitempty"`
 	SQL         string    `json:"-"`
 	RollbackSQL string    `json:"-"`
+	
+	// Checksum is the SHA-256 of the migration SQL. For applied migrations it
+	// is the checksum recorded when they were applied, and empty for records
+	// that predate checksums.
+	Checksum string `json:"checksum,omitempty"`
+	
+	// Replaces lists the labels of the migrations a squashed migration
+	// replaces, e.g. "0001_create_users"
+	Replaces []string `json:"replaces,omitempty"`
+}
+
+// SquashedMigrationPrefix starts the name of migrations created by Squash.
+// A squashed migration shares the ID of the last migration it replaces.
+const SquashedMigrationPrefix = "squashed_"
+
+// replacesDirective marks a replaced migration in a squashed migration file
+const replacesDirective = "-- gojango:replaces "
+
+// Label returns the migration filename without the .sql and _up suffixes,
+// which identifies it in applied records and Replaces lists
+func (m Migration) Label() string {
+	return migrationLabel(m.Filename)
+}
+
+// migrationLabel strips the .sql and _up suffixes from a migration filename
+func migrationLabel(filename string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(filename, ".sql"), "_up")
+}
+
+// migrationChecksum returns the hex encoded SHA-256 of migration SQL
+func migrationChecksum(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChecksumMismatch is an applied migration whose file changed after it was applied
+type ChecksumMismatch struct {
+	Migration Migration `json:"migration"`
+	Recorded  string    `json:"recorded"`
+	Current   string    `json:"current"`
 }
 
 // MigrationStatus represents the status of migrations
@@ -35,6 +78,10 @@ type MigrationStatus struct {
 	Applied   []Migration `json:"applied"`
 	Pending   []Migration `json:"pending"`
 	LastApplied *Migration `json:"last_applied,omitempty"`
+	
+	// Conflicts are pending migrations with a lower ID than the last applied
+	// one, typically introduced by merging branches
+	Conflicts []Migration `json:"conflicts,omitempty"`
 }
 
 // Migrator handles database migrations
@@ -42,6 +89,7 @@ type Migrator struct {
 	conn           *Connection
 	migrationsPath string
 	tableName      string
+	atomic         bool
 }
 
 // NewMigrator creates a new migration manager
@@ -58,6 +106,12 @@ func (m *Migrator) SetMigrationsTable(tableName string) {
 	m.tableName = tableName
 }
 
+// SetAtomic makes Apply run all pending migrations in a single transaction,
+// so a failing migration rolls back the whole batch
+func (m *Migrator) SetAtomic(atomic bool) {
+	m.atomic = atomic
+}
+
 // Initialize creates the migrations table if it doesn't exist
 func (m *Migrator) Initialize(ctx context.Context) error {
 	var createTableSQL string
@@ -69,6 +123,7 @@ func (m *Migrator) Initialize(ctx context.Context) error {
 				id SERIAL PRIMARY KEY,
 				name VARCHAR(255) NOT NULL UNIQUE,
 				filename VARCHAR(255) NOT NULL,
+				checksum VARCHAR(64),
 				applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 			);
 			CREATE INDEX IF NOT EXISTS idx_%s_applied_at ON %s (applied_at);
@@ -79,6 +134,7 @@ func (m *Migrator) Initialize(ctx context.Context) error {
 				id INTEGER PRIMARY KEY AUTOINCREMENT,
 				name TEXT NOT NULL UNIQUE,
 				filename TEXT NOT NULL,
+				checksum TEXT,
 				applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
 			);
 			CREATE INDEX IF NOT EXISTS idx_%s_applied_at ON %s (applied_at);
@@ -89,6 +145,7 @@ func (m *Migrator) Initialize(ctx context.Context) error {
 				id INT AUTO_INCREMENT PRIMARY KEY,
 				name VARCHAR(255) NOT NULL UNIQUE,
 				filename VARCHAR(255) NOT NULL,
+				checksum VARCHAR(64),
 				applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 				INDEX idx_%s_applied_at (applied_at)
 			);
@@ -102,10 +159,33 @@ func (m *Migrator) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
+	if err := m.ensureChecksumColumn(ctx); err != nil {
+		return err
+	}
+
 	log.Printf("Initialized migrations table: %s", m.tableName)
 	return nil
 }
 
+// ensureChecksumColumn adds the checksum column to migrations tables created
+// before checksums were recorded. The column is nullable; existing records
+// keep a NULL checksum and are not verified.
+func (m *Migrator) ensureChecksumColumn(ctx context.Context) error {
+	probe := fmt.Sprintf("SELECT checksum FROM %s WHERE 1 = 0", m.tableName)
+	if rows, err := m.conn.DB().QueryContext(ctx, probe); err == nil {
+		rows.Close()
+		return nil
+	}
+
+	alterSQL := fmt.Sprintf("ALTER TABLE %s ADD COLUMN checksum VARCHAR(64)", m.tableName)
+	if _, err := m.conn.DB().ExecContext(ctx, alterSQL); err != nil {
+		return fmt.Errorf("failed to add checksum column to migrations table: %w", err)
+	}
+
+	log.Printf("Added checksum column to migrations table: %s", m.tableName)
+	return nil
+}
+
 // DiscoverMigrations finds all migration files in the migrations directory
 func (m *Migrator) DiscoverMigrations() ([]Migration, error) {
 	var migrations []Migration
@@ -144,9 +224,13 @@ func (m *Migrator) DiscoverMigrations() ([]Migration, error) {
 		return nil, fmt.Errorf("failed to discover migrations: %w", err)
 	}
 
-	// Sort migrations by ID
-	sort.Slice(migrations, func(i, j int) bool {
-		return migrations[i].ID < migrations[j].ID
+	// Sort migrations by ID; a squashed migration sorts after the
+	// migration whose ID it shares
+	sort.SliceStable(migrations, func(i, j int) bool {
+		if migrations[i].ID != migrations[j].ID {
+			return migrations[i].ID < migrations[j].ID
+		}
+		return len(migrations[i].Replaces) < len(migrations[j].Replaces)
 	})
 
 	return migrations, nil
@@ -187,6 +271,15 @@ func (m *Migrator) parseMigrationFile(filename, path string) (Migration, error)
 		Name:     name,
 		Filename: filename,
 		SQL:      string(content),
+		Checksum: migrationChecksum(string(content)),
+	}
+
+	// Squashed migrations list the migrations they replace in their header
+	for _, line := range strings.Split(migration.SQL, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, replacesDirective) {
+			migration.Replaces = append(migration.Replaces, strings.TrimSpace(strings.TrimPrefix(line, replacesDirective)))
+		}
 	}
 
 	// Look for corresponding rollback file
@@ -205,7 +298,7 @@ func (m *Migrator) parseMigrationFile(filename, path string) (Migration, error)
 // GetAppliedMigrations returns all migrations that have been applied
 func (m *Migrator) GetAppliedMigrations(ctx context.Context) ([]Migration, error) {
 	query := fmt.Sprintf(`
-		SELECT id, name, filename, applied_at 
+		SELECT id, name, filename, checksum, applied_at 
 		FROM %s 
 		ORDER BY id ASC
 	`, m.tableName)
@@ -219,10 +312,12 @@ func (m *Migrator) GetAppliedMigrations(ctx context.Context) ([]Migration, error
 	var migrations []Migration
 	for rows.Next() {
 		var migration Migration
-		err := rows.Scan(&migration.ID, &migration.Name, &migration.Filename, &migration.AppliedAt)
+		var checksum sql.NullString
+		err := rows.Scan(&migration.ID, &migration.Name, &migration.Filename, &checksum, &migration.AppliedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan migration row: %w", err)
 		}
+		migration.Checksum = checksum.String
 		migrations = append(migrations, migration)
 	}
 
@@ -246,16 +341,27 @@ func (m *Migrator) GetStatus(ctx context.Context) (*MigrationStatus, error) {
 	}
 
 	// Create a map of applied migrations for quick lookup
-	appliedMap := make(map[int]Migration)
+	appliedMap := make(map[string]Migration)
 	for _, applied := range appliedMigrations {
-		appliedMap[applied.ID] = applied
+		appliedMap[migrationLabel(applied.Filename)] = applied
 	}
 
+	hidden, squashedApplied := resolveReplacements(allMigrations, appliedMap)
+
 	// Separate applied and pending migrations
 	var applied, pending []Migration
 	for _, migration := range allMigrations {
-		if appliedMigration, exists := appliedMap[migration.ID]; exists {
-			applied = append(applied, appliedMigration)
+		label := migration.Label()
+		if hidden[label] {
+			continue
+		}
+		
+		if appliedMigration, exists := appliedMap[label]; exists {
+			migration.AppliedAt = appliedMigration.AppliedAt
+			applied = append(applied, migration)
+		} else if appliedAt, exists := squashedApplied[label]; exists {
+			migration.AppliedAt = appliedAt
+			applied = append(applied, migration)
 		} else {
 			pending = append(pending, migration)
 		}
@@ -268,13 +374,199 @@ func (m *Migrator) GetStatus(ctx context.Context) (*MigrationStatus, error) {
 
 	if len(applied) > 0 {
 		status.LastApplied = &applied[len(applied)-1]
+		
+		for _, migration := range pending {
+			if migration.ID < status.LastApplied.ID {
+				status.Conflicts = append(status.Conflicts, migration)
+			}
+		}
 	}
 
 	return status, nil
 }
 
-// Apply runs all pending migrations
+// Verify recomputes the checksums of applied migrations from their files and
+// returns the migrations whose SQL changed since they were applied. Records
+// without a checksum, and applied migrations whose file no longer exists,
+// are skipped.
+func (m *Migrator) Verify(ctx context.Context) ([]ChecksumMismatch, error) {
+	allMigrations, err := m.DiscoverMigrations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover migrations: %w", err)
+	}
+
+	appliedMigrations, err := m.GetAppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+
+	files := make(map[string]Migration)
+	for _, migration := range allMigrations {
+		files[migration.Label()] = migration
+	}
+
+	var mismatches []ChecksumMismatch
+	for _, applied := range appliedMigrations {
+		if applied.Checksum == "" {
+			continue
+		}
+		
+		file, exists := files[migrationLabel(applied.Filename)]
+		if !exists || file.Checksum == applied.Checksum {
+			continue
+		}
+		
+		file.AppliedAt = applied.AppliedAt
+		mismatches = append(mismatches, ChecksumMismatch{
+			Migration: file,
+			Recorded:  applied.Checksum,
+			Current:   file.Checksum,
+		})
+	}
+
+	return mismatches, nil
+}
+
+// resolveReplacements decides, for each squashed migration, whether it or
+// the migrations it replaces are used. The squashed migration is used when
+// it was applied itself, when none of the replaced migrations were applied
+// (a fresh database), or when all of them were applied, in which case it
+// counts as applied at the time of the latest one. If only some were applied
+// the originals are used until they have all been applied. It returns the
+// labels of migrations to leave out and the squashed migrations that count
+// as applied through their originals.
+func resolveReplacements(migrations []Migration, applied map[string]Migration) (map[string]bool, map[string]time.Time) {
+	hidden := make(map[string]bool)
+	squashedApplied := make(map[string]time.Time)
+
+	for _, migration := range migrations {
+		if len(migration.Replaces) == 0 {
+			continue
+		}
+		label := migration.Label()
+
+		appliedCount := 0
+		var latest time.Time
+		for _, replaced := range migration.Replaces {
+			if record, exists := applied[replaced]; exists {
+				appliedCount++
+				if record.AppliedAt.After(latest) {
+					latest = record.AppliedAt
+				}
+			}
+		}
+
+		_, squashedWasApplied := applied[label]
+		switch {
+		case squashedWasApplied || appliedCount == 0:
+			// Fall through to hiding the originals
+		case appliedCount == len(migration.Replaces):
+			squashedApplied[label] = latest
+		default:
+			hidden[label] = true
+			continue
+		}
+
+		for _, replaced := range migration.Replaces {
+			hidden[replaced] = true
+		}
+	}
+
+	return hidden, squashedApplied
+}
+
+// Squash collapses the migrations with IDs from through to into a single
+// migration file named NNNN_squashed_FROM_TO_up.sql, where NNNN is the last
+// replaced ID. The up SQL of the originals is concatenated in order and their
+// down SQL in reverse. The original files are kept; the squashed migration
+// records them as replaced, so databases that applied them stay consistent
+// and fresh databases only run the squashed migration.
+func (m *Migrator) Squash(from, to int) (*Migration, error) {
+	if from >= to {
+		return nil, fmt.Errorf("invalid squash range %04d-%04d: start must be before end", from, to)
+	}
+
+	allMigrations, err := m.DiscoverMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]Migration)
+	for _, migration := range allMigrations {
+		if len(migration.Replaces) > 0 {
+			if migration.ID >= from && migration.ID <= to {
+				return nil, fmt.Errorf("migration %s is already squashed", migration.Label())
+			}
+			continue
+		}
+		if _, exists := byID[migration.ID]; exists {
+			return nil, fmt.Errorf("duplicate migration ID %04d", migration.ID)
+		}
+		byID[migration.ID] = migration
+	}
+
+	var replaced []Migration
+	for id := from; id <= to; id++ {
+		migration, exists := byID[id]
+		if !exists {
+			return nil, fmt.Errorf("migration %04d not found", id)
+		}
+		replaced = append(replaced, migration)
+	}
+
+	name := fmt.Sprintf("%s%04d_%04d", SquashedMigrationPrefix, from, to)
+	base := fmt.Sprintf("%04d_%s", to, name)
+
+	var up strings.Builder
+	fmt.Fprintf(&up, "-- Squashed migrations %04d to %04d\n", from, to)
+	fmt.Fprintf(&up, "-- Created: %s\n", time.Now().Format("2006-01-02 15:04:05"))
+	for _, migration := range replaced {
+		fmt.Fprintf(&up, "%s%s\n", replacesDirective, migration.Label())
+	}
+	for _, migration := range replaced {
+		fmt.Fprintf(&up, "\n-- From %s\n%s\n", migration.Label(), strings.TrimSpace(migration.SQL))
+	}
+
+	var down strings.Builder
+	fmt.Fprintf(&down, "-- Rollback for squashed migrations %04d to %04d\n", from, to)
+	for i := len(replaced) - 1; i >= 0; i-- {
+		migration := replaced[i]
+		if migration.RollbackSQL == "" {
+			fmt.Fprintf(&down, "\n-- No rollback SQL for %s\n", migration.Label())
+			continue
+		}
+		fmt.Fprintf(&down, "\n-- From %s\n%s\n", migration.Label(), strings.TrimSpace(migration.RollbackSQL))
+	}
+
+	upPath := filepath.Join(m.migrationsPath, base+"_up.sql")
+	downPath := filepath.Join(m.migrationsPath, base+"_down.sql")
+	if _, err := os.Stat(upPath); err == nil {
+		return nil, fmt.Errorf("squashed migration already exists: %s", upPath)
+	}
+
+	if err := os.WriteFile(upPath, []byte(up.String()), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write squashed migration: %w", err)
+	}
+	if err := os.WriteFile(downPath, []byte(down.String()), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write squashed rollback: %w", err)
+	}
+
+	squashed, err := m.parseMigrationFile(filepath.Base(upPath), upPath)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Squashed %d migrations into %s", len(replaced), squashed.Filename)
+	return &squashed, nil
+}
+
+// Apply runs all pending migrations, each in its own transaction unless
+// SetAtomic(true) was called
 func (m *Migrator) Apply(ctx context.Context) error {
+	if m.atomic {
+		return m.ApplyAtomic(ctx)
+	}
+	
 	status, err := m.GetStatus(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get migration status: %w", err)
@@ -298,6 +590,158 @@ func (m *Migrator) Apply(ctx context.Context) error {
 	return nil
 }
 
+// ApplyAtomic runs all pending migrations in a single transaction. If any
+// migration fails, none of the batch is applied or recorded. Postgres and
+// SQLite support transactional DDL; MySQL implicitly commits most DDL
+// statements, so a failed batch may be left partially applied there.
+func (m *Migrator) ApplyAtomic(ctx context.Context) error {
+	status, err := m.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	if len(status.Pending) == 0 {
+		log.Println("No pending migrations to apply")
+		return nil
+	}
+
+	if m.conn.Driver() == DriverMySQL {
+		log.Println("Warning: MySQL does not support transactional DDL; a failed batch may be partially applied")
+	}
+
+	log.Printf("Applying %d pending migrations atomically", len(status.Pending))
+
+	tx, err := m.conn.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, migration := range status.Pending {
+		if err := m.execMigration(ctx, tx, migration); err != nil {
+			return fmt.Errorf("failed to apply migration %d_%s, rolled back %d migrations: %w",
+				migration.ID, migration.Name, len(status.Pending), err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migrations: %w", err)
+	}
+
+	for _, migration := range status.Pending {
+		log.Printf("Applied migration: %d_%s", migration.ID, migration.Name)
+	}
+	log.Printf("Successfully applied %d migrations", len(status.Pending))
+	return nil
+}
+
+// MigrateTo brings the database to the state right after the migration with
+// targetID: pending migrations up to and including it are applied, and
+// applied migrations after it are rolled back in reverse order. A target of
+// 0 rolls back every migration. It is a no-op when the database is already
+// at the target. Rollback SQL is checked for every migration to be rolled
+// back before anything is changed.
+func (m *Migrator) MigrateTo(ctx context.Context, targetID int) error {
+	status, err := m.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	if targetID != 0 && !hasMigrationID(status.Applied, targetID) && !hasMigrationID(status.Pending, targetID) {
+		return fmt.Errorf("migration %04d not found", targetID)
+	}
+
+	var toRollback, toApply []Migration
+	for i := len(status.Applied) - 1; i >= 0; i-- {
+		if status.Applied[i].ID > targetID {
+			toRollback = append(toRollback, status.Applied[i])
+		}
+	}
+	for _, migration := range status.Pending {
+		if migration.ID <= targetID {
+			toApply = append(toApply, migration)
+		}
+	}
+
+	if len(toRollback) == 0 && len(toApply) == 0 {
+		log.Printf("Already at migration %04d", targetID)
+		return nil
+	}
+
+	for _, migration := range toRollback {
+		if migration.RollbackSQL == "" {
+			return fmt.Errorf("cannot migrate back to %04d: no rollback SQL found for migration: %d_%s",
+				targetID, migration.ID, migration.Name)
+		}
+	}
+
+	for _, migration := range toRollback {
+		log.Printf("Rolling back migration: %d_%s", migration.ID, migration.Name)
+		if err := m.rollbackMigration(ctx, migration, migration.RollbackSQL); err != nil {
+			return fmt.Errorf("failed to roll back migration %d_%s: %w", migration.ID, migration.Name, err)
+		}
+	}
+
+	for _, migration := range toApply {
+		if err := m.applyMigration(ctx, migration); err != nil {
+			return fmt.Errorf("failed to apply migration %d_%s: %w", migration.ID, migration.Name, err)
+		}
+		log.Printf("Applied migration: %d_%s", migration.ID, migration.Name)
+	}
+
+	log.Printf("Migrated to %04d: %d applied, %d rolled back", targetID, len(toApply), len(toRollback))
+	return nil
+}
+
+// hasMigrationID reports whether migrations contains a migration with id
+func hasMigrationID(migrations []Migration, id int) bool {
+	for _, migration := range migrations {
+		if migration.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Fake records the pending migration with the given ID as applied without
+// running its SQL, for schemas that were already changed by other means
+func (m *Migrator) Fake(ctx context.Context, id int) error {
+	status, err := m.GetStatus(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get migration status: %w", err)
+	}
+
+	for _, migration := range status.Applied {
+		if migration.ID == id {
+			return fmt.Errorf("migration %d_%s is already applied", migration.ID, migration.Name)
+		}
+	}
+
+	for _, migration := range status.Pending {
+		if migration.ID != id {
+			continue
+		}
+
+		tx, err := m.conn.DB().BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to start transaction: %w", err)
+		}
+		defer tx.Rollback()
+
+		if err := m.recordMigration(ctx, tx, migration); err != nil {
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit fake migration: %w", err)
+		}
+
+		log.Printf("Faked migration: %d_%s", migration.ID, migration.Name)
+		return nil
+	}
+
+	return fmt.Errorf("migration %04d not found", id)
+}
+
 // applyMigration applies a single migration
 func (m *Migrator) applyMigration(ctx context.Context, migration Migration) error {
 	tx, err := m.conn.DB().BeginTx(ctx, nil)
@@ -306,18 +750,32 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 	}
 	defer tx.Rollback()
 
+	if err := m.execMigration(ctx, tx, migration); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// execMigration runs a migration's SQL and records it as applied within tx
+func (m *Migrator) execMigration(ctx context.Context, tx *sql.Tx, migration Migration) error {
 	// Execute migration SQL
 	if migration.SQL != "" {
-		_, err = tx.ExecContext(ctx, migration.SQL)
+		_, err := tx.ExecContext(ctx, migration.SQL)
 		if err != nil {
 			return fmt.Errorf("failed to execute migration SQL: %w", err)
 		}
 	}
 
+	return m.recordMigration(ctx, tx, migration)
+}
+
+// recordMigration marks a migration as applied within tx
+func (m *Migrator) recordMigration(ctx context.Context, tx *sql.Tx, migration Migration) error {
 	// Record migration as applied
 	insertQuery := fmt.Sprintf(`
-		INSERT INTO %s (name, filename, applied_at) 
-		VALUES ($1, $2, $3)
+		INSERT INTO %s (name, filename, checksum, applied_at) 
+		VALUES ($1, $2, $3, $4)
 	`, m.tableName)
 
 	// Adjust placeholder for different databases
@@ -326,19 +784,21 @@ func (m *Migrator) applyMigration(ctx context.Context, migration Migration) erro
 		insertQuery = strings.Replace(insertQuery, "$1", "?", -1)
 		insertQuery = strings.Replace(insertQuery, "$2", "?", -1)
 		insertQuery = strings.Replace(insertQuery, "$3", "?", -1)
+		insertQuery = strings.Replace(insertQuery, "$4", "?", -1)
 	case DriverSQLite:
 		// SQLite uses ? placeholders
 		insertQuery = strings.Replace(insertQuery, "$1", "?", -1)
 		insertQuery = strings.Replace(insertQuery, "$2", "?", -1)
 		insertQuery = strings.Replace(insertQuery, "$3", "?", -1)
+		insertQuery = strings.Replace(insertQuery, "$4", "?", -1)
 	}
 
-	_, err = tx.ExecContext(ctx, insertQuery, migration.Name, migration.Filename, time.Now())
+	_, err := tx.ExecContext(ctx, insertQuery, migration.Name, migration.Filename, migrationChecksum(migration.SQL), time.Now())
 	if err != nil {
 		return fmt.Errorf("failed to record migration: %w", err)
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // Rollback rolls back the last applied migration
@@ -355,31 +815,13 @@ func (m *Migrator) Rollback(ctx context.Context) error {
 
 	migration := *status.LastApplied
 
-	// Find the migration file to get rollback SQL
-	allMigrations, err := m.DiscoverMigrations()
-	if err != nil {
-		return fmt.Errorf("failed to discover migrations: %w", err)
-	}
-
-	var rollbackMigration *Migration
-	for _, m := range allMigrations {
-		if m.ID == migration.ID {
-			rollbackMigration = &m
-			break
-		}
-	}
-
-	if rollbackMigration == nil {
-		return fmt.Errorf("migration file not found for rollback: %d_%s", migration.ID, migration.Name)
-	}
-
-	if rollbackMigration.RollbackSQL == "" {
+	if migration.RollbackSQL == "" {
 		return fmt.Errorf("no rollback SQL found for migration: %d_%s", migration.ID, migration.Name)
 	}
 
 	log.Printf("Rolling back migration: %d_%s", migration.ID, migration.Name)
 
-	return m.rollbackMigration(ctx, migration, rollbackMigration.RollbackSQL)
+	return m.rollbackMigration(ctx, migration, migration.RollbackSQL)
 }
 
 // rollbackMigration rolls back a single migration
@@ -396,8 +838,9 @@ func (m *Migrator) rollbackMigration(ctx context.Context, migration Migration, r
 		return fmt.Errorf("failed to execute rollback SQL: %w", err)
 	}
 
-	// Remove migration record
-	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, m.tableName)
+	// Remove migration record, along with the records of the migrations a
+	// squashed migration replaces
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE filename = $1`, m.tableName)
 	
 	// Adjust placeholder for different databases
 	switch m.conn.Driver() {
@@ -405,9 +848,15 @@ func (m *Migrator) rollbackMigration(ctx context.Context, migration Migration, r
 		deleteQuery = strings.Replace(deleteQuery, "$1", "?", -1)
 	}
 
-	_, err = tx.ExecContext(ctx, deleteQuery, migration.ID)
-	if err != nil {
-		return fmt.Errorf("failed to remove migration record: %w", err)
+	filenames := []string{migration.Filename}
+	for _, replaced := range migration.Replaces {
+		filenames = append(filenames, replaced+"_up.sql", replaced+".sql")
+	}
+
+	for _, filename := range filenames {
+		if _, err := tx.ExecContext(ctx, deleteQuery, filename); err != nil {
+			return fmt.Errorf("failed to remove migration record: %w", err)
+		}
 	}
 
 	log.Printf("Rolled back migration: %d_%s", migration.ID, migration.Name)
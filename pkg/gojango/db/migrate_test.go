@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -139,11 +140,11 @@ func TestDiscoverMigrations(t *testing.T) {
 	for _, migration := range upFiles {
 		upFile := filepath.Join(migrationsPath, fmt.Sprintf("%04d_%s_up.sql", migration.id, migration.name))
 		downFile := filepath.Join(migrationsPath, fmt.Sprintf("%04d_%s_down.sql", migration.id, migration.name))
-		
+
 		if err := os.WriteFile(upFile, []byte(migration.sql), 0644); err != nil {
 			t.Fatalf("Failed to create up migration: %v", err)
 		}
-		
+
 		if err := os.WriteFile(downFile, []byte("-- Rollback"), 0644); err != nil {
 			t.Fatalf("Failed to create down migration: %v", err)
 		}
@@ -162,7 +163,7 @@ func TestDiscoverMigrations(t *testing.T) {
 	// Check if migrations are sorted by ID
 	expectedOrder := []int{1, 2, 3}
 	expectedNames := []string{"initial", "add_posts", "add_email"}
-	
+
 	for i, migration := range migrations {
 		if migration.ID != expectedOrder[i] {
 			t.Errorf("Expected migration ID %d at position %d, got %d", expectedOrder[i], i, migration.ID)
@@ -184,10 +185,10 @@ func TestParseMigrationFile(t *testing.T) {
 	defer cleanup()
 
 	tests := []struct {
-		name      string
-		filename  string
-		shouldErr bool
-		expectedID int
+		name         string
+		filename     string
+		shouldErr    bool
+		expectedID   int
 		expectedName string
 	}{
 		{"Valid up migration", "0001_initial_up.sql", false, 1, "initial"},
@@ -255,11 +256,11 @@ func TestMigratorFullWorkflow(t *testing.T) {
 	}
 
 	// Create test migrations
-	createTestMigration(t, migrationsPath, 1, "create_users", 
+	createTestMigration(t, migrationsPath, 1, "create_users",
 		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);",
 		"DROP TABLE users;")
-	
-	createTestMigration(t, migrationsPath, 2, "create_posts", 
+
+	createTestMigration(t, migrationsPath, 2, "create_posts",
 		"CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT, user_id INTEGER);",
 		"DROP TABLE posts;")
 
@@ -290,7 +291,7 @@ func TestMigratorFullWorkflow(t *testing.T) {
 	// Verify tables were created
 	db := migrator.conn.DB()
 	var userTableCount, postTableCount int
-	
+
 	err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='users'").Scan(&userTableCount)
 	if err != nil {
 		t.Fatalf("Failed to check users table: %v", err)
@@ -434,7 +435,7 @@ func TestMigratorEdgeCases(t *testing.T) {
 	}
 
 	// Create migration without rollback SQL
-	createTestMigration(t, migrationsPath, 1, "no_rollback", 
+	createTestMigration(t, migrationsPath, 1, "no_rollback",
 		"CREATE TABLE test (id INTEGER);",
 		"")
 
@@ -464,7 +465,7 @@ func TestMigratorSQLErrors(t *testing.T) {
 	}
 
 	// Create migration with invalid SQL
-	createTestMigration(t, migrationsPath, 1, "invalid_sql", 
+	createTestMigration(t, migrationsPath, 1, "invalid_sql",
 		"INVALID SQL STATEMENT;",
 		"DROP TABLE nonexistent;")
 
@@ -488,13 +489,13 @@ func TestMigratorSQLErrors(t *testing.T) {
 func TestMigratorDifferentDrivers(t *testing.T) {
 	// Test PostgreSQL-style queries (can't actually connect to PostgreSQL in tests)
 	pgConfig := PostgresConfig("localhost", "test", "user", "pass")
-	
+
 	// This is a simplified test - we can't actually test PostgreSQL without a real connection
 	// But we can test that the migrator accepts PostgreSQL connections
 	migrator := &Migrator{
-		conn: &Connection{config: pgConfig},
+		conn:           &Connection{config: pgConfig},
 		migrationsPath: "/tmp/migrations",
-		tableName: "test_migrations",
+		tableName:      "test_migrations",
 	}
 
 	if migrator.conn.config.Driver != DriverPostgres {
@@ -532,7 +533,7 @@ func TestGetAppliedMigrations(t *testing.T) {
 
 	// Manually insert a migration record for testing
 	db := migrator.conn.DB()
-	_, err = db.ExecContext(ctx, 
+	_, err = db.ExecContext(ctx,
 		fmt.Sprintf("INSERT INTO %s (name, filename, applied_at) VALUES (?, ?, ?)", migrator.tableName),
 		"test_migration", "0001_test_migration.sql", time.Now())
 	if err != nil {
@@ -558,4 +559,581 @@ func TestGetAppliedMigrations(t *testing.T) {
 	if migration.AppliedAt.IsZero() {
 		t.Errorf("Expected applied_at to be set")
 	}
-}
\ No newline at end of file
+}
+
+func createSquashTestMigrations(t *testing.T, migrationsPath string) {
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);",
+		"DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT);",
+		"DROP TABLE posts;")
+	createTestMigration(t, migrationsPath, 3, "add_post_author",
+		"ALTER TABLE posts ADD COLUMN user_id INTEGER;",
+		"ALTER TABLE posts DROP COLUMN user_id;")
+}
+
+func TestMigratorSquash(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	// Apply the originals, then squash them
+	createSquashTestMigrations(t, migrationsPath)
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+
+	squashed, err := migrator.Squash(1, 3)
+	if err != nil {
+		t.Fatalf("Failed to squash migrations: %v", err)
+	}
+	if squashed.Filename != "0003_squashed_0001_0003_up.sql" {
+		t.Errorf("Unexpected squashed filename: %s", squashed.Filename)
+	}
+	expectedReplaces := []string{"0001_create_users", "0002_create_posts", "0003_add_post_author"}
+	if len(squashed.Replaces) != len(expectedReplaces) {
+		t.Fatalf("Expected squashed migration to replace %v, got %v", expectedReplaces, squashed.Replaces)
+	}
+	for i, label := range expectedReplaces {
+		if squashed.Replaces[i] != label {
+			t.Errorf("Expected replaces[%d] to be %s, got %s", i, label, squashed.Replaces[i])
+		}
+	}
+
+	// The database with the originals applied counts the squashed migration as applied
+	status, err := migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if len(status.Applied) != 1 || status.Applied[0].Filename != squashed.Filename {
+		t.Errorf("Expected only the squashed migration to be applied, got %v", status.Applied)
+	}
+	if len(status.Pending) != 0 {
+		t.Errorf("Expected no pending migrations after squashing, got %d", len(status.Pending))
+	}
+
+	// Applying again is a no-op
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to re-apply migrations: %v", err)
+	}
+
+	// A fresh database only runs the squashed migration
+	freshConn, err := Open(SQLiteConfig(filepath.Join(t.TempDir(), "fresh.db")))
+	if err != nil {
+		t.Fatalf("Failed to create fresh database: %v", err)
+	}
+	defer freshConn.Close()
+
+	fresh := NewMigrator(freshConn, migrationsPath)
+	if err := fresh.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize fresh migrator: %v", err)
+	}
+
+	status, err = fresh.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get fresh status: %v", err)
+	}
+	if len(status.Pending) != 1 || status.Pending[0].Filename != squashed.Filename {
+		t.Errorf("Expected only the squashed migration to be pending, got %v", status.Pending)
+	}
+
+	if err := fresh.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply squashed migration: %v", err)
+	}
+
+	var columnCount int
+	err = freshConn.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM pragma_table_info('posts') WHERE name = 'user_id'").Scan(&columnCount)
+	if err != nil {
+		t.Fatalf("Failed to inspect posts table: %v", err)
+	}
+	if columnCount != 1 {
+		t.Errorf("Expected squashed migration to add posts.user_id")
+	}
+
+	status, err = fresh.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get fresh status after apply: %v", err)
+	}
+	if len(status.Applied) != 1 || len(status.Pending) != 0 {
+		t.Errorf("Expected 1 applied and 0 pending on fresh database, got %d applied and %d pending",
+			len(status.Applied), len(status.Pending))
+	}
+
+	// Later migrations apply on top of the squashed one
+	createTestMigration(t, migrationsPath, 4, "create_tags",
+		"CREATE TABLE tags (id INTEGER PRIMARY KEY);",
+		"DROP TABLE tags;")
+
+	status, err = fresh.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status with new migration: %v", err)
+	}
+	if len(status.Pending) != 1 || status.Pending[0].ID != 4 {
+		t.Errorf("Expected migration 4 to be pending, got %v", status.Pending)
+	}
+
+	// Rolling back the squashed migration on the original database removes
+	// the records of the migrations it replaces
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply migration 4: %v", err)
+	}
+	if err := migrator.Reset(ctx); err != nil {
+		t.Fatalf("Failed to reset squashed migrations: %v", err)
+	}
+	applied, err := migrator.GetAppliedMigrations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Expected no migration records after reset, got %d", len(applied))
+	}
+}
+
+func TestMigratorSquashPartiallyApplied(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	// Only the first migration is applied before squashing
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);",
+		"DROP TABLE users;")
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply first migration: %v", err)
+	}
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT);",
+		"DROP TABLE posts;")
+
+	if _, err := migrator.Squash(1, 2); err != nil {
+		t.Fatalf("Failed to squash migrations: %v", err)
+	}
+
+	// The originals are used until all of them are applied
+	status, err := migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if len(status.Pending) != 1 || status.Pending[0].Name != "create_posts" {
+		t.Errorf("Expected original migration 2 to be pending, got %v", status.Pending)
+	}
+
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply remaining migration: %v", err)
+	}
+
+	status, err = migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status after apply: %v", err)
+	}
+	if len(status.Applied) != 1 || len(status.Applied[0].Replaces) != 2 || len(status.Pending) != 0 {
+		t.Errorf("Expected the squashed migration to count as applied, got %v applied and %v pending",
+			status.Applied, status.Pending)
+	}
+}
+
+func TestMigratorSquashInvalidRange(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	createSquashTestMigrations(t, migrationsPath)
+
+	if _, err := migrator.Squash(2, 2); err == nil {
+		t.Error("Expected error for empty squash range")
+	}
+	if _, err := migrator.Squash(2, 5); err == nil {
+		t.Error("Expected error for squash range with missing migrations")
+	}
+	if _, err := migrator.Squash(1, 2); err != nil {
+		t.Fatalf("Failed to squash migrations: %v", err)
+	}
+	if _, err := migrator.Squash(1, 3); err == nil {
+		t.Error("Expected error when squashing an already squashed migration")
+	}
+}
+
+func TestMigratorApplyAtomic(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT);",
+		"DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 2, "broken",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY); INVALID SQL STATEMENT;",
+		"DROP TABLE posts;")
+
+	migrator.SetAtomic(true)
+	if err := migrator.Apply(ctx); err == nil {
+		t.Fatal("Expected atomic apply to fail on the broken migration")
+	}
+
+	// Nothing from the batch is recorded or left behind
+	applied, err := migrator.GetAppliedMigrations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("Expected 0 migrations recorded after failed atomic apply, got %d", len(applied))
+	}
+
+	var tableCount int
+	err = migrator.conn.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name IN ('users', 'posts')").Scan(&tableCount)
+	if err != nil {
+		t.Fatalf("Failed to check tables: %v", err)
+	}
+	if tableCount != 0 {
+		t.Errorf("Expected no tables from the failed batch, found %d", tableCount)
+	}
+
+	// Without atomic mode the first migration stays applied
+	migrator.SetAtomic(false)
+	if err := migrator.Apply(ctx); err == nil {
+		t.Fatal("Expected non-atomic apply to fail on the broken migration")
+	}
+	applied, err = migrator.GetAppliedMigrations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	if len(applied) != 1 {
+		t.Errorf("Expected 1 migration recorded after failed non-atomic apply, got %d", len(applied))
+	}
+
+	// Fixing the broken migration lets the atomic batch through
+	createTestMigration(t, migrationsPath, 2, "broken",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY);",
+		"DROP TABLE posts;")
+	createTestMigration(t, migrationsPath, 3, "create_tags",
+		"CREATE TABLE tags (id INTEGER PRIMARY KEY);",
+		"DROP TABLE tags;")
+
+	migrator.SetAtomic(true)
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations atomically: %v", err)
+	}
+	status, err := migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if len(status.Applied) != 3 || len(status.Pending) != 0 {
+		t.Errorf("Expected 3 applied and 0 pending, got %d applied and %d pending", len(status.Applied), len(status.Pending))
+	}
+}
+
+func TestMigratorOutOfOrderConflicts(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY);", "DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 3, "create_tags",
+		"CREATE TABLE tags (id INTEGER PRIMARY KEY);", "DROP TABLE tags;")
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+
+	// A migration merged in from another branch fills the gap below the last applied one
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY);", "DROP TABLE posts;")
+	createTestMigration(t, migrationsPath, 4, "create_comments",
+		"CREATE TABLE comments (id INTEGER PRIMARY KEY);", "DROP TABLE comments;")
+
+	status, err := migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if len(status.Pending) != 2 {
+		t.Errorf("Expected 2 pending migrations, got %d", len(status.Pending))
+	}
+	if len(status.Conflicts) != 1 || status.Conflicts[0].ID != 2 {
+		t.Errorf("Expected migration 2 to be reported as a conflict, got %v", status.Conflicts)
+	}
+
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply remaining migrations: %v", err)
+	}
+	status, err = migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status after apply: %v", err)
+	}
+	if len(status.Conflicts) != 0 {
+		t.Errorf("Expected no conflicts after applying all migrations, got %v", status.Conflicts)
+	}
+}
+
+func TestMigratorFake(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY);", "DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY);", "DROP TABLE posts;")
+
+	if err := migrator.Fake(ctx, 1); err != nil {
+		t.Fatalf("Failed to fake migration: %v", err)
+	}
+
+	status, err := migrator.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	if len(status.Applied) != 1 || status.Applied[0].ID != 1 {
+		t.Errorf("Expected migration 1 to be recorded as applied, got %v", status.Applied)
+	}
+	if len(status.Pending) != 1 || status.Pending[0].ID != 2 {
+		t.Errorf("Expected migration 2 to remain pending, got %v", status.Pending)
+	}
+
+	// The faked migration's SQL was not run
+	var tableCount int
+	err = migrator.conn.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='users'").Scan(&tableCount)
+	if err != nil {
+		t.Fatalf("Failed to check users table: %v", err)
+	}
+	if tableCount != 0 {
+		t.Errorf("Expected faked migration not to create the users table")
+	}
+
+	if err := migrator.Fake(ctx, 1); err == nil {
+		t.Error("Expected error when faking an applied migration")
+	}
+	if err := migrator.Fake(ctx, 9); err == nil {
+		t.Error("Expected error when faking an unknown migration")
+	}
+}
+
+func TestMigratorVerifyChecksums(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY);", "DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY);", "DROP TABLE posts;")
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+
+	applied, err := migrator.GetAppliedMigrations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	for _, migration := range applied {
+		if len(migration.Checksum) != 64 {
+			t.Errorf("Expected a SHA-256 checksum for %s, got %q", migration.Filename, migration.Checksum)
+		}
+	}
+
+	mismatches, err := migrator.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Failed to verify migrations: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("Expected no mismatches for untouched migrations, got %v", mismatches)
+	}
+
+	// Edit an applied migration
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT);", "DROP TABLE posts;")
+
+	mismatches, err = migrator.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Failed to verify migrations: %v", err)
+	}
+	if len(mismatches) != 1 || mismatches[0].Migration.ID != 2 {
+		t.Fatalf("Expected migration 2 to be reported as modified, got %v", mismatches)
+	}
+	if mismatches[0].Recorded == mismatches[0].Current {
+		t.Error("Expected recorded and current checksums to differ")
+	}
+}
+
+func TestMigratorChecksumColumnUpgrade(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// A migrations table created before checksums were recorded
+	_, err := migrator.conn.DB().ExecContext(ctx, `
+		CREATE TABLE gojango_migrations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			filename TEXT NOT NULL,
+			applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+		INSERT INTO gojango_migrations (name, filename) VALUES ('create_users', '0001_create_users_up.sql');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to create legacy migrations table: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY);", "DROP TABLE users;")
+
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator on legacy table: %v", err)
+	}
+	// Initializing again must not try to add the column twice
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to re-initialize migrator: %v", err)
+	}
+
+	applied, err := migrator.GetAppliedMigrations(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get applied migrations: %v", err)
+	}
+	if len(applied) != 1 || applied[0].Checksum != "" {
+		t.Errorf("Expected the legacy record without a checksum, got %v", applied)
+	}
+
+	// Legacy records are not verified
+	mismatches, err := migrator.Verify(ctx)
+	if err != nil {
+		t.Fatalf("Failed to verify migrations: %v", err)
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("Expected records without checksums to be skipped, got %v", mismatches)
+	}
+}
+
+func appliedMigrationIDs(t *testing.T, migrator *Migrator) []int {
+	status, err := migrator.GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	ids := []int{}
+	for _, migration := range status.Applied {
+		ids = append(ids, migration.ID)
+	}
+	return ids
+}
+
+func TestMigratorMigrateTo(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY);", "DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY);", "DROP TABLE posts;")
+	createTestMigration(t, migrationsPath, 3, "create_tags",
+		"CREATE TABLE tags (id INTEGER PRIMARY KEY);", "DROP TABLE tags;")
+
+	// Forward
+	if err := migrator.MigrateTo(ctx, 2); err != nil {
+		t.Fatalf("Failed to migrate forward: %v", err)
+	}
+	if got := fmt.Sprint(appliedMigrationIDs(t, migrator)); got != "[1 2]" {
+		t.Errorf("Expected migrations 1 and 2 applied, got %s", got)
+	}
+
+	// Same state
+	if err := migrator.MigrateTo(ctx, 2); err != nil {
+		t.Fatalf("Expected migrating to the current state to be a no-op: %v", err)
+	}
+	if got := fmt.Sprint(appliedMigrationIDs(t, migrator)); got != "[1 2]" {
+		t.Errorf("Expected no change when already at the target, got %s", got)
+	}
+
+	// Forward to the end, then backward
+	if err := migrator.MigrateTo(ctx, 3); err != nil {
+		t.Fatalf("Failed to migrate to latest: %v", err)
+	}
+	if err := migrator.MigrateTo(ctx, 1); err != nil {
+		t.Fatalf("Failed to migrate backward: %v", err)
+	}
+	if got := fmt.Sprint(appliedMigrationIDs(t, migrator)); got != "[1]" {
+		t.Errorf("Expected only migration 1 applied, got %s", got)
+	}
+
+	var tableCount int
+	err := migrator.conn.DB().QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name IN ('posts', 'tags')").Scan(&tableCount)
+	if err != nil {
+		t.Fatalf("Failed to check tables: %v", err)
+	}
+	if tableCount != 0 {
+		t.Errorf("Expected posts and tags tables to be dropped, found %d", tableCount)
+	}
+
+	// Zero rolls back everything
+	if err := migrator.MigrateTo(ctx, 0); err != nil {
+		t.Fatalf("Failed to migrate to zero: %v", err)
+	}
+	if got := fmt.Sprint(appliedMigrationIDs(t, migrator)); got != "[]" {
+		t.Errorf("Expected no migrations applied, got %s", got)
+	}
+
+	if err := migrator.MigrateTo(ctx, 9); err == nil {
+		t.Error("Expected error for an unknown target migration")
+	}
+}
+
+func TestMigratorMigrateToMissingRollback(t *testing.T) {
+	migrator, migrationsPath, cleanup := setupTestMigrator(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := migrator.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize migrator: %v", err)
+	}
+
+	createTestMigration(t, migrationsPath, 1, "create_users",
+		"CREATE TABLE users (id INTEGER PRIMARY KEY);", "DROP TABLE users;")
+	createTestMigration(t, migrationsPath, 2, "create_posts",
+		"CREATE TABLE posts (id INTEGER PRIMARY KEY);", "")
+	createTestMigration(t, migrationsPath, 3, "create_tags",
+		"CREATE TABLE tags (id INTEGER PRIMARY KEY);", "DROP TABLE tags;")
+	if err := migrator.Apply(ctx); err != nil {
+		t.Fatalf("Failed to apply migrations: %v", err)
+	}
+
+	err := migrator.MigrateTo(ctx, 1)
+	if err == nil {
+		t.Fatal("Expected error when a migration to roll back has no rollback SQL")
+	}
+	if !strings.Contains(err.Error(), "no rollback SQL") {
+		t.Errorf("Expected missing rollback SQL error, got: %v", err)
+	}
+
+	// Nothing was rolled back, including migrations after the blocking one
+	if got := fmt.Sprint(appliedMigrationIDs(t, migrator)); got != "[1 2 3]" {
+		t.Errorf("Expected all migrations to remain applied, got %s", got)
+	}
+}
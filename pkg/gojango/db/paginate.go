@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCursor is returned by Paginate for a cursor it didn't make
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// PageQuery fetches up to limit rows matching Django-style filters, such as
+// {"id__gt": "42"}, in the given ordering. Filter values from cursors are
+// strings, as from query parameters, which EntPredicateBuilder converts to
+// the column's type. The query adds its own filters to the ones it's given:
+//
+//	query := func(ctx context.Context, filters map[string]interface{}, ordering []string, limit int) ([]*ent.Post, error) {
+//		filters["status"] = "published"
+//		preds, err := db.EntPredicates[predicate.Post](builder, filters)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return client.Post.Query().Where(preds...).Order(orderOptions(ordering)...).Limit(limit).All(ctx)
+//	}
+type PageQuery[T any] func(ctx context.Context, filters map[string]interface{}, ordering []string, limit int) ([]T, error)
+
+// Page is one page of keyset-paginated rows
+type Page[T any] struct {
+	Items []T
+
+	// NextCursor and PrevCursor fetch the following and preceding pages,
+	// and are empty when there are none
+	NextCursor string
+	PrevCursor string
+}
+
+// HasNext reports whether there is a following page
+func (p *Page[T]) HasNext() bool {
+	return p.NextCursor != ""
+}
+
+// HasPrev reports whether there is a preceding page
+func (p *Page[T]) HasPrev() bool {
+	return p.PrevCursor != ""
+}
+
+// pageCursor is the decoded form of a page cursor: the order field value of
+// the row at the page boundary, and whether the page is before it
+type pageCursor struct {
+	Field  string `json:"f"`
+	Value  string `json:"v"`
+	Before bool   `json:"b,omitempty"`
+}
+
+// Paginate fetches the page of rows after (or, for a PrevCursor, before)
+// cursor, ordered by orderField, e.g. "id" or "-created_at" for descending
+// order. An empty cursor fetches the first page. Instead of an offset, each
+// page continues from the order field value of the previous page's last
+// row, so pages stay stable when rows are inserted and deep pages are as
+// fast as the first. orderField must be unique and non-null, such as the
+// primary key, or rows sharing a value across a page boundary are skipped.
+// Rows are structs with fields tagged like Ent entities, or maps.
+func Paginate[T any](ctx context.Context, query PageQuery[T], cursor string, limit int, orderField string) (*Page[T], error) {
+	if limit < 1 {
+		return nil, fmt.Errorf("page limit must be positive, got %d", limit)
+	}
+	field := strings.TrimPrefix(orderField, "-")
+	descending := field != orderField
+	if field == "" {
+		return nil, fmt.Errorf("order field is required")
+	}
+
+	var current *pageCursor
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		if decoded.Field != field {
+			return nil, fmt.Errorf("%w: it orders by %s, not %s", ErrInvalidCursor, decoded.Field, field)
+		}
+		current = decoded
+	}
+	before := current != nil && current.Before
+
+	filters := make(map[string]interface{})
+	ordering := orderField
+	if current != nil {
+		lookup := "gt"
+		if descending != before {
+			lookup = "lt"
+		}
+		filters[field+lookupSeparator+lookup] = current.Value
+	}
+	if before {
+		// Fetch backwards from the cursor, then restore the page's order
+		ordering = "-" + field
+		if descending {
+			ordering = field
+		}
+	}
+
+	items, err := query(ctx, filters, []string{ordering}, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	more := len(items) > limit
+	if more {
+		items = items[:limit]
+	}
+	if before {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+
+	// The page's boundaries are its first and last rows, or the cursor's
+	// value when the page is empty
+	var first, last string
+	if len(items) > 0 {
+		if first, err = cursorValue(items[0], field); err != nil {
+			return nil, err
+		}
+		if last, err = cursorValue(items[len(items)-1], field); err != nil {
+			return nil, err
+		}
+	} else if current != nil {
+		first, last = current.Value, current.Value
+	}
+
+	page := &Page[T]{Items: items}
+	hasNext, hasPrev := more, current != nil
+	if before {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext && (len(items) > 0 || current != nil) {
+		page.NextCursor = encodeCursor(pageCursor{Field: field, Value: last})
+	}
+	if hasPrev && (len(items) > 0 || current != nil) {
+		page.PrevCursor = encodeCursor(pageCursor{Field: field, Value: first, Before: true})
+	}
+	return page, nil
+}
+
+// encodeCursor encodes a cursor as opaque URL-safe text
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes a cursor made by encodeCursor
+func decodeCursor(cursor string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var decoded pageCursor
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return &decoded, nil
+}
+
+// cursorValue returns the value of a row's order field as cursor text.
+// Struct fields are matched by json tag, as Ent sets it to the column name,
+// then by name.
+func cursorValue(row interface{}, field string) (string, error) {
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return "", fmt.Errorf("cannot read %s of a nil row", field)
+		}
+		rv = rv.Elem()
+	}
+
+	var value reflect.Value
+	switch rv.Kind() {
+	case reflect.Map:
+		value = rv.MapIndex(reflect.ValueOf(field))
+	case reflect.Struct:
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]; name == field {
+				value = rv.Field(i)
+				break
+			}
+		}
+		if !value.IsValid() {
+			value = rv.FieldByName(field)
+		}
+	default:
+		return "", fmt.Errorf("cannot read %s of %T", field, row)
+	}
+	for value.IsValid() && (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) {
+		if value.IsNil() {
+			return "", fmt.Errorf("order field %s is null", field)
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return "", fmt.Errorf("%T has no field %s", row, field)
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.UTC().Format(time.RFC3339Nano), nil
+	}
+	return fmt.Sprint(value.Interface()), nil
+}
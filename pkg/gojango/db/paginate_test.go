@@ -0,0 +1,227 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// pagePost mirrors the shape of an Ent-generated entity
+type pagePost struct {
+	ID        int       `json:"id,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+// newPaginateTestDB opens a SQLite database with a posts table holding
+// posts 1 to count, and returns a PageQuery over it
+func newPaginateTestDB(t *testing.T, count int) (*Connection, PageQuery[pagePost]) {
+	t.Helper()
+	conn, err := Open(SQLiteConfig(":memory:"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if _, err := conn.DB().Exec("CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT, created_at DATETIME)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	for id := 1; id <= count; id++ {
+		insertPagePost(t, conn, id)
+	}
+
+	builder, err := NewEntPredicateBuilder(&pagePost{})
+	if err != nil {
+		t.Fatalf("Failed to create predicate builder: %v", err)
+	}
+	query := func(ctx context.Context, filters map[string]interface{}, ordering []string, limit int) ([]pagePost, error) {
+		predicates, err := builder.Build(filters)
+		if err != nil {
+			return nil, err
+		}
+		selector := entsql.Dialect(dialect.SQLite).Select("id", "title", "created_at").From(entsql.Table("posts"))
+		for _, predicate := range predicates {
+			predicate(selector)
+		}
+		for _, field := range ordering {
+			if field[0] == '-' {
+				selector.OrderBy(entsql.Desc(field[1:]))
+			} else {
+				selector.OrderBy(entsql.Asc(field))
+			}
+		}
+		selector.Limit(limit)
+
+		sqlQuery, args := selector.Query()
+		rows, err := conn.DB().QueryContext(ctx, sqlQuery, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		var posts []pagePost
+		for rows.Next() {
+			var post pagePost
+			if err := rows.Scan(&post.ID, &post.Title, &post.CreatedAt); err != nil {
+				return nil, err
+			}
+			posts = append(posts, post)
+		}
+		return posts, rows.Err()
+	}
+	return conn, query
+}
+
+// insertPagePost inserts a post created id minutes after a fixed time
+func insertPagePost(t *testing.T, conn *Connection, id int) {
+	t.Helper()
+	created := time.Date(2024, 1, 1, 0, id, 0, 0, time.UTC)
+	if _, err := conn.DB().Exec("INSERT INTO posts (id, title, created_at) VALUES (?, ?, ?)", id, "post", created); err != nil {
+		t.Fatalf("Failed to insert post %d: %v", id, err)
+	}
+}
+
+// pagePostIDs returns the IDs of posts
+func pagePostIDs(posts []pagePost) []int {
+	ids := make([]int, len(posts))
+	for i, post := range posts {
+		ids[i] = post.ID
+	}
+	return ids
+}
+
+func equalIDs(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+	_, query := newPaginateTestDB(t, 7)
+
+	var pages [][]int
+	cursor := ""
+	for {
+		page, err := Paginate(ctx, query, cursor, 3, "id")
+		if err != nil {
+			t.Fatalf("Failed to paginate: %v", err)
+		}
+		pages = append(pages, pagePostIDs(page.Items))
+		if len(pages) == 1 && page.HasPrev() {
+			t.Error("Expected the first page to have no previous page")
+		}
+		if !page.HasNext() {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	expected := [][]int{{1, 2, 3}, {4, 5, 6}, {7}}
+	if len(pages) != len(expected) {
+		t.Fatalf("Expected pages %v, got: %v", expected, pages)
+	}
+	for i := range expected {
+		if !equalIDs(pages[i], expected[i]) {
+			t.Errorf("Expected page %d to be %v, got: %v", i+1, expected[i], pages[i])
+		}
+	}
+}
+
+func TestPaginatePrevious(t *testing.T) {
+	ctx := context.Background()
+	_, query := newPaginateTestDB(t, 7)
+
+	first, _ := Paginate(ctx, query, "", 3, "-created_at")
+	second, err := Paginate(ctx, query, first.NextCursor, 3, "-created_at")
+	if err != nil {
+		t.Fatalf("Failed to paginate: %v", err)
+	}
+	if ids := pagePostIDs(second.Items); !equalIDs(ids, []int{4, 3, 2}) {
+		t.Errorf("Expected descending page [4 3 2], got: %v", ids)
+	}
+
+	back, err := Paginate(ctx, query, second.PrevCursor, 3, "-created_at")
+	if err != nil {
+		t.Fatalf("Failed to paginate back: %v", err)
+	}
+	if ids := pagePostIDs(back.Items); !equalIDs(ids, []int{7, 6, 5}) {
+		t.Errorf("Expected the first page again, got: %v", ids)
+	}
+	if back.HasPrev() || !back.HasNext() {
+		t.Errorf("Expected only a next page, got next %q prev %q", back.NextCursor, back.PrevCursor)
+	}
+}
+
+func TestPaginateStableAcrossInserts(t *testing.T) {
+	ctx := context.Background()
+	conn, query := newPaginateTestDB(t, 6)
+
+	// Newest first, as a feed would list them
+	page, err := Paginate(ctx, query, "", 3, "-id")
+	if err != nil {
+		t.Fatalf("Failed to paginate: %v", err)
+	}
+	offsetPage, _ := query(ctx, map[string]interface{}{}, []string{"-id"}, 3)
+	if !equalIDs(pagePostIDs(page.Items), []int{6, 5, 4}) || !equalIDs(pagePostIDs(offsetPage), []int{6, 5, 4}) {
+		t.Fatalf("Unexpected first pages: %v and %v", pagePostIDs(page.Items), pagePostIDs(offsetPage))
+	}
+
+	// New posts arrive while the first page is being read
+	insertPagePost(t, conn, 7)
+	insertPagePost(t, conn, 8)
+
+	// The cursor continues where the first page ended...
+	next, err := Paginate(ctx, query, page.NextCursor, 3, "-id")
+	if err != nil {
+		t.Fatalf("Failed to paginate: %v", err)
+	}
+	if ids := pagePostIDs(next.Items); !equalIDs(ids, []int{3, 2, 1}) {
+		t.Errorf("Expected the cursor's second page to be [3 2 1], got: %v", ids)
+	}
+
+	// ...while an offset repeats rows pushed down by the inserts
+	selector := entsql.Dialect(dialect.SQLite).Select("id").From(entsql.Table("posts")).OrderBy(entsql.Desc("id")).Limit(3).Offset(3)
+	sqlQuery, args := selector.Query()
+	rows, err := conn.DB().Query(sqlQuery, args...)
+	if err != nil {
+		t.Fatalf("Failed to query offset page: %v", err)
+	}
+	defer rows.Close()
+	var offsetIDs []int
+	for rows.Next() {
+		var id int
+		rows.Scan(&id)
+		offsetIDs = append(offsetIDs, id)
+	}
+	if !equalIDs(offsetIDs, []int{5, 4, 3}) {
+		t.Errorf("Expected the offset's second page to repeat rows, got: %v", offsetIDs)
+	}
+}
+
+func TestPaginateInvalidCursor(t *testing.T) {
+	ctx := context.Background()
+	_, query := newPaginateTestDB(t, 3)
+
+	if _, err := Paginate(ctx, query, "not a cursor!", 2, "id"); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a malformed cursor, got: %v", err)
+	}
+
+	page, _ := Paginate(ctx, query, "", 2, "id")
+	if _, err := Paginate(ctx, query, page.NextCursor, 2, "created_at"); !errors.Is(err, ErrInvalidCursor) {
+		t.Error("Expected an error for a cursor of another ordering")
+	}
+	if _, err := Paginate(ctx, query, "", 0, "id"); err == nil {
+		t.Error("Expected an error for a zero limit")
+	}
+}
@@ -0,0 +1,356 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// SearchFiltersKey is the filter key holding search lookups that are OR-ed
+// together, as produced by the admin list view
+const SearchFiltersKey = "__search"
+
+// lookupSeparator separates a field name from its lookup, e.g. "age__gte"
+const lookupSeparator = "__"
+
+// supportedLookups are the Django-style lookup suffixes understood by
+// EntPredicateBuilder. A key without a suffix is an exact match.
+var supportedLookups = map[string]bool{
+	"exact":       true,
+	"iexact":      true,
+	"ne":          true,
+	"contains":    true,
+	"icontains":   true,
+	"startswith":  true,
+	"istartswith": true,
+	"endswith":    true,
+	"iendswith":   true,
+	"gt":          true,
+	"gte":         true,
+	"lt":          true,
+	"lte":         true,
+	"in":          true,
+	"isnull":      true,
+}
+
+// EntPredicateBuilder translates filter maps with Django-style lookups, such
+// as {"age__gte": 18, "name__icontains": "jo"}, into Ent SQL predicates.
+// Field names and value types are taken from the fields of an Ent entity
+// struct, so string values from query parameters are converted to the
+// column's type before they reach the database.
+type EntPredicateBuilder struct {
+	fields map[string]reflect.Type
+}
+
+// NewEntPredicateBuilder creates a predicate builder for an Ent entity, e.g.
+// &ent.User{}. Fields are identified by their json tag, which Ent sets to
+// the column name.
+func NewEntPredicateBuilder(model interface{}) (*EntPredicateBuilder, error) {
+	t := reflect.TypeOf(model)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model must be a struct, got %T", model)
+	}
+
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Anonymous {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" || name == "edges" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+
+	return &EntPredicateBuilder{fields: fields}, nil
+}
+
+// HasField reports whether the entity has a field with the given name
+func (b *EntPredicateBuilder) HasField(name string) bool {
+	_, exists := b.fields[name]
+	return exists
+}
+
+// Build translates filters into predicates that are AND-ed by Ent's Where.
+// Keys are processed in sorted order so the generated SQL is stable. The
+// SearchFiltersKey entry, when present, becomes a single OR predicate.
+func (b *EntPredicateBuilder) Build(filters map[string]interface{}) ([]func(*entsql.Selector), error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	predicates := make([]func(*entsql.Selector), 0, len(keys))
+	for _, key := range keys {
+		if key == SearchFiltersKey {
+			search, err := b.buildSearch(filters[key])
+			if err != nil {
+				return nil, err
+			}
+			if search != nil {
+				predicates = append(predicates, search)
+			}
+			continue
+		}
+
+		predicate, err := b.BuildLookup(key, filters[key])
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	return predicates, nil
+}
+
+// EntPredicates builds predicates typed for an Ent-generated predicate
+// package, ready to pass to a query's Where, e.g.
+//
+//	preds, err := db.EntPredicates[predicate.User](builder, filters)
+//	users, err := client.User.Query().Where(preds...).All(ctx)
+func EntPredicates[P ~func(*entsql.Selector)](b *EntPredicateBuilder, filters map[string]interface{}) ([]P, error) {
+	built, err := b.Build(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	predicates := make([]P, len(built))
+	for i, predicate := range built {
+		predicates[i] = P(predicate)
+	}
+	return predicates, nil
+}
+
+// BuildLookup translates a single "field__lookup" key and its value
+func (b *EntPredicateBuilder) BuildLookup(key string, value interface{}) (func(*entsql.Selector), error) {
+	field, lookup := splitLookup(key)
+	fieldType, exists := b.fields[field]
+	if !exists {
+		return nil, fmt.Errorf("unknown filter field: %s", field)
+	}
+
+	switch lookup {
+	case "isnull":
+		isNull, err := toBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		if isNull {
+			return entsql.FieldIsNull(field), nil
+		}
+		return entsql.FieldNotNull(field), nil
+
+	case "iexact", "contains", "icontains", "startswith", "istartswith", "endswith", "iendswith":
+		str := fmt.Sprintf("%v", value)
+		switch lookup {
+		case "iexact":
+			return entsql.FieldEqualFold(field, str), nil
+		case "contains":
+			return entsql.FieldContains(field, str), nil
+		case "icontains":
+			return entsql.FieldContainsFold(field, str), nil
+		case "startswith":
+			return entsql.FieldHasPrefix(field, str), nil
+		case "istartswith":
+			return entsql.FieldHasPrefixFold(field, str), nil
+		case "endswith":
+			return entsql.FieldHasSuffix(field, str), nil
+		default:
+			return entsql.FieldHasSuffixFold(field, str), nil
+		}
+
+	case "in":
+		items := toList(value)
+		values := make([]interface{}, len(items))
+		for i, item := range items {
+			converted, err := convertLookupValue(item, fieldType)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+			}
+			values[i] = converted
+		}
+		return entsql.FieldIn(field, values...), nil
+	}
+
+	converted, err := convertLookupValue(value, fieldType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	switch lookup {
+	case "ne":
+		return entsql.FieldNEQ(field, converted), nil
+	case "gt":
+		return entsql.FieldGT(field, converted), nil
+	case "gte":
+		return entsql.FieldGTE(field, converted), nil
+	case "lt":
+		return entsql.FieldLT(field, converted), nil
+	case "lte":
+		return entsql.FieldLTE(field, converted), nil
+	default:
+		return entsql.FieldEQ(field, converted), nil
+	}
+}
+
+// buildSearch ORs together the lookups of a search filter map
+func (b *EntPredicateBuilder) buildSearch(value interface{}) (func(*entsql.Selector), error) {
+	var lookups map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		lookups = v
+	case map[string]string:
+		lookups = make(map[string]interface{}, len(v))
+		for key, val := range v {
+			lookups[key] = val
+		}
+	default:
+		return nil, fmt.Errorf("search filters must be a map, got %T", value)
+	}
+
+	predicates, err := b.Build(lookups)
+	if err != nil {
+		return nil, err
+	}
+	if len(predicates) == 0 {
+		return nil, nil
+	}
+	return entsql.OrPredicates(predicates...), nil
+}
+
+// splitLookup splits "field__lookup" into its field and lookup. Keys without
+// a supported lookup suffix are exact matches on the whole key.
+func splitLookup(key string) (string, string) {
+	if idx := strings.LastIndex(key, lookupSeparator); idx > 0 {
+		if lookup := key[idx+len(lookupSeparator):]; supportedLookups[lookup] {
+			return key[:idx], lookup
+		}
+	}
+	return key, "exact"
+}
+
+// convertLookupValue converts a filter value, often a string from a query
+// parameter, to the Go type of the field it is compared against
+func convertLookupValue(value interface{}, fieldType reflect.Type) (interface{}, error) {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		return toTime(value)
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return toBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch v := value.(type) {
+		case float64:
+			if v != float64(int64(v)) {
+				return nil, fmt.Errorf("%v is not an integer", v)
+			}
+			return int64(v), nil
+		case string:
+			return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		}
+		rv := reflect.ValueOf(value)
+		if rv.CanInt() {
+			return rv.Int(), nil
+		}
+		if rv.CanUint() {
+			return int64(rv.Uint()), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if str, ok := value.(string); ok {
+			return strconv.ParseUint(strings.TrimSpace(str), 10, 64)
+		}
+		rv := reflect.ValueOf(value)
+		if rv.CanUint() {
+			return rv.Uint(), nil
+		}
+		if rv.CanInt() && rv.Int() >= 0 {
+			return uint64(rv.Int()), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if str, ok := value.(string); ok {
+			return strconv.ParseFloat(strings.TrimSpace(str), 64)
+		}
+		rv := reflect.ValueOf(value)
+		if rv.CanFloat() {
+			return rv.Float(), nil
+		}
+		if rv.CanInt() {
+			return float64(rv.Int()), nil
+		}
+	case reflect.String:
+		// Enum fields are named string types; compare on the plain string
+		return fmt.Sprintf("%v", value), nil
+	default:
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("cannot convert %T to %s", value, fieldType)
+}
+
+// toBool converts a boolean filter value such as "true", "1" or "0"
+func toBool(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	}
+	return false, fmt.Errorf("cannot convert %T to bool", value)
+}
+
+// toTime converts an RFC 3339 timestamp or a YYYY-MM-DD date
+func toTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		v = strings.TrimSpace(v)
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02", v)
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %T to time", value)
+}
+
+// toList converts an "in" lookup value: a slice, or a comma-separated string
+func toList(value interface{}) []interface{} {
+	if str, ok := value.(string); ok {
+		var items []interface{}
+		for _, item := range strings.Split(str, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []interface{}{value}
+	}
+	items := make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// predicateUser mirrors the shape of an Ent-generated entity
+type predicateUser struct {
+	ID        int       `json:"id,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Age       int       `json:"age,omitempty"`
+	Score     float64   `json:"score,omitempty"`
+	IsActive  bool      `json:"is_active,omitempty"`
+	Nickname  *string   `json:"nickname,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	Edges     struct{}  `json:"edges"`
+	groupID   *int
+}
+
+// userPredicate mirrors an Ent-generated predicate type
+type userPredicate func(*entsql.Selector)
+
+// predicateSQL renders predicates as a SQLite WHERE clause
+func predicateSQL(predicates ...func(*entsql.Selector)) (string, []interface{}) {
+	selector := entsql.Dialect(dialect.SQLite).Select("*").From(entsql.Table("users"))
+	for _, predicate := range predicates {
+		predicate(selector)
+	}
+	return selector.Query()
+}
+
+func newPredicateUserBuilder(t *testing.T) *EntPredicateBuilder {
+	builder, err := NewEntPredicateBuilder(&predicateUser{})
+	if err != nil {
+		t.Fatalf("Failed to create predicate builder: %v", err)
+	}
+	return builder
+}
+
+func TestEntPredicateBuilderLookups(t *testing.T) {
+	builder := newPredicateUserBuilder(t)
+	created := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		key   string
+		value interface{}
+		where string
+		args  []interface{}
+	}{
+		{"name", "john", "`users`.`name` = ?", []interface{}{"john"}},
+		{"name__exact", "john", "`users`.`name` = ?", []interface{}{"john"}},
+		{"name__iexact", "John", "LOWER(`users`.`name`) = ?", []interface{}{"john"}},
+		{"name__ne", "john", "`users`.`name` <> ?", []interface{}{"john"}},
+		{"name__contains", "oh", "`users`.`name` LIKE ?", []interface{}{"%oh%"}},
+		{"name__icontains", "OH", "LOWER(`users`.`name`) LIKE ?", []interface{}{"%oh%"}},
+		{"name__startswith", "jo", "`users`.`name` LIKE ?", []interface{}{"jo%"}},
+		{"name__istartswith", "JO", "LOWER(`users`.`name`) LIKE ?", []interface{}{"jo%"}},
+		{"name__endswith", "hn", "`users`.`name` LIKE ?", []interface{}{"%hn"}},
+		{"name__iendswith", "HN", "LOWER(`users`.`name`) LIKE ?", []interface{}{"%hn"}},
+		{"age__gt", "18", "`users`.`age` > ?", []interface{}{int64(18)}},
+		{"age__gte", 18.0, "`users`.`age` >= ?", []interface{}{int64(18)}},
+		{"score__lt", "9.5", "`users`.`score` < ?", []interface{}{9.5}},
+		{"created_at__lte", "2024-01-02", "`users`.`created_at` <= ?", []interface{}{created}},
+		{"id__in", "1, 2", "`users`.`id` IN (?, ?)", []interface{}{int64(1), int64(2)}},
+		{"id__in", []int{3, 4}, "`users`.`id` IN (?, ?)", []interface{}{int64(3), int64(4)}},
+		{"nickname__isnull", "true", "`users`.`nickname` IS NULL", nil},
+		{"nickname__isnull", false, "`users`.`nickname` IS NOT NULL", nil},
+		{"is_active", "true", "`users`.`is_active`", nil},
+		{"is_active", "0", "NOT `users`.`is_active`", nil},
+	}
+
+	for _, tt := range tests {
+		predicate, err := builder.BuildLookup(tt.key, tt.value)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.key, err)
+			continue
+		}
+
+		query, args := predicateSQL(predicate)
+		if want := "SELECT * FROM `users` WHERE " + tt.where; query != want {
+			t.Errorf("%s: expected %q, got %q", tt.key, want, query)
+		}
+		if len(args) != len(tt.args) || (len(args) > 0 && !reflect.DeepEqual(args, tt.args)) {
+			t.Errorf("%s: expected args %v, got %v", tt.key, tt.args, args)
+		}
+	}
+}
+
+func TestEntPredicateBuilderErrors(t *testing.T) {
+	builder := newPredicateUserBuilder(t)
+
+	invalid := map[string]interface{}{
+		"unknown":          "x",
+		"groupID":          1,
+		"edges":            "x",
+		"age__gte":         "abc",
+		"is_active":        "maybe",
+		"created_at__gt":   "yesterday",
+		"nickname__isnull": "sometimes",
+	}
+	for key, value := range invalid {
+		if _, err := builder.BuildLookup(key, value); err == nil {
+			t.Errorf("Expected an error for %s=%v", key, value)
+		}
+	}
+
+	if _, err := NewEntPredicateBuilder("not a struct"); err == nil {
+		t.Error("Expected an error for a non-struct model")
+	}
+}
+
+func TestEntPredicateBuilderSearch(t *testing.T) {
+	builder := newPredicateUserBuilder(t)
+
+	predicates, err := EntPredicates[userPredicate](builder, map[string]interface{}{
+		"is_active": "1",
+		SearchFiltersKey: map[string]interface{}{
+			"name__icontains":     "jo",
+			"nickname__icontains": "jo",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to build predicates: %v", err)
+	}
+	if len(predicates) != 2 {
+		t.Fatalf("Expected 2 predicates, got %d", len(predicates))
+	}
+
+	plain := make([]func(*entsql.Selector), len(predicates))
+	for i, predicate := range predicates {
+		plain[i] = predicate
+	}
+	query, args := predicateSQL(plain...)
+
+	want := "SELECT * FROM `users` WHERE (LOWER(`users`.`name`) LIKE ? OR LOWER(`users`.`nickname`) LIKE ?) AND `users`.`is_active`"
+	if query != want {
+		t.Errorf("Expected %q, got %q", want, query)
+	}
+	if !reflect.DeepEqual(args, []interface{}{"%jo%", "%jo%"}) {
+		t.Errorf("Unexpected args: %v", args)
+	}
+}
@@ -0,0 +1,336 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TableSchema describes a table, either as the models declare it or, with
+// only column names, as it exists in the database
+type TableSchema struct {
+	Name    string
+	Columns []ColumnSchema
+}
+
+// ColumnSchema describes a column by its Ent field type, such as "string",
+// "int64", "time" or "enum", which is mapped to a SQL type per driver
+type ColumnSchema struct {
+	Name       string
+	Type       string
+	Size       int
+	Enum       []string
+	Nullable   bool
+	Unique     bool
+	PrimaryKey bool
+
+	// Default is a literal default value (string, bool, int64 or float64),
+	// or nil when the column has no default or it is computed in Go
+	Default interface{}
+}
+
+// AddedColumn is a column added to an existing table
+type AddedColumn struct {
+	Table  string
+	Column ColumnSchema
+}
+
+// SchemaDiff is the change from the database schema to the models' schema.
+// Only additions are detected: dropped or altered tables and columns are
+// left for hand-written migrations.
+type SchemaDiff struct {
+	AddedTables  []TableSchema
+	AddedColumns []AddedColumn
+}
+
+// InspectSchema returns the user tables of the database with their column
+// names, leaving out the framework's bookkeeping tables
+func InspectSchema(ctx context.Context, conn *Connection) ([]TableSchema, error) {
+	tables, err := listTables(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := make([]TableSchema, 0, len(tables))
+	for _, table := range tables {
+		var columns []string
+		switch conn.Driver() {
+		case DriverSQLite:
+			columns, err = queryStrings(ctx, conn, `SELECT name FROM pragma_table_info(?) ORDER BY cid`, table)
+		case DriverPostgres:
+			columns, err = queryStrings(ctx, conn, `
+				SELECT column_name FROM information_schema.columns
+				WHERE table_schema = current_schema() AND table_name = $1
+				ORDER BY ordinal_position`, table)
+		case DriverMySQL:
+			columns, err = queryStrings(ctx, conn, `
+				SELECT column_name FROM information_schema.columns
+				WHERE table_schema = DATABASE() AND table_name = ?
+				ORDER BY ordinal_position`, table)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+
+		tableSchema := TableSchema{Name: table}
+		for _, column := range columns {
+			tableSchema.Columns = append(tableSchema.Columns, ColumnSchema{Name: column})
+		}
+		schema = append(schema, tableSchema)
+	}
+	return schema, nil
+}
+
+// DiffSchema compares the current schema with the desired one, returning
+// the tables and columns the desired schema adds. Names are compared case
+// insensitively, as databases fold unquoted identifiers.
+func DiffSchema(current, desired []TableSchema) *SchemaDiff {
+	existing := make(map[string]map[string]bool, len(current))
+	for _, table := range current {
+		columns := make(map[string]bool, len(table.Columns))
+		for _, column := range table.Columns {
+			columns[strings.ToLower(column.Name)] = true
+		}
+		existing[strings.ToLower(table.Name)] = columns
+	}
+
+	diff := &SchemaDiff{}
+	for _, table := range desired {
+		columns, ok := existing[strings.ToLower(table.Name)]
+		if !ok {
+			diff.AddedTables = append(diff.AddedTables, table)
+			continue
+		}
+		for _, column := range table.Columns {
+			if !columns[strings.ToLower(column.Name)] {
+				diff.AddedColumns = append(diff.AddedColumns, AddedColumn{Table: table.Name, Column: column})
+			}
+		}
+	}
+	return diff
+}
+
+// Empty reports whether the diff has no changes
+func (d *SchemaDiff) Empty() bool {
+	return len(d.AddedTables) == 0 && len(d.AddedColumns) == 0
+}
+
+// Summary describes each change on its own line
+func (d *SchemaDiff) Summary() []string {
+	var lines []string
+	for _, table := range d.AddedTables {
+		lines = append(lines, fmt.Sprintf("Create table %s", table.Name))
+	}
+	for _, added := range d.AddedColumns {
+		lines = append(lines, fmt.Sprintf("Add column %s.%s", added.Table, added.Column.Name))
+	}
+	return lines
+}
+
+// SQL returns the statements applying the diff and rolling it back, in
+// reverse order, for a driver. Required columns added to existing tables
+// without a default are added as nullable, since existing rows have no
+// value for them.
+func (d *SchemaDiff) SQL(driver Driver) (string, string, error) {
+	var up, down []string
+
+	for _, table := range d.AddedTables {
+		if !identifierPattern.MatchString(table.Name) {
+			return "", "", fmt.Errorf("invalid table name: %q", table.Name)
+		}
+		definitions := make([]string, 0, len(table.Columns))
+		for _, column := range table.Columns {
+			definition, err := columnDefinition(driver, column)
+			if err != nil {
+				return "", "", fmt.Errorf("table %s: %w", table.Name, err)
+			}
+			if column.Unique && !column.PrimaryKey {
+				definition += " UNIQUE"
+			}
+			definitions = append(definitions, definition)
+		}
+		up = append(up, fmt.Sprintf("CREATE TABLE %s (\n    %s\n);", table.Name, strings.Join(definitions, ",\n    ")))
+		down = append([]string{fmt.Sprintf("DROP TABLE IF EXISTS %s;", table.Name)}, down...)
+	}
+
+	for _, added := range d.AddedColumns {
+		if !identifierPattern.MatchString(added.Table) {
+			return "", "", fmt.Errorf("invalid table name: %q", added.Table)
+		}
+		column := added.Column
+		if !column.Nullable && column.Default == nil {
+			up = append(up, fmt.Sprintf("-- %s.%s is required: fill it in for existing rows, then make it NOT NULL", added.Table, column.Name))
+			column.Nullable = true
+		}
+		definition, err := columnDefinition(driver, column)
+		if err != nil {
+			return "", "", fmt.Errorf("table %s: %w", added.Table, err)
+		}
+		up = append(up, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;", added.Table, definition))
+
+		drop := []string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", added.Table, column.Name)}
+		if column.Unique {
+			index := added.Table + "_" + column.Name + "_key"
+			up = append(up, fmt.Sprintf("CREATE UNIQUE INDEX %s ON %s (%s);", index, added.Table, column.Name))
+			if driver == DriverMySQL {
+				drop = append([]string{fmt.Sprintf("DROP INDEX %s ON %s;", index, added.Table)}, drop...)
+			} else {
+				drop = append([]string{fmt.Sprintf("DROP INDEX IF EXISTS %s;", index)}, drop...)
+			}
+		}
+		down = append(drop, down...)
+	}
+
+	return strings.Join(up, "\n\n") + "\n", strings.Join(down, "\n") + "\n", nil
+}
+
+// columnDefinition renders a column for CREATE TABLE or ADD COLUMN
+func columnDefinition(driver Driver, column ColumnSchema) (string, error) {
+	if !identifierPattern.MatchString(column.Name) {
+		return "", fmt.Errorf("invalid column name: %q", column.Name)
+	}
+
+	if column.PrimaryKey && isIntegerType(column.Type) {
+		switch driver {
+		case DriverSQLite:
+			return column.Name + " INTEGER PRIMARY KEY AUTOINCREMENT", nil
+		case DriverPostgres:
+			return column.Name + " BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY", nil
+		case DriverMySQL:
+			return column.Name + " BIGINT AUTO_INCREMENT PRIMARY KEY", nil
+		}
+	}
+
+	sqlType, err := columnType(driver, column)
+	if err != nil {
+		return "", fmt.Errorf("column %s: %w", column.Name, err)
+	}
+	definition := column.Name + " " + sqlType
+	if column.PrimaryKey {
+		definition += " PRIMARY KEY"
+	} else if !column.Nullable {
+		definition += " NOT NULL"
+	}
+	if column.Default != nil {
+		definition += " DEFAULT " + sqlLiteral(column.Default)
+	}
+	return definition, nil
+}
+
+// columnType maps an Ent field type to a SQL column type
+func columnType(driver Driver, column ColumnSchema) (string, error) {
+	switch driver {
+	case DriverSQLite:
+		switch column.Type {
+		case "string", "enum", "uuid":
+			return "TEXT", nil
+		case "bool":
+			return "BOOLEAN", nil
+		case "time":
+			return "DATETIME", nil
+		case "float32", "float64":
+			return "REAL", nil
+		case "bytes":
+			return "BLOB", nil
+		case "json":
+			return "JSON", nil
+		}
+		if isIntegerType(column.Type) {
+			return "INTEGER", nil
+		}
+	case DriverPostgres:
+		switch column.Type {
+		case "string", "enum":
+			if column.Size > 0 {
+				return fmt.Sprintf("VARCHAR(%d)", column.Size), nil
+			}
+			return "VARCHAR", nil
+		case "uuid":
+			return "UUID", nil
+		case "bool":
+			return "BOOLEAN", nil
+		case "time":
+			return "TIMESTAMP WITH TIME ZONE", nil
+		case "float32":
+			return "REAL", nil
+		case "float64":
+			return "DOUBLE PRECISION", nil
+		case "bytes":
+			return "BYTEA", nil
+		case "json":
+			return "JSONB", nil
+		case "int8", "int16", "uint8":
+			return "SMALLINT", nil
+		case "int32", "uint16":
+			return "INTEGER", nil
+		}
+		if isIntegerType(column.Type) {
+			return "BIGINT", nil
+		}
+	case DriverMySQL:
+		switch column.Type {
+		case "string":
+			size := column.Size
+			if size == 0 {
+				size = 255
+			}
+			return fmt.Sprintf("VARCHAR(%d)", size), nil
+		case "enum":
+			values := make([]string, len(column.Enum))
+			for i, value := range column.Enum {
+				values[i] = sqlLiteral(value)
+			}
+			return "ENUM(" + strings.Join(values, ", ") + ")", nil
+		case "uuid":
+			return "CHAR(36)", nil
+		case "bool":
+			return "BOOLEAN", nil
+		case "time":
+			return "TIMESTAMP", nil
+		case "float32":
+			return "FLOAT", nil
+		case "float64":
+			return "DOUBLE", nil
+		case "bytes":
+			return "BLOB", nil
+		case "json":
+			return "JSON", nil
+		case "int8", "uint8":
+			return "TINYINT", nil
+		case "int16", "uint16":
+			return "SMALLINT", nil
+		case "int32", "uint32":
+			return "INT", nil
+		}
+		if isIntegerType(column.Type) {
+			return "BIGINT", nil
+		}
+	default:
+		return "", fmt.Errorf("unsupported database driver: %s", driver)
+	}
+	return "", fmt.Errorf("unsupported field type: %s", column.Type)
+}
+
+// isIntegerType reports whether an Ent field type is an integer
+func isIntegerType(fieldType string) bool {
+	switch fieldType {
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+// sqlLiteral renders a default value as a SQL literal
+func sqlLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// blogSchema returns the tables of a blog before and after adding a
+// tags table and a column to posts
+func blogSchema() (before, after []TableSchema) {
+	posts := TableSchema{Name: "posts", Columns: []ColumnSchema{
+		{Name: "id", Type: "int", PrimaryKey: true},
+		{Name: "title", Type: "string", Size: 200},
+	}}
+	before = []TableSchema{posts}
+
+	postsAfter := posts
+	postsAfter.Columns = append(append([]ColumnSchema{}, posts.Columns...),
+		ColumnSchema{Name: "status", Type: "enum", Enum: []string{"draft", "published"}, Default: "draft"},
+		ColumnSchema{Name: "slug", Type: "string", Unique: true},
+	)
+	tags := TableSchema{Name: "tags", Columns: []ColumnSchema{
+		{Name: "id", Type: "int", PrimaryKey: true},
+		{Name: "name", Type: "string", Unique: true},
+		{Name: "weight", Type: "float64", Nullable: true},
+	}}
+	after = []TableSchema{postsAfter, tags}
+	return before, after
+}
+
+func TestDiffSchema(t *testing.T) {
+	before, after := blogSchema()
+
+	diff := DiffSchema(before, after)
+	if len(diff.AddedTables) != 1 || diff.AddedTables[0].Name != "tags" {
+		t.Errorf("Expected the tags table to be added, got: %+v", diff.AddedTables)
+	}
+	var added []string
+	for _, column := range diff.AddedColumns {
+		added = append(added, column.Table+"."+column.Column.Name)
+	}
+	if expected := []string{"posts.status", "posts.slug"}; !reflect.DeepEqual(added, expected) {
+		t.Errorf("Expected added columns %v, got: %v", expected, added)
+	}
+
+	expectedSummary := []string{"Create table tags", "Add column posts.status", "Add column posts.slug"}
+	if summary := diff.Summary(); !reflect.DeepEqual(summary, expectedSummary) {
+		t.Errorf("Expected summary %v, got: %v", expectedSummary, summary)
+	}
+
+	// Names are compared case insensitively
+	if diff := DiffSchema([]TableSchema{{Name: "POSTS", Columns: []ColumnSchema{{Name: "ID"}, {Name: "Title"}}}}, before); !diff.Empty() {
+		t.Errorf("Expected no changes, got: %v", diff.Summary())
+	}
+}
+
+func TestSchemaDiffSQL(t *testing.T) {
+	before, after := blogSchema()
+	diff := DiffSchema(before, after)
+
+	up, down, err := diff.SQL(DriverPostgres)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"CREATE TABLE tags (\n    id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY,\n    name VARCHAR NOT NULL UNIQUE,\n    weight DOUBLE PRECISION\n);",
+		"ALTER TABLE posts ADD COLUMN status VARCHAR NOT NULL DEFAULT 'draft';",
+		"-- posts.slug is required",
+		"ALTER TABLE posts ADD COLUMN slug VARCHAR;",
+		"CREATE UNIQUE INDEX posts_slug_key ON posts (slug);",
+	} {
+		if !strings.Contains(up, expected) {
+			t.Errorf("Expected the up SQL to contain %q, got:\n%s", expected, up)
+		}
+	}
+	expectedDown := "DROP INDEX IF EXISTS posts_slug_key;\nALTER TABLE posts DROP COLUMN slug;\nALTER TABLE posts DROP COLUMN status;\nDROP TABLE IF EXISTS tags;\n"
+	if down != expectedDown {
+		t.Errorf("Expected down SQL:\n%s\ngot:\n%s", expectedDown, down)
+	}
+
+	mysqlUp, _, err := diff.SQL(DriverMySQL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(mysqlUp, "status ENUM('draft', 'published') NOT NULL DEFAULT 'draft'") {
+		t.Errorf("Expected a MySQL enum column, got:\n%s", mysqlUp)
+	}
+
+	invalid := &SchemaDiff{AddedTables: []TableSchema{{Name: "posts; DROP TABLE users"}}}
+	if _, _, err := invalid.SQL(DriverSQLite); err == nil {
+		t.Error("Expected an error for an invalid table name")
+	}
+	unknown := &SchemaDiff{AddedTables: []TableSchema{{Name: "posts", Columns: []ColumnSchema{{Name: "price", Type: "decimal"}}}}}
+	if _, _, err := unknown.SQL(DriverSQLite); err == nil {
+		t.Error("Expected an error for an unsupported field type")
+	}
+}
+
+func TestSchemaDiffAppliesToSQLite(t *testing.T) {
+	ctx := context.Background()
+	conn, err := Open(SQLiteConfig(filepath.Join(t.TempDir(), "schema.db")))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	before, after := blogSchema()
+	initial, _, err := DiffSchema(nil, before).SQL(DriverSQLite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := conn.DB().ExecContext(ctx, initial); err != nil {
+		t.Fatalf("Failed to create the initial schema: %v\n%s", err, initial)
+	}
+	if _, err := conn.DB().ExecContext(ctx, `INSERT INTO posts (title) VALUES ('Hello')`); err != nil {
+		t.Fatalf("Failed to insert a post: %v", err)
+	}
+
+	current, err := InspectSchema(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to inspect schema: %v", err)
+	}
+	diff := DiffSchema(current, after)
+	up, down, err := diff.SQL(DriverSQLite)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := conn.DB().ExecContext(ctx, up); err != nil {
+		t.Fatalf("Failed to apply the migration to a table with rows: %v\n%s", err, up)
+	}
+
+	current, err = InspectSchema(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to inspect schema: %v", err)
+	}
+	if diff := DiffSchema(current, after); !diff.Empty() {
+		t.Errorf("Expected no changes after migrating, got: %v", diff.Summary())
+	}
+
+	if _, err := conn.DB().ExecContext(ctx, down); err != nil {
+		t.Fatalf("Failed to roll back the migration: %v\n%s", err, down)
+	}
+	current, err = InspectSchema(ctx, conn)
+	if err != nil {
+		t.Fatalf("Failed to inspect schema: %v", err)
+	}
+	if diff := DiffSchema(current, before); !diff.Empty() || len(current) != 1 || len(current[0].Columns) != 2 {
+		t.Errorf("Expected the initial schema after rolling back, got: %+v", current)
+	}
+}
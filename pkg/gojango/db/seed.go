@@ -0,0 +1,258 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SeedFunc populates the database with data. Seeders are written in Go so that
+// complex seed logic (relationships, generated data) can be expressed in code.
+// Each seeder runs in its own transaction, committed together with the record
+// of the seeder having been applied.
+type SeedFunc func(ctx context.Context, tx *sql.Tx) error
+
+// Seeder is a named seed function registered with the framework
+type Seeder struct {
+	Name string
+	Fn   SeedFunc
+}
+
+// SeederRegistry holds registered seeders in registration order
+type SeederRegistry struct {
+	mu      sync.RWMutex
+	seeders map[string]Seeder
+	order   []string
+}
+
+// NewSeederRegistry creates an empty seeder registry
+func NewSeederRegistry() *SeederRegistry {
+	return &SeederRegistry{
+		seeders: make(map[string]Seeder),
+		order:   make([]string, 0),
+	}
+}
+
+// Register adds a seeder to the registry
+func (r *SeederRegistry) Register(name string, fn SeedFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name == "" {
+		panic("seeder name cannot be empty")
+	}
+	if fn == nil {
+		panic(fmt.Sprintf("seeder %s has no seed function", name))
+	}
+	if _, exists := r.seeders[name]; exists {
+		panic(fmt.Sprintf("seeder %s is already registered", name))
+	}
+
+	r.seeders[name] = Seeder{Name: name, Fn: fn}
+	r.order = append(r.order, name)
+}
+
+// Get returns a seeder by name
+func (r *SeederRegistry) Get(name string) (Seeder, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seeder, exists := r.seeders[name]
+	return seeder, exists
+}
+
+// All returns all seeders in registration order
+func (r *SeederRegistry) All() []Seeder {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seeders := make([]Seeder, 0, len(r.order))
+	for _, name := range r.order {
+		seeders = append(seeders, r.seeders[name])
+	}
+	return seeders
+}
+
+// Names returns the names of all seeders in registration order
+func (r *SeederRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
+
+var defaultSeeders = NewSeederRegistry()
+
+// RegisterSeeder registers a seeder with the global seeder registry.
+// Typically called from an app's init function.
+func RegisterSeeder(name string, fn SeedFunc) {
+	defaultSeeders.Register(name, fn)
+}
+
+// GetSeeders returns the global seeder registry
+func GetSeeders() *SeederRegistry {
+	return defaultSeeders
+}
+
+// SeedRunner runs seeders against a connection and records which ones have
+// already been applied so that running them again is a no-op
+type SeedRunner struct {
+	conn      *Connection
+	registry  *SeederRegistry
+	tableName string
+}
+
+// NewSeedRunner creates a seed runner for the given connection and registry
+func NewSeedRunner(conn *Connection, registry *SeederRegistry) *SeedRunner {
+	if registry == nil {
+		registry = defaultSeeders
+	}
+
+	return &SeedRunner{
+		conn:      conn,
+		registry:  registry,
+		tableName: "gojango_seeds",
+	}
+}
+
+// SetSeedsTable sets a custom seeds table name
+func (s *SeedRunner) SetSeedsTable(tableName string) {
+	s.tableName = tableName
+}
+
+// Initialize creates the seeds table if it doesn't exist
+func (s *SeedRunner) Initialize(ctx context.Context) error {
+	var createTableSQL string
+
+	switch s.conn.Driver() {
+	case DriverPostgres:
+		createTableSQL = fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				name VARCHAR(255) PRIMARY KEY,
+				applied_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+			);
+		`, s.tableName)
+	case DriverSQLite:
+		createTableSQL = fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				name TEXT PRIMARY KEY,
+				applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+			);
+		`, s.tableName)
+	case DriverMySQL:
+		createTableSQL = fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				name VARCHAR(255) PRIMARY KEY,
+				applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+			);
+		`, s.tableName)
+	default:
+		return fmt.Errorf("unsupported database driver: %s", s.conn.Driver())
+	}
+
+	if _, err := s.conn.DB().ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("failed to create seeds table: %w", err)
+	}
+
+	return nil
+}
+
+// IsApplied reports whether the named seeder has already been run
+func (s *SeedRunner) IsApplied(ctx context.Context, name string) (bool, error) {
+	query := s.placeholders(fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE name = $1`, s.tableName))
+
+	var count int
+	if err := s.conn.DB().QueryRowContext(ctx, query, name).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check seed status: %w", err)
+	}
+	return count > 0, nil
+}
+
+// Run runs the named seeders, or every registered seeder when no names are
+// given. Seeders that have already been applied are skipped. It returns the
+// names of the seeders that were actually run.
+func (s *SeedRunner) Run(ctx context.Context, names ...string) ([]string, error) {
+	if err := s.Initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	var seeders []Seeder
+	if len(names) == 0 {
+		seeders = s.registry.All()
+	} else {
+		for _, name := range names {
+			seeder, exists := s.registry.Get(name)
+			if !exists {
+				return nil, fmt.Errorf("seeder not found: %s", name)
+			}
+			seeders = append(seeders, seeder)
+		}
+	}
+
+	var ran []string
+	for _, seeder := range seeders {
+		applied, err := s.IsApplied(ctx, seeder.Name)
+		if err != nil {
+			return ran, err
+		}
+		if applied {
+			log.Printf("Seeder already applied, skipping: %s", seeder.Name)
+			continue
+		}
+
+		if err := s.apply(ctx, seeder); err != nil {
+			return ran, err
+		}
+
+		log.Printf("Applied seeder: %s", seeder.Name)
+		ran = append(ran, seeder.Name)
+	}
+
+	return ran, nil
+}
+
+// apply runs a seeder and records it in one transaction, so a failing seeder
+// leaves neither its data nor a record behind
+func (s *SeedRunner) apply(ctx context.Context, seeder Seeder) error {
+	tx, err := s.conn.DB().BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := seeder.Fn(ctx, tx); err != nil {
+		return fmt.Errorf("seeder %s failed: %w", seeder.Name, err)
+	}
+
+	insertQuery := s.placeholders(fmt.Sprintf(`INSERT INTO %s (name, applied_at) VALUES ($1, $2)`, s.tableName))
+	if _, err := tx.ExecContext(ctx, insertQuery, seeder.Name, time.Now()); err != nil {
+		return fmt.Errorf("failed to record seeder %s: %w", seeder.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit seeder %s: %w", seeder.Name, err)
+	}
+	return nil
+}
+
+// placeholders adjusts $n placeholders for drivers that use ?
+func (s *SeedRunner) placeholders(query string) string {
+	switch s.conn.Driver() {
+	case DriverMySQL, DriverSQLite:
+		query = strings.Replace(query, "$1", "?", -1)
+		query = strings.Replace(query, "$2", "?", -1)
+	}
+	return query
+}
+
+// RunSeeders runs globally registered seeders against a connection. It backs
+// the `seed [name]` management command.
+func RunSeeders(ctx context.Context, conn *Connection, names ...string) ([]string, error) {
+	return NewSeedRunner(conn, nil).Run(ctx, names...)
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func setupSeedConnection(t *testing.T) *Connection {
+	conn, err := Open(SQLiteConfig(filepath.Join(t.TempDir(), "seed.db")))
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	_, err = conn.DB().Exec(`CREATE TABLE authors (id INTEGER PRIMARY KEY, name TEXT NOT NULL)`)
+	if err != nil {
+		t.Fatalf("Failed to create authors table: %v", err)
+	}
+	return conn
+}
+
+func countRows(t *testing.T, conn *Connection, table string) int {
+	var count int
+	if err := conn.DB().QueryRow("SELECT COUNT(*) FROM " + table).Scan(&count); err != nil {
+		t.Fatalf("Failed to count rows in %s: %v", table, err)
+	}
+	return count
+}
+
+func TestRunSeedersCommandPath(t *testing.T) {
+	conn := setupSeedConnection(t)
+	ctx := context.Background()
+
+	RegisterSeeder("test_authors", func(ctx context.Context, tx *sql.Tx) error {
+		for _, name := range []string{"Ada", "Grace", "Linus"} {
+			if _, err := tx.ExecContext(ctx, "INSERT INTO authors (name) VALUES (?)", name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	ran, err := RunSeeders(ctx, conn, "test_authors")
+	if err != nil {
+		t.Fatalf("Failed to run seeders: %v", err)
+	}
+	if len(ran) != 1 || ran[0] != "test_authors" {
+		t.Errorf("Expected test_authors to run, got: %v", ran)
+	}
+	if count := countRows(t, conn, "authors"); count != 3 {
+		t.Errorf("Expected 3 authors after seeding, got: %d", count)
+	}
+
+	// Running the same seeder again must not insert duplicate rows
+	ran, err = RunSeeders(ctx, conn, "test_authors")
+	if err != nil {
+		t.Fatalf("Failed to re-run seeders: %v", err)
+	}
+	if len(ran) != 0 {
+		t.Errorf("Expected no seeders to run the second time, got: %v", ran)
+	}
+	if count := countRows(t, conn, "authors"); count != 3 {
+		t.Errorf("Expected 3 authors after re-seeding, got: %d", count)
+	}
+}
+
+func TestSeedRunnerRunAll(t *testing.T) {
+	conn := setupSeedConnection(t)
+	ctx := context.Background()
+
+	registry := NewSeederRegistry()
+	var order []string
+	registry.Register("first", func(ctx context.Context, tx *sql.Tx) error {
+		order = append(order, "first")
+		_, err := tx.ExecContext(ctx, "INSERT INTO authors (name) VALUES ('first')")
+		return err
+	})
+	registry.Register("second", func(ctx context.Context, tx *sql.Tx) error {
+		order = append(order, "second")
+		_, err := tx.ExecContext(ctx, "INSERT INTO authors (name) VALUES ('second')")
+		return err
+	})
+
+	runner := NewSeedRunner(conn, registry)
+	if _, err := runner.Run(ctx); err != nil {
+		t.Fatalf("Failed to run all seeders: %v", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("Expected seeders to run in registration order, got: %v", order)
+	}
+
+	if _, err := runner.Run(ctx); err != nil {
+		t.Fatalf("Failed to re-run all seeders: %v", err)
+	}
+	if count := countRows(t, conn, "authors"); count != 2 {
+		t.Errorf("Expected 2 authors after running twice, got: %d", count)
+	}
+
+	if _, err := runner.Run(ctx, "missing"); err == nil {
+		t.Error("Expected error for unknown seeder")
+	}
+}
+
+func TestSeedRunnerRollsBackFailedSeeder(t *testing.T) {
+	conn := setupSeedConnection(t)
+	ctx := context.Background()
+
+	registry := NewSeederRegistry()
+	registry.Register("broken", func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO authors (name) VALUES ('partial')"); err != nil {
+			return err
+		}
+		return errors.New("boom")
+	})
+
+	runner := NewSeedRunner(conn, registry)
+	if _, err := runner.Run(ctx); err == nil {
+		t.Fatal("Expected the failing seeder to return an error")
+	}
+	if count := countRows(t, conn, "authors"); count != 0 {
+		t.Errorf("Expected the failed seeder's rows to be rolled back, got: %d", count)
+	}
+	if applied, err := runner.IsApplied(ctx, "broken"); err != nil || applied {
+		t.Errorf("Expected the failed seeder not to be recorded, got: %v %v", applied, err)
+	}
+}
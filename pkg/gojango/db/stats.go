@@ -0,0 +1,77 @@
+package db
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ConnectionStats summarizes a connection's pool usage
+type ConnectionStats struct {
+	Driver             Driver `json:"driver"`
+	Role               string `json:"role"` // "primary" or "replica"
+	Default            bool   `json:"default"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	MaxIdleConnections int    `json:"max_idle_connections"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDurationMs     int64  `json:"wait_duration_ms"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+// PoolStats is the pool usage of every connection of a Manager
+type PoolStats struct {
+	Default     string                     `json:"default"`
+	Connections map[string]ConnectionStats `json:"connections"`
+}
+
+// Stats returns the pool usage of every managed connection
+func (m *Manager) Stats() PoolStats {
+	stats := PoolStats{
+		Default:     m.defaultConn,
+		Connections: make(map[string]ConnectionStats, len(m.connections)),
+	}
+
+	for _, name := range m.ListConnections() {
+		conn := m.connections[name]
+		dbStats := conn.Stats()
+
+		role := "primary"
+		if m.isReplica(name) {
+			role = "replica"
+		}
+
+		stats.Connections[name] = ConnectionStats{
+			Driver:             conn.Driver(),
+			Role:               role,
+			Default:            name == m.defaultConn,
+			MaxOpenConnections: dbStats.MaxOpenConnections,
+			MaxIdleConnections: conn.Config().MaxIdleConns,
+			OpenConnections:    dbStats.OpenConnections,
+			InUse:              dbStats.InUse,
+			Idle:               dbStats.Idle,
+			WaitCount:          dbStats.WaitCount,
+			WaitDurationMs:     dbStats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:      dbStats.MaxIdleClosed,
+			MaxIdleTimeClosed:  dbStats.MaxIdleTimeClosed,
+			MaxLifetimeClosed:  dbStats.MaxLifetimeClosed,
+		}
+	}
+
+	return stats
+}
+
+// StatsHandler serves the manager's pool statistics as JSON, for diagnosing
+// pool exhaustion under load. Mount it on a protected route, e.g.
+//
+//	router.GET("/debug/db", db.StatsHandler(manager))
+func StatsHandler(mgr *Manager) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, mgr.Stats())
+	}
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStatsHandler(t *testing.T) {
+	manager := NewManager()
+	defer manager.CloseAll()
+
+	if err := manager.AddConnection("primary", SQLiteConfig(":memory:")); err != nil {
+		t.Fatalf("Failed to add primary connection: %v", err)
+	}
+	if err := manager.AddReplica("replica", SQLiteConfig(filepath.Join(t.TempDir(), "replica.db"))); err != nil {
+		t.Fatalf("Failed to add replica connection: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/debug/db", StatsHandler(manager))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/debug/db", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Expected JSON response: %v", err)
+	}
+
+	if body["default"] != "primary" {
+		t.Errorf("Expected default connection 'primary', got %v", body["default"])
+	}
+
+	connections, ok := body["connections"].(map[string]interface{})
+	if !ok || len(connections) != 2 {
+		t.Fatalf("Expected stats for 2 connections, got %v", body["connections"])
+	}
+
+	for _, name := range []string{"primary", "replica"} {
+		stats, ok := connections[name].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected stats for %s", name)
+		}
+		for _, key := range []string{"driver", "role", "max_open_connections", "open_connections", "in_use", "idle", "wait_count", "wait_duration_ms"} {
+			if _, exists := stats[key]; !exists {
+				t.Errorf("Expected %s stats to include %s", name, key)
+			}
+		}
+		if stats["driver"] != "sqlite3" {
+			t.Errorf("Expected driver sqlite3 for %s, got %v", name, stats["driver"])
+		}
+		if stats["max_open_connections"] != float64(1) {
+			t.Errorf("Expected max_open_connections 1 for %s, got %v", name, stats["max_open_connections"])
+		}
+	}
+
+	primary := connections["primary"].(map[string]interface{})
+	replica := connections["replica"].(map[string]interface{})
+	if primary["role"] != "primary" || primary["default"] != true {
+		t.Errorf("Expected primary to be the default primary connection, got %v", primary)
+	}
+	if replica["role"] != "replica" || replica["default"] != false {
+		t.Errorf("Expected replica role for the replica connection, got %v", replica)
+	}
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"strings"
+)
+
+// Field types returned by InferFieldType. These match the field type
+// vocabulary used by the admin widgets (see widgets.GetWidgetForType).
+const (
+	FieldTypeString   = "string"
+	FieldTypeText     = "text"
+	FieldTypeInteger  = "integer"
+	FieldTypeFloat    = "float"
+	FieldTypeBoolean  = "boolean"
+	FieldTypeDate     = "date"
+	FieldTypeDateTime = "datetime"
+	FieldTypeFile     = "file"
+	FieldTypeArray    = "array"
+)
+
+// sqlTypeNames maps normalized SQL type names from SQLite, Postgres and
+// MySQL to field types
+var sqlTypeNames = map[string]string{
+	// Integers
+	"INTEGER":     FieldTypeInteger,
+	"INT":         FieldTypeInteger,
+	"INT2":        FieldTypeInteger,
+	"INT4":        FieldTypeInteger,
+	"INT8":        FieldTypeInteger,
+	"TINYINT":     FieldTypeInteger,
+	"SMALLINT":    FieldTypeInteger,
+	"MEDIUMINT":   FieldTypeInteger,
+	"BIGINT":      FieldTypeInteger,
+	"SERIAL":      FieldTypeInteger,
+	"SMALLSERIAL": FieldTypeInteger,
+	"BIGSERIAL":   FieldTypeInteger,
+	"YEAR":        FieldTypeInteger,
+
+	// Floating point and fixed precision
+	"REAL":             FieldTypeFloat,
+	"FLOAT":            FieldTypeFloat,
+	"FLOAT4":           FieldTypeFloat,
+	"FLOAT8":           FieldTypeFloat,
+	"DOUBLE":           FieldTypeFloat,
+	"DOUBLE PRECISION": FieldTypeFloat,
+	"NUMERIC":          FieldTypeFloat,
+	"DECIMAL":          FieldTypeFloat,
+	"MONEY":            FieldTypeFloat,
+
+	// Booleans
+	"BOOLEAN": FieldTypeBoolean,
+	"BOOL":    FieldTypeBoolean,
+
+	// Dates and times
+	"DATE":                        FieldTypeDate,
+	"DATETIME":                    FieldTypeDateTime,
+	"TIMESTAMP":                   FieldTypeDateTime,
+	"TIMESTAMPTZ":                 FieldTypeDateTime,
+	"TIMESTAMP WITH TIME ZONE":    FieldTypeDateTime,
+	"TIMESTAMP WITHOUT TIME ZONE": FieldTypeDateTime,
+
+	// Short strings
+	"VARCHAR":           FieldTypeString,
+	"CHARACTER VARYING": FieldTypeString,
+	"NVARCHAR":          FieldTypeString,
+	"CHAR":              FieldTypeString,
+	"CHARACTER":         FieldTypeString,
+	"NCHAR":             FieldTypeString,
+	"UUID":              FieldTypeString,
+	"ENUM":              FieldTypeString,
+	"TIME":              FieldTypeString,
+	"INET":              FieldTypeString,
+
+	// Long text
+	"TEXT":       FieldTypeText,
+	"TINYTEXT":   FieldTypeText,
+	"MEDIUMTEXT": FieldTypeText,
+	"LONGTEXT":   FieldTypeText,
+	"CLOB":       FieldTypeText,
+	"JSON":       FieldTypeText,
+	"JSONB":      FieldTypeText,
+
+	// Binary data
+	"BLOB":       FieldTypeFile,
+	"TINYBLOB":   FieldTypeFile,
+	"MEDIUMBLOB": FieldTypeFile,
+	"LONGBLOB":   FieldTypeFile,
+	"BYTEA":      FieldTypeFile,
+	"BINARY":     FieldTypeFile,
+	"VARBINARY":  FieldTypeFile,
+}
+
+// InferFieldType maps a column type as reported by SQLite, Postgres or MySQL
+// (e.g. "VARCHAR(255)", "int(11) unsigned", "INTEGER NOT NULL") to an admin
+// field type. Types that are not recognized fall back to SQLite's type
+// affinity rules.
+func InferFieldType(sqlType string) string {
+	typeName := strings.ToUpper(strings.TrimSpace(sqlType))
+
+	// Postgres arrays, e.g. "TEXT[]"
+	if strings.HasSuffix(typeName, "[]") {
+		return FieldTypeArray
+	}
+
+	// Nullability and sign modifiers don't affect the field type
+	typeName = strings.TrimSuffix(typeName, " NOT NULL")
+	typeName = strings.TrimSuffix(typeName, " NULL")
+	typeName = strings.TrimSuffix(typeName, " UNSIGNED")
+
+	// MySQL uses TINYINT(1) and BIT(1) for booleans
+	compact := strings.ReplaceAll(typeName, " ", "")
+	if compact == "TINYINT(1)" || compact == "BIT(1)" {
+		return FieldTypeBoolean
+	}
+
+	// Strip length and precision qualifiers, e.g. "VARCHAR(255)", "DECIMAL(10, 2)"
+	if idx := strings.Index(typeName, "("); idx >= 0 {
+		suffix := ""
+		if end := strings.Index(typeName[idx:], ")"); end >= 0 {
+			suffix = typeName[idx+end+1:]
+		}
+		typeName = strings.TrimSpace(typeName[:idx]) + suffix
+	}
+	typeName = strings.Join(strings.Fields(typeName), " ")
+
+	if fieldType, exists := sqlTypeNames[typeName]; exists {
+		return fieldType
+	}
+
+	return inferFieldTypeFromAffinity(typeName)
+}
+
+// inferFieldTypeFromAffinity applies SQLite's column affinity rules
+// (https://www.sqlite.org/datatype3.html#determination_of_column_affinity)
+func inferFieldTypeFromAffinity(typeName string) string {
+	switch {
+	case typeName == "":
+		return FieldTypeString
+	case strings.Contains(typeName, "INT"):
+		return FieldTypeInteger
+	case strings.Contains(typeName, "CHAR"):
+		return FieldTypeString
+	case strings.Contains(typeName, "CLOB"), strings.Contains(typeName, "TEXT"):
+		return FieldTypeText
+	case strings.Contains(typeName, "BLOB"):
+		return FieldTypeFile
+	case strings.Contains(typeName, "REAL"), strings.Contains(typeName, "FLOA"), strings.Contains(typeName, "DOUB"):
+		return FieldTypeFloat
+	case strings.Contains(typeName, "BOOL"):
+		return FieldTypeBoolean
+	case strings.Contains(typeName, "TIMESTAMP"), strings.Contains(typeName, "DATETIME"):
+		return FieldTypeDateTime
+	case strings.Contains(typeName, "DATE"):
+		return FieldTypeDate
+	default:
+		// NUMERIC affinity
+		return FieldTypeFloat
+	}
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"testing"
+)
+
+func TestInferFieldType(t *testing.T) {
+	tests := []struct {
+		driver   Driver
+		sqlType  string
+		expected string
+	}{
+		// SQLite
+		{DriverSQLite, "INTEGER", FieldTypeInteger},
+		{DriverSQLite, "integer NOT NULL", FieldTypeInteger},
+		{DriverSQLite, "REAL", FieldTypeFloat},
+		{DriverSQLite, "TEXT", FieldTypeText},
+		{DriverSQLite, "BLOB", FieldTypeFile},
+		{DriverSQLite, "NUMERIC", FieldTypeFloat},
+		{DriverSQLite, "DATETIME", FieldTypeDateTime},
+		{DriverSQLite, "DATE", FieldTypeDate},
+		{DriverSQLite, "BOOLEAN", FieldTypeBoolean},
+		{DriverSQLite, "bool NULL", FieldTypeBoolean},
+		{DriverSQLite, "VARCHAR(255)", FieldTypeString},
+		{DriverSQLite, "", FieldTypeString},
+		{DriverSQLite, "UNSIGNED BIG INT", FieldTypeInteger},
+		{DriverSQLite, "NATIVE CHARACTER(70)", FieldTypeString},
+		{DriverSQLite, "DOUBLE PRECISION", FieldTypeFloat},
+
+		// Postgres
+		{DriverPostgres, "character varying(255)", FieldTypeString},
+		{DriverPostgres, "bigint", FieldTypeInteger},
+		{DriverPostgres, "serial", FieldTypeInteger},
+		{DriverPostgres, "numeric(10, 2)", FieldTypeFloat},
+		{DriverPostgres, "boolean", FieldTypeBoolean},
+		{DriverPostgres, "timestamp with time zone", FieldTypeDateTime},
+		{DriverPostgres, "timestamp(6) without time zone", FieldTypeDateTime},
+		{DriverPostgres, "timestamptz", FieldTypeDateTime},
+		{DriverPostgres, "jsonb", FieldTypeText},
+		{DriverPostgres, "bytea", FieldTypeFile},
+		{DriverPostgres, "uuid", FieldTypeString},
+		{DriverPostgres, "text[]", FieldTypeArray},
+
+		// MySQL
+		{DriverMySQL, "int(11)", FieldTypeInteger},
+		{DriverMySQL, "int(10) unsigned", FieldTypeInteger},
+		{DriverMySQL, "tinyint(1)", FieldTypeBoolean},
+		{DriverMySQL, "tinyint(4)", FieldTypeInteger},
+		{DriverMySQL, "varchar(191)", FieldTypeString},
+		{DriverMySQL, "longtext", FieldTypeText},
+		{DriverMySQL, "decimal(10,2)", FieldTypeFloat},
+		{DriverMySQL, "double", FieldTypeFloat},
+		{DriverMySQL, "datetime(6)", FieldTypeDateTime},
+		{DriverMySQL, "enum('draft','published')", FieldTypeString},
+		{DriverMySQL, "varbinary(16)", FieldTypeFile},
+	}
+
+	for _, tt := range tests {
+		if got := InferFieldType(tt.sqlType); got != tt.expected {
+			t.Errorf("InferFieldType(%q) [%s] = %s, want %s", tt.sqlType, tt.driver, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package gojango
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+// WithErrorTemplates sets the template directory the 404.html and 500.html
+// error pages are looked up in, e.g. "errors" for templates/errors/404.html.
+// By default they are the top-level templates/404.html and
+// templates/500.html. Without a template, a built-in page is rendered.
+func WithErrorTemplates(dir string) Option {
+	return func(app *Application) {
+		app.errorTemplates = strings.Trim(dir, "/")
+	}
+}
+
+// handleNotFound responds to unmatched routes with a 404 page, or JSON for
+// API requests
+func (app *Application) handleNotFound(c *gin.Context) {
+	app.renderError(c, http.StatusNotFound, gin.H{})
+}
+
+// handlePanic responds to a recovered panic with a 500 page, or JSON for
+// API requests. The panic and its stack are only shown in debug mode; in
+// production the 500.html template is rendered.
+func (app *Application) handlePanic(c *gin.Context, recovered interface{}, stack []byte) {
+	data := gin.H{}
+	if app.settings.GetBool("DEBUG", app.debug) {
+		data["Error"] = fmt.Sprint(recovered)
+		data["Stack"] = string(stack)
+	}
+	app.renderError(c, http.StatusInternalServerError, data)
+}
+
+// renderError writes an error response. API requests, whose path has an
+// api segment or which prefer JSON, get a JSON body. Others get the
+// <status>.html template, except for debug 500 pages, falling back to a
+// built-in page.
+func (app *Application) renderError(c *gin.Context, status int, data gin.H) {
+	data["Status"] = status
+	data["StatusText"] = http.StatusText(status)
+	data["Path"] = c.Request.URL.Path
+	data["AppName"] = app.name
+
+	if wantsJSON(c) {
+		body := gin.H{"error": data["StatusText"], "status": status, "path": data["Path"]}
+		if err, ok := data["Error"]; ok {
+			body["panic"] = err
+			body["stack"] = data["Stack"]
+		}
+		render.JSON(c, status, body)
+		return
+	}
+
+	if _, debugPage := data["Stack"]; !debugPage {
+		name := path.Join(app.errorTemplates, fmt.Sprintf("%d.html", status))
+		if resolved, err := app.templates.Resolve(name); err == nil && resolved == name {
+			page, err := app.templates.RenderContext(c.Request.Context(), name, data)
+			if err == nil {
+				render.HTML(c, status, page)
+				return
+			}
+			log.Printf("Warning: failed to render %s: %v", name, err)
+		}
+	}
+
+	render.HTML(c, status, defaultErrorPage(data))
+}
+
+// wantsJSON reports whether an error response should be JSON: for paths
+// with an api segment, such as /api/posts or /admin/api/models, and for
+// requests whose Accept header prefers JSON to HTML
+func wantsJSON(c *gin.Context) bool {
+	for _, segment := range strings.Split(c.Request.URL.Path, "/") {
+		if segment == "api" {
+			return true
+		}
+	}
+	return c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+}
+
+// defaultErrorPage is the page rendered without an error template
+func defaultErrorPage(data gin.H) string {
+	title := fmt.Sprintf("%d %s", data["Status"], data["StatusText"])
+
+	var details string
+	if data["Status"] == http.StatusNotFound {
+		details = fmt.Sprintf("<p>The page <code>%s</code> could not be found.</p>", html.EscapeString(fmt.Sprint(data["Path"])))
+	} else {
+		details = "<p>Something went wrong while handling this request.</p>"
+	}
+	if err, ok := data["Error"]; ok {
+		details += fmt.Sprintf("\n    <h2>%s</h2>\n    <pre>%s</pre>", html.EscapeString(fmt.Sprint(err)), html.EscapeString(fmt.Sprint(data["Stack"])))
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html>
+<head>
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; margin: 2rem; }
+        pre { background: #f5f5f5; padding: 1rem; overflow-x: auto; font-size: 0.85em; }
+    </style>
+</head>
+<body>
+    <h1>%s</h1>
+    %s
+</body>
+</html>`, title, title, details)
+}
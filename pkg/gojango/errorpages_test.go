@@ -0,0 +1,139 @@
+package gojango
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newErrorPagesTestApp initializes an application in a temporary project
+// with the given templates and a route that panics
+func newErrorPagesTestApp(t *testing.T, debug bool, files map[string]string, opts ...Option) *Application {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, "templates", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create templates dir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template: %v", err)
+		}
+	}
+	t.Chdir(dir)
+
+	app := New(append([]Option{WithName("test-app"), WithDebug(debug)}, opts...)...)
+	app.registry = NewRegistry()
+	if err := app.LoadSettings(newTestSettings()); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+	app.GetRouter().GET("/boom", func(c *gin.Context) { panic("kaboom") })
+	app.GetRouter().GET("/api/boom", func(c *gin.Context) { panic("kaboom") })
+	return app
+}
+
+// requestError requests path with an Accept header and returns the response
+func requestError(app *Application, path, accept string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	app.GetRouter().ServeHTTP(w, req)
+	return w
+}
+
+func TestNotFoundPage(t *testing.T) {
+	app := newErrorPagesTestApp(t, false, map[string]string{
+		"404.html": "<h1>Lost: {{ .Path }}</h1>",
+	})
+
+	w := requestError(app, "/missing", "text/html")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404, got: %d", w.Code)
+	}
+	if body := w.Body.String(); body != "<h1>Lost: /missing</h1>" {
+		t.Errorf("Expected the 404.html template, got: %q", body)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Expected an HTML response, got: %s", ct)
+	}
+
+	// API paths and clients asking for JSON get JSON
+	for _, tc := range []struct{ path, accept string }{
+		{"/api/missing", "text/html"},
+		{"/missing", "application/json"},
+	} {
+		w := requestError(app, tc.path, tc.accept)
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Expected JSON for %s, got %q: %v", tc.path, w.Body.String(), err)
+		}
+		if w.Code != http.StatusNotFound || body["error"] != "Not Found" || body["path"] != tc.path {
+			t.Errorf("Unexpected JSON response for %s: %d %v", tc.path, w.Code, body)
+		}
+	}
+}
+
+func TestNotFoundPageWithoutTemplate(t *testing.T) {
+	app := newErrorPagesTestApp(t, false, nil)
+
+	w := requestError(app, "/missing<b>", "")
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "<h1>404 Not Found</h1>") {
+		t.Errorf("Expected the built-in 404 page, got %d: %s", w.Code, w.Body.String())
+	}
+	if strings.Contains(w.Body.String(), "<b>") {
+		t.Error("Expected the path to be escaped")
+	}
+}
+
+func TestErrorTemplatesDirectory(t *testing.T) {
+	app := newErrorPagesTestApp(t, false, map[string]string{
+		"404.html":        "top-level",
+		"errors/404.html": "custom",
+	}, WithErrorTemplates("errors"))
+
+	if body := requestError(app, "/missing", "").Body.String(); body != "custom" {
+		t.Errorf("Expected errors/404.html, got: %q", body)
+	}
+}
+
+func TestServerErrorPage(t *testing.T) {
+	files := map[string]string{"500.html": "<h1>Sorry</h1>"}
+
+	// In production the 500.html template is rendered without details
+	app := newErrorPagesTestApp(t, false, files)
+	w := requestError(app, "/boom", "")
+	if w.Code != http.StatusInternalServerError || w.Body.String() != "<h1>Sorry</h1>" {
+		t.Errorf("Expected the 500.html template, got %d: %q", w.Code, w.Body.String())
+	}
+
+	w = requestError(app, "/api/boom", "")
+	if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "kaboom") {
+		t.Errorf("Expected JSON without the panic, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// In debug mode the panic and its stack are shown
+	app = newErrorPagesTestApp(t, true, files)
+	w = requestError(app, "/boom", "")
+	body := w.Body.String()
+	if w.Code != http.StatusInternalServerError || !strings.Contains(body, "kaboom") || !strings.Contains(body, "errorpages_test.go") {
+		t.Errorf("Expected the debug page with the stack, got %d: %s", w.Code, body)
+	}
+
+	var apiBody map[string]interface{}
+	w = requestError(app, "/api/boom", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &apiBody); err != nil || apiBody["panic"] != "kaboom" || apiBody["stack"] == "" {
+		t.Errorf("Expected JSON with the panic in debug mode, got %q: %v", w.Body.String(), err)
+	}
+}
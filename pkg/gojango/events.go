@@ -0,0 +1,106 @@
+package gojango
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// Event is an app-level domain event, such as "post.published"
+type Event struct {
+	Topic   string
+	Payload interface{}
+}
+
+// EventHandler handles an event published to a topic it subscribed to
+type EventHandler func(event Event) error
+
+// SubscriptionID identifies a subscription so it can be cancelled
+type SubscriptionID uint64
+
+// EventBus lets apps communicate through published events without
+// importing each other. Unlike model signals, topics are free-form names
+// chosen by the apps.
+type EventBus struct {
+	mu            sync.RWMutex
+	nextID        SubscriptionID
+	subscriptions map[string][]subscription
+}
+
+type subscription struct {
+	id      SubscriptionID
+	handler EventHandler
+}
+
+// NewEventBus creates an event bus with no subscribers
+func NewEventBus() *EventBus {
+	return &EventBus{subscriptions: make(map[string][]subscription)}
+}
+
+// Subscribe adds a handler for a topic, called after those already
+// subscribed
+func (b *EventBus) Subscribe(topic string, handler EventHandler) SubscriptionID {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	b.subscriptions[topic] = append(b.subscriptions[topic], subscription{id: b.nextID, handler: handler})
+	return b.nextID
+}
+
+// Unsubscribe removes a handler. It returns false if it was not subscribed.
+func (b *EventBus) Unsubscribe(id SubscriptionID) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for topic, subs := range b.subscriptions {
+		for i, sub := range subs {
+			if sub.id == id {
+				subs = append(subs[:i:i], subs[i+1:]...)
+				if len(subs) == 0 {
+					delete(b.subscriptions, topic)
+				} else {
+					b.subscriptions[topic] = subs
+				}
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasSubscribers reports whether any handlers are subscribed to topic
+func (b *EventBus) HasSubscribers(topic string) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscriptions[topic]) > 0
+}
+
+// Publish synchronously calls every handler subscribed to topic, in
+// subscription order. A failing handler doesn't stop delivery to the
+// others; their errors are joined. Handlers subscribed or unsubscribed
+// during delivery take effect from the next Publish.
+func (b *EventBus) Publish(topic string, payload interface{}) error {
+	b.mu.RLock()
+	subs := b.subscriptions[topic]
+	b.mu.RUnlock()
+
+	event := Event{Topic: topic, Payload: payload}
+	var errs []error
+	for _, sub := range subs {
+		if err := sub.handler(event); err != nil {
+			errs = append(errs, fmt.Errorf("%s handler failed: %w", topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishAsync delivers an event in the background, logging handler errors
+func (b *EventBus) PublishAsync(topic string, payload interface{}) {
+	go func() {
+		if err := b.Publish(topic, payload); err != nil {
+			log.Printf("Error publishing event: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,130 @@
+package gojango
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEventBusPublish(t *testing.T) {
+	bus := NewEventBus()
+
+	var received []string
+	bus.Subscribe("post.published", func(event Event) error {
+		received = append(received, "notifications:"+event.Payload.(string))
+		return nil
+	})
+	bus.Subscribe("post.published", func(event Event) error {
+		received = append(received, "search:"+event.Payload.(string))
+		return nil
+	})
+	bus.Subscribe("post.deleted", func(event Event) error {
+		t.Error("handler of another topic should not be called")
+		return nil
+	})
+
+	if err := bus.Publish("post.published", "hello-world"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if strings.Join(received, ",") != "notifications:hello-world,search:hello-world" {
+		t.Errorf("Expected both subscribers in order, got %v", received)
+	}
+
+	if err := bus.Publish("user.joined", nil); err != nil {
+		t.Errorf("Publishing without subscribers should succeed, got %v", err)
+	}
+}
+
+func TestEventBusUnsubscribe(t *testing.T) {
+	bus := NewEventBus()
+
+	calls := 0
+	id := bus.Subscribe("post.published", func(event Event) error {
+		calls++
+		return nil
+	})
+	if !bus.HasSubscribers("post.published") {
+		t.Error("Topic should have a subscriber")
+	}
+
+	bus.Publish("post.published", nil)
+	if !bus.Unsubscribe(id) {
+		t.Error("Unsubscribe should report the subscription was removed")
+	}
+	if bus.Unsubscribe(id) {
+		t.Error("Unsubscribing twice should report false")
+	}
+	bus.Publish("post.published", nil)
+
+	if calls != 1 {
+		t.Errorf("Expected 1 call before unsubscribing, got %d", calls)
+	}
+	if bus.HasSubscribers("post.published") {
+		t.Error("Topic should have no subscribers")
+	}
+}
+
+func TestEventBusHandlerErrors(t *testing.T) {
+	bus := NewEventBus()
+
+	delivered := false
+	bus.Subscribe("post.published", func(event Event) error {
+		return errors.New("mail server down")
+	})
+	bus.Subscribe("post.published", func(event Event) error {
+		delivered = true
+		return nil
+	})
+
+	err := bus.Publish("post.published", nil)
+	if err == nil || !strings.Contains(err.Error(), "mail server down") {
+		t.Errorf("Expected the handler error, got %v", err)
+	}
+	if !delivered {
+		t.Error("A failing handler should not stop delivery to the others")
+	}
+}
+
+// eventApp publishes or subscribes to events during initialization
+type eventApp struct {
+	BaseApp
+	name string
+	deps []string
+	init func(ctx *AppContext)
+}
+
+func (app *eventApp) Config() AppConfig {
+	return AppConfig{Name: app.name, Dependencies: app.deps}
+}
+
+func (app *eventApp) Initialize(ctx *AppContext) error {
+	app.init(ctx)
+	return nil
+}
+
+func TestAppContextEvents(t *testing.T) {
+	registry := NewRegistry()
+
+	var notified []interface{}
+	registry.RegisterApp(&eventApp{name: "notifications", init: func(ctx *AppContext) {
+		ctx.Events.Subscribe("post.published", func(event Event) error {
+			notified = append(notified, event.Payload)
+			return nil
+		})
+	}})
+	registry.RegisterApp(&eventApp{name: "blog", deps: []string{"notifications"}, init: func(ctx *AppContext) {
+		if ctx.Events != registry.Events() {
+			t.Error("Apps should share the registry's event bus")
+		}
+	}})
+
+	if err := registry.Initialize(context.Background(), NewBasicSettings()); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	registry.Events().Publish("post.published", 42)
+	if len(notified) != 1 || notified[0] != 42 {
+		t.Errorf("Expected the notifications app to receive the event, got %v", notified)
+	}
+}
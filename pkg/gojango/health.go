@@ -0,0 +1,223 @@
+package gojango
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultHealthCheckTimeout bounds how long a single health check may run
+const DefaultHealthCheckTimeout = 5 * time.Second
+
+// HealthCheck reports an unhealthy dependency or process by returning an
+// error. It should return promptly once ctx is done.
+type HealthCheck func(ctx context.Context) error
+
+// HealthRegistry holds the named health checks served under /health.
+//
+// Readiness checks, added with Register, cover the dependencies needed to
+// serve traffic, such as the database or a cache. Liveness checks, added
+// with RegisterLiveness, cover the process itself and should only fail
+// when restarting it would help. /health/live runs the liveness checks,
+// while /health and /health/ready run all of them.
+type HealthRegistry struct {
+	mu        sync.RWMutex
+	readiness map[string]HealthCheck
+	liveness  map[string]HealthCheck
+	timeout   time.Duration
+	debug     bool
+}
+
+// HealthReport is the result of running health checks
+type HealthReport struct {
+	Status string `json:"status"`
+	// Checks maps each check to "ok" or "fail". Failures include their error
+	// only in debug mode, as health endpoints are usually public.
+	Checks map[string]string `json:"checks"`
+	// Failing lists the names of the failed checks, sorted
+	Failing []string `json:"failing,omitempty"`
+}
+
+// Healthy reports whether every check passed
+func (r HealthReport) Healthy() bool {
+	return len(r.Failing) == 0
+}
+
+// NewHealthRegistry creates an empty health check registry
+func NewHealthRegistry() *HealthRegistry {
+	return &HealthRegistry{
+		readiness: make(map[string]HealthCheck),
+		liveness:  make(map[string]HealthCheck),
+		timeout:   DefaultHealthCheckTimeout,
+	}
+}
+
+// Register adds a readiness check, replacing any check with the same name
+func (h *HealthRegistry) Register(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.liveness, name)
+	h.readiness[name] = check
+}
+
+// RegisterLiveness adds a liveness check, replacing any check with the same
+// name
+func (h *HealthRegistry) RegisterLiveness(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.readiness, name)
+	h.liveness[name] = check
+}
+
+// SetTimeout sets how long a single check may run before it fails
+func (h *HealthRegistry) SetTimeout(timeout time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.timeout = timeout
+}
+
+// SetDebug sets whether reports include the errors of failed checks, which
+// are logged either way
+func (h *HealthRegistry) SetDebug(debug bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.debug = debug
+}
+
+// Names returns the names of all registered checks, sorted
+func (h *HealthRegistry) Names() []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	names := make([]string, 0, len(h.readiness)+len(h.liveness))
+	for name := range h.readiness {
+		names = append(names, name)
+	}
+	for name := range h.liveness {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Live runs the liveness checks
+func (h *HealthRegistry) Live(ctx context.Context) HealthReport {
+	h.mu.RLock()
+	checks := make(map[string]HealthCheck, len(h.liveness))
+	for name, check := range h.liveness {
+		checks[name] = check
+	}
+	timeout, debug := h.timeout, h.debug
+	h.mu.RUnlock()
+	return runHealthChecks(ctx, checks, timeout, debug)
+}
+
+// Ready runs the liveness and readiness checks
+func (h *HealthRegistry) Ready(ctx context.Context) HealthReport {
+	h.mu.RLock()
+	checks := make(map[string]HealthCheck, len(h.liveness)+len(h.readiness))
+	for name, check := range h.liveness {
+		checks[name] = check
+	}
+	for name, check := range h.readiness {
+		checks[name] = check
+	}
+	timeout, debug := h.timeout, h.debug
+	h.mu.RUnlock()
+	return runHealthChecks(ctx, checks, timeout, debug)
+}
+
+// LiveHandler serves the liveness checks: 200 when they pass, 503 otherwise
+func (h *HealthRegistry) LiveHandler(appName string) gin.HandlerFunc {
+	return healthHandler(appName, h.Live)
+}
+
+// ReadyHandler serves all checks: 200 when they pass, 503 otherwise
+func (h *HealthRegistry) ReadyHandler(appName string) gin.HandlerFunc {
+	return healthHandler(appName, h.Ready)
+}
+
+// healthHandler responds with a report and its status code
+func healthHandler(appName string, run func(context.Context) HealthReport) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := run(c.Request.Context())
+		status := http.StatusOK
+		if !report.Healthy() {
+			status = http.StatusServiceUnavailable
+		}
+		response := gin.H{
+			"status": report.Status,
+			"app":    appName,
+			"checks": report.Checks,
+		}
+		if !report.Healthy() {
+			response["failing"] = report.Failing
+		}
+		render.JSON(c, status, response)
+	}
+}
+
+// runHealthChecks runs checks concurrently, each bounded by timeout, and
+// logs the errors of failed checks
+func runHealthChecks(ctx context.Context, checks map[string]HealthCheck, timeout time.Duration, debug bool) HealthReport {
+	report := HealthReport{Status: "ok", Checks: make(map[string]string, len(checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+			err := runHealthCheck(ctx, check, timeout)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("Health check %s failed: %v", name, err)
+				report.Checks[name] = "fail"
+				if debug {
+					report.Checks[name] = "fail: " + err.Error()
+				}
+				report.Failing = append(report.Failing, name)
+				return
+			}
+			report.Checks[name] = "ok"
+		}(name, check)
+	}
+	wg.Wait()
+
+	if len(report.Failing) > 0 {
+		sort.Strings(report.Failing)
+		report.Status = "unavailable"
+	}
+	return report
+}
+
+// runHealthCheck runs a check, failing it when it panics or outlives timeout
+func runHealthCheck(ctx context.Context, check HealthCheck, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+}
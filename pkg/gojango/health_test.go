@@ -0,0 +1,170 @@
+package gojango
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+// newHealthTestApp initializes an application with a fresh registry
+func newHealthTestApp(t *testing.T, opts ...Option) *Application {
+	t.Helper()
+	app := New(opts...)
+	app.registry = &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+	if err := app.LoadSettings(newTestSettings()); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+	return app
+}
+
+// getHealth requests a health endpoint and decodes its response
+func getHealth(t *testing.T, app *Application, path string) (int, map[string]interface{}) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	app.GetRouter().ServeHTTP(w, req)
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode %s response: %v\n%s", path, err, w.Body.String())
+	}
+	return w.Code, body
+}
+
+func TestHealthAllPassing(t *testing.T) {
+	app := newHealthTestApp(t)
+	app.Health().Register("cache", func(ctx context.Context) error { return nil })
+	app.Health().RegisterLiveness("goroutines", func(ctx context.Context) error { return nil })
+
+	for _, path := range []string{"/health", "/health/ready", "/health/live"} {
+		code, body := getHealth(t, app, path)
+		if code != http.StatusOK {
+			t.Errorf("Expected %s to respond 200, got: %d", path, code)
+		}
+		if body["status"] != "ok" {
+			t.Errorf("Expected %s status ok, got: %v", path, body["status"])
+		}
+		if _, ok := body["failing"]; ok {
+			t.Errorf("Expected %s to list no failing checks, got: %v", path, body["failing"])
+		}
+	}
+}
+
+func TestHealthFailingCheck(t *testing.T) {
+	app := newHealthTestApp(t)
+	app.Health().Register("cache", func(ctx context.Context) error { return nil })
+	app.Health().Register("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	code, body := getHealth(t, app, "/health")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got: %d", code)
+	}
+	if !reflect.DeepEqual(body["failing"], []interface{}{"redis"}) {
+		t.Errorf("Expected redis to be failing, got: %v", body["failing"])
+	}
+	checks, _ := body["checks"].(map[string]interface{})
+	if checks["cache"] != "ok" || checks["redis"] != "fail" {
+		t.Errorf("Unexpected check results: %v", checks)
+	}
+
+	// Errors are only reported in debug mode
+	app = newHealthTestApp(t, WithDebug(true))
+	app.Health().Register("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+	_, body = getHealth(t, app, "/health")
+	checks, _ = body["checks"].(map[string]interface{})
+	if checks["redis"] != "fail: connection refused" {
+		t.Errorf("Expected the error in debug mode, got: %v", checks)
+	}
+}
+
+func TestHealthLiveAndReady(t *testing.T) {
+	app := newHealthTestApp(t)
+	app.Health().Register("redis", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	// A failing dependency makes the app unready but keeps it alive
+	if code, _ := getHealth(t, app, "/health/ready"); code != http.StatusServiceUnavailable {
+		t.Errorf("Expected /health/ready to respond 503, got: %d", code)
+	}
+	if code, _ := getHealth(t, app, "/health/live"); code != http.StatusOK {
+		t.Errorf("Expected /health/live to respond 200, got: %d", code)
+	}
+
+	// A failing liveness check fails both
+	app.Health().RegisterLiveness("deadlock", func(ctx context.Context) error { return errors.New("stuck") })
+	code, body := getHealth(t, app, "/health/live")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected /health/live to respond 503, got: %d", code)
+	}
+	if !reflect.DeepEqual(body["failing"], []interface{}{"deadlock"}) {
+		t.Errorf("Expected only deadlock to fail liveness, got: %v", body["failing"])
+	}
+	if _, body := getHealth(t, app, "/health/ready"); !reflect.DeepEqual(body["failing"], []interface{}{"deadlock", "redis"}) {
+		t.Errorf("Expected deadlock and redis to fail readiness, got: %v", body["failing"])
+	}
+}
+
+func TestHealthCheckTimeoutAndPanic(t *testing.T) {
+	health := NewHealthRegistry()
+	health.SetTimeout(10 * time.Millisecond)
+	health.SetDebug(true)
+	health.Register("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	})
+	health.Register("broken", func(ctx context.Context) error { panic("boom") })
+
+	report := health.Ready(context.Background())
+	if !reflect.DeepEqual(report.Failing, []string{"broken", "slow"}) {
+		t.Errorf("Expected broken and slow to fail, got: %v", report.Failing)
+	}
+	if report.Checks["slow"] != "fail: timed out after 10ms" {
+		t.Errorf("Unexpected slow check result: %s", report.Checks["slow"])
+	}
+	if report.Checks["broken"] != "fail: panic: boom" {
+		t.Errorf("Unexpected broken check result: %s", report.Checks["broken"])
+	}
+}
+
+func TestHealthDatabaseCheck(t *testing.T) {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "app.db")))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	app := newHealthTestApp(t, WithDatabase(conn))
+	if names := app.Health().Names(); !reflect.DeepEqual(names, []string{"database"}) {
+		t.Fatalf("Expected a database check, got: %v", names)
+	}
+	if code, _ := getHealth(t, app, "/health"); code != http.StatusOK {
+		t.Errorf("Expected 200 with an open database, got: %d", code)
+	}
+
+	conn.Close()
+	code, body := getHealth(t, app, "/health")
+	if code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 with a closed database, got: %d", code)
+	}
+	if !reflect.DeepEqual(body["failing"], []interface{}{"database"}) {
+		t.Errorf("Expected the database check to fail, got: %v", body["failing"])
+	}
+	if code, _ := getHealth(t, app, "/health/live"); code != http.StatusOK {
+		t.Errorf("Expected /health/live to ignore the database, got: %d", code)
+	}
+}
@@ -0,0 +1,109 @@
+// Package htmx provides helpers for handlers serving HTMX requests.
+//
+// HTMX marks its requests with HX-* request headers and can be steered by
+// HX-* response headers. These helpers read and write those headers so
+// handlers can return fragments to HTMX and full pages to direct navigation.
+package htmx
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/epuerta9/gojango/pkg/gojango/templates"
+	"github.com/gin-gonic/gin"
+)
+
+// Request headers sent by HTMX
+const (
+	HeaderRequest        = "HX-Request"
+	HeaderBoosted        = "HX-Boosted"
+	HeaderTarget         = "HX-Target"
+	HeaderTrigger        = "HX-Trigger"
+	HeaderCurrentURL     = "HX-Current-URL"
+	HeaderHistoryRestore = "HX-History-Restore-Request"
+)
+
+// Response headers understood by HTMX
+const (
+	HeaderRetarget = "HX-Retarget"
+	HeaderReswap   = "HX-Reswap"
+	HeaderPushURL  = "HX-Push-Url"
+	HeaderRedirect = "HX-Redirect"
+	HeaderRefresh  = "HX-Refresh"
+)
+
+// IsHTMXRequest reports whether the request was made by HTMX, boosted
+// links and forms included
+func IsHTMXRequest(c *gin.Context) bool {
+	return c.GetHeader(HeaderRequest) == "true"
+}
+
+// IsBoosted reports whether the request came from a boosted link or form
+func IsBoosted(c *gin.Context) bool {
+	return c.GetHeader(HeaderBoosted) == "true"
+}
+
+// Retarget replaces the element the response is swapped into with the one
+// matching selector
+func Retarget(c *gin.Context, selector string) {
+	c.Header(HeaderRetarget, selector)
+}
+
+// Reswap changes how the response is swapped in, e.g. "outerHTML" or
+// "beforeend"
+func Reswap(c *gin.Context, strategy string) {
+	c.Header(HeaderReswap, strategy)
+}
+
+// PushURL pushes url onto the browser history
+func PushURL(c *gin.Context, url string) {
+	c.Header(HeaderPushURL, url)
+}
+
+// Redirect makes HTMX navigate the browser to url with a full page load
+func Redirect(c *gin.Context, url string) {
+	c.Header(HeaderRedirect, url)
+}
+
+// TriggerEvent triggers a client-side event named name once the response is
+// received, with detail as the event's detail. Triggering several events
+// adds them all to the HX-Trigger header.
+func TriggerEvent(c *gin.Context, name string, detail interface{}) error {
+	events := make(map[string]interface{})
+	if existing := c.Writer.Header().Get(HeaderTrigger); existing != "" {
+		if err := json.Unmarshal([]byte(existing), &events); err != nil {
+			// A plain event name set elsewhere
+			events = map[string]interface{}{existing: nil}
+		}
+	}
+	events[name] = detail
+
+	encoded, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", name, err)
+	}
+	c.Header(HeaderTrigger, string(encoded))
+	return nil
+}
+
+// RenderPartial renders partial for HTMX requests and page otherwise, so a
+// handler serves the fragment HTMX swaps in and the full page, base layout
+// included, for direct navigation. History restores fetch the full page
+// since they replace the whole document.
+func RenderPartial(c *gin.Context, engine *templates.Engine, code int, page, partial string, data interface{}) {
+	name := page
+	if IsHTMXRequest(c) && c.GetHeader(HeaderHistoryRestore) != "true" {
+		name = partial
+	}
+
+	html, err := engine.RenderContext(c.Request.Context(), name, data)
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+
+	// The same URL returns different content depending on HX-Request
+	c.Writer.Header().Add("Vary", HeaderRequest)
+	c.Data(code, "text/html; charset=utf-8", []byte(html))
+}
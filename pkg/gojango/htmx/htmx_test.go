@@ -0,0 +1,150 @@
+package htmx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/templates"
+	"github.com/gin-gonic/gin"
+)
+
+func newContext(headers map[string]string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/", nil)
+	for name, value := range headers {
+		c.Request.Header.Set(name, value)
+	}
+	return c, w
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func TestIsHTMXRequest(t *testing.T) {
+	c, _ := newContext(nil)
+	if IsHTMXRequest(c) || IsBoosted(c) {
+		t.Error("Expected a plain request not to be detected as HTMX")
+	}
+
+	c, _ = newContext(map[string]string{HeaderRequest: "true"})
+	if !IsHTMXRequest(c) {
+		t.Error("Expected HX-Request: true to be detected")
+	}
+	if IsBoosted(c) {
+		t.Error("Expected a non-boosted HTMX request")
+	}
+
+	c, _ = newContext(map[string]string{HeaderRequest: "true", HeaderBoosted: "true"})
+	if !IsHTMXRequest(c) || !IsBoosted(c) {
+		t.Error("Expected a boosted HTMX request")
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	c, w := newContext(nil)
+	Retarget(c, "#errors")
+	Reswap(c, "outerHTML")
+	PushURL(c, "/posts/1/")
+	Redirect(c, "/login/")
+	c.Status(http.StatusOK)
+
+	expected := map[string]string{
+		"HX-Retarget": "#errors",
+		"HX-Reswap":   "outerHTML",
+		"HX-Push-Url": "/posts/1/",
+		"HX-Redirect": "/login/",
+	}
+	for name, value := range expected {
+		if got := w.Header().Get(name); got != value {
+			t.Errorf("Expected %s %q, got: %q", name, value, got)
+		}
+	}
+}
+
+func TestTriggerEvent(t *testing.T) {
+	c, w := newContext(nil)
+	if err := TriggerEvent(c, "saved", map[string]int{"id": 7}); err != nil {
+		t.Fatalf("Failed to trigger event: %v", err)
+	}
+	if err := TriggerEvent(c, "refresh", nil); err != nil {
+		t.Fatalf("Failed to trigger event: %v", err)
+	}
+
+	var events map[string]interface{}
+	if err := json.Unmarshal([]byte(w.Header().Get("HX-Trigger")), &events); err != nil {
+		t.Fatalf("Expected JSON in HX-Trigger, got: %q", w.Header().Get("HX-Trigger"))
+	}
+	if len(events) != 2 {
+		t.Errorf("Expected both events, got: %v", events)
+	}
+	if detail, ok := events["saved"].(map[string]interface{}); !ok || detail["id"] != float64(7) {
+		t.Errorf("Expected the saved event detail, got: %v", events["saved"])
+	}
+	if _, ok := events["refresh"]; !ok {
+		t.Error("Expected the refresh event")
+	}
+
+	// A plain event name set directly is kept
+	c, w = newContext(nil)
+	c.Header("HX-Trigger", "closeModal")
+	if err := TriggerEvent(c, "saved", nil); err != nil {
+		t.Fatalf("Failed to trigger event: %v", err)
+	}
+	if got := w.Header().Get("HX-Trigger"); got != `{"closeModal":null,"saved":null}` {
+		t.Errorf("Expected both events, got: %q", got)
+	}
+}
+
+func TestRenderPartial(t *testing.T) {
+	engine := templates.NewEngine()
+	tempDir := t.TempDir()
+	writeFile(t, tempDir, "page.html", "<html><body>{{.}}</body></html>")
+	writeFile(t, tempDir, "partial.html", "<p>{{.}}</p>")
+	if err := engine.LoadGlobalTemplates(tempDir); err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected string
+	}{
+		{"direct navigation", nil, "<html><body>hi</body></html>"},
+		{"htmx request", map[string]string{HeaderRequest: "true"}, "<p>hi</p>"},
+		{"boosted request", map[string]string{HeaderRequest: "true", HeaderBoosted: "true"}, "<p>hi</p>"},
+		{"history restore", map[string]string{HeaderRequest: "true", HeaderHistoryRestore: "true"}, "<html><body>hi</body></html>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, w := newContext(tt.headers)
+			RenderPartial(c, engine, http.StatusOK, "page.html", "partial.html", "hi")
+
+			if w.Code != http.StatusOK {
+				t.Errorf("Expected status 200, got: %d", w.Code)
+			}
+			if w.Body.String() != tt.expected {
+				t.Errorf("Expected %q, got: %q", tt.expected, w.Body.String())
+			}
+			if vary := w.Header().Get("Vary"); vary != "HX-Request" {
+				t.Errorf("Expected Vary: HX-Request, got: %q", vary)
+			}
+		})
+	}
+
+	c, w := newContext(nil)
+	RenderPartial(c, engine, http.StatusOK, "missing.html", "partial.html", nil)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 for a missing template, got: %d", w.Code)
+	}
+}
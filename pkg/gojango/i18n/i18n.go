@@ -0,0 +1,257 @@
+// Package i18n translates messages for Gojango apps, in the spirit of
+// Django's gettext support.
+//
+// Translations live in one JSON file per locale, named after the locale
+// (locale/fr.json, locale/pt-BR.json). Each key maps to a translation, or
+// for messages that depend on a count, to its plural forms:
+//
+//	{
+//	    "welcome": "Bienvenue, %s",
+//	    "items": {"one": "%d article", "other": "%d articles"}
+//	}
+//
+// Lookups fall back from a regional locale to its language (pt-BR to pt),
+// then to the default locale, then to the key itself.
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Message holds a translation's plural forms by CLDR category ("zero",
+// "one", "two", "few", "many", "other"). Messages without plural forms only
+// have "other".
+type Message map[string]string
+
+// UnmarshalJSON accepts a plain string as well as an object of plural forms
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*m = Message{"other": text}
+		return nil
+	}
+
+	var forms map[string]string
+	if err := json.Unmarshal(data, &forms); err != nil {
+		return fmt.Errorf("translation must be a string or an object of plural forms")
+	}
+	if _, ok := forms["other"]; !ok {
+		return fmt.Errorf("plural forms must include \"other\"")
+	}
+	*m = forms
+	return nil
+}
+
+// Catalog holds the translations for every locale
+type Catalog struct {
+	mu            sync.RWMutex
+	defaultLocale string
+	messages      map[string]map[string]Message
+	debug         bool
+	missing       map[string]bool
+}
+
+// NewCatalog creates an empty catalog that falls back to defaultLocale
+func NewCatalog(defaultLocale string) *Catalog {
+	return &Catalog{
+		defaultLocale: normalize(defaultLocale),
+		messages:      make(map[string]map[string]Message),
+		missing:       make(map[string]bool),
+	}
+}
+
+// DefaultLocale returns the locale used when no other matches
+func (c *Catalog) DefaultLocale() string {
+	return c.defaultLocale
+}
+
+// SetDebug enables logging of missing translations, once per locale and key
+func (c *Catalog) SetDebug(debug bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.debug = debug
+}
+
+// Load adds the translations in a JSON document to locale, replacing
+// existing translations for the same keys
+func (c *Catalog) Load(locale string, data []byte) error {
+	var messages map[string]Message
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return fmt.Errorf("invalid translations for %s: %w", locale, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locale = normalize(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]Message)
+	}
+	for key, message := range messages {
+		c.messages[locale][key] = message
+	}
+	return nil
+}
+
+// LoadDir loads every <locale>.json file in dir. A missing directory is not
+// an error.
+func (c *Catalog) LoadDir(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read translations: %w", err)
+		}
+		locale := strings.TrimSuffix(filepath.Base(path), ".json")
+		if err := c.Load(locale, data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Locales returns the locales that have translations, sorted
+func (c *Catalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Match returns the catalog locale to use for locale: the locale itself or
+// its language. It returns "" when the catalog has neither.
+func (c *Catalog) Match(locale string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, candidate := range candidates(normalize(locale)) {
+		if _, ok := c.messages[candidate]; ok {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// T translates key into locale, formatting the translation with args when
+// any are given
+func (c *Catalog) T(locale, key string, args ...interface{}) string {
+	text, found := c.lookup(locale, key, "other")
+	if !found {
+		return text
+	}
+	return format(text, args)
+}
+
+// TN translates key into locale using the plural form for count. Without
+// args the translation is formatted with count.
+func (c *Catalog) TN(locale, key string, count int, args ...interface{}) string {
+	text, found := c.lookup(locale, key, pluralForm(locale, count))
+	if !found {
+		return text
+	}
+	if len(args) == 0 {
+		args = []interface{}{count}
+	}
+	return format(text, args)
+}
+
+// lookup finds the form of key's translation, trying locale, its language
+// and the default locale in turn, and falls back to the key. It reports
+// whether a translation was found, as the key is returned unformatted.
+func (c *Catalog) lookup(locale, key, form string) (string, bool) {
+	c.mu.RLock()
+	locale = normalize(locale)
+	for _, candidate := range append(candidates(locale), candidates(c.defaultLocale)...) {
+		message, ok := c.messages[candidate][key]
+		if !ok {
+			continue
+		}
+		c.mu.RUnlock()
+		if text, ok := message[form]; ok {
+			return text, true
+		}
+		return message["other"], true
+	}
+	debug := c.debug
+	c.mu.RUnlock()
+
+	if debug {
+		c.logMissing(locale, key)
+	}
+	return key, false
+}
+
+// logMissing logs a missing translation the first time it is looked up
+func (c *Catalog) logMissing(locale, key string) {
+	c.mu.Lock()
+	id := locale + "\x00" + key
+	logged := c.missing[id]
+	c.missing[id] = true
+	c.mu.Unlock()
+
+	if !logged {
+		log.Printf("i18n: missing translation for %q in %s", key, locale)
+	}
+}
+
+// format applies args to a translation, if there are any
+func format(text string, args []interface{}) string {
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// normalize canonicalizes a locale name to lowercase with hyphens, so
+// "pt_BR", "pt-br" and "pt-BR" are the same locale
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// candidates returns locale followed by its language, if it has a region
+func candidates(locale string) []string {
+	if locale == "" {
+		return nil
+	}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		return []string{locale, language}
+	}
+	return []string{locale}
+}
+
+// defaultCatalog backs the package-level translation functions
+var defaultCatalog = NewCatalog("en")
+
+// Default returns the catalog used by T and TN
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// SetDefault replaces the catalog used by T and TN
+func SetDefault(catalog *Catalog) {
+	defaultCatalog = catalog
+}
+
+// T translates key into locale with the default catalog
+func T(locale, key string, args ...interface{}) string {
+	return defaultCatalog.T(locale, key, args...)
+}
+
+// TN translates key into locale with the default catalog, using the plural
+// form for count
+func TN(locale, key string, count int, args ...interface{}) string {
+	return defaultCatalog.TN(locale, key, count, args...)
+}
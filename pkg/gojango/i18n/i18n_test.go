@@ -0,0 +1,190 @@
+package i18n
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"en.json":    `{"welcome": "Welcome, %s", "items": {"one": "%d item", "other": "%d items"}, "only_en": "English"}`,
+		"fr.json":    `{"welcome": "Bienvenue, %s", "items": {"one": "%d article", "other": "%d articles"}}`,
+		"pt.json":    `{"welcome": "Bem-vindo, %s", "color": "cor"}`,
+		"pt_BR.json": `{"color": "cor (BR)"}`,
+		"ru.json":    `{"items": {"one": "%d предмет", "few": "%d предмета", "many": "%d предметов", "other": "%d предмета"}}`,
+		"notes.txt":  `not translations`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	catalog := NewCatalog("en")
+	if err := catalog.LoadDir(dir); err != nil {
+		t.Fatalf("Failed to load catalog: %v", err)
+	}
+	return catalog
+}
+
+func TestLoadDir(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	expected := []string{"en", "fr", "pt", "pt-br", "ru"}
+	locales := catalog.Locales()
+	if strings.Join(locales, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected locales %v, got: %v", expected, locales)
+	}
+
+	if err := NewCatalog("en").LoadDir(filepath.Join(t.TempDir(), "missing")); err != nil {
+		t.Errorf("Expected a missing directory to be ignored, got: %v", err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	catalog := NewCatalog("en")
+	if err := catalog.Load("en", []byte(`{"key": 1}`)); err == nil {
+		t.Error("Expected an error for a non-string translation")
+	}
+	if err := catalog.Load("en", []byte(`{"key": {"one": "x"}}`)); err == nil {
+		t.Error("Expected an error for plural forms without \"other\"")
+	}
+	if err := catalog.Load("en", []byte(`not json`)); err == nil {
+		t.Error("Expected an error for invalid JSON")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		locale, key string
+		args        []interface{}
+		expected    string
+	}{
+		{"fr", "welcome", []interface{}{"Ana"}, "Bienvenue, Ana"},
+		{"FR", "welcome", []interface{}{"Ana"}, "Bienvenue, Ana"},
+		{"pt-BR", "color", nil, "cor (BR)"},
+		{"pt_br", "color", nil, "cor (BR)"},
+		// Regional locales fall back to their language
+		{"pt-BR", "welcome", []interface{}{"Ana"}, "Bem-vindo, Ana"},
+		{"fr-CA", "welcome", []interface{}{"Ana"}, "Bienvenue, Ana"},
+		// Then to the default locale
+		{"fr", "only_en", nil, "English"},
+		{"de", "welcome", []interface{}{"Ana"}, "Welcome, Ana"},
+		// Then to the key itself, which is never used as a format
+		{"fr", "missing key", nil, "missing key"},
+		{"fr", "Hello %s", []interface{}{"Ana"}, "Hello %s"},
+		{"fr", "100% done", []interface{}{"Ana"}, "100% done"},
+	}
+
+	for _, tt := range tests {
+		if result := catalog.T(tt.locale, tt.key, tt.args...); result != tt.expected {
+			t.Errorf("T(%q, %q) = %q, expected %q", tt.locale, tt.key, result, tt.expected)
+		}
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		locale   string
+		count    int
+		expected string
+	}{
+		{"en", 0, "0 items"},
+		{"en", 1, "1 item"},
+		{"en", 2, "2 items"},
+		// French treats zero as singular
+		{"fr", 0, "0 article"},
+		{"fr", 1, "1 article"},
+		{"fr", 5, "5 articles"},
+		{"ru", 1, "1 предмет"},
+		{"ru", 21, "21 предмет"},
+		{"ru", 3, "3 предмета"},
+		{"ru", 12, "12 предметов"},
+		{"ru", 5, "5 предметов"},
+		// Forms missing from a translation use "other"
+		{"pt", 1, "1 item"},
+	}
+
+	for _, tt := range tests {
+		if result := catalog.TN(tt.locale, "items", tt.count); result != tt.expected {
+			t.Errorf("TN(%q, %d) = %q, expected %q", tt.locale, tt.count, result, tt.expected)
+		}
+	}
+
+	if result := catalog.TN("en", "items", 3, 3); result != "3 items" {
+		t.Errorf("Expected explicit args to be used, got: %q", result)
+	}
+}
+
+func TestMissingPluralReturnsKey(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	// Without a translation TN would otherwise format the key with the count
+	for _, key := range []string{"%d new messages", "50% off"} {
+		if result := catalog.TN("fr", key, 3); result != key {
+			t.Errorf("TN(%q) = %q, expected the key unchanged", key, result)
+		}
+	}
+}
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		language string
+		counts   map[int]string
+	}{
+		{"en", map[int]string{0: "other", 1: "one", 2: "other"}},
+		{"fr", map[int]string{0: "one", 1: "one", 2: "other"}},
+		{"ja", map[int]string{0: "other", 1: "other", 2: "other"}},
+		{"pl", map[int]string{1: "one", 2: "few", 12: "many", 21: "many", 22: "few", 25: "many"}},
+		{"uk", map[int]string{1: "one", 11: "many", 22: "few", 111: "many"}},
+	}
+	for _, tt := range tests {
+		for n, expected := range tt.counts {
+			if form := pluralForm(tt.language, n); form != expected {
+				t.Errorf("pluralForm(%q, %d) = %q, expected %q", tt.language, n, form, expected)
+			}
+		}
+	}
+}
+
+func TestMissingTranslationLogging(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	catalog := newTestCatalog(t)
+	catalog.T("fr", "missing")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no logging outside debug mode, got: %q", buf.String())
+	}
+
+	catalog.SetDebug(true)
+	catalog.T("fr", "missing")
+	catalog.T("fr", "missing")
+	if count := strings.Count(buf.String(), `missing translation for "missing" in fr`); count != 1 {
+		t.Errorf("Expected the missing key to be logged once, got: %q", buf.String())
+	}
+}
+
+func TestDefaultCatalog(t *testing.T) {
+	previous := Default()
+	defer SetDefault(previous)
+
+	SetDefault(newTestCatalog(t))
+	if result := T("fr", "welcome", "Ana"); result != "Bienvenue, Ana" {
+		t.Errorf("Expected the default catalog to be used, got: %q", result)
+	}
+	if result := TN("fr", "items", 2); result != "2 articles" {
+		t.Errorf("Expected the default catalog to be used, got: %q", result)
+	}
+}
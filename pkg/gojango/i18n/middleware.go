@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleContextKey is the gin context key holding the request locale
+const LocaleContextKey = "gojango_locale"
+
+type localeKey struct{}
+
+// WithLocale returns a context carrying locale
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext returns the locale stored in ctx, or "" if there is none
+func LocaleFromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
+
+// Locale returns the locale chosen for a request by Middleware
+func Locale(c *gin.Context) string {
+	return c.GetString(LocaleContextKey)
+}
+
+// Middleware picks each request's locale from its Accept-Language header,
+// using the catalog's default locale when none of the client's languages
+// have translations. The locale is stored in the gin context and the
+// request context, so templates rendered with the request context are
+// translated into it.
+func Middleware(catalog *Catalog) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := DetectLocale(catalog, c.GetHeader("Accept-Language"))
+
+		c.Set(LocaleContextKey, locale)
+		c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", locale)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
+
+// DetectLocale returns the catalog locale best matching an Accept-Language
+// header, or the catalog's default locale
+func DetectLocale(catalog *Catalog, acceptLanguage string) string {
+	for _, language := range parseAcceptLanguage(acceptLanguage) {
+		if language == "*" {
+			break
+		}
+		if locale := catalog.Match(language); locale != "" {
+			return locale
+		}
+	}
+	return catalog.DefaultLocale()
+}
+
+// parseAcceptLanguage returns the languages in an Accept-Language header,
+// most preferred first. Languages with q=0 are left out.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		language string
+		q        float64
+	}
+
+	var languages []weighted
+	for _, part := range strings.Split(header, ",") {
+		language, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		language = strings.TrimSpace(language)
+		if language == "" {
+			continue
+		}
+
+		q := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			languages = append(languages, weighted{language, q})
+		}
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].q > languages[j].q
+	})
+
+	result := make([]string, len(languages))
+	for i, language := range languages {
+		result[i] = language.language
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/templates"
+	"github.com/gin-gonic/gin"
+)
+
+func TestDetectLocale(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"", "en"},
+		{"fr", "fr"},
+		{"fr-CA,fr;q=0.9", "fr"},
+		{"pt-BR,pt;q=0.9", "pt-br"},
+		{"de,fr;q=0.8", "fr"},
+		{"en;q=0.5,fr;q=0.9", "fr"},
+		{"fr;q=0,ru", "ru"},
+		{"de,*", "en"},
+		{"xx;q=abc", "en"},
+	}
+	for _, tt := range tests {
+		if locale := DetectLocale(catalog, tt.header); locale != tt.expected {
+			t.Errorf("DetectLocale(%q) = %q, expected %q", tt.header, locale, tt.expected)
+		}
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	catalog := newTestCatalog(t)
+
+	router := gin.New()
+	router.Use(Middleware(catalog))
+	router.GET("/", func(c *gin.Context) {
+		if LocaleFromContext(c.Request.Context()) != Locale(c) {
+			t.Error("Expected the locale in both the gin and request contexts")
+		}
+		c.String(http.StatusOK, catalog.T(Locale(c), "welcome", "Ana"))
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Language", "fr-FR,fr;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "Bienvenue, Ana" {
+		t.Errorf("Expected a French response, got: %q", w.Body.String())
+	}
+	if language := w.Header().Get("Content-Language"); language != "fr" {
+		t.Errorf("Expected Content-Language fr, got: %q", language)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept-Language" {
+		t.Errorf("Expected Vary: Accept-Language, got: %q", vary)
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	catalog := newTestCatalog(t)
+	engine := templates.NewEngine()
+	engine.AddContextFuncs(TemplateFuncs(catalog))
+
+	dir := t.TempDir()
+	content := `{{ trans "welcome" .Name }} - {{ transn "items" .Count }} - {{ trans "<b>" }}`
+	if err := os.WriteFile(filepath.Join(dir, "page.html"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if err := engine.LoadGlobalTemplates(dir); err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+	data := map[string]interface{}{"Name": "Ana", "Count": 0}
+
+	result, err := engine.RenderContext(WithLocale(context.Background(), "fr"), "page.html", data)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if result != "Bienvenue, Ana - 0 article - &lt;b&gt;" {
+		t.Errorf("Unexpected French output: %q", result)
+	}
+
+	// Without a locale in the context the default locale is used
+	result, err = engine.Render("page.html", data)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if result != "Welcome, Ana - 0 items - &lt;b&gt;" {
+		t.Errorf("Unexpected default output: %q", result)
+	}
+}
@@ -0,0 +1,87 @@
+package i18n
+
+// PluralRule returns the CLDR plural category of a count
+type PluralRule func(n int) string
+
+// pluralRules maps languages to their plural rules. Languages not listed
+// use the English rule.
+var pluralRules = map[string]PluralRule{
+	"fr": pluralFrench,
+	"ja": pluralNone,
+	"ko": pluralNone,
+	"zh": pluralNone,
+	"vi": pluralNone,
+	"th": pluralNone,
+	"ru": pluralEastSlavic,
+	"uk": pluralEastSlavic,
+	"pl": pluralPolish,
+}
+
+// RegisterPluralRule sets the plural rule for a language, such as "cs"
+func RegisterPluralRule(language string, rule PluralRule) {
+	pluralRules[normalize(language)] = rule
+}
+
+// pluralForm returns the plural category of n in locale
+func pluralForm(locale string, n int) string {
+	for _, candidate := range candidates(normalize(locale)) {
+		if rule, ok := pluralRules[candidate]; ok {
+			return rule(n)
+		}
+	}
+	return pluralEnglish(n)
+}
+
+// pluralEnglish: 1 is "one", everything else "other"
+func pluralEnglish(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralFrench: 0 and 1 are "one"
+func pluralFrench(n int) string {
+	if n == 0 || n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// pluralNone is for languages without plural forms
+func pluralNone(n int) string {
+	return "other"
+}
+
+// pluralEastSlavic is the Russian and Ukrainian rule: 1, 21, 31... are
+// "one"; 2-4, 22-24... are "few"; the rest "many"
+func pluralEastSlavic(n int) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+	switch {
+	case mod10 == 1 && mod100 != 11:
+		return "one"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
+
+// pluralPolish: 1 is "one"; 2-4, 22-24... are "few"; the rest "many"
+func pluralPolish(n int) string {
+	if n < 0 {
+		n = -n
+	}
+	mod10, mod100 := n%10, n%100
+	switch {
+	case n == 1:
+		return "one"
+	case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+		return "few"
+	default:
+		return "many"
+	}
+}
@@ -0,0 +1,32 @@
+package i18n
+
+import (
+	"context"
+	"html/template"
+)
+
+// TemplateFuncs returns the translation template functions bound to the
+// locale in ctx, falling back to the catalog's default locale:
+//
+//	{{ trans "welcome" .User.Name }}
+//	{{ transn "items" .Count }}
+//
+// Register them with templates.Engine.AddContextFuncs so templates rendered
+// with a request context are translated into the request locale.
+func TemplateFuncs(catalog *Catalog) func(ctx context.Context) template.FuncMap {
+	return func(ctx context.Context) template.FuncMap {
+		locale := LocaleFromContext(ctx)
+		if locale == "" {
+			locale = catalog.DefaultLocale()
+		}
+
+		return template.FuncMap{
+			"trans": func(key string, args ...interface{}) string {
+				return catalog.T(locale, key, args...)
+			},
+			"transn": func(key string, count int, args ...interface{}) string {
+				return catalog.TN(locale, key, count, args...)
+			},
+		}
+	}
+}
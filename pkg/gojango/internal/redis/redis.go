@@ -0,0 +1,212 @@
+// Package redis is a minimal Redis client shared by the framework's Redis
+// backends, such as the jobs queue and the cache.
+package redis
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// commandTimeout bounds commands whose context has no deadline
+const commandTimeout = 10 * time.Second
+
+// Error is an error reply from the Redis server
+type Error string
+
+func (e Error) Error() string {
+	return "redis: " + string(e)
+}
+
+// Client is a minimal Redis client speaking RESP over a single connection.
+// Commands are serialized; the commands of its users are short, so a pool
+// is not needed. A connection that fails is dropped and redialed on the
+// next command.
+type Client struct {
+	addr     string
+	password string
+	db       int
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewClient parses a redis:// URL. The connection is opened on first use.
+func NewClient(rawURL string) (*Client, error) {
+	if rawURL == "" {
+		rawURL = "redis://localhost:6379/0"
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("unsupported Redis URL scheme: %s", u.Scheme)
+	}
+
+	client := &Client{addr: u.Host}
+	if u.Port() == "" {
+		client.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			client.password = password
+		} else {
+			client.password = u.User.Username()
+		}
+	}
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		client.db, err = strconv.Atoi(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis database: %s", path)
+		}
+	}
+	return client, nil
+}
+
+// Do sends a command and returns its reply: a string, an int64, nil, a
+// []interface{} of replies, or an Error
+func (c *Client) Do(ctx context.Context, args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		if err := c.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := c.roundTrip(ctx, args)
+	var replyErr Error
+	if err != nil && !errors.As(err, &replyErr) {
+		c.conn.Close()
+		c.conn = nil
+	}
+	return reply, err
+}
+
+func (c *Client) connect(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Redis at %s: %w", c.addr, err)
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+
+	setup := [][]string{}
+	if c.password != "" {
+		setup = append(setup, []string{"AUTH", c.password})
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	for _, args := range setup {
+		if _, err := c.roundTrip(ctx, args); err != nil {
+			conn.Close()
+			c.conn = nil
+			return fmt.Errorf("failed to set up Redis connection: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) roundTrip(ctx context.Context, args []string) (interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(commandTimeout)
+	}
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(c.conn, args); err != nil {
+		return nil, err
+	}
+	return readReply(c.reader)
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// writeCommand encodes a command as a RESP array of bulk strings
+func writeCommand(w io.Writer, args []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// readReply decodes one RESP reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("invalid Redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, Error(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis bulk length: %s", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid Redis array length: %s", line)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = readReply(r); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("unknown Redis reply type: %q", line[0])
+	}
+}
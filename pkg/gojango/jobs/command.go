@@ -0,0 +1,100 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/spf13/cobra"
+)
+
+// QueueFromSettings creates the queue configured by JOBS_BACKEND ("memory"
+// or "redis") and REDIS_URL
+func QueueFromSettings(settings gojango.Settings) (Queue, error) {
+	backend := settings.GetString("JOBS_BACKEND", "memory")
+	return NewQueue(backend, settings.GetString("REDIS_URL", ""))
+}
+
+// Configure loads a settings file, overridden by environment variables, and
+// makes the queue it configures the default queue used by Enqueue. Servers
+// and workers call it at startup so they share the same queue.
+func Configure(settingsFile string) (Queue, error) {
+	settings := gojango.NewSettingsStack()
+	if err := settings.LoadFile(settingsFile); err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+	settings.LoadEnv()
+
+	queue, err := QueueFromSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	SetDefaultQueue(queue)
+	return queue, nil
+}
+
+// NewCommand returns the "jobs" command for a project's manage.go
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Background job management",
+	}
+
+	cmd.AddCommand(newWorkerCommand())
+	return cmd
+}
+
+func newWorkerCommand() *cobra.Command {
+	var settingsFile string
+	var concurrency int
+	var maxRetries int
+	var pollInterval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "worker",
+		Short: "Run a background job worker",
+		Long: `Run a worker that executes queued background jobs.
+
+The queue is configured by JOBS_BACKEND and REDIS_URL in the settings file.
+Workers run until interrupted; jobs that keep failing are moved to the
+dead-letter list after the configured number of retries.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			queue, err := Configure(settingsFile)
+			if err != nil {
+				return err
+			}
+			if _, ok := queue.(*MemoryQueue); ok {
+				fmt.Println("⚠️  JOBS_BACKEND is \"memory\": this worker only sees jobs enqueued in its own process")
+			}
+
+			tasks := DefaultRegistry.Names()
+			if len(tasks) == 0 {
+				fmt.Println("⚠️  No tasks registered")
+			} else {
+				fmt.Printf("Registered tasks: %s\n", strings.Join(tasks, ", "))
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			fmt.Printf("Starting worker with concurrency %d. Quit with CONTROL-C.\n", concurrency)
+			return NewWorker(queue,
+				WithConcurrency(concurrency),
+				WithMaxRetries(maxRetries),
+				WithPollInterval(pollInterval),
+			).Run(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&settingsFile, "settings", "config/settings.star", "Settings file configuring the queue")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", DefaultConcurrency, "Number of jobs to run at the same time")
+	cmd.Flags().IntVar(&maxRetries, "max-retries", DefaultMaxRetries, "Retries before a failing job is dead-lettered")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", DefaultPollInterval, "How often an idle worker checks for jobs")
+
+	return cmd
+}
@@ -0,0 +1,170 @@
+// Package jobs runs tasks in the background, outside the request cycle.
+//
+// Tasks are registered by name, usually from an app's init function, and
+// enqueued with a JSON-serializable payload:
+//
+//	jobs.Register("send_welcome_email", func(ctx context.Context, payload json.RawMessage) error {
+//		var p struct{ UserID int `json:"user_id"` }
+//		if err := json.Unmarshal(payload, &p); err != nil {
+//			return err
+//		}
+//		return sendWelcomeEmail(ctx, p.UserID)
+//	})
+//
+//	jobs.Enqueue(ctx, "send_welcome_email", map[string]int{"user_id": user.ID})
+//
+// A Worker polls a Queue and executes jobs, retrying failures with backoff
+// and moving jobs that keep failing to a dead-letter list. The in-memory
+// queue suits development and tests; production deployments use Redis so
+// jobs survive restarts and are shared between the server and workers.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskFunc executes a job with its JSON payload
+type TaskFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Job is a unit of work waiting in a queue
+type Job struct {
+	ID         string          `json:"id"`
+	Name       string          `json:"name"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	Attempts   int             `json:"attempts"`
+	LastError  string          `json:"last_error,omitempty"`
+	EnqueuedAt time.Time       `json:"enqueued_at"`
+	// RunAt delays the job until the given time, as used for retries
+	RunAt time.Time `json:"run_at,omitempty"`
+}
+
+// NewJob creates a job for a task, encoding payload as JSON
+func NewJob(name string, payload interface{}) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload for job %s: %w", name, err)
+	}
+
+	return &Job{
+		ID:         uuid.NewString(),
+		Name:       name,
+		Payload:    data,
+		EnqueuedAt: time.Now(),
+	}, nil
+}
+
+// ready reports whether the job may run at the given time
+func (j *Job) ready(now time.Time) bool {
+	return j.RunAt.IsZero() || !j.RunAt.After(now)
+}
+
+// Queue stores jobs until a worker takes them
+type Queue interface {
+	// Push adds a job, which becomes available once its RunAt has passed
+	Push(ctx context.Context, job *Job) error
+	// Pop removes and returns the next available job, or nil if none is ready
+	Pop(ctx context.Context) (*Job, error)
+	// DeadLetter stores a job that failed permanently
+	DeadLetter(ctx context.Context, job *Job) error
+	// DeadLetters returns the dead-lettered jobs, oldest first
+	DeadLetters(ctx context.Context) ([]*Job, error)
+}
+
+// NewQueue creates a queue for a backend: "memory" or "redis"
+func NewQueue(backend, redisURL string) (Queue, error) {
+	switch backend {
+	case "", "memory":
+		return NewMemoryQueue(), nil
+	case "redis":
+		return NewRedisQueue(redisURL)
+	default:
+		return nil, fmt.Errorf("unknown jobs backend: %s", backend)
+	}
+}
+
+// Registry maps task names to their functions
+type Registry struct {
+	mu    sync.RWMutex
+	tasks map[string]TaskFunc
+}
+
+// NewRegistry creates an empty task registry
+func NewRegistry() *Registry {
+	return &Registry{tasks: make(map[string]TaskFunc)}
+}
+
+// Register adds a task, replacing any task with the same name
+func (r *Registry) Register(name string, fn TaskFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks[name] = fn
+}
+
+// Get returns the task registered under name
+func (r *Registry) Get(name string) (TaskFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fn, exists := r.tasks[name]
+	return fn, exists
+}
+
+// Names returns the registered task names in sorted order
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.tasks))
+	for name := range r.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultRegistry holds the tasks added with Register
+var DefaultRegistry = NewRegistry()
+
+// Register adds a task to the default registry
+func Register(name string, fn TaskFunc) {
+	DefaultRegistry.Register(name, fn)
+}
+
+var (
+	defaultQueueMu sync.RWMutex
+	defaultQueue   Queue = NewMemoryQueue()
+)
+
+// SetDefaultQueue sets the queue used by Enqueue
+func SetDefaultQueue(queue Queue) {
+	defaultQueueMu.Lock()
+	defer defaultQueueMu.Unlock()
+	defaultQueue = queue
+}
+
+// DefaultQueue returns the queue used by Enqueue. It is an in-memory queue
+// unless configured otherwise.
+func DefaultQueue() Queue {
+	defaultQueueMu.RLock()
+	defer defaultQueueMu.RUnlock()
+	return defaultQueue
+}
+
+// Enqueue adds a job for the named task to the default queue
+func Enqueue(ctx context.Context, name string, payload interface{}) (*Job, error) {
+	job, err := NewJob(name, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := DefaultQueue().Push(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to enqueue job %s: %w", name, err)
+	}
+	return job, nil
+}
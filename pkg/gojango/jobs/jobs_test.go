@@ -0,0 +1,285 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func noBackoff(int) time.Duration { return 0 }
+
+func pushJob(t *testing.T, queue Queue, name string, payload interface{}) *Job {
+	t.Helper()
+	job, err := NewJob(name, payload)
+	if err != nil {
+		t.Fatalf("NewJob failed: %v", err)
+	}
+	if err := queue.Push(context.Background(), job); err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	return job
+}
+
+// drain processes jobs until the queue has none ready
+func drain(t *testing.T, worker *Worker) int {
+	t.Helper()
+	processed := 0
+	for {
+		ok, err := worker.ProcessNext(context.Background())
+		if err != nil {
+			t.Fatalf("ProcessNext failed: %v", err)
+		}
+		if !ok {
+			return processed
+		}
+		processed++
+	}
+}
+
+func TestEnqueueAndExecute(t *testing.T) {
+	queue := NewMemoryQueue()
+	registry := NewRegistry()
+
+	var got []int
+	registry.Register("add", func(ctx context.Context, payload json.RawMessage) error {
+		var p struct{ A, B int }
+		if err := json.Unmarshal(payload, &p); err != nil {
+			return err
+		}
+		got = append(got, p.A+p.B)
+		return nil
+	})
+
+	pushJob(t, queue, "add", map[string]int{"A": 1, "B": 2})
+	pushJob(t, queue, "add", map[string]int{"A": 3, "B": 4})
+
+	worker := NewWorker(queue, WithRegistry(registry))
+	if n := drain(t, worker); n != 2 {
+		t.Errorf("Expected 2 jobs processed, got %d", n)
+	}
+	if len(got) != 2 || got[0] != 3 || got[1] != 7 {
+		t.Errorf("Expected jobs executed in order with results [3 7], got %v", got)
+	}
+	if queue.Len() != 0 {
+		t.Errorf("Expected an empty queue, got %d jobs", queue.Len())
+	}
+}
+
+func TestEnqueueUsesDefaultQueue(t *testing.T) {
+	queue := NewMemoryQueue()
+	previous := DefaultQueue()
+	SetDefaultQueue(queue)
+	defer SetDefaultQueue(previous)
+
+	job, err := Enqueue(context.Background(), "welcome", map[string]string{"email": "john@example.com"})
+	if err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if job.ID == "" || job.Name != "welcome" {
+		t.Errorf("Expected a named job with an ID, got %+v", job)
+	}
+	if string(job.Payload) != `{"email":"john@example.com"}` {
+		t.Errorf("Expected JSON payload, got %s", job.Payload)
+	}
+	if queue.Len() != 1 {
+		t.Errorf("Expected the job on the default queue, got %d jobs", queue.Len())
+	}
+}
+
+func TestFailedJobIsRetried(t *testing.T) {
+	queue := NewMemoryQueue()
+	registry := NewRegistry()
+
+	attempts := 0
+	registry.Register("flaky", func(ctx context.Context, payload json.RawMessage) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	pushJob(t, queue, "flaky", nil)
+
+	worker := NewWorker(queue, WithRegistry(registry), WithMaxRetries(3), WithBackoff(noBackoff))
+	drain(t, worker)
+
+	if attempts != 3 {
+		t.Errorf("Expected the job to run until it succeeded on attempt 3, got %d attempts", attempts)
+	}
+	dead, _ := queue.DeadLetters(context.Background())
+	if len(dead) != 0 {
+		t.Errorf("Expected no dead letters, got %d", len(dead))
+	}
+}
+
+func TestRetryWaitsForBackoff(t *testing.T) {
+	queue := NewMemoryQueue()
+	registry := NewRegistry()
+	registry.Register("failing", func(ctx context.Context, payload json.RawMessage) error {
+		return errors.New("boom")
+	})
+	pushJob(t, queue, "failing", nil)
+
+	worker := NewWorker(queue, WithRegistry(registry), WithBackoff(func(int) time.Duration { return time.Hour }))
+	if n := drain(t, worker); n != 1 {
+		t.Errorf("Expected the delayed retry not to run yet, processed %d jobs", n)
+	}
+	if queue.Len() != 1 {
+		t.Fatalf("Expected the job to be requeued, got %d jobs", queue.Len())
+	}
+
+	job := queue.jobs[0]
+	if job.Attempts != 1 || job.LastError != "boom" {
+		t.Errorf("Expected attempt and error to be recorded, got %+v", job)
+	}
+	if time.Until(job.RunAt) < 59*time.Minute {
+		t.Errorf("Expected the retry to be delayed by the backoff, runs at %v", job.RunAt)
+	}
+}
+
+func TestJobIsDeadLetteredAfterMaxRetries(t *testing.T) {
+	queue := NewMemoryQueue()
+	registry := NewRegistry()
+
+	attempts := 0
+	registry.Register("broken", func(ctx context.Context, payload json.RawMessage) error {
+		attempts++
+		return errors.New("permanent failure")
+	})
+	job := pushJob(t, queue, "broken", nil)
+
+	worker := NewWorker(queue, WithRegistry(registry), WithMaxRetries(2), WithBackoff(noBackoff))
+	drain(t, worker)
+
+	if attempts != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d attempts", attempts)
+	}
+	dead, err := queue.DeadLetters(context.Background())
+	if err != nil {
+		t.Fatalf("DeadLetters failed: %v", err)
+	}
+	if len(dead) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d", len(dead))
+	}
+	if dead[0].ID != job.ID || dead[0].Attempts != 3 || dead[0].LastError != "permanent failure" {
+		t.Errorf("Unexpected dead letter: %+v", dead[0])
+	}
+	if queue.Len() != 0 {
+		t.Errorf("Expected the job to leave the queue, got %d jobs", queue.Len())
+	}
+}
+
+func TestUnknownAndPanickingTasksAreDeadLettered(t *testing.T) {
+	queue := NewMemoryQueue()
+	registry := NewRegistry()
+	registry.Register("panics", func(ctx context.Context, payload json.RawMessage) error {
+		panic("unexpected")
+	})
+	pushJob(t, queue, "missing", nil)
+	pushJob(t, queue, "panics", nil)
+
+	worker := NewWorker(queue, WithRegistry(registry), WithMaxRetries(0))
+	drain(t, worker)
+
+	dead, _ := queue.DeadLetters(context.Background())
+	if len(dead) != 2 {
+		t.Fatalf("Expected 2 dead letters, got %d", len(dead))
+	}
+	if dead[0].LastError != "unknown task: missing" {
+		t.Errorf("Expected unknown task error, got %q", dead[0].LastError)
+	}
+	if dead[1].LastError != "task panicked: unexpected" {
+		t.Errorf("Expected panic error, got %q", dead[1].LastError)
+	}
+}
+
+func TestWorkerRunConcurrency(t *testing.T) {
+	queue := NewMemoryQueue()
+	registry := NewRegistry()
+
+	var running, peak, done int32
+	var wg sync.WaitGroup
+	registry.Register("slow", func(ctx context.Context, payload json.RawMessage) error {
+		defer wg.Done()
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&done, 1)
+		return nil
+	})
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		pushJob(t, queue, "slow", i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	worker := NewWorker(queue, WithRegistry(registry), WithConcurrency(3), WithPollInterval(5*time.Millisecond))
+	finished := make(chan struct{})
+	go func() {
+		worker.Run(ctx)
+		close(finished)
+	}()
+
+	wg.Wait()
+	cancel()
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Run to return after cancellation")
+	}
+
+	if done != 6 {
+		t.Errorf("Expected 6 jobs done, got %d", done)
+	}
+	if peak > 3 {
+		t.Errorf("Expected at most 3 concurrent jobs, got %d", peak)
+	}
+	if peak < 2 {
+		t.Errorf("Expected jobs to run concurrently, peak was %d", peak)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(time.Second, 10*time.Second)
+	expected := map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 8 * time.Second,
+		5: 10 * time.Second,
+		9: 10 * time.Second,
+	}
+	for attempts, delay := range expected {
+		if got := backoff(attempts); got != delay {
+			t.Errorf("Expected backoff(%d) = %v, got %v", attempts, delay, got)
+		}
+	}
+}
+
+func TestNewQueue(t *testing.T) {
+	if q, err := NewQueue("memory", ""); err != nil {
+		t.Errorf("Expected memory queue, got error: %v", err)
+	} else if _, ok := q.(*MemoryQueue); !ok {
+		t.Errorf("Expected *MemoryQueue, got %T", q)
+	}
+
+	if q, err := NewQueue("redis", "redis://localhost:6379/0"); err != nil {
+		t.Errorf("Expected redis queue, got error: %v", err)
+	} else if _, ok := q.(*RedisQueue); !ok {
+		t.Errorf("Expected *RedisQueue, got %T", q)
+	}
+
+	if _, err := NewQueue("rabbitmq", ""); err == nil {
+		t.Error("Expected an error for an unknown backend")
+	}
+}
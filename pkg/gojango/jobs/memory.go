@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryQueue keeps jobs in process memory. Jobs are lost when the process
+// exits and are only visible to workers in the same process, so it is meant
+// for development and tests.
+type MemoryQueue struct {
+	mu   sync.Mutex
+	jobs []*Job
+	dead []*Job
+}
+
+// NewMemoryQueue creates an empty in-memory queue
+func NewMemoryQueue() *MemoryQueue {
+	return &MemoryQueue{}
+}
+
+// Push adds a job to the end of the queue
+func (q *MemoryQueue) Push(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+// Pop removes the oldest job that is ready to run
+func (q *MemoryQueue) Pop(ctx context.Context) (*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	for i, job := range q.jobs {
+		if job.ready(now) {
+			q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeadLetter stores a job that failed permanently
+func (q *MemoryQueue) DeadLetter(ctx context.Context, job *Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.dead = append(q.dead, job)
+	return nil
+}
+
+// DeadLetters returns the dead-lettered jobs, oldest first
+func (q *MemoryQueue) DeadLetters(ctx context.Context) ([]*Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return append([]*Job(nil), q.dead...), nil
+}
+
+// Len returns the number of queued jobs, including delayed ones
+func (q *MemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.jobs)
+}
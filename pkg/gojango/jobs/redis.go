@@ -0,0 +1,135 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango/internal/redis"
+)
+
+// DefaultRedisKeyPrefix prefixes the keys used by RedisQueue
+const DefaultRedisKeyPrefix = "gojango:jobs"
+
+// promoteScript atomically moves due jobs from the scheduled sorted set to
+// the ready list, so concurrent workers never promote a job twice
+const promoteScript = `
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, 100)
+for _, job in ipairs(due) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('LPUSH', KEYS[2], job)
+end
+return #due`
+
+// RedisQueue stores jobs in Redis. Ready jobs are kept in a list, delayed
+// jobs in a sorted set scored by their run time, and failed jobs in a
+// dead-letter list, all under a common key prefix. A job is removed from
+// Redis when a worker takes it, so jobs running when a worker crashes are
+// not retried.
+type RedisQueue struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisQueue creates a queue for a Redis URL such as
+// redis://:password@localhost:6379/0. The connection is opened on first use.
+func NewRedisQueue(redisURL string) (*RedisQueue, error) {
+	client, err := redis.NewClient(redisURL)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisQueue{client: client, prefix: DefaultRedisKeyPrefix}, nil
+}
+
+// SetKeyPrefix changes the prefix of the queue's keys, so several projects
+// can share a Redis database
+func (q *RedisQueue) SetKeyPrefix(prefix string) *RedisQueue {
+	q.prefix = prefix
+	return q
+}
+
+func (q *RedisQueue) key(name string) string {
+	return q.prefix + ":" + name
+}
+
+// Push adds a job to the ready list, or to the scheduled set when it is delayed
+func (q *RedisQueue) Push(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+
+	if !job.ready(time.Now()) {
+		score := strconv.FormatInt(job.RunAt.UnixMilli(), 10)
+		_, err = q.client.Do(ctx, "ZADD", q.key("scheduled"), score, string(data))
+		return err
+	}
+	_, err = q.client.Do(ctx, "LPUSH", q.key("ready"), string(data))
+	return err
+}
+
+// Pop promotes due scheduled jobs and removes the oldest ready job
+func (q *RedisQueue) Pop(ctx context.Context) (*Job, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	if _, err := q.client.Do(ctx, "EVAL", promoteScript, "2", q.key("scheduled"), q.key("ready"), now); err != nil {
+		return nil, fmt.Errorf("failed to promote scheduled jobs: %w", err)
+	}
+
+	reply, err := q.client.Do(ctx, "RPOP", q.key("ready"))
+	if err != nil || reply == nil {
+		return nil, err
+	}
+	return decodeRedisJob(reply)
+}
+
+// DeadLetter appends a job to the dead-letter list
+func (q *RedisQueue) DeadLetter(ctx context.Context, job *Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+	_, err = q.client.Do(ctx, "RPUSH", q.key("dead"), string(data))
+	return err
+}
+
+// DeadLetters returns the dead-lettered jobs, oldest first
+func (q *RedisQueue) DeadLetters(ctx context.Context) ([]*Job, error) {
+	reply, err := q.client.Do(ctx, "LRANGE", q.key("dead"), "0", "-1")
+	if err != nil {
+		return nil, err
+	}
+
+	items, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected LRANGE reply: %T", reply)
+	}
+	jobs := make([]*Job, 0, len(items))
+	for _, item := range items {
+		job, err := decodeRedisJob(item)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// Close closes the Redis connection
+func (q *RedisQueue) Close() error {
+	return q.client.Close()
+}
+
+func decodeRedisJob(reply interface{}) (*Job, error) {
+	data, ok := reply.(string)
+	if !ok {
+		return nil, fmt.Errorf("unexpected job reply: %T", reply)
+	}
+
+	var job Job
+	if err := json.Unmarshal([]byte(data), &job); err != nil {
+		return nil, fmt.Errorf("failed to decode job: %w", err)
+	}
+	return &job, nil
+}
@@ -0,0 +1,201 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Worker defaults
+const (
+	DefaultConcurrency  = 4
+	DefaultMaxRetries   = 3
+	DefaultPollInterval = time.Second
+)
+
+// Backoff returns how long to wait before retrying a job that has failed
+// the given number of times
+type Backoff func(attempts int) time.Duration
+
+// ExponentialBackoff doubles the delay after each failure, starting at base
+// and capped at maxDelay
+func ExponentialBackoff(base, maxDelay time.Duration) Backoff {
+	return func(attempts int) time.Duration {
+		delay := base
+		for i := 1; i < attempts && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		return delay
+	}
+}
+
+// Worker polls a queue and executes jobs with their registered tasks
+type Worker struct {
+	queue        Queue
+	registry     *Registry
+	concurrency  int
+	maxRetries   int
+	backoff      Backoff
+	pollInterval time.Duration
+}
+
+// WorkerOption configures a Worker
+type WorkerOption func(*Worker)
+
+// WithConcurrency sets how many jobs run at the same time
+func WithConcurrency(n int) WorkerOption {
+	return func(w *Worker) {
+		if n > 0 {
+			w.concurrency = n
+		}
+	}
+}
+
+// WithMaxRetries sets how many times a failed job is retried before it is
+// dead-lettered
+func WithMaxRetries(n int) WorkerOption {
+	return func(w *Worker) {
+		if n >= 0 {
+			w.maxRetries = n
+		}
+	}
+}
+
+// WithBackoff sets the delay between retries
+func WithBackoff(backoff Backoff) WorkerOption {
+	return func(w *Worker) {
+		w.backoff = backoff
+	}
+}
+
+// WithPollInterval sets how long an idle worker waits before polling again
+func WithPollInterval(interval time.Duration) WorkerOption {
+	return func(w *Worker) {
+		if interval > 0 {
+			w.pollInterval = interval
+		}
+	}
+}
+
+// WithRegistry sets the tasks the worker executes, instead of DefaultRegistry
+func WithRegistry(registry *Registry) WorkerOption {
+	return func(w *Worker) {
+		w.registry = registry
+	}
+}
+
+// NewWorker creates a worker for a queue
+func NewWorker(queue Queue, opts ...WorkerOption) *Worker {
+	w := &Worker{
+		queue:        queue,
+		registry:     DefaultRegistry,
+		concurrency:  DefaultConcurrency,
+		maxRetries:   DefaultMaxRetries,
+		backoff:      ExponentialBackoff(time.Second, 5*time.Minute),
+		pollInterval: DefaultPollInterval,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Run processes jobs until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.poll(ctx)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// poll processes jobs back to back, sleeping when the queue is empty
+func (w *Worker) poll(ctx context.Context) {
+	for ctx.Err() == nil {
+		processed, err := w.ProcessNext(ctx)
+		if err != nil {
+			log.Printf("jobs: %v", err)
+		}
+		if processed && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(w.pollInterval):
+		}
+	}
+}
+
+// ProcessNext takes one job from the queue and executes it. It reports
+// whether a job was taken; task failures are handled by retrying or
+// dead-lettering the job and are not returned.
+func (w *Worker) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := w.queue.Pop(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to take job: %w", err)
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	// A taken job is requeued or dead-lettered even if the worker is
+	// stopping, so it is not lost
+	queueCtx := context.WithoutCancel(ctx)
+
+	task, exists := w.registry.Get(job.Name)
+	if !exists {
+		job.Attempts++
+		job.LastError = fmt.Sprintf("unknown task: %s", job.Name)
+		return true, w.deadLetter(queueCtx, job)
+	}
+
+	if err := runTask(ctx, task, job); err != nil {
+		return true, w.fail(queueCtx, job, err)
+	}
+	return true, nil
+}
+
+// runTask executes a task, turning a panic into an error
+func runTask(ctx context.Context, task TaskFunc, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+	return task(ctx, job.Payload)
+}
+
+// fail schedules a retry, or dead-letters the job once retries are exhausted
+func (w *Worker) fail(ctx context.Context, job *Job, taskErr error) error {
+	job.Attempts++
+	job.LastError = taskErr.Error()
+
+	if job.Attempts > w.maxRetries {
+		return w.deadLetter(ctx, job)
+	}
+
+	job.RunAt = time.Now().Add(w.backoff(job.Attempts))
+	if err := w.queue.Push(ctx, job); err != nil {
+		return fmt.Errorf("failed to requeue job %s (%s): %w", job.ID, job.Name, err)
+	}
+	return nil
+}
+
+func (w *Worker) deadLetter(ctx context.Context, job *Job) error {
+	log.Printf("jobs: job %s (%s) failed after %d attempts: %s", job.ID, job.Name, job.Attempts, job.LastError)
+	if err := w.queue.DeadLetter(ctx, job); err != nil {
+		return fmt.Errorf("failed to dead-letter job %s (%s): %w", job.ID, job.Name, err)
+	}
+	return nil
+}
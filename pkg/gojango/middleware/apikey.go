@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrincipalKey is the context key under which authenticated principals are stored
+const PrincipalKey = "principal"
+
+// APIKeyConfig configures the APIKey middleware
+type APIKeyConfig struct {
+	// Header is the request header carrying the key (default "X-API-Key")
+	Header string
+
+	// Keys maps valid API keys to the principal they authenticate
+	Keys map[string]interface{}
+
+	// KeyLookup resolves keys not found in Keys, e.g. from a database.
+	// It returns the principal and whether the key is valid.
+	KeyLookup func(key string) (interface{}, bool)
+}
+
+// APIKey authenticates requests using a static API key header. The principal
+// associated with the key is stored in the context under PrincipalKey.
+// Requests with a missing or invalid key are rejected with 401.
+func APIKey(config APIKeyConfig) gin.HandlerFunc {
+	header := config.Header
+	if header == "" {
+		header = "X-API-Key"
+	}
+
+	// Copy keys so later changes to the caller's map don't race with requests
+	keys := make([]string, 0, len(config.Keys))
+	principals := make([]interface{}, 0, len(config.Keys))
+	for key, principal := range config.Keys {
+		keys = append(keys, key)
+		principals = append(principals, principal)
+	}
+
+	return func(c *gin.Context) {
+		provided := c.GetHeader(header)
+		if provided == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key required"})
+			return
+		}
+
+		principal, ok := matchAPIKey(keys, principals, provided)
+		if !ok && config.KeyLookup != nil {
+			principal, ok = config.KeyLookup(provided)
+		}
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+			return
+		}
+
+		c.Set(PrincipalKey, principal)
+		c.Next()
+	}
+}
+
+// matchAPIKey compares the provided key against every configured key without
+// returning early, so response timing doesn't reveal how much of a key matched
+// or which key it matched
+func matchAPIKey(keys []string, principals []interface{}, provided string) (interface{}, bool) {
+	var principal interface{}
+	found := 0
+	for i, key := range keys {
+		if subtle.ConstantTimeCompare([]byte(key), []byte(provided)) == 1 {
+			principal = principals[i]
+			found = 1
+		}
+	}
+	return principal, found == 1
+}
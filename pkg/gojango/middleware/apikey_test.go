@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newAPIKeyRouter(config APIKeyConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(APIKey(config))
+	router.GET("/test", func(c *gin.Context) {
+		principal, _ := c.Get(PrincipalKey)
+		c.String(200, "%v", principal)
+	})
+	return router
+}
+
+func doAPIKeyRequest(router *gin.Engine, header, key string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	if key != "" {
+		req.Header.Set(header, key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyValid(t *testing.T) {
+	router := newAPIKeyRouter(APIKeyConfig{
+		Keys: map[string]interface{}{"secret-key": "billing-service"},
+	})
+
+	w := doAPIKeyRequest(router, "X-API-Key", "secret-key")
+	if w.Code != 200 {
+		t.Errorf("Expected status 200 for valid key, got: %d", w.Code)
+	}
+	if w.Body.String() != "billing-service" {
+		t.Errorf("Expected principal 'billing-service', got: %s", w.Body.String())
+	}
+}
+
+func TestAPIKeyInvalid(t *testing.T) {
+	router := newAPIKeyRouter(APIKeyConfig{
+		Keys: map[string]interface{}{"secret-key": "billing-service"},
+	})
+
+	if w := doAPIKeyRequest(router, "X-API-Key", "wrong-key"); w.Code != 401 {
+		t.Errorf("Expected status 401 for invalid key, got: %d", w.Code)
+	}
+	if w := doAPIKeyRequest(router, "X-API-Key", ""); w.Code != 401 {
+		t.Errorf("Expected status 401 for missing key, got: %d", w.Code)
+	}
+}
+
+func TestAPIKeyCustomHeaderAndLookup(t *testing.T) {
+	router := newAPIKeyRouter(APIKeyConfig{
+		Header: "X-Service-Token",
+		KeyLookup: func(key string) (interface{}, bool) {
+			if key == "db-key" {
+				return "reporting-service", true
+			}
+			return nil, false
+		},
+	})
+
+	w := doAPIKeyRequest(router, "X-Service-Token", "db-key")
+	if w.Code != 200 || w.Body.String() != "reporting-service" {
+		t.Errorf("Expected lookup key to authenticate, got: %d %s", w.Code, w.Body.String())
+	}
+
+	if w := doAPIKeyRequest(router, "X-API-Key", "db-key"); w.Code != 401 {
+		t.Errorf("Expected status 401 when key sent in the wrong header, got: %d", w.Code)
+	}
+}
+
+func TestAPIKeyMultipleKeys(t *testing.T) {
+	router := newAPIKeyRouter(APIKeyConfig{
+		Keys: map[string]interface{}{
+			"key-one":   "one",
+			"key-two":   "two",
+			"key-three": "three",
+		},
+	})
+
+	// Every configured key authenticates its own principal
+	for key, principal := range map[string]string{"key-one": "one", "key-two": "two", "key-three": "three"} {
+		w := doAPIKeyRequest(router, "X-API-Key", key)
+		if w.Code != 200 || w.Body.String() != principal {
+			t.Errorf("Expected %s to authenticate %s, got: %d %s", key, principal, w.Code, w.Body.String())
+		}
+	}
+
+	// Prefixes and extensions of a key don't match it
+	for _, key := range []string{"key-", "key-one-extra", "KEY-ONE"} {
+		if w := doAPIKeyRequest(router, "X-API-Key", key); w.Code != 401 {
+			t.Errorf("Expected status 401 for %q, got: %d", key, w.Code)
+		}
+	}
+}
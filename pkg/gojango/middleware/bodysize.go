@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodySize limits request bodies to limit bytes. Requests declaring a
+// larger Content-Length are rejected with 413 before the handler runs.
+// Other bodies are wrapped with http.MaxBytesReader, so reading past the
+// limit fails with an *http.MaxBytesError; the request is then answered with
+// 413 unless the handler has already responded. Handlers that respond to
+// read errors themselves can check for that error to do the same.
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			abortBodyTooLarge(c)
+			return
+		}
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, limit)}
+		c.Request.Body = body
+		c.Next()
+
+		if body.exceeded && !c.Writer.Written() {
+			abortBodyTooLarge(c)
+		}
+	}
+}
+
+func abortBodyTooLarge(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": "Request body too large"})
+}
+
+// limitedBody records whether reading a body failed for exceeding its limit
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		b.exceeded = true
+	}
+	return n, err
+}
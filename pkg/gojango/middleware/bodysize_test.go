@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newBodySizeRouter(limit int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MaxBodySize(limit))
+	router.POST("/upload", func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			// Leave the response to the middleware
+			c.Error(err)
+			return
+		}
+		c.String(http.StatusOK, "%d", len(body))
+	})
+	router.POST("/bind", func(c *gin.Context) {
+		var data map[string]interface{}
+		if err := c.ShouldBindJSON(&data); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.String(http.StatusRequestEntityTooLarge, "limit %d", tooLarge.Limit)
+				return
+			}
+			c.String(http.StatusBadRequest, "bad request")
+			return
+		}
+		c.String(http.StatusOK, "ok")
+	})
+	return router
+}
+
+func TestMaxBodySizeAllowsSmallBodies(t *testing.T) {
+	router := newBodySizeRouter(16)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/upload", strings.NewReader("0123456789abcdef")))
+	if w.Code != 200 || w.Body.String() != "16" {
+		t.Errorf("Expected a body at the limit to be read, got %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestMaxBodySizeRejectsContentLength(t *testing.T) {
+	router := newBodySizeRouter(16)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/upload", strings.NewReader(strings.Repeat("x", 17))))
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for a large Content-Length, got: %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Request body too large") {
+		t.Errorf("Expected an error message, got: %s", w.Body.String())
+	}
+}
+
+func TestMaxBodySizeRejectsStreamedBodies(t *testing.T) {
+	router := newBodySizeRouter(16)
+
+	// Chunked bodies have no Content-Length, so the limit applies on read
+	req := httptest.NewRequest("POST", "/upload", strings.NewReader(strings.Repeat("x", 64)))
+	req.ContentLength = -1
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("Expected status 413 for a streamed body over the limit, got: %d", w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/bind", strings.NewReader(`{"name": "`+strings.Repeat("x", 64)+`"}`))
+	req.ContentLength = -1
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusRequestEntityTooLarge || w.Body.String() != "limit 16" {
+		t.Errorf("Expected the handler to see the limit error, got: %d %q", w.Code, w.Body.String())
+	}
+}
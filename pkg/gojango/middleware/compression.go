@@ -0,0 +1,307 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCompressibleTypes are the content types compressed by default.
+// Entries ending in "/*" match a whole media type.
+var DefaultCompressibleTypes = []string{
+	"text/*",
+	"application/json",
+	"application/javascript",
+	"application/x-javascript",
+	"application/xml",
+	"application/wasm",
+	"image/svg+xml",
+}
+
+// CompressionConfig configures the Compression middleware
+type CompressionConfig struct {
+	// MinSize is the smallest response body, in bytes, worth compressing
+	// (default 1024)
+	MinSize int
+
+	// ContentTypes lists the compressible content types
+	// (default DefaultCompressibleTypes)
+	ContentTypes []string
+
+	// Encodings lists the supported encodings in order of preference
+	// (default "br", "gzip")
+	Encodings []string
+
+	// GzipLevel is the gzip compression level (default gzip.DefaultCompression)
+	GzipLevel int
+
+	// BrotliLevel is the brotli compression level (default brotli.DefaultCompression)
+	BrotliLevel int
+}
+
+// withDefaults returns the config with unset fields filled in
+func (config CompressionConfig) withDefaults() CompressionConfig {
+	if config.MinSize == 0 {
+		config.MinSize = 1024
+	}
+	if config.ContentTypes == nil {
+		config.ContentTypes = DefaultCompressibleTypes
+	}
+	if config.Encodings == nil {
+		config.Encodings = []string{"br", "gzip"}
+	}
+	if config.GzipLevel == 0 {
+		config.GzipLevel = gzip.DefaultCompression
+	}
+	if config.BrotliLevel == 0 {
+		config.BrotliLevel = brotli.DefaultCompression
+	}
+	return config
+}
+
+// Compression compresses responses with brotli or gzip, as negotiated with
+// the client's Accept-Encoding header. Only bodies of at least MinSize bytes
+// with a compressible content type are compressed; responses that are
+// already encoded, partial, or streamed (flushed before they reach MinSize)
+// are sent as is. It returns a MiddlewareFunc for Registry.Add.
+func Compression(config CompressionConfig) MiddlewareFunc {
+	config = config.withDefaults()
+	for _, encoding := range config.Encodings {
+		if encoding != "br" && encoding != "gzip" {
+			panic("middleware: unsupported compression encoding " + strconv.Quote(encoding))
+		}
+	}
+
+	pools := map[string]*sync.Pool{
+		"gzip": {New: func() interface{} {
+			w, err := gzip.NewWriterLevel(io.Discard, config.GzipLevel)
+			if err != nil {
+				panic("middleware: invalid gzip level " + strconv.Itoa(config.GzipLevel))
+			}
+			return w
+		}},
+		"br": {New: func() interface{} {
+			return brotli.NewWriterLevel(io.Discard, config.BrotliLevel)
+		}},
+	}
+
+	handler := func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressWriter{
+			ResponseWriter: c.Writer,
+			config:         &config,
+			encoding:       negotiateEncoding(c.GetHeader("Accept-Encoding"), config.Encodings),
+			pools:          pools,
+		}
+		c.Writer = w
+		defer func() {
+			w.finish()
+			c.Writer = w.ResponseWriter
+		}()
+
+		c.Next()
+	}
+
+	return func() gin.HandlerFunc {
+		return handler
+	}
+}
+
+// negotiateEncoding picks the supported encoding the client prefers, using
+// the server's order to break ties. It returns "" if none is acceptable.
+func negotiateEncoding(header string, supported []string) string {
+	if header == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		qualities[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range supported {
+		q, ok := qualities[encoding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// isCompressibleType reports whether contentType matches one of types
+func isCompressibleType(contentType string, types []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range types {
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok {
+			if strings.HasPrefix(mediaType, prefix+"/") {
+				return true
+			}
+		} else if mediaType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter buffers the start of a response until it knows whether the
+// body is worth compressing, then either compresses or passes it through
+type compressWriter struct {
+	gin.ResponseWriter
+	config   *CompressionConfig
+	encoding string
+	pools    map[string]*sync.Pool
+
+	buf        []byte
+	decided    bool
+	compressor io.WriteCloser
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.compressor != nil {
+			return w.compressor.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) >= w.config.MinSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow is deferred until the encoding is decided, since the
+// Content-Encoding header can't be changed once headers are sent
+func (w *compressWriter) WriteHeaderNow() {
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Written reports whether the handler has written a response, including
+// one still being buffered
+func (w *compressWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends buffered data to the client. A response flushed before the
+// encoding is decided is streaming and is not compressed.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.decide(false)
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// decide chooses whether to compress the response and writes out the
+// buffered data
+func (w *compressWriter) decide(compress bool) error {
+	w.decided = true
+	header := w.Header()
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" && len(w.buf) > 0 {
+		contentType = http.DetectContentType(w.buf)
+		header.Set("Content-Type", contentType)
+	}
+
+	status := w.Status()
+	eligible := status >= http.StatusOK &&
+		status != http.StatusNoContent &&
+		status != http.StatusPartialContent &&
+		status != http.StatusNotModified &&
+		header.Get("Content-Encoding") == "" &&
+		header.Get("Content-Range") == "" &&
+		isCompressibleType(contentType, w.config.ContentTypes)
+	if eligible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if compress && eligible && w.encoding != "" {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+		w.compressor = w.newCompressor()
+		_, err := w.compressor.Write(buf)
+		return err
+	}
+
+	if len(buf) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// finish completes the response once the handlers have returned
+func (w *compressWriter) finish() {
+	if !w.decided {
+		w.decide(false)
+		return
+	}
+	if w.compressor != nil {
+		w.compressor.Close()
+		w.pools[w.encoding].Put(w.compressor)
+		w.compressor = nil
+	}
+}
+
+// newCompressor returns a pooled compressor for the negotiated encoding,
+// writing to the underlying response
+func (w *compressWriter) newCompressor() io.WriteCloser {
+	switch compressor := w.pools[w.encoding].Get().(type) {
+	case *gzip.Writer:
+		compressor.Reset(w.ResponseWriter)
+		return compressor
+	case *brotli.Writer:
+		compressor.Reset(w.ResponseWriter)
+		return compressor
+	}
+	panic("middleware: unexpected compressor type")
+}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+var largeJSON = map[string]interface{}{"data": strings.Repeat("gojango ", 500)}
+
+func newCompressionRouter(config CompressionConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registry := NewRegistry()
+	registry.Add(Compression(config))
+	registry.Apply(router)
+	router.GET("/large", func(c *gin.Context) { c.JSON(200, largeJSON) })
+	router.GET("/small", func(c *gin.Context) { c.JSON(200, gin.H{"ok": true}) })
+	router.GET("/image", func(c *gin.Context) {
+		c.Data(200, "image/png", make([]byte, 4096))
+	})
+	router.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/plain")
+		for i := 0; i < 3; i++ {
+			c.Writer.WriteString(strings.Repeat("chunk ", 500))
+			c.Writer.Flush()
+		}
+	})
+	router.GET("/flushed", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Writer.WriteString("data: hello\n\n")
+		c.Writer.Flush()
+		c.Writer.WriteString(strings.Repeat("data: more\n\n", 200))
+	})
+	return router
+}
+
+func doCompressionRequest(router *gin.Engine, path, acceptEncoding string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCompressionGzipLargeJSON(t *testing.T) {
+	router := newCompressionRouter(CompressionConfig{})
+
+	w := doCompressionRequest(router, "/large", "gzip, deflate")
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected gzip encoding, got: %q", w.Header().Get("Content-Encoding"))
+	}
+	if w.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got: %q", w.Header().Get("Vary"))
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		t.Errorf("Expected JSON content type, got: %q", w.Header().Get("Content-Type"))
+	}
+
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Invalid gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(reader)
+	if !strings.Contains(string(body), "gojango gojango") {
+		t.Errorf("Unexpected decompressed body: %.50s", body)
+	}
+	if w.Body.Len() >= len(body) {
+		t.Errorf("Expected the body to shrink, got %d compressed bytes for %d", w.Body.Len(), len(body))
+	}
+}
+
+func TestCompressionPrefersBrotli(t *testing.T) {
+	router := newCompressionRouter(CompressionConfig{})
+
+	w := doCompressionRequest(router, "/large", "gzip, br")
+	if w.Header().Get("Content-Encoding") != "br" {
+		t.Fatalf("Expected br encoding, got: %q", w.Header().Get("Content-Encoding"))
+	}
+	body, err := io.ReadAll(brotli.NewReader(w.Body))
+	if err != nil || !strings.Contains(string(body), "gojango gojango") {
+		t.Errorf("Invalid brotli body: %v", err)
+	}
+
+	// The client's q-values win over the server's preference
+	w = doCompressionRequest(router, "/large", "br;q=0.5, gzip")
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Errorf("Expected gzip for a client preferring it, got: %q", w.Header().Get("Content-Encoding"))
+	}
+}
+
+func TestCompressionSkipsSmallBody(t *testing.T) {
+	router := newCompressionRouter(CompressionConfig{})
+
+	w := doCompressionRequest(router, "/small", "gzip")
+	if w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Expected no encoding for a small body, got: %q", w.Header().Get("Content-Encoding"))
+	}
+	if w.Body.String() != `{"ok":true}` {
+		t.Errorf("Expected the body unchanged, got: %s", w.Body.String())
+	}
+	if w.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Expected Vary on a compressible type, got: %q", w.Header().Get("Vary"))
+	}
+}
+
+func TestCompressionHonorsAcceptEncoding(t *testing.T) {
+	router := newCompressionRouter(CompressionConfig{})
+
+	for _, acceptEncoding := range []string{"", "identity", "gzip;q=0, br;q=0", "deflate"} {
+		w := doCompressionRequest(router, "/large", acceptEncoding)
+		if w.Header().Get("Content-Encoding") != "" {
+			t.Errorf("%q: expected no encoding, got: %q", acceptEncoding, w.Header().Get("Content-Encoding"))
+		}
+		if !strings.Contains(w.Body.String(), "gojango gojango") {
+			t.Errorf("%q: expected the plain body", acceptEncoding)
+		}
+		if w.Header().Get("Vary") != "Accept-Encoding" {
+			t.Errorf("%q: expected Vary so caches keep the variants apart", acceptEncoding)
+		}
+	}
+
+	if w := doCompressionRequest(router, "/large", "*"); w.Header().Get("Content-Encoding") != "br" {
+		t.Errorf("Expected a wildcard to accept the preferred encoding, got: %q", w.Header().Get("Content-Encoding"))
+	}
+}
+
+func TestCompressionSkipsIncompressibleAndStreaming(t *testing.T) {
+	router := newCompressionRouter(CompressionConfig{})
+
+	w := doCompressionRequest(router, "/image", "gzip")
+	if w.Header().Get("Content-Encoding") != "" || w.Body.Len() != 4096 {
+		t.Errorf("Expected images to be sent as is, got encoding %q and %d bytes", w.Header().Get("Content-Encoding"), w.Body.Len())
+	}
+	if w.Header().Get("Vary") != "" {
+		t.Errorf("Expected no Vary for an incompressible type, got: %q", w.Header().Get("Vary"))
+	}
+
+	w = doCompressionRequest(router, "/flushed", "gzip")
+	if w.Header().Get("Content-Encoding") != "" || !strings.HasPrefix(w.Body.String(), "data: hello") {
+		t.Errorf("Expected a stream flushed early to be sent as is, got encoding %q", w.Header().Get("Content-Encoding"))
+	}
+
+	// Chunks that are large enough on their own are compressed and flushed
+	// through the compressor
+	w = doCompressionRequest(router, "/stream", "gzip")
+	if w.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected gzip encoding, got: %q", w.Header().Get("Content-Encoding"))
+	}
+	reader, err := gzip.NewReader(w.Body)
+	if err != nil {
+		t.Fatalf("Invalid gzip body: %v", err)
+	}
+	body, _ := io.ReadAll(reader)
+	if len(body) != 3*len(strings.Repeat("chunk ", 500)) {
+		t.Errorf("Expected all chunks, got %d bytes", len(body))
+	}
+}
+
+func TestCompressionMinSize(t *testing.T) {
+	router := newCompressionRouter(CompressionConfig{MinSize: 5})
+
+	if w := doCompressionRequest(router, "/small", "gzip"); w.Header().Get("Content-Encoding") != "gzip" {
+		t.Errorf("Expected a body over MinSize to be compressed, got: %q", w.Header().Get("Content-Encoding"))
+	}
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	supported := []string{"br", "gzip"}
+	tests := map[string]string{
+		"":                    "",
+		"gzip":                "gzip",
+		"GZIP":                "gzip",
+		"gzip, br":            "br",
+		"gzip;q=1, br;q=0.8":  "gzip",
+		"*;q=0.1, gzip;q=0.5": "gzip",
+		"*, br;q=0":           "gzip",
+		"gzip;q=bogus":        "",
+	}
+	for header, expected := range tests {
+		if got := negotiateEncoding(header, supported); got != expected {
+			t.Errorf("%q: expected %q, got %q", header, expected, got)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CSRFTokenKey is the context key under which the current CSRF token is stored
+const CSRFTokenKey = "csrf_token"
+
+// DefaultCSRFTokenPath is where the CSRF token endpoint is mounted by default
+const DefaultCSRFTokenPath = "/csrf-token"
+
+// csrfTokenLength is the number of random bytes in a CSRF token
+const csrfTokenLength = 32
+
+// CSRFConfig configures the CSRF middleware and token endpoint
+type CSRFConfig struct {
+	// CookieName is the cookie holding the token (default "csrftoken")
+	CookieName string
+
+	// HeaderName is the request header carrying the token on unsafe requests
+	// (default "X-CSRF-Token")
+	HeaderName string
+
+	// FormField is the form field carrying the token for HTML forms
+	// (default "csrf_token")
+	FormField string
+
+	// CookiePath is the cookie path (default "/")
+	CookiePath string
+
+	// CookieMaxAge is the cookie lifetime in seconds (default one year)
+	CookieMaxAge int
+
+	// CookieSecure marks the cookie as HTTPS only
+	CookieSecure bool
+
+	// CookieSameSite sets the cookie SameSite attribute (default Lax)
+	CookieSameSite http.SameSite
+}
+
+// withDefaults returns the config with unset fields filled in
+func (config CSRFConfig) withDefaults() CSRFConfig {
+	if config.CookieName == "" {
+		config.CookieName = "csrftoken"
+	}
+	if config.HeaderName == "" {
+		config.HeaderName = "X-CSRF-Token"
+	}
+	if config.FormField == "" {
+		config.FormField = "csrf_token"
+	}
+	if config.CookiePath == "" {
+		config.CookiePath = "/"
+	}
+	if config.CookieMaxAge == 0 {
+		config.CookieMaxAge = 365 * 24 * 60 * 60
+	}
+	if config.CookieSameSite == 0 {
+		config.CookieSameSite = http.SameSiteLaxMode
+	}
+	return config
+}
+
+// CSRF protects unsafe requests (anything but GET, HEAD, OPTIONS and TRACE)
+// using the double-submit cookie pattern: the token stored in the CSRF cookie
+// must be sent back in the configured header or form field. A token cookie is
+// issued when missing, and the current token is stored in the context under
+// CSRFTokenKey for templates.
+func CSRF(config CSRFConfig) gin.HandlerFunc {
+	config = config.withDefaults()
+
+	return func(c *gin.Context) {
+		token, err := ensureCSRFToken(c, config)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate CSRF token"})
+			return
+		}
+
+		if !isSafeMethod(c.Request.Method) {
+			provided := c.GetHeader(config.HeaderName)
+			if provided == "" {
+				provided = c.PostForm(config.FormField)
+			}
+			if provided == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "CSRF token missing or incorrect"})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// CSRFTokenHandler returns the current CSRF token as {"csrf_token": "..."},
+// setting the token cookie if it is absent. SPA clients call it before
+// sending mutations and put the token in the CSRF header. It is a safe GET
+// and can be mounted with or without the CSRF middleware in front of it.
+func CSRFTokenHandler(config CSRFConfig) gin.HandlerFunc {
+	config = config.withDefaults()
+
+	return func(c *gin.Context) {
+		token, err := ensureCSRFToken(c, config)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate CSRF token"})
+			return
+		}
+
+		// The token is per client; never let a shared cache store it
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, gin.H{"csrf_token": token})
+	}
+}
+
+// GetCSRFToken returns the CSRF token for the current request, as set by
+// the CSRF middleware
+func GetCSRFToken(c *gin.Context) string {
+	return c.GetString(CSRFTokenKey)
+}
+
+// ensureCSRFToken returns the request's CSRF token, issuing a new token
+// cookie if the request has none
+func ensureCSRFToken(c *gin.Context, config CSRFConfig) (string, error) {
+	if token := c.GetString(CSRFTokenKey); token != "" {
+		return token, nil
+	}
+
+	token, err := c.Cookie(config.CookieName)
+	if err != nil || !isValidCSRFToken(token) {
+		token, err = generateCSRFToken()
+		if err != nil {
+			return "", err
+		}
+
+		c.SetSameSite(config.CookieSameSite)
+		// The cookie is readable by JavaScript so clients can echo it back
+		c.SetCookie(config.CookieName, token, config.CookieMaxAge, config.CookiePath, "", config.CookieSecure, false)
+	}
+
+	c.Set(CSRFTokenKey, token)
+	return token, nil
+}
+
+// generateCSRFToken returns a new random token
+func generateCSRFToken() (string, error) {
+	b := make([]byte, csrfTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// isValidCSRFToken reports whether token has the format of a generated token
+func isValidCSRFToken(token string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(b) == csrfTokenLength
+}
+
+// isSafeMethod reports whether an HTTP method is defined as safe (RFC 9110)
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCSRFRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	config := CSRFConfig{}
+	router.Use(CSRF(config))
+	router.GET(DefaultCSRFTokenPath, CSRFTokenHandler(config))
+	router.POST("/submit", func(c *gin.Context) {
+		c.String(200, "ok")
+	})
+	return router
+}
+
+func fetchCSRFToken(t *testing.T, router *gin.Engine) (string, *http.Cookie) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", DefaultCSRFTokenPath, nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != 200 {
+		t.Fatalf("Expected status 200 from token endpoint, got: %d", w.Code)
+	}
+
+	var body map[string]string
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Token endpoint returned invalid JSON: %v", err)
+	}
+
+	var cookie *http.Cookie
+	for _, c := range w.Result().Cookies() {
+		if c.Name == "csrftoken" {
+			cookie = c
+		}
+	}
+	return body["csrf_token"], cookie
+}
+
+func TestCSRFTokenEndpoint(t *testing.T) {
+	router := newCSRFRouter()
+
+	token, cookie := fetchCSRFToken(t, router)
+	if token == "" {
+		t.Fatal("Expected token endpoint to return a token")
+	}
+	if cookie == nil {
+		t.Fatal("Expected token endpoint to set the CSRF cookie")
+	}
+	if cookie.Value != token {
+		t.Errorf("Expected cookie value to match returned token")
+	}
+	if cookie.HttpOnly {
+		t.Error("CSRF cookie must be readable by JavaScript clients")
+	}
+}
+
+func TestCSRFTokenEndpointReusesCookie(t *testing.T) {
+	router := newCSRFRouter()
+	token, cookie := fetchCSRFToken(t, router)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", DefaultCSRFTokenPath, nil)
+	req.AddCookie(cookie)
+	router.ServeHTTP(w, req)
+
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("Expected no new cookie when the request already has one")
+	}
+	if !strings.Contains(w.Body.String(), token) {
+		t.Errorf("Expected existing token to be returned, got: %s", w.Body.String())
+	}
+}
+
+func TestCSRFPostWithToken(t *testing.T) {
+	router := newCSRFRouter()
+	token, cookie := fetchCSRFToken(t, router)
+
+	// Header
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/submit", nil)
+	req.AddCookie(cookie)
+	req.Header.Set("X-CSRF-Token", token)
+	router.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Errorf("Expected POST with token header to pass, got: %d", w.Code)
+	}
+
+	// Form field
+	w = httptest.NewRecorder()
+	form := url.Values{"csrf_token": {token}}
+	req, _ = http.NewRequest("POST", "/submit", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	router.ServeHTTP(w, req)
+	if w.Code != 200 {
+		t.Errorf("Expected POST with token form field to pass, got: %d", w.Code)
+	}
+}
+
+func TestCSRFPostRejected(t *testing.T) {
+	router := newCSRFRouter()
+	_, cookie := fetchCSRFToken(t, router)
+
+	// Missing token
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/submit", nil)
+	req.AddCookie(cookie)
+	router.ServeHTTP(w, req)
+	if w.Code != 403 {
+		t.Errorf("Expected POST without token to be rejected, got: %d", w.Code)
+	}
+
+	// Wrong token
+	otherToken, _ := fetchCSRFToken(t, router)
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/submit", nil)
+	req.AddCookie(cookie)
+	req.Header.Set("X-CSRF-Token", otherToken)
+	router.ServeHTTP(w, req)
+	if w.Code != 403 {
+		t.Errorf("Expected POST with mismatched token to be rejected, got: %d", w.Code)
+	}
+
+	// No cookie at all
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/submit", nil)
+	req.Header.Set("X-CSRF-Token", otherToken)
+	router.ServeHTTP(w, req)
+	if w.Code != 403 {
+		t.Errorf("Expected POST without cookie to be rejected, got: %d", w.Code)
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newLoggingRouter(config StructuredLoggerConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID(), StructuredLogger(config))
+	router.GET("/posts/:id", func(c *gin.Context) { c.String(200, "post") })
+	router.GET("/health", func(c *gin.Context) { c.String(200, "ok") })
+	router.GET("/broken", func(c *gin.Context) {
+		c.Error(http.ErrAbortHandler)
+		c.String(500, "broken")
+	})
+	return router
+}
+
+// logLines decodes each JSON line written by the logger
+func logLines(t *testing.T, output *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Expected a JSON log line, got %q: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestStructuredLoggerFields(t *testing.T) {
+	var output bytes.Buffer
+	router := newLoggingRouter(StructuredLoggerConfig{Output: &output})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/posts/42?page=2&Token=abc123&api_key=xyz", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("User-Agent", "test-agent")
+	req.RemoteAddr = "192.0.2.10:1234"
+	router.ServeHTTP(w, req)
+
+	lines := logLines(t, &output)
+	if len(lines) != 1 {
+		t.Fatalf("Expected one log line, got: %d", len(lines))
+	}
+	entry := lines[0]
+
+	expected := map[string]interface{}{
+		"level":      "INFO",
+		"msg":        "request",
+		"method":     "GET",
+		"path":       "/posts/42",
+		"route":      "/posts/:id",
+		"query":      "page=2&Token=REDACTED&api_key=REDACTED",
+		"status":     float64(200),
+		"bytes":      float64(4),
+		"client_ip":  "192.0.2.10",
+		"request_id": "req-1",
+		"user_agent": "test-agent",
+	}
+	for key, value := range expected {
+		if entry[key] != value {
+			t.Errorf("Expected %s to be %v, got: %v", key, value, entry[key])
+		}
+	}
+	if _, ok := entry["latency_ms"].(float64); !ok {
+		t.Errorf("Expected a numeric latency, got: %v", entry["latency_ms"])
+	}
+	if strings.Contains(output.String(), "abc123") || strings.Contains(output.String(), "xyz") {
+		t.Errorf("Expected secrets to be redacted, got: %s", output.String())
+	}
+}
+
+func TestStructuredLoggerSkipsPaths(t *testing.T) {
+	var output bytes.Buffer
+	router := newLoggingRouter(StructuredLoggerConfig{Output: &output, SkipPaths: []string{"/health", "/broken"}})
+
+	for _, path := range []string{"/health", "/broken"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+	}
+
+	// Skipped paths are still logged when they fail
+	lines := logLines(t, &output)
+	if len(lines) != 1 || lines[0]["path"] != "/broken" {
+		t.Fatalf("Expected only the failed request to be logged, got: %v", lines)
+	}
+	if lines[0]["level"] != "ERROR" {
+		t.Errorf("Expected a server error to be logged at ERROR, got: %v", lines[0]["level"])
+	}
+	if lines[0]["error"] == nil {
+		t.Error("Expected the handler error to be logged")
+	}
+}
+
+func TestStructuredLoggerLevel(t *testing.T) {
+	var output bytes.Buffer
+	router := newLoggingRouter(StructuredLoggerConfig{Output: &output, Level: slog.LevelDebug, RedactQueryParams: []string{}})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/posts/1?token=visible", nil)
+	router.ServeHTTP(w, req)
+
+	lines := logLines(t, &output)
+	if len(lines) != 1 || lines[0]["level"] != "DEBUG" {
+		t.Fatalf("Expected a DEBUG log line, got: %v", lines)
+	}
+	if lines[0]["query"] != "token=visible" {
+		t.Errorf("Expected no redaction with an empty list, got: %v", lines[0]["query"])
+	}
+}
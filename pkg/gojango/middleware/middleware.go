@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 	"log"
+	"runtime/debug"
 	"time"
 
 	"github.com/gin-contrib/cors"
@@ -54,6 +55,20 @@ func Logger() gin.HandlerFunc {
 
 // Recovery provides panic recovery with logging
 func Recovery() gin.HandlerFunc {
+	return RecoveryWithConfig(RecoveryConfig{})
+}
+
+// RecoveryConfig configures the Recovery middleware
+type RecoveryConfig struct {
+	// Handler writes the response for a recovered panic, given the
+	// recovered value and the stack of the panicking goroutine. When nil,
+	// the request is aborted with an empty 500 response.
+	Handler func(c *gin.Context, recovered interface{}, stack []byte)
+}
+
+// RecoveryWithConfig provides panic recovery with logging, writing the
+// response with config.Handler
+func RecoveryWithConfig(config RecoveryConfig) gin.HandlerFunc {
 	return gin.RecoveryWithWriter(log.Writer(), func(c *gin.Context, recovered interface{}) {
 		requestID, exists := c.Get("request_id")
 		if !exists {
@@ -62,7 +77,12 @@ func Recovery() gin.HandlerFunc {
 		
 		log.Printf("[PANIC RECOVERED] Request ID: %s, Error: %v\n", requestID, recovered)
 		
-		c.AbortWithStatus(500)
+		if config.Handler == nil || c.Writer.Written() {
+			c.AbortWithStatus(500)
+			return
+		}
+		config.Handler(c, recovered, debug.Stack())
+		c.Abort()
 	})
 }
 
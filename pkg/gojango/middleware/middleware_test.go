@@ -104,6 +104,31 @@ func TestRecovery(t *testing.T) {
 	}
 }
 
+func TestRecoveryWithConfig(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RecoveryWithConfig(RecoveryConfig{
+		Handler: func(c *gin.Context, recovered interface{}, stack []byte) {
+			if len(stack) == 0 {
+				t.Error("Expected the panic's stack")
+			}
+			c.String(500, "recovered: %v", recovered)
+		},
+	}))
+	
+	router.GET("/panic", func(c *gin.Context) {
+		panic("test panic")
+	})
+	
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/panic", nil)
+	router.ServeHTTP(w, req)
+	
+	if w.Code != 500 || w.Body.String() != "recovered: test panic" {
+		t.Errorf("Expected the handler's response, got %d: %q", w.Code, w.Body.String())
+	}
+}
+
 func TestCORS(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
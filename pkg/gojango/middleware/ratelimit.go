@@ -0,0 +1,270 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitRule is a token bucket: Burst requests may be made at once, and
+// the bucket refills at Rate requests per second
+type RateLimitRule struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitConfig configures the RateLimit middleware
+type RateLimitConfig struct {
+	// Rate is the default number of requests per second allowed per client
+	Rate float64
+
+	// Burst is the default bucket size (default: Rate rounded up, at least 1)
+	Burst int
+
+	// Routes overrides the default rule for specific routes. Keys are gin
+	// route patterns ("/admin/login"), optionally prefixed with a method
+	// ("POST /admin/login"). Each overridden route has its own bucket per
+	// client; all other routes share the client's default bucket.
+	Routes map[string]RateLimitRule
+
+	// TrustedProxies lists the proxy IPs or CIDRs whose X-Forwarded-For
+	// header is trusted. Without it the client is always the remote address.
+	TrustedProxies []string
+
+	// Store holds the buckets (default: an in-memory store)
+	Store RateLimitStore
+}
+
+// RateLimitStore keeps token buckets. Implementations must be safe for
+// concurrent use; a shared store such as Redis lets several server
+// processes enforce one limit.
+type RateLimitStore interface {
+	// Take removes a token from the bucket for key, reporting whether one
+	// was available and, if not, how long until one will be
+	Take(ctx context.Context, key string, rule RateLimitRule) (allowed bool, retryAfter time.Duration, err error)
+}
+
+// RateLimit limits clients, identified by IP, with token buckets. Requests
+// over the limit are rejected with 429 and a Retry-After header. It returns
+// a MiddlewareFunc for Registry.Add; every handler it creates shares the same
+// buckets.
+func RateLimit(config RateLimitConfig) MiddlewareFunc {
+	defaultRule := RateLimitRule{Rate: config.Rate, Burst: config.Burst}.withDefaults()
+	routes := make(map[string]RateLimitRule, len(config.Routes))
+	for route, rule := range config.Routes {
+		routes[route] = rule.withDefaults()
+	}
+
+	proxies, err := parseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		panic(fmt.Sprintf("middleware: %v", err))
+	}
+
+	store := config.Store
+	if store == nil {
+		store = NewMemoryRateLimitStore()
+	}
+
+	handler := func(c *gin.Context) {
+		key := clientIP(c.Request, proxies)
+		rule := defaultRule
+		if route, override := matchRateLimitRoute(routes, c.Request.Method, c.FullPath()); override {
+			rule = routes[route]
+			key += "|" + route
+		}
+
+		allowed, retryAfter, err := store.Take(c.Request.Context(), key, rule)
+		if err != nil {
+			// Fail open: an unavailable store shouldn't take the site down
+			log.Printf("rate limit store error: %v", err)
+			c.Next()
+			return
+		}
+		if !allowed {
+			seconds := int(math.Ceil(retryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "Too many requests"})
+			return
+		}
+
+		c.Next()
+	}
+
+	return func() gin.HandlerFunc {
+		return handler
+	}
+}
+
+// withDefaults returns the rule with an unset burst filled in
+func (rule RateLimitRule) withDefaults() RateLimitRule {
+	if rule.Rate <= 0 {
+		panic("middleware: rate limit rate must be positive")
+	}
+	if rule.Burst <= 0 {
+		rule.Burst = int(math.Ceil(rule.Rate))
+	}
+	return rule
+}
+
+// matchRateLimitRoute returns the Routes key overriding the matched route,
+// preferring a method-specific entry
+func matchRateLimitRoute(routes map[string]RateLimitRule, method, path string) (string, bool) {
+	if path == "" {
+		return "", false
+	}
+	if _, ok := routes[method+" "+path]; ok {
+		return method + " " + path, true
+	}
+	if _, ok := routes[path]; ok {
+		return path, true
+	}
+	return "", false
+}
+
+// parseTrustedProxies parses IPs and CIDRs into networks
+func parseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(proxies))
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q", proxy)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// clientIP returns the request's client IP. X-Forwarded-For is only used
+// when the request comes from a trusted proxy, and is read right to left so
+// a client can't spoof its address by sending the header itself.
+func clientIP(r *http.Request, proxies []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remote = r.RemoteAddr
+	}
+	if len(proxies) == 0 || !isTrustedProxy(remote, proxies) {
+		return remote
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if net.ParseIP(hop) == nil {
+			break
+		}
+		if !isTrustedProxy(hop, proxies) {
+			return hop
+		}
+		remote = hop
+	}
+	return remote
+}
+
+// isTrustedProxy reports whether ip belongs to one of the trusted networks
+func isTrustedProxy(ip string, proxies []*net.IPNet) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range proxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitSweepInterval is how often the memory store drops idle buckets
+const rateLimitSweepInterval = time.Minute
+
+// MemoryRateLimitStore keeps token buckets in process memory
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// tokenBucket is the state of one client's bucket
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	full   time.Time // when the bucket will be full again
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// Take implements RateLimitStore
+func (s *MemoryRateLimitStore) Take(ctx context.Context, key string, rule RateLimitRule) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(rule.Burst), last: now}
+		s.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.last).Seconds()
+	if elapsed > 0 {
+		bucket.tokens = math.Min(float64(rule.Burst), bucket.tokens+elapsed*rule.Rate)
+		bucket.last = now
+	}
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / rule.Rate * float64(time.Second))
+		return false, wait, nil
+	}
+
+	bucket.tokens--
+	missing := float64(rule.Burst) - bucket.tokens
+	bucket.full = now.Add(time.Duration(missing / rule.Rate * float64(time.Second)))
+	return true, 0, nil
+}
+
+// sweep drops buckets that have refilled, since a new bucket would be
+// identical; it runs at most once per rateLimitSweepInterval
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitRouter(config RateLimitConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	registry := NewRegistry()
+	registry.Add(RateLimit(config))
+	registry.Apply(router)
+	router.GET("/api", func(c *gin.Context) { c.String(200, "ok") })
+	router.GET("/other", func(c *gin.Context) { c.String(200, "ok") })
+	router.POST("/login", func(c *gin.Context) { c.String(200, "ok") })
+	return router
+}
+
+func doRateLimitRequest(router *gin.Engine, method, path, remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(method, path, nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimitBurst(t *testing.T) {
+	router := newRateLimitRouter(RateLimitConfig{Rate: 0.1, Burst: 3})
+
+	for i := 0; i < 3; i++ {
+		if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", ""); w.Code != 200 {
+			t.Fatalf("Request %d: expected status 200 within burst, got: %d", i+1, w.Code)
+		}
+	}
+
+	w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 after the bucket drained, got: %d", w.Code)
+	}
+	if retryAfter := w.Header().Get("Retry-After"); retryAfter != "10" {
+		t.Errorf("Expected Retry-After of 10 seconds, got: %q", retryAfter)
+	}
+
+	// Routes without an override share the client's bucket
+	if w := doRateLimitRequest(router, "GET", "/other", "10.0.0.1:1234", ""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429 on another route, got: %d", w.Code)
+	}
+
+	// Other clients have their own buckets
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.2:1234", ""); w.Code != 200 {
+		t.Errorf("Expected status 200 for another client, got: %d", w.Code)
+	}
+}
+
+func TestRateLimitRefill(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+	router := newRateLimitRouter(RateLimitConfig{Rate: 2, Burst: 2, Store: store})
+
+	for i := 0; i < 2; i++ {
+		doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "")
+	}
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", ""); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 after the burst, got: %d", w.Code)
+	}
+
+	// Two tokens per second: one is back after half a second
+	now = now.Add(500 * time.Millisecond)
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", ""); w.Code != 200 {
+		t.Errorf("Expected status 200 after refill, got: %d", w.Code)
+	}
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", ""); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status 429 once the refilled token is used, got: %d", w.Code)
+	}
+}
+
+func TestRateLimitRouteOverride(t *testing.T) {
+	router := newRateLimitRouter(RateLimitConfig{
+		Rate:  100,
+		Burst: 100,
+		Routes: map[string]RateLimitRule{
+			"POST /login": {Rate: 0.01, Burst: 2},
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		if w := doRateLimitRequest(router, "POST", "/login", "10.0.0.1:1234", ""); w.Code != 200 {
+			t.Fatalf("Login %d: expected status 200, got: %d", i+1, w.Code)
+		}
+	}
+	w := doRateLimitRequest(router, "POST", "/login", "10.0.0.1:1234", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429 for the overridden route, got: %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "100" {
+		t.Errorf("Expected Retry-After of 100 seconds, got: %q", w.Header().Get("Retry-After"))
+	}
+
+	// The override has its own bucket; the default limit is untouched
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", ""); w.Code != 200 {
+		t.Errorf("Expected status 200 on a route using the default rule, got: %d", w.Code)
+	}
+}
+
+func TestRateLimitForwardedFor(t *testing.T) {
+	// Without trusted proxies X-Forwarded-For is ignored, so spoofed headers
+	// don't get a client a fresh bucket
+	router := newRateLimitRouter(RateLimitConfig{Rate: 0.1, Burst: 1})
+	doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "1.1.1.1")
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "2.2.2.2"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected X-Forwarded-For to be ignored without trusted proxies, got: %d", w.Code)
+	}
+
+	router = newRateLimitRouter(RateLimitConfig{Rate: 0.1, Burst: 1, TrustedProxies: []string{"10.0.0.0/8"}})
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "1.1.1.1"); w.Code != 200 {
+		t.Fatalf("Expected status 200, got: %d", w.Code)
+	}
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "2.2.2.2"); w.Code != 200 {
+		t.Errorf("Expected forwarded clients to have separate buckets, got: %d", w.Code)
+	}
+
+	// The client can prepend anything; only the address added by the proxy counts
+	if w := doRateLimitRequest(router, "GET", "/api", "10.0.0.1:1234", "9.9.9.9, 1.1.1.1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected a spoofed leading address to be ignored, got: %d", w.Code)
+	}
+
+	// Untrusted peers can't set the header at all
+	doRateLimitRequest(router, "GET", "/api", "192.168.1.1:1234", "3.3.3.3")
+	if w := doRateLimitRequest(router, "GET", "/api", "192.168.1.1:1234", "4.4.4.4"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected X-Forwarded-For from an untrusted peer to be ignored, got: %d", w.Code)
+	}
+}
+
+func TestRateLimitInvalidConfig(t *testing.T) {
+	for name, config := range map[string]RateLimitConfig{
+		"zero rate":     {},
+		"invalid proxy": {Rate: 1, TrustedProxies: []string{"not-an-ip"}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected RateLimit to panic", name)
+				}
+			}()
+			RateLimit(config)
+		}()
+	}
+}
+
+func TestMemoryRateLimitStoreSweep(t *testing.T) {
+	store := NewMemoryRateLimitStore()
+	now := time.Unix(1700000000, 0)
+	store.now = func() time.Time { return now }
+	rule := RateLimitRule{Rate: 1, Burst: 5}
+
+	store.Take(context.Background(), "a", rule)
+	now = now.Add(2 * rateLimitSweepInterval)
+	store.Take(context.Background(), "b", rule)
+
+	if _, ok := store.buckets["a"]; ok {
+		t.Error("Expected the refilled bucket to be swept")
+	}
+	if _, ok := store.buckets["b"]; !ok {
+		t.Error("Expected the active bucket to be kept")
+	}
+}
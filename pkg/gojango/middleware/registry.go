@@ -1,6 +1,9 @@
 package middleware
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -89,6 +92,22 @@ func Minimal() *Registry {
 	return registry
 }
 
+// Preset returns the middleware stack for a preset name: "minimal",
+// "development" or "production". It is how the MIDDLEWARE_PRESET setting
+// picks a stack independently of debug mode.
+func Preset(name string) (*Registry, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "minimal":
+		return Minimal(), nil
+	case "development":
+		return GetDevelopment(), nil
+	case "production":
+		return GetDefaults(), nil
+	default:
+		return nil, fmt.Errorf("unknown middleware preset %q (expected minimal, development or production)", name)
+	}
+}
+
 // Count returns the number of middleware functions in the registry
 func (r *Registry) Count() int {
 	return len(r.middlewares)
@@ -176,4 +176,36 @@ func TestRegistryExtensibility(t *testing.T) {
 	if w.Header().Get("X-Request-ID") == "" {
 		t.Error("Expected RequestID middleware from minimal registry")
 	}
-}
\ No newline at end of file
+}
+
+func TestPreset(t *testing.T) {
+	tests := map[string]int{
+		"minimal":     Minimal().Count(),
+		"development": GetDevelopment().Count(),
+		"production":  GetDefaults().Count(),
+		" Production": GetDefaults().Count(),
+	}
+	
+	for name, expected := range tests {
+		registry, err := Preset(name)
+		if err != nil {
+			t.Errorf("Preset(%q) failed: %v", name, err)
+			continue
+		}
+		if registry.Count() != expected {
+			t.Errorf("Preset(%q): expected %d middlewares, got: %d", name, expected, registry.Count())
+		}
+	}
+}
+
+func TestPresetUnknown(t *testing.T) {
+	for _, name := range []string{"", "staging"} {
+		registry, err := Preset(name)
+		if err == nil {
+			t.Errorf("Preset(%q): expected an error", name)
+		}
+		if registry != nil {
+			t.Errorf("Preset(%q): expected no registry", name)
+		}
+	}
+}
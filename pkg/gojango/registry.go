@@ -2,9 +2,14 @@ package gojango
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
 	"sort"
+	"strings"
 	"sync"
+	
+	"github.com/spf13/cobra"
 )
 
 // Registry manages all registered apps in the Gojango application.
@@ -12,10 +17,17 @@ import (
 type Registry struct {
 	mu       sync.RWMutex
 	apps     map[string]App
-	order    []string              // Registration order for dependency resolution
-	models   map[string]ModelMeta  // All models across apps
-	routes   map[string][]Route    // Routes grouped by app
-	services map[string]Service    // gRPC/Connect services
+	order    []string                    // Registration order for dependency resolution
+	models   map[string]ModelMeta        // All models across apps
+	routes   map[string][]Route          // Routes grouped by app
+	groups   map[string][]RouteGroup     // Route groups by app
+	services map[string]Service          // gRPC/Connect services
+	commands map[string][]*cobra.Command // Management commands by app
+	started  []string                    // Initialized apps, in initialization order
+	
+	// Event bus shared by the apps
+	events     *EventBus
+	eventsOnce sync.Once
 	
 	// Lifecycle hooks
 	preInit  []func() error
@@ -42,16 +54,22 @@ var (
 // GetRegistry returns the global registry instance
 func GetRegistry() *Registry {
 	registryOnce.Do(func() {
-		globalRegistry = &Registry{
-			apps:     make(map[string]App),
-			models:   make(map[string]ModelMeta),
-			routes:   make(map[string][]Route),
-			services: make(map[string]Service),
-		}
+		globalRegistry = NewRegistry()
 	})
 	return globalRegistry
 }
 
+// NewRegistry creates an empty registry, separate from the global one
+func NewRegistry() *Registry {
+	return &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		groups:   make(map[string][]RouteGroup),
+		services: make(map[string]Service),
+	}
+}
+
 // Register adds an app to the global registry.
 // This is typically called from app init() functions.
 func Register(app App) {
@@ -92,6 +110,22 @@ func (r *Registry) RegisterApp(app App) {
 		r.routes[config.Name] = provider.Routes()
 	}
 	
+	// Register route groups if app provides them
+	if provider, ok := app.(RouteGroupProvider); ok {
+		if r.groups == nil {
+			r.groups = make(map[string][]RouteGroup)
+		}
+		r.groups[config.Name] = provider.RouteGroups()
+	}
+	
+	// Register management commands if app provides them
+	if provider, ok := app.(CommandProvider); ok {
+		if r.commands == nil {
+			r.commands = make(map[string][]*cobra.Command)
+		}
+		r.commands[config.Name] = provider.Commands()
+	}
+	
 	// Register services if app provides them
 	if provider, ok := app.(ServiceProvider); ok {
 		for _, service := range provider.Services() {
@@ -100,6 +134,16 @@ func (r *Registry) RegisterApp(app App) {
 	}
 }
 
+// Events returns the event bus apps publish and subscribe to
+func (r *Registry) Events() *EventBus {
+	r.eventsOnce.Do(func() {
+		if r.events == nil {
+			r.events = NewEventBus()
+		}
+	})
+	return r.events
+}
+
 // HasApp checks if an app with the given name is registered
 func (r *Registry) HasApp(name string) bool {
 	r.mu.RLock()
@@ -148,8 +192,30 @@ func (r *Registry) GetAllRoutes() map[string][]Route {
 	return routes
 }
 
-// Initialize initializes all registered apps in dependency order
+// GetAllRouteGroups returns route groups from all apps
+func (r *Registry) GetAllRouteGroups() map[string][]RouteGroup {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	groups := make(map[string][]RouteGroup, len(r.groups))
+	for app, appGroups := range r.groups {
+		groups[app] = make([]RouteGroup, len(appGroups))
+		copy(groups[app], appGroups)
+	}
+	return groups
+}
+
+// Initialize initializes all registered apps in dependency order, breaking
+// ties by registration order
 func (r *Registry) Initialize(ctx context.Context, settings Settings) error {
+	return r.InitializeInOrder(ctx, settings, nil)
+}
+
+// InitializeInOrder initializes apps in the given order, such as the order
+// of INSTALLED_APPS. Every app in order must be registered. Dependencies
+// are still initialized before the apps that need them, and registered apps
+// missing from order are initialized last, in registration order.
+func (r *Registry) InitializeInOrder(ctx context.Context, settings Settings, order []string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	
@@ -157,6 +223,17 @@ func (r *Registry) Initialize(ctx context.Context, settings Settings) error {
 		// Allow reinitialization by resetting the flag
 		r.initialized = false
 	}
+	r.started = nil
+	
+	var missing []string
+	for _, appName := range order {
+		if _, exists := r.apps[appName]; !exists {
+			missing = append(missing, appName)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("apps are installed but not registered (is the app package imported?): %s", strings.Join(missing, ", "))
+	}
 	
 	// Run pre-init hooks
 	for _, hook := range r.preInit {
@@ -166,11 +243,23 @@ func (r *Registry) Initialize(ctx context.Context, settings Settings) error {
 	}
 	
 	// Sort apps by dependencies
-	sorted, err := r.topologicalSort()
+	sorted, err := r.sortApps(order)
 	if err != nil {
 		return fmt.Errorf("dependency resolution failed: %w", err)
 	}
 	
+	if order != nil {
+		listed := make(map[string]bool, len(order))
+		for _, appName := range order {
+			listed[appName] = true
+		}
+		for _, appName := range sorted {
+			if !listed[appName] {
+				log.Printf("Warning: app '%s' is registered but not listed in INSTALLED_APPS", appName)
+			}
+		}
+	}
+	
 	// Initialize apps in dependency order
 	for _, appName := range sorted {
 		app := r.apps[appName]
@@ -181,12 +270,14 @@ func (r *Registry) Initialize(ctx context.Context, settings Settings) error {
 			Name:     appName,
 			Settings: settings,
 			Registry: r,
+			Events:   r.Events(),
 		}
 		
 		// Initialize the app
 		if err := app.Initialize(appCtx); err != nil {
 			return fmt.Errorf("failed to initialize app '%s': %w", appName, err)
 		}
+		r.started = append(r.started, appName)
 	}
 	
 	// Run post-init hooks
@@ -200,6 +291,51 @@ func (r *Registry) Initialize(ctx context.Context, settings Settings) error {
 	return nil
 }
 
+// Shutdown calls Shutdown on the initialized apps that implement
+// ShutdownableApp, in reverse initialization order so that apps shut down
+// before the apps they depend on. Once ctx is done, a hook that is still
+// running is abandoned and the remaining apps are skipped. The errors of
+// all apps are joined.
+func (r *Registry) Shutdown(ctx context.Context) error {
+	r.mu.RLock()
+	var names []string
+	var apps []ShutdownableApp
+	for i := len(r.started) - 1; i >= 0; i-- {
+		if app, ok := r.apps[r.started[i]].(ShutdownableApp); ok {
+			names = append(names, r.started[i])
+			apps = append(apps, app)
+		}
+	}
+	r.mu.RUnlock()
+	
+	var errs []error
+	for i, app := range apps {
+		if err := shutdownApp(ctx, app); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shut down app '%s': %w", names[i], err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// shutdownApp runs an app's shutdown hook until it returns or ctx is done
+func shutdownApp(ctx context.Context, app ShutdownableApp) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Shutdown(ctx)
+	}()
+	
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // AddPreInitHook adds a hook that runs before app initialization
 func (r *Registry) AddPreInitHook(hook func() error) {
 	r.mu.Lock()
@@ -233,6 +369,26 @@ func (r *Registry) registerModel(appName string, model interface{}) {
 
 // topologicalSort sorts apps by their dependencies
 func (r *Registry) topologicalSort() ([]string, error) {
+	return r.sortApps(nil)
+}
+
+// sortApps sorts apps by their dependencies. Among apps whose dependencies
+// are satisfied, those earlier in order come first, then the rest in
+// registration order, so the result is deterministic.
+func (r *Registry) sortApps(order []string) ([]string, error) {
+	// Rank apps by position in order, then by registration
+	rank := make(map[string]int, len(r.apps))
+	for i, appName := range order {
+		rank[appName] = i
+	}
+	next := len(order)
+	for _, appName := range r.registrationOrder() {
+		if _, ranked := rank[appName]; !ranked {
+			rank[appName] = next
+			next++
+		}
+	}
+	
 	// Build dependency graph
 	graph := make(map[string][]string)
 	inDegree := make(map[string]int)
@@ -258,36 +414,86 @@ func (r *Registry) topologicalSort() ([]string, error) {
 		}
 	}
 	
-	// Kahn's algorithm for topological sorting
-	var queue []string
+	// Kahn's algorithm, taking the lowest ranked ready app each time
+	var ready []string
 	for appName, degree := range inDegree {
 		if degree == 0 {
-			queue = append(queue, appName)
+			ready = append(ready, appName)
 		}
 	}
 	
 	var result []string
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool {
+			return rank[ready[i]] < rank[ready[j]]
+		})
+		current := ready[0]
+		ready = ready[1:]
 		result = append(result, current)
 		
 		for _, neighbor := range graph[current] {
 			inDegree[neighbor]--
 			if inDegree[neighbor] == 0 {
-				queue = append(queue, neighbor)
+				ready = append(ready, neighbor)
 			}
 		}
 	}
 	
 	// Check for circular dependencies
 	if len(result) != len(r.apps) {
-		return nil, fmt.Errorf("circular dependency detected")
+		cycle := r.findCycle(inDegree, rank)
+		return nil, fmt.Errorf("circular dependency detected: %s", strings.Join(cycle, " -> "))
 	}
 	
 	return result, nil
 }
 
+// findCycle returns a dependency cycle among the apps left unsorted, those
+// with a positive in-degree, as a path that starts and ends with the same
+// app, e.g. [blog auth blog]. Every unsorted app depends on another, so
+// following dependencies from one must come back around.
+func (r *Registry) findCycle(inDegree map[string]int, rank map[string]int) []string {
+	var start string
+	for appName, degree := range inDegree {
+		if degree > 0 && (start == "" || rank[appName] < rank[start]) {
+			start = appName
+		}
+	}
+	
+	var path []string
+	seen := make(map[string]int)
+	current := start
+	for {
+		if i, ok := seen[current]; ok {
+			return append(path[i:], current)
+		}
+		seen[current] = len(path)
+		path = append(path, current)
+		
+		for _, dep := range r.apps[current].Config().Dependencies {
+			if inDegree[dep] > 0 {
+				current = dep
+				break
+			}
+		}
+	}
+}
+
+// registrationOrder returns the registered app names in registration order.
+// Registries built without RegisterApp fall back to alphabetical order.
+func (r *Registry) registrationOrder() []string {
+	if len(r.order) == len(r.apps) {
+		return r.order
+	}
+	
+	names := make([]string, 0, len(r.apps))
+	for name := range r.apps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // GetAppNames returns all registered app names sorted alphabetically
 func (r *Registry) GetAppNames() []string {
 	r.mu.RLock()
@@ -301,6 +507,21 @@ func (r *Registry) GetAppNames() []string {
 	return names
 }
 
+// GetModels returns all registered models sorted by their app.model name
+func (r *Registry) GetModels() []ModelMeta {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	models := make([]ModelMeta, 0, len(r.models))
+	for _, meta := range r.models {
+		models = append(models, meta)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].FullName < models[j].FullName
+	})
+	return models
+}
+
 // toSnakeCase converts CamelCase to snake_case
 func toSnakeCase(input string) string {
 	if len(input) == 0 {
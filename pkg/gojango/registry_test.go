@@ -2,14 +2,19 @@ package gojango
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // TestApp is a simple test app implementation
 type TestApp struct {
 	BaseApp
-	name string
-	deps []string
+	name        string
+	deps        []string
 	initialized bool
 }
 
@@ -134,7 +139,48 @@ func TestRegistryCircularDependency(t *testing.T) {
 	// Should detect circular dependency
 	_, err := registry.topologicalSort()
 	if err == nil {
-		t.Error("Expected error for circular dependency")
+		t.Fatal("Expected error for circular dependency")
+	}
+	if !strings.Contains(err.Error(), "a -> c -> b -> a") {
+		t.Errorf("Expected the cycle path in the error, got %v", err)
+	}
+}
+
+func TestRegistryInitializeDependencyChain(t *testing.T) {
+	registry := NewRegistry()
+
+	var initialized []string
+	record := func(ctx *AppContext) { initialized = append(initialized, ctx.Name) }
+	registry.RegisterApp(&eventApp{name: "comments", deps: []string{"blog"}, init: record})
+	registry.RegisterApp(&eventApp{name: "blog", deps: []string{"auth"}, init: record})
+	registry.RegisterApp(&eventApp{name: "auth", init: record})
+
+	if err := registry.Initialize(context.Background(), NewBasicSettings()); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	if got := strings.Join(initialized, ","); got != "auth,blog,comments" {
+		t.Errorf("Expected dependencies to initialize first, got %s", got)
+	}
+}
+
+func TestRegistryInitializeCycle(t *testing.T) {
+	registry := NewRegistry()
+
+	initialized := false
+	record := func(ctx *AppContext) { initialized = true }
+	registry.RegisterApp(&eventApp{name: "admin", deps: []string{"blog"}, init: record})
+	registry.RegisterApp(&eventApp{name: "blog", deps: []string{"auth"}, init: record})
+	registry.RegisterApp(&eventApp{name: "auth", deps: []string{"blog"}, init: record})
+
+	err := registry.Initialize(context.Background(), NewBasicSettings())
+	if err == nil {
+		t.Fatal("Expected error for circular dependency")
+	}
+	if !strings.Contains(err.Error(), "circular dependency detected: blog -> auth -> blog") {
+		t.Errorf("Expected the cycle path without the apps depending on it, got %v", err)
+	}
+	if initialized {
+		t.Error("No app should be initialized when dependencies are circular")
 	}
 }
 
@@ -240,4 +286,176 @@ func TestRegistryHooks(t *testing.T) {
 	if !postInitCalled {
 		t.Error("Post-init hook was not called")
 	}
-}
\ No newline at end of file
+}
+
+// orderedApp records the order apps are initialized in
+type orderedApp struct {
+	TestApp
+	log *[]string
+}
+
+func (app *orderedApp) Initialize(ctx *AppContext) error {
+	*app.log = append(*app.log, app.name)
+	return nil
+}
+
+func newOrderedRegistry(log *[]string, apps ...*TestApp) *Registry {
+	registry := &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+	for _, app := range apps {
+		registry.RegisterApp(&orderedApp{TestApp: *app, log: log})
+	}
+	return registry
+}
+
+func TestRegistryInitializeInOrder(t *testing.T) {
+	var initialized []string
+	registry := newOrderedRegistry(&initialized,
+		&TestApp{name: "auth"},
+		&TestApp{name: "shop", deps: []string{"catalog"}},
+		&TestApp{name: "catalog"},
+		&TestApp{name: "blog"},
+		&TestApp{name: "extra"},
+	)
+
+	// shop is listed before its dependency, and extra isn't listed at all
+	err := registry.InitializeInOrder(context.Background(), NewBasicSettings(), []string{"blog", "shop", "auth", "catalog"})
+	if err != nil {
+		t.Fatalf("Registry initialization failed: %v", err)
+	}
+
+	// shop waits for catalog; the rest keep their listed order
+	expected := "blog,auth,catalog,shop,extra"
+	if got := strings.Join(initialized, ","); got != expected {
+		t.Errorf("Expected initialization order %s, got: %s", expected, got)
+	}
+}
+
+func TestRegistryInitializeRegistrationOrder(t *testing.T) {
+	for i := 0; i < 5; i++ {
+		var initialized []string
+		registry := newOrderedRegistry(&initialized,
+			&TestApp{name: "zeta"},
+			&TestApp{name: "alpha"},
+			&TestApp{name: "mid"},
+		)
+		if err := registry.Initialize(context.Background(), NewBasicSettings()); err != nil {
+			t.Fatalf("Registry initialization failed: %v", err)
+		}
+		if got := strings.Join(initialized, ","); got != "zeta,alpha,mid" {
+			t.Fatalf("Expected registration order, got: %s", got)
+		}
+	}
+}
+
+func TestRegistryInitializeInOrderNotRegistered(t *testing.T) {
+	var initialized []string
+	registry := newOrderedRegistry(&initialized, &TestApp{name: "blog"})
+
+	err := registry.InitializeInOrder(context.Background(), NewBasicSettings(), []string{"blog", "shop", "forum"})
+	if err == nil {
+		t.Fatal("Expected an error for installed apps that aren't registered")
+	}
+	if !strings.Contains(err.Error(), "not registered") || !strings.HasSuffix(err.Error(), "shop, forum") {
+		t.Errorf("Expected every missing app to be named, got: %v", err)
+	}
+	if len(initialized) != 0 {
+		t.Errorf("Expected no apps to be initialized, got: %v", initialized)
+	}
+}
+
+// shutdownTestApp records its shutdown and can block until its context is done
+type shutdownTestApp struct {
+	TestApp
+	log   *[]string
+	mu    *sync.Mutex
+	block bool
+	err   error
+}
+
+func (app *shutdownTestApp) Shutdown(ctx context.Context) error {
+	if app.block {
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+	}
+	app.mu.Lock()
+	*app.log = append(*app.log, app.name)
+	app.mu.Unlock()
+	return app.err
+}
+
+func newShutdownRegistry(apps ...App) *Registry {
+	registry := NewRegistry()
+	for _, app := range apps {
+		registry.RegisterApp(app)
+	}
+	return registry
+}
+
+func TestRegistryShutdownOrder(t *testing.T) {
+	var mu sync.Mutex
+	var shutdown []string
+	registry := newShutdownRegistry(
+		&shutdownTestApp{TestApp: TestApp{name: "shop", deps: []string{"catalog"}}, log: &shutdown, mu: &mu},
+		&shutdownTestApp{TestApp: TestApp{name: "catalog", deps: []string{"auth"}}, log: &shutdown, mu: &mu},
+		&TestApp{name: "blog"},
+		&shutdownTestApp{TestApp: TestApp{name: "auth"}, log: &shutdown, mu: &mu, err: errors.New("pool busy")},
+	)
+
+	// Apps that were never initialized aren't shut down
+	if err := registry.Shutdown(context.Background()); err != nil || len(shutdown) != 0 {
+		t.Fatalf("Expected no shutdown before initialization, got: %v, %v", shutdown, err)
+	}
+
+	if err := registry.Initialize(context.Background(), NewBasicSettings()); err != nil {
+		t.Fatalf("Registry initialization failed: %v", err)
+	}
+	err := registry.Shutdown(context.Background())
+
+	// Apps are initialized as auth, catalog, shop and shut down in reverse;
+	// blog doesn't implement ShutdownableApp
+	if got := strings.Join(shutdown, ","); got != "shop,catalog,auth" {
+		t.Errorf("Expected shutdown order shop,catalog,auth, got: %s", got)
+	}
+	if err == nil || !strings.Contains(err.Error(), "app 'auth': pool busy") {
+		t.Errorf("Expected the auth shutdown error, got: %v", err)
+	}
+}
+
+func TestRegistryShutdownTimeout(t *testing.T) {
+	var mu sync.Mutex
+	var shutdown []string
+	registry := newShutdownRegistry(
+		&shutdownTestApp{TestApp: TestApp{name: "db"}, log: &shutdown, mu: &mu},
+		&shutdownTestApp{TestApp: TestApp{name: "jobs", deps: []string{"db"}}, log: &shutdown, mu: &mu, block: true},
+	)
+	if err := registry.Initialize(context.Background(), NewBasicSettings()); err != nil {
+		t.Fatalf("Registry initialization failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := registry.Shutdown(ctx)
+
+	if elapsed := time.Since(start); elapsed > 80*time.Millisecond {
+		t.Errorf("Expected the slow hook to be cut off, shutdown took %s", elapsed)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected a deadline error, got: %v", err)
+	}
+	for _, name := range []string{"jobs", "db"} {
+		if !strings.Contains(fmt.Sprint(err), "app '"+name+"'") {
+			t.Errorf("Expected %s to be reported as not shut down, got: %v", name, err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(shutdown) != 0 {
+		t.Errorf("Expected no hooks to complete, got: %v", shutdown)
+	}
+}
@@ -0,0 +1,44 @@
+// Package render provides shared response helpers for Gojango handlers.
+//
+// Handlers in apps and the admin use these helpers instead of calling Gin's
+// render methods directly, so that output formatting (such as indented JSON
+// in debug mode) is controlled from one place.
+package render
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+const jsonContentType = "application/json; charset=utf-8"
+
+var indentJSON atomic.Bool
+
+// SetIndentJSON enables or disables two-space indented JSON responses
+func SetIndentJSON(indent bool) {
+	indentJSON.Store(indent)
+}
+
+// IndentJSON returns true if JSON responses are indented
+func IndentJSON() bool {
+	return indentJSON.Load()
+}
+
+// JSON writes obj as a JSON response, indented with two spaces when pretty
+// output is enabled and compact otherwise
+func JSON(c *gin.Context, code int, obj interface{}) {
+	if !indentJSON.Load() {
+		c.JSON(code, obj)
+		return
+	}
+
+	data, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		c.AbortWithError(http.StatusInternalServerError, err)
+		return
+	}
+	c.Data(code, jsonContentType, data)
+}
@@ -0,0 +1,97 @@
+package render
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func renderPayload(t *testing.T, indent bool) string {
+	gin.SetMode(gin.TestMode)
+	SetIndentJSON(indent)
+	defer SetIndentJSON(false)
+
+	router := gin.New()
+	router.GET("/test", func(c *gin.Context) {
+		JSON(c, http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got: %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Expected JSON content type, got: %s", ct)
+	}
+	return w.Body.String()
+}
+
+func TestJSONIndented(t *testing.T) {
+	body := renderPayload(t, true)
+
+	expected := "{\n  \"status\": \"ok\"\n}"
+	if body != expected {
+		t.Errorf("Expected indented JSON %q, got: %q", expected, body)
+	}
+}
+
+func TestJSONCompact(t *testing.T) {
+	body := renderPayload(t, false)
+
+	expected := `{"status":"ok"}`
+	if body != expected {
+		t.Errorf("Expected compact JSON %q, got: %q", expected, body)
+	}
+}
+
+func newTextRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/html", func(c *gin.Context) {
+		HTML(c, http.StatusOK, "<p>héllo</p>")
+	})
+	router.GET("/text", func(c *gin.Context) {
+		String(c, http.StatusOK, "hello %s", "world")
+	})
+	return router
+}
+
+func TestTextResponsesCarryCharset(t *testing.T) {
+	defer SetDefaultCharset("")
+
+	testCases := []struct {
+		charset  string
+		path     string
+		expected string
+	}{
+		{"", "/html", "text/html; charset=utf-8"},
+		{"", "/text", "text/plain; charset=utf-8"},
+		{"ISO-8859-1", "/html", "text/html; charset=iso-8859-1"},
+		{"ISO-8859-1", "/text", "text/plain; charset=iso-8859-1"},
+	}
+
+	router := newTextRouter()
+	for _, tc := range testCases {
+		SetDefaultCharset(tc.charset)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", tc.path, nil)
+		router.ServeHTTP(w, req)
+
+		if ct := w.Header().Get("Content-Type"); ct != tc.expected {
+			t.Errorf("%s with charset %q: expected Content-Type %q, got: %q", tc.path, tc.charset, tc.expected, ct)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/text", nil)
+	router.ServeHTTP(w, req)
+	if w.Body.String() != "hello world" {
+		t.Errorf("Expected formatted body, got: %q", w.Body.String())
+	}
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultCharset is the charset text responses declare unless configured
+const DefaultCharset = "utf-8"
+
+var defaultCharset atomic.Value
+
+// SetDefaultCharset sets the charset declared in the Content-Type of text
+// and HTML responses. An empty charset restores utf-8. Response bodies are
+// not transcoded; handlers must write text in the configured charset.
+func SetDefaultCharset(charset string) {
+	charset = strings.TrimSpace(charset)
+	if charset == "" {
+		charset = DefaultCharset
+	}
+	defaultCharset.Store(strings.ToLower(charset))
+}
+
+// Charset returns the configured default charset
+func Charset() string {
+	if charset, ok := defaultCharset.Load().(string); ok {
+		return charset
+	}
+	return DefaultCharset
+}
+
+// ContentType returns mediaType with the default charset appended, e.g.
+// "text/html; charset=utf-8"
+func ContentType(mediaType string) string {
+	return mediaType + "; charset=" + Charset()
+}
+
+// HTML writes an HTML string response
+func HTML(c *gin.Context, code int, html string) {
+	c.Data(code, ContentType("text/html"), []byte(html))
+}
+
+// String writes a formatted plain text response
+func String(c *gin.Context, code int, format string, values ...interface{}) {
+	body := format
+	if len(values) > 0 {
+		body = fmt.Sprintf(format, values...)
+	}
+	c.Data(code, ContentType("text/plain"), []byte(body))
+}
+
+// Template renders a loaded HTML template. Gin only sets its own
+// Content-Type when none is present, so the configured charset wins.
+func Template(c *gin.Context, code int, name string, obj interface{}) {
+	c.Header("Content-Type", ContentType("text/html"))
+	c.HTML(code, name, obj)
+}
@@ -0,0 +1,65 @@
+package gojango
+
+import (
+	"github.com/epuerta9/gojango/pkg/gojango/admin"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/gin-gonic/gin"
+)
+
+// requestScopeKey is the gin context key the request scope is stored under
+const requestScopeKey = "gojango.request"
+
+// RequestScope carries the application's resources into a request handler,
+// as returned by RequestContext
+type RequestScope struct {
+	// Settings are the application settings
+	Settings Settings
+
+	// DB is the database connection set with WithDatabase, if any
+	DB *db.Connection
+
+	// Client is the project's Ent client set with WithEntClient, if any.
+	// Type-assert it to the generated *ent.Client.
+	Client interface{}
+
+	// User is the authenticated user stored by authentication middleware
+	// under admin.UserContextKey, or nil
+	User interface{}
+}
+
+// WithEntClient sets the project's Ent client, made available to handlers
+// through RequestContext
+func WithEntClient(client interface{}) Option {
+	return func(app *Application) {
+		app.client = client
+	}
+}
+
+// RequestContext returns the application resources and current user of a
+// request. The user is looked up when RequestContext is called, so it
+// reflects authentication middleware that ran after the framework's own.
+// Outside an application, such as in a bare gin engine, only User is set.
+func RequestContext(c *gin.Context) *RequestScope {
+	var scope RequestScope
+	if value, exists := c.Get(requestScopeKey); exists {
+		if stored, ok := value.(*RequestScope); ok {
+			scope = *stored
+		}
+	}
+
+	scope.User = admin.CurrentUser(c)
+	return &scope
+}
+
+// requestScopeMiddleware stores the application's resources in each
+// request for RequestContext
+func (app *Application) requestScopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(requestScopeKey, &RequestScope{
+			Settings: app.settings,
+			DB:       app.database,
+			Client:   app.client,
+		})
+		c.Next()
+	}
+}
@@ -0,0 +1,72 @@
+package gojango
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/admin"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/gin-gonic/gin"
+)
+
+// testEntClient stands in for a generated Ent client
+type testEntClient struct{}
+
+func TestRequestContextMiddleware(t *testing.T) {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "app.db")))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+	client := &testEntClient{}
+
+	app := newHealthTestApp(t, WithDatabase(conn), WithEntClient(client))
+	var scope *RequestScope
+	app.GetRouter().GET("/whoami",
+		func(c *gin.Context) { c.Set(admin.UserContextKey, "alice") },
+		func(c *gin.Context) { scope = RequestContext(c) },
+	)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/whoami", nil)
+	app.GetRouter().ServeHTTP(w, req)
+
+	if scope == nil {
+		t.Fatal("Expected the handler to run")
+	}
+	if scope.Settings != app.settings {
+		t.Error("Expected the application settings")
+	}
+	if scope.DB != conn {
+		t.Error("Expected the database connection")
+	}
+	if scope.Client != client {
+		t.Errorf("Expected the Ent client, got: %v", scope.Client)
+	}
+	if scope.User != "alice" {
+		t.Errorf("Expected the user set by authentication middleware, got: %v", scope.User)
+	}
+}
+
+func TestRequestContextWithoutApplication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	var scope *RequestScope
+	engine.GET("/", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(admin.ContextWithUser(c.Request.Context(), "bob"))
+		scope = RequestContext(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	engine.ServeHTTP(w, req)
+
+	if scope == nil || scope.Settings != nil || scope.DB != nil || scope.Client != nil {
+		t.Fatalf("Expected an empty scope, got: %+v", scope)
+	}
+	if scope.User != "bob" {
+		t.Errorf("Expected the user from the request context, got: %v", scope.User)
+	}
+}
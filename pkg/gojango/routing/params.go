@@ -0,0 +1,136 @@
+package routing
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// slugPattern matches Django-style slugs: letters, numbers, hyphens and
+// underscores
+var slugPattern = regexp.MustCompile(`^[-a-zA-Z0-9_]+$`)
+
+// paramTypes validates path parameters declared with a type, e.g. ":id|int"
+var paramTypes = map[string]func(string) error{
+	"int": func(value string) error {
+		_, err := strconv.Atoi(value)
+		return err
+	},
+	"uuid": func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	},
+	"slug": func(value string) error {
+		if !slugPattern.MatchString(value) {
+			return fmt.Errorf("not a slug")
+		}
+		return nil
+	},
+}
+
+// ParamError describes a path parameter that is missing or doesn't parse as
+// the expected type. Its message is safe to return to clients in a 400
+// response.
+type ParamError struct {
+	Name  string
+	Value string
+	Type  string
+	Err   error
+}
+
+func (e *ParamError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("missing %s parameter '%s'", e.Type, e.Name)
+	}
+	return fmt.Sprintf("invalid %s parameter '%s': %q", e.Type, e.Name, e.Value)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// parseParam validates the named path parameter as paramType
+func parseParam(c *gin.Context, name, paramType string) (string, error) {
+	value := c.Param(name)
+	if value == "" {
+		return "", &ParamError{Name: name, Type: paramType}
+	}
+	if err := paramTypes[paramType](value); err != nil {
+		return "", &ParamError{Name: name, Value: value, Type: paramType, Err: err}
+	}
+	return value, nil
+}
+
+// IntParam returns the named path parameter as an int
+func IntParam(c *gin.Context, name string) (int, error) {
+	value, err := parseParam(c, name, "int")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(value)
+}
+
+// UUIDParam returns the named path parameter as a UUID
+func UUIDParam(c *gin.Context, name string) (uuid.UUID, error) {
+	value, err := parseParam(c, name, "uuid")
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(value)
+}
+
+// SlugParam returns the named path parameter, validated as a slug
+func SlugParam(c *gin.Context, name string) (string, error) {
+	return parseParam(c, name, "slug")
+}
+
+// typedParam is a path parameter declared with a type
+type typedParam struct {
+	name      string
+	paramType string
+}
+
+// parseTypedPath strips parameter types from a route path, turning
+// "/posts/:id|int" into "/posts/:id", and returns the typed parameters
+func parseTypedPath(path string) (string, []typedParam, error) {
+	if !strings.Contains(path, "|") {
+		return path, nil, nil
+	}
+
+	segments := strings.Split(path, "/")
+	var params []typedParam
+	for i, segment := range segments {
+		name, paramType, typed := strings.Cut(segment, "|")
+		if !typed {
+			continue
+		}
+		if len(name) < 2 || name[0] != ':' {
+			return "", nil, fmt.Errorf("type '%s' declared on '%s', which is not a path parameter", paramType, name)
+		}
+		if _, ok := paramTypes[paramType]; !ok {
+			return "", nil, fmt.Errorf("unknown parameter type '%s' for '%s'", paramType, name)
+		}
+		segments[i] = name
+		params = append(params, typedParam{name: name[1:], paramType: paramType})
+	}
+	return strings.Join(segments, "/"), params, nil
+}
+
+// requireParamTypes returns a handler that rejects requests whose typed path
+// parameters don't match with 404, since such a URL doesn't name the route
+func requireParamTypes(params []typedParam) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, param := range params {
+			if _, err := parseParam(c, param.name, param.paramType); err != nil {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,130 @@
+package routing
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// paramContext returns a gin context with the given path parameters
+func paramContext(params ...gin.Param) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Params = params
+	return c
+}
+
+func TestIntParam(t *testing.T) {
+	id, err := IntParam(paramContext(gin.Param{Key: "id", Value: "42"}), "id")
+	if err != nil || id != 42 {
+		t.Errorf("Expected 42, got: %d (%v)", id, err)
+	}
+
+	for _, value := range []string{"abc", "4.2", "", "99999999999999999999"} {
+		_, err := IntParam(paramContext(gin.Param{Key: "id", Value: value}), "id")
+		var paramErr *ParamError
+		if !errors.As(err, &paramErr) {
+			t.Errorf("%q: expected a ParamError, got: %v", value, err)
+			continue
+		}
+		if paramErr.Name != "id" || paramErr.Type != "int" {
+			t.Errorf("%q: unexpected error details: %+v", value, paramErr)
+		}
+	}
+
+	_, err = IntParam(paramContext(gin.Param{Key: "id", Value: "abc"}), "id")
+	if err.Error() != `invalid int parameter 'id': "abc"` {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+	_, err = IntParam(paramContext(), "id")
+	if err.Error() != "missing int parameter 'id'" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+}
+
+func TestUUIDParam(t *testing.T) {
+	const value = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	id, err := UUIDParam(paramContext(gin.Param{Key: "id", Value: value}), "id")
+	if err != nil || id.String() != value {
+		t.Errorf("Expected %s, got: %s (%v)", value, id, err)
+	}
+
+	if _, err := UUIDParam(paramContext(gin.Param{Key: "id", Value: "not-a-uuid"}), "id"); err == nil {
+		t.Error("Expected an error for an invalid UUID")
+	}
+}
+
+func TestSlugParam(t *testing.T) {
+	slug, err := SlugParam(paramContext(gin.Param{Key: "slug", Value: "hello-world_2"}), "slug")
+	if err != nil || slug != "hello-world_2" {
+		t.Errorf("Expected 'hello-world_2', got: %s (%v)", slug, err)
+	}
+
+	for _, value := range []string{"hello world", "héllo", "a.b", ""} {
+		if _, err := SlugParam(paramContext(gin.Param{Key: "slug", Value: value}), "slug"); err == nil {
+			t.Errorf("%q: expected an error for an invalid slug", value)
+		}
+	}
+}
+
+func TestTypedRouteParams(t *testing.T) {
+	router := NewRouter()
+	handler := func(c *gin.Context) {
+		id, err := IntParam(c, "id")
+		if err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.String(200, "post %d", id)
+	}
+
+	err := router.RegisterRoutes("blog", []Route{
+		{Method: "GET", Path: "/posts/:id|int", Handler: handler, Name: "post"},
+		{Method: "GET", Path: "/tags/:tag|slug/posts", Handler: func(c *gin.Context) { c.String(200, c.Param("tag")) }, Name: "tag"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register routes: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		code int
+		body string
+	}{
+		{"/blog/posts/5", 200, "post 5"},
+		{"/blog/posts/abc", http.StatusNotFound, ""},
+		{"/blog/tags/go-lang/posts", 200, "go-lang"},
+		{"/blog/tags/go%20lang/posts", http.StatusNotFound, ""},
+	}
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", tc.path, nil)
+		router.ServeHTTP(w, req)
+
+		if w.Code != tc.code || w.Body.String() != tc.body {
+			t.Errorf("%s: expected %d %q, got: %d %q", tc.path, tc.code, tc.body, w.Code, w.Body.String())
+		}
+	}
+
+	// Reverse strips the type and checks the value
+	path, err := router.Reverse("blog:post", map[string]string{"id": "5"})
+	if err != nil || path != "/blog/posts/5" {
+		t.Errorf("Expected '/blog/posts/5', got: %s (%v)", path, err)
+	}
+	if _, err := router.Reverse("blog:post", map[string]string{"id": "abc"}); err == nil {
+		t.Error("Expected an error reversing with a non-int id")
+	}
+}
+
+func TestTypedRouteInvalidDeclaration(t *testing.T) {
+	for _, path := range []string{"/posts/:id|float", "/posts|int"} {
+		err := NewRouter().RegisterRoutes("blog", []Route{
+			{Method: "GET", Path: path, Handler: func(c *gin.Context) {}, Name: "post"},
+		})
+		if err == nil {
+			t.Errorf("%s: expected a registration error", path)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -23,11 +25,20 @@ type Route struct {
 	Name    string
 }
 
+// RouteGroup is a set of routes sharing a path prefix and middleware. The
+// middleware runs only for the group's routes, after any global middleware.
+type RouteGroup struct {
+	Prefix     string
+	Middleware []gin.HandlerFunc
+	Routes     []Route
+}
+
 // RegisteredRoute contains a route and its metadata
 type RegisteredRoute struct {
 	Route
 	AppName  string
 	FullName string // app:name format
+	Prefix   string // route group prefix, if any
 }
 
 // NewRouter creates a new router instance
@@ -51,6 +62,19 @@ func (r *Router) RegisterRoutes(appName string, routes []Route) error {
 	// Create app route group
 	group := r.engine.Group("/" + appName)
 	
+	return r.registerRoutes(appName, group, "", routes)
+}
+
+// RegisterGroup registers a route group for an app under /appname/prefix,
+// applying the group's middleware to its routes only
+func (r *Router) RegisterGroup(appName string, routeGroup RouteGroup) error {
+	group := r.engine.Group("/"+appName+routeGroup.Prefix, routeGroup.Middleware...)
+	
+	return r.registerRoutes(appName, group, routeGroup.Prefix, routeGroup.Routes)
+}
+
+// registerRoutes registers routes on a gin group and records them for reversal
+func (r *Router) registerRoutes(appName string, group *gin.RouterGroup, prefix string, routes []Route) error {
 	for _, route := range routes {
 		// Create full route name: app:name
 		fullName := fmt.Sprintf("%s:%s", appName, route.Name)
@@ -65,53 +89,122 @@ func (r *Router) RegisterRoutes(appName string, routes []Route) error {
 			Route:    route,
 			AppName:  appName,
 			FullName: fullName,
+			Prefix:   prefix,
+		}
+		
+		// Typed parameters ("/posts/:id|int") are checked before the handler
+		path, typedParams, err := parseTypedPath(route.Path)
+		if err != nil {
+			return fmt.Errorf("route '%s': %w", fullName, err)
+		}
+		handlers := []gin.HandlerFunc{route.Handler}
+		if len(typedParams) > 0 {
+			handlers = append([]gin.HandlerFunc{requireParamTypes(typedParams)}, handlers...)
 		}
-		r.routes[fullName] = registeredRoute
 		
 		// Register with Gin engine
 		switch strings.ToUpper(route.Method) {
-		case "GET":
-			group.GET(route.Path, route.Handler)
+		case "GET", MethodWebSocket:
+			group.GET(path, handlers...)
 		case "POST":
-			group.POST(route.Path, route.Handler)
+			group.POST(path, handlers...)
 		case "PUT":
-			group.PUT(route.Path, route.Handler)
+			group.PUT(path, handlers...)
 		case "DELETE":
-			group.DELETE(route.Path, route.Handler)
+			group.DELETE(path, handlers...)
 		case "PATCH":
-			group.PATCH(route.Path, route.Handler)
+			group.PATCH(path, handlers...)
 		default:
 			return fmt.Errorf("unsupported HTTP method: %s", route.Method)
 		}
+		
+		r.routes[fullName] = registeredRoute
 	}
 	
 	return nil
 }
 
-// Reverse performs URL reversal - converts route name to URL
-func (r *Router) Reverse(routeName string, params ...interface{}) string {
+// Reverse performs URL reversal - converts a route name ("app:name") and
+// values for its path parameters into a URL path. Each ":param" segment is
+// replaced with its URL-encoded value; a "*param" catch-all keeps the
+// slashes of its value. Unknown route names, missing parameters, values
+// that don't match a typed parameter and parameters the route doesn't have
+// are errors.
+func (r *Router) Reverse(routeName string, params map[string]string) (string, error) {
 	route, exists := r.routes[routeName]
 	if !exists {
-		// Return empty string for missing routes (fail gracefully in templates)
-		return ""
+		return "", fmt.Errorf("route '%s' not found", routeName)
 	}
 	
 	// Build the full path
-	path := "/" + route.AppName + route.Path
+	segments := strings.Split("/"+route.AppName+route.Prefix+route.Path, "/")
+	used := make(map[string]bool, len(params))
+	for i, segment := range segments {
+		if len(segment) < 2 || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		
+		name, paramType, typed := strings.Cut(segment[1:], "|")
+		value, ok := params[name]
+		if !ok || value == "" {
+			return "", fmt.Errorf("route '%s' requires parameter '%s'", routeName, name)
+		}
+		if typed {
+			if err := paramTypes[paramType](value); err != nil {
+				return "", fmt.Errorf("route '%s': %w", routeName, &ParamError{Name: name, Value: value, Type: paramType, Err: err})
+			}
+		}
+		used[name] = true
+		
+		if segment[0] == '*' {
+			segments[i] = escapePathSegments(strings.TrimPrefix(value, "/"))
+		} else {
+			segments[i] = url.PathEscape(value)
+		}
+	}
 	
-	// Simple parameter substitution (basic implementation)
-	// In a full implementation, this would handle URL parameters properly
-	if len(params) > 0 {
-		// Replace path parameters with provided values
-		for i, param := range params {
-			placeholder := fmt.Sprintf("{%d}", i)
-			if paramStr, ok := param.(string); ok {
-				path = strings.ReplaceAll(path, placeholder, paramStr)
+	if len(used) < len(params) {
+		unexpected := make([]string, 0, len(params)-len(used))
+		for name := range params {
+			if !used[name] {
+				unexpected = append(unexpected, name)
 			}
 		}
+		sort.Strings(unexpected)
+		return "", fmt.Errorf("route '%s' has no parameter '%s'", routeName, strings.Join(unexpected, "', '"))
+	}
+	
+	return strings.Join(segments, "/"), nil
+}
+
+// escapePathSegments URL-encodes each segment of a slash-separated path
+func escapePathSegments(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// urlFunc is the "url" template function. Parameters are given as
+// alternating names and values, and values are formatted with fmt.Sprint:
+//
+//	{{ url "blog:post-detail" "id" .Post.ID }}
+func (r *Router) urlFunc(routeName string, pairs ...interface{}) (string, error) {
+	if len(pairs)%2 != 0 {
+		return "", fmt.Errorf("url %s: parameters must be name/value pairs", routeName)
+	}
+	
+	params := make(map[string]string, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		name, ok := pairs[i].(string)
+		if !ok {
+			return "", fmt.Errorf("url %s: parameter name must be a string, got %T", routeName, pairs[i])
+		}
+		params[name] = fmt.Sprint(pairs[i+1])
 	}
 	
-	return path
+	return r.Reverse(routeName, params)
 }
 
 // GetRoutes returns all registered routes
@@ -126,7 +219,7 @@ func (r *Router) GetRoutes() map[string]*RegisteredRoute {
 // TemplateFuncs returns template functions for URL reversal and static files
 func (r *Router) TemplateFuncs() template.FuncMap {
 	return template.FuncMap{
-		"url": r.Reverse,
+		"url": r.urlFunc,
 		"static": func(path string) string {
 			// Static file URL generation
 			return "/static/" + strings.TrimPrefix(path, "/")
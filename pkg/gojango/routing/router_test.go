@@ -1,8 +1,10 @@
 package routing
 
 import (
+	"html/template"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -119,20 +121,111 @@ func TestURLReversal(t *testing.T) {
 	router.RegisterRoutes("blog", routes)
 
 	// Test URL reversal
-	indexURL := router.Reverse("blog:index")
-	if indexURL != "/blog/" {
-		t.Errorf("Expected '/blog/', got: %s", indexURL)
+	indexURL, err := router.Reverse("blog:index", nil)
+	if err != nil || indexURL != "/blog/" {
+		t.Errorf("Expected '/blog/', got: %s (%v)", indexURL, err)
 	}
 
-	detailURL := router.Reverse("blog:detail")
-	if detailURL != "/blog/detail" {
-		t.Errorf("Expected '/blog/detail', got: %s", detailURL)
+	detailURL, err := router.Reverse("blog:detail", nil)
+	if err != nil || detailURL != "/blog/detail" {
+		t.Errorf("Expected '/blog/detail', got: %s (%v)", detailURL, err)
 	}
 
 	// Test nonexistent route
-	missingURL := router.Reverse("blog:missing")
-	if missingURL != "" {
-		t.Errorf("Expected empty string for missing route, got: %s", missingURL)
+	if _, err := router.Reverse("blog:missing", nil); err == nil {
+		t.Error("Expected error for missing route")
+	}
+}
+
+func TestURLReversalParams(t *testing.T) {
+	router := NewRouter()
+
+	routes := []Route{
+		{Method: "GET", Path: "/posts/:id", Handler: func(c *gin.Context) {}, Name: "post-detail"},
+		{Method: "GET", Path: "/archive/:year/:slug/", Handler: func(c *gin.Context) {}, Name: "post-archive"},
+		{Method: "GET", Path: "/files/*path", Handler: func(c *gin.Context) {}, Name: "file"},
+	}
+	if err := router.RegisterRoutes("blog", routes); err != nil {
+		t.Fatalf("Failed to register routes: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected string
+	}{
+		{"blog:post-detail", map[string]string{"id": "5"}, "/blog/posts/5"},
+		{"blog:post-detail", map[string]string{"id": "a b/c?"}, "/blog/posts/a%20b%2Fc%3F"},
+		{"blog:post-archive", map[string]string{"year": "2024", "slug": "hello-world"}, "/blog/archive/2024/hello-world/"},
+		{"blog:file", map[string]string{"path": "docs/read me.txt"}, "/blog/files/docs/read%20me.txt"},
+	}
+	for _, tc := range tests {
+		path, err := router.Reverse(tc.name, tc.params)
+		if err != nil {
+			t.Errorf("%s %v: unexpected error: %v", tc.name, tc.params, err)
+			continue
+		}
+		if path != tc.expected {
+			t.Errorf("%s %v: expected '%s', got: '%s'", tc.name, tc.params, tc.expected, path)
+		}
+	}
+}
+
+func TestURLReversalErrors(t *testing.T) {
+	router := NewRouter()
+	router.RegisterRoutes("blog", []Route{
+		{Method: "GET", Path: "/posts/:year/:slug", Handler: func(c *gin.Context) {}, Name: "post"},
+	})
+
+	tests := map[string]struct {
+		name    string
+		params  map[string]string
+		message string
+	}{
+		"unknown name":  {"blog:nope", nil, "route 'blog:nope' not found"},
+		"missing param": {"blog:post", map[string]string{"year": "2024"}, "route 'blog:post' requires parameter 'slug'"},
+		"empty param":   {"blog:post", map[string]string{"year": "2024", "slug": ""}, "route 'blog:post' requires parameter 'slug'"},
+		"extra param":   {"blog:post", map[string]string{"year": "2024", "slug": "x", "id": "1"}, "route 'blog:post' has no parameter 'id'"},
+	}
+	for desc, tc := range tests {
+		_, err := router.Reverse(tc.name, tc.params)
+		if err == nil {
+			t.Errorf("%s: expected an error", desc)
+			continue
+		}
+		if err.Error() != tc.message {
+			t.Errorf("%s: expected '%s', got: '%s'", desc, tc.message, err.Error())
+		}
+	}
+}
+
+func TestURLTemplateFunction(t *testing.T) {
+	router := NewRouter()
+	router.RegisterRoutes("core", []Route{
+		{Method: "GET", Path: "/posts/:id", Handler: func(c *gin.Context) {}, Name: "post-detail"},
+	})
+
+	tmpl := template.Must(template.New("page").Funcs(router.TemplateFuncs()).Parse(
+		`<a href="{{ url "core:post-detail" "id" .ID }}">post</a>`))
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, map[string]interface{}{"ID": 5}); err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+	if buf.String() != `<a href="/core/posts/5">post</a>` {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+
+	// Errors abort rendering rather than producing a broken link
+	for _, source := range []string{
+		`{{ url "core:missing" }}`,
+		`{{ url "core:post-detail" }}`,
+		`{{ url "core:post-detail" "id" }}`,
+	} {
+		tmpl := template.Must(template.New("page").Funcs(router.TemplateFuncs()).Parse(source))
+		if err := tmpl.Execute(&buf, nil); err == nil {
+			t.Errorf("%s: expected a render error", source)
+		}
 	}
 }
 
@@ -211,4 +304,69 @@ func TestUnsupportedHTTPMethod(t *testing.T) {
 	if err.Error() != "unsupported HTTP method: INVALID" {
 		t.Errorf("Unexpected error message: %s", err.Error())
 	}
-}
\ No newline at end of file
+}
+
+func TestRouteGroupMiddleware(t *testing.T) {
+	router := NewRouter()
+
+	requireToken := func(c *gin.Context) {
+		if c.GetHeader("Authorization") != "Bearer token" {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	}
+	handler := func(c *gin.Context) { c.String(200, c.FullPath()) }
+
+	if err := router.RegisterRoutes("blog", []Route{
+		{Method: "GET", Path: "/", Handler: handler, Name: "index"},
+	}); err != nil {
+		t.Fatalf("Failed to register routes: %v", err)
+	}
+	if err := router.RegisterGroup("blog", RouteGroup{
+		Prefix:     "/api",
+		Middleware: []gin.HandlerFunc{requireToken},
+		Routes: []Route{
+			{Method: "GET", Path: "/posts/:id", Handler: handler, Name: "api-post"},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to register group: %v", err)
+	}
+
+	request := func(path, authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Group middleware doesn't apply to the app's flat routes
+	if w := request("/blog/", ""); w.Code != 200 {
+		t.Errorf("Expected status 200 for a route outside the group, got: %d", w.Code)
+	}
+
+	if w := request("/blog/api/posts/1", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected group middleware to reject the request, got: %d", w.Code)
+	}
+	if w := request("/blog/api/posts/1", "Bearer token"); w.Code != 200 || w.Body.String() != "/blog/api/posts/:id" {
+		t.Errorf("Expected the group route to be served, got: %d %s", w.Code, w.Body.String())
+	}
+
+	// Group routes reverse to their prefixed path
+	path, err := router.Reverse("blog:api-post", map[string]string{"id": "1"})
+	if err != nil || path != "/blog/api/posts/1" {
+		t.Errorf("Expected '/blog/api/posts/1', got: %s (%v)", path, err)
+	}
+
+	// Route names are unique across flat routes and groups
+	err = router.RegisterGroup("blog", RouteGroup{
+		Prefix: "/v2",
+		Routes: []Route{{Method: "GET", Path: "/", Handler: handler, Name: "index"}},
+	})
+	if err == nil {
+		t.Error("Expected error for a group route reusing a route name")
+	}
+}
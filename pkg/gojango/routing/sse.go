@@ -0,0 +1,156 @@
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SSEHeartbeat is how often Stream writes a comment to idle connections, so
+// proxies don't close them and disconnected clients are noticed
+var SSEHeartbeat = 15 * time.Second
+
+// Event is a Server-Sent Event. Data is sent as is when it is a string or
+// []byte and as JSON otherwise; multi-line data is split over several data
+// fields.
+type Event struct {
+	ID    string
+	Event string
+	Data  interface{}
+	Retry time.Duration
+}
+
+// EventStream writes Server-Sent Events to one client
+type EventStream struct {
+	c *gin.Context
+}
+
+// SSE returns a gin handler that starts an event stream and passes it to
+// handler. The stream ends when handler returns; handlers should return once
+// the stream's context is done, which happens when the client disconnects.
+func SSE(handler func(stream *EventStream)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		// Stop nginx from buffering the stream
+		header.Set("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+		c.Writer.WriteHeaderNow()
+		c.Writer.Flush()
+
+		handler(&EventStream{c: c})
+	}
+}
+
+// Context returns the request context, which is done when the client
+// disconnects
+func (s *EventStream) Context() context.Context {
+	return s.c.Request.Context()
+}
+
+// Send writes an event and flushes it to the client
+func (s *EventStream) Send(event Event) error {
+	if err := s.Context().Err(); err != nil {
+		return err
+	}
+
+	frame, err := formatEvent(event)
+	if err != nil {
+		return err
+	}
+	return s.write(frame)
+}
+
+// Comment writes a comment line, which clients ignore
+func (s *EventStream) Comment(text string) error {
+	if err := s.Context().Err(); err != nil {
+		return err
+	}
+	if strings.ContainsAny(text, "\r\n") {
+		return fmt.Errorf("sse: comment must be a single line")
+	}
+	return s.write(": " + text + "\n\n")
+}
+
+// Stream sends events from the channel until it is closed or the client
+// disconnects, writing a heartbeat comment when the stream is idle. It
+// returns nil when the channel is closed and the context error on
+// disconnect.
+func (s *EventStream) Stream(events <-chan Event) error {
+	heartbeat := time.NewTicker(SSEHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-s.Context().Done():
+			return s.Context().Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := s.Send(event); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			if err := s.Comment("heartbeat"); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// write writes a frame and flushes it
+func (s *EventStream) write(frame string) error {
+	if _, err := s.c.Writer.WriteString(frame); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+// formatEvent encodes an event in the text/event-stream format
+func formatEvent(event Event) (string, error) {
+	if strings.ContainsAny(event.ID, "\r\n\x00") || strings.ContainsAny(event.Event, "\r\n") {
+		return "", fmt.Errorf("sse: event id and name must be a single line")
+	}
+
+	var data string
+	switch value := event.Data.(type) {
+	case nil:
+	case string:
+		data = value
+	case []byte:
+		data = string(value)
+	default:
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("sse: failed to encode event data: %w", err)
+		}
+		data = string(encoded)
+	}
+
+	var frame strings.Builder
+	if event.ID != "" {
+		frame.WriteString("id: " + event.ID + "\n")
+	}
+	if event.Event != "" {
+		frame.WriteString("event: " + event.Event + "\n")
+	}
+	if event.Retry > 0 {
+		frame.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	data = strings.ReplaceAll(strings.ReplaceAll(data, "\r\n", "\n"), "\r", "\n")
+	for _, line := range strings.Split(data, "\n") {
+		frame.WriteString("data: " + line + "\n")
+	}
+	frame.WriteString("\n")
+	return frame.String(), nil
+}
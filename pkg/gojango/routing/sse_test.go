@@ -0,0 +1,146 @@
+package routing
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestFormatEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    Event
+		expected string
+	}{
+		{"data only", Event{Data: "hello"}, "data: hello\n\n"},
+		{"all fields", Event{ID: "7", Event: "change", Data: "hi", Retry: 3 * time.Second}, "id: 7\nevent: change\nretry: 3000\ndata: hi\n\n"},
+		{"multi-line data", Event{Data: "one\ntwo\r\nthree"}, "data: one\ndata: two\ndata: three\n\n"},
+		{"json data", Event{Data: map[string]int{"id": 1}}, "data: {\"id\":1}\n\n"},
+		{"bytes", Event{Data: []byte("raw")}, "data: raw\n\n"},
+		{"no data", Event{Event: "ping"}, "event: ping\ndata: \n\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := formatEvent(tt.event)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if frame != tt.expected {
+				t.Errorf("Expected frame %q, got: %q", tt.expected, frame)
+			}
+		})
+	}
+
+	if _, err := formatEvent(Event{ID: "1\n2"}); err == nil {
+		t.Error("Expected an error for a multi-line id")
+	}
+	if _, err := formatEvent(Event{Event: "a\nb"}); err == nil {
+		t.Error("Expected an error for a multi-line event name")
+	}
+}
+
+func TestSSEStream(t *testing.T) {
+	router := gin.New()
+	router.GET("/events", SSE(func(stream *EventStream) {
+		events := make(chan Event, 2)
+		events <- Event{ID: "1", Event: "greeting", Data: "hello"}
+		events <- Event{ID: "2", Data: "line one\nline two"}
+		close(events)
+		if err := stream.Stream(events); err != nil {
+			t.Errorf("Expected nil after the channel closes, got: %v", err)
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/events", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got: %d", w.Code)
+	}
+	if contentType := w.Header().Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("Expected Content-Type text/event-stream, got: %s", contentType)
+	}
+	if cache := w.Header().Get("Cache-Control"); cache != "no-cache" {
+		t.Errorf("Expected Cache-Control no-cache, got: %s", cache)
+	}
+
+	expected := "id: 1\nevent: greeting\ndata: hello\n\n" +
+		"id: 2\ndata: line one\ndata: line two\n\n"
+	if w.Body.String() != expected {
+		t.Errorf("Expected body %q, got: %q", expected, w.Body.String())
+	}
+}
+
+func TestSSEStopsOnDisconnect(t *testing.T) {
+	stopped := make(chan error, 1)
+	router := gin.New()
+	router.GET("/events", SSE(func(stream *EventStream) {
+		// Nothing is ever sent; only a disconnect ends the stream
+		stopped <- stream.Stream(make(chan Event))
+	}))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// Headers are flushed before any event is sent
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("Expected Content-Type text/event-stream, got: %s", contentType)
+	}
+
+	cancel()
+
+	select {
+	case err := <-stopped:
+		if err == nil {
+			t.Error("Expected the context error when the client disconnects")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the stream to stop when the client disconnects")
+	}
+}
+
+func TestSSEHeartbeat(t *testing.T) {
+	previous := SSEHeartbeat
+	SSEHeartbeat = 10 * time.Millisecond
+	defer func() { SSEHeartbeat = previous }()
+
+	router := gin.New()
+	router.GET("/events", SSE(func(stream *EventStream) {
+		stream.Stream(make(chan Event))
+	}))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/events", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("Failed to read heartbeat: %v", err)
+	}
+	if !strings.HasPrefix(line, ": heartbeat") {
+		t.Errorf("Expected a heartbeat comment, got: %q", line)
+	}
+}
@@ -0,0 +1,127 @@
+package routing
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// MethodWebSocket is the Route method for WebSocket endpoints. Such routes
+// are registered as GET and should use a handler built with WebSocket.
+const MethodWebSocket = "WS"
+
+// WebSocketFunc handles an upgraded WebSocket connection. The connection is
+// closed when it returns.
+type WebSocketFunc func(conn *websocket.Conn)
+
+// WebSocketConfig configures WebSocket upgrades and keepalive
+type WebSocketConfig struct {
+	// PingInterval is how often the server pings the client (default 30s)
+	PingInterval time.Duration
+
+	// PongWait is how long a read may wait without hearing from the client,
+	// pongs included, before the connection is considered dead. It must be
+	// longer than PingInterval (default 60s).
+	PongWait time.Duration
+
+	// CheckOrigin decides whether to accept a request's Origin. The default
+	// rejects cross-origin requests.
+	CheckOrigin func(r *http.Request) bool
+
+	// ReadBufferSize and WriteBufferSize size the connection's I/O buffers
+	// (default 4096 bytes)
+	ReadBufferSize  int
+	WriteBufferSize int
+}
+
+// withDefaults returns the config with unset fields filled in
+func (config WebSocketConfig) withDefaults() WebSocketConfig {
+	if config.PingInterval == 0 {
+		config.PingInterval = 30 * time.Second
+	}
+	if config.PongWait == 0 {
+		config.PongWait = 2 * config.PingInterval
+	}
+	return config
+}
+
+// WebSocket returns a gin handler that upgrades the request to a WebSocket
+// and passes the connection to handler, with the default configuration.
+// Register it with the WS method:
+//
+//	routing.Route{
+//		Method: routing.MethodWebSocket,
+//		Path:   "/echo",
+//		Name:   "echo",
+//		Handler: routing.WebSocket(func(conn *websocket.Conn) {
+//			for {
+//				messageType, message, err := conn.ReadMessage()
+//				if err != nil {
+//					return
+//				}
+//				if err := conn.WriteMessage(messageType, message); err != nil {
+//					return
+//				}
+//			}
+//		}),
+//	}
+func WebSocket(handler WebSocketFunc) gin.HandlerFunc {
+	return WebSocketWithConfig(WebSocketConfig{}, handler)
+}
+
+// WebSocketWithConfig is WebSocket with a custom configuration. The server
+// pings the client every PingInterval and each pong extends the read
+// deadline, so a handler blocked reading from a dead client returns an
+// error after PongWait. Control frames are processed while reading, so
+// handlers should keep reading from the connection.
+func WebSocketWithConfig(config WebSocketConfig, handler WebSocketFunc) gin.HandlerFunc {
+	config = config.withDefaults()
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  config.ReadBufferSize,
+		WriteBufferSize: config.WriteBufferSize,
+		CheckOrigin:     config.CheckOrigin,
+	}
+
+	return func(c *gin.Context) {
+		// Upgrade writes its own error response on failure
+		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			c.Abort()
+			return
+		}
+		defer conn.Close()
+
+		conn.SetReadDeadline(time.Now().Add(config.PongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(config.PongWait))
+		})
+
+		done := make(chan struct{})
+		defer close(done)
+		go keepAlive(conn, config.PingInterval, done)
+
+		handler(conn)
+	}
+}
+
+// keepAlive pings the client until done is closed or a ping fails
+func keepAlive(conn *websocket.Conn, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			// WriteControl is safe to call concurrently with the handler's
+			// writes. A failed ping means the connection is gone; the
+			// handler's next read or write reports it.
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(interval)); err != nil {
+				return
+			}
+		}
+	}
+}
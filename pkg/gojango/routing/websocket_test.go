@@ -0,0 +1,136 @@
+package routing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+func echo(conn *websocket.Conn) {
+	for {
+		messageType, message, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if err := conn.WriteMessage(messageType, message); err != nil {
+			return
+		}
+	}
+}
+
+func newWebSocketServer(t *testing.T, handler gin.HandlerFunc) *httptest.Server {
+	router := NewRouter()
+	err := router.RegisterRoutes("live", []Route{
+		{Method: MethodWebSocket, Path: "/echo", Handler: handler, Name: "echo"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to register routes: %v", err)
+	}
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func dialWebSocket(t *testing.T, server *httptest.Server, path string) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + path
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial %s: %v", url, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestWebSocketEcho(t *testing.T) {
+	server := newWebSocketServer(t, WebSocket(echo))
+	conn := dialWebSocket(t, server, "/live/echo")
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("hello")); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	messageType, message, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	if messageType != websocket.TextMessage || string(message) != "hello" {
+		t.Errorf("Expected text 'hello', got: %d %q", messageType, message)
+	}
+}
+
+func TestWebSocketKeepAlive(t *testing.T) {
+	config := WebSocketConfig{PingInterval: 10 * time.Millisecond}
+	server := newWebSocketServer(t, WebSocketWithConfig(config, echo))
+	conn := dialWebSocket(t, server, "/live/echo")
+
+	pings := make(chan struct{}, 10)
+	conn.SetPingHandler(func(data string) error {
+		select {
+		case pings <- struct{}{}:
+		default:
+		}
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+	})
+
+	// Control frames are handled while reading
+	messages := make(chan string, 1)
+	go func() {
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			messages <- string(message)
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-pings:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected ping %d from the server", i+1)
+		}
+	}
+
+	// Answering pings keeps the connection open past PongWait
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("still here")); err != nil {
+		t.Fatalf("Failed to write message: %v", err)
+	}
+	select {
+	case message := <-messages:
+		if message != "still here" {
+			t.Errorf("Expected the message echoed, got: %q", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Expected the connection to stay open")
+	}
+}
+
+func TestWebSocketRejectsPlainRequests(t *testing.T) {
+	server := newWebSocketServer(t, WebSocket(echo))
+
+	resp, err := http.Get(server.URL + "/live/echo")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for a non-upgrade request, got: %d", resp.StatusCode)
+	}
+
+	// Cross-origin upgrades are rejected by default
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/live/echo"
+	_, resp, err = websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://evil.example"}})
+	if err == nil {
+		t.Fatal("Expected a cross-origin upgrade to fail")
+	}
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected status 403 for a cross-origin upgrade, got: %d", resp.StatusCode)
+	}
+}
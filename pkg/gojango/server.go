@@ -0,0 +1,211 @@
+package gojango
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Default HTTP server timeouts. There is no default write timeout, since it
+// would cut off long-lived responses such as server-sent event streams.
+const (
+	DefaultReadHeaderTimeout = 10 * time.Second
+	DefaultReadTimeout       = 30 * time.Second
+	DefaultIdleTimeout       = 120 * time.Second
+)
+
+// unixAddressPrefix marks a host as a Unix socket path
+const unixAddressPrefix = "unix:"
+
+// WithHost sets the interface the server binds to, e.g. "127.0.0.1" to
+// accept connections only from a reverse proxy on the same machine. A host
+// of the form "unix:/path/to.sock" serves on a Unix socket instead, and the
+// port is ignored.
+func WithHost(host string) Option {
+	return func(app *Application) {
+		app.host = host
+	}
+}
+
+// WithListener serves on an existing listener, such as one inherited from
+// a process manager, instead of binding the configured address
+func WithListener(listener net.Listener) Option {
+	return func(app *Application) {
+		app.listener = listener
+	}
+}
+
+// Addr returns the address the server binds to: host:port, :port when no
+// host is set, or unix:/path for a Unix socket
+func (app *Application) Addr() string {
+	if app.listener != nil {
+		return app.listener.Addr().String()
+	}
+	if strings.HasPrefix(app.host, unixAddressPrefix) {
+		return app.host
+	}
+	return net.JoinHostPort(app.host, app.port)
+}
+
+// listen binds the configured address. A stale Unix socket left by an
+// earlier process is removed first, while one another process still
+// accepts connections on is left alone.
+func (app *Application) listen() (net.Listener, error) {
+	addr := app.Addr()
+	if path, ok := strings.CutPrefix(addr, unixAddressPrefix); ok {
+		if info, err := os.Stat(path); err == nil && info.Mode().Type() == fs.ModeSocket {
+			if err := removeStaleSocket(path); err != nil {
+				return nil, err
+			}
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to check socket %s: %w", path, err)
+		}
+
+		listener, err := net.Listen("unix", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen on socket %s: %w", path, err)
+		}
+		return listener, nil
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return listener, nil
+}
+
+// removeStaleSocket removes a Unix socket nothing listens on, which refuses
+// connections
+func removeStaleSocket(path string) error {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("socket %s is in use by another process", path)
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return fmt.Errorf("failed to check socket %s: %w", path, err)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+	}
+	return nil
+}
+
+// serverTimeouts holds the HTTP server timeouts. A negative value means
+// unset, so the setting or default applies; zero disables the timeout.
+type serverTimeouts struct {
+	read       time.Duration
+	readHeader time.Duration
+	write      time.Duration
+	idle       time.Duration
+}
+
+// unsetServerTimeouts marks every timeout as unset
+var unsetServerTimeouts = serverTimeouts{read: -1, readHeader: -1, write: -1, idle: -1}
+
+// WithReadTimeout limits how long reading a request, including its body,
+// may take. Zero disables the timeout.
+func WithReadTimeout(timeout time.Duration) Option {
+	return func(app *Application) {
+		app.timeouts.read = timeout
+	}
+}
+
+// WithReadHeaderTimeout limits how long reading request headers may take.
+// Zero disables the timeout.
+func WithReadHeaderTimeout(timeout time.Duration) Option {
+	return func(app *Application) {
+		app.timeouts.readHeader = timeout
+	}
+}
+
+// WithWriteTimeout limits how long writing a response may take, counted
+// from the end of reading the request headers. Zero disables the timeout.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(app *Application) {
+		app.timeouts.write = timeout
+	}
+}
+
+// WithIdleTimeout limits how long a keep-alive connection may wait for the
+// next request. Zero disables the timeout.
+func WithIdleTimeout(timeout time.Duration) Option {
+	return func(app *Application) {
+		app.timeouts.idle = timeout
+	}
+}
+
+// loadTimeoutSettings fills timeouts not set in code from READ_TIMEOUT,
+// READ_HEADER_TIMEOUT, WRITE_TIMEOUT and IDLE_TIMEOUT, then the defaults
+func (app *Application) loadTimeoutSettings() error {
+	timeouts := []struct {
+		key          string
+		value        *time.Duration
+		defaultValue time.Duration
+	}{
+		{"READ_TIMEOUT", &app.timeouts.read, DefaultReadTimeout},
+		{"READ_HEADER_TIMEOUT", &app.timeouts.readHeader, DefaultReadHeaderTimeout},
+		{"WRITE_TIMEOUT", &app.timeouts.write, 0},
+		{"IDLE_TIMEOUT", &app.timeouts.idle, DefaultIdleTimeout},
+	}
+
+	for _, timeout := range timeouts {
+		if *timeout.value >= 0 {
+			continue
+		}
+		*timeout.value = timeout.defaultValue
+		if app.settings == nil {
+			continue
+		}
+		if value := app.settings.Get(timeout.key); value != nil && value != "" {
+			duration, err := parseDurationSetting(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", timeout.key, err)
+			}
+			*timeout.value = duration
+		}
+	}
+	return nil
+}
+
+// parseDurationSetting converts a duration setting, either a string such as
+// "30s" or "1m30s" or a number of seconds
+func parseDurationSetting(value interface{}) (time.Duration, error) {
+	var duration time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		duration = v
+	case int:
+		duration = time.Duration(v) * time.Second
+	case int64:
+		duration = time.Duration(v) * time.Second
+	case float64:
+		duration = time.Duration(v * float64(time.Second))
+	case string:
+		v = strings.TrimSpace(v)
+		if seconds, err := strconv.Atoi(v); err == nil {
+			duration = time.Duration(seconds) * time.Second
+			break
+		}
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("expected a duration such as \"30s\", got %q", v)
+		}
+		duration = parsed
+	default:
+		return 0, fmt.Errorf("expected a duration such as \"30s\", got %T", value)
+	}
+
+	if duration < 0 {
+		return 0, fmt.Errorf("duration must not be negative, got %s", duration)
+	}
+	return duration, nil
+}
@@ -0,0 +1,272 @@
+package gojango
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupTestServer configures app's HTTP server with the given settings
+func setupTestServer(t *testing.T, app *Application, values map[string]interface{}) error {
+	t.Helper()
+	settings := newTestSettings()
+	for key, value := range values {
+		settings.Set(key, value)
+	}
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	return app.setupHTTPServer()
+}
+
+func TestServerTimeoutDefaults(t *testing.T) {
+	app := New()
+	if err := setupTestServer(t, app, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server := app.server
+	if server.ReadTimeout != DefaultReadTimeout || server.ReadHeaderTimeout != DefaultReadHeaderTimeout || server.IdleTimeout != DefaultIdleTimeout {
+		t.Errorf("Expected default timeouts, got read %s, read header %s, idle %s", server.ReadTimeout, server.ReadHeaderTimeout, server.IdleTimeout)
+	}
+	if server.WriteTimeout != 0 {
+		t.Errorf("Expected no default write timeout, got: %s", server.WriteTimeout)
+	}
+	if server.TLSConfig != nil {
+		t.Error("Expected no TLS config without a certificate")
+	}
+}
+
+func TestServerTimeoutOptions(t *testing.T) {
+	app := New(
+		WithReadTimeout(5*time.Second),
+		WithReadHeaderTimeout(2*time.Second),
+		WithWriteTimeout(15*time.Second),
+		WithIdleTimeout(0),
+	)
+	// Options take precedence over settings
+	err := setupTestServer(t, app, map[string]interface{}{"READ_TIMEOUT": "1m", "IDLE_TIMEOUT": "1m"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server := app.server
+	if server.ReadTimeout != 5*time.Second {
+		t.Errorf("Expected read timeout 5s, got: %s", server.ReadTimeout)
+	}
+	if server.ReadHeaderTimeout != 2*time.Second {
+		t.Errorf("Expected read header timeout 2s, got: %s", server.ReadHeaderTimeout)
+	}
+	if server.WriteTimeout != 15*time.Second {
+		t.Errorf("Expected write timeout 15s, got: %s", server.WriteTimeout)
+	}
+	if server.IdleTimeout != 0 {
+		t.Errorf("Expected the idle timeout to be disabled, got: %s", server.IdleTimeout)
+	}
+}
+
+func TestServerTimeoutSettings(t *testing.T) {
+	app := New()
+	err := setupTestServer(t, app, map[string]interface{}{
+		"READ_TIMEOUT":        "45s",
+		"READ_HEADER_TIMEOUT": 5,
+		"WRITE_TIMEOUT":       "1m30s",
+		"IDLE_TIMEOUT":        "0s",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	server := app.server
+	if server.ReadTimeout != 45*time.Second || server.ReadHeaderTimeout != 5*time.Second ||
+		server.WriteTimeout != 90*time.Second || server.IdleTimeout != 0 {
+		t.Errorf("Unexpected timeouts: read %s, read header %s, write %s, idle %s",
+			server.ReadTimeout, server.ReadHeaderTimeout, server.WriteTimeout, server.IdleTimeout)
+	}
+
+	for _, value := range []interface{}{"soon", "-5s", true} {
+		err := setupTestServer(t, New(), map[string]interface{}{"WRITE_TIMEOUT": value})
+		if err == nil || !strings.Contains(err.Error(), "invalid WRITE_TIMEOUT") {
+			t.Errorf("Expected an error for WRITE_TIMEOUT %v, got: %v", value, err)
+		}
+	}
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	certFile, keyFile, _ := writeSelfSignedCert(t, t.TempDir())
+	app := New(WithTLS(certFile, keyFile), WithTLSMinVersion(tls.VersionTLS13))
+	if err := setupTestServer(t, app, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !app.TLSEnabled() {
+		t.Error("Expected TLS to be enabled")
+	}
+	if app.server.TLSConfig == nil || app.server.TLSConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("Expected a TLS 1.3 config, got: %+v", app.server.TLSConfig)
+	}
+	if app.tls.certFile != certFile || app.tls.keyFile != keyFile {
+		t.Errorf("Expected the certificate files to be set, got: %s, %s", app.tls.certFile, app.tls.keyFile)
+	}
+}
+
+func TestApplicationAddr(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		expected string
+	}{
+		{nil, ":8080"},
+		{[]Option{WithPort("9000")}, ":9000"},
+		{[]Option{WithHost("127.0.0.1"), WithPort("9000")}, "127.0.0.1:9000"},
+		{[]Option{WithHost("::1")}, "[::1]:8080"},
+		{[]Option{WithHost("unix:/run/app.sock"), WithPort("9000")}, "unix:/run/app.sock"},
+	}
+
+	for _, tt := range tests {
+		app := New(tt.opts...)
+		if addr := app.Addr(); addr != tt.expected {
+			t.Errorf("Expected address %s, got: %s", tt.expected, addr)
+		}
+		if err := setupTestServer(t, app, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if app.server.Addr != tt.expected {
+			t.Errorf("Expected server address %s, got: %s", tt.expected, app.server.Addr)
+		}
+	}
+}
+
+// runTestApp runs app until the returned function is called, which stops
+// it and returns Run's error
+func runTestApp(t *testing.T, app *Application) func() error {
+	t.Helper()
+	app.registry = NewRegistry()
+	app.LoadSettings(newTestSettings())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Run(ctx)
+	}()
+
+	return func() error {
+		cancel()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(10 * time.Second):
+			t.Fatal("Server did not shut down")
+			return nil
+		}
+	}
+}
+
+// getStatus requests /health through client, retrying while the server
+// starts
+func getStatus(t *testing.T, client *http.Client, url string) int {
+	t.Helper()
+	var err error
+	for i := 0; i < 50; i++ {
+		var resp *http.Response
+		if resp, err = client.Get(url); err == nil {
+			resp.Body.Close()
+			return resp.StatusCode
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatalf("Request to %s failed: %v", url, err)
+	return 0
+}
+
+func TestRunWithListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	app := New(WithListener(listener), WithPort("1"))
+	if app.Addr() != listener.Addr().String() {
+		t.Errorf("Expected the listener address, got: %s", app.Addr())
+	}
+	stop := runTestApp(t, app)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	if status := getStatus(t, client, "http://"+listener.Addr().String()+"/health"); status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+	if err := stop(); err != nil {
+		t.Errorf("Run returned an error: %v", err)
+	}
+}
+
+func TestRunOnUnixSocket(t *testing.T) {
+	// Socket paths are limited to about 100 bytes, so avoid long temp dirs
+	dir, err := os.MkdirTemp("", "gojango")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "app.sock")
+
+	// A socket left behind by an earlier process is replaced
+	stale, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Failed to create socket: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	stop := runTestApp(t, New(WithHost("unix:"+socket)))
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+	if status := getStatus(t, client, "http://app/health"); status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+	if err := stop(); err != nil {
+		t.Errorf("Run returned an error: %v", err)
+	}
+	if _, err := os.Stat(socket); !os.IsNotExist(err) {
+		t.Errorf("Expected the socket to be removed on shutdown, got: %v", err)
+	}
+}
+
+func TestListenKeepsSocketInUse(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gojango")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "app.sock")
+
+	// Another server still accepts connections on the socket
+	live, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Failed to create socket: %v", err)
+	}
+	defer live.Close()
+
+	if listener, err := New(WithHost("unix:" + socket)).listen(); err == nil {
+		listener.Close()
+		t.Fatal("Expected listening on a socket in use to fail")
+	} else if !strings.Contains(err.Error(), "in use") {
+		t.Errorf("Expected an in use error, got: %v", err)
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		t.Fatalf("Expected the other server's socket to be left alone: %v", err)
+	}
+	conn.Close()
+}
@@ -1,6 +1,7 @@
 package gojango
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -85,11 +86,11 @@ func (s *BasicSettings) GetString(key string, defaultValue ...string) string {
 	}
 	
 	if str, ok := val.(string); ok {
-		return str
+		return strings.TrimSpace(str)
 	}
 	
 	// Try to convert to string
-	return strings.TrimSpace(val.(string))
+	return fmt.Sprintf("%v", val)
 }
 
 // GetInt retrieves an integer setting with optional default
@@ -0,0 +1,98 @@
+package gojango
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango/cache"
+)
+
+// Cache returns the application's cache, created from the default alias of
+// CACHES on first use and made the default cache of the cache package, which
+// CacheView uses
+func (app *Application) Cache() (cache.Cache, error) {
+	if app.cache != nil {
+		return app.cache, nil
+	}
+	if app.settings == nil {
+		return nil, fmt.Errorf("settings not loaded - call LoadSettings() first")
+	}
+
+	c, err := NewCacheFromSettings(app.settings, "default")
+	if err != nil {
+		return nil, err
+	}
+	app.cache = c
+	cache.SetDefault(c)
+	return c, nil
+}
+
+// NewCacheFromSettings creates the cache configured by CACHES[alias]:
+//
+//	CACHES = {
+//	    "default": {
+//	        "backend": "redis",   # memory or redis
+//	        "location": "redis://localhost:6379/1",
+//	        "key_prefix": "blog",
+//	    },
+//	}
+//
+// Keys are matched case-insensitively, so Django's "BACKEND" and "LOCATION"
+// work too. Memory caches take max_entries. Without CACHES, the default
+// alias is an in-memory cache.
+func NewCacheFromSettings(settings Settings, alias string) (cache.Cache, error) {
+	config, err := cacheConfig(settings, alias)
+	if err != nil {
+		return nil, err
+	}
+	return cache.New(*config)
+}
+
+// cacheConfig builds a cache.Config from CACHES[alias]
+func cacheConfig(settings Settings, alias string) (*cache.Config, error) {
+	raw := settings.Get("CACHES")
+	if raw == nil && alias == "default" {
+		return &cache.Config{}, nil
+	}
+	caches, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("CACHES is not set or not a dict")
+	}
+	entry, ok := caches[alias].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("CACHES has no %q entry", alias)
+	}
+
+	options := make(map[string]interface{}, len(entry))
+	for key, value := range entry {
+		options[strings.ToLower(key)] = value
+	}
+	field := func(key string) string {
+		return fmt.Sprintf("CACHES[%q].%s", alias, key)
+	}
+
+	config := &cache.Config{}
+	for key, target := range map[string]*string{
+		"backend":    &config.Backend,
+		"location":   &config.Location,
+		"key_prefix": &config.KeyPrefix,
+	} {
+		value, exists := options[key]
+		if !exists {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a string, got %T", field(key), value)
+		}
+		*target = str
+	}
+	if value, exists := options["max_entries"]; exists {
+		n, ok := settingInt(value)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an integer, got %v", field("max_entries"), value)
+		}
+		config.MaxEntries = n
+	}
+	return config, nil
+}
@@ -0,0 +1,60 @@
+package gojango
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/cache"
+)
+
+func TestNewCacheFromSettings(t *testing.T) {
+	settings := loadStarlarkSettings(t, `
+CACHES = {
+    "default": {
+        "BACKEND": "memory",
+        "MAX_ENTRIES": 50,
+    },
+    "shared": {
+        "backend": "redis",
+        "location": "redis://cache.internal:6379/1",
+        "key_prefix": "blog",
+    },
+    "broken": {
+        "backend": "redis",
+        "location": 6379,
+    },
+}
+`)
+
+	config, err := cacheConfig(settings, "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Backend != "memory" || config.MaxEntries != 50 {
+		t.Errorf("Unexpected default config: %+v", config)
+	}
+
+	c, err := NewCacheFromSettings(settings, "shared")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := c.(*cache.RedisCache); !ok {
+		t.Errorf("Expected a Redis cache, got %T", c)
+	}
+
+	if _, err := NewCacheFromSettings(settings, "broken"); err == nil || !strings.Contains(err.Error(), `CACHES["broken"].location must be a string`) {
+		t.Errorf("Expected a location type error, got %v", err)
+	}
+	if _, err := NewCacheFromSettings(settings, "missing"); err == nil {
+		t.Error("Expected an error for a missing alias")
+	}
+
+	// Without CACHES the default cache lives in memory
+	c, err = NewCacheFromSettings(NewBasicSettings(), "default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := c.(*cache.MemoryCache); !ok {
+		t.Errorf("Expected a memory cache, got %T", c)
+	}
+}
@@ -0,0 +1,142 @@
+package gojango
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+// GetNested retrieves a nested setting by its keys, e.g.
+// GetNested("DATABASES", "default", "host"). Unlike GetPath, keys may
+// contain dots. It returns nil if the setting is not set.
+func (s *StarlarkSettings) GetNested(path ...string) interface{} {
+	val, _ := resolveNested(s.data, path)
+	return val
+}
+
+// GetDatabaseConfig builds the database configuration for an alias of the
+// DATABASES setting
+func (s *StarlarkSettings) GetDatabaseConfig(alias string) (*db.Config, error) {
+	return databaseConfig(s, alias)
+}
+
+// GetNested retrieves a nested setting by its keys, e.g.
+// GetNested("DATABASES", "default", "host"). Unlike GetPath, keys may
+// contain dots. It returns nil if the setting is not set.
+func (s *SettingsStack) GetNested(path ...string) interface{} {
+	val, _ := resolveNested(s.merged.data, path)
+	return val
+}
+
+// GetDatabaseConfig builds the database configuration for an alias of the
+// DATABASES setting
+func (s *SettingsStack) GetDatabaseConfig(alias string) (*db.Config, error) {
+	return databaseConfig(s, alias)
+}
+
+// databaseConfig builds a db.Config from DATABASES[alias]:
+//
+//	DATABASES = {
+//	    "default": {
+//	        "engine": "postgres",   # postgres, mysql or sqlite
+//	        "host": "localhost",
+//	        "port": 5432,
+//	        "name": "blog",
+//	        "user": "blog",
+//	        "password": "",
+//	    },
+//	}
+//
+// Keys are matched case-insensitively, so Django's "ENGINE" and "NAME" work
+// too. Optional keys are sslmode, connect_retries, max_open_conns and
+// max_idle_conns.
+func databaseConfig(settings Settings, alias string) (*db.Config, error) {
+	databases, ok := settings.Get("DATABASES").(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("DATABASES is not set or not a dict")
+	}
+	entry, ok := databases[alias].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("DATABASES has no %q entry", alias)
+	}
+
+	options := make(map[string]interface{}, len(entry))
+	for key, value := range entry {
+		options[strings.ToLower(key)] = value
+	}
+	field := func(key string) string {
+		return fmt.Sprintf("DATABASES[%q].%s", alias, key)
+	}
+
+	engine, _ := options["engine"].(string)
+	driver, err := db.ParseDriver(engine)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", field("engine"), err)
+	}
+
+	values := make(map[string]string)
+	for _, key := range []string{"name", "host", "user", "password", "sslmode"} {
+		if value, exists := options[key]; exists {
+			str, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a string, got %T", field(key), value)
+			}
+			values[key] = str
+		}
+	}
+
+	var config *db.Config
+	switch driver {
+	case db.DriverPostgres:
+		config = db.PostgresConfig(values["host"], values["name"], values["user"], values["password"])
+	case db.DriverSQLite:
+		config = db.SQLiteConfig(values["name"])
+	default:
+		config = db.DefaultConfig()
+		config.Driver = driver
+		config.Host = values["host"]
+		config.Port = 3306
+		config.Database = values["name"]
+		config.Username = values["user"]
+		config.Password = values["password"]
+	}
+	if sslMode := values["sslmode"]; sslMode != "" {
+		config.SSLMode = sslMode
+	}
+
+	for key, target := range map[string]*int{
+		"port":            &config.Port,
+		"connect_retries": &config.ConnectRetries,
+		"max_open_conns":  &config.MaxOpenConns,
+		"max_idle_conns":  &config.MaxIdleConns,
+	} {
+		value, exists := options[key]
+		if !exists {
+			continue
+		}
+		n, ok := settingInt(value)
+		if !ok {
+			return nil, fmt.Errorf("%s must be an integer, got %v", field(key), value)
+		}
+		*target = n
+	}
+
+	return config, nil
+}
+
+// settingInt converts an integer setting, which may be a numeric string when
+// it comes from the environment
+func settingInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		return n, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,123 @@
+package gojango
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+)
+
+func loadStarlarkSettings(t *testing.T, content string) *StarlarkSettings {
+	t.Helper()
+	settingsFile := filepath.Join(t.TempDir(), "settings.star")
+	if err := os.WriteFile(settingsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write settings file: %v", err)
+	}
+
+	settings := NewStarlarkSettings()
+	if err := settings.LoadFromFile(settingsFile); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	return settings
+}
+
+func TestGetDatabaseConfig(t *testing.T) {
+	settings := loadStarlarkSettings(t, `
+DATABASES = {
+    "default": {
+        "engine": "postgres",
+        "host": "db.internal",
+        "port": 5433,
+        "name": "blog",
+        "user": "blog",
+        "password": "s3cret",
+        "connect_retries": 5,
+    },
+    "analytics": {
+        "ENGINE": "mysql",
+        "HOST": "mysql.internal",
+        "NAME": "events",
+        "USER": "reader",
+    },
+    "cache": {
+        "engine": "sqlite",
+        "name": "cache.db",
+    },
+}
+`)
+
+	config, err := settings.GetDatabaseConfig("default")
+	if err != nil {
+		t.Fatalf("Failed to build config: %v", err)
+	}
+	if config.Driver != db.DriverPostgres || config.Host != "db.internal" || config.Port != 5433 ||
+		config.Database != "blog" || config.Username != "blog" || config.Password != "s3cret" {
+		t.Errorf("Unexpected postgres config: %+v", config)
+	}
+	if config.SSLMode != "disable" || config.ConnectRetries != 5 {
+		t.Errorf("Expected postgres defaults and retries, got: %+v", config)
+	}
+
+	config, err = settings.GetDatabaseConfig("analytics")
+	if err != nil {
+		t.Fatalf("Failed to build config: %v", err)
+	}
+	if config.Driver != db.DriverMySQL || config.Host != "mysql.internal" || config.Port != 3306 ||
+		config.Database != "events" || config.Username != "reader" {
+		t.Errorf("Unexpected mysql config: %+v", config)
+	}
+
+	config, err = settings.GetDatabaseConfig("cache")
+	if err != nil {
+		t.Fatalf("Failed to build config: %v", err)
+	}
+	if config.Driver != db.DriverSQLite || config.Database != "cache.db" || config.MaxOpenConns != 1 {
+		t.Errorf("Unexpected sqlite config: %+v", config)
+	}
+}
+
+func TestGetDatabaseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"no DATABASES", `DEBUG = True`, "DATABASES is not set"},
+		{"missing alias", `DATABASES = {"other": {"engine": "sqlite"}}`, `no "default" entry`},
+		{"unknown engine", `DATABASES = {"default": {"engine": "oracle"}}`, `unsupported database engine: "oracle"`},
+		{"bad port", `DATABASES = {"default": {"engine": "postgres", "port": "high"}}`, `DATABASES["default"].port must be an integer`},
+		{"bad host", `DATABASES = {"default": {"engine": "postgres", "host": 1}}`, `DATABASES["default"].host must be a string`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := loadStarlarkSettings(t, tt.content)
+			_, err := settings.GetDatabaseConfig("default")
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("Expected error containing %q, got: %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestGetNested(t *testing.T) {
+	settings := loadStarlarkSettings(t, `
+CACHES = {"default": {"backend": "redis", "options": {"db.index": 2}}}
+`)
+
+	if got := settings.GetNested("CACHES", "default", "backend"); got != "redis" {
+		t.Errorf("Expected 'redis', got: %v", got)
+	}
+	// Keys containing dots can't be reached with GetPath
+	if got := settings.GetNested("CACHES", "default", "options", "db.index"); got != 2 {
+		t.Errorf("Expected 2, got: %v", got)
+	}
+	if got := settings.GetNested("CACHES", "missing"); got != nil {
+		t.Errorf("Expected nil for a missing key, got: %v", got)
+	}
+	if got := settings.GetNested("CACHES", "default", "backend", "deeper"); got != nil {
+		t.Errorf("Expected nil below a non-dict value, got: %v", got)
+	}
+}
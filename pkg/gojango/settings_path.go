@@ -0,0 +1,158 @@
+package gojango
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// resolveSettingPath looks up a dotted path such as "DATABASES.default.host"
+// in settings data. The first segment names a top-level setting and each
+// following segment a key of a nested map. A top-level key that itself
+// contains dots is matched as-is first.
+func resolveSettingPath(data map[string]interface{}, path string) (interface{}, bool) {
+	if val, exists := data[path]; exists {
+		return val, true
+	}
+
+	return resolveNested(data, strings.Split(path, "."))
+}
+
+// resolveNested looks up a sequence of keys in nested settings maps
+func resolveNested(data map[string]interface{}, keys []string) (interface{}, bool) {
+	var current interface{} = data
+	for _, key := range keys {
+		switch m := current.(type) {
+		case map[string]interface{}:
+			val, exists := m[key]
+			if !exists {
+				return nil, false
+			}
+			current = val
+		case map[string]string:
+			val, exists := m[key]
+			if !exists {
+				return nil, false
+			}
+			current = val
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// pathString converts a resolved path value to a string
+func pathString(val interface{}, found bool, defaultValue []string) string {
+	if !found || val == nil {
+		if len(defaultValue) > 0 {
+			return defaultValue[0]
+		}
+		return ""
+	}
+	if str, ok := val.(string); ok {
+		return str
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// pathInt converts a resolved path value to an int
+func pathInt(val interface{}, found bool, defaultValue []int) int {
+	if found {
+		switch v := val.(type) {
+		case int:
+			return v
+		case int64:
+			return int(v)
+		case float64:
+			if v == float64(int(v)) {
+				return int(v)
+			}
+		case string:
+			if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return i
+			}
+		}
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return 0
+}
+
+// pathBool converts a resolved path value to a bool
+func pathBool(val interface{}, found bool, defaultValue []bool) bool {
+	if found {
+		switch v := val.(type) {
+		case bool:
+			return v
+		case string:
+			str := strings.ToLower(strings.TrimSpace(v))
+			return str == "true" || str == "1" || str == "yes" || str == "on"
+		}
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return false
+}
+
+// GetPath retrieves a nested setting by dotted path, e.g.
+// "DATABASES.default.host", with optional default
+func (s *BasicSettings) GetPath(path string, defaultValue ...interface{}) interface{} {
+	if val, found := resolveSettingPath(s.data, path); found {
+		return val
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return nil
+}
+
+// GetPathString retrieves a nested string setting by dotted path
+func (s *BasicSettings) GetPathString(path string, defaultValue ...string) string {
+	val, found := resolveSettingPath(s.data, path)
+	return pathString(val, found, defaultValue)
+}
+
+// GetPathInt retrieves a nested integer setting by dotted path
+func (s *BasicSettings) GetPathInt(path string, defaultValue ...int) int {
+	val, found := resolveSettingPath(s.data, path)
+	return pathInt(val, found, defaultValue)
+}
+
+// GetPathBool retrieves a nested boolean setting by dotted path
+func (s *BasicSettings) GetPathBool(path string, defaultValue ...bool) bool {
+	val, found := resolveSettingPath(s.data, path)
+	return pathBool(val, found, defaultValue)
+}
+
+// GetPath retrieves a nested setting by dotted path, e.g.
+// "DATABASES.default.host", with optional default
+func (s *StarlarkSettings) GetPath(path string, defaultValue ...interface{}) interface{} {
+	if val, found := resolveSettingPath(s.data, path); found {
+		return val
+	}
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+	return nil
+}
+
+// GetPathString retrieves a nested string setting by dotted path
+func (s *StarlarkSettings) GetPathString(path string, defaultValue ...string) string {
+	val, found := resolveSettingPath(s.data, path)
+	return pathString(val, found, defaultValue)
+}
+
+// GetPathInt retrieves a nested integer setting by dotted path
+func (s *StarlarkSettings) GetPathInt(path string, defaultValue ...int) int {
+	val, found := resolveSettingPath(s.data, path)
+	return pathInt(val, found, defaultValue)
+}
+
+// GetPathBool retrieves a nested boolean setting by dotted path
+func (s *StarlarkSettings) GetPathBool(path string, defaultValue ...bool) bool {
+	val, found := resolveSettingPath(s.data, path)
+	return pathBool(val, found, defaultValue)
+}
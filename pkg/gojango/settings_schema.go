@@ -0,0 +1,216 @@
+package gojango
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SettingType is the type a setting's value must have
+type SettingType string
+
+const (
+	SettingAny    SettingType = ""
+	SettingString SettingType = "string"
+	SettingInt    SettingType = "int"
+	SettingBool   SettingType = "bool"
+	SettingList   SettingType = "list"
+	SettingMap    SettingType = "map"
+)
+
+// SettingRule describes what a valid setting looks like. Values read from
+// the environment are strings, so numeric and boolean strings pass the int
+// and bool types.
+type SettingRule struct {
+	// Required settings must be set, and strings must not be empty
+	Required bool
+
+	// Type is the type the value must have, if any
+	Type SettingType
+
+	// Choices lists the allowed values, if they are restricted
+	Choices []string
+}
+
+// SettingsSchema declares the settings an application expects. Keys may be
+// dotted paths into nested settings, e.g. "DATABASES.default.ENGINE".
+type SettingsSchema struct {
+	Rules map[string]SettingRule
+
+	// Warnings returns problems worth logging that shouldn't stop startup
+	Warnings func(settings Settings) []string
+}
+
+// SettingError describes one invalid setting
+type SettingError struct {
+	Key     string
+	Message string
+}
+
+func (e *SettingError) Error() string {
+	return fmt.Sprintf("%s %s", e.Key, e.Message)
+}
+
+// SettingsErrors collects every invalid setting, so all problems are
+// reported at once instead of only the first
+type SettingsErrors []*SettingError
+
+func (e SettingsErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = "  " + err.Error()
+	}
+	return fmt.Sprintf("%d settings are invalid:\n%s", len(e), strings.Join(messages, "\n"))
+}
+
+// databaseDrivers are the DATABASE_DRIVER names the db package understands
+var databaseDrivers = []string{"postgres", "postgresql", "pgsql", "sqlite", "sqlite3", "mysql"}
+
+// DefaultSettingsSchema returns the schema Application.Initialize validates
+// settings against. It requires SECRET_KEY, checks the types of framework
+// settings and the DATABASE_DRIVER name, and warns when DEBUG is enabled
+// with ENVIRONMENT set to "production". Apps can add rules to the returned
+// schema and validate against it themselves.
+func DefaultSettingsSchema() SettingsSchema {
+	return SettingsSchema{
+		Rules: map[string]SettingRule{
+			"SECRET_KEY":        {Required: true, Type: SettingString},
+			"DEBUG":             {Type: SettingBool},
+			"PORT":              {Type: SettingInt},
+			"HOST":              {Type: SettingString},
+			"DATABASE_URL":      {Type: SettingString},
+			"DATABASE_DRIVER":   {Type: SettingString, Choices: databaseDrivers},
+			"MIDDLEWARE_PRESET": {Type: SettingString},
+			"CSRF_ENABLED":      {Type: SettingBool},
+			"JSON_INDENT":       {Type: SettingBool},
+			"USE_I18N":          {Type: SettingBool},
+			"LANGUAGE_CODE":     {Type: SettingString},
+			"LOCALE_DIR":        {Type: SettingString},
+			"TIME_ZONE":         {Type: SettingString},
+			"CACHES":            {Type: SettingMap},
+			"MEDIA_ROOT":        {Type: SettingString},
+			"MEDIA_URL":         {Type: SettingString},
+			"INSTALLED_APPS":    {Type: SettingList},
+		},
+		Warnings: func(settings Settings) []string {
+			if strings.EqualFold(settings.GetString("ENVIRONMENT"), "production") && settings.GetBool("DEBUG") {
+				return []string{"DEBUG is enabled in production; set DEBUG=false"}
+			}
+			return nil
+		},
+	}
+}
+
+// Validate checks settings against the schema, logging its warnings, and
+// returns SettingsErrors listing every invalid setting
+func (schema SettingsSchema) Validate(settings Settings) error {
+	keys := make([]string, 0, len(schema.Rules))
+	for key := range schema.Rules {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var errs SettingsErrors
+	for _, key := range keys {
+		if message := schema.Rules[key].check(settings.GetPath(key)); message != "" {
+			errs = append(errs, &SettingError{Key: key, Message: message})
+		}
+	}
+
+	if schema.Warnings != nil {
+		for _, warning := range schema.Warnings(settings) {
+			log.Printf("Warning: %s", warning)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// check returns what is wrong with a setting's value, or "" if it is valid
+func (rule SettingRule) check(value interface{}) string {
+	if value == nil || value == "" {
+		if rule.Required {
+			return "is required"
+		}
+		return ""
+	}
+
+	if !hasSettingType(value, rule.Type) {
+		return fmt.Sprintf("must be of type %s, got %T", rule.Type, value)
+	}
+
+	if len(rule.Choices) > 0 {
+		str := fmt.Sprintf("%v", value)
+		for _, choice := range rule.Choices {
+			if str == choice {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(rule.Choices, ", "), str)
+	}
+	return ""
+}
+
+// hasSettingType reports whether value has, or converts cleanly to, the type
+func hasSettingType(value interface{}, settingType SettingType) bool {
+	switch settingType {
+	case SettingString:
+		_, ok := value.(string)
+		return ok
+	case SettingInt:
+		switch v := value.(type) {
+		case int, int64:
+			return true
+		case float64:
+			return v == float64(int(v))
+		case string:
+			_, err := strconv.Atoi(strings.TrimSpace(v))
+			return err == nil
+		}
+		return false
+	case SettingBool:
+		switch v := value.(type) {
+		case bool:
+			return true
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "false", "1", "0", "yes", "no", "on", "off":
+				return true
+			}
+		}
+		return false
+	case SettingList:
+		switch value.(type) {
+		case []interface{}, []string:
+			return true
+		}
+		return false
+	case SettingMap:
+		switch value.(type) {
+		case map[string]interface{}, map[string]string:
+			return true
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// Validate checks the settings against a schema
+func (s *BasicSettings) Validate(schema SettingsSchema) error {
+	return schema.Validate(s)
+}
+
+// Validate checks the settings against a schema
+func (s *StarlarkSettings) Validate(schema SettingsSchema) error {
+	return schema.Validate(s)
+}
@@ -0,0 +1,151 @@
+package gojango
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// validationErrors validates settings against schema and returns the
+// invalid keys
+func validationErrors(t *testing.T, settings Settings, schema SettingsSchema) []string {
+	t.Helper()
+	err := settings.Validate(schema)
+	if err == nil {
+		return nil
+	}
+
+	var errs SettingsErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected SettingsErrors, got: %v", err)
+	}
+	keys := make([]string, len(errs))
+	for i, settingErr := range errs {
+		keys[i] = settingErr.Key
+	}
+	return keys
+}
+
+func TestValidateRequired(t *testing.T) {
+	settings := NewBasicSettings()
+	if keys := validationErrors(t, settings, DefaultSettingsSchema()); strings.Join(keys, ",") != "SECRET_KEY" {
+		t.Errorf("Expected a missing SECRET_KEY, got: %v", keys)
+	}
+
+	settings.Set("SECRET_KEY", "")
+	err := settings.Validate(DefaultSettingsSchema())
+	if err == nil || err.Error() != "SECRET_KEY is required" {
+		t.Errorf("Expected an empty SECRET_KEY to be rejected, got: %v", err)
+	}
+
+	settings.Set("SECRET_KEY", "s3cret")
+	if err := settings.Validate(DefaultSettingsSchema()); err != nil {
+		t.Errorf("Expected valid settings, got: %v", err)
+	}
+}
+
+func TestValidateTypes(t *testing.T) {
+	schema := SettingsSchema{Rules: map[string]SettingRule{
+		"NAME":    {Type: SettingString},
+		"WORKERS": {Type: SettingInt},
+		"VERBOSE": {Type: SettingBool},
+		"HOSTS":   {Type: SettingList},
+		"CACHES":  {Type: SettingMap},
+	}}
+
+	settings := NewBasicSettings()
+	settings.Set("NAME", "blog")
+	// Values from the environment are strings
+	settings.Set("WORKERS", "4")
+	settings.Set("VERBOSE", "yes")
+	settings.Set("HOSTS", []string{"example.com"})
+	settings.Set("CACHES", map[string]interface{}{"default": "memory"})
+	if keys := validationErrors(t, settings, schema); len(keys) != 0 {
+		t.Errorf("Expected valid settings, got errors for: %v", keys)
+	}
+
+	settings.Set("NAME", 42)
+	settings.Set("WORKERS", "four")
+	settings.Set("VERBOSE", "maybe")
+	settings.Set("HOSTS", "example.com")
+	settings.Set("CACHES", []string{"memory"})
+	expected := []string{"CACHES", "HOSTS", "NAME", "VERBOSE", "WORKERS"}
+	if keys := validationErrors(t, settings, schema); strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected errors for %v, got: %v", expected, keys)
+	}
+
+	err := settings.Validate(schema)
+	if !strings.HasPrefix(err.Error(), "5 settings are invalid:") || !strings.Contains(err.Error(), "WORKERS must be of type int, got string") {
+		t.Errorf("Expected every problem in the message, got: %v", err)
+	}
+}
+
+func TestValidateChoices(t *testing.T) {
+	settings := NewBasicSettings()
+	settings.Set("SECRET_KEY", "s3cret")
+	settings.Set("DATABASE_DRIVER", "postgresql")
+	if err := settings.Validate(DefaultSettingsSchema()); err != nil {
+		t.Errorf("Expected postgresql to be allowed, got: %v", err)
+	}
+
+	settings.Set("DATABASE_DRIVER", "oracle")
+	err := settings.Validate(DefaultSettingsSchema())
+	if err == nil || !strings.Contains(err.Error(), `DATABASE_DRIVER must be one of`) || !strings.Contains(err.Error(), `got "oracle"`) {
+		t.Errorf("Expected oracle to be rejected, got: %v", err)
+	}
+}
+
+func TestValidateWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	settings := NewBasicSettings()
+	settings.Set("SECRET_KEY", "s3cret")
+	settings.Set("DEBUG", true)
+	if err := settings.Validate(DefaultSettingsSchema()); err != nil {
+		t.Fatalf("Expected valid settings, got: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no warning outside production, got: %q", buf.String())
+	}
+
+	settings.Set("ENVIRONMENT", "production")
+	if err := settings.Validate(DefaultSettingsSchema()); err != nil {
+		t.Fatalf("Expected a warning rather than an error, got: %v", err)
+	}
+	if !strings.Contains(buf.String(), "DEBUG is enabled in production") {
+		t.Errorf("Expected a DEBUG warning, got: %q", buf.String())
+	}
+}
+
+func TestStarlarkSettingsValidate(t *testing.T) {
+	settingsFile := filepath.Join(t.TempDir(), "settings.star")
+	content := `
+SECRET_KEY = "s3cret"
+PORT = "http"
+DATABASES = {
+    "default": {"ENGINE": "oracle", "PORT": 5432},
+}
+`
+	if err := os.WriteFile(settingsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write settings file: %v", err)
+	}
+
+	settings := NewStarlarkSettings()
+	if err := settings.LoadFromFile(settingsFile); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	schema := DefaultSettingsSchema()
+	schema.Rules["DATABASES.default.ENGINE"] = SettingRule{Required: true, Choices: databaseDrivers}
+	schema.Rules["DATABASES.default.PORT"] = SettingRule{Type: SettingInt}
+	expected := []string{"DATABASES.default.ENGINE", "PORT"}
+	if keys := validationErrors(t, settings, schema); strings.Join(keys, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected errors for %v, got: %v", expected, keys)
+	}
+}
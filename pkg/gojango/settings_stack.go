@@ -0,0 +1,206 @@
+package gojango
+
+import (
+	"fmt"
+
+	"github.com/epuerta9/gojango/pkg/gojango/render"
+)
+
+// Settings layers, from lowest to highest precedence
+const (
+	LayerDefaults = "defaults"
+	LayerFile     = "file"
+	LayerEnv      = "env"
+	LayerExplicit = "explicit"
+)
+
+// settingsLayers lists the layers in the order they are merged
+var settingsLayers = []string{LayerDefaults, LayerFile, LayerEnv, LayerExplicit}
+
+// DefaultSettings returns the framework defaults, the lowest settings layer
+func DefaultSettings() map[string]interface{} {
+	return map[string]interface{}{
+		"USE_I18N":        true,
+		"LANGUAGE_CODE":   "en",
+		"LOCALE_DIR":      "locale",
+		"DEFAULT_CHARSET": render.DefaultCharset,
+		"CSRF_ENABLED":    false,
+	}
+}
+
+// SettingsStack merges settings from several sources. Framework defaults are
+// overridden by the settings file, which is overridden by environment
+// variables, which are overridden by explicit Set calls, regardless of the
+// order the layers are loaded in. Layers override whole top-level settings;
+// nested maps such as DATABASES are not merged.
+//
+//	settings := gojango.NewSettingsStack()
+//	if err := settings.LoadFile("config/settings.star"); err != nil {
+//		return err
+//	}
+//	settings.LoadEnv()
+//	app.LoadSettings(settings)
+type SettingsStack struct {
+	layers map[string]map[string]interface{}
+	merged *BasicSettings
+}
+
+// NewSettingsStack creates a stack holding the framework defaults
+func NewSettingsStack() *SettingsStack {
+	s := &SettingsStack{
+		layers: map[string]map[string]interface{}{
+			LayerDefaults: DefaultSettings(),
+		},
+	}
+	s.merge()
+	return s
+}
+
+// LoadFile loads a Starlark settings file as the file layer
+func (s *SettingsStack) LoadFile(filename string) error {
+	fileSettings := NewStarlarkSettings()
+	if err := fileSettings.LoadFromFile(filename); err != nil {
+		return err
+	}
+	s.replaceLayer(LayerFile, fileSettings.GetAll())
+	return nil
+}
+
+// LoadEnv loads environment variables as the env layer, as
+// BasicSettings.LoadFromEnv reads them
+func (s *SettingsStack) LoadEnv() {
+	envSettings := NewBasicSettings()
+	envSettings.LoadFromEnv()
+	s.replaceLayer(LayerEnv, envSettings.GetAll())
+}
+
+// SetLayer replaces the settings in a layer
+func (s *SettingsStack) SetLayer(layer string, data map[string]interface{}) error {
+	if !isSettingsLayer(layer) {
+		return fmt.Errorf("unknown settings layer '%s'", layer)
+	}
+	s.replaceLayer(layer, data)
+	return nil
+}
+
+// replaceLayer replaces a known layer's settings with a copy of data
+func (s *SettingsStack) replaceLayer(layer string, data map[string]interface{}) {
+	values := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		values[key] = value
+	}
+	s.layers[layer] = values
+	s.merge()
+}
+
+// SetDefault sets a default, overridden by every other layer
+func (s *SettingsStack) SetDefault(key string, value interface{}) {
+	s.setIn(LayerDefaults, key, value)
+}
+
+// Set sets a setting explicitly, overriding every other layer
+func (s *SettingsStack) Set(key string, value interface{}) {
+	s.setIn(LayerExplicit, key, value)
+}
+
+// Source returns the layer a setting's value comes from, or "" if it is
+// not set
+func (s *SettingsStack) Source(key string) string {
+	for i := len(settingsLayers) - 1; i >= 0; i-- {
+		if _, exists := s.layers[settingsLayers[i]][key]; exists {
+			return settingsLayers[i]
+		}
+	}
+	return ""
+}
+
+// setIn sets a single setting in a layer
+func (s *SettingsStack) setIn(layer, key string, value interface{}) {
+	if s.layers[layer] == nil {
+		s.layers[layer] = make(map[string]interface{})
+	}
+	s.layers[layer][key] = value
+	s.merged.Set(key, s.lookup(key))
+}
+
+// lookup returns a setting's value from the highest layer that sets it
+func (s *SettingsStack) lookup(key string) interface{} {
+	return s.layers[s.Source(key)][key]
+}
+
+// merge rebuilds the merged view of all layers
+func (s *SettingsStack) merge() {
+	merged := NewBasicSettings()
+	for _, layer := range settingsLayers {
+		for key, value := range s.layers[layer] {
+			merged.Set(key, value)
+		}
+	}
+	s.merged = merged
+}
+
+// isSettingsLayer reports whether name is a known layer
+func isSettingsLayer(name string) bool {
+	for _, layer := range settingsLayers {
+		if layer == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Get retrieves a setting value with optional default
+func (s *SettingsStack) Get(key string, defaultValue ...interface{}) interface{} {
+	return s.merged.Get(key, defaultValue...)
+}
+
+// GetString retrieves a string setting with optional default
+func (s *SettingsStack) GetString(key string, defaultValue ...string) string {
+	return s.merged.GetString(key, defaultValue...)
+}
+
+// GetInt retrieves an integer setting with optional default
+func (s *SettingsStack) GetInt(key string, defaultValue ...int) int {
+	return s.merged.GetInt(key, defaultValue...)
+}
+
+// GetBool retrieves a boolean setting with optional default
+func (s *SettingsStack) GetBool(key string, defaultValue ...bool) bool {
+	return s.merged.GetBool(key, defaultValue...)
+}
+
+// GetPath retrieves a nested setting by dotted path, e.g.
+// "DATABASES.default.host", with optional default
+func (s *SettingsStack) GetPath(path string, defaultValue ...interface{}) interface{} {
+	return s.merged.GetPath(path, defaultValue...)
+}
+
+// GetPathString retrieves a nested string setting by dotted path
+func (s *SettingsStack) GetPathString(path string, defaultValue ...string) string {
+	return s.merged.GetPathString(path, defaultValue...)
+}
+
+// GetPathInt retrieves a nested integer setting by dotted path
+func (s *SettingsStack) GetPathInt(path string, defaultValue ...int) int {
+	return s.merged.GetPathInt(path, defaultValue...)
+}
+
+// GetPathBool retrieves a nested boolean setting by dotted path
+func (s *SettingsStack) GetPathBool(path string, defaultValue ...bool) bool {
+	return s.merged.GetPathBool(path, defaultValue...)
+}
+
+// Validate checks the merged settings against a schema
+func (s *SettingsStack) Validate(schema SettingsSchema) error {
+	return schema.Validate(s)
+}
+
+// Has checks if a setting exists in any layer
+func (s *SettingsStack) Has(key string) bool {
+	return s.merged.Has(key)
+}
+
+// GetAll returns the merged settings
+func (s *SettingsStack) GetAll() map[string]interface{} {
+	return s.merged.GetAll()
+}
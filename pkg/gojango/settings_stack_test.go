@@ -0,0 +1,110 @@
+package gojango
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSettingsFile(t *testing.T, content string) string {
+	t.Helper()
+	settingsFile := filepath.Join(t.TempDir(), "settings.star")
+	if err := os.WriteFile(settingsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write settings file: %v", err)
+	}
+	return settingsFile
+}
+
+func TestSettingsStackPrecedence(t *testing.T) {
+	settingsFile := writeSettingsFile(t, `
+LANGUAGE_CODE = "fr"
+SECRET_KEY = "from-file"
+DATABASE_URL = "sqlite://file.db"
+PORT = 8000
+`)
+	t.Setenv("SECRET_KEY", "from-env")
+	t.Setenv("DATABASE_URL", "postgres://env")
+
+	settings := NewSettingsStack()
+	// Explicit settings win even when set before the other layers load
+	settings.Set("DATABASE_URL", "postgres://explicit")
+	settings.LoadEnv()
+	if err := settings.LoadFile(settingsFile); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	tests := []struct {
+		key      string
+		expected string
+		source   string
+	}{
+		{"LOCALE_DIR", "locale", LayerDefaults},
+		{"LANGUAGE_CODE", "fr", LayerFile},
+		{"SECRET_KEY", "from-env", LayerEnv},
+		{"DATABASE_URL", "postgres://explicit", LayerExplicit},
+	}
+	for _, tt := range tests {
+		if got := settings.GetString(tt.key); got != tt.expected {
+			t.Errorf("Expected %s to be %q, got: %q", tt.key, tt.expected, got)
+		}
+		if source := settings.Source(tt.key); source != tt.source {
+			t.Errorf("Expected %s from the %s layer, got: %q", tt.key, tt.source, source)
+		}
+	}
+
+	if got := settings.GetInt("PORT"); got != 8000 {
+		t.Errorf("Expected PORT from the file, got: %d", got)
+	}
+	if got := settings.GetString("PORT"); got != "8000" {
+		t.Errorf("Expected PORT as a string, got: %q", got)
+	}
+	if settings.Has("MISSING") || settings.Source("MISSING") != "" {
+		t.Error("Expected MISSING not to be set")
+	}
+}
+
+func TestSettingsStackLayers(t *testing.T) {
+	settings := NewSettingsStack()
+	settings.SetDefault("PAGE_SIZE", 20)
+	if err := settings.SetLayer(LayerFile, map[string]interface{}{"PAGE_SIZE": 50}); err != nil {
+		t.Fatalf("Failed to set layer: %v", err)
+	}
+	if got := settings.GetInt("PAGE_SIZE"); got != 50 {
+		t.Errorf("Expected the file to override the default, got: %d", got)
+	}
+
+	// Replacing a layer drops its old settings
+	if err := settings.SetLayer(LayerFile, nil); err != nil {
+		t.Fatalf("Failed to set layer: %v", err)
+	}
+	if got := settings.GetInt("PAGE_SIZE"); got != 20 {
+		t.Errorf("Expected the default once the file layer is empty, got: %d", got)
+	}
+
+	if err := settings.SetLayer("cli", nil); err == nil {
+		t.Error("Expected an error for an unknown layer")
+	}
+}
+
+func TestLoadSettingsFromFileLayersEnv(t *testing.T) {
+	settingsFile := writeSettingsFile(t, `
+SECRET_KEY = "from-file"
+DATABASES = {"default": {"ENGINE": "sqlite3"}}
+`)
+	t.Setenv("SECRET_KEY", "from-env")
+
+	app := New()
+	if err := app.LoadSettingsFromFile(settingsFile); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if got := app.settings.GetString("SECRET_KEY"); got != "from-env" {
+		t.Errorf("Expected the environment to override the file, got: %q", got)
+	}
+	if got := app.settings.GetPathString("DATABASES.default.ENGINE"); got != "sqlite3" {
+		t.Errorf("Expected nested settings from the file, got: %q", got)
+	}
+	if got := app.settings.GetString("LANGUAGE_CODE"); got != "en" {
+		t.Errorf("Expected framework defaults, got: %q", got)
+	}
+}
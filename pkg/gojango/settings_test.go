@@ -2,6 +2,7 @@ package gojango
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -60,6 +61,18 @@ func TestBasicSettingsGetString(t *testing.T) {
 	if value != "" {
 		t.Errorf("Expected empty string, got '%s'", value)
 	}
+
+	// Surrounding whitespace, e.g. from env files, is trimmed
+	settings.Set("PADDED_VAL", "  test\n")
+	if value := settings.GetString("PADDED_VAL"); value != "test" {
+		t.Errorf("Expected 'test', got '%s'", value)
+	}
+
+	// Other values are formatted
+	settings.Set("INT_VAL", 42)
+	if value := settings.GetString("INT_VAL"); value != "42" {
+		t.Errorf("Expected '42', got '%s'", value)
+	}
 }
 
 func TestBasicSettingsGetInt(t *testing.T) {
@@ -192,7 +205,7 @@ func TestBasicSettingsLoadFromEnv(t *testing.T) {
 	os.Setenv("DATABASE_URL", "postgres://localhost/test")
 	os.Setenv("PORT", "9000")
 	os.Setenv("GOJANGO_CUSTOM", "custom-value")
-	
+
 	// Clean up after test
 	defer func() {
 		os.Unsetenv("DEBUG")
@@ -233,7 +246,7 @@ func TestBasicSettingsLoadFromEnv(t *testing.T) {
 func TestBasicSettingsInterface(t *testing.T) {
 	// Test that BasicSettings implements Settings interface
 	var settings Settings = NewBasicSettings()
-	
+
 	// Test interface methods
 	settings.Get("test")
 	settings.GetString("test")
@@ -241,4 +254,91 @@ func TestBasicSettingsInterface(t *testing.T) {
 	settings.GetBool("test")
 
 	// If this compiles, the interface is implemented correctly
-}
\ No newline at end of file
+}
+
+func TestBasicSettingsGetPath(t *testing.T) {
+	settings := NewBasicSettings()
+	settings.Set("DATABASES", map[string]interface{}{
+		"default": map[string]interface{}{
+			"host":  "db.internal",
+			"port":  5432,
+			"debug": "true",
+			"options": map[string]interface{}{
+				"sslmode": "require",
+			},
+		},
+	})
+	settings.Set("CACHES", map[string]string{"default": "redis://cache"})
+	settings.Set("SITE_NAME", "example")
+
+	if got := settings.GetPath("DATABASES.default.host"); got != "db.internal" {
+		t.Errorf("Expected 'db.internal', got %v", got)
+	}
+	if got := settings.GetPathString("DATABASES.default.options.sslmode"); got != "require" {
+		t.Errorf("Expected 'require', got %s", got)
+	}
+	if got := settings.GetPathInt("DATABASES.default.port"); got != 5432 {
+		t.Errorf("Expected 5432, got %d", got)
+	}
+	if got := settings.GetPathBool("DATABASES.default.debug"); !got {
+		t.Error("Expected string 'true' to convert to true")
+	}
+	if got := settings.GetPathString("CACHES.default"); got != "redis://cache" {
+		t.Errorf("Expected 'redis://cache', got %s", got)
+	}
+
+	// Intermediate maps are returned as-is
+	if _, ok := settings.GetPath("DATABASES.default").(map[string]interface{}); !ok {
+		t.Error("Expected a nested map for DATABASES.default")
+	}
+
+	// Single segments behave like Get
+	if got := settings.GetPathString("SITE_NAME"); got != "example" {
+		t.Errorf("Expected 'example', got %s", got)
+	}
+
+	// Missing paths fall back to defaults
+	if got := settings.GetPath("DATABASES.replica.host", "fallback"); got != "fallback" {
+		t.Errorf("Expected default for a missing path, got %v", got)
+	}
+	if got := settings.GetPathString("DATABASES.default.host.extra", "fallback"); got != "fallback" {
+		t.Errorf("Expected default when traversing past a leaf, got %s", got)
+	}
+	if got := settings.GetPathInt("DATABASES.default.missing", 3306); got != 3306 {
+		t.Errorf("Expected default 3306, got %d", got)
+	}
+	if got := settings.GetPath("NOPE.default"); got != nil {
+		t.Errorf("Expected nil for a missing path without default, got %v", got)
+	}
+}
+
+func TestStarlarkSettingsGetPath(t *testing.T) {
+	settingsFile := filepath.Join(t.TempDir(), "settings.star")
+	content := `
+DATABASES = {
+    "default": {
+        "engine": "postgres",
+        "host": "localhost",
+        "port": 5432,
+    },
+}
+`
+	if err := os.WriteFile(settingsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write settings file: %v", err)
+	}
+
+	settings := NewStarlarkSettings()
+	if err := settings.LoadFromFile(settingsFile); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if got := settings.GetPathString("DATABASES.default.host"); got != "localhost" {
+		t.Errorf("Expected 'localhost', got %s", got)
+	}
+	if got := settings.GetPathInt("DATABASES.default.port"); got != 5432 {
+		t.Errorf("Expected 5432, got %d", got)
+	}
+	if got := settings.GetPathString("DATABASES.default.user", "postgres"); got != "postgres" {
+		t.Errorf("Expected default 'postgres', got %s", got)
+	}
+}
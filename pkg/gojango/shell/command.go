@@ -0,0 +1,80 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"github.com/spf13/cobra"
+	"go.starlark.net/starlark"
+)
+
+// NewCommand returns the "shell" command for a project's manage.go
+func NewCommand() *cobra.Command {
+	var settingsFile string
+	var command string
+	var alias string
+
+	cmd := &cobra.Command{
+		Use:   "shell",
+		Short: "Interactive shell with the project context",
+		Long: `Start an interactive Starlark shell with the project's settings, apps
+and database, like Django's "manage.py shell".
+
+The shell defines settings, db, apps() and models(); type help() in the
+shell for details. Use -c to evaluate one expression and exit:
+
+  go run manage.go shell -c 'settings.DEBUG'
+  go run manage.go shell -c 'db.query("SELECT count(*) AS n FROM users")'`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			settings := gojango.NewSettingsStack()
+			if err := settings.LoadFile(settingsFile); err != nil {
+				return fmt.Errorf("failed to load settings: %w", err)
+			}
+			settings.LoadEnv()
+
+			conn, err := Connect(cmd.Context(), settings, alias)
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  No database connection, db is None: %v\n", err)
+			} else {
+				defer conn.Close()
+			}
+
+			shell := New(settings, gojango.GetRegistry(), conn, cmd.OutOrStdout())
+			if command == "" {
+				return shell.Run(cmd.InOrStdin())
+			}
+
+			value, err := shell.Eval(command)
+			if err != nil {
+				return err
+			}
+			if value != starlark.None {
+				fmt.Fprintln(cmd.OutOrStdout(), value)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&command, "command", "c", "", "Evaluate the given code and exit")
+	cmd.Flags().StringVar(&settingsFile, "settings", filepath.Join("config", "settings.star"), "Settings file to load")
+	cmd.Flags().StringVar(&alias, "database", "default", "DATABASES alias to connect to")
+
+	return cmd
+}
+
+// Connect opens the database configured for alias in DATABASES, trying
+// once so the shell starts promptly when the database is down
+func Connect(ctx context.Context, settings *gojango.SettingsStack, alias string) (*db.Connection, error) {
+	config, err := settings.GetDatabaseConfig(alias)
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return db.OpenWithRetry(ctx, config, 1, 0)
+}
@@ -0,0 +1,174 @@
+// Package shell provides an interactive Starlark shell with a project's
+// settings, apps and database, like Django's "manage.py shell".
+package shell
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// fileOptions allows top-level loops and reassignment, as an interactive
+// shell should
+var fileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+	Recursion:       true,
+}
+
+// Shell evaluates Starlark with the project context bound to these globals:
+//
+//	settings  the project settings; settings.DEBUG, settings.get("KEY", default)
+//	db        the database connection; db.query(sql, *args), db.exec(sql, *args)
+//	apps()    the registered app names
+//	models()  the registered models, optionally for one app
+//
+// db is None when no database connection is available.
+type Shell struct {
+	globals starlark.StringDict
+	thread  *starlark.Thread
+	out     io.Writer
+}
+
+// New creates a shell over the given settings, registry and database
+// connection. conn may be nil.
+func New(settings gojango.Settings, registry *gojango.Registry, conn *db.Connection, out io.Writer) *Shell {
+	s := &Shell{
+		out: out,
+	}
+	s.thread = &starlark.Thread{
+		Name:  "shell",
+		Print: func(_ *starlark.Thread, msg string) { fmt.Fprintln(s.out, msg) },
+	}
+
+	var dbValue starlark.Value = starlark.None
+	if conn != nil {
+		dbValue = &database{conn: conn}
+	}
+
+	s.globals = starlark.StringDict{
+		"settings": &settingsValue{settings: settings},
+		"db":       dbValue,
+		"apps":     starlark.NewBuiltin("apps", appsBuiltin(registry)),
+		"models":   starlark.NewBuiltin("models", modelsBuiltin(registry)),
+	}
+	return s
+}
+
+// Globals returns the shell's global variables, including those defined
+// by evaluated code
+func (s *Shell) Globals() starlark.StringDict {
+	return s.globals
+}
+
+// Eval evaluates source, a single expression or a block of statements, and
+// returns the expression's value or None for statements. Variables that
+// statements assign stay defined for later evaluations.
+func (s *Shell) Eval(source string) (starlark.Value, error) {
+	file, err := fileOptions.Parse("<shell>", source, 0)
+	if err != nil {
+		return nil, err
+	}
+	return s.exec(file)
+}
+
+// Run reads statements from in, printing the value of each expression, until
+// in is exhausted or exit() is called. Errors are printed and the shell
+// carries on.
+func (s *Shell) Run(in io.Reader) error {
+	reader := bufio.NewReader(in)
+	fmt.Fprintln(s.out, `Gojango shell. Type help() for the available variables, exit() to quit.`)
+
+	exited := false
+	s.globals["exit"] = starlark.NewBuiltin("exit", func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+		exited = true
+		return starlark.None, nil
+	})
+	s.globals["help"] = starlark.NewBuiltin("help", func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+		fmt.Fprint(s.out, helpText)
+		return starlark.None, nil
+	})
+	defer delete(s.globals, "exit")
+	defer delete(s.globals, "help")
+
+	for !exited {
+		prompt := ">>> "
+		eof := false
+		readline := func() ([]byte, error) {
+			fmt.Fprint(s.out, prompt)
+			prompt = "... "
+			line, err := reader.ReadString('\n')
+			if err == io.EOF {
+				eof = true
+				if line == "" {
+					return nil, io.EOF
+				}
+				return []byte(line + "\n"), nil
+			}
+			return []byte(line), err
+		}
+
+		file, err := fileOptions.ParseCompoundStmt("<stdin>", readline)
+		if err != nil {
+			if eof {
+				fmt.Fprintln(s.out)
+				return nil
+			}
+			fmt.Fprintln(s.out, err)
+			continue
+		}
+
+		value, err := s.exec(file)
+		if err != nil {
+			printError(s.out, err)
+		} else if value != starlark.None {
+			fmt.Fprintln(s.out, value)
+		}
+		if eof {
+			fmt.Fprintln(s.out)
+			return nil
+		}
+	}
+	return nil
+}
+
+// exec evaluates a parsed chunk; a lone expression returns its value
+func (s *Shell) exec(file *syntax.File) (starlark.Value, error) {
+	if len(file.Stmts) == 1 {
+		if stmt, ok := file.Stmts[0].(*syntax.ExprStmt); ok {
+			return starlark.EvalExprOptions(file.Options, s.thread, stmt.X, s.globals)
+		}
+	}
+	if err := starlark.ExecREPLChunk(file, s.thread, s.globals); err != nil {
+		return nil, err
+	}
+	return starlark.None, nil
+}
+
+// printError prints an error, with its Starlark backtrace if it has one
+func printError(out io.Writer, err error) {
+	var evalErr *starlark.EvalError
+	if errors.As(err, &evalErr) {
+		fmt.Fprintln(out, evalErr.Backtrace())
+		return
+	}
+	fmt.Fprintln(out, err)
+}
+
+const helpText = `Available variables:
+  settings              project settings: settings.DEBUG, settings.get("KEY", default)
+  db                    database: db.query(sql, *args) -> rows, db.exec(sql, *args) -> rows affected
+  apps()                registered app names
+  models(app=None)      registered models, optionally for one app
+  exit()                leave the shell
+
+Statements are Starlark, a dialect of Python.
+`
@@ -0,0 +1,165 @@
+package shell
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	_ "github.com/mattn/go-sqlite3"
+	"go.starlark.net/starlark"
+)
+
+type shopApp struct {
+	gojango.BaseApp
+}
+
+type Product struct{}
+
+func (a *shopApp) Config() gojango.AppConfig {
+	return gojango.AppConfig{Name: "shop"}
+}
+
+func (a *shopApp) Models() []interface{} {
+	return []interface{}{&Product{}}
+}
+
+func newTestRegistry() *gojango.Registry {
+	registry := gojango.NewRegistry()
+	registry.RegisterApp(&shopApp{})
+	return registry
+}
+
+func loadTestSettings(t *testing.T) *gojango.SettingsStack {
+	t.Helper()
+	settingsFile := filepath.Join(t.TempDir(), "settings.star")
+	content := `
+DEBUG = True
+SECRET_KEY = "shell-test"
+INSTALLED_APPS = ["shop"]
+DATABASES = {"default": {"engine": "postgres", "host": "db.internal", "port": 5433}}
+`
+	if err := os.WriteFile(settingsFile, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write settings file: %v", err)
+	}
+
+	settings := gojango.NewSettingsStack()
+	if err := settings.LoadFile(settingsFile); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	return settings
+}
+
+func TestEvalSettings(t *testing.T) {
+	shell := New(loadTestSettings(t), newTestRegistry(), nil, &bytes.Buffer{})
+
+	tests := []struct {
+		expr     string
+		expected string
+	}{
+		{"settings.DEBUG", "True"},
+		{"settings.INSTALLED_APPS", `["shop"]`},
+		{"settings.DATABASES['default']['port'] + 1", "5434"},
+		{"settings.get('DATABASES.default.host')", `"db.internal"`},
+		{"settings.get('MISSING', 'fallback')", `"fallback"`},
+		{"db", "None"},
+		{"apps()", `["shop"]`},
+		{"[m['name'] for m in models('shop')]", `["shop.*shell.Product"]`},
+		{"len(models('blog'))", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			value, err := shell.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if value.String() != tt.expected {
+				t.Errorf("Expected %s, got: %s", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestEvalStatements(t *testing.T) {
+	shell := New(loadTestSettings(t), newTestRegistry(), nil, &bytes.Buffer{})
+
+	value, err := shell.Eval("debug = settings.DEBUG\nname = 'on' if debug else 'off'")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != starlark.None {
+		t.Errorf("Expected None for statements, got: %s", value)
+	}
+
+	// Variables persist between evaluations
+	value, err = shell.Eval("name")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value.String() != `"on"` {
+		t.Errorf("Expected \"on\", got: %s", value)
+	}
+
+	if _, err := shell.Eval("settings.NOPE"); err == nil || !strings.Contains(err.Error(), "NOPE") {
+		t.Errorf("Expected an error for a missing setting, got: %v", err)
+	}
+	if _, err := shell.Eval("1 +"); err == nil {
+		t.Error("Expected a syntax error")
+	}
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	shell := New(loadTestSettings(t), newTestRegistry(), nil, &out)
+
+	input := "total = 0\nfor n in [1, 2, 3]:\n    total += n\n\ntotal\nundefined\nexit()\nprint('not reached')\n"
+	if err := shell.Run(strings.NewReader(input)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, ">>> 6\n") {
+		t.Errorf("Expected the expression value, got: %q", output)
+	}
+	if !strings.Contains(output, "undefined: undefined") {
+		t.Errorf("Expected the error to be printed, got: %q", output)
+	}
+	if strings.Contains(output, "not reached") {
+		t.Errorf("Expected exit() to stop the shell, got: %q", output)
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	conn, err := db.Open(db.SQLiteConfig(filepath.Join(t.TempDir(), "shell.db")))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer conn.Close()
+
+	shell := New(loadTestSettings(t), newTestRegistry(), conn, &bytes.Buffer{})
+
+	for _, statement := range []string{
+		`db.exec("CREATE TABLE products (id INTEGER PRIMARY KEY, name TEXT)")`,
+		`db.exec("INSERT INTO products (name) VALUES (?), (?)", "mug", "pen")`,
+	} {
+		if _, err := shell.Eval(statement); err != nil {
+			t.Fatalf("Failed to run %s: %v", statement, err)
+		}
+	}
+
+	value, err := shell.Eval(`[row["name"] for row in db.query("SELECT name FROM products WHERE id > ? ORDER BY id", 0)]`)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value.String() != `["mug", "pen"]` {
+		t.Errorf("Expected product names, got: %s", value)
+	}
+
+	if _, err := shell.Eval(`db.query("SELECT ?", [1])`); err == nil {
+		t.Error("Expected an error for an unsupported parameter")
+	}
+}
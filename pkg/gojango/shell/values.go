@@ -0,0 +1,289 @@
+package shell
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/epuerta9/gojango/pkg/gojango"
+	"github.com/epuerta9/gojango/pkg/gojango/db"
+	"go.starlark.net/starlark"
+)
+
+// settingsValue exposes settings as attributes, e.g. settings.DEBUG
+type settingsValue struct {
+	settings gojango.Settings
+}
+
+func (s *settingsValue) String() string        { return "<settings>" }
+func (s *settingsValue) Type() string          { return "settings" }
+func (s *settingsValue) Freeze()               {}
+func (s *settingsValue) Truth() starlark.Bool  { return starlark.True }
+func (s *settingsValue) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable: settings") }
+
+func (s *settingsValue) Attr(name string) (starlark.Value, error) {
+	if name == "get" {
+		return starlark.NewBuiltin("settings.get", s.get), nil
+	}
+	value := s.settings.Get(name)
+	if value == nil {
+		return nil, nil
+	}
+	return toStarlark(value), nil
+}
+
+func (s *settingsValue) AttrNames() []string {
+	names := []string{"get"}
+	if all, ok := s.settings.(interface{ GetAll() map[string]interface{} }); ok {
+		for name := range all.GetAll() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// settings.get(key, default=None), which also accepts dotted paths such as
+// "DATABASES.default.host"
+func (s *settingsValue) get(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	var defaultVal starlark.Value = starlark.None
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "key", &key, "default?", &defaultVal); err != nil {
+		return nil, err
+	}
+
+	value := s.settings.GetPath(key)
+	if value == nil {
+		return defaultVal, nil
+	}
+	return toStarlark(value), nil
+}
+
+// database exposes a connection to run SQL from the shell
+type database struct {
+	conn *db.Connection
+}
+
+func (d *database) String() string        { return fmt.Sprintf("<db %s>", d.conn.Driver()) }
+func (d *database) Type() string          { return "db" }
+func (d *database) Freeze()               {}
+func (d *database) Truth() starlark.Bool  { return starlark.True }
+func (d *database) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable: db") }
+
+func (d *database) Attr(name string) (starlark.Value, error) {
+	switch name {
+	case "query":
+		return starlark.NewBuiltin("db.query", d.query), nil
+	case "exec":
+		return starlark.NewBuiltin("db.exec", d.exec), nil
+	case "driver":
+		return starlark.String(d.conn.Driver()), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (d *database) AttrNames() []string {
+	return []string{"driver", "exec", "query"}
+}
+
+// db.query(sql, *args) returns the rows as a list of dicts
+func (d *database) query(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	query, queryArgs, err := sqlArgs(fn, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := d.conn.DB().Query(query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []starlark.Value
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		pointers := make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+
+		row := starlark.NewDict(len(columns))
+		for i, column := range columns {
+			row.SetKey(starlark.String(column), toStarlark(values[i]))
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return starlark.NewList(result), nil
+}
+
+// db.exec(sql, *args) returns the number of rows affected
+func (d *database) exec(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	query, queryArgs, err := sqlArgs(fn, args, kwargs)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := d.conn.DB().Exec(query, queryArgs...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	return starlark.MakeInt64(affected), nil
+}
+
+// sqlArgs unpacks the SQL statement and its parameters
+func sqlArgs(fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (string, []interface{}, error) {
+	if len(kwargs) > 0 {
+		return "", nil, fmt.Errorf("%s: unexpected keyword arguments", fn.Name())
+	}
+	if len(args) == 0 {
+		return "", nil, fmt.Errorf("%s: missing sql argument", fn.Name())
+	}
+	query, ok := starlark.AsString(args[0])
+	if !ok {
+		return "", nil, fmt.Errorf("%s: sql must be a string, got %s", fn.Name(), args[0].Type())
+	}
+
+	params := make([]interface{}, len(args)-1)
+	for i, arg := range args[1:] {
+		param, err := fromStarlark(arg)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: argument %d: %w", fn.Name(), i+1, err)
+		}
+		params[i] = param
+	}
+	return query, params, nil
+}
+
+// appsBuiltin implements apps(), the registered app names
+func appsBuiltin(registry *gojango.Registry) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs); err != nil {
+			return nil, err
+		}
+		return toStarlark(registry.GetAppNames()), nil
+	}
+}
+
+// modelsBuiltin implements models(app=None), the registered models as dicts
+// with their app, name and table
+func modelsBuiltin(registry *gojango.Registry) func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error) {
+	return func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var app string
+		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "app?", &app); err != nil {
+			return nil, err
+		}
+
+		var models []starlark.Value
+		for _, meta := range registry.GetModels() {
+			if app != "" && meta.App != app {
+				continue
+			}
+			models = append(models, toStarlark(map[string]interface{}{
+				"app":   meta.App,
+				"name":  meta.FullName,
+				"model": meta.Name,
+				"table": meta.TableName,
+			}))
+		}
+		return starlark.NewList(models), nil
+	}
+}
+
+// toStarlark converts a Go value from settings or the database to Starlark.
+// Values of other types are shown as strings.
+func toStarlark(value interface{}) starlark.Value {
+	switch v := value.(type) {
+	case nil:
+		return starlark.None
+	case starlark.Value:
+		return v
+	case string:
+		return starlark.String(v)
+	case []byte:
+		return starlark.String(v)
+	case bool:
+		return starlark.Bool(v)
+	case int:
+		return starlark.MakeInt(v)
+	case int32:
+		return starlark.MakeInt64(int64(v))
+	case int64:
+		return starlark.MakeInt64(v)
+	case uint:
+		return starlark.MakeUint(v)
+	case uint64:
+		return starlark.MakeUint64(v)
+	case float32:
+		return starlark.Float(v)
+	case float64:
+		return starlark.Float(v)
+	case time.Time:
+		return starlark.String(v.Format(time.RFC3339))
+	case []string:
+		items := make([]starlark.Value, len(v))
+		for i, item := range v {
+			items[i] = starlark.String(item)
+		}
+		return starlark.NewList(items)
+	case []interface{}:
+		items := make([]starlark.Value, len(v))
+		for i, item := range v {
+			items[i] = toStarlark(item)
+		}
+		return starlark.NewList(items)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		dict := starlark.NewDict(len(v))
+		for _, key := range keys {
+			dict.SetKey(starlark.String(key), toStarlark(v[key]))
+		}
+		return dict
+	default:
+		return starlark.String(fmt.Sprintf("%v", v))
+	}
+}
+
+// fromStarlark converts a Starlark value to a SQL parameter
+func fromStarlark(value starlark.Value) (interface{}, error) {
+	switch v := value.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.String:
+		return string(v), nil
+	case starlark.Bytes:
+		return []byte(v), nil
+	case starlark.Bool:
+		return bool(v), nil
+	case starlark.Int:
+		i, ok := v.Int64()
+		if !ok {
+			return nil, fmt.Errorf("integer too large")
+		}
+		return i, nil
+	case starlark.Float:
+		return float64(v), nil
+	default:
+		return nil, fmt.Errorf("unsupported parameter type %s", value.Type())
+	}
+}
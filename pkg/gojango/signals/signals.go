@@ -0,0 +1,114 @@
+// Package signals lets decoupled code be notified when something happens
+// elsewhere in the application, in the style of Django signals.
+//
+// Receivers are connected to a Signal and called synchronously, in the order
+// they were connected, each time the signal is sent:
+//
+//	id := signals.PostSave.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+//		log.Printf("saved %v", kwargs["instance"])
+//		return nil
+//	})
+//	defer signals.PostSave.Disconnect(id)
+package signals
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Receiver handles a signal. sender identifies what sent it, usually the
+// model, and kwargs carries signal-specific values. Returning an error stops
+// dispatch and is returned by Send.
+type Receiver func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error
+
+// ConnectionID identifies a connected receiver so it can be disconnected
+type ConnectionID uint64
+
+// Signal is a named event that receivers connect to
+type Signal struct {
+	name      string
+	mu        sync.RWMutex
+	nextID    ConnectionID
+	receivers []connection
+}
+
+type connection struct {
+	id       ConnectionID
+	receiver Receiver
+}
+
+// Predefined signals sent by the admin around model mutations.
+//
+// PreSave and PostSave receive "created" (true for new objects) and "data",
+// the submitted field values, which PreSave receivers may modify. Updates
+// also receive "id", and PostSave receives the saved "instance".
+// PreDelete and PostDelete receive the "id" of the deleted object.
+var (
+	PreSave    = New("pre_save")
+	PostSave   = New("post_save")
+	PreDelete  = New("pre_delete")
+	PostDelete = New("post_delete")
+)
+
+// New creates a signal
+func New(name string) *Signal {
+	return &Signal{name: name}
+}
+
+// Name returns the signal's name
+func (s *Signal) Name() string {
+	return s.name
+}
+
+// Connect adds a receiver, called after those already connected
+func (s *Signal) Connect(receiver Receiver) ConnectionID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	s.receivers = append(s.receivers, connection{id: s.nextID, receiver: receiver})
+	return s.nextID
+}
+
+// Disconnect removes a receiver. It returns false if the receiver was not connected.
+func (s *Signal) Disconnect(id ConnectionID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, conn := range s.receivers {
+		if conn.id == id {
+			s.receivers = append(s.receivers[:i:i], s.receivers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// HasReceivers reports whether any receivers are connected
+func (s *Signal) HasReceivers() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.receivers) > 0
+}
+
+// Send calls each receiver in connection order. Dispatch stops at the first
+// receiver error, which is returned wrapped with the signal's name.
+// Receivers connected or disconnected during dispatch take effect from the
+// next Send.
+func (s *Signal) Send(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+	s.mu.RLock()
+	receivers := s.receivers
+	s.mu.RUnlock()
+
+	if kwargs == nil {
+		kwargs = make(map[string]interface{})
+	}
+
+	for _, conn := range receivers {
+		if err := conn.receiver(ctx, sender, kwargs); err != nil {
+			return fmt.Errorf("%s receiver failed: %w", s.name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package signals
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func recordingReceiver(calls *[]string, name string) Receiver {
+	return func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+}
+
+func TestSendCallsReceiversInOrder(t *testing.T) {
+	signal := New("test")
+	var calls []string
+	signal.Connect(recordingReceiver(&calls, "first"))
+	signal.Connect(recordingReceiver(&calls, "second"))
+	signal.Connect(recordingReceiver(&calls, "third"))
+
+	if err := signal.Send(context.Background(), nil, nil); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	expected := []string{"first", "second", "third"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected receivers called in order %v, got %v", expected, calls)
+	}
+}
+
+func TestSendPassesSenderAndKwargs(t *testing.T) {
+	signal := New("test")
+	type user struct{}
+	var gotSender interface{}
+	var gotKwargs map[string]interface{}
+	signal.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		gotSender = sender
+		gotKwargs = kwargs
+		kwargs["touched"] = true
+		return nil
+	})
+
+	sender := &user{}
+	kwargs := map[string]interface{}{"created": true}
+	if err := signal.Send(context.Background(), sender, kwargs); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if gotSender != sender {
+		t.Errorf("Expected sender %v, got %v", sender, gotSender)
+	}
+	if gotKwargs["created"] != true {
+		t.Errorf("Expected kwargs to be passed, got %v", gotKwargs)
+	}
+	if kwargs["touched"] != true {
+		t.Error("Expected receivers to share the caller's kwargs map")
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	signal := New("test")
+	var calls []string
+	signal.Connect(recordingReceiver(&calls, "first"))
+	second := signal.Connect(recordingReceiver(&calls, "second"))
+	signal.Connect(recordingReceiver(&calls, "third"))
+
+	if !signal.Disconnect(second) {
+		t.Error("Expected Disconnect to report the receiver was connected")
+	}
+	if signal.Disconnect(second) {
+		t.Error("Expected a second Disconnect to report the receiver was not connected")
+	}
+
+	signal.Send(context.Background(), nil, nil)
+
+	expected := []string{"first", "third"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected %v after disconnect, got %v", expected, calls)
+	}
+}
+
+func TestHasReceivers(t *testing.T) {
+	signal := New("test")
+	if signal.HasReceivers() {
+		t.Error("Expected a new signal to have no receivers")
+	}
+
+	id := signal.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		return nil
+	})
+	if !signal.HasReceivers() {
+		t.Error("Expected the signal to have a receiver")
+	}
+
+	signal.Disconnect(id)
+	if signal.HasReceivers() {
+		t.Error("Expected no receivers after disconnect")
+	}
+}
+
+func TestSendStopsAtFirstError(t *testing.T) {
+	signal := New("pre_save")
+	errRejected := errors.New("rejected")
+	var calls []string
+	signal.Connect(recordingReceiver(&calls, "first"))
+	signal.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		calls = append(calls, "failing")
+		return errRejected
+	})
+	signal.Connect(recordingReceiver(&calls, "after"))
+
+	err := signal.Send(context.Background(), nil, nil)
+	if !errors.Is(err, errRejected) {
+		t.Errorf("Expected the receiver error to be returned, got: %v", err)
+	}
+	if err != nil && err.Error() != "pre_save receiver failed: rejected" {
+		t.Errorf("Expected the error to name the signal, got: %v", err)
+	}
+
+	expected := []string{"first", "failing"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected dispatch to stop at the failing receiver, got %v", calls)
+	}
+}
+
+func TestDisconnectDuringSend(t *testing.T) {
+	signal := New("test")
+	var calls []string
+	var second ConnectionID
+	signal.Connect(func(ctx context.Context, sender interface{}, kwargs map[string]interface{}) error {
+		calls = append(calls, "first")
+		signal.Disconnect(second)
+		return nil
+	})
+	second = signal.Connect(recordingReceiver(&calls, "second"))
+
+	signal.Send(context.Background(), nil, nil)
+	signal.Send(context.Background(), nil, nil)
+
+	expected := []string{"first", "second", "first"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("Expected disconnect to apply from the next Send, got %v", calls)
+	}
+}
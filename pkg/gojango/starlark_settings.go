@@ -8,20 +8,25 @@ import (
 	"strings"
 
 	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
 )
 
 // StarlarkSettings provides Django-style configuration using Starlark
 type StarlarkSettings struct {
 	data   map[string]interface{}
-	thread *starlark.Thread
 	globals starlark.StringDict
+	
+	// loading is the chain of files being executed, to detect load cycles,
+	// and modules caches the files already loaded
+	loading []string
+	modules map[string]starlark.StringDict
 }
 
 // NewStarlarkSettings creates a new StarlarkSettings instance
 func NewStarlarkSettings() *StarlarkSettings {
 	s := &StarlarkSettings{
-		data:   make(map[string]interface{}),
-		thread: &starlark.Thread{Name: "settings"},
+		data:    make(map[string]interface{}),
+		modules: make(map[string]starlark.StringDict),
 	}
 	
 	// Setup built-in functions
@@ -30,63 +35,107 @@ func NewStarlarkSettings() *StarlarkSettings {
 	return s
 }
 
-// LoadFromFile loads settings from a Starlark file (like Django's settings.py)
+// LoadFromFile loads settings from a Starlark file (like Django's settings.py).
+// The file can load() other settings files, relative to itself, to share
+// settings between environments:
+//
+//	load("settings_base.star", "DATABASES", "INSTALLED_APPS")
+//
+// Symbols loaded this way are settings of the loading file too.
 func (s *StarlarkSettings) LoadFromFile(filename string) error {
-	// Read the settings file
-	content, err := os.ReadFile(filename)
+	path, err := filepath.Abs(filename)
 	if err != nil {
 		return fmt.Errorf("failed to read settings file %s: %w", filename, err)
 	}
 	
-	// Execute the Starlark code
-	globals, err := starlark.ExecFile(s.thread, filename, content, s.globals)
+	globals, err := s.execFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to execute settings file %s: %w", filename, err)
+		return err
 	}
 	
 	// Convert Starlark values to Go values and store in data
 	for name, value := range globals {
-		if !strings.HasPrefix(name, "_") { // Skip private variables
-			goValue, err := s.starlarkToGo(value)
-			if err != nil {
-				return fmt.Errorf("failed to convert setting %s: %w", name, err)
-			}
-			s.data[name] = goValue
+		if strings.HasPrefix(name, "_") { // Skip private variables
+			continue
+		}
+		// Skip the env module and helper functions
+		if _, isModule := value.(*envModule); isModule {
+			continue
+		}
+		if _, isCallable := value.(starlark.Callable); isCallable {
+			continue
 		}
+		
+		goValue, err := s.starlarkToGo(value)
+		if err != nil {
+			return fmt.Errorf("failed to convert setting %s: %w", name, err)
+		}
+		s.data[name] = goValue
 	}
 	
 	return nil
 }
 
-// setupBuiltins sets up Django-style built-in functions for Starlark
-func (s *StarlarkSettings) setupBuiltins() {
-	s.globals = starlark.StringDict{
-		"env": s.makeEnvFunction(),
-		"load": starlark.NewBuiltin("load", s.loadBuiltin),
+// execFile executes a settings file in its own thread and returns its globals
+func (s *StarlarkSettings) execFile(path string) (starlark.StringDict, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file %s: %w", path, err)
+	}
+	
+	s.loading = append(s.loading, path)
+	defer func() { s.loading = s.loading[:len(s.loading)-1] }()
+	
+	// Loaded symbols are bound as globals so they become settings, and
+	// may be reassigned so a file can override what it loads
+	thread := &starlark.Thread{Name: path, Load: s.load}
+	options := &syntax.FileOptions{LoadBindsGlobally: true, GlobalReassign: true}
+	globals, err := starlark.ExecFileOptions(options, thread, path, content, s.globals)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute settings file %s: %w", path, err)
 	}
+	return globals, nil
 }
 
-// makeEnvFunction creates the Django-style env() function
-func (s *StarlarkSettings) makeEnvFunction() *starlark.Builtin {
-	return starlark.NewBuiltin("env", func(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
-		// env.get(key, default) or env(key, default)
-		var key string
-		var defaultVal starlark.Value = starlark.None
-		
-		if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "key", &key, "default?", &defaultVal); err != nil {
-			return nil, err
-		}
-		
-		// Get environment variable
-		if val := os.Getenv(key); val != "" {
-			return starlark.String(val), nil
+// load implements load() statements. "env" is the built-in environment
+// module; any other module is a settings file, relative to the loading file.
+func (s *StarlarkSettings) load(thread *starlark.Thread, module string) (starlark.StringDict, error) {
+	if module == "env" {
+		return starlark.StringDict{"env": &envModule{}}, nil
+	}
+	
+	path := module
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(thread.Name), module)
+	}
+	path = filepath.Clean(path)
+	
+	if globals, loaded := s.modules[path]; loaded {
+		return globals, nil
+	}
+	for _, loading := range s.loading {
+		if loading == path {
+			return nil, fmt.Errorf("load cycle: %s", strings.Join(append(s.loading, path), " -> "))
 		}
-		
-		return defaultVal, nil
-	}).BindReceiver(&envModule{})
+	}
+	
+	globals, err := s.execFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s.modules[path] = globals
+	return globals, nil
 }
 
-// envModule provides Django-style environment variable access
+// setupBuiltins sets up Django-style built-in functions for Starlark
+func (s *StarlarkSettings) setupBuiltins() {
+	s.globals = starlark.StringDict{
+		"env": &envModule{},
+	}
+}
+
+// envModule provides Django-style environment variable access, both as
+// env(key, default) and through its env.get, env.int, ... methods
 type envModule struct{}
 
 func (e *envModule) String() string        { return "env" }
@@ -94,6 +143,23 @@ func (e *envModule) Type() string          { return "env" }
 func (e *envModule) Freeze()               {}
 func (e *envModule) Truth() starlark.Bool  { return starlark.True }
 func (e *envModule) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable: env") }
+func (e *envModule) Name() string          { return "env" }
+
+// CallInternal implements env(key, default), which defaults to None
+func (e *envModule) CallInternal(thread *starlark.Thread, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	var defaultVal starlark.Value = starlark.None
+	
+	if err := starlark.UnpackArgs("env", args, kwargs, "key", &key, "default?", &defaultVal); err != nil {
+		return nil, err
+	}
+	
+	if val := os.Getenv(key); val != "" {
+		return starlark.String(val), nil
+	}
+	
+	return defaultVal, nil
+}
 
 func (e *envModule) Attr(name string) (starlark.Value, error) {
 	switch name {
@@ -105,13 +171,15 @@ func (e *envModule) Attr(name string) (starlark.Value, error) {
 		return starlark.NewBuiltin("env.int", e.getInt), nil
 	case "list":
 		return starlark.NewBuiltin("env.list", e.getList), nil
+	case "dict":
+		return starlark.NewBuiltin("env.dict", e.getDict), nil
 	default:
 		return nil, nil
 	}
 }
 
 func (e *envModule) AttrNames() []string {
-	return []string{"get", "bool", "int", "list"}
+	return []string{"get", "bool", "int", "list", "dict"}
 }
 
 // env.get(key, default)
@@ -169,13 +237,20 @@ func (e *envModule) getInt(thread *starlark.Thread, fn *starlark.Builtin, args s
 	return defaultVal, nil
 }
 
-// env.list(key, separator, default)
+// env.list(key, separator, default, type)
+//
+// Items are strings unless type is "int", "float" or "bool"; if any item
+// can't be converted the default is returned.
 func (e *envModule) getList(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
 	var key string
 	var separator string = ","
 	var defaultVal *starlark.List = starlark.NewList(nil)
+	var itemType string = "str"
 	
-	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "key", &key, "separator?", &separator, "default?", &defaultVal); err != nil {
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "key", &key, "separator?", &separator, "default?", &defaultVal, "type?", &itemType); err != nil {
+		return nil, err
+	}
+	if err := checkEnvType(fn.Name(), itemType); err != nil {
 		return nil, err
 	}
 	
@@ -183,7 +258,11 @@ func (e *envModule) getList(thread *starlark.Thread, fn *starlark.Builtin, args
 		parts := strings.Split(val, separator)
 		items := make([]starlark.Value, len(parts))
 		for i, part := range parts {
-			items[i] = starlark.String(strings.TrimSpace(part))
+			item, ok := parseEnvValue(strings.TrimSpace(part), itemType)
+			if !ok {
+				return defaultVal, nil
+			}
+			items[i] = item
 		}
 		return starlark.NewList(items), nil
 	}
@@ -191,35 +270,83 @@ func (e *envModule) getList(thread *starlark.Thread, fn *starlark.Builtin, args
 	return defaultVal, nil
 }
 
-// loadBuiltin handles the load() function for importing modules
-func (s *StarlarkSettings) loadBuiltin(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
-	var module string
-	var symbols []string
+// env.dict(key, default, separator, type)
+//
+// Parses KEY1=val1,KEY2=val2 into a dict. Values are strings unless type is
+// "int", "float" or "bool"; malformed input returns the default.
+func (e *envModule) getDict(thread *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var key string
+	var defaultVal *starlark.Dict = starlark.NewDict(0)
+	var separator string = ","
+	var valueType string = "str"
 	
-	if args.Len() < 1 {
-		return nil, fmt.Errorf("load: missing module argument")
+	if err := starlark.UnpackArgs(fn.Name(), args, kwargs, "key", &key, "default?", &defaultVal, "separator?", &separator, "type?", &valueType); err != nil {
+		return nil, err
+	}
+	if err := checkEnvType(fn.Name(), valueType); err != nil {
+		return nil, err
 	}
 	
-	if moduleVal, ok := args.Index(0).(starlark.String); ok {
-		module = string(moduleVal)
-	} else {
-		return nil, fmt.Errorf("load: module must be a string")
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal, nil
 	}
 	
-	// Extract symbol names
-	for i := 1; i < args.Len(); i++ {
-		if sym, ok := args.Index(i).(starlark.String); ok {
-			symbols = append(symbols, string(sym))
+	parts := strings.Split(val, separator)
+	result := starlark.NewDict(len(parts))
+	for _, part := range parts {
+		name, raw, found := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return defaultVal, nil
+		}
+		value, ok := parseEnvValue(strings.TrimSpace(raw), valueType)
+		if !ok {
+			return defaultVal, nil
+		}
+		if err := result.SetKey(starlark.String(name), value); err != nil {
+			return nil, err
 		}
 	}
 	
-	// Handle built-in modules
-	switch module {
-	case "env":
-		// env module is already available as global
-		return starlark.None, nil
+	return result, nil
+}
+
+// checkEnvType validates the type argument of env.list and env.dict
+func checkEnvType(fnName, valueType string) error {
+	switch valueType {
+	case "str", "int", "float", "bool":
+		return nil
+	}
+	return fmt.Errorf("%s: type must be one of str, int, float or bool, got %q", fnName, valueType)
+}
+
+// parseEnvValue converts an environment value to a Starlark value of the
+// given type, reporting whether it could
+func parseEnvValue(val, valueType string) (starlark.Value, bool) {
+	switch valueType {
+	case "int":
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return nil, false
+		}
+		return starlark.MakeInt(i), true
+	case "float":
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, false
+		}
+		return starlark.Float(f), true
+	case "bool":
+		switch strings.ToLower(val) {
+		case "true", "1", "yes", "on":
+			return starlark.True, true
+		case "false", "0", "no", "off":
+			return starlark.False, true
+		}
+		return nil, false
 	default:
-		return nil, fmt.Errorf("load: unknown module %s", module)
+		return starlark.String(val), true
 	}
 }
 
@@ -416,7 +543,9 @@ func (s *StarlarkSettings) GetAll() map[string]interface{} {
 	return result
 }
 
-// Helper method to add LoadSettingsFromFile to Application
+// LoadSettingsFromFile loads settings from a file layered over the framework
+// defaults, with environment variables overriding the file. Starlark files
+// (.star, .bzl) are executed; for other files only the environment is read.
 func (app *Application) LoadSettingsFromFile(filename string) error {
 	// Check if file exists
 	if _, err := os.Stat(filename); os.IsNotExist(err) {
@@ -426,22 +555,16 @@ func (app *Application) LoadSettingsFromFile(filename string) error {
 	// Determine file type by extension
 	ext := strings.ToLower(filepath.Ext(filename))
 	
-	var settings Settings
+	settings := NewSettingsStack()
 	
 	switch ext {
 	case ".star", ".bzl":
 		// Starlark settings file
-		starlarkSettings := NewStarlarkSettings()
-		if err := starlarkSettings.LoadFromFile(filename); err != nil {
+		if err := settings.LoadFile(filename); err != nil {
 			return fmt.Errorf("failed to load Starlark settings: %w", err)
 		}
-		settings = starlarkSettings
-	default:
-		// Fall back to basic settings with environment variables
-		basicSettings := NewBasicSettings()
-		basicSettings.LoadFromEnv()
-		settings = basicSettings
 	}
+	settings.LoadEnv()
 	
 	return app.LoadSettings(settings)
-}
\ No newline at end of file
+}
@@ -0,0 +1,245 @@
+package gojango
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeStarlarkFiles writes settings files into a temp dir and returns it
+func writeStarlarkFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func TestStarlarkSettingsLoad(t *testing.T) {
+	dir := writeStarlarkFiles(t, map[string]string{
+		"settings_base.star": `
+DATABASES = {"default": {"engine": "sqlite3", "name": "base.db"}}
+INSTALLED_APPS = ["blog", "shop"]
+SECRET_KEY = "base-secret"
+_HELPER = "private"
+`,
+		"settings_dev.star": `
+load("settings_base.star", "DATABASES", "INSTALLED_APPS", base_key = "SECRET_KEY")
+
+DEBUG = True
+INSTALLED_APPS = INSTALLED_APPS + ["debug_toolbar"]
+SECRET_KEY = base_key + "-dev"
+`,
+	})
+
+	settings := NewStarlarkSettings()
+	if err := settings.LoadFromFile(filepath.Join(dir, "settings_dev.star")); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+
+	if name := settings.GetNested("DATABASES", "default", "name"); name != "base.db" {
+		t.Errorf("Expected DATABASES to be imported from the base file, got: %v", name)
+	}
+	apps := settings.GetStringSlice("INSTALLED_APPS", nil)
+	if strings.Join(apps, ",") != "blog,shop,debug_toolbar" {
+		t.Errorf("Expected extended INSTALLED_APPS, got: %v", apps)
+	}
+	if key := settings.GetString("SECRET_KEY", ""); key != "base-secret-dev" {
+		t.Errorf("Expected SECRET_KEY base-secret-dev, got: %s", key)
+	}
+	if !settings.GetBool("DEBUG", false) {
+		t.Error("Expected DEBUG from the dev file")
+	}
+	if settings.Has("_HELPER") {
+		t.Error("Expected symbols that were not loaded to stay out of the settings")
+	}
+}
+
+func TestStarlarkSettingsLoadRelativeToFile(t *testing.T) {
+	dir := writeStarlarkFiles(t, map[string]string{
+		"config/settings.star":      `load("shared/base.star", "APP_NAME")`,
+		"config/shared/base.star":   `load("../common.star", "PREFIX")` + "\nAPP_NAME = PREFIX + \"-app\"",
+		"config/common.star":        `PREFIX = "acme"`,
+		"config/shared/unused.star": `APP_NAME = "wrong"`,
+	})
+
+	settings := NewStarlarkSettings()
+	if err := settings.LoadFromFile(filepath.Join(dir, "config", "settings.star")); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if name := settings.GetString("APP_NAME", ""); name != "acme-app" {
+		t.Errorf("Expected APP_NAME acme-app, got: %s", name)
+	}
+}
+
+func TestStarlarkSettingsLoadEnv(t *testing.T) {
+	os.Setenv("GOJANGO_TEST_LOAD_ENV", "from-env")
+	defer os.Unsetenv("GOJANGO_TEST_LOAD_ENV")
+
+	settings := loadStarlarkSettings(t, `
+load("env", "env")
+
+VALUE = env.get("GOJANGO_TEST_LOAD_ENV", "default")
+`)
+	if value := settings.GetString("VALUE", ""); value != "from-env" {
+		t.Errorf("Expected VALUE from the environment, got: %s", value)
+	}
+	if settings.Has("env") {
+		t.Error("Expected the env module not to be a setting")
+	}
+}
+
+func TestStarlarkSettingsLoadErrors(t *testing.T) {
+	dir := writeStarlarkFiles(t, map[string]string{
+		"a.star":       `load("b.star", "B")` + "\nA = 1",
+		"b.star":       `load("a.star", "A")` + "\nB = 2",
+		"missing.star": `load("nowhere.star", "X")`,
+		"unknown.star": `load("common.star", "NOPE")`,
+		"common.star":  `VALUE = 1`,
+	})
+
+	tests := []struct {
+		file     string
+		contains []string
+	}{
+		{"a.star", []string{"load cycle", filepath.Join(dir, "a.star") + " -> " + filepath.Join(dir, "b.star") + " -> " + filepath.Join(dir, "a.star")}},
+		{"missing.star", []string{filepath.Join(dir, "nowhere.star")}},
+		{"unknown.star", []string{"NOPE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			err := NewStarlarkSettings().LoadFromFile(filepath.Join(dir, tt.file))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Expected error to contain %q, got: %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestEnvTypedList(t *testing.T) {
+	env := map[string]string{
+		"GOJANGO_TEST_PORTS":   "8000, 8001,8002",
+		"GOJANGO_TEST_RATIOS":  "0.5,1.25",
+		"GOJANGO_TEST_FLAGS":   "true,off,1",
+		"GOJANGO_TEST_HOSTS":   "a.example.com;b.example.com",
+		"GOJANGO_TEST_BADINTS": "1,two,3",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	settings := loadStarlarkSettings(t, `
+PORTS = env.list("GOJANGO_TEST_PORTS", type = "int")
+RATIOS = env.list("GOJANGO_TEST_RATIOS", type = "float")
+FLAGS = env.list("GOJANGO_TEST_FLAGS", type = "bool")
+HOSTS = env.list("GOJANGO_TEST_HOSTS", ";")
+BAD = env.list("GOJANGO_TEST_BADINTS", default = [0], type = "int")
+UNSET = env.list("GOJANGO_TEST_UNSET", default = [[1, 2], [3]])
+`)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"PORTS", "[8000 8001 8002]"},
+		{"RATIOS", "[0.5 1.25]"},
+		{"FLAGS", "[true false true]"},
+		{"HOSTS", "[a.example.com b.example.com]"},
+		{"BAD", "[0]"},
+		{"UNSET", "[[1 2] [3]]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%v", settings.Get(tt.key)); got != tt.expected {
+			t.Errorf("Expected %s to be %s, got: %s", tt.key, tt.expected, got)
+		}
+	}
+
+	if _, ok := settings.Get("PORTS").([]interface{})[0].(int); !ok {
+		t.Errorf("Expected int items, got: %T", settings.Get("PORTS").([]interface{})[0])
+	}
+}
+
+func TestEnvDict(t *testing.T) {
+	env := map[string]string{
+		"GOJANGO_TEST_FEATURES":  "beta=true, dark_mode = off",
+		"GOJANGO_TEST_LIMITS":    "api=100,upload=5",
+		"GOJANGO_TEST_LABELS":    "team=core,tier=gold=plus",
+		"GOJANGO_TEST_MALFORMED": "api=100,upload",
+		"GOJANGO_TEST_BADVALUE":  "api=lots",
+	}
+	for key, value := range env {
+		os.Setenv(key, value)
+		defer os.Unsetenv(key)
+	}
+
+	settings := loadStarlarkSettings(t, `
+FEATURES = env.dict("GOJANGO_TEST_FEATURES", type = "bool")
+LIMITS = env.dict("GOJANGO_TEST_LIMITS", type = "int")
+LABELS = env.dict("GOJANGO_TEST_LABELS")
+MALFORMED = env.dict("GOJANGO_TEST_MALFORMED", {"api": 1})
+BADVALUE = env.dict("GOJANGO_TEST_BADVALUE", {"api": 2}, type = "int")
+UNSET = env.dict("GOJANGO_TEST_UNSET")
+`)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"FEATURES", "map[beta:true dark_mode:false]"},
+		{"LIMITS", "map[api:100 upload:5]"},
+		{"LABELS", "map[team:core tier:gold=plus]"},
+		{"MALFORMED", "map[api:1]"},
+		{"BADVALUE", "map[api:2]"},
+		{"UNSET", "map[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf("%v", settings.Get(tt.key)); got != tt.expected {
+			t.Errorf("Expected %s to be %s, got: %s", tt.key, tt.expected, got)
+		}
+	}
+}
+
+func TestEnvInvalidType(t *testing.T) {
+	settingsFile := filepath.Join(t.TempDir(), "settings.star")
+	os.WriteFile(settingsFile, []byte(`X = env.list("GOJANGO_TEST_UNSET", type = "complex")`), 0644)
+
+	err := NewStarlarkSettings().LoadFromFile(settingsFile)
+	if err == nil || !strings.Contains(err.Error(), "type must be one of") {
+		t.Errorf("Expected an invalid type error, got: %v", err)
+	}
+}
+
+func TestEnvCallable(t *testing.T) {
+	t.Setenv("GOJANGO_TEST_CALLABLE", "value")
+
+	settings := loadStarlarkSettings(t, `
+SET = env("GOJANGO_TEST_CALLABLE")
+UNSET = env("GOJANGO_TEST_UNSET", "fallback")
+HOSTS = env.list("GOJANGO_TEST_UNSET", default = ["localhost"])
+`)
+	if value := settings.GetString("SET"); value != "value" {
+		t.Errorf("Expected env() to read the environment, got: %s", value)
+	}
+	if value := settings.GetString("UNSET"); value != "fallback" {
+		t.Errorf("Expected env() default, got: %s", value)
+	}
+	if hosts := settings.GetStringSlice("HOSTS"); len(hosts) != 1 || hosts[0] != "localhost" {
+		t.Errorf("Expected env methods without load(), got: %v", hosts)
+	}
+}
@@ -0,0 +1,194 @@
+package gojango
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/epuerta9/gojango/pkg/gojango/staticfiles"
+)
+
+// WithEmbeddedStatic serves static files from fsys, such as an embed.FS,
+// so a project can ship as a single binary. The prefix directory of fsys is
+// served at /static, and apps' static files are looked up under
+// apps/<app>/static in fsys, mirroring the project layout:
+//
+//	//go:embed static apps/*/static
+//	var assets embed.FS
+//
+//	app := gojango.New(gojango.WithEmbeddedStatic(assets, "static"))
+//
+// In debug mode, static directories that exist on disk are served from
+// disk instead, so edits show up without rebuilding.
+func WithEmbeddedStatic(fsys fs.FS, prefix string) Option {
+	return func(app *Application) {
+		app.staticFS = fsys
+		app.staticPrefix = prefix
+	}
+}
+
+// WithEmbeddedAdmin serves the React admin's build from the prefix
+// directory of fsys, which holds its index.html and assets directory. An
+// invalid prefix is reported by SetupAdmin.
+func WithEmbeddedAdmin(fsys fs.FS, prefix string) Option {
+	return func(app *Application) {
+		app.adminFS = fsys
+		app.adminPrefix = prefix
+	}
+}
+
+// setupStaticFiles serves static files at /static and each app's static
+// directory at /<app>/static. Once static files are collected, /static
+// serves the collected files, caching fingerprinted ones forever. Until
+// then it serves the project's static directory and falls back to the
+// apps' static directories, so {{ static }} paths resolve the same way
+// before and after collecting.
+func (app *Application) setupStaticFiles() error {
+	engine := app.router.GetEngine()
+	debug := app.settings.GetBool("DEBUG", app.debug)
+
+	var dirs staticDirs
+	global, err := app.staticDir(app.staticPrefix, "static", debug)
+	if err != nil {
+		return err
+	}
+	if global != nil {
+		dirs = append(dirs, global)
+	}
+
+	// Serve app-specific static files
+	for _, appName := range app.registry.GetAppNames() {
+		dir, err := app.staticDir(path.Join("apps", appName, "static"), filepath.Join("apps", appName, "static"), debug)
+		if err != nil {
+			return err
+		}
+		if dir != nil {
+			engine.StaticFS("/"+appName+"/static", staticDirs{dir})
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if app.staticManifest != nil {
+		engine.Group("/static", staticfiles.CacheHeaders(app.staticManifest)).StaticFS("/", app.collectedStatic)
+	} else {
+		engine.StaticFS("/static", dirs)
+	}
+	return nil
+}
+
+// mediaSettings returns MEDIA_ROOT, the directory uploaded files are saved
+// in (default "./media"), and MEDIA_URL, the URL they are served at
+// (default "/media/")
+func (app *Application) mediaSettings() (root, url string) {
+	return app.settings.GetString("MEDIA_ROOT", "./media"), app.settings.GetString("MEDIA_URL", "/media/")
+}
+
+// setupMediaFiles serves uploaded files from MEDIA_ROOT at MEDIA_URL. A
+// MEDIA_URL on another host, such as a CDN, is left for that host to serve.
+func (app *Application) setupMediaFiles() {
+	root, url := app.mediaSettings()
+	if !strings.HasPrefix(url, "/") || strings.HasPrefix(url, "//") {
+		return
+	}
+	app.router.GetEngine().Static(url, root)
+}
+
+// setupStaticManifest loads the manifest of collected static files, from
+// the embedded static files or else STATIC_ROOT (default "./staticfiles"),
+// and adds the static template function resolving fingerprinted paths. In
+// debug mode, or before static files are collected, paths are left as is.
+func (app *Application) setupStaticManifest(debug bool) error {
+	if !debug {
+		var collected fs.FS
+		if app.staticFS != nil {
+			sub, err := fs.Sub(app.staticFS, cleanPrefix(app.staticPrefix))
+			if err != nil {
+				return fmt.Errorf("invalid embedded static files prefix: %w", err)
+			}
+			collected = sub
+		} else {
+			collected = os.DirFS(app.settings.GetString("STATIC_ROOT", "./staticfiles"))
+		}
+
+		manifest, err := staticfiles.LoadManifest(collected)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to load static files manifest: %w", err)
+		}
+		if manifest != nil {
+			app.staticManifest = manifest
+			app.collectedStatic = staticDirs{http.FS(collected)}
+		}
+	}
+
+	app.templates.AddFuncs(staticfiles.TemplateFuncs(app.settings.GetString("STATIC_URL", "/static/"), app.staticManifest))
+	return nil
+}
+
+// staticDir returns the static directory dir of the embedded static files,
+// or diskDir on disk: from disk when nothing is embedded, the directory
+// isn't embedded, or in debug mode when diskDir exists. It returns nil when
+// neither exists.
+func (app *Application) staticDir(dir, diskDir string, debug bool) (http.FileSystem, error) {
+	onDisk := false
+	if info, err := os.Stat(diskDir); err == nil && info.IsDir() {
+		onDisk = true
+	}
+	if app.staticFS == nil || (debug && onDisk) {
+		if onDisk {
+			return http.Dir(diskDir), nil
+		}
+		return nil, nil
+	}
+
+	dir = cleanPrefix(dir)
+	info, err := fs.Stat(app.staticFS, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		if onDisk {
+			return http.Dir(diskDir), nil
+		}
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded static files %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("embedded static files %s: not a directory", dir)
+	}
+	sub, err := fs.Sub(app.staticFS, dir)
+	if err != nil {
+		return nil, err
+	}
+	return http.FS(sub), nil
+}
+
+// cleanPrefix turns an empty directory into "."
+func cleanPrefix(dir string) string {
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// staticDirs serves files from the first directory that has them. It
+// serves no directories, so directory listings are not exposed.
+type staticDirs []http.FileSystem
+
+func (dirs staticDirs) Open(name string) (http.File, error) {
+	for _, dir := range dirs {
+		file, err := dir.Open(name)
+		if err != nil {
+			continue
+		}
+		if info, err := file.Stat(); err != nil || info.IsDir() {
+			file.Close()
+			continue
+		}
+		return file, nil
+	}
+	return nil, fs.ErrNotExist
+}
@@ -0,0 +1,204 @@
+package gojango
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/epuerta9/gojango/pkg/gojango/staticfiles"
+)
+
+//go:embed testdata/embedded
+var embeddedTestFiles embed.FS
+
+// newStaticTestApp initializes an application with the blog app registered
+// and the embedded test files as its static files
+func newStaticTestApp(t *testing.T, debug bool) *Application {
+	t.Helper()
+	assets, err := fs.Sub(embeddedTestFiles, "testdata/embedded")
+	if err != nil {
+		t.Fatalf("Failed to open embedded files: %v", err)
+	}
+
+	app := New(WithEmbeddedStatic(assets, "static"), WithDebug(debug))
+	app.registry = NewRegistry()
+	app.registry.RegisterApp(&TestApp{name: "blog"})
+	if err := app.LoadSettings(newTestSettings()); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+	return app
+}
+
+// getBody requests path and returns the status and body
+func getBody(app *Application, path string) (int, string) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", path, nil)
+	app.GetRouter().ServeHTTP(w, req)
+	return w.Code, w.Body.String()
+}
+
+func TestEmbeddedStaticFiles(t *testing.T) {
+	t.Chdir(t.TempDir())
+	app := newStaticTestApp(t, false)
+
+	tests := map[string]string{
+		"/static/css/site.css": "body { color: navy; }\n",
+		"/blog/static/blog.js": "console.log(\"blog\");\n",
+	}
+	for path, expected := range tests {
+		code, body := getBody(app, path)
+		if code != http.StatusOK || body != expected {
+			t.Errorf("Expected %s to serve %q, got %d: %q", path, expected, code, body)
+		}
+	}
+
+	if code, _ := getBody(app, "/static/missing.css"); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing file, got: %d", code)
+	}
+}
+
+func TestEmbeddedStaticFilesDebugUsesDisk(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "static", "css"), 0755); err != nil {
+		t.Fatalf("Failed to create static dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "static", "css", "site.css"), []byte("edited"), 0644); err != nil {
+		t.Fatalf("Failed to write static file: %v", err)
+	}
+
+	// In release mode the embedded copy wins
+	if _, body := getBody(newStaticTestApp(t, false), "/static/css/site.css"); body != "body { color: navy; }\n" {
+		t.Errorf("Expected the embedded file in release mode, got: %q", body)
+	}
+
+	// In debug mode the file on disk is served, and directories missing
+	// from disk still come from the embedded files
+	app := newStaticTestApp(t, true)
+	if _, body := getBody(app, "/static/css/site.css"); body != "edited" {
+		t.Errorf("Expected the file on disk in debug mode, got: %q", body)
+	}
+	if code, _ := getBody(app, "/blog/static/blog.js"); code != http.StatusOK {
+		t.Errorf("Expected the embedded app file in debug mode, got: %d", code)
+	}
+}
+
+func TestCollectedStaticFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	files := map[string]string{
+		"static/css/app.css":       "body {}",
+		"templates/page.html":      `{{ static "css/app.css" }}`,
+		"apps/blog/static/blog.js": "blog()",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	sources, err := staticfiles.ProjectSources(".")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	manifest, err := staticfiles.Collect("staticfiles", sources...)
+	if err != nil {
+		t.Fatalf("Failed to collect static files: %v", err)
+	}
+	hashed := manifest.Paths["css/app.css"]
+
+	newApp := func(debug bool) *Application {
+		app := New(WithDebug(debug))
+		app.registry = NewRegistry()
+		app.registry.RegisterApp(&TestApp{name: "blog"})
+		if err := app.LoadSettings(newTestSettings()); err != nil {
+			t.Fatalf("Failed to load settings: %v", err)
+		}
+		if err := app.Initialize(context.Background()); err != nil {
+			t.Fatalf("Application initialization failed: %v", err)
+		}
+		return app
+	}
+
+	// In release mode paths are fingerprinted and cached forever
+	app := newApp(false)
+	html, err := app.GetTemplates().Render("page.html", nil)
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+	if html != "/static/"+hashed {
+		t.Errorf("Expected the fingerprinted URL, got: %s", html)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", html, nil)
+	app.GetRouter().ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != "body {}" {
+		t.Errorf("Expected %s to serve the file, got %d: %q", html, w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Cache-Control"); got != staticfiles.CacheControl {
+		t.Errorf("Expected immutable caching, got: %q", got)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/static/blog.js", nil)
+	app.GetRouter().ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Cache-Control") != "" {
+		t.Errorf("Expected the unhashed app file without caching, got %d: %q", w.Code, w.Header().Get("Cache-Control"))
+	}
+
+	// In debug mode paths are left as is
+	app = newApp(true)
+	html, err = app.GetTemplates().Render("page.html", nil)
+	if err != nil {
+		t.Fatalf("Failed to render template: %v", err)
+	}
+	if html != "/static/css/app.css" {
+		t.Errorf("Expected the plain URL in debug mode, got: %s", html)
+	}
+	if _, body := getBody(app, "/static/blog.js"); !strings.Contains(body, "blog()") {
+		t.Errorf("Expected app files under /static in debug mode, got: %q", body)
+	}
+}
+
+func TestMediaFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "uploads", "blog", "post"), 0755); err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "uploads", "blog", "post", "cover.txt"), []byte("cover"), 0644); err != nil {
+		t.Fatalf("Failed to write upload: %v", err)
+	}
+
+	settings := newTestSettings()
+	settings.Set("MEDIA_ROOT", "uploads")
+	settings.Set("MEDIA_URL", "/files/")
+	app := New()
+	app.registry = NewRegistry()
+	if err := app.LoadSettings(settings); err != nil {
+		t.Fatalf("Failed to load settings: %v", err)
+	}
+	if err := app.Initialize(context.Background()); err != nil {
+		t.Fatalf("Application initialization failed: %v", err)
+	}
+
+	if code, body := getBody(app, "/files/blog/post/cover.txt"); code != http.StatusOK || body != "cover" {
+		t.Errorf("Expected the upload under MEDIA_URL, got %d: %q", code, body)
+	}
+	if code, _ := getBody(app, "/files/missing.txt"); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing upload, got: %d", code)
+	}
+}
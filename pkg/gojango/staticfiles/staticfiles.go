@@ -0,0 +1,239 @@
+// Package staticfiles fingerprints static files for cache busting.
+//
+// Collect copies static files to a root directory, such as STATIC_ROOT,
+// next to copies named after a hash of their content (css/app.css becomes
+// css/app.3f2a9c1b7e4d.css), and writes a manifest mapping each file to its
+// hashed name. The static template function resolves paths through the
+// manifest, so a changed file gets a new URL and hashed files can be cached
+// forever.
+package staticfiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ManifestName is the name of the manifest Collect writes to the root
+const ManifestName = "staticfiles.json"
+
+// hashLength is the number of hex digits of the content hash in file names
+const hashLength = 12
+
+// CacheControl is the Cache-Control header sent for fingerprinted files
+const CacheControl = "public, max-age=31536000, immutable"
+
+// Source is a directory of static files to collect
+type Source struct {
+	// Dir is the directory on disk
+	Dir string
+
+	// Prefix is the directory the files are collected under, e.g. an app's
+	// name, or "" for the root
+	Prefix string
+}
+
+// ProjectSources returns the static directories of a project: its static
+// directory, then each app's apps/<app>/static in name order. Like the
+// project's, apps' files are collected at the root, so apps should keep
+// them in a directory named after the app, e.g. apps/blog/static/blog/.
+func ProjectSources(projectDir string) ([]Source, error) {
+	var sources []Source
+	if isDir(filepath.Join(projectDir, "static")) {
+		sources = append(sources, Source{Dir: filepath.Join(projectDir, "static")})
+	}
+
+	appDirs, err := filepath.Glob(filepath.Join(projectDir, "apps", "*", "static"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(appDirs)
+	for _, dir := range appDirs {
+		if isDir(dir) {
+			sources = append(sources, Source{Dir: dir})
+		}
+	}
+	return sources, nil
+}
+
+// Manifest maps static file paths, relative to the root, to their
+// fingerprinted paths
+type Manifest struct {
+	Version int               `json:"version"`
+	Paths   map[string]string `json:"paths"`
+
+	hashed map[string]bool
+}
+
+// Lookup returns the fingerprinted path of a static file
+func (m *Manifest) Lookup(name string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	hashed, ok := m.Paths[strings.TrimPrefix(name, "/")]
+	return hashed, ok
+}
+
+// IsHashed reports whether name is a fingerprinted path in the manifest
+func (m *Manifest) IsHashed(name string) bool {
+	if m == nil {
+		return false
+	}
+	name = strings.TrimPrefix(name, "/")
+	if m.hashed != nil {
+		return m.hashed[name]
+	}
+	for _, hashed := range m.Paths {
+		if hashed == name {
+			return true
+		}
+	}
+	return false
+}
+
+// index builds the set of fingerprinted paths used by IsHashed
+func (m *Manifest) index() {
+	m.hashed = make(map[string]bool, len(m.Paths))
+	for _, hashed := range m.Paths {
+		m.hashed[hashed] = true
+	}
+}
+
+// Collect copies the files of each source to root, both under their own
+// name and under their fingerprinted name, and writes the manifest to
+// root/staticfiles.json. When several sources have a file with the same
+// path, the first one wins.
+func Collect(root string, sources ...Source) (*Manifest, error) {
+	manifest := &Manifest{Version: 1, Paths: make(map[string]string)}
+
+	for _, source := range sources {
+		err := filepath.WalkDir(source.Dir, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(source.Dir, file)
+			if err != nil {
+				return err
+			}
+			name := path.Join(source.Prefix, filepath.ToSlash(rel))
+			if _, exists := manifest.Paths[name]; exists {
+				return nil
+			}
+
+			content, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			hashed := HashedName(name, content)
+			for _, target := range []string{name, hashed} {
+				if err := writeFile(filepath.Join(root, filepath.FromSlash(target)), content); err != nil {
+					return err
+				}
+			}
+			manifest.Paths[name] = hashed
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", source.Dir, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(filepath.Join(root, ManifestName), append(data, '\n')); err != nil {
+		return nil, err
+	}
+	manifest.index()
+	return manifest, nil
+}
+
+// LoadManifest reads the manifest from the root of fsys. It returns an
+// error wrapping fs.ErrNotExist when static files were not collected.
+func LoadManifest(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestName)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestName, err)
+	}
+	if manifest.Paths == nil {
+		manifest.Paths = make(map[string]string)
+	}
+	manifest.index()
+	return &manifest, nil
+}
+
+// HashedName inserts a hash of content before the extension of name
+func HashedName(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:hashLength]
+
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// URL returns the URL of a static file under baseURL, e.g. "/static/" or
+// a CDN, using its fingerprinted path when the manifest has one
+func URL(baseURL string, manifest *Manifest, name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if hashed, ok := manifest.Lookup(name); ok {
+		name = hashed
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + name
+}
+
+// TemplateFuncs returns the static template function, which resolves a
+// path such as "css/app.css" to its URL under baseURL. With a nil manifest,
+// e.g. in debug mode, it returns the plain path.
+func TemplateFuncs(baseURL string, manifest *Manifest) template.FuncMap {
+	return template.FuncMap{
+		"static": func(name string) string {
+			return URL(baseURL, manifest, name)
+		},
+	}
+}
+
+// CacheHeaders marks fingerprinted files as cacheable forever. It must run
+// on a static file route with a *filepath parameter, such as the one
+// gin's Static registers.
+func CacheHeaders(manifest *Manifest) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if manifest.IsHashed(c.Param("filepath")) {
+			c.Header("Cache-Control", CacheControl)
+		}
+		c.Next()
+	}
+}
+
+// writeFile writes a file, creating its directory
+func writeFile(name string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(name, content, 0644)
+}
+
+// isDir reports whether name is an existing directory
+func isDir(name string) bool {
+	info, err := os.Stat(name)
+	return err == nil && info.IsDir()
+}
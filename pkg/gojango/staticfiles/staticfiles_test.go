@@ -0,0 +1,154 @@
+package staticfiles
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// writeFiles writes files relative to dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", filepath.Dir(path), err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	project := t.TempDir()
+	writeFiles(t, project, map[string]string{
+		"static/css/app.css":           "body {}",
+		"static/robots.txt":            "User-agent: *",
+		"apps/blog/static/blog/app.js": "blog()",
+		"apps/blog/static/robots.txt":  "shadowed",
+	})
+
+	sources, err := ProjectSources(project)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sources) != 2 {
+		t.Fatalf("Expected the project and blog static dirs, got: %+v", sources)
+	}
+
+	root := filepath.Join(t.TempDir(), "staticfiles")
+	manifest, err := Collect(root, sources...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cssHash := HashedName("css/app.css", []byte("body {}"))
+	expected := map[string]string{
+		"css/app.css": cssHash,
+		"robots.txt":  HashedName("robots.txt", []byte("User-agent: *")),
+		"blog/app.js": HashedName("blog/app.js", []byte("blog()")),
+	}
+	if len(manifest.Paths) != len(expected) {
+		t.Errorf("Expected %d paths, got: %v", len(expected), manifest.Paths)
+	}
+	for name, hashed := range expected {
+		if manifest.Paths[name] != hashed {
+			t.Errorf("Expected %s to map to %s, got: %s", name, hashed, manifest.Paths[name])
+		}
+	}
+	if !strings.HasPrefix(cssHash, "css/app.") || !strings.HasSuffix(cssHash, ".css") || len(cssHash) != len("css/app..css")+hashLength {
+		t.Errorf("Unexpected hashed name: %s", cssHash)
+	}
+
+	// Both names are written, and the project's files win over apps'
+	for _, name := range []string{"css/app.css", cssHash} {
+		if content, err := os.ReadFile(filepath.Join(root, name)); err != nil || string(content) != "body {}" {
+			t.Errorf("Expected %s to be collected, got %q: %v", name, content, err)
+		}
+	}
+	if content, _ := os.ReadFile(filepath.Join(root, "robots.txt")); string(content) != "User-agent: *" {
+		t.Errorf("Expected the project's robots.txt, got: %q", content)
+	}
+
+	loaded, err := LoadManifest(os.DirFS(root))
+	if err != nil {
+		t.Fatalf("Failed to load manifest: %v", err)
+	}
+	if loaded.Paths["css/app.css"] != cssHash || !loaded.IsHashed(cssHash) || loaded.IsHashed("css/app.css") {
+		t.Errorf("Unexpected loaded manifest: %+v", loaded.Paths)
+	}
+
+	// A changed file gets a new name
+	writeFiles(t, project, map[string]string{"static/css/app.css": "body { margin: 0 }"})
+	changed, err := Collect(root, sources...)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if changed.Paths["css/app.css"] == cssHash {
+		t.Error("Expected a changed file to get a new hashed name")
+	}
+}
+
+func TestLoadManifestNotCollected(t *testing.T) {
+	_, err := LoadManifest(os.DirFS(t.TempDir()))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected a not-exist error, got: %v", err)
+	}
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{ManifestName: "{"})
+	if _, err := LoadManifest(os.DirFS(dir)); err == nil || errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected an invalid manifest error, got: %v", err)
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	manifest := &Manifest{Paths: map[string]string{"css/app.css": "css/app.0123456789ab.css"}}
+
+	tests := []struct {
+		baseURL  string
+		manifest *Manifest
+		path     string
+		expected string
+	}{
+		{"/static/", manifest, "css/app.css", "/static/css/app.0123456789ab.css"},
+		{"/static/", manifest, "/css/app.css", "/static/css/app.0123456789ab.css"},
+		{"https://cdn.example.com/assets", manifest, "css/app.css", "https://cdn.example.com/assets/css/app.0123456789ab.css"},
+		{"/static/", manifest, "js/missing.js", "/static/js/missing.js"},
+		{"/static/", nil, "css/app.css", "/static/css/app.css"},
+	}
+
+	for _, tt := range tests {
+		static := TemplateFuncs(tt.baseURL, tt.manifest)["static"].(func(string) string)
+		if url := static(tt.path); url != tt.expected {
+			t.Errorf("Expected %s, got: %s", tt.expected, url)
+		}
+	}
+}
+
+func TestCacheHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	manifest := &Manifest{Paths: map[string]string{"app.css": "app.0123456789ab.css"}}
+	router := gin.New()
+	router.GET("/static/*filepath", CacheHeaders(manifest), func(c *gin.Context) { c.String(200, "") })
+
+	for path, expected := range map[string]string{
+		"/static/app.0123456789ab.css": CacheControl,
+		"/static/app.css":              "",
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(w, req)
+		if got := w.Header().Get("Cache-Control"); got != expected {
+			t.Errorf("Expected %s Cache-Control %q, got: %q", path, expected, got)
+		}
+	}
+}
@@ -1,33 +1,156 @@
 package templates
 
 import (
+	"context"
 	"fmt"
 	"html/template"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"text/template/parse"
+	"time"
+
+	"github.com/a-h/templ"
 )
 
 // Engine manages template discovery and rendering
 type Engine struct {
-	templates map[string]*template.Template
-	funcMap   template.FuncMap
+	mu         sync.RWMutex
+	templates  map[string]*template.Template
+	funcMap    template.FuncMap
+	sources    map[string]templateSource
+	components map[string]ComponentFunc
+	autoReload bool
+	
+	// contextFuncs build template functions bound to a render's context
+	contextFuncs []ContextFuncMap
+	
+	// bare maps app template names without their app prefix, such as
+	// "index.html", to the namespaced names, such as "blog/index.html"
+	bare map[string][]string
+	
+	// content holds the source of each template, for composing pages with
+	// layouts; layouts caches the composed templates
+	content map[string]string
+	layouts map[string]*template.Template
+}
+
+// ContextFuncMap builds template functions bound to the context a template
+// is rendered with, such as translations into the request locale
+type ContextFuncMap func(ctx context.Context) template.FuncMap
+
+// ComponentFunc builds a Templ component from the data passed to Render
+type ComponentFunc func(data interface{}) templ.Component
+
+// templateSource records the file a template was loaded from, so it can be
+// reloaded when the file changes
+type templateSource struct {
+	path    string
+	modTime time.Time
+}
+
+// TemplateError describes a template that failed to load
+type TemplateError struct {
+	Name string
+	Path string
+	Err  error
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("failed to parse template %s: %v", e.Path, e.Err)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// LoadErrors collects every template that failed to load, so all broken
+// templates are reported at once instead of only the first
+type LoadErrors []*TemplateError
+
+func (e LoadErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = "  " + err.Error()
+	}
+	return fmt.Sprintf("%d templates failed to load:\n%s", len(e), strings.Join(messages, "\n"))
+}
+
+// err returns the collected errors, or nil when there are none
+func (e LoadErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
 }
 
 // NewEngine creates a new template engine
 func NewEngine() *Engine {
 	return &Engine{
-		templates: make(map[string]*template.Template),
-		funcMap:   make(template.FuncMap),
+		templates:  make(map[string]*template.Template),
+		funcMap:    make(template.FuncMap),
+		sources:    make(map[string]templateSource),
+		components: make(map[string]ComponentFunc),
+		bare:       make(map[string][]string),
+		content:    make(map[string]string),
+		layouts:    make(map[string]*template.Template),
 	}
 }
 
+// SetAutoReload enables re-parsing a template on Render when its file has
+// been modified since it was loaded. It is meant for development: each
+// render costs a stat of the template file. Templates loaded from an
+// embedded filesystem are never reloaded.
+func (e *Engine) SetAutoReload(enabled bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.autoReload = enabled
+}
+
+// AutoReload reports whether auto-reload is enabled
+func (e *Engine) AutoReload() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.autoReload
+}
+
+// RegisterComponent registers a Templ component under name, so it can be
+// rendered by name like a template. Components take precedence over
+// html/template templates with the same name.
+func (e *Engine) RegisterComponent(name string, fn ComponentFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.components[name] = fn
+}
+
 // AddFuncs adds template functions
 func (e *Engine) AddFuncs(funcs template.FuncMap) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	for name, fn := range funcs {
 		e.funcMap[name] = fn
 	}
+	e.layouts = make(map[string]*template.Template)
+}
+
+// AddContextFuncs adds template functions bound to each render's context.
+// Templates are parsed with the functions built for a background context
+// and rendered with those built for the context passed to RenderContext.
+func (e *Engine) AddContextFuncs(funcs ContextFuncMap) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for name, fn := range funcs(context.Background()) {
+		e.funcMap[name] = fn
+	}
+	e.contextFuncs = append(e.contextFuncs, funcs)
+	e.layouts = make(map[string]*template.Template)
 }
 
 // LoadAppTemplates loads templates for a specific app
@@ -37,7 +160,8 @@ func (e *Engine) LoadAppTemplates(appName string, templateDir string) error {
 		return nil
 	}
 	
-	return filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
+	var loadErrs LoadErrors
+	err := filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -54,24 +178,19 @@ func (e *Engine) LoadAppTemplates(appName string, templateDir string) error {
 		}
 		
 		// Template name format: app/template.html
-		templateName := fmt.Sprintf("%s/%s", appName, relPath)
-		
-		// Read template content
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("failed to read template %s: %w", path, err)
-		}
+		templateName := e.namespace(appName, relPath)
 		
-		// Parse template content with our custom name
-		tmpl, err := template.New(templateName).Funcs(e.funcMap).Parse(string(content))
-		if err != nil {
-			return fmt.Errorf("failed to parse template %s: %w", path, err)
+		if err := e.loadFile(templateName, path); err != nil {
+			loadErrs = append(loadErrs, &TemplateError{Name: templateName, Path: path, Err: err})
 		}
 		
-		e.templates[templateName] = tmpl
-		
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	
+	return loadErrs.err()
 }
 
 // LoadGlobalTemplates loads global templates from the templates directory
@@ -81,7 +200,8 @@ func (e *Engine) LoadGlobalTemplates(templateDir string) error {
 		return nil
 	}
 	
-	return filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
+	var loadErrs LoadErrors
+	err := filepath.Walk(templateDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -100,22 +220,23 @@ func (e *Engine) LoadGlobalTemplates(templateDir string) error {
 		// Template name is just the relative path
 		templateName := relPath
 		
-		// Parse template
-		tmpl := template.New(templateName).Funcs(e.funcMap)
-		tmpl, err = tmpl.ParseFiles(path)
-		if err != nil {
-			return fmt.Errorf("failed to parse template %s: %w", path, err)
+		if err := e.loadFile(templateName, path); err != nil {
+			loadErrs = append(loadErrs, &TemplateError{Name: templateName, Path: path, Err: err})
 		}
 		
-		e.templates[templateName] = tmpl
-		
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	
+	return loadErrs.err()
 }
 
 // LoadEmbeddedTemplates loads templates from an embedded filesystem
 func (e *Engine) LoadEmbeddedTemplates(appName string, embedFS fs.FS, root string) error {
-	return fs.WalkDir(embedFS, root, func(path string, d fs.DirEntry, err error) error {
+	var loadErrs LoadErrors
+	err := fs.WalkDir(embedFS, root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -137,48 +258,364 @@ func (e *Engine) LoadEmbeddedTemplates(appName string, embedFS fs.FS, root strin
 			return err
 		}
 		
-		templateName := fmt.Sprintf("%s/%s", appName, relPath)
+		templateName := e.namespace(appName, relPath)
 		
 		// Parse template
-		tmpl := template.New(templateName).Funcs(e.funcMap)
-		tmpl, err = tmpl.Parse(string(content))
-		if err != nil {
-			return fmt.Errorf("failed to parse template %s: %w", path, err)
+		if err := e.parse(templateName, string(content)); err != nil {
+			loadErrs = append(loadErrs, &TemplateError{Name: templateName, Path: path, Err: err})
 		}
 		
-		e.templates[templateName] = tmpl
-		
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	
+	return loadErrs.err()
+}
+
+// namespace returns the name of an app's template, app/template.html, and
+// records it so the template can also be looked up by its bare name
+func (e *Engine) namespace(appName, relPath string) string {
+	bareName := filepath.ToSlash(relPath)
+	name := appName + "/" + bareName
+	
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, existing := range e.bare[bareName] {
+		if existing == name {
+			return name
+		}
+	}
+	e.bare[bareName] = append(e.bare[bareName], name)
+	return name
+}
+
+// Resolve returns the name a template or component is registered under.
+// Names are looked up as is first, so global templates and namespaced app
+// templates such as "blog/index.html" resolve to themselves. A bare name
+// such as "index.html" that is not a global template resolves to the app
+// template with that name, and is an error when several apps have one.
+func (e *Engine) Resolve(templateName string) (string, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if _, exists := e.components[templateName]; exists {
+		return templateName, nil
+	}
+	if _, exists := e.templates[templateName]; exists {
+		return templateName, nil
+	}
+	
+	var matches []string
+	for _, name := range e.bare[templateName] {
+		if _, exists := e.templates[name]; exists {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("template '%s' not found", templateName)
+	case 1:
+		return matches[0], nil
+	}
+	sort.Strings(matches)
+	return "", fmt.Errorf("template '%s' is ambiguous, use one of: %s", templateName, strings.Join(matches, ", "))
+}
+
+// loadFile reads and parses a template file, remembering its path and
+// modification time for auto-reload
+func (e *Engine) loadFile(name, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := e.parse(name, string(content)); err != nil {
+		return err
+	}
+	
+	e.mu.Lock()
+	e.sources[name] = templateSource{path: path, modTime: info.ModTime()}
+	e.mu.Unlock()
+	return nil
+}
+
+// parse parses template content and registers it under name
+func (e *Engine) parse(name, content string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	
+	tmpl, err := template.New(name).Funcs(e.funcMap).Parse(content)
+	if err != nil {
+		return err
+	}
+	
+	e.templates[name] = tmpl
+	e.content[name] = content
+	e.layouts = make(map[string]*template.Template)
+	return nil
+}
+
+// reloadIfModified re-parses a file-backed template whose file changed
+// since it was loaded. If the file can no longer be read the cached
+// template keeps being used; a parse error is returned so it shows up on
+// the page being developed.
+func (e *Engine) reloadIfModified(name string) error {
+	e.mu.RLock()
+	source, ok := e.sources[name]
+	e.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	
+	info, err := os.Stat(source.path)
+	if err != nil || info.ModTime().Equal(source.modTime) {
+		return nil
+	}
+	
+	if err := e.loadFile(name, source.path); err != nil {
+		return &TemplateError{Name: name, Path: source.path, Err: err}
+	}
+	return nil
 }
 
 // Render renders a template with the given data
 func (e *Engine) Render(templateName string, data interface{}) (string, error) {
+	return e.RenderContext(context.Background(), templateName, data)
+}
+
+// RenderContext renders a template with the given data, binding context
+// template functions and Templ components to ctx. Handlers pass the request
+// context so templates can use request-scoped values such as the locale.
+// The template is looked up with Resolve.
+func (e *Engine) RenderContext(ctx context.Context, templateName string, data interface{}) (string, error) {
+	templateName, err := e.Resolve(templateName)
+	if err != nil {
+		return "", err
+	}
+	
+	e.mu.RLock()
+	component, isComponent := e.components[templateName]
+	e.mu.RUnlock()
+	if isComponent {
+		return renderComponent(ctx, templateName, component, data)
+	}
+	
+	if e.AutoReload() {
+		if err := e.reloadIfModified(templateName); err != nil {
+			return "", err
+		}
+	}
+	
+	e.mu.RLock()
 	tmpl, exists := e.templates[templateName]
+	e.mu.RUnlock()
 	if !exists {
 		return "", fmt.Errorf("template '%s' not found", templateName)
 	}
 	
-	var buf strings.Builder
-	err := tmpl.Execute(&buf, data)
+	html, err := e.execute(ctx, tmpl, data)
 	if err != nil {
 		return "", fmt.Errorf("failed to render template '%s': %w", templateName, err)
 	}
+	return html, nil
+}
+
+// RenderWithLayout renders a page inside a layout. The layout is a template
+// that marks where pages go with {{ template "name" . }}, or with
+// {{ block "name" . }}default{{ end }} for optional parts, and the page
+// fills them with {{ define "name" }}...{{ end }}:
+//
+//	<!-- base.html -->
+//	<html><body>{{ template "content" . }}</body></html>
+//
+//	<!-- blog/index.html -->
+//	{{ define "content" }}<h1>{{ .Title }}</h1>{{ end }}
+//
+// Both templates are looked up with Resolve. Rendering fails when the
+// layout uses a block that nothing defines, or the page defines a block
+// that the layout never uses, instead of rendering the page blank.
+func (e *Engine) RenderWithLayout(layout, page string, data interface{}) (string, error) {
+	return e.RenderWithLayoutContext(context.Background(), layout, page, data)
+}
+
+// RenderWithLayoutContext renders a page inside a layout like
+// RenderWithLayout, binding context template functions to ctx
+func (e *Engine) RenderWithLayoutContext(ctx context.Context, layout, page string, data interface{}) (string, error) {
+	layout, err := e.Resolve(layout)
+	if err != nil {
+		return "", fmt.Errorf("layout: %w", err)
+	}
+	page, err = e.Resolve(page)
+	if err != nil {
+		return "", err
+	}
+	
+	if e.AutoReload() {
+		for _, name := range []string{layout, page} {
+			if err := e.reloadIfModified(name); err != nil {
+				return "", err
+			}
+		}
+	}
+	
+	tmpl, err := e.compose(layout, page)
+	if err != nil {
+		return "", err
+	}
+	
+	html, err := e.execute(ctx, tmpl, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to render template '%s' in layout '%s': %w", page, layout, err)
+	}
+	return html, nil
+}
+
+// compose parses a layout and the blocks a page defines into one template,
+// caching the result until templates or functions change
+func (e *Engine) compose(layout, page string) (*template.Template, error) {
+	key := layout + "\x00" + page
+	
+	e.mu.RLock()
+	tmpl, cached := e.layouts[key]
+	layoutContent, layoutExists := e.content[layout]
+	pageContent, pageExists := e.content[page]
+	e.mu.RUnlock()
+	if cached {
+		return tmpl, nil
+	}
+	if !layoutExists {
+		return nil, fmt.Errorf("layout '%s' is not a template", layout)
+	}
+	if !pageExists {
+		return nil, fmt.Errorf("template '%s' cannot be rendered in a layout", page)
+	}
+	
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	
+	// Parse the page on its own first to find the blocks it defines
+	pageTmpl, err := template.New(page).Funcs(e.funcMap).Parse(pageContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s': %w", page, err)
+	}
+	
+	tmpl, err = template.New(layout).Funcs(e.funcMap).Parse(layoutContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse layout '%s': %w", layout, err)
+	}
+	if _, err := tmpl.New(page).Parse(pageContent); err != nil {
+		return nil, fmt.Errorf("failed to parse template '%s': %w", page, err)
+	}
+	
+	used := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			templateRefs(t.Tree.Root, used)
+		}
+	}
+	for name := range used {
+		if tmpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("block '%s' is used but defined by neither layout '%s' nor template '%s'", name, layout, page)
+		}
+	}
+	for _, t := range pageTmpl.Templates() {
+		if name := t.Name(); name != page && !used[name] {
+			return nil, fmt.Errorf("template '%s' defines block '%s', which layout '%s' does not use", page, name, layout)
+		}
+	}
+	
+	e.layouts[key] = tmpl
+	return tmpl, nil
+}
+
+// templateRefs adds the names of the templates node invokes to refs
+func templateRefs(node parse.Node, refs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			templateRefs(child, refs)
+		}
+	case *parse.TemplateNode:
+		refs[n.Name] = true
+	case *parse.IfNode:
+		templateRefs(n.List, refs)
+		templateRefs(n.ElseList, refs)
+	case *parse.RangeNode:
+		templateRefs(n.List, refs)
+		templateRefs(n.ElseList, refs)
+	case *parse.WithNode:
+		templateRefs(n.List, refs)
+		templateRefs(n.ElseList, refs)
+	}
+}
+
+// execute executes a parsed template with the context functions bound to
+// ctx. Context functions are bound on a copy; the parsed template is never
+// executed itself in that case, since executed templates can't be cloned.
+func (e *Engine) execute(ctx context.Context, tmpl *template.Template, data interface{}) (string, error) {
+	e.mu.RLock()
+	contextFuncs := e.contextFuncs
+	e.mu.RUnlock()
+	
+	if len(contextFuncs) > 0 {
+		clone, err := tmpl.Clone()
+		if err != nil {
+			return "", err
+		}
+		for _, funcs := range contextFuncs {
+			clone.Funcs(funcs(ctx))
+		}
+		tmpl = clone
+	}
+	
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderComponent renders a Templ component built from data
+func renderComponent(ctx context.Context, name string, fn ComponentFunc, data interface{}) (string, error) {
+	component := fn(data)
+	if component == nil {
+		return "", fmt.Errorf("component '%s' returned nil", name)
+	}
+	
+	var buf strings.Builder
+	if err := component.Render(ctx, &buf); err != nil {
+		return "", fmt.Errorf("failed to render component '%s': %w", name, err)
+	}
 	
 	return buf.String(), nil
 }
 
-// Has checks if a template exists
+// Has checks if a template or component exists, looking it up with Resolve.
+// An ambiguous bare name doesn't count as existing.
 func (e *Engine) Has(templateName string) bool {
-	_, exists := e.templates[templateName]
-	return exists
+	_, err := e.Resolve(templateName)
+	return err == nil
 }
 
-// List returns all available template names
+// List returns all available template and component names
 func (e *Engine) List() []string {
-	names := make([]string, 0, len(e.templates))
-	for name := range e.templates {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	names := make([]string, 0, len(e.components)+len(e.templates))
+	for name := range e.components {
 		names = append(names, name)
 	}
+	for name := range e.templates {
+		if _, shadowed := e.components[name]; !shadowed {
+			names = append(names, name)
+		}
+	}
 	return names
 }
\ No newline at end of file
@@ -1,10 +1,17 @@
 package templates
 
 import (
+	"context"
+	"errors"
 	"html/template"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/a-h/templ"
 )
 
 func TestEngineCreation(t *testing.T) {
@@ -270,4 +277,363 @@ func TestTemplateFunctions(t *testing.T) {
 	if html != expected {
 		t.Errorf("Expected '%s', got: '%s'", expected, html)
 	}
-}
\ No newline at end of file
+}
+
+func TestLoadAppTemplatesCollectsParseErrors(t *testing.T) {
+	templateDir := t.TempDir()
+	testTemplates := map[string]string{
+		"good.html":    "<h1>{{.Title}}</h1>",
+		"broken.html":  "<h1>{{.Title</h1>",
+		"unknown.html": "{{ missingFunc . }}",
+	}
+	for name, content := range testTemplates {
+		if err := os.WriteFile(filepath.Join(templateDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template %s: %v", name, err)
+		}
+	}
+
+	engine := NewEngine()
+	err := engine.LoadAppTemplates("testapp", templateDir)
+
+	var loadErrs LoadErrors
+	if !errors.As(err, &loadErrs) {
+		t.Fatalf("Expected LoadErrors, got: %v", err)
+	}
+	if len(loadErrs) != 2 {
+		t.Fatalf("Expected 2 template errors, got %d: %v", len(loadErrs), err)
+	}
+	for _, name := range []string{"broken.html", "unknown.html"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected error to mention %s, got: %v", name, err)
+		}
+	}
+
+	// Valid templates still load alongside broken ones
+	if !engine.Has("testapp/good.html") {
+		t.Error("Expected good.html to be loaded")
+	}
+	if engine.Has("testapp/broken.html") {
+		t.Error("Broken template should not be registered")
+	}
+}
+
+// writeTemplate writes a template file and moves its mtime forward, so
+// consecutive writes are seen as modifications even on filesystems with
+// coarse timestamps
+func writeTemplate(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set template mtime: %v", err)
+	}
+}
+
+func TestAutoReload(t *testing.T) {
+	for _, autoReload := range []bool{false, true} {
+		tempDir := t.TempDir()
+		path := filepath.Join(tempDir, "page.html")
+		modTime := time.Now().Add(-time.Hour)
+		writeTemplate(t, path, "<h1>{{.}}</h1>", modTime)
+
+		engine := NewEngine()
+		engine.SetAutoReload(autoReload)
+		if err := engine.LoadAppTemplates("blog", tempDir); err != nil {
+			t.Fatalf("Failed to load templates: %v", err)
+		}
+
+		html, err := engine.Render("blog/page.html", "Hello")
+		if err != nil || html != "<h1>Hello</h1>" {
+			t.Fatalf("Unexpected first render: %q, %v", html, err)
+		}
+
+		writeTemplate(t, path, "<h2>{{.}}</h2>", modTime.Add(time.Minute))
+
+		html, err = engine.Render("blog/page.html", "Hello")
+		if err != nil {
+			t.Fatalf("Unexpected render error: %v", err)
+		}
+		expected := "<h1>Hello</h1>"
+		if autoReload {
+			expected = "<h2>Hello</h2>"
+		}
+		if html != expected {
+			t.Errorf("autoReload=%v: expected %q, got %q", autoReload, expected, html)
+		}
+	}
+}
+
+func TestAutoReloadParseError(t *testing.T) {
+	tempDir := t.TempDir()
+	path := filepath.Join(tempDir, "page.html")
+	modTime := time.Now().Add(-time.Hour)
+	writeTemplate(t, path, "<h1>{{.}}</h1>", modTime)
+
+	engine := NewEngine()
+	engine.SetAutoReload(true)
+	if err := engine.LoadGlobalTemplates(tempDir); err != nil {
+		t.Fatalf("Failed to load templates: %v", err)
+	}
+
+	// A broken edit is reported on render...
+	writeTemplate(t, path, "<h1>{{.</h1>", modTime.Add(time.Minute))
+	_, err := engine.Render("page.html", "Hello")
+	var templateErr *TemplateError
+	if !errors.As(err, &templateErr) || templateErr.Path != path {
+		t.Fatalf("Expected a TemplateError for %s, got: %v", path, err)
+	}
+
+	// ...and fixing it is picked up on the next render
+	writeTemplate(t, path, "<h1>{{.}}!</h1>", modTime.Add(2*time.Minute))
+	html, err := engine.Render("page.html", "Hello")
+	if err != nil || html != "<h1>Hello!</h1>" {
+		t.Errorf("Expected the fixed template to render, got: %q, %v", html, err)
+	}
+}
+
+// greeting is a hand-written Templ component; generated components have the
+// same shape
+func greeting(data interface{}) templ.Component {
+	return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+		_, err := io.WriteString(w, "<p>Hello, "+templ.EscapeString(data.(string))+"</p>")
+		return err
+	})
+}
+
+func TestRenderComponent(t *testing.T) {
+	engine := NewEngine()
+	if engine.Has("greeting") {
+		t.Error("Expected component 'greeting' to not exist")
+	}
+
+	engine.RegisterComponent("greeting", greeting)
+	if !engine.Has("greeting") {
+		t.Error("Expected component 'greeting' to exist")
+	}
+
+	result, err := engine.Render("greeting", "<World>")
+	if err != nil {
+		t.Fatalf("Failed to render component: %v", err)
+	}
+	if result != "<p>Hello, &lt;World&gt;</p>" {
+		t.Errorf("Unexpected component output: %q", result)
+	}
+
+	found := false
+	for _, name := range engine.List() {
+		if name == "greeting" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected List to include the component")
+	}
+}
+
+func TestComponentTakesPrecedence(t *testing.T) {
+	engine := NewEngine()
+	if err := engine.parse("page.html", "template {{.}}"); err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+	engine.RegisterComponent("page.html", greeting)
+
+	result, err := engine.Render("page.html", "there")
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if result != "<p>Hello, there</p>" {
+		t.Errorf("Expected the component to be rendered, got: %q", result)
+	}
+	if len(engine.List()) != 1 {
+		t.Errorf("Expected one name listed for a shadowed template, got: %v", engine.List())
+	}
+}
+
+func TestRenderComponentError(t *testing.T) {
+	engine := NewEngine()
+	failure := errors.New("boom")
+	engine.RegisterComponent("broken", func(data interface{}) templ.Component {
+		return templ.ComponentFunc(func(ctx context.Context, w io.Writer) error {
+			return failure
+		})
+	})
+
+	_, err := engine.Render("broken", nil)
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the component error to be wrapped, got: %v", err)
+	}
+}
+
+type userKey struct{}
+
+func TestRenderContextFuncs(t *testing.T) {
+	engine := NewEngine()
+	engine.AddContextFuncs(func(ctx context.Context) template.FuncMap {
+		return template.FuncMap{
+			"user": func() string {
+				if name, ok := ctx.Value(userKey{}).(string); ok {
+					return name
+				}
+				return "anonymous"
+			},
+		}
+	})
+	if err := engine.parse("hello.html", "Hello {{ user }}"); err != nil {
+		t.Fatalf("Failed to parse template: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), userKey{}, "ana")
+	for i := 0; i < 2; i++ {
+		result, err := engine.RenderContext(ctx, "hello.html", nil)
+		if err != nil {
+			t.Fatalf("Failed to render: %v", err)
+		}
+		if result != "Hello ana" {
+			t.Errorf("Expected the context-bound function, got: %q", result)
+		}
+	}
+
+	result, err := engine.Render("hello.html", nil)
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if result != "Hello anonymous" {
+		t.Errorf("Expected a background context for Render, got: %q", result)
+	}
+}
+
+func TestAppTemplateNamespacing(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"blog/index.html":   "blog index",
+		"shop/index.html":   "shop index",
+		"shop/product.html": "shop product",
+		"global/base.html":  "global base",
+		"blog/base.html":    "blog base",
+	}
+	for path, content := range files {
+		fullPath := filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write template %s: %v", path, err)
+		}
+	}
+
+	engine := NewEngine()
+	if err := engine.LoadGlobalTemplates(filepath.Join(dir, "global")); err != nil {
+		t.Fatalf("Failed to load global templates: %v", err)
+	}
+	for _, app := range []string{"blog", "shop"} {
+		if err := engine.LoadAppTemplates(app, filepath.Join(dir, app)); err != nil {
+			t.Fatalf("Failed to load %s templates: %v", app, err)
+		}
+	}
+
+	// Namespaced names resolve to each app's own template, and bare names
+	// to the global template or the only app template with that name
+	tests := map[string]string{
+		"blog/index.html":   "blog index",
+		"shop/index.html":   "shop index",
+		"shop/product.html": "shop product",
+		"product.html":      "shop product",
+		"blog/base.html":    "blog base",
+		"base.html":         "global base",
+	}
+	for name, expected := range tests {
+		html, err := engine.Render(name, nil)
+		if err != nil {
+			t.Errorf("Failed to render %s: %v", name, err)
+			continue
+		}
+		if html != expected {
+			t.Errorf("Expected %s to render %q, got: %q", name, expected, html)
+		}
+	}
+
+	// A bare name several apps share is ambiguous rather than silently
+	// picking one
+	_, err := engine.Render("index.html", nil)
+	if err == nil || !strings.Contains(err.Error(), "blog/index.html, shop/index.html") {
+		t.Errorf("Expected an ambiguous template error, got: %v", err)
+	}
+	if engine.Has("index.html") {
+		t.Error("Expected an ambiguous bare name not to exist")
+	}
+
+	if name, err := engine.Resolve("product.html"); err != nil || name != "shop/product.html" {
+		t.Errorf("Expected product.html to resolve to shop/product.html, got %q: %v", name, err)
+	}
+}
+
+func TestRenderWithLayout(t *testing.T) {
+	engine := NewEngine()
+	templates := map[string]string{
+		"base.html":       `<html><title>{{ block "title" . }}Site{{ end }}</title><body>{{ template "content" . }}</body></html>`,
+		"blog/index.html": `{{ define "content" }}<h1>{{ .Title }}</h1>{{ end }}`,
+		"blog/about.html": `{{ define "title" }}About{{ end }}{{ define "content" }}<p>About</p>{{ end }}`,
+		"blog/extra.html": `{{ define "content" }}<p>Extra</p>{{ end }}{{ define "sidebar" }}<aside></aside>{{ end }}`,
+		"blog/empty.html": `<p>No blocks</p>`,
+	}
+	for name, content := range templates {
+		if err := engine.parse(name, content); err != nil {
+			t.Fatalf("Failed to parse %s: %v", name, err)
+		}
+	}
+
+	html, err := engine.RenderWithLayout("base.html", "blog/index.html", map[string]string{"Title": "<Posts>"})
+	if err != nil {
+		t.Fatalf("Failed to render with layout: %v", err)
+	}
+	expected := "<html><title>Site</title><body><h1>&lt;Posts&gt;</h1></body></html>"
+	if html != expected {
+		t.Errorf("Expected %q, got: %q", expected, html)
+	}
+
+	// Blocks with defaults can be overridden, and the cached layout isn't
+	// shared between pages
+	html, err = engine.RenderWithLayout("base.html", "blog/about.html", nil)
+	if err != nil {
+		t.Fatalf("Failed to render with layout: %v", err)
+	}
+	if expected := "<html><title>About</title><body><p>About</p></body></html>"; html != expected {
+		t.Errorf("Expected %q, got: %q", expected, html)
+	}
+	if html, _ := engine.RenderWithLayout("base.html", "blog/index.html", nil); strings.Contains(html, "About") {
+		t.Errorf("Expected the index page to keep the default title, got: %q", html)
+	}
+
+	errorCases := []struct {
+		layout   string
+		page     string
+		expected string
+	}{
+		{"missing.html", "blog/index.html", "template 'missing.html' not found"},
+		{"base.html", "blog/missing.html", "template 'blog/missing.html' not found"},
+		{"base.html", "blog/empty.html", "block 'content' is used"},
+		{"base.html", "blog/extra.html", "defines block 'sidebar'"},
+	}
+	for _, tc := range errorCases {
+		_, err := engine.RenderWithLayout(tc.layout, tc.page, nil)
+		if err == nil || !strings.Contains(err.Error(), tc.expected) {
+			t.Errorf("Expected %s in %s to fail with %q, got: %v", tc.page, tc.layout, tc.expected, err)
+		}
+	}
+}
+
+func TestRenderWithLayoutReparsesChangedTemplates(t *testing.T) {
+	engine := NewEngine()
+	engine.parse("base.html", `[{{ template "content" . }}]`)
+	engine.parse("page.html", `{{ define "content" }}one{{ end }}`)
+
+	if html, err := engine.RenderWithLayout("base.html", "page.html", nil); err != nil || html != "[one]" {
+		t.Fatalf("Expected [one], got %q: %v", html, err)
+	}
+
+	engine.parse("page.html", `{{ define "content" }}two{{ end }}`)
+	if html, err := engine.RenderWithLayout("base.html", "page.html", nil); err != nil || html != "[two]" {
+		t.Errorf("Expected [two] after the page changed, got %q: %v", html, err)
+	}
+}
@@ -0,0 +1,163 @@
+package gojango
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+)
+
+// tlsOptions holds the TLS configuration used by Run
+type tlsOptions struct {
+	certFile     string
+	keyFile      string
+	minVersion   uint16
+	cipherSuites []uint16
+}
+
+// WithTLS serves HTTPS using the given certificate and key files. HTTP/2 is
+// negotiated automatically for TLS connections.
+func WithTLS(certFile, keyFile string) Option {
+	return func(app *Application) {
+		app.tls.certFile = certFile
+		app.tls.keyFile = keyFile
+	}
+}
+
+// WithTLSMinVersion sets the minimum TLS version, e.g. tls.VersionTLS13
+func WithTLSMinVersion(version uint16) Option {
+	return func(app *Application) {
+		app.tls.minVersion = version
+	}
+}
+
+// WithTLSCipherSuites restricts the cipher suites offered for TLS 1.2 and
+// below. TLS 1.3 suites are not configurable.
+func WithTLSCipherSuites(suites ...uint16) Option {
+	return func(app *Application) {
+		app.tls.cipherSuites = suites
+	}
+}
+
+// TLSEnabled reports whether the server will be started with TLS
+func (app *Application) TLSEnabled() bool {
+	return app.tls.certFile != "" && app.tls.keyFile != ""
+}
+
+// loadTLSSettings fills TLS options not set in code from TLS_CERT_FILE,
+// TLS_KEY_FILE, TLS_MIN_VERSION and TLS_CIPHER_SUITES
+func (app *Application) loadTLSSettings() error {
+	if app.settings == nil {
+		return nil
+	}
+
+	if app.tls.certFile == "" && app.tls.keyFile == "" {
+		app.tls.certFile = app.settings.GetString("TLS_CERT_FILE", "")
+		app.tls.keyFile = app.settings.GetString("TLS_KEY_FILE", "")
+	}
+	if (app.tls.certFile == "") != (app.tls.keyFile == "") {
+		return fmt.Errorf("both a TLS certificate and key file must be configured")
+	}
+
+	if app.tls.minVersion == 0 {
+		if name := app.settings.GetString("TLS_MIN_VERSION", ""); name != "" {
+			version, err := parseTLSVersion(name)
+			if err != nil {
+				return err
+			}
+			app.tls.minVersion = version
+		}
+	}
+
+	if len(app.tls.cipherSuites) == 0 {
+		names, err := stringList(app.settings.Get("TLS_CIPHER_SUITES"))
+		if err != nil {
+			return fmt.Errorf("invalid TLS_CIPHER_SUITES: %w", err)
+		}
+		for _, name := range names {
+			suite, err := parseCipherSuite(name)
+			if err != nil {
+				return err
+			}
+			app.tls.cipherSuites = append(app.tls.cipherSuites, suite)
+		}
+	}
+
+	return nil
+}
+
+// tlsConfig builds the server TLS configuration, defaulting to TLS 1.2
+func (app *Application) tlsConfig() *tls.Config {
+	minVersion := app.tls.minVersion
+	if minVersion == 0 {
+		minVersion = tls.VersionTLS12
+	}
+	return &tls.Config{
+		MinVersion:   minVersion,
+		CipherSuites: app.tls.cipherSuites,
+	}
+}
+
+// parseTLSVersion converts a version such as "1.2" or "TLS1.3" to its constant
+func parseTLSVersion(name string) (uint16, error) {
+	version := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "TLS")
+	switch strings.TrimSpace(version) {
+	case "1.0", "10":
+		return tls.VersionTLS10, nil
+	case "1.1", "11":
+		return tls.VersionTLS11, nil
+	case "1.2", "12":
+		return tls.VersionTLS12, nil
+	case "1.3", "13":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unknown TLS version: %s", name)
+	}
+}
+
+// parseCipherSuite converts a cipher suite name such as
+// "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256" to its ID
+func parseCipherSuite(name string) (uint16, error) {
+	name = strings.TrimSpace(name)
+	for _, suite := range tls.CipherSuites() {
+		if suite.Name == name {
+			return suite.ID, nil
+		}
+	}
+	for _, suite := range tls.InsecureCipherSuites() {
+		if suite.Name == name {
+			return suite.ID, fmt.Errorf("insecure TLS cipher suite: %s", name)
+		}
+	}
+	return 0, fmt.Errorf("unknown TLS cipher suite: %s", name)
+}
+
+// stringList converts a list setting to strings. A single string is split
+// on commas.
+func stringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		var items []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	case []string:
+		return v, nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %T", item)
+			}
+			items = append(items, str)
+		}
+		return items, nil
+	default:
+		return nil, fmt.Errorf("expected a list of strings, got %T", value)
+	}
+}
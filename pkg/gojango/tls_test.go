@@ -0,0 +1,205 @@
+package gojango
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSelfSignedCert writes a certificate and key for 127.0.0.1 to dir
+func writeSelfSignedCert(t *testing.T, dir string) (certFile, keyFile string, pool *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"Gojango Test"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	pool = x509.NewCertPool()
+	pool.AppendCertsFromPEM(certPEM)
+	return certFile, keyFile, pool
+}
+
+// serveTestApp starts app on an ephemeral port and returns its address and
+// a function that stops the server and returns Serve's error
+func serveTestApp(t *testing.T, app *Application) (string, func() error) {
+	t.Helper()
+
+	app.registry = &Registry{
+		apps:     make(map[string]App),
+		models:   make(map[string]ModelMeta),
+		routes:   make(map[string][]Route),
+		services: make(map[string]Service),
+	}
+	if app.settings == nil {
+		app.LoadSettings(newTestSettings())
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- app.Serve(ctx, listener)
+	}()
+
+	return listener.Addr().String(), func() error {
+		cancel()
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(10 * time.Second):
+			t.Fatal("Server did not shut down")
+			return nil
+		}
+	}
+}
+
+func TestServeTLSWithHTTP2(t *testing.T) {
+	certFile, keyFile, pool := writeSelfSignedCert(t, t.TempDir())
+	app := New(WithTLS(certFile, keyFile), WithTLSMinVersion(tls.VersionTLS12))
+
+	addr, stop := serveTestApp(t, app)
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: pool},
+			ForceAttemptHTTP2: true,
+		},
+	}
+	resp, err := client.Get("https://" + addr + "/health")
+	if err != nil {
+		t.Fatalf("HTTPS request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if resp.ProtoMajor != 2 {
+		t.Errorf("Expected HTTP/2, got %s", resp.Proto)
+	}
+
+	if err := stop(); err != nil {
+		t.Errorf("Serve returned an error: %v", err)
+	}
+}
+
+func TestServeTLSMinVersion(t *testing.T) {
+	certFile, keyFile, pool := writeSelfSignedCert(t, t.TempDir())
+	settings := newTestSettings()
+	settings.Set("TLS_CERT_FILE", certFile)
+	settings.Set("TLS_KEY_FILE", keyFile)
+	settings.Set("TLS_MIN_VERSION", "1.3")
+	app := New()
+	app.LoadSettings(settings)
+
+	addr, stop := serveTestApp(t, app)
+	defer stop()
+
+	client := &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MaxVersion: tls.VersionTLS12},
+		},
+	}
+	if resp, err := client.Get("https://" + addr + "/health"); err == nil {
+		resp.Body.Close()
+		t.Error("Expected a TLS 1.2 client to be rejected when TLS_MIN_VERSION is 1.3")
+	}
+}
+
+func TestServePlainHTTPWithoutCertificate(t *testing.T) {
+	app := New()
+	addr, stop := serveTestApp(t, app)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + addr + "/health")
+	if err != nil {
+		stop()
+		t.Fatalf("HTTP request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", resp.StatusCode)
+	}
+
+	// Serve loads the TLS settings, so they are only read once it returns
+	if err := stop(); err != nil {
+		t.Errorf("Serve returned an error: %v", err)
+	}
+	if app.TLSEnabled() {
+		t.Error("TLS should not be enabled without a certificate")
+	}
+}
+
+func TestParseTLSSettings(t *testing.T) {
+	if version, err := parseTLSVersion("TLS1.3"); err != nil || version != tls.VersionTLS13 {
+		t.Errorf("Expected TLS 1.3, got %x (%v)", version, err)
+	}
+	if _, err := parseTLSVersion("2.0"); err == nil {
+		t.Error("Expected an error for an unknown TLS version")
+	}
+
+	if suite, err := parseCipherSuite("TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256"); err != nil || suite != tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256 {
+		t.Errorf("Unexpected cipher suite %x (%v)", suite, err)
+	}
+	if _, err := parseCipherSuite("TLS_RSA_WITH_RC4_128_SHA"); err == nil {
+		t.Error("Expected an error for an insecure cipher suite")
+	}
+
+	settings := newTestSettings()
+	settings.Set("TLS_CERT_FILE", "cert.pem")
+	app := New()
+	app.LoadSettings(settings)
+	if err := app.loadTLSSettings(); err == nil {
+		t.Error("Expected an error when only a certificate file is configured")
+	}
+}